@@ -0,0 +1,78 @@
+/*
+# Load Test
+
+Walks the paginated endpoints of the synthetic mock server (or any compatible URL) and reports pages/sec and
+allocations, optionally with injected 429s to exercise retries.
+
+	go run ./cmd/loadtest -users 5000 -limit 200 -throttle 25
+	go run ./cmd/loadtest -serve :8080 -users 5000
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// cmd/loadtest/main.go
+package main
+
+import (
+	"flag"
+	"net/http/httptest"
+
+	"github.com/gemini-oss/rego/pkg/common/log"
+	"github.com/gemini-oss/rego/pkg/common/requests"
+	"github.com/gemini-oss/rego/pkg/common/server"
+	"github.com/gemini-oss/rego/pkg/synthetic"
+)
+
+func main() {
+	var (
+		users      = flag.Int("users", 1000, "number of synthetic users to serve")
+		events     = flag.Int("events", 5000, "number of synthetic events to serve")
+		limit      = flag.Int("limit", 200, "page size to request")
+		throttle   = flag.Int("throttle", 0, "answer every Nth request with 429 (0 disables)")
+		iterations = flag.Int("iterations", 1, "number of walks over each endpoint")
+		serve      = flag.String("serve", "", "only serve the mock server on this address (e.g. :8080)")
+		seed       = flag.Int64("seed", 1, "seed of the synthetic data")
+	)
+	flag.Parse()
+
+	l := log.NewLogger("{loadtest}", log.INFO)
+
+	mock := synthetic.NewMockServer(synthetic.New(*seed, "example.com").Fleet(*users, *events))
+	mock.ThrottleEvery = *throttle
+
+	if *serve != "" {
+		l.Printf("Serving the mock server on %s", *serve)
+		server.StartServer(*serve, mock.Handlers())
+		return
+	}
+
+	srv := httptest.NewServer(mock.Handler())
+	defer srv.Close()
+
+	client := requests.NewClient(nil, requests.Headers{"Content-Type": requests.JSON}, nil)
+	for i := 0; i < *iterations; i++ {
+		mock.Reset()
+
+		reports := []*synthetic.LoadReport{}
+		for _, path := range []string{synthetic.MockOktaUsers, synthetic.MockOktaLogs} {
+			report, err := synthetic.WalkLinkPages(client, srv.URL+path, *limit)
+			if err != nil {
+				l.Fatalf("Walking %s: %v", path, err)
+			}
+			reports = append(reports, report)
+		}
+		report, err := synthetic.WalkTokenPages(client, srv.URL+synthetic.MockGoogleUsers, "users", *limit)
+		if err != nil {
+			l.Fatalf("Walking %s: %v", synthetic.MockGoogleUsers, err)
+		}
+		reports = append(reports, report)
+
+		for _, r := range reports {
+			l.Println(r)
+		}
+		served, throttled := mock.Stats()
+		l.Printf("Iteration %d: %d requests served, %d throttled", i+1, served, throttled)
+	}
+}
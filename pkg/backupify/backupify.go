@@ -13,6 +13,7 @@ https://www.backupify.com/
 package backupify
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -52,6 +53,16 @@ func (c *Client) BuildURL(endpoint string, identifiers ...interface{}) string {
 	return url
 }
 
+/*
+ * WithContext returns a copy of the client whose requests end when ctx is done, e.g. to cancel a long pagination
+ */
+func (c *Client) WithContext(ctx context.Context) *Client {
+	clone := *c
+	clone.HTTP = c.HTTP.WithContext(ctx)
+	clone.Log = c.Log.WithContext(ctx)
+	return &clone
+}
+
 // UseCache() enables caching for the next method call.
 func (c *Client) UseCache() *Client {
 	c.Cache.Enabled = true
@@ -13,6 +13,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/gemini-oss/rego/pkg/common/config"
 	"github.com/gemini-oss/rego/pkg/common/crypt"
 )
 
@@ -30,6 +31,7 @@ type Cache struct {
 	maxItems        int                  // Maximum number of items in the cache
 	mutex           sync.RWMutex         // Mutex for thread safety
 	persistencePath string               // Path to the file for disk-based cache
+	snapshots       *snapshotStore       // Versioned values, see Snapshot and AsOf; loaded on first use
 	Enabled         bool                 // Defines if the cache is enabled
 }
 
@@ -55,12 +57,16 @@ func NewCache(args ...interface{}) (*Cache, error) {
 		MaxItems: 1000,
 	}
 
+	// The file name is suffixed with the profile of the client, the default one unless given
+	profile, name := config.NewProfile(), ""
 	for _, arg := range args {
 		switch v := arg.(type) {
 		case []byte:
 			opts.EncryptionKey = v
 		case string:
-			opts.PersistencePath = filepath.Join(os.TempDir(), v)
+			name = v
+		case config.Profile:
+			profile = v
 		case bool:
 			opts.InMemory = v
 		case int:
@@ -72,6 +78,9 @@ func NewCache(args ...interface{}) (*Cache, error) {
 			}
 		}
 	}
+	if name != "" {
+		opts.PersistencePath = filepath.Join(os.TempDir(), profile.FileName(name))
+	}
 
 	// Validate the encryption key
 	err := crypt.ValidPassphrase(opts.EncryptionKey)
@@ -175,6 +184,42 @@ func (c *Cache) Get(key string) ([]byte, bool) {
 	return result, true
 }
 
+// Delete removes keys from the cache, e.g. when an event reports their data changed; missing keys are ignored
+// - the cache is persisted once for all the keys, so batch the keys of a change rather than deleting them one by one
+func (c *Cache) Delete(keys ...string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	deleted := false
+	for _, key := range keys {
+		if _, exists := c.data[key]; !exists {
+			continue
+		}
+		deleted = true
+
+		delete(c.data, key)
+		for hash, k := range c.hashes {
+			if k == key {
+				delete(c.hashes, hash)
+			}
+		}
+
+		if idx, found := c.accessMap[key]; found {
+			c.accessList = append(c.accessList[:idx], c.accessList[idx+1:]...)
+			delete(c.accessMap, key)
+			for i := idx; i < len(c.accessList); i++ {
+				c.accessMap[c.accessList[i]] = i
+			}
+		}
+	}
+
+	if deleted && !c.inMemory {
+		return c.persistToDisk()
+	}
+
+	return nil
+}
+
 func (c *Cache) serializeWithGob(data interface{}) ([]byte, error) {
 	var buffer bytes.Buffer
 	gz := gzip.NewWriter(&buffer)
@@ -0,0 +1,298 @@
+// pkg/common/cache/snapshots.go
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrNoSnapshot is returned by AsOf when no snapshot of the key was taken at or before the requested time
+var ErrNoSnapshot = errors.New("no snapshot")
+
+/*
+ * Retention decides which snapshots are kept, from the most to the least granular:
+ * - every snapshot younger than KeepAll
+ * - then the last snapshot of each day, up to KeepDaily
+ * - then the last snapshot of each month (e.g. the quarter end evidence), up to KeepMonthly; older snapshots are deleted
+ */
+type Retention struct {
+	KeepAll     time.Duration // Default: 7 days
+	KeepDaily   time.Duration // Default: 90 days
+	KeepMonthly time.Duration // Default: 2 years
+}
+
+// DefaultRetention is the retention of the snapshots of a cache, unless set with SetRetention
+var DefaultRetention = Retention{
+	KeepAll:     7 * 24 * time.Hour,
+	KeepDaily:   90 * 24 * time.Hour,
+	KeepMonthly: 2 * 365 * 24 * time.Hour,
+}
+
+// snapshotStore keeps the snapshots of a cache; values are stored once per content, encrypted, however many snapshots share them
+type snapshotStore struct {
+	Entries   map[string][]snapshotEntry // Snapshots of each key, oldest first
+	Blobs     map[string]string          // Encrypted values, keyed by the SHA-256 of their content
+	loaded    bool
+	retention Retention
+	mutex     sync.Mutex
+}
+
+type snapshotEntry struct {
+	Taken time.Time
+	Hash  string
+}
+
+/*
+ * # cache.Snapshot
+ * - records the current value of a key, so it can be read back "as of" a past date with AsOf, e.g. group memberships
+ *   snapshotted daily, read back at the quarter end for audit evidence
+ * - values are marshalled to JSON; snapshots persist next to the cache file, and are pruned according to the retention
+ */
+func (c *Cache) Snapshot(key string, value interface{}) error {
+	return c.SnapshotAt(key, time.Now(), value)
+}
+
+// SnapshotAt records the value of a key at the given time, e.g. to import a past inventory
+func (c *Cache) SnapshotAt(key string, taken time.Time, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	s, err := c.snapshotStore()
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	hash := sha256Hash(data)
+	if _, exists := s.Blobs[hash]; !exists {
+		encrypted, err := c.encrypt(data)
+		if err != nil {
+			return err
+		}
+		s.Blobs[hash] = encrypted
+	}
+
+	entries := append(s.Entries[key], snapshotEntry{Taken: taken, Hash: hash})
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Taken.Before(entries[j].Taken) })
+	s.Entries[key] = entries
+
+	s.prune(time.Now())
+	return c.persistSnapshots(s)
+}
+
+/*
+ * # cache.AsOf
+ * - decodes into target the last snapshot of a key taken at or before `at`, and returns the time it was taken
+ * - returns ErrNoSnapshot when there is none, e.g. before the first snapshot or after the retention removed it
+ */
+func (c *Cache) AsOf(key string, at time.Time, target interface{}) (time.Time, error) {
+	s, err := c.snapshotStore()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	s.mutex.Lock()
+	entries := s.Entries[key]
+	i := sort.Search(len(entries), func(i int) bool { return entries[i].Taken.After(at) })
+	if i == 0 {
+		s.mutex.Unlock()
+		return time.Time{}, fmt.Errorf("%w of %s as of %s", ErrNoSnapshot, key, at.Format(time.RFC3339))
+	}
+	entry := entries[i-1]
+	encrypted := s.Blobs[entry.Hash]
+	s.mutex.Unlock()
+
+	data, err := c.decrypt(encrypted)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return time.Time{}, err
+	}
+
+	return entry.Taken, nil
+}
+
+// AsOfValue is AsOf returning the decoded value
+func AsOfValue[T any](c *Cache, key string, at time.Time) (T, time.Time, error) {
+	var value T
+	taken, err := c.AsOf(key, at, &value)
+	return value, taken, err
+}
+
+// Snapshots returns the times the snapshots of a key were taken, oldest first
+func (c *Cache) Snapshots(key string) ([]time.Time, error) {
+	s, err := c.snapshotStore()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	taken := make([]time.Time, 0, len(s.Entries[key]))
+	for _, entry := range s.Entries[key] {
+		taken = append(taken, entry.Taken)
+	}
+	return taken, nil
+}
+
+// SetRetention replaces DefaultRetention for the snapshots of the cache; zero fields keep their default
+func (c *Cache) SetRetention(retention Retention) error {
+	s, err := c.snapshotStore()
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if retention.KeepAll == 0 {
+		retention.KeepAll = DefaultRetention.KeepAll
+	}
+	if retention.KeepDaily == 0 {
+		retention.KeepDaily = DefaultRetention.KeepDaily
+	}
+	if retention.KeepMonthly == 0 {
+		retention.KeepMonthly = DefaultRetention.KeepMonthly
+	}
+	s.retention = retention
+	return nil
+}
+
+// PruneSnapshots applies the retention now, and returns the number of snapshots deleted
+func (c *Cache) PruneSnapshots() (int, error) {
+	s, err := c.snapshotStore()
+	if err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	pruned := s.prune(time.Now())
+	if pruned == 0 {
+		return 0, nil
+	}
+	return pruned, c.persistSnapshots(s)
+}
+
+// prune deletes the snapshots outside the retention, and the values no snapshot refers to anymore
+func (s *snapshotStore) prune(now time.Time) int {
+	pruned := 0
+	referenced := make(map[string]bool, len(s.Blobs))
+
+	for key, entries := range s.Entries {
+		kept := make([]snapshotEntry, 0, len(entries))
+		for i, entry := range entries {
+			age := now.Sub(entry.Taken)
+
+			var next *snapshotEntry
+			if i+1 < len(entries) {
+				next = &entries[i+1]
+			}
+
+			keep := false
+			switch {
+			case age < s.retention.KeepAll:
+				keep = true
+			case age < s.retention.KeepDaily:
+				keep = next == nil || !sameDay(entry.Taken, next.Taken)
+			case age < s.retention.KeepMonthly:
+				keep = next == nil || !sameMonth(entry.Taken, next.Taken)
+			}
+
+			if keep {
+				kept = append(kept, entry)
+				referenced[entry.Hash] = true
+			} else {
+				pruned++
+			}
+		}
+
+		if len(kept) == 0 {
+			delete(s.Entries, key)
+		} else {
+			s.Entries[key] = kept
+		}
+	}
+
+	for hash := range s.Blobs {
+		if !referenced[hash] {
+			delete(s.Blobs, hash)
+		}
+	}
+
+	return pruned
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
+
+func sameMonth(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.Month() == b.Month()
+}
+
+// snapshotStore returns the snapshots of the cache, loading them from disk on first use
+func (c *Cache) snapshotStore() (*snapshotStore, error) {
+	c.mutex.Lock()
+	if c.snapshots == nil {
+		c.snapshots = &snapshotStore{
+			Entries:   make(map[string][]snapshotEntry),
+			Blobs:     make(map[string]string),
+			retention: DefaultRetention,
+		}
+	}
+	s := c.snapshots
+	c.mutex.Unlock()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.loaded || c.inMemory || c.persistencePath == "" {
+		s.loaded = true
+		return s, nil
+	}
+
+	fileData, err := os.ReadFile(c.snapshotsPath())
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, err
+	default:
+		if err := c.deserializeWithGob(fileData, s); err != nil {
+			return nil, fmt.Errorf("reading the snapshots %s: %w", c.snapshotsPath(), err)
+		}
+	}
+
+	s.loaded = true
+	return s, nil
+}
+
+// persistSnapshots writes the snapshots next to the cache file; the caller holds the lock of the store
+func (c *Cache) persistSnapshots(s *snapshotStore) error {
+	if c.inMemory || c.persistencePath == "" {
+		return nil
+	}
+
+	fileData, err := c.serializeWithGob(s)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.snapshotsPath(), fileData, 0600)
+}
+
+func (c *Cache) snapshotsPath() string {
+	return c.persistencePath + ".snapshots"
+}
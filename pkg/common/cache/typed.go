@@ -0,0 +1,237 @@
+// pkg/common/cache/typed.go
+package cache
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Typed is an in-memory cache of values of a single type, avoiding the serialization round trip of Cache.
+// Concurrent loads of the same key are coalesced (singleflight), and entries close to expiry are refreshed in the background.
+// Like Cache, it is disabled until enabled with SetEnabled (see the UseCache method of the clients), and every caller gets
+// its own copy of a value, so one caller's edits never reach the others.
+type Typed[T any] struct {
+	calls        map[string]*call[T]       // In-flight loads
+	entries      map[string]*typedEntry[T] // Cache data
+	maxItems     int                       // Maximum number of items in the cache
+	mutex        sync.Mutex                // Mutex for thread safety
+	refreshAhead time.Duration             // Entries expiring within this window are refreshed in the background
+	ttl          time.Duration             // Lifetime of an entry
+	Enabled      bool                      // Defines if the cache is enabled; loads always go to the source when disabled (see SetEnabled)
+}
+
+type typedEntry[T any] struct {
+	value   T
+	expires time.Time
+}
+
+// call is a single in-flight load shared by every caller of the same key
+type call[T any] struct {
+	wg    sync.WaitGroup
+	value T
+	err   error
+}
+
+// NewTyped creates a typed cache whose entries live for `ttl`, refreshing them in the background during the final `refreshAhead`
+func NewTyped[T any](ttl, refreshAhead time.Duration, maxItems int) *Typed[T] {
+	if maxItems <= 0 {
+		maxItems = 1000
+	}
+
+	return &Typed[T]{
+		calls:        make(map[string]*call[T]),
+		entries:      make(map[string]*typedEntry[T]),
+		maxItems:     maxItems,
+		refreshAhead: refreshAhead,
+		ttl:          ttl,
+	}
+}
+
+// SetEnabled enables or disables the cache; safe to call while the cache is in use
+func (t *Typed[T]) SetEnabled(enabled bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.Enabled = enabled
+}
+
+// Get returns the cached value for a key, if present and not expired
+func (t *Typed[T]) Get(key string) (T, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	var zero T
+	if !t.Enabled {
+		return zero, false
+	}
+
+	e, exists := t.entries[key]
+	if !exists || time.Now().After(e.expires) {
+		return zero, false
+	}
+
+	return clone(e.value), true
+}
+
+// Set stores a value for a key for the cache's TTL; nothing is stored while the cache is disabled
+func (t *Typed[T]) Set(key string, value T) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if !t.Enabled {
+		return
+	}
+	t.set(key, value)
+}
+
+// Delete removes a key from the cache
+func (t *Typed[T]) Delete(key string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	delete(t.entries, key)
+}
+
+/*
+ * GetOrLoad returns the cached value for a key, calling `load` on a miss
+ * - Concurrent misses for the same key share a single call to `load`
+ * - A hit within the refresh-ahead window is returned immediately while `load` runs in the background
+ */
+func (t *Typed[T]) GetOrLoad(key string, load func() (T, error)) (T, error) {
+	t.mutex.Lock()
+	if !t.Enabled {
+		t.mutex.Unlock()
+		return load()
+	}
+
+	if e, exists := t.entries[key]; exists {
+		remaining := time.Until(e.expires)
+		if remaining > 0 {
+			if remaining <= t.refreshAhead {
+				if _, inflight := t.calls[key]; !inflight {
+					c := t.start(key)
+					go t.load(key, c, load)
+				}
+			}
+			t.mutex.Unlock()
+			return clone(e.value), nil
+		}
+	}
+
+	if c, inflight := t.calls[key]; inflight {
+		t.mutex.Unlock()
+		c.wg.Wait()
+		return clone(c.value), c.err
+	}
+
+	c := t.start(key)
+	t.mutex.Unlock()
+
+	t.load(key, c, load)
+	return c.value, c.err
+}
+
+// start registers an in-flight load for a key; the caller must hold the mutex
+func (t *Typed[T]) start(key string) *call[T] {
+	c := &call[T]{}
+	c.wg.Add(1)
+	t.calls[key] = c
+	return c
+}
+
+// load runs `load`, caching a successful result and releasing every caller waiting on it
+func (t *Typed[T]) load(key string, c *call[T], load func() (T, error)) {
+	c.value, c.err = load()
+
+	t.mutex.Lock()
+	if c.err == nil {
+		t.set(key, c.value)
+	}
+	delete(t.calls, key)
+	t.mutex.Unlock()
+
+	c.wg.Done()
+}
+
+// set stores a value, evicting expired entries (then the soonest to expire) when full; the caller must hold the mutex
+func (t *Typed[T]) set(key string, value T) {
+	if _, exists := t.entries[key]; !exists && len(t.entries) >= t.maxItems {
+		now := time.Now()
+		for k, e := range t.entries {
+			if now.After(e.expires) {
+				delete(t.entries, k)
+			}
+		}
+
+		if len(t.entries) >= t.maxItems {
+			var oldest string
+			for k, e := range t.entries {
+				if oldest == "" || e.expires.Before(t.entries[oldest].expires) {
+					oldest = k
+				}
+			}
+			delete(t.entries, oldest)
+		}
+	}
+
+	t.entries[key] = &typedEntry[T]{
+		value:   clone(value),
+		expires: time.Now().Add(t.ttl),
+	}
+}
+
+// clone returns a deep copy of a value, following pointers, slices, maps and interfaces; unexported fields are copied as is
+func clone[T any](value T) T {
+	v := reflect.ValueOf(&value).Elem()
+	return deepCopy(v).Interface().(T)
+}
+
+func deepCopy(v reflect.Value) reflect.Value {
+	out := reflect.New(v.Type()).Elem()
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			p := reflect.New(v.Type().Elem())
+			p.Elem().Set(deepCopy(v.Elem()))
+			out.Set(p)
+		}
+	case reflect.Interface:
+		if !v.IsNil() {
+			out.Set(deepCopy(v.Elem()))
+		}
+	case reflect.Slice:
+		if !v.IsNil() {
+			s := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+			for i := 0; i < v.Len(); i++ {
+				s.Index(i).Set(deepCopy(v.Index(i)))
+			}
+			out.Set(s)
+		}
+	case reflect.Map:
+		if !v.IsNil() {
+			m := reflect.MakeMapWithSize(v.Type(), v.Len())
+			iter := v.MapRange()
+			for iter.Next() {
+				m.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+			}
+			out.Set(m)
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(deepCopy(v.Index(i)))
+		}
+	case reflect.Struct:
+		out.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if out.Field(i).CanSet() {
+				out.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+	default:
+		out.Set(v)
+	}
+
+	return out
+}
@@ -2,21 +2,20 @@
 package config
 
 import (
-	"os"
 	"strconv"
 )
 
-// Get environment variable (no default value)
+// Get environment variable (no default value), preferring the selected tenant's variant, then the environment profile's
 func GetEnv(key string) string {
-	if value, exists := os.LookupEnv(key); exists {
-		return value
-	}
-	return ""
+	return NewProfile().GetEnv(key)
 }
 
 // Get environment variable as integer (no default value)
 func GetEnvAsInt(key string) int {
-	valueStr := GetEnv(key)
+	return atoi(GetEnv(key))
+}
+
+func atoi(valueStr string) int {
 	if value, err := strconv.Atoi(valueStr); err == nil {
 		return value
 	}
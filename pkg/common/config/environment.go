@@ -0,0 +1,125 @@
+// pkg/common/config/environment.go
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+const (
+	Production = "production" // Default environment; variables are read without a profile
+)
+
+func normalizeEnvironment(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" || name == "prod" {
+		return Production
+	}
+	return name
+}
+
+// Environment returns the default environment profile, read from REGO_ENVIRONMENT, of clients created without WithEnvironment
+func Environment() string {
+	return normalizeEnvironment(os.Getenv("REGO_ENVIRONMENT"))
+}
+
+// IsProduction reports whether the default environment is production
+func IsProduction() bool {
+	return Environment() == Production
+}
+
+// Profile is the tenant and environment a client reads its configuration for
+type Profile struct {
+	Tenant      string
+	Environment string
+}
+
+// Option configures the profile of a single client, e.g. `okta.NewClient(log.INFO, config.WithEnvironment("sandbox"))`
+type Option func(*Profile)
+
+/*
+ * WithEnvironment selects the environment profile (e.g. "staging", "sandbox") a client is configured from
+ * - Variables are looked up as `{SERVICE}_{ENVIRONMENT}_{NAME}` (e.g. OKTA_SANDBOX_API_TOKEN) before falling back to `{SERVICE}_{NAME}`
+ * - Only the client created with the option is affected, so clients of different environments can be used side by side:
+ *
+ *	prod := okta.NewClient(log.INFO)
+ *	sandbox := okta.NewClient(log.INFO, config.WithEnvironment("sandbox"))
+ */
+func WithEnvironment(name string) Option {
+	return func(p *Profile) {
+		p.Environment = normalizeEnvironment(name)
+	}
+}
+
+// NewProfile returns the profile of a client: the selected tenant and the default environment, changed by opts
+func NewProfile(opts ...Option) Profile {
+	p := Profile{Tenant: Tenant(), Environment: Environment()}
+	for _, opt := range opts {
+		opt(&p)
+	}
+	return p
+}
+
+// GetEnv returns an environment variable, preferring the tenant's variant, then the environment profile's
+func (p Profile) GetEnv(key string) string {
+	if p.Tenant != "" {
+		if value, exists := os.LookupEnv(profileKey(key, p.Tenant)); exists {
+			return value
+		}
+	}
+
+	if p.Environment != "" && p.Environment != Production {
+		if value, exists := os.LookupEnv(profileKey(key, p.Environment)); exists {
+			return value
+		}
+	}
+
+	if value, exists := os.LookupEnv(key); exists {
+		return value
+	}
+	return ""
+}
+
+// GetEnvAsInt returns an environment variable of the profile as an integer (no default value)
+func (p Profile) GetEnvAsInt(key string) int {
+	return atoi(p.GetEnv(key))
+}
+
+// profileKey returns the environment/tenant-specific name of a variable, e.g. OKTA_API_TOKEN -> OKTA_SANDBOX_API_TOKEN
+func profileKey(key, env string) string {
+	profile := strings.ToUpper(strings.NewReplacer("-", "_", " ", "_").Replace(env))
+
+	service, name, found := strings.Cut(key, "_")
+	if !found {
+		return key + "_" + profile
+	}
+	return service + "_" + profile + "_" + name
+}
+
+/*
+ * FileName adds the tenant and environment of the profile to a file name, keeping caches and logs of different tenants
+ * and environments apart, e.g. rego_cache_okta.gob -> rego_cache_okta_acme_sandbox.gob
+ */
+func (p Profile) FileName(name string) string {
+	suffix := ""
+	if p.Tenant != "" {
+		suffix += "_" + p.Tenant
+	}
+	if p.Environment != "" && p.Environment != Production {
+		suffix += "_" + p.Environment
+	}
+	if suffix == "" {
+		return name
+	}
+
+	ext := ""
+	if i := strings.LastIndex(name, "."); i > 0 {
+		name, ext = name[:i], name[i:]
+	}
+	return name + suffix + ext
+}
+
+// ProfileFileName adds the selected tenant and the default environment to a file name (see Profile.FileName)
+func ProfileFileName(name string) string {
+	return NewProfile().FileName(name)
+}
@@ -0,0 +1,66 @@
+// pkg/common/config/tenant.go
+package config
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+var (
+	tenant      string
+	tenantMutex sync.RWMutex
+	tenantScope sync.Mutex // Serializes UsingTenant
+)
+
+func normalizeTenant(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+/*
+ * WithTenant selects the tenant (e.g. a subsidiary's Google/Okta/Jamf organization) clients are configured for
+ * - Variables are looked up as `{SERVICE}_{TENANT}_{NAME}` (e.g. OKTA_ACME_API_TOKEN) before the environment profile and the default
+ * - Cache files and the log file are suffixed with the tenant, keeping tenants apart
+ * - An empty name selects the default (single tenant) configuration
+ */
+func WithTenant(name string) {
+	tenantMutex.Lock()
+	defer tenantMutex.Unlock()
+
+	tenant = normalizeTenant(name)
+}
+
+// Tenant returns the selected tenant, or an empty string for the default configuration
+func Tenant() string {
+	tenantMutex.RLock()
+	defer tenantMutex.RUnlock()
+
+	return tenant
+}
+
+/*
+ * UsingTenant selects a tenant while `fn` runs, restoring the previous one afterwards
+ * Calls are serialized so concurrent client construction for different tenants cannot interleave;
+ * clients read their configuration while being created, so create per-tenant clients inside `fn`
+ */
+func UsingTenant(name string, fn func() error) error {
+	tenantScope.Lock()
+	defer tenantScope.Unlock()
+
+	previous := Tenant()
+	WithTenant(name)
+	defer WithTenant(previous)
+
+	return fn()
+}
+
+// Tenants returns the tenants listed in REGO_TENANTS (comma separated)
+func Tenants() []string {
+	tenants := []string{}
+	for _, name := range strings.Split(os.Getenv("REGO_TENANTS"), ",") {
+		if name = normalizeTenant(name); name != "" {
+			tenants = append(tenants, name)
+		}
+	}
+	return tenants
+}
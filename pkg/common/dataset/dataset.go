@@ -0,0 +1,100 @@
+// pkg/common/dataset/dataset.go
+package dataset
+
+import (
+	"sort"
+)
+
+/*
+ * Dataset is an in-memory, chainable view over a typed slice for ad-hoc questions across providers, e.g.
+ *
+ *	suspended := dataset.From(googleUsers).Where(func(u *google.User) bool { return u.Suspended })
+ *	devices := dataset.Join(dataset.From(computers), suspended, computerEmail, userEmail)
+ *
+ * Operations changing the element type (Map, Join, GroupBy) are functions, as Go methods cannot add type parameters
+ */
+type Dataset[T any] struct {
+	Items []T
+}
+
+// From wraps a slice; nil elements are kept, so filter them with Where if the source may contain them
+func From[T any](items []T) *Dataset[T] {
+	return &Dataset[T]{Items: items}
+}
+
+// Where keeps the items matching every predicate
+func (d *Dataset[T]) Where(predicates ...func(T) bool) *Dataset[T] {
+	out := make([]T, 0, len(d.Items))
+items:
+	for _, item := range d.Items {
+		for _, p := range predicates {
+			if !p(item) {
+				continue items
+			}
+		}
+		out = append(out, item)
+	}
+	return From(out)
+}
+
+// SortBy orders the items (stable) with `less`
+func (d *Dataset[T]) SortBy(less func(a, b T) bool) *Dataset[T] {
+	out := append([]T{}, d.Items...)
+	sort.SliceStable(out, func(i, j int) bool { return less(out[i], out[j]) })
+	return From(out)
+}
+
+// Limit keeps at most the first n items
+func (d *Dataset[T]) Limit(n int) *Dataset[T] {
+	if n < 0 || n >= len(d.Items) {
+		return d
+	}
+	return From(d.Items[:n])
+}
+
+// Count returns the number of items
+func (d *Dataset[T]) Count() int {
+	return len(d.Items)
+}
+
+// First returns the first item, if any
+func (d *Dataset[T]) First() (T, bool) {
+	if len(d.Items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.Items[0], true
+}
+
+// Map selects a value from every item
+func Map[T, U any](d *Dataset[T], selector func(T) U) *Dataset[U] {
+	out := make([]U, len(d.Items))
+	for i, item := range d.Items {
+		out[i] = selector(item)
+	}
+	return From(out)
+}
+
+// Index maps each key to its last item with that key
+func Index[T any, K comparable](d *Dataset[T], key func(T) K) map[K]T {
+	index := make(map[K]T, len(d.Items))
+	for _, item := range d.Items {
+		index[key(item)] = item
+	}
+	return index
+}
+
+// Distinct keeps the first item of each key
+func Distinct[T any, K comparable](d *Dataset[T], key func(T) K) *Dataset[T] {
+	seen := make(map[K]bool, len(d.Items))
+	out := make([]T, 0, len(d.Items))
+	for _, item := range d.Items {
+		k := key(item)
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		out = append(out, item)
+	}
+	return From(out)
+}
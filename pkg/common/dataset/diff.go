@@ -0,0 +1,222 @@
+// pkg/common/dataset/diff.go
+package dataset
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/cache"
+)
+
+// FieldChange is a top-level field whose JSON value differs between two versions of an item
+type FieldChange struct {
+	Field  string
+	Before string // JSON value; empty when the field was absent
+	After  string // JSON value; empty when the field is absent
+}
+
+// Modification is an item present in both datasets, with its changed fields, ordered by name
+type Modification[T any, K comparable] struct {
+	Key    K
+	Before T
+	After  T
+	Fields []FieldChange
+}
+
+/*
+ * Report is what changed between two datasets, e.g. the super admins added since last week
+ * Added and Removed keep the order of their dataset; Modified keeps the order of the current dataset
+ */
+type Report[T any, K comparable] struct {
+	Since    time.Time // When the previous dataset was taken; zero for a baseline (see Changes)
+	Added    []T
+	Removed  []T
+	Modified []Modification[T, K]
+}
+
+/*
+ * Diff compares two datasets of items identified by key:
+ *
+ *	admins := dataset.From(users).Where(func(u *google.User) bool { return u.IsAdmin })
+ *	report, err := dataset.Diff(previous, admins, func(u *google.User) string { return u.ID }, "lastLoginTime", "etag")
+ *
+ * Items are compared by their JSON encoding, field by field; the `ignore`d fields (JSON names) never make an item
+ * modified, e.g. timestamps changing on every run. Items whose key is the zero value are skipped; the last item wins
+ * when a key is repeated
+ */
+func Diff[T any, K comparable](previous, current *Dataset[T], key func(T) K, ignore ...string) (*Report[T, K], error) {
+	var zero K
+
+	ignored := make(map[string]bool, len(ignore))
+	for _, field := range ignore {
+		ignored[field] = true
+	}
+
+	before := Index(previous.Where(func(item T) bool { return key(item) != zero }), key)
+	after := Index(current.Where(func(item T) bool { return key(item) != zero }), key)
+
+	report := &Report[T, K]{}
+	seen := make(map[K]bool, len(after))
+
+	for _, item := range current.Items {
+		k := key(item)
+		if k == zero || seen[k] {
+			continue
+		}
+		seen[k] = true
+		item = after[k]
+
+		old, existed := before[k]
+		if !existed {
+			report.Added = append(report.Added, item)
+			continue
+		}
+
+		fields, err := diffFields(old, item, ignored)
+		if err != nil {
+			return nil, fmt.Errorf("comparing %v: %w", k, err)
+		}
+		if len(fields) > 0 {
+			report.Modified = append(report.Modified, Modification[T, K]{Key: k, Before: old, After: item, Fields: fields})
+		}
+	}
+
+	removed := make(map[K]bool, len(before))
+	for _, item := range previous.Items {
+		k := key(item)
+		if k == zero || removed[k] {
+			continue
+		}
+		if _, exists := after[k]; !exists {
+			removed[k] = true
+			report.Removed = append(report.Removed, before[k])
+		}
+	}
+
+	return report, nil
+}
+
+/*
+ * Changes compares the current dataset with its last snapshot taken at or before `since`, then snapshots it under
+ * `name`, so the next run compares against it:
+ *
+ *	report, err := dataset.Changes(c.Cache, "google/super-admins", admins, userID, time.Now().AddDate(0, 0, -7))
+ *
+ * Use time.Now() as `since` for the changes since the last run. Without a previous snapshot (the first run, or once
+ * the retention removed it), the report is a baseline: Since is zero and it lists no changes
+ */
+func Changes[T any, K comparable](c *cache.Cache, name string, current *Dataset[T], key func(T) K, since time.Time, ignore ...string) (*Report[T, K], error) {
+	var previous []T
+	taken, err := c.AsOf(name, since, &previous)
+	switch {
+	case errors.Is(err, cache.ErrNoSnapshot):
+		if err := c.Snapshot(name, current.Items); err != nil {
+			return nil, err
+		}
+		return &Report[T, K]{}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	report, err := Diff(From(previous), current, key, ignore...)
+	if err != nil {
+		return nil, err
+	}
+	report.Since = taken
+
+	if err := c.Snapshot(name, current.Items); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// Empty reports whether nothing changed
+func (r *Report[T, K]) Empty() bool {
+	return len(r.Added) == 0 && len(r.Removed) == 0 && len(r.Modified) == 0
+}
+
+// String summarizes the report, e.g. `2 added, 0 removed, 1 modified since 2024-05-01T09:00:00Z`
+func (r *Report[T, K]) String() string {
+	if r.Since.IsZero() {
+		return "baseline, no previous snapshot"
+	}
+	return fmt.Sprintf("%d added, %d removed, %d modified since %s", len(r.Added), len(r.Removed), len(r.Modified), r.Since.Format(time.RFC3339))
+}
+
+/*
+ * Rows flattens the report for an export.Sink or a notification: one row per added or removed item, and one per
+ * changed field of a modified item, under the headers `change, key, field, before, after`
+ */
+func (r *Report[T, K]) Rows(key func(T) K) (headers []string, rows [][]string) {
+	headers = []string{"change", "key", "field", "before", "after"}
+
+	for _, item := range r.Added {
+		rows = append(rows, []string{"added", fmt.Sprint(key(item)), "", "", ""})
+	}
+	for _, item := range r.Removed {
+		rows = append(rows, []string{"removed", fmt.Sprint(key(item)), "", "", ""})
+	}
+	for _, m := range r.Modified {
+		for _, field := range m.Fields {
+			rows = append(rows, []string{"modified", fmt.Sprint(m.Key), field.Field, field.Before, field.After})
+		}
+	}
+
+	return headers, rows
+}
+
+// diffFields returns the top-level JSON fields differing between two items
+func diffFields[T any](before, after T, ignored map[string]bool) ([]FieldChange, error) {
+	old, err := jsonFields(before)
+	if err != nil {
+		return nil, err
+	}
+	cur, err := jsonFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool, len(old)+len(cur))
+	for name := range old {
+		names[name] = true
+	}
+	for name := range cur {
+		names[name] = true
+	}
+
+	changes := []FieldChange{}
+	for name := range names {
+		if ignored[name] || bytes.Equal(old[name], cur[name]) {
+			continue
+		}
+		changes = append(changes, FieldChange{Field: name, Before: string(old[name]), After: string(cur[name])})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+
+	return changes, nil
+}
+
+// jsonFields encodes an item to its compacted top-level JSON fields; non-object items are a single field named ""
+func jsonFields(item any) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return map[string]json.RawMessage{"": data}, nil
+	}
+
+	for name, value := range fields {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, value); err == nil {
+			fields[name] = compact.Bytes()
+		}
+	}
+	return fields, nil
+}
@@ -0,0 +1,92 @@
+// pkg/common/dataset/join.go
+package dataset
+
+// Pair is a joined row; Right is the zero value for unmatched rows of a LeftJoin
+type Pair[L, R any] struct {
+	Left    L
+	Right   R
+	Matched bool
+}
+
+// Group is the items sharing a key, in their original order
+type Group[K comparable, T any] struct {
+	Key   K
+	Items []T
+}
+
+/*
+ * Join pairs every left item with every right item sharing its key (inner join)
+ * Items whose key is the zero value (e.g. a device without an assigned user) never match
+ */
+func Join[L, R any, K comparable](left *Dataset[L], right *Dataset[R], leftKey func(L) K, rightKey func(R) K) *Dataset[Pair[L, R]] {
+	return join(left, right, leftKey, rightKey, false)
+}
+
+// LeftJoin is Join, additionally keeping left items without a match (with Matched false)
+func LeftJoin[L, R any, K comparable](left *Dataset[L], right *Dataset[R], leftKey func(L) K, rightKey func(R) K) *Dataset[Pair[L, R]] {
+	return join(left, right, leftKey, rightKey, true)
+}
+
+// AntiJoin keeps the left items without a matching right item, e.g. Google users missing from Okta
+func AntiJoin[L, R any, K comparable](left *Dataset[L], right *Dataset[R], leftKey func(L) K, rightKey func(R) K) *Dataset[L] {
+	keys := make(map[K]bool, len(right.Items))
+	for _, item := range right.Items {
+		keys[rightKey(item)] = true
+	}
+
+	var zero K
+	return left.Where(func(item L) bool {
+		k := leftKey(item)
+		return k == zero || !keys[k]
+	})
+}
+
+func join[L, R any, K comparable](left *Dataset[L], right *Dataset[R], leftKey func(L) K, rightKey func(R) K, keepUnmatched bool) *Dataset[Pair[L, R]] {
+	var zero K
+
+	index := make(map[K][]R, len(right.Items))
+	for _, item := range right.Items {
+		k := rightKey(item)
+		if k == zero {
+			continue
+		}
+		index[k] = append(index[k], item)
+	}
+
+	out := []Pair[L, R]{}
+	for _, l := range left.Items {
+		k := leftKey(l)
+		matches := index[k]
+		if k == zero {
+			matches = nil
+		}
+
+		for _, r := range matches {
+			out = append(out, Pair[L, R]{Left: l, Right: r, Matched: true})
+		}
+		if len(matches) == 0 && keepUnmatched {
+			out = append(out, Pair[L, R]{Left: l})
+		}
+	}
+
+	return From(out)
+}
+
+// GroupBy groups the items by key, ordered by each key's first appearance
+func GroupBy[T any, K comparable](d *Dataset[T], key func(T) K) *Dataset[Group[K, T]] {
+	positions := make(map[K]int)
+	groups := []Group[K, T]{}
+
+	for _, item := range d.Items {
+		k := key(item)
+		i, ok := positions[k]
+		if !ok {
+			i = len(groups)
+			positions[k] = i
+			groups = append(groups, Group[K, T]{Key: k})
+		}
+		groups[i].Items = append(groups[i].Items, item)
+	}
+
+	return From(groups)
+}
@@ -0,0 +1,113 @@
+// pkg/common/events/elastic.go
+package events
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/retry"
+)
+
+/*
+ * Elasticsearch exports events through the Bulk API
+ * - https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-bulk.html
+ * - Documents rejected with 429 or 5xx are retried on their own; other rejections fail the batch
+ */
+type Elasticsearch struct {
+	URL      string // Base URL of the cluster, e.g. https://es.example.com:9200
+	Index    string // Index or data stream to write to. Default: logs-rego-default
+	APIKey   string // Encoded API key, sent as `Authorization: ApiKey`
+	Username string // Basic authentication, when no API key is set
+	Password string
+}
+
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		Status int `json:"status"`
+		Error  *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error,omitempty"`
+	} `json:"items"`
+}
+
+func (e *Elasticsearch) Name() string {
+	return "elasticsearch"
+}
+
+func (e *Elasticsearch) Export(batch []*Event) error {
+	index := e.Index
+	if index == "" {
+		index = "logs-rego-default"
+	}
+
+	headers := map[string]string{"Content-Type": "application/x-ndjson"}
+	switch {
+	case e.APIKey != "":
+		headers["Authorization"] = "ApiKey " + e.APIKey
+	case e.Username != "":
+		headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(e.Username+":"+e.Password))
+	}
+
+	url := strings.TrimSuffix(e.URL, "/") + "/_bulk"
+	action, _ := json.Marshal(map[string]map[string]string{"create": {"_index": index}})
+
+	for i := 0; len(batch) > 0; i++ {
+		var payload bytes.Buffer
+		for _, event := range batch {
+			doc, err := json.Marshal(event)
+			if err != nil {
+				return err
+			}
+			payload.Write(action)
+			payload.WriteByte('\n')
+			payload.Write(doc)
+			payload.WriteByte('\n')
+		}
+
+		body, err := post(url, headers, payload.Bytes())
+		if err != nil {
+			return err
+		}
+
+		var res bulkResponse
+		if err := json.Unmarshal(body, &res); err != nil {
+			return fmt.Errorf("decoding bulk response: %w", err)
+		}
+		if !res.Errors {
+			return nil
+		}
+
+		retryable := []*Event{}
+		var errs []error
+		for j, item := range res.Items {
+			for _, result := range item {
+				switch {
+				case result.Status == 429 || result.Status >= 500:
+					retryable = append(retryable, batch[j])
+				case result.Error != nil:
+					errs = append(errs, fmt.Errorf("document %d: %s: %s", j, result.Error.Type, result.Error.Reason))
+				}
+			}
+		}
+		if len(errs) > 0 {
+			return errors.Join(errs...)
+		}
+		if len(retryable) > 0 && i+1 >= retry.MaxRetries {
+			return fmt.Errorf("%d documents were still rejected after %d attempts", len(retryable), retry.MaxRetries)
+		}
+
+		batch = retryable
+		if len(batch) > 0 {
+			time.Sleep(retry.BackoffWithJitter(i))
+		}
+	}
+
+	return nil
+}
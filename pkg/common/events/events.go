@@ -0,0 +1,169 @@
+// pkg/common/events/events.go
+package events
+
+import (
+	"strings"
+	"time"
+)
+
+// Outcomes of an event, as defined by `event.outcome`
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+	OutcomeUnknown = "unknown"
+)
+
+// Categories of an event, as defined by `event.category`
+const (
+	CategoryAuthentication = "authentication"
+	CategoryConfiguration  = "configuration"
+	CategoryIAM            = "iam"
+	CategorySession        = "session"
+	CategoryFile           = "file"
+)
+
+/*
+ * Event is a provider neutral audit event, shaped after the Elastic Common Schema (ECS):
+ * - https://www.elastic.co/guide/en/ecs/current/ecs-field-reference.html
+ *
+ * Providers convert their native audit records (the Okta System Log, the Google Admin audit log, ...) into Events,
+ * so SIEM exporters only ever handle this schema. Marshalling an Event produces ECS field names
+ */
+type Event struct {
+	Timestamp time.Time         `json:"@timestamp"`           // Time the event occurred
+	Message   string            `json:"message,omitempty"`    // Human readable description of the event
+	Event     Meta              `json:"event"`                // What happened
+	User      *User             `json:"user,omitempty"`       // Who did it
+	Source    *Source           `json:"source,omitempty"`     // Where it was done from
+	UserAgent *UserAgent        `json:"user_agent,omitempty"` // What it was done with
+	Related   *Related          `json:"related,omitempty"`    // Every user and IP mentioned by the event, for pivoting
+	Labels    map[string]string `json:"labels,omitempty"`     // Provider specific keywords, e.g. the transaction ID
+}
+
+// Meta is the `event.*` field set
+type Meta struct {
+	ID       string   `json:"id,omitempty"`       // Unique identifier of the event at the provider
+	Kind     string   `json:"kind,omitempty"`     // Always `event`
+	Category []string `json:"category,omitempty"` // Broad categories, e.g. `authentication`, `iam`
+	Action   string   `json:"action,omitempty"`   // Provider specific action, e.g. `user.session.start`, `CHANGE_PASSWORD`
+	Outcome  string   `json:"outcome,omitempty"`  // {success, failure, unknown}
+	Reason   string   `json:"reason,omitempty"`   // Reason for the outcome
+	Severity int      `json:"severity,omitempty"` // Syslog severity (3 error, 4 warning, 6 informational, 7 debug)
+	Provider string   `json:"provider,omitempty"` // Source of the event, e.g. `okta`, `google`
+	Dataset  string   `json:"dataset,omitempty"`  // Log the event was read from, e.g. `okta.system`, `google.admin`
+}
+
+// User is the `user.*` field set; Target is the user acted upon
+type User struct {
+	ID     string `json:"id,omitempty"`     // Provider ID of the user
+	Name   string `json:"name,omitempty"`   // Login/username
+	Email  string `json:"email,omitempty"`  // Email address
+	Domain string `json:"domain,omitempty"` // Domain of the user
+	Target *User  `json:"target,omitempty"` // User the action was performed on
+}
+
+// Source is the `source.*` field set
+type Source struct {
+	IP  string `json:"ip,omitempty"`  // IP address the request came from
+	Geo *Geo   `json:"geo,omitempty"` // Location of the IP address, when the provider resolves it
+}
+
+// Geo is the `source.geo.*` field set
+type Geo struct {
+	CityName    string `json:"city_name,omitempty"`
+	RegionName  string `json:"region_name,omitempty"`
+	CountryName string `json:"country_name,omitempty"`
+}
+
+// UserAgent is the `user_agent.*` field set
+type UserAgent struct {
+	Original string `json:"original,omitempty"` // The raw User-Agent header
+	Name     string `json:"name,omitempty"`     // Browser name
+	OS       string `json:"os,omitempty"`       // Operating system name
+}
+
+// Related is the `related.*` field set
+type Related struct {
+	User []string `json:"user,omitempty"`
+	IP   []string `json:"ip,omitempty"`
+}
+
+// New returns an Event of kind `event` from `provider`/`dataset`
+func New(provider, dataset, action string, timestamp time.Time) *Event {
+	return &Event{
+		Timestamp: timestamp.UTC(),
+		Event: Meta{
+			Kind:     "event",
+			Action:   action,
+			Outcome:  OutcomeUnknown,
+			Provider: provider,
+			Dataset:  provider + "." + dataset,
+		},
+	}
+}
+
+// Label sets a provider specific keyword, ignoring empty values
+func (e *Event) Label(key, value string) {
+	if value == "" {
+		return
+	}
+	if e.Labels == nil {
+		e.Labels = map[string]string{}
+	}
+	e.Labels[key] = value
+}
+
+// Relate records the users and IPs the event mentions, ignoring empty and duplicate values
+func (e *Event) Relate(users []string, ips []string) {
+	if e.Related == nil {
+		e.Related = &Related{}
+	}
+	e.Related.User = appendUnique(e.Related.User, users...)
+	e.Related.IP = appendUnique(e.Related.IP, ips...)
+}
+
+func appendUnique(list []string, values ...string) []string {
+values:
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		for _, existing := range list {
+			if existing == v {
+				continue values
+			}
+		}
+		list = append(list, v)
+	}
+	return list
+}
+
+// Outcome maps a provider result (e.g. SUCCESS, ALLOW, DENY, FAILURE) onto `event.outcome`
+func Outcome(result string) string {
+	switch strings.ToUpper(result) {
+	case "SUCCESS", "ALLOW", "ALLOWED", "SUCCEEDED":
+		return OutcomeSuccess
+	case "FAILURE", "DENY", "DENIED", "FAILED", "ERROR":
+		return OutcomeFailure
+	default:
+		return OutcomeUnknown
+	}
+}
+
+// Severity maps a provider level (e.g. DEBUG, INFO, WARN, ERROR) onto a syslog severity
+func Severity(level string) int {
+	switch strings.ToUpper(level) {
+	case "DEBUG":
+		return 7
+	case "INFO":
+		return 6
+	case "WARN", "WARNING":
+		return 4
+	case "ERROR":
+		return 3
+	case "CRITICAL":
+		return 2
+	default:
+		return 0
+	}
+}
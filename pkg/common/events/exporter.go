@@ -0,0 +1,184 @@
+// pkg/common/events/exporter.go
+package events
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/retry"
+)
+
+// Exporter pushes batches of events to a SIEM
+type Exporter interface {
+	Name() string
+	Export(batch []*Event) error
+}
+
+// ForwarderOptions configure the batching of a Forwarder
+type ForwarderOptions struct {
+	BatchSize     int           // Events per batch. Default: 500
+	FlushInterval time.Duration // Maximum time an event waits for its batch to fill. Default: 5s
+	BufferSize    int           // Events buffered before Send blocks (backpressure). Default: 10 * BatchSize
+	OnError       func(error)   // Called with the error of a batch that failed every retry. Default: the error is dropped
+}
+
+/*
+ * Forwarder batches events in the background and hands each batch to an Exporter
+ *
+ *	f := events.NewForwarder(&events.SplunkHEC{URL: url, Token: token}, events.ForwarderOptions{})
+ *	defer f.Close()
+ *	for _, e := range oktaEvents.CommonEvents() {
+ *		f.Send(ctx, e)
+ *	}
+ *
+ * Send blocks once the buffer is full, so a slow SIEM slows down the producer instead of growing memory
+ */
+type Forwarder struct {
+	exporter Exporter
+	opts     ForwarderOptions
+	queue    chan *Event
+	done     chan struct{}
+	once     sync.Once
+}
+
+// NewForwarder starts a Forwarder exporting to `exporter`
+func NewForwarder(exporter Exporter, opts ForwarderOptions) *Forwarder {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 500
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = 5 * time.Second
+	}
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = 10 * opts.BatchSize
+	}
+
+	f := &Forwarder{
+		exporter: exporter,
+		opts:     opts,
+		queue:    make(chan *Event, opts.BufferSize),
+		done:     make(chan struct{}),
+	}
+	go f.run()
+
+	return f
+}
+
+// Send queues an event, blocking while the buffer is full until `ctx` is done
+func (f *Forwarder) Send(ctx context.Context, event *Event) error {
+	select {
+	case f.queue <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close flushes the queued events and stops the Forwarder. Send must not be called afterwards
+func (f *Forwarder) Close() {
+	f.once.Do(func() {
+		close(f.queue)
+		<-f.done
+	})
+}
+
+func (f *Forwarder) run() {
+	defer close(f.done)
+
+	ticker := time.NewTicker(f.opts.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*Event, 0, f.opts.BatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := f.exporter.Export(batch); err != nil && f.opts.OnError != nil {
+			f.opts.OnError(fmt.Errorf("%s: exporting %d events: %w", f.exporter.Name(), len(batch), err))
+		}
+		batch = make([]*Event, 0, f.opts.BatchSize)
+	}
+
+	for {
+		select {
+		case event, ok := <-f.queue:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, event)
+			if len(batch) >= f.opts.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+var httpClient = &http.Client{Timeout: 60 * time.Second}
+
+// errRetryable marks responses worth retrying (429 and 5xx)
+var errRetryable = errors.New("retryable")
+
+// post sends a gzipped payload, retrying throttled and failed requests with backoff, and returns the response body
+func post(url string, headers map[string]string, payload []byte) ([]byte, error) {
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if _, err := gz.Write(payload); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+
+	var body []byte
+	var err error
+	for i := 0; i < retry.MaxRetries; i++ {
+		body, err = postOnce(url, headers, compressed.Bytes())
+		if err == nil || !errors.Is(err, errRetryable) {
+			return body, err
+		}
+		time.Sleep(retry.BackoffWithJitter(i))
+	}
+
+	return nil, err
+}
+
+func postOnce(url string, headers map[string]string, payload []byte) ([]byte, error) {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Encoding", "gzip")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errRetryable, err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errRetryable, err)
+	}
+
+	switch {
+	case res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500:
+		return nil, fmt.Errorf("%w: unexpected status %s: %s", errRetryable, res.Status, body)
+	case res.StatusCode < 200 || res.StatusCode > 299:
+		return nil, fmt.Errorf("unexpected status %s: %s", res.Status, body)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,69 @@
+// pkg/common/events/splunk.go
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+/*
+ * SplunkHEC exports events to a Splunk HTTP Event Collector
+ * - https://docs.splunk.com/Documentation/Splunk/latest/Data/HECExamples
+ * - Each batch is a single gzipped request of concatenated event objects
+ */
+type SplunkHEC struct {
+	URL        string // Base URL of the collector, e.g. https://splunk.example.com:8088
+	Token      string // HEC token
+	Index      string // Index to write to. Default: the token's default index
+	Source     string // Source of the events. Default: rego
+	SourceType string // Sourcetype of the events. Default: _json
+	Host       string // Host field of the events
+}
+
+type splunkEvent struct {
+	Time       float64 `json:"time"`
+	Host       string  `json:"host,omitempty"`
+	Source     string  `json:"source,omitempty"`
+	SourceType string  `json:"sourcetype,omitempty"`
+	Index      string  `json:"index,omitempty"`
+	Event      *Event  `json:"event"`
+}
+
+func (s *SplunkHEC) Name() string {
+	return "splunk"
+}
+
+func (s *SplunkHEC) Export(batch []*Event) error {
+	source := s.Source
+	if source == "" {
+		source = "rego"
+	}
+	sourceType := s.SourceType
+	if sourceType == "" {
+		sourceType = "_json"
+	}
+
+	var payload bytes.Buffer
+	enc := json.NewEncoder(&payload)
+	for _, event := range batch {
+		err := enc.Encode(splunkEvent{
+			Time:       float64(event.Timestamp.UnixMilli()) / 1000,
+			Host:       s.Host,
+			Source:     source,
+			SourceType: sourceType,
+			Index:      s.Index,
+			Event:      event,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	headers := map[string]string{
+		"Authorization": "Splunk " + s.Token,
+		"Content-Type":  "application/json",
+	}
+	_, err := post(strings.TrimSuffix(s.URL, "/")+"/services/collector/event", headers, payload.Bytes())
+	return err
+}
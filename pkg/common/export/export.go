@@ -0,0 +1,89 @@
+// pkg/common/export/export.go
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// Sink receives rows as they are produced, so exports never hold the full dataset in memory
+type Sink interface {
+	WriteHeader(headers []string) error
+	WriteRows(rows [][]string) error
+	Close() error
+}
+
+// CSVSink writes rows to a CSV stream, flushing after every batch
+type CSVSink struct {
+	w      *csv.Writer
+	closer io.Closer
+	Rows   int // Rows written, excluding the header
+}
+
+// NewCSVSink writes to `w`; it is closed with the sink when it is an io.Closer (e.g. an *os.File)
+func NewCSVSink(w io.Writer) *CSVSink {
+	s := &CSVSink{w: csv.NewWriter(w)}
+	if c, ok := w.(io.Closer); ok {
+		s.closer = c
+	}
+	return s
+}
+
+func (s *CSVSink) WriteHeader(headers []string) error {
+	if err := s.w.Write(headers); err != nil {
+		return fmt.Errorf("writing csv header: %w", err)
+	}
+	s.w.Flush()
+	return s.w.Error()
+}
+
+func (s *CSVSink) WriteRows(rows [][]string) error {
+	if err := s.w.WriteAll(rows); err != nil {
+		return fmt.Errorf("writing csv rows: %w", err)
+	}
+	s.Rows += len(rows)
+	return nil
+}
+
+func (s *CSVSink) Close() error {
+	s.w.Flush()
+	if err := s.w.Error(); err != nil {
+		return err
+	}
+	if s.closer != nil {
+		return s.closer.Close()
+	}
+	return nil
+}
+
+/*
+ * Stream writes each page produced by `pages` to the sink as it arrives
+ * - `pages` calls `yield` once per fetched page, and stops when it returns an error
+ * - `row` converts an item into its columns, in the order of `headers`
+ * The sink is always closed; the number of rows written is returned
+ */
+func Stream[T any](sink Sink, headers []string, pages func(yield func(page []T) error) error, row func(item T) []string) (int, error) {
+	written := 0
+
+	err := sink.WriteHeader(headers)
+	if err == nil {
+		err = pages(func(page []T) error {
+			rows := make([][]string, len(page))
+			for i, item := range page {
+				rows[i] = row(item)
+			}
+			if err := sink.WriteRows(rows); err != nil {
+				return err
+			}
+			written += len(rows)
+			return nil
+		})
+	}
+
+	if closeErr := sink.Close(); err == nil {
+		err = closeErr
+	}
+
+	return written, err
+}
@@ -0,0 +1,118 @@
+// pkg/common/flags/flags.go
+package flags
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gemini-oss/rego/pkg/common/config"
+	"github.com/gemini-oss/rego/pkg/common/log"
+)
+
+// ErrDisabled is returned (wrapped) when an automation has been switched off
+var ErrDisabled = errors.New("disabled by kill switch")
+
+// Provider supplies the current value of flags; flags it doesn't know about are omitted from the map
+type Provider interface {
+	Name() string
+	Flags() (map[string]bool, error)
+}
+
+/*
+ * Set consults its providers, in order, for the state of a flag
+ * - The first provider defining a flag wins
+ * - Flags no provider defines are enabled, so automations only stop when explicitly switched off
+ * - A provider which fails keeps serving its last known values; the failure is logged once, until it changes
+ */
+type Set struct {
+	Log       *log.Logger // Logger for provider failures
+	providers []Provider
+	last      map[Provider]map[string]bool
+	failures  map[Provider]string
+	mutex     sync.Mutex
+}
+
+var (
+	defaultSet *Set
+	once       sync.Once
+)
+
+/*
+ * Default returns the shared flag set, built from the environment:
+ * 1. REGO_FLAG_{NAME} and REGO_DISABLED_AUTOMATIONS (see EnvProvider)
+ * 2. The JSON file at REGO_FLAGS_FILE, if set
+ * 3. The JSON document at REGO_FLAGS_URL, if set
+ */
+func Default() *Set {
+	once.Do(func() {
+		providers := []Provider{&EnvProvider{}}
+		if path := config.GetEnv("REGO_FLAGS_FILE"); path != "" {
+			providers = append(providers, NewFileProvider(path))
+		}
+		if url := config.GetEnv("REGO_FLAGS_URL"); url != "" {
+			providers = append(providers, NewHTTPProvider(url, 0))
+		}
+		defaultSet = New(providers...)
+	})
+	return defaultSet
+}
+
+// New creates a flag set consulting the given providers in order
+func New(providers ...Provider) *Set {
+	return &Set{
+		Log:       log.NewLogger("{flags}", log.INFO),
+		providers: providers,
+		last:      make(map[Provider]map[string]bool),
+		failures:  make(map[Provider]string),
+	}
+}
+
+// Enabled reports whether the flag is switched on
+func (s *Set) Enabled(name string) bool {
+	name = Normalize(name)
+
+	for _, p := range s.providers {
+		// Providers are consulted without the lock, so a slow provider doesn't block other flags
+		values := s.values(p)
+		if enabled, ok := values[name]; ok {
+			return enabled
+		}
+	}
+
+	return true
+}
+
+// values returns the current flags of a provider, or its last known flags when it fails
+func (s *Set) values(p Provider) map[string]bool {
+	values, err := p.Flags()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err == nil {
+		s.last[p] = values
+		delete(s.failures, p)
+		return values
+	}
+
+	if s.failures[p] != err.Error() {
+		s.failures[p] = err.Error()
+		s.Log.Warningf("flags provider %s failed, serving its last known values: %v", p.Name(), err)
+	}
+	return s.last[p]
+}
+
+// Check returns an error wrapping ErrDisabled when the flag is switched off
+func (s *Set) Check(name string) error {
+	if !s.Enabled(name) {
+		return fmt.Errorf("%s: %w", name, ErrDisabled)
+	}
+	return nil
+}
+
+// Normalize returns the canonical form of a flag name, e.g. "Auto Suspend_Inactive" -> "auto-suspend-inactive"
+func Normalize(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "-", " ", "-").Replace(strings.TrimSpace(name)))
+}
@@ -0,0 +1,180 @@
+// pkg/common/flags/providers.go
+package flags
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+ * EnvProvider reads flags from the environment
+ * - REGO_FLAG_{NAME}=true|false, e.g. REGO_FLAG_AUTO_SUSPEND_INACTIVE=false
+ * - REGO_DISABLED_AUTOMATIONS, a comma separated list of flags to switch off
+ */
+type EnvProvider struct{}
+
+func (p *EnvProvider) Name() string {
+	return "env"
+}
+
+func (p *EnvProvider) Flags() (map[string]bool, error) {
+	values := make(map[string]bool)
+
+	for _, name := range strings.Split(os.Getenv("REGO_DISABLED_AUTOMATIONS"), ",") {
+		if name = Normalize(name); name != "" {
+			values[name] = false
+		}
+	}
+
+	for _, kv := range os.Environ() {
+		key, value, _ := strings.Cut(kv, "=")
+		name, found := strings.CutPrefix(key, "REGO_FLAG_")
+		if !found {
+			continue
+		}
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			continue
+		}
+		values[Normalize(name)] = enabled
+	}
+
+	return values, nil
+}
+
+// FileProvider reads flags from a JSON object of `"name": bool`, re-reading the file whenever it changes
+type FileProvider struct {
+	Path     string
+	modified time.Time
+	values   map[string]bool
+	mutex    sync.Mutex
+}
+
+func NewFileProvider(path string) *FileProvider {
+	return &FileProvider{Path: path}
+}
+
+func (p *FileProvider) Name() string {
+	return "file:" + p.Path
+}
+
+func (p *FileProvider) Flags() (map[string]bool, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	info, err := os.Stat(p.Path)
+	if err != nil {
+		return nil, err
+	}
+	if p.values != nil && info.ModTime().Equal(p.modified) {
+		return p.values, nil
+	}
+
+	b, err := os.ReadFile(p.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := decode(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", p.Path, err)
+	}
+
+	p.values = values
+	p.modified = info.ModTime()
+	return values, nil
+}
+
+// HTTPProvider fetches flags from a JSON object of `"name": bool`, refreshing at most once per TTL, even when it fails
+type HTTPProvider struct {
+	URL     string
+	TTL     time.Duration
+	Client  *http.Client
+	fetched time.Time
+	values  map[string]bool
+	err     error
+	mutex   sync.Mutex
+}
+
+// NewHTTPProvider creates a provider for `url`; a zero TTL defaults to 1 minute
+func NewHTTPProvider(url string, ttl time.Duration) *HTTPProvider {
+	if ttl <= 0 {
+		ttl = time.Minute
+	}
+
+	return &HTTPProvider{
+		URL:    url,
+		TTL:    ttl,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *HTTPProvider) Name() string {
+	return "http:" + p.URL
+}
+
+func (p *HTTPProvider) Flags() (map[string]bool, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	// A failure is cached like the values, so an unreachable URL is only tried once per TTL
+	if !p.fetched.IsZero() && time.Since(p.fetched) < p.TTL {
+		if p.err != nil {
+			return nil, p.err
+		}
+		return p.values, nil
+	}
+
+	values, err := p.fetch()
+	p.fetched = time.Now()
+	p.err = err
+	if err != nil {
+		return nil, err
+	}
+
+	p.values = values
+	return values, nil
+}
+
+func (p *HTTPProvider) fetch() (map[string]bool, error) {
+	res, err := p.Client.Get(p.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: unexpected status %s", p.URL, res.Status)
+	}
+
+	var raw json.RawMessage
+	if err := json.NewDecoder(res.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", p.URL, err)
+	}
+
+	values, err := decode(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", p.URL, err)
+	}
+	return values, nil
+}
+
+// decode parses a JSON object of flag states, normalizing the names
+func decode(b []byte) (map[string]bool, error) {
+	raw := make(map[string]bool)
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, fmt.Errorf("decoding flags: %w", err)
+	}
+
+	values := make(map[string]bool, len(raw))
+	for name, enabled := range raw {
+		values[Normalize(name)] = enabled
+	}
+	return values, nil
+}
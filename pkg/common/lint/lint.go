@@ -0,0 +1,218 @@
+// pkg/common/lint/lint.go
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Rules a Policy checks; each Violation names the rule it broke
+const (
+	RulePattern     = "pattern"     // The name does not match the policy's pattern
+	RulePrefix      = "prefix"      // The name does not start with an allowed prefix
+	RuleLength      = "length"      // The name is longer than allowed
+	RuleLowercase   = "lowercase"   // The name contains uppercase letters
+	RuleForbidden   = "forbidden"   // The name contains a forbidden word
+	RuleDescription = "description" // The group has no description
+	RuleOwners      = "owners"      // The group has fewer owners than required
+)
+
+/*
+ * Policy is a naming policy for groups, e.g.
+ *
+ *	&lint.Policy{
+ *		Name:               "teams",
+ *		Prefixes:           []string{"team-", "dl-", "app-"},
+ *		Lowercase:          true,
+ *		MaxLength:          64,
+ *		RequireDescription: true,
+ *		MinOwners:          1,
+ *	}
+ *
+ * Every rule is optional; zero values are not checked
+ */
+type Policy struct {
+	Name               string   // Name of the policy, reported with its violations
+	Providers          []string // Providers the policy applies to, e.g. `okta`, `google`; every provider when empty
+	Match              string   // Regular expression selecting the groups the policy applies to, by name; every group when empty
+	Pattern            string   // Regular expression group names must match
+	Prefixes           []string // Prefixes group names must start with (any of them)
+	MaxLength          int      // Maximum length of group names
+	Lowercase          bool     // Group names must be lowercase
+	Separator          string   // Separator used by suggested names in place of spaces and underscores. Default: "-"
+	Forbidden          []string // Words group names must not contain, matched case-insensitively
+	RequireDescription bool     // Groups must have a description
+	MinOwners          int      // Minimum number of owners of groups
+}
+
+// Group is a group checked against the policies, whichever provider it comes from
+type Group struct {
+	Provider    string   // Provider the group comes from, e.g. `okta`, `google`
+	ID          string   // ID of the group at the provider
+	Name        string   // Name checked by the policies
+	Description string   // Description of the group
+	Owners      []string // Owners of the group; nil when they were not looked up
+}
+
+// Violation is a rule of a policy broken by a group
+type Violation struct {
+	Group      *Group
+	Policy     string // Name of the policy
+	Rule       string // Rule broken, e.g. RulePrefix
+	Message    string // Human readable description of the violation
+	Suggestion string // Compliant name to rename the group to, for naming rules; empty when none could be derived
+}
+
+// Applies reports whether the policy applies to a group
+func (p *Policy) Applies(group *Group) (bool, error) {
+	if len(p.Providers) > 0 {
+		found := false
+		for _, provider := range p.Providers {
+			if strings.EqualFold(provider, group.Provider) {
+				found = true
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+
+	if p.Match == "" {
+		return true, nil
+	}
+	match, err := regexp.Compile(p.Match)
+	if err != nil {
+		return false, fmt.Errorf("policy %s: invalid match: %w", p.Name, err)
+	}
+	return match.MatchString(group.Name), nil
+}
+
+// Check returns the violations of a group against the policy, if it applies to the group
+func (p *Policy) Check(group *Group) ([]*Violation, error) {
+	applies, err := p.Applies(group)
+	if err != nil || !applies {
+		return nil, err
+	}
+
+	broken, err := p.nameRules(group.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := []*Violation{}
+	if len(broken) > 0 {
+		suggestion := p.Suggest(group.Name)
+		for _, rule := range broken {
+			violations = append(violations, &Violation{Group: group, Policy: p.Name, Rule: rule.rule, Message: rule.message, Suggestion: suggestion})
+		}
+	}
+
+	if p.RequireDescription && strings.TrimSpace(group.Description) == "" {
+		violations = append(violations, &Violation{Group: group, Policy: p.Name, Rule: RuleDescription, Message: "has no description"})
+	}
+	if p.MinOwners > 0 && group.Owners != nil && len(group.Owners) < p.MinOwners {
+		violations = append(violations, &Violation{Group: group, Policy: p.Name, Rule: RuleOwners, Message: fmt.Sprintf("has %d owner(s), %d required", len(group.Owners), p.MinOwners)})
+	}
+
+	return violations, nil
+}
+
+/*
+ * Suggest derives a compliant name from a non-compliant one: lowercased (when required), with spaces and underscores
+ * replaced by the separator, forbidden words removed, an allowed prefix added and the length capped
+ * Returns an empty string when the derived name still breaks a naming rule (e.g. the pattern)
+ */
+func (p *Policy) Suggest(name string) string {
+	separator := p.Separator
+	if separator == "" {
+		separator = "-"
+	}
+
+	suggestion := strings.TrimSpace(name)
+	if p.Lowercase {
+		suggestion = strings.ToLower(suggestion)
+	}
+	for _, word := range p.Forbidden {
+		suggestion = regexp.MustCompile(`(?i)`+regexp.QuoteMeta(word)).ReplaceAllString(suggestion, "")
+	}
+
+	// Collapse whitespace, underscores and repeated separators into single separators
+	suggestion = strings.Join(strings.FieldsFunc(suggestion, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '_' || string(r) == separator
+	}), separator)
+
+	if len(p.Prefixes) > 0 && !hasPrefix(suggestion, p.Prefixes) {
+		suggestion = p.Prefixes[0] + strings.TrimPrefix(suggestion, separator)
+	}
+	if p.MaxLength > 0 && len(suggestion) > p.MaxLength {
+		suggestion = strings.TrimRight(suggestion[:p.MaxLength], separator)
+	}
+
+	if broken, err := p.nameRules(suggestion); err != nil || len(broken) > 0 || suggestion == name {
+		return ""
+	}
+	return suggestion
+}
+
+// Check returns the violations of every group against every policy
+func Check(policies []*Policy, groups []*Group) ([]*Violation, error) {
+	violations := []*Violation{}
+	for _, group := range groups {
+		for _, policy := range policies {
+			found, err := policy.Check(group)
+			if err != nil {
+				return nil, err
+			}
+			violations = append(violations, found...)
+		}
+	}
+
+	return violations, nil
+}
+
+type brokenRule struct {
+	rule    string
+	message string
+}
+
+// nameRules returns the naming rules broken by a name
+func (p *Policy) nameRules(name string) ([]brokenRule, error) {
+	broken := []brokenRule{}
+
+	if p.Pattern != "" {
+		pattern, err := regexp.Compile(p.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("policy %s: invalid pattern: %w", p.Name, err)
+		}
+		if !pattern.MatchString(name) {
+			broken = append(broken, brokenRule{RulePattern, fmt.Sprintf("does not match %s", p.Pattern)})
+		}
+	}
+	if len(p.Prefixes) > 0 && !hasPrefix(name, p.Prefixes) {
+		broken = append(broken, brokenRule{RulePrefix, fmt.Sprintf("does not start with %s", strings.Join(p.Prefixes, ", "))})
+	}
+	if p.MaxLength > 0 && len(name) > p.MaxLength {
+		broken = append(broken, brokenRule{RuleLength, fmt.Sprintf("is %d characters long, %d allowed", len(name), p.MaxLength)})
+	}
+	if p.Lowercase && name != strings.ToLower(name) {
+		broken = append(broken, brokenRule{RuleLowercase, "is not lowercase"})
+	}
+	for _, word := range p.Forbidden {
+		if strings.Contains(strings.ToLower(name), strings.ToLower(word)) {
+			broken = append(broken, brokenRule{RuleForbidden, fmt.Sprintf("contains %q", word)})
+		}
+	}
+
+	return broken, nil
+}
+
+func hasPrefix(name string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+// pkg/common/log/context.go
+package log
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Keys of the fields set by WithRunID and WithStep
+const (
+	RunKey  = "run"
+	StepKey = "step"
+)
+
+// Field is a key/value pair attached to a context, and written on every log line of a Logger bound to that context
+type Field struct {
+	Key   string
+	Value string
+}
+
+type fieldsKey struct{}
+
+/*
+ * # log.WithField
+ * - returns a copy of ctx carrying the field; a field already set under the same key is replaced
+ */
+func WithField(ctx context.Context, key, value string) context.Context {
+	current := Fields(ctx)
+
+	fields := make([]Field, 0, len(current)+1)
+	replaced := false
+	for _, field := range current {
+		if field.Key == key {
+			field.Value = value
+			replaced = true
+		}
+		fields = append(fields, field)
+	}
+	if !replaced {
+		fields = append(fields, Field{Key: key, Value: value})
+	}
+
+	return context.WithValue(ctx, fieldsKey{}, fields)
+}
+
+/*
+ * # log.WithRunID
+ * - returns a copy of ctx carrying the ID of a run; a new ID is generated when runID is empty:
+ *
+ *	ctx := log.WithRunID(context.Background(), "")
+ *	ctx = log.WithStep(ctx, "offboarding")
+ *	c.WithContext(ctx).Log.Println("Suspending users") // [...] {rego} run=20240501-9f2c1ab4 step=offboarding {...} INFO - ...
+ */
+func WithRunID(ctx context.Context, runID string) context.Context {
+	if runID == "" {
+		runID = NewRunID()
+	}
+	return WithField(ctx, RunKey, runID)
+}
+
+/*
+ * # log.WithStep
+ * - returns a copy of ctx carrying the current step of a workflow, replacing the previous step
+ */
+func WithStep(ctx context.Context, step string) context.Context {
+	return WithField(ctx, StepKey, step)
+}
+
+// RunID returns the run ID carried by ctx, if any
+func RunID(ctx context.Context) string {
+	return FieldValue(ctx, RunKey)
+}
+
+// Step returns the workflow step carried by ctx, if any
+func Step(ctx context.Context) string {
+	return FieldValue(ctx, StepKey)
+}
+
+// FieldValue returns the value of a field carried by ctx, if any
+func FieldValue(ctx context.Context, key string) string {
+	for _, field := range Fields(ctx) {
+		if field.Key == key {
+			return field.Value
+		}
+	}
+	return ""
+}
+
+// Fields returns the fields carried by ctx, in the order they were first set
+func Fields(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsKey{}).([]Field)
+	return fields
+}
+
+/*
+ * # log.ContextString
+ * - formats the fields carried by ctx as `key=value` pairs, e.g. `run=20240501-9f2c1ab4 step=offboarding`
+ * - values containing spaces are quoted, so the pairs stay greppable
+ */
+func ContextString(ctx context.Context) string {
+	fields := Fields(ctx)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(fields))
+	for _, field := range fields {
+		value := field.Value
+		if value == "" || strings.ContainsAny(value, " \t\"=") {
+			value = fmt.Sprintf("%q", value)
+		}
+		pairs = append(pairs, field.Key+"="+value)
+	}
+	return strings.Join(pairs, " ")
+}
+
+// NewRunID returns a new run ID: the date, then random hex, e.g. `20240501-9f2c1ab4`
+func NewRunID() string {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return time.Now().Format("20060102-150405.000000")
+	}
+	return time.Now().Format("20060102") + "-" + hex.EncodeToString(b)
+}
+
+/*
+ * # log.WithContext
+ * - returns a copy of the Logger writing the fields carried by ctx (see WithRunID, WithStep) on every log line
+ * - the copy shares the output of the Logger
+ */
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	if l == nil {
+		return nil
+	}
+
+	clone := *l
+	clone.fields = ContextString(ctx)
+	return &clone
+}
@@ -8,7 +8,10 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/config"
 )
 
 const (
@@ -99,6 +102,8 @@ type Logger struct {
 	logger    *log.Logger    // standard logger
 	out       io.WriteCloser // destination for output
 	Verbosity int            // log level {TRACE, DEBUG, INFO, WARNING, ERROR, FATAL, PANIC}
+	fields    string         // `key=value` pairs of the bound context, see WithContext
+	sampler   *sampler       // Sampling and burst limit of TRACE, DEBUG and INFO lines, see Every and Limit
 }
 
 /*
@@ -107,6 +112,13 @@ type Logger struct {
 */
 func (l *Logger) logf(level int, format string, v ...interface{}) {
 	if level >= l.Verbosity {
+		ok, suppressed := l.sampler.allow(level)
+		if !ok {
+			return
+		}
+		if suppressed > 0 {
+			format += " " + suppressedNote(suppressed)
+		}
 		l.logger.SetPrefix(l.getPrefix(level))
 		l.logger.Printf(format, v...)
 	}
@@ -118,6 +130,13 @@ func (l *Logger) logf(level int, format string, v ...interface{}) {
  */
 func (l *Logger) log(level int, v ...interface{}) {
 	if level >= l.Verbosity {
+		ok, suppressed := l.sampler.allow(level)
+		if !ok {
+			return
+		}
+		if suppressed > 0 {
+			v = append(v, suppressedNote(suppressed))
+		}
 		l.logger.SetPrefix(l.getPrefix(level))
 		l.logger.Println(v...)
 	}
@@ -299,7 +318,12 @@ func (l *Logger) getPrefix(level int) string {
 		Python Format
 		[%s] {%s:%d} %s - ", timestamp, file, line, LogLevel(level))
 	*/
-	return fmt.Sprintf("[%s] %s {%s:%s} %s - ", timestamp, l.prefix, file, lineColor, LogLevel(level, l.Color))
+	prefix := l.prefix
+	if l.fields != "" {
+		prefix += " " + l.fields
+	}
+
+	return fmt.Sprintf("[%s] %s {%s:%s} %s - ", timestamp, prefix, file, lineColor, LogLevel(level, l.Color))
 
 }
 
@@ -326,6 +350,13 @@ func (l *Logger) SetNewFile(logFilePath string) {
  */
 func NewLogger(prefix string, verbosity int) *Logger {
 	LOG_FILE := "./rego.log"
+
+	// Tenant clients log to their own file, prefixed with the tenant, e.g. {okta:acme}
+	tenant := config.Tenant()
+	if tenant != "" {
+		LOG_FILE = fmt.Sprintf("./rego_%s.log", tenant)
+		prefix = strings.TrimSuffix(prefix, "}") + ":" + tenant + "}"
+	}
 	logFile, err := os.OpenFile(LOG_FILE, os.O_APPEND|os.O_RDWR|os.O_CREATE, 0644)
 	if err != nil {
 		log.Panic(err)
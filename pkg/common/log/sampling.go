@@ -0,0 +1,100 @@
+// pkg/common/log/sampling.go
+package log
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// sampler thins out the TRACE, DEBUG and INFO lines of a Logger; warnings and errors are always written
+type sampler struct {
+	mutex       sync.Mutex
+	every       int           // Write 1 line in `every`; every line when 0 or 1
+	burst       int           // Lines written per interval at most; unlimited when 0
+	interval    time.Duration // Window of the burst limit
+	count       uint64        // Lines seen, for sampling
+	windowStart time.Time     // Start of the current burst window
+	written     int           // Lines written in the current burst window
+	suppressed  int           // Lines dropped by the burst limit since the last written line
+}
+
+/*
+ * # log.Every
+ * - returns a copy of the Logger writing 1 in n TRACE, DEBUG and INFO lines, e.g. for per-item lines of bulk loops:
+ *
+ *	sampled := c.Log.Every(1000)
+ *	for i, user := range users {
+ *		sampled.Debugf("Processed %d/%d: %s", i+1, len(users), user.Email)
+ *	}
+ *
+ * - the first line is always written; warnings and errors are never sampled
+ * - the copy keeps the burst limit of the Logger, if any
+ */
+func (l *Logger) Every(n int) *Logger {
+	clone := *l
+	clone.sampler = l.newSampler()
+	clone.sampler.every = n
+	return &clone
+}
+
+/*
+ * # log.Limit
+ * - returns a copy of the Logger writing at most `burst` TRACE, DEBUG and INFO lines per interval
+ * - the number of lines dropped is appended to the next line written, e.g. `(1520 lines suppressed)`
+ * - the copy keeps the sampling of the Logger, if any
+ */
+func (l *Logger) Limit(burst int, interval time.Duration) *Logger {
+	clone := *l
+	clone.sampler = l.newSampler()
+	clone.sampler.burst = burst
+	clone.sampler.interval = interval
+	return &clone
+}
+
+// newSampler returns a sampler with the settings (but not the counters) of the Logger's sampler
+func (l *Logger) newSampler() *sampler {
+	s := &sampler{}
+	if l.sampler != nil {
+		s.every = l.sampler.every
+		s.burst = l.sampler.burst
+		s.interval = l.sampler.interval
+	}
+	return s
+}
+
+// allow reports whether a line is written, and the number of lines suppressed by the burst limit before it
+func (s *sampler) allow(level int) (bool, int) {
+	if s == nil || level >= WARNING {
+		return true, 0
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.count++
+	if s.every > 1 && (s.count-1)%uint64(s.every) != 0 {
+		return false, 0
+	}
+
+	if s.burst > 0 {
+		now := time.Now()
+		if now.Sub(s.windowStart) >= s.interval {
+			s.windowStart, s.written = now, 0
+		}
+		if s.written >= s.burst {
+			s.suppressed++
+			return false, 0
+		}
+		s.written++
+	}
+
+	suppressed := s.suppressed
+	s.suppressed = 0
+	return true, suppressed
+}
+
+// suppressedNote is appended to the first line written after the burst limit dropped lines
+func suppressedNote(suppressed int) string {
+	return fmt.Sprintf("(%d lines suppressed)", suppressed)
+}
@@ -0,0 +1,22 @@
+// pkg/common/notify/chat.go
+package notify
+
+import (
+	"fmt"
+)
+
+// GoogleChatNotifier posts messages to a Google Chat space through an incoming webhook
+// https://developers.google.com/workspace/chat/quickstart/webhooks
+type GoogleChatNotifier struct {
+	WebhookURL string
+}
+
+func (n *GoogleChatNotifier) Name() string {
+	return "google-chat"
+}
+
+func (n *GoogleChatNotifier) Notify(m *Message) error {
+	return postJSON(n.WebhookURL, map[string]string{
+		"text": fmt.Sprintf("*%s* (%s)\n```%s```", m.Title, m.Severity, m.Text()),
+	})
+}
@@ -0,0 +1,75 @@
+// pkg/common/notify/email.go
+package notify
+
+import (
+	"fmt"
+	"net/smtp"
+	"strings"
+
+	"github.com/gemini-oss/rego/pkg/common/config"
+	"github.com/gemini-oss/rego/pkg/common/templates"
+)
+
+// EmailNotifier sends plain text email through an SMTP relay (e.g. smtp.gmail.com with an app password)
+type EmailNotifier struct {
+	Host     string
+	Port     string
+	From     string
+	Username string
+	Password string
+	To       []string
+	Template string // Template wrapping the message body. Default: email
+	Tenant   string // Tenant whose template override is used, if any
+}
+
+/*
+ * EmailNotifierFromEnv configures an EmailNotifier from the environment
+ * SMTP_HOST, SMTP_PORT (default 587), SMTP_FROM and optionally SMTP_USERNAME/SMTP_PASSWORD
+ */
+func EmailNotifierFromEnv(to ...string) *EmailNotifier {
+	port := config.GetEnv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+
+	return &EmailNotifier{
+		Host:     config.GetEnv("SMTP_HOST"),
+		Port:     port,
+		From:     config.GetEnv("SMTP_FROM"),
+		Username: config.GetEnv("SMTP_USERNAME"),
+		Password: config.GetEnv("SMTP_PASSWORD"),
+		To:       to,
+	}
+}
+
+func (n *EmailNotifier) Name() string {
+	return "email:" + strings.Join(n.To, ",")
+}
+
+func (n *EmailNotifier) Notify(m *Message) error {
+	if n.Host == "" || n.From == "" {
+		return fmt.Errorf("SMTP_HOST and SMTP_FROM must be set to send email")
+	}
+	if len(n.To) == 0 {
+		return fmt.Errorf("no email recipients configured")
+	}
+
+	var auth smtp.Auth
+	if n.Username != "" {
+		auth = smtp.PlainAuth("", n.Username, n.Password, n.Host)
+	}
+
+	name := n.Template
+	if name == "" {
+		name = "email"
+	}
+	body, err := templates.Default().RenderFor(n.Tenant, name, m)
+	if err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s",
+		n.From, strings.Join(n.To, ", "), m.Title, body)
+
+	return smtp.SendMail(fmt.Sprintf("%s:%s", n.Host, n.Port), auth, n.From, n.To, []byte(msg))
+}
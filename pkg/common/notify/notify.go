@@ -0,0 +1,151 @@
+// pkg/common/notify/notify.go
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gemini-oss/rego/pkg/common/templates"
+)
+
+// Severity of a notification; routes only deliver messages at or above their minimum severity
+type Severity int
+
+const (
+	Info Severity = iota
+	Warning
+	Error
+	Critical
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Info:
+		return "info"
+	case Warning:
+		return "warning"
+	case Error:
+		return "error"
+	case Critical:
+		return "critical"
+	default:
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+}
+
+// Message is a channel-agnostic notification
+type Message struct {
+	Severity Severity          // Severity used for routing (and by channels which support it, e.g. PagerDuty)
+	Title    string            // Short summary; used as the email subject and PagerDuty summary
+	Body     string            // Plain text body
+	Fields   map[string]string // Optional structured details
+	DedupKey string            // Optional key used by channels which deduplicate (PagerDuty)
+}
+
+/*
+ * NewMessage renders a message body from an inline text/template
+ * @param severity Severity
+ * @param title string
+ * @param body string (text/template, with the functions of pkg/common/templates)
+ * @param data interface{}
+ */
+func NewMessage(severity Severity, title, body string, data interface{}) (*Message, error) {
+	rendered, err := templates.RenderString(title, body, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		Severity: severity,
+		Title:    title,
+		Body:     rendered,
+	}, nil
+}
+
+/*
+ * FromTemplate renders a message body from a named template of the default library, honoring the tenant's override
+ * @param tenant string (empty for no tenant override)
+ * @param severity Severity
+ * @param title string
+ * @param name string
+ * @param data interface{}
+ */
+func FromTemplate(tenant string, severity Severity, title, name string, data interface{}) (*Message, error) {
+	rendered, err := templates.Default().RenderFor(tenant, name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		Severity: severity,
+		Title:    title,
+		Body:     rendered,
+	}, nil
+}
+
+// Text renders the message as plain text (title, body and fields)
+func (m *Message) Text() string {
+	var b strings.Builder
+	b.WriteString(m.Body)
+	if len(m.Fields) > 0 {
+		b.WriteString("\n")
+		for _, key := range sortedKeys(m.Fields) {
+			fmt.Fprintf(&b, "\n%s: %s", key, m.Fields[key])
+		}
+	}
+	return b.String()
+}
+
+// Notifier delivers a message to a single channel
+type Notifier interface {
+	Name() string
+	Notify(m *Message) error
+}
+
+type route struct {
+	min      Severity
+	notifier Notifier
+}
+
+// Router delivers each message to every notifier whose minimum severity it meets
+type Router struct {
+	routes []route
+	mutex  sync.RWMutex
+}
+
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// Route adds a notifier which receives every message at or above `min`
+func (r *Router) Route(min Severity, n Notifier) *Router {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.routes = append(r.routes, route{min: min, notifier: n})
+	return r
+}
+
+func (r *Router) Name() string {
+	return "router"
+}
+
+// Notify delivers the message to each matching notifier, returning the combined errors of any that failed
+func (r *Router) Notify(m *Message) error {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var errs []error
+	for _, rt := range r.routes {
+		if m.Severity < rt.min {
+			continue
+		}
+		if err := rt.notifier.Notify(m); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", rt.notifier.Name(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,42 @@
+// pkg/common/notify/pagerduty.go
+package notify
+
+var PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue" // https://developer.pagerduty.com/api-reference/368ae3d938c9e-send-an-event-to-pager-duty
+
+// PagerDutyNotifier triggers PagerDuty incidents through the Events API v2
+type PagerDutyNotifier struct {
+	RoutingKey string // Integration key of the service
+	Source     string // Source of the event. Default: rego
+}
+
+func (n *PagerDutyNotifier) Name() string {
+	return "pagerduty"
+}
+
+func (n *PagerDutyNotifier) Notify(m *Message) error {
+	source := n.Source
+	if source == "" {
+		source = "rego"
+	}
+
+	details := map[string]string{"body": m.Body}
+	for key, value := range m.Fields {
+		details[key] = value
+	}
+
+	event := map[string]interface{}{
+		"routing_key":  n.RoutingKey,
+		"event_action": "trigger",
+		"payload": map[string]interface{}{
+			"summary":        m.Title,
+			"source":         source,
+			"severity":       m.Severity.String(),
+			"custom_details": details,
+		},
+	}
+	if m.DedupKey != "" {
+		event["dedup_key"] = m.DedupKey
+	}
+
+	return postJSON(PagerDutyEventsURL, event)
+}
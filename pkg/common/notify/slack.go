@@ -0,0 +1,36 @@
+// pkg/common/notify/slack.go
+package notify
+
+import (
+	"fmt"
+
+	"github.com/gemini-oss/rego/pkg/slack"
+)
+
+// SlackNotifier posts messages to a Slack channel through the bot client
+type SlackNotifier struct {
+	Client  *slack.Client
+	Channel string
+}
+
+func (n *SlackNotifier) Name() string {
+	return "slack:" + n.Channel
+}
+
+func (n *SlackNotifier) Notify(m *Message) error {
+	if n.Client == nil {
+		return fmt.Errorf("no slack client configured for channel %s", n.Channel)
+	}
+
+	return n.Client.SendMessage(nil, &slack.SlackMessage{
+		Channel: n.Channel,
+		Text:    fmt.Sprintf("%s *%s*\n```%s```", severityEmoji[m.Severity], m.Title, m.Text()),
+	})
+}
+
+var severityEmoji = map[Severity]string{
+	Info:     ":information_source:",
+	Warning:  ":warning:",
+	Error:    ":x:",
+	Critical: ":rotating_light:",
+}
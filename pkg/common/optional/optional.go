@@ -0,0 +1,40 @@
+// pkg/common/optional/optional.go
+package optional
+
+/*
+ * Optional fields of the mutation structs (the bodies of PATCH requests and partial updates) are pointers with
+ * `omitempty`, so callers can tell "unset" from the zero value:
+ *
+ *	restrictions := &google.SharedDriveRestrictions{DomainUsersOnly: optional.Of(false)} // sends {"domainUsersOnly": false}
+ *	restrictions := &google.SharedDriveRestrictions{}                                   // sends {}, leaving every restriction as is
+ *
+ * A nil field is left out of the request and left unchanged by the API; a set field is always sent, even when false,
+ * 0 or "". Read the fields of responses with Value or Or
+ */
+
+// Of returns a pointer to v, to set an optional field, e.g. `optional.Of(false)`
+func Of[T any](v T) *T {
+	return &v
+}
+
+// Value returns the value of an optional field, or the zero value when it is unset
+func Value[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
+
+// Or returns the value of an optional field, or fallback when it is unset
+func Or[T any](p *T, fallback T) T {
+	if p == nil {
+		return fallback
+	}
+	return *p
+}
+
+// IsSet reports whether an optional field is set
+func IsSet[T any](p *T) bool {
+	return p != nil
+}
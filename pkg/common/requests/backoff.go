@@ -0,0 +1,94 @@
+// pkg/common/requests/backoff.go
+package requests
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/retry"
+)
+
+// MaxRetryAfter caps the wait honored from a Retry-After header, so a misbehaving server cannot stall a job indefinitely
+const MaxRetryAfter = 5 * time.Minute
+
+// StatusError is returned for responses with an unsuccessful status code
+type StatusError struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	RetryAfter time.Duration // Wait requested by the server with a Retry-After header, if any
+	msg        string
+}
+
+func (e *StatusError) Error() string {
+	return e.msg
+}
+
+func newStatusError(resp *http.Response, body []byte, msg string) *StatusError {
+	return &StatusError{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+		RetryAfter: ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		msg:        msg,
+	}
+}
+
+// StatusCode returns the status code of a failed request, or 0 if the error did not come from a response
+func StatusCode(err error) int {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode
+	}
+	return 0
+}
+
+/*
+ * ParseRetryAfter parses a Retry-After header, in seconds or as an HTTP date, into a wait capped at MaxRetryAfter
+ * - https://www.rfc-editor.org/rfc/rfc9110#field.retry-after
+ */
+func ParseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	var wait time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		wait = date.Sub(now)
+	}
+
+	switch {
+	case wait < 0:
+		return 0
+	case wait > MaxRetryAfter:
+		return MaxRetryAfter
+	}
+	return wait
+}
+
+// DefaultBackoff honors a Retry-After header when the server sends one, and otherwise backs off exponentially with jitter.
+// Responses over the maximum size (ErrResponseTooLarge) are not retried, as they would only be too large again.
+func DefaultBackoff(attempt int, err error) (time.Duration, bool) {
+	if errors.Is(err, ErrResponseTooLarge) {
+		return 0, false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+		return statusErr.RetryAfter, true
+	}
+	return retry.BackoffWithJitter(attempt), true
+}
+
+// WithBackoff replaces DefaultBackoff, e.g. to wait out provider maintenance windows or to stop retrying some errors
+func WithBackoff(backoff retry.Backoff) Option {
+	return func(c *Client) {
+		c.backoff = backoff
+	}
+}
@@ -0,0 +1,90 @@
+// pkg/common/requests/compression.go
+package requests
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	Gzip    = "gzip"    // RFC-1952 (https://www.rfc-editor.org/rfc/rfc1952.html)
+	Deflate = "deflate" // RFC-1951 (https://www.rfc-editor.org/rfc/rfc1951.html)
+)
+
+// AcceptEncoding lists the response encodings the client can decode
+var AcceptEncoding = strings.Join([]string{Gzip, Deflate}, ", ")
+
+/*
+ * CompressPayload gzips the request body, if any, and sets the Content-Encoding
+ * @param req *http.Request
+ * @return error
+ */
+func CompressPayload(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+
+	payload, err := io.ReadAll(req.Body)
+	if err != nil {
+		return fmt.Errorf("reading request body: %w", err)
+	}
+	req.Body.Close()
+
+	var buffer bytes.Buffer
+	gz := gzip.NewWriter(&buffer)
+	if _, err := gz.Write(payload); err != nil {
+		gz.Close()
+		return fmt.Errorf("compressing request body: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("compressing request body: %w", err)
+	}
+
+	req.Body = io.NopCloser(&buffer)
+	req.ContentLength = int64(buffer.Len())
+	req.Header.Set("Content-Encoding", Gzip)
+	return nil
+}
+
+/*
+ * DecompressBody returns a reader decoding the response body according to its Content-Encoding
+ * @param resp *http.Response
+ * @return io.ReadCloser
+ */
+func DecompressBody(resp *http.Response) (io.ReadCloser, error) {
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "", "identity":
+		return resp.Body, nil
+	case Gzip, "x-gzip":
+		gz, err := gzip.NewReader(resp.Body)
+		if err == io.EOF {
+			// Empty bodies (e.g. 204 No Content) may still carry the header
+			return io.NopCloser(strings.NewReader("")), nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decoding gzip response: %w", err)
+		}
+		return gz, nil
+	case Deflate:
+		// HTTP `deflate` is zlib-wrapped (RFC-1950), though some servers send a raw deflate stream
+		br := bufio.NewReader(resp.Body)
+		header, err := br.Peek(2)
+		if err == nil && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+			zr, err := zlib.NewReader(br)
+			if err != nil {
+				return nil, fmt.Errorf("decoding deflate response: %w", err)
+			}
+			return zr, nil
+		}
+		return flate.NewReader(br), nil
+	default:
+		return nil, fmt.Errorf("unsupported response encoding: %s", resp.Header.Get("Content-Encoding"))
+	}
+}
@@ -0,0 +1,66 @@
+// pkg/common/requests/context.go
+package requests
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+/*
+ * WithContext returns a copy of the client whose requests (and the waits between their retries) end when ctx is done
+ * Its log lines, and the audit record of each request, carry the fields of ctx (see log.WithRunID and log.WithStep)
+ * The copy shares the rate limiter, cache and learned limits of the client:
+ *
+ *	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+ *	defer cancel()
+ *	res, body, err := client.WithContext(ctx).DoRequest("GET", url, nil, nil)
+ */
+func (c *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		panic("requests: nil context")
+	}
+
+	clone := *c
+	clone.ctx = ctx
+	clone.Log = c.Log.WithContext(ctx)
+	return &clone
+}
+
+/*
+ * WithHTTPClient returns a copy of the client sending its requests with another *http.Client, e.g. one authorized as
+ * another user; the copy keeps the context, options, rate limiter and cache of the client
+ */
+func (c *Client) WithHTTPClient(httpClient *http.Client) *Client {
+	clone := *c
+	clone.httpClient = httpClient
+	return &clone
+}
+
+// Context returns the context of the client's requests
+func (c *Client) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// DoRequestContext is DoRequest bound to ctx
+func (c *Client) DoRequestContext(ctx context.Context, method string, url string, query interface{}, data interface{}) (*http.Response, []byte, error) {
+	return c.WithContext(ctx).DoRequest(method, url, query, data)
+}
+
+// contextTime sleeps between retries until the wait is over or the context is done
+type contextTime struct {
+	ctx context.Context
+}
+
+func (t contextTime) Sleep(d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-t.ctx.Done():
+	}
+}
@@ -0,0 +1,96 @@
+// pkg/common/requests/limits.go
+package requests
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ErrResponseTooLarge is returned (wrapped) when a response exceeds the client's maximum size
+var ErrResponseTooLarge = errors.New("response exceeds the maximum size")
+
+// DefaultResponseWarnSize is the (decoded) size above which a single response is logged as a warning
+const DefaultResponseWarnSize = 50 << 20 // 50 MiB
+
+// sizeLimits bound the memory a single response may use
+type sizeLimits struct {
+	max  int64 // Responses larger than this fail with ErrResponseTooLarge. 0 means no limit.
+	warn int64 // Responses larger than this are logged as a warning. 0 means DefaultResponseWarnSize.
+}
+
+// WithMaxResponseSize fails requests whose (decoded) response body exceeds `bytes`, instead of reading it into memory. 0 means no limit.
+func WithMaxResponseSize(bytes int64) Option {
+	return func(c *Client) {
+		c.sizeLimits.max = bytes
+	}
+}
+
+// WithResponseSizeWarning logs a warning for responses larger than `bytes`, e.g. pages that are too large for the deployment
+func WithResponseSizeWarning(bytes int64) Option {
+	return func(c *Client) {
+		c.sizeLimits.warn = bytes
+	}
+}
+
+/*
+ * ParseSize parses a human readable size, e.g. "512MB", "1GiB", "1048576"
+ * Decimal (KB, MB, GB) and binary (KiB, MiB, GiB) units are both accepted
+ */
+func ParseSize(s string) (int64, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	units := []struct {
+		suffix     string
+		multiplier int64
+	}{
+		{"KIB", 1 << 10}, {"MIB", 1 << 20}, {"GIB", 1 << 30},
+		{"KB", 1000}, {"MB", 1000 * 1000}, {"GB", 1000 * 1000 * 1000},
+		{"B", 1},
+	}
+
+	for _, unit := range units {
+		if number, found := strings.CutSuffix(s, unit.suffix); found {
+			n, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid size %q: %w", s, err)
+			}
+			return int64(n * float64(unit.multiplier)), nil
+		}
+	}
+
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// readBody reads a response body within the client's size limits
+func (c *Client) readBody(req *http.Request, resp *http.Response, reader io.Reader) ([]byte, error) {
+	max := c.sizeLimits.max
+	if max > 0 && resp.ContentLength > max && resp.Header.Get("Content-Encoding") == "" {
+		return nil, fmt.Errorf("%s %s: %d bytes: %w (%d bytes)", req.Method, req.URL.Path, resp.ContentLength, ErrResponseTooLarge, max)
+	}
+
+	if max > 0 {
+		reader = io.LimitReader(reader, max+1)
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if max > 0 && int64(len(body)) > max {
+		return nil, fmt.Errorf("%s %s: truncated at %d bytes: %w (%d bytes)", req.Method, req.URL.Path, max, ErrResponseTooLarge, max)
+	}
+
+	warn := c.sizeLimits.warn
+	if warn == 0 {
+		warn = DefaultResponseWarnSize
+	}
+	if int64(len(body)) > warn {
+		c.Log.Warningf("%s %s returned %d bytes (warning threshold %d); consider a smaller page size", req.Method, req.URL.Path, len(body), warn)
+	}
+
+	return body, nil
+}
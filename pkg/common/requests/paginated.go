@@ -0,0 +1,230 @@
+// pkg/common/requests/paginated.go
+package requests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/gemini-oss/rego/pkg/common/schema"
+	ss "github.com/gemini-oss/rego/pkg/common/starstruct"
+)
+
+// Matches the next page of a Link header, e.g. `<https://example.okta.com/api/v1/users?after=abc>; rel="next"`
+var nextLinkPattern = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// PageRequest is the request of the next page, updated by a PageStrategy after every page
+type PageRequest struct {
+	URL    string
+	Params map[string]interface{} // Query parameters, on top of the query given to PaginatedDo
+}
+
+// PageStrategy describes how an API moves from one page to the next
+type PageStrategy interface {
+	// First sets the parameters of the first page
+	First(req *PageRequest, pageSize int)
+	// Next reads a page (of `items` items) and updates the request for the next one; false when it was the last page
+	Next(req *PageRequest, res *http.Response, body []byte, items int) (bool, error)
+}
+
+// Pagination configures PaginatedDo
+type Pagination[T any] struct {
+	Strategy PageStrategy                   // How pages are requested; defaults to TokenPages{}
+	Items    func(body []byte) ([]T, error) // Reads the items of a page; defaults to decoding the body as a list (see schema.Unmarshal)
+	PageSize int                            // Items requested per page; the provider's default when 0
+	MaxPages int                            // Pages read at most; every page when 0
+	OnPage   func(page int, items []T) bool // Called with every page (numbered from 1); returning false stops early
+	Discard  bool                           // Pages are only handed to OnPage and not returned, to stream more items than fit in memory
+}
+
+/*
+ * PaginatedDo performs a request and every request of its following pages, returning the items of every page
+ * @param c *Client
+ * @param method string
+ * @param url string
+ * @param query interface{} struct (with `url` tags) or map of parameters sent with every page
+ * @param p Pagination[T]
+ */
+func PaginatedDo[T any](c *Client, method string, url string, query interface{}, p Pagination[T]) ([]T, error) {
+	if p.Strategy == nil {
+		p.Strategy = TokenPages{}
+	}
+	if p.Items == nil {
+		p.Items = func(body []byte) ([]T, error) {
+			var items []T
+			err := schema.Unmarshal(body, &items)
+			return items, err
+		}
+	}
+
+	base := map[string]interface{}{}
+	if query != nil {
+		params, err := ss.ToMap(query, false)
+		if err != nil {
+			return nil, fmt.Errorf("reading query: %w", err)
+		}
+		base = params
+	}
+
+	req := &PageRequest{URL: url, Params: map[string]interface{}{}}
+	p.Strategy.First(req, p.PageSize)
+
+	var all []T
+	for page := 1; ; page++ {
+		params := map[string]interface{}{}
+		if req.URL == url {
+			for k, v := range base {
+				params[k] = v
+			}
+		}
+		for k, v := range req.Params {
+			params[k] = v
+		}
+
+		res, body, err := c.DoRequest(method, req.URL, params, nil)
+		if err != nil {
+			return all, fmt.Errorf("page %d: %w", page, err)
+		}
+
+		items, err := p.Items(body)
+		if err != nil {
+			return all, fmt.Errorf("unmarshalling page %d: %w", page, err)
+		}
+		if !p.Discard {
+			all = append(all, items...)
+		}
+
+		if p.OnPage != nil && !p.OnPage(page, items) {
+			return all, nil
+		}
+		if p.MaxPages > 0 && page >= p.MaxPages {
+			c.Log.Debugf("Stopping %s after %d pages", url, page)
+			return all, nil
+		}
+
+		more, err := p.Strategy.Next(req, res, body, len(items))
+		if err != nil {
+			return all, fmt.Errorf("page %d: %w", page, err)
+		}
+		if !more {
+			return all, nil
+		}
+	}
+}
+
+/*
+ * ItemsField reads the items of a page from a field of a JSON object, e.g. `users` of a Google response
+ */
+func ItemsField[T any](field string) func(body []byte) ([]T, error) {
+	return func(body []byte) ([]T, error) {
+		var page map[string]json.RawMessage
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, err
+		}
+
+		var items []T
+		if raw, ok := page[field]; ok {
+			if err := schema.Unmarshal(raw, &items); err != nil {
+				return nil, fmt.Errorf("%s: %w", field, err)
+			}
+		}
+		return items, nil
+	}
+}
+
+// TokenPages follows a token returned in the body of every page, e.g. Google's `nextPageToken`
+type TokenPages struct {
+	TokenParam string // Query parameter of the token; defaults to `pageToken`
+	TokenField string // Field of the response holding the next token; defaults to `nextPageToken`
+	SizeParam  string // Query parameter of the page size; defaults to `maxResults`
+}
+
+func (s TokenPages) First(req *PageRequest, pageSize int) {
+	if pageSize > 0 {
+		req.Params[defaultString(s.SizeParam, "maxResults")] = pageSize
+	}
+}
+
+func (s TokenPages) Next(req *PageRequest, res *http.Response, body []byte, items int) (bool, error) {
+	var page map[string]json.RawMessage
+	if err := json.Unmarshal(body, &page); err != nil {
+		return false, err
+	}
+
+	var token string
+	if raw, ok := page[defaultString(s.TokenField, "nextPageToken")]; ok {
+		if err := json.Unmarshal(raw, &token); err != nil {
+			return false, err
+		}
+	}
+	if token == "" {
+		return false, nil
+	}
+
+	req.Params[defaultString(s.TokenParam, "pageToken")] = token
+	return true, nil
+}
+
+// OffsetPages moves an offset (or page number) forward until a page comes back short, e.g. Snipe-IT's `offset`
+type OffsetPages struct {
+	OffsetParam string // Query parameter of the offset; defaults to `offset`
+	SizeParam   string // Query parameter of the page size; defaults to `limit`
+	PageNumbers bool   // The offset counts pages (from 1) instead of items
+}
+
+func (s OffsetPages) First(req *PageRequest, pageSize int) {
+	if pageSize > 0 {
+		req.Params[defaultString(s.SizeParam, "limit")] = pageSize
+	}
+	if s.PageNumbers {
+		req.Params[defaultString(s.OffsetParam, "offset")] = 1
+	} else {
+		req.Params[defaultString(s.OffsetParam, "offset")] = 0
+	}
+}
+
+func (s OffsetPages) Next(req *PageRequest, res *http.Response, body []byte, items int) (bool, error) {
+	pageSize, _ := req.Params[defaultString(s.SizeParam, "limit")].(int)
+	if items == 0 || (pageSize > 0 && items < pageSize) {
+		return false, nil
+	}
+
+	param := defaultString(s.OffsetParam, "offset")
+	offset, _ := req.Params[param].(int)
+	if s.PageNumbers {
+		req.Params[param] = offset + 1
+	} else {
+		req.Params[param] = offset + items
+	}
+	return true, nil
+}
+
+// LinkPages follows `rel="next"` Link headers, e.g. Okta's cursor pagination
+type LinkPages struct {
+	SizeParam string // Query parameter of the page size; defaults to `limit`
+}
+
+func (s LinkPages) First(req *PageRequest, pageSize int) {
+	if pageSize > 0 {
+		req.Params[defaultString(s.SizeParam, "limit")] = pageSize
+	}
+}
+
+func (s LinkPages) Next(req *PageRequest, res *http.Response, body []byte, items int) (bool, error) {
+	for _, link := range res.Header.Values("Link") {
+		if m := nextLinkPattern.FindStringSubmatch(link); m != nil {
+			// The next link carries every parameter of the page
+			req.URL, req.Params = m[1], map[string]interface{}{}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func defaultString(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,126 @@
+// pkg/common/requests/pagination.go
+package requests
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PageSizeParams are the query parameters providers use to request a page size
+var PageSizeParams = []string{"limit", "pageSize", "page-size", "page_size", "per_page", "maxResults", "count"}
+
+// Matches the maximum a provider reports in a 400 response, e.g. "limit must be less than or equal to 200"
+var pageLimitPattern = regexp.MustCompile(`(?i)(?:maximum(?: value)?(?: of| is)?|max(?: value)?(?: of| is)?|less than or equal to|cannot exceed|must not exceed|no (?:more|greater) than|at most|up to|<=|between \d+ and)\s*:?\s*(\d+)`)
+
+// pageLimits tracks the maximum page size learned for each endpoint
+type pageLimits struct {
+	limits map[string]int
+	mutex  sync.RWMutex
+}
+
+func pageLimitKey(req *http.Request) string {
+	return req.URL.Host + req.URL.Path
+}
+
+/*
+ * SetPageLimit records the maximum page size of an endpoint, e.g. from first-response metadata
+ * Requests to the endpoint are clamped to this size from then on
+ * @param url string
+ * @param limit int
+ */
+func (c *Client) SetPageLimit(url string, limit int) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil || limit <= 0 {
+		return
+	}
+	c.setPageLimit(pageLimitKey(req), limit)
+}
+
+// PageLimit returns the learned maximum page size of an endpoint, if any
+func (c *Client) PageLimit(url string) (int, bool) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return 0, false
+	}
+
+	c.pageLimits.mutex.RLock()
+	defer c.pageLimits.mutex.RUnlock()
+
+	limit, ok := c.pageLimits.limits[pageLimitKey(req)]
+	return limit, ok
+}
+
+func (c *Client) setPageLimit(key string, limit int) {
+	c.pageLimits.mutex.Lock()
+	defer c.pageLimits.mutex.Unlock()
+
+	if c.pageLimits.limits == nil {
+		c.pageLimits.limits = make(map[string]int)
+	}
+	c.pageLimits.limits[key] = limit
+}
+
+// clampPageSize lowers any page size parameter above the endpoint's learned maximum
+func (c *Client) clampPageSize(req *http.Request) {
+	c.pageLimits.mutex.RLock()
+	limit, ok := c.pageLimits.limits[pageLimitKey(req)]
+	c.pageLimits.mutex.RUnlock()
+	if !ok {
+		return
+	}
+
+	q := req.URL.Query()
+	clamped := false
+	for _, param := range PageSizeParams {
+		size, err := strconv.Atoi(q.Get(param))
+		if err != nil || size <= limit {
+			continue
+		}
+		c.Log.Debugf("Clamping %s from %d to %d for %s", param, size, limit, req.URL.Path)
+		q.Set(param, strconv.Itoa(limit))
+		clamped = true
+	}
+
+	if clamped {
+		req.URL.RawQuery = q.Encode()
+	}
+}
+
+/*
+ * learnPageLimit inspects a 400 response for a page size maximum
+ * Returns true when a page size parameter of the request exceeded it, meaning the request can be retried clamped
+ */
+func (c *Client) learnPageLimit(req *http.Request, body []byte) bool {
+	message := strings.ToLower(string(body))
+	if !strings.Contains(message, "page") && !strings.Contains(message, "limit") && !strings.Contains(message, "results") {
+		return false
+	}
+
+	q := req.URL.Query()
+	requested := 0
+	for _, param := range PageSizeParams {
+		if size, err := strconv.Atoi(q.Get(param)); err == nil && size > requested {
+			requested = size
+		}
+	}
+	if requested == 0 {
+		return false
+	}
+
+	match := pageLimitPattern.FindSubmatch(body)
+	if match == nil {
+		return false
+	}
+
+	limit, err := strconv.Atoi(string(match[1]))
+	if err != nil || limit <= 0 || limit >= requested {
+		return false
+	}
+
+	c.Log.Warningf("%s rejected a page size of %d; using the reported maximum of %d", req.URL.Path, requested, limit)
+	c.setPageLimit(pageLimitKey(req), limit)
+	return true
+}
@@ -2,6 +2,8 @@
 package requests
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
@@ -9,6 +11,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/gemini-oss/rego/pkg/common/cache"
 	"github.com/gemini-oss/rego/pkg/common/config"
@@ -16,6 +19,7 @@ import (
 	rl "github.com/gemini-oss/rego/pkg/common/ratelimit"
 	"github.com/gemini-oss/rego/pkg/common/retry"
 	ss "github.com/gemini-oss/rego/pkg/common/starstruct"
+	"github.com/gemini-oss/rego/pkg/common/validate"
 )
 
 type Headers map[string]string
@@ -56,20 +60,28 @@ var (
  * @param headers Headers
  */
 type Client struct {
-	httpClient  *http.Client
-	BodyType    string
-	Cache       *cache.Cache
-	Headers     Headers
-	Log         *log.Logger
-	RateLimiter *rl.RateLimiter
+	httpClient       *http.Client
+	BodyType         string
+	Cache            *cache.Cache
+	CompressRequests bool // Gzip request bodies; only enable for APIs which accept `Content-Encoding: gzip`
+	Headers          Headers
+	Log              *log.Logger
+	RateLimiter      *rl.RateLimiter
+	pageLimits       *pageLimits     // Learned maximum page sizes per endpoint; shared with WithContext copies
+	timeouts         *timeouts       // Request deadlines, client-wide and per endpoint; shared with WithContext copies
+	sizeLimits       sizeLimits      // Maximum and warning sizes of a response body
+	backoff          retry.Backoff   // Wait between retries; DefaultBackoff when nil
+	tagging          tagging         // User-Agent and X-Request-ID of the requests, see WithUserAgent
+	ctx              context.Context // Context of every request, see WithContext; context.Background() when nil
 }
 
 /*
  * NewClient
  * @param headers Headers
+ * @param opts ...Option
  * @return *Client
  */
-func NewClient(c *http.Client, headers Headers, rateLimiter *rl.RateLimiter) *Client {
+func NewClient(c *http.Client, headers Headers, rateLimiter *rl.RateLimiter, opts ...Option) *Client {
 	encryptionKey := []byte(config.GetEnv("REGO_ENCRYPTION_KEY"))
 	if len(encryptionKey) == 0 {
 		l.Fatal("REGO_ENCRYPTION_KEY is not set")
@@ -80,22 +92,35 @@ func NewClient(c *http.Client, headers Headers, rateLimiter *rl.RateLimiter) *Cl
 		panic(err)
 	}
 
-	if c != nil {
-		return &Client{
-			httpClient:  c,
-			Cache:       cache,
-			Headers:     headers,
-			Log:         l,
-			RateLimiter: rateLimiter,
-		}
+	if c == nil {
+		c = &http.Client{Transport: NewTransport(nil)}
 	}
-	return &Client{
-		httpClient:  &http.Client{},
+
+	client := &Client{
+		httpClient:  c,
 		Cache:       cache,
 		Headers:     headers,
 		Log:         l,
 		RateLimiter: rateLimiter,
+		pageLimits:  &pageLimits{},
+		timeouts:    &timeouts{},
+	}
+
+	// REGO_HTTP_TIMEOUT (e.g. "2m") sets the default deadline of every request
+	if timeout, err := time.ParseDuration(config.GetEnv("REGO_HTTP_TIMEOUT")); err == nil {
+		client.Apply(WithTimeout(timeout))
+	}
+	// REGO_USER_AGENT (e.g. "acme-rego/1.0") identifies the requests in provider audit logs
+	if userAgent := config.GetEnv("REGO_USER_AGENT"); userAgent != "" {
+		client.Apply(WithUserAgent(userAgent))
 	}
+	// REGO_MAX_RESPONSE_SIZE (e.g. "256MB") fails responses larger than the limit instead of exhausting memory
+	if size, err := ParseSize(config.GetEnv("REGO_MAX_RESPONSE_SIZE")); err == nil && size > 0 {
+		client.Apply(WithMaxResponseSize(size))
+	}
+	client.Apply(opts...)
+
+	return client
 }
 
 // UpdateHeaders changes the headers for the HTTP client
@@ -132,7 +157,7 @@ func DecodeJSON(body []byte, result interface{}) error {
 }
 
 func (c *Client) CreateRequest(method string, url string) (*http.Request, error) {
-	req, err := http.NewRequest(method, url, nil)
+	req, err := http.NewRequestWithContext(c.Context(), method, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -141,6 +166,7 @@ func (c *Client) CreateRequest(method string, url string) (*http.Request, error)
 	for key, value := range c.Headers {
 		req.Header.Set(key, value)
 	}
+	c.tag(req)
 
 	return req, nil
 }
@@ -234,18 +260,40 @@ func SetXMLPayload(req *http.Request, data interface{}) error {
 }
 
 func (c *Client) DoRequest(method string, url string, query interface{}, data interface{}) (*http.Response, []byte, error) {
-	realTime := retry.RealTime{}
-	return c.doRetry(method, url, query, data, realTime)
+	// Catch payloads the provider would reject (e.g. a missing required field) before they are sent, and never retry them
+	if err := validate.Struct(data); err != nil {
+		return nil, nil, fmt.Errorf("invalid %s %s request: %w", method, url, err)
+	}
+
+	return c.retrying(contextTime{ctx: c.Context()}, func() (*http.Response, []byte, error) {
+		return c.do(method, url, query, data)
+	})
 }
 
-func (c *Client) doRetry(method string, url string, query interface{}, data interface{}, time retry.Time) (*http.Response, []byte, error) {
+// retrying runs a request until it succeeds, the client's backoff gives up on its error, or the client's context is done
+func (c *Client) retrying(clock retry.Time, attempt func() (*http.Response, []byte, error)) (*http.Response, []byte, error) {
 	var resp *http.Response
 	var body []byte
-	err := retry.Retry(func() error {
+	backoff := c.backoff
+	if backoff == nil {
+		backoff = DefaultBackoff
+	}
+
+	// A cancelled context ends the retries, instead of waiting out the remaining attempts
+	ctx := c.Context()
+	err := retry.RetryWith(func() error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		var reqErr error
-		resp, body, reqErr = c.do(method, url, query, data)
+		resp, body, reqErr = attempt()
 		return reqErr
-	}, time)
+	}, clock, func(attempt int, err error) (time.Duration, bool) {
+		if ctx.Err() != nil {
+			return 0, false
+		}
+		return backoff(attempt, err)
+	})
 
 	return resp, body, err
 }
@@ -266,38 +314,130 @@ func (c *Client) do(method string, url string, query interface{}, data interface
 	}
 
 	SetQueryParams(req, query)
+	c.clampPageSize(req)
 
 	if err := setPayload(req, data, c.BodyType); err != nil {
 		return nil, nil, err
 	}
 
-	resp, err := c.httpClient.Do(req)
+	if c.CompressRequests {
+		if err := CompressPayload(req); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	resp, body, err := c.send(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent, http.StatusPartialContent:
+		return resp, body, nil
+	case http.StatusBadRequest:
+		// Providers may lower their maximum page size; learn it from the error and retry with a clamped page size
+		if c.learnPageLimit(req, body) {
+			return c.do(method, url, query, data)
+		}
+		return nil, body, newStatusError(resp, body, string(body))
+	case http.StatusTooManyRequests:
+		c.Log.Warningf("%s %s was rate limited: %s", method, req.URL.Path, body)
+	default:
+		return nil, body, newStatusError(resp, body, string(body))
+	}
+
+	return nil, body, newStatusError(resp, body, fmt.Sprintf("unexpected status code: %d", resp.StatusCode))
+}
+
+/*
+ * DoBody sends a pre-encoded body (e.g. a multipart/mixed batch) with the client's headers, rate limiting, retries and response decoding
+ * - Like DoRequest, unsuccessful responses are returned as a *StatusError, and retried as the client's backoff decides
+ * @param method string
+ * @param url string
+ * @param contentType string
+ * @param body []byte
+ */
+func (c *Client) DoBody(method string, url string, contentType string, body []byte) (*http.Response, []byte, error) {
+	return c.retrying(contextTime{ctx: c.Context()}, func() (*http.Response, []byte, error) {
+		return c.doBody(method, url, contentType, body)
+	})
+}
+
+func (c *Client) doBody(method string, url string, contentType string, body []byte) (*http.Response, []byte, error) {
+	req, err := c.CreateRequest(method, url)
 	if err != nil {
 		return nil, nil, err
 	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	req.Header.Set("Content-Type", contentType)
+
+	resp, respBody, err := c.send(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent, http.StatusPartialContent:
+		return resp, respBody, nil
+	case http.StatusTooManyRequests:
+		c.Log.Warningf("%s %s was rate limited: %s", method, req.URL.Path, respBody)
+	default:
+		return nil, respBody, newStatusError(resp, respBody, string(respBody))
+	}
+
+	return nil, respBody, newStatusError(resp, respBody, fmt.Sprintf("unexpected status code: %d", resp.StatusCode))
+}
+
+/*
+ * Send executes a prepared request (see CreateRequest) with the client's rate limiting, deadlines and response decoding
+ * Unlike DoRequest and DoBody, every status is returned as is, for protocols which rely on e.g. `308 Resume Incomplete`
+ * @param req *http.Request
+ */
+func (c *Client) Send(req *http.Request) (*http.Response, []byte, error) {
+	return c.send(req)
+}
+
+// send executes a prepared request, updating the rate limiter and decoding the response body
+func (c *Client) send(req *http.Request) (*http.Response, []byte, error) {
+	// Requesting compression explicitly disables the transport's transparent gzip handling, so responses are decoded below
+	if req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", AcceptEncoding)
+	}
+
+	req, cancel := c.withDeadline(req)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.Log.Tracef("audit: %s %s%s [%s] failed after %s: %v", req.Method, req.URL.Host, req.URL.Path, req.Header.Get(RequestIDHeader), time.Since(start), err)
+		return nil, nil, timeoutError(req, c.Timeout(req.URL.Path), err)
+	}
 	defer resp.Body.Close()
 
+	// Audit record of the request; carries the run and step of the client's context, see WithContext
+	c.Log.Tracef("audit: %s %s%s [%s] %d in %s", req.Method, req.URL.Host, req.URL.Path, req.Header.Get(RequestIDHeader), resp.StatusCode, time.Since(start))
+
 	// Update rate limiter if headers are present
 	if c.RateLimiter != nil {
 		c.RateLimiter.UpdateFromHeaders(resp.Header)
 		c.RateLimiter.Wait()
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	reader, err := DecompressBody(resp)
 	if err != nil {
-		return nil, nil, fmt.Errorf("reading response body: %w", err)
+		return nil, nil, err
 	}
+	defer reader.Close()
 
-	switch resp.StatusCode {
-	case http.StatusOK, http.StatusNoContent:
-		return resp, body, nil
-	case http.StatusTooManyRequests:
-		fmt.Println(string(body)) // Will consider logging instead of printing
-	default:
-		return nil, body, fmt.Errorf(string(body))
+	body, err := c.readBody(req, resp, reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading response body: %w", timeoutError(req, c.Timeout(req.URL.Path), err))
 	}
 
-	return nil, body, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	return resp, body, nil
 }
 
 func setPayload(req *http.Request, data interface{}, bodyType string) error {
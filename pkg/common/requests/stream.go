@@ -0,0 +1,57 @@
+// pkg/common/requests/stream.go
+package requests
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+/*
+ * Stream executes a prepared request (see CreateRequest) and copies a successful response body to w as it arrives,
+ * so large downloads are never held in memory; the response size limits therefore do not apply
+ * Unsuccessful responses are read (within the limits) into a *StatusError, and nothing is written to w
+ * The request is not retried, as part of the body may already have been written
+ * @param req *http.Request
+ * @param w io.Writer
+ */
+func (c *Client) Stream(req *http.Request, w io.Writer) (*http.Response, int64, error) {
+	if req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", AcceptEncoding)
+	}
+
+	req, cancel := c.withDeadline(req)
+	defer cancel()
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, timeoutError(req, c.Timeout(req.URL.Path), err)
+	}
+	defer resp.Body.Close()
+
+	if c.RateLimiter != nil {
+		c.RateLimiter.UpdateFromHeaders(resp.Header)
+		c.RateLimiter.Wait()
+	}
+
+	reader, err := DecompressBody(resp)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer reader.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, err := c.readBody(req, resp, reader)
+		if err != nil {
+			return resp, 0, fmt.Errorf("reading response body: %w", err)
+		}
+		return resp, 0, newStatusError(resp, body, string(body))
+	}
+
+	n, err := io.Copy(w, reader)
+	if err != nil {
+		return resp, n, fmt.Errorf("streaming response body: %w", timeoutError(req, c.Timeout(req.URL.Path), err))
+	}
+
+	return resp, n, nil
+}
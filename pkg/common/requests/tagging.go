@@ -0,0 +1,92 @@
+// pkg/common/requests/tagging.go
+package requests
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gemini-oss/rego/pkg/common/log"
+)
+
+const (
+	DefaultUserAgent = "rego (+https://github.com/gemini-oss/rego)" // User-Agent of requests, unless set with WithUserAgent or REGO_USER_AGENT
+	RequestIDHeader  = "X-Request-ID"                               // Header carrying the ID of each request
+)
+
+// tagging identifies the requests of a client to the providers, so their logs can be correlated to rego jobs
+type tagging struct {
+	userAgent string        // Base User-Agent; DefaultUserAgent when empty
+	requestID func() string // Generates the X-Request-ID of each request; NewRequestID when nil
+	disabled  bool          // Do not send X-Request-ID, see WithRequestIDs(nil)
+}
+
+/*
+ * WithUserAgent sets the User-Agent of the client's requests, e.g. `acme-offboarding/1.2`
+ * The run and step of the client's context (see log.WithRunID and log.WithStep) are appended, e.g.
+ * `acme-offboarding/1.2 run/20240501-9f2c1ab4 step/suspend`, so they show up in provider audit logs (e.g. the Okta System Log)
+ */
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.tagging.userAgent = userAgent
+	}
+}
+
+// WithRequestIDs sets the generator of the X-Request-ID of each request; nil stops sending the header
+func WithRequestIDs(generate func() string) Option {
+	return func(c *Client) {
+		c.tagging.requestID = generate
+		c.tagging.disabled = generate == nil
+	}
+}
+
+// NewRequestID returns a random request ID; NewClient's default generator prefixes it with the run ID of the context
+func NewRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// UserAgent returns the User-Agent of the client's requests, tagged with the run and step of its context
+func (c *Client) UserAgent() string {
+	userAgent := c.tagging.userAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+
+	ctx := c.Context()
+	tags := []string{userAgent}
+	if run := log.RunID(ctx); run != "" {
+		tags = append(tags, "run/"+run)
+	}
+	if step := log.Step(ctx); step != "" {
+		tags = append(tags, "step/"+strings.ReplaceAll(step, " ", "_"))
+	}
+	return strings.Join(tags, " ")
+}
+
+// tag sets the User-Agent (unless the client's headers set one) and the X-Request-ID of a request
+func (c *Client) tag(req *http.Request) {
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", c.UserAgent())
+	}
+
+	if c.tagging.disabled || req.Header.Get(RequestIDHeader) != "" {
+		return
+	}
+
+	id := ""
+	if c.tagging.requestID != nil {
+		id = c.tagging.requestID()
+	} else if id = NewRequestID(); id != "" {
+		if run := log.RunID(c.Context()); run != "" {
+			id = run + "." + id
+		}
+	}
+	if id != "" {
+		req.Header.Set(RequestIDHeader, id)
+	}
+}
@@ -0,0 +1,97 @@
+// pkg/common/requests/timeout.go
+package requests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Option configures a Client, e.g. `NewClient(nil, headers, nil, requests.WithTimeout(30*time.Second))`
+type Option func(*Client)
+
+// timeouts holds the client-wide timeout and the per-endpoint overrides
+type timeouts struct {
+	fallback  time.Duration
+	endpoints map[string]time.Duration // Keyed by path prefix
+	mutex     sync.RWMutex
+}
+
+// WithTimeout sets the deadline of every request without an endpoint specific timeout. 0 means no limit.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeouts.mutex.Lock()
+		defer c.timeouts.mutex.Unlock()
+
+		c.timeouts.fallback = d
+	}
+}
+
+/*
+ * WithEndpointTimeout sets the deadline of requests whose path starts with `prefix`; the longest matching prefix wins
+ * e.g. fail fast on interactive lookups, and allow long running exports:
+ *
+ *	requests.WithEndpointTimeout("/api/v1/users", 10*time.Second)
+ *	requests.WithEndpointTimeout("/api/v1/logs", 10*time.Minute)
+ */
+func WithEndpointTimeout(prefix string, d time.Duration) Option {
+	return func(c *Client) {
+		c.timeouts.mutex.Lock()
+		defer c.timeouts.mutex.Unlock()
+
+		if c.timeouts.endpoints == nil {
+			c.timeouts.endpoints = make(map[string]time.Duration)
+		}
+		c.timeouts.endpoints[prefix] = d
+	}
+}
+
+// Apply configures the client with the given options
+func (c *Client) Apply(opts ...Option) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// Timeout returns the deadline applied to requests for a URL path
+func (c *Client) Timeout(path string) time.Duration {
+	c.timeouts.mutex.RLock()
+	defer c.timeouts.mutex.RUnlock()
+
+	prefixes := make([]string, 0, len(c.timeouts.endpoints))
+	for prefix := range c.timeouts.endpoints {
+		if strings.HasPrefix(path, prefix) {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	if len(prefixes) == 0 {
+		return c.timeouts.fallback
+	}
+
+	sort.Slice(prefixes, func(i, j int) bool { return len(prefixes[i]) > len(prefixes[j]) })
+	return c.timeouts.endpoints[prefixes[0]]
+}
+
+// withDeadline applies the endpoint's timeout to the request; the returned cancel func must be called once the body is read
+func (c *Client) withDeadline(req *http.Request) (*http.Request, context.CancelFunc) {
+	d := c.Timeout(req.URL.Path)
+	if d <= 0 {
+		return req, func() {}
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), d)
+	return req.WithContext(ctx), cancel
+}
+
+// timeoutError explains a request which ran out of its deadline
+func timeoutError(req *http.Request, d time.Duration, err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%s %s timed out after %s: %w", req.Method, req.URL.Path, d, err)
+	}
+	return err
+}
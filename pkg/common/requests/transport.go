@@ -0,0 +1,102 @@
+// pkg/common/requests/transport.go
+package requests
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+/*
+ * TransportOptions
+ * Connection pooling and protocol settings for a service client's transport
+ */
+type TransportOptions struct {
+	MaxIdleConns          int           // Maximum idle (keep-alive) connections across all hosts. 0 means no limit.
+	MaxIdleConnsPerHost   int           // Maximum idle (keep-alive) connections kept per host. http.DefaultTransport keeps 2, which causes churn under concurrency.
+	MaxConnsPerHost       int           // Maximum connections per host, including those in use. 0 means no limit.
+	IdleConnTimeout       time.Duration // How long an idle connection is kept before closing.
+	KeepAlive             time.Duration // Interval between TCP keep-alive probes.
+	DialTimeout           time.Duration // Maximum time to establish a TCP connection.
+	TLSHandshakeTimeout   time.Duration // Maximum time to complete the TLS handshake.
+	ResponseHeaderTimeout time.Duration // Maximum time to wait for response headers after sending the request. 0 means no limit.
+	DisableKeepAlives     bool          // Use a new connection for every request.
+	DisableHTTP2          bool          // Only use HTTP/1.1.
+}
+
+// DefaultTransportOptions returns the transport settings used by clients created without a custom *http.Client
+func DefaultTransportOptions() *TransportOptions {
+	return &TransportOptions{
+		MaxIdleConns:        200,
+		MaxIdleConnsPerHost: 100,
+		IdleConnTimeout:     90 * time.Second,
+		KeepAlive:           30 * time.Second,
+		DialTimeout:         30 * time.Second,
+		TLSHandshakeTimeout: 10 * time.Second,
+	}
+}
+
+/*
+ * NewTransport
+ * @param opts *TransportOptions
+ * @return *http.Transport
+ */
+func NewTransport(opts *TransportOptions) *http.Transport {
+	if opts == nil {
+		opts = DefaultTransportOptions()
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   opts.DialTimeout,
+		KeepAlive: opts.KeepAlive,
+	}
+
+	t := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     !opts.DisableHTTP2,
+		MaxIdleConns:          opts.MaxIdleConns,
+		MaxIdleConnsPerHost:   opts.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       opts.MaxConnsPerHost,
+		IdleConnTimeout:       opts.IdleConnTimeout,
+		TLSHandshakeTimeout:   opts.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: opts.ResponseHeaderTimeout,
+		ExpectContinueTimeout: 1 * time.Second,
+		DisableKeepAlives:     opts.DisableKeepAlives,
+	}
+
+	if opts.DisableHTTP2 {
+		// A non-nil, empty TLSNextProto disables HTTP/2 negotiation
+		t.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+
+	return t
+}
+
+/*
+ * TuneTransport replaces the client's transport, closing the idle connections of the previous one
+ * - An *oauth2.Transport (e.g. Google clients) keeps its token source; only its base transport is replaced
+ * @param opts *TransportOptions
+ */
+func (c *Client) TuneTransport(opts *TransportOptions) {
+	if t, ok := c.httpClient.Transport.(*oauth2.Transport); ok {
+		if old, ok := t.Base.(*http.Transport); ok {
+			old.CloseIdleConnections()
+		}
+		t.Base = NewTransport(opts)
+		return
+	}
+
+	if old, ok := c.httpClient.Transport.(*http.Transport); ok {
+		old.CloseIdleConnections()
+	}
+	c.httpClient.Transport = NewTransport(opts)
+}
+
+// CloseIdleConnections closes any idle keep-alive connections held by the client
+func (c *Client) CloseIdleConnections() {
+	c.httpClient.CloseIdleConnections()
+}
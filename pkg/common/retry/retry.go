@@ -56,3 +56,26 @@ func Retry(operation func() error, time Time) error {
 	}
 	return err
 }
+
+// Backoff decides how long to wait after the failed attempt `attempt` (0-based), or that the error is not worth retrying
+type Backoff func(attempt int, err error) (wait time.Duration, retry bool)
+
+// RetryWith retries the given operation up to MaxRetries times, waiting as long as `backoff` decides between attempts
+func RetryWith(operation func() error, time Time, backoff Backoff) error {
+	var err error
+	for i := 0; i < MaxRetries; i++ {
+		err = operation()
+		if err == nil {
+			return nil
+		}
+		if i == MaxRetries-1 {
+			break
+		}
+		wait, retry := backoff(i, err)
+		if !retry {
+			return err
+		}
+		time.Sleep(wait)
+	}
+	return err
+}
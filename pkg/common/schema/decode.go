@@ -0,0 +1,152 @@
+// pkg/common/schema/decode.go
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gemini-oss/rego/pkg/common/config"
+)
+
+// DecodeMode controls how Unmarshal treats keys which are not mapped by the target type
+type DecodeMode int
+
+const (
+	Lenient DecodeMode = iota // Unknown keys are silently dropped (encoding/json behaviour)
+	Report                    // Unknown keys are dropped and recorded in the collector
+	Strict                    // Unknown keys are recorded and fail the decode (DisallowUnknownFields)
+)
+
+var (
+	mode      = modeFromEnv(config.GetEnv("REGO_DECODE_MODE"))
+	modeMutex sync.RWMutex
+)
+
+func modeFromEnv(value string) DecodeMode {
+	switch strings.ToLower(value) {
+	case "report":
+		return Report
+	case "strict":
+		return Strict
+	default:
+		return Lenient
+	}
+}
+
+// SetDecodeMode selects how unknown keys are handled by Unmarshal. The initial mode is read from REGO_DECODE_MODE {lenient, report, strict}
+func SetDecodeMode(m DecodeMode) {
+	modeMutex.Lock()
+	defer modeMutex.Unlock()
+
+	mode = m
+}
+
+// Mode returns the selected decode mode
+func Mode() DecodeMode {
+	modeMutex.RLock()
+	defer modeMutex.RUnlock()
+
+	return mode
+}
+
+/*
+ * Unmarshal decodes a JSON document into `v` according to the selected decode mode
+ * In Report and Strict modes every unknown key is added to the DefaultCollector, feeding the schema-drift report
+ */
+func Unmarshal(data []byte, v interface{}) error {
+	m := Mode()
+	if m == Lenient {
+		return json.Unmarshal(data, v)
+	}
+
+	if drift, err := Diff(data, v); err == nil && drift.HasDrift() {
+		DefaultCollector.Add(drift)
+	}
+
+	if m == Report {
+		return json.Unmarshal(data, v)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("strict decode: %w", err)
+	}
+	return nil
+}
+
+// Collector accumulates the unknown keys observed for each type
+type Collector struct {
+	unknown map[string]map[string]int // Type -> path -> occurrences
+	mutex   sync.Mutex
+}
+
+// DefaultCollector receives the unknown keys found by Unmarshal
+var DefaultCollector = NewCollector()
+
+func NewCollector() *Collector {
+	return &Collector{
+		unknown: make(map[string]map[string]int),
+	}
+}
+
+// Add records the unmapped paths of a drift
+func (c *Collector) Add(drift *Drift) {
+	if !drift.HasDrift() {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	paths, ok := c.unknown[drift.Type]
+	if !ok {
+		paths = make(map[string]int)
+		c.unknown[drift.Type] = paths
+	}
+	for _, path := range drift.Unmapped {
+		paths[path]++
+	}
+}
+
+// Report returns the unknown keys seen so far, one drift per type, sorted by type
+func (c *Collector) Report() []*Drift {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	report := []*Drift{}
+	for t, paths := range c.unknown {
+		drift := &Drift{Type: t}
+		for path := range paths {
+			drift.Unmapped = append(drift.Unmapped, path)
+		}
+		sort.Strings(drift.Unmapped)
+		report = append(report, drift)
+	}
+
+	sort.Slice(report, func(i, j int) bool {
+		return report[i].Type < report[j].Type
+	})
+
+	return report
+}
+
+// Occurrences returns how many times an unknown key was seen on a type
+func (c *Collector) Occurrences(t, path string) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.unknown[t][path]
+}
+
+// Reset clears the collected keys
+func (c *Collector) Reset() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.unknown = make(map[string]map[string]int)
+}
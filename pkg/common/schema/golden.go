@@ -0,0 +1,117 @@
+// pkg/common/schema/golden.go
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// Redacted replaces every string value of a recorded document
+const Redacted = "REDACTED"
+
+// SampleSize is the number of array elements kept when a document is sanitized
+var SampleSize = 3
+
+var timestamp = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}([T ]\d{2}:\d{2}(:\d{2}(\.\d+)?)?(Z|[+-]\d{2}:?\d{2})?)?$`)
+
+/*
+ * Sanitize strips a JSON document down to its shape so it can be committed as a golden file
+ * - Strings are replaced (timestamps keep their format), numbers are zeroed and arrays are trimmed to SampleSize elements
+ * - Keys, and therefore the schema, are preserved
+ */
+func Sanitize(data []byte) ([]byte, error) {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decoding document: %w", err)
+	}
+
+	return json.MarshalIndent(sanitize(doc), "", "  ")
+}
+
+func sanitize(doc interface{}) interface{} {
+	switch node := doc.(type) {
+	case map[string]interface{}:
+		for key, value := range node {
+			node[key] = sanitize(value)
+		}
+		return node
+	case []interface{}:
+		if len(node) > SampleSize {
+			node = node[:SampleSize]
+		}
+		for i, value := range node {
+			node[i] = sanitize(value)
+		}
+		return node
+	case string:
+		if timestamp.MatchString(node) {
+			return sanitizeTimestamp(node)
+		}
+		return Redacted
+	case float64:
+		return 0
+	default:
+		return node
+	}
+}
+
+// sanitizeTimestamp replaces a timestamp with the epoch, keeping the layout so custom time decoding still works
+func sanitizeTimestamp(s string) string {
+	for _, layout := range []string{time.RFC3339Nano, time.RFC3339, "2006-01-02T15:04:05.000Z0700", "2006-01-02 15:04:05", "2006-01-02"} {
+		if _, err := time.Parse(layout, s); err == nil {
+			return time.Unix(0, 0).UTC().Format(layout)
+		}
+	}
+	return s
+}
+
+/*
+ * Record sanitizes a document and writes it to `{dir}/{name}.json`
+ * When the document is an array, its first element is recorded
+ */
+func Record(dir, name string, data []byte) error {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("decoding %s: %w", name, err)
+	}
+
+	if list, ok := doc.([]interface{}); ok {
+		if len(list) == 0 {
+			return fmt.Errorf("%s: no objects to record", name)
+		}
+		doc = list[0]
+	}
+
+	sample, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	golden, err := Sanitize(sample)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, name+".json"), append(golden, '\n'), 0644)
+}
+
+/*
+ * CheckGolden diffs the golden file `{dir}/{name}.json` against the type of `v`
+ * Returns os.ErrNotExist (wrapped) when the golden file has not been recorded
+ */
+func CheckGolden(dir, name string, v interface{}) (*Drift, error) {
+	data, err := os.ReadFile(filepath.Join(dir, name+".json"))
+	if err != nil {
+		return nil, err
+	}
+
+	return Diff(data, v)
+}
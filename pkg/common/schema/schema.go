@@ -0,0 +1,171 @@
+// pkg/common/schema/schema.go
+package schema
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+/*
+ * Drift lists the keys of a JSON document which are not mapped by a Go type
+ * Paths are dotted, with `[]` marking array elements and `{}` marking map values, e.g. `emails[].customType`
+ */
+type Drift struct {
+	Type     string   // Go type the document was compared against
+	Unmapped []string // Paths of keys with no corresponding struct field
+}
+
+// HasDrift reports whether any keys were unmapped
+func (d *Drift) HasDrift() bool {
+	return d != nil && len(d.Unmapped) > 0
+}
+
+func (d *Drift) String() string {
+	if !d.HasDrift() {
+		return fmt.Sprintf("%s: no drift", d.Type)
+	}
+	return fmt.Sprintf("%s: %d unmapped field(s): %s", d.Type, len(d.Unmapped), strings.Join(d.Unmapped, ", "))
+}
+
+var (
+	jsonUnmarshaler = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+/*
+ * Diff compares a JSON document against the type of `v` and returns the keys `encoding/json` would silently drop
+ * Types with custom unmarshalling, `interface{}` and `json.RawMessage` fields accept any content and are not descended into
+ */
+func Diff(data []byte, v interface{}) (*Drift, error) {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decoding document: %w", err)
+	}
+
+	t := reflect.TypeOf(v)
+	unmapped := make(map[string]bool)
+	walk(doc, t, "", unmapped)
+
+	drift := &Drift{Type: typeName(t)}
+	for path := range unmapped {
+		drift.Unmapped = append(drift.Unmapped, path)
+	}
+	sort.Strings(drift.Unmapped)
+
+	return drift, nil
+}
+
+func walk(doc interface{}, t reflect.Type, path string, unmapped map[string]bool) {
+	if t == nil {
+		return
+	}
+	for t.Kind() == reflect.Ptr {
+		if t.Implements(jsonUnmarshaler) || t.Implements(textUnmarshaler) {
+			return
+		}
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(jsonUnmarshaler) || reflect.PointerTo(t).Implements(textUnmarshaler) {
+		return
+	}
+
+	switch node := doc.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Struct:
+			fields := jsonFields(t)
+			for key, value := range node {
+				field, ok := lookupField(fields, key)
+				if !ok {
+					unmapped[join(path, key)] = true
+					continue
+				}
+				walk(value, field, join(path, key), unmapped)
+			}
+		case reflect.Map:
+			for _, value := range node {
+				walk(value, t.Elem(), path+"{}", unmapped)
+			}
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for _, value := range node {
+				walk(value, t.Elem(), path+"[]", unmapped)
+			}
+		}
+	}
+}
+
+// jsonFields returns the JSON keys of a struct (including promoted fields of embedded structs) and their types
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for key, value := range jsonFields(ft) {
+					if _, exists := fields[key]; !exists {
+						fields[key] = value
+					}
+				}
+				continue
+			}
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+
+	return fields
+}
+
+// lookupField matches a key the way `encoding/json` does: exactly, then case-insensitively
+func lookupField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if t, ok := fields[key]; ok {
+		return t, true
+	}
+	for name, t := range fields {
+		if strings.EqualFold(name, key) {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func typeName(t reflect.Type) string {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return "<nil>"
+	}
+	return t.String()
+}
@@ -79,6 +79,12 @@ func ToMap(item interface{}, includeZeroValues bool) (map[string]interface{}, er
 				return nil, err
 			}
 			value = sliceValues
+		case reflect.Ptr:
+			// Optional fields (e.g. `optional.Of(false)`) are sent as their value, even when it is the zero value
+			value = field.Interface()
+			if !field.IsNil() && field.Elem().Kind() != reflect.Struct {
+				value = field.Elem().Interface()
+			}
 		default:
 			value = field.Interface()
 		}
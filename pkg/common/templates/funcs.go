@@ -0,0 +1,178 @@
+// pkg/common/templates/funcs.go
+package templates
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+)
+
+// Funcs are the helpers available to every template, modelled on the commonly used subset of sprig
+func Funcs() template.FuncMap {
+	return template.FuncMap{
+		// Strings
+		"lower":     strings.ToLower,
+		"upper":     strings.ToUpper,
+		"title":     title,
+		"trim":      strings.TrimSpace,
+		"replace":   func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+		"contains":  func(substr, s string) bool { return strings.Contains(s, substr) },
+		"hasPrefix": func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix": func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+		"trunc":     truncate,
+		"indent":    indent,
+		"repeat":    func(n int, s string) string { return strings.Repeat(s, n) },
+		"join":      join,
+		"split":     func(sep, s string) []string { return strings.Split(s, sep) },
+		"plural":    plural,
+
+		// Defaults
+		"default": defaultValue,
+		"empty":   empty,
+		"coalesce": func(values ...interface{}) interface{} {
+			for _, v := range values {
+				if !empty(v) {
+					return v
+				}
+			}
+			return nil
+		},
+
+		// Dates
+		"now":  time.Now,
+		"date": date,
+		"ago":  func(t time.Time) string { return time.Since(t).Round(time.Second).String() },
+
+		// Math
+		"add": func(a, b int) int { return a + b },
+		"sub": func(a, b int) int { return a - b },
+
+		// Collections
+		"list": func(values ...interface{}) []interface{} { return values },
+		"keys": keys,
+
+		// Encoding
+		"toJSON":       toJSON,
+		"toPrettyJSON": toPrettyJSON,
+	}
+}
+
+func title(s string) string {
+	prev := ' '
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(prev) {
+			prev = r
+			return unicode.ToTitle(r)
+		}
+		prev = r
+		return r
+	}, s)
+}
+
+func truncate(n int, s string) string {
+	if n < 0 || len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
+
+func indent(n int, s string) string {
+	pad := strings.Repeat(" ", n)
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+}
+
+// join accepts any slice, e.g. `{{ .Emails | join ", " }}`
+func join(sep string, v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fmt.Sprint(v)
+	}
+
+	parts := make([]string, rv.Len())
+	for i := range parts {
+		parts[i] = fmt.Sprint(rv.Index(i).Interface())
+	}
+	return strings.Join(parts, sep)
+}
+
+// plural returns `one` when count is 1, otherwise `many`, e.g. `{{ plural "user" "users" .Count }}`
+func plural(one, many string, count int) string {
+	if count == 1 {
+		return one
+	}
+	return many
+}
+
+// date formats a time.Time (or *time.Time) with a Go layout; zero times render as an empty string
+func date(layout string, v interface{}) string {
+	var t time.Time
+	switch d := v.(type) {
+	case time.Time:
+		t = d
+	case *time.Time:
+		if d != nil {
+			t = *d
+		}
+	default:
+		return fmt.Sprint(v)
+	}
+
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(layout)
+}
+
+// defaultValue returns `def` when `v` is empty, e.g. `{{ .Manager | default "none" }}`
+func defaultValue(def, v interface{}) interface{} {
+	if empty(v) {
+		return def
+	}
+	return v
+}
+
+func empty(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return rv.IsNil()
+	case reflect.Slice, reflect.Map, reflect.Array, reflect.String:
+		return rv.Len() == 0
+	default:
+		return rv.IsZero()
+	}
+}
+
+// keys returns the sorted keys of a map with string keys
+func keys(v interface{}) []string {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Map {
+		return nil
+	}
+
+	keys := make([]string, 0, rv.Len())
+	for _, k := range rv.MapKeys() {
+		keys = append(keys, fmt.Sprint(k.Interface()))
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func toJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+func toPrettyJSON(v interface{}) (string, error) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	return string(b), err
+}
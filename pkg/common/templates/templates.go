@@ -0,0 +1,135 @@
+// pkg/common/templates/templates.go
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/gemini-oss/rego/pkg/common/config"
+)
+
+const Extension = ".tmpl"
+
+var (
+	//go:embed defaults/*.tmpl
+	defaults embed.FS
+)
+
+/*
+ * Library resolves templates by name, most specific first:
+ * 1. <Dir>/<tenant>/<name>.tmpl
+ * 2. <Dir>/<name>.tmpl
+ * 3. The default embedded in rego
+ * Overrides are read from disk on every render, so message formats can be changed without recompiling or restarting.
+ */
+type Library struct {
+	Dir      string            // Directory of template overrides; overrides are skipped when empty
+	embedded map[string]string // Templates registered in code, on top of the embedded defaults
+	mutex    sync.RWMutex
+}
+
+var (
+	defaultLibrary *Library
+	once           sync.Once
+)
+
+// Default returns the shared library, reading overrides from REGO_TEMPLATE_DIR
+func Default() *Library {
+	once.Do(func() {
+		defaultLibrary = New(config.GetEnv("REGO_TEMPLATE_DIR"))
+	})
+	return defaultLibrary
+}
+
+// New creates a library reading overrides from `dir`
+func New(dir string) *Library {
+	return &Library{
+		Dir:      dir,
+		embedded: make(map[string]string),
+	}
+}
+
+// Register adds (or replaces) a built-in template, e.g. one shipped by a downstream package
+func (l *Library) Register(name, text string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.embedded[name] = text
+}
+
+// Render executes the template `name` with `data`, without a tenant override
+func (l *Library) Render(name string, data interface{}) (string, error) {
+	return l.RenderFor("", name, data)
+}
+
+// RenderFor executes the template `name` with `data`, preferring the override of `tenant`
+func (l *Library) RenderFor(tenant, name string, data interface{}) (string, error) {
+	text, source, err := l.Lookup(tenant, name)
+	if err != nil {
+		return "", err
+	}
+
+	return RenderString(source, text, data)
+}
+
+/*
+ * Lookup returns the text of the template `name` and where it was found
+ * @param tenant string
+ * @param name string
+ * @return text string, source string, err error
+ */
+func (l *Library) Lookup(tenant, name string) (string, string, error) {
+	if strings.ContainsAny(name, `/\`) || strings.Contains(tenant, "..") || strings.ContainsAny(tenant, `/\`) {
+		return "", "", fmt.Errorf("invalid template name: %s/%s", tenant, name)
+	}
+
+	if l.Dir != "" {
+		candidates := []string{filepath.Join(l.Dir, name+Extension)}
+		if tenant != "" {
+			candidates = append([]string{filepath.Join(l.Dir, tenant, name+Extension)}, candidates...)
+		}
+		for _, path := range candidates {
+			b, err := os.ReadFile(path)
+			if err == nil {
+				return string(b), path, nil
+			}
+			if !os.IsNotExist(err) {
+				return "", "", fmt.Errorf("reading template override %s: %w", path, err)
+			}
+		}
+	}
+
+	l.mutex.RLock()
+	text, ok := l.embedded[name]
+	l.mutex.RUnlock()
+	if ok {
+		return text, name, nil
+	}
+
+	b, err := defaults.ReadFile("defaults/" + name + Extension)
+	if err != nil {
+		return "", "", fmt.Errorf("template %s not found", name)
+	}
+	return string(b), name, nil
+}
+
+// RenderString executes an ad-hoc template with the library's functions
+func RenderString(name, text string, data interface{}) (string, error) {
+	tmpl, err := template.New(name).Funcs(Funcs()).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("parsing template %s: %w", name, err)
+	}
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("rendering template %s: %w", name, err)
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,311 @@
+// pkg/common/validate/validate.go
+package validate
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+ * Rules are declared with a `validate` struct tag and checked before a request is marshaled, e.g.
+ *
+ *	type CheckoutRequest struct {
+ *		CheckoutToType string `json:"checkout_to_type,omitempty" validate:"required,enum=user|asset|location"`
+ *		Email          string `json:"email,omitempty" validate:"format=email"`
+ *	}
+ *
+ * - required: the field must not be its zero value (or empty, for slices and maps)
+ * - enum=a|b|c: a non-empty field must be one of the listed values
+ * - format=email|url|uuid|date|datetime|ip|mac: a non-empty string field must be in the given format
+ *
+ * Nested structs, pointers, slices and maps of structs are validated recursively
+ */
+
+// FieldError describes a single field that failed validation
+type FieldError struct {
+	Field   string // Path of the field, using JSON names, e.g. `applicationDataTransfers[0].applicationId`
+	Rule    string // Rule that failed, e.g. `required`
+	Message string // Human readable description of the failure
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// Errors is the list of fields that failed validation
+type Errors []*FieldError
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Validator is implemented by types with rules that cannot be expressed with tags
+type Validator interface {
+	Validate() error
+}
+
+var formats = map[string]func(string) bool{
+	"email": func(s string) bool {
+		addr, err := mail.ParseAddress(s)
+		return err == nil && addr.Address == s
+	},
+	"url": func(s string) bool {
+		u, err := url.Parse(s)
+		return err == nil && u.Scheme != "" && u.Host != ""
+	},
+	"uuid": regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`).MatchString,
+	"date": func(s string) bool {
+		_, err := time.Parse("2006-01-02", s)
+		return err == nil
+	},
+	"datetime": func(s string) bool {
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	},
+	"ip": func(s string) bool {
+		return net.ParseIP(s) != nil
+	},
+	"mac": func(s string) bool {
+		_, err := net.ParseMAC(s)
+		return err == nil
+	},
+}
+
+// rule is a parsed `validate` tag of a single field
+type rule struct {
+	index    int
+	name     string // JSON name of the field
+	required bool
+	enum     []string
+	format   string
+}
+
+var (
+	typeRules     sync.Map // map[reflect.Type][]rule; parsed rules of each struct type
+	typeChecked   sync.Map // map[reflect.Type]bool; whether a type, or anything it contains, can fail validation
+	validatorType = reflect.TypeOf((*Validator)(nil)).Elem()
+)
+
+/*
+ * Struct validates `v` against its `validate` tags and returns Errors listing every failing field, or nil
+ * Values which are not structs (or pointers to, or collections of, structs) are always valid
+ */
+func Struct(v interface{}) error {
+	if v == nil {
+		return nil
+	}
+
+	value := reflect.ValueOf(v)
+	if !needsCheck(value.Type()) {
+		return nil
+	}
+
+	var errs Errors
+	check(value, "", &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func check(v reflect.Value, path string, errs *Errors) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			check(v.Index(i), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+		return
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			check(iter.Value(), fmt.Sprintf("%s[%v]", path, iter.Key()), errs)
+		}
+		return
+	case reflect.Struct:
+	default:
+		return
+	}
+
+	if validator, ok := addressable(v).Interface().(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			*errs = append(*errs, &FieldError{Field: fieldPath(path), Rule: "validate", Message: err.Error()})
+		}
+	}
+
+	t := v.Type()
+	rules := rulesFor(t)
+	for _, r := range rules {
+		field := v.Field(r.index)
+		name := join(path, r.name)
+
+		if field.IsZero() || (hasLen(field) && field.Len() == 0) {
+			if r.required {
+				*errs = append(*errs, &FieldError{Field: name, Rule: "required", Message: "is required"})
+			}
+			continue
+		}
+
+		value := indirect(field)
+		if len(r.enum) > 0 {
+			s := fmt.Sprint(value.Interface())
+			if !contains(r.enum, s) {
+				*errs = append(*errs, &FieldError{Field: name, Rule: "enum", Message: fmt.Sprintf("%q is not one of {%s}", s, strings.Join(r.enum, ", "))})
+			}
+		}
+		if r.format != "" && value.Kind() == reflect.String {
+			if valid, ok := formats[r.format]; ok && !valid(value.String()) {
+				*errs = append(*errs, &FieldError{Field: name, Rule: "format", Message: fmt.Sprintf("%q is not a valid %s", value.String(), r.format)})
+			}
+		}
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.IsExported() && needsCheck(f.Type) {
+			check(v.Field(i), join(path, jsonName(f)), errs)
+		}
+	}
+}
+
+// needsCheck reports whether values of a type can fail validation, so large payloads without rules are not walked
+func needsCheck(t reflect.Type) bool {
+	if cached, ok := typeChecked.Load(t); ok {
+		return cached.(bool)
+	}
+	result := typeNeedsCheck(t, map[reflect.Type]bool{})
+	typeChecked.Store(t, result)
+	return result
+}
+
+func typeNeedsCheck(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return typeNeedsCheck(t.Elem(), seen)
+	case reflect.Struct:
+		if len(rulesFor(t)) > 0 || reflect.PointerTo(t).Implements(validatorType) {
+			return true
+		}
+		for i := 0; i < t.NumField(); i++ {
+			if f := t.Field(i); f.IsExported() && typeNeedsCheck(f.Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rulesFor parses, and caches, the rules of a struct type
+func rulesFor(t reflect.Type) []rule {
+	if cached, ok := typeRules.Load(t); ok {
+		return cached.([]rule)
+	}
+
+	rules := []rule{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup("validate")
+		if !ok || !f.IsExported() {
+			continue
+		}
+
+		r := rule{index: i, name: jsonName(f)}
+		for _, part := range strings.Split(tag, ",") {
+			key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+			switch key {
+			case "required":
+				r.required = true
+			case "enum":
+				r.enum = strings.Split(value, "|")
+			case "format":
+				if _, known := formats[value]; !known {
+					panic(fmt.Sprintf("validate: unknown format %q on %s.%s", value, t, f.Name))
+				}
+				r.format = value
+			case "":
+			default:
+				panic(fmt.Sprintf("validate: unknown rule %q on %s.%s", key, t, f.Name))
+			}
+		}
+		rules = append(rules, r)
+	}
+
+	typeRules.Store(t, rules)
+	return rules
+}
+
+func jsonName(f reflect.StructField) string {
+	if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return f.Name
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func fieldPath(path string) string {
+	if path == "" {
+		return "(root)"
+	}
+	return path
+}
+
+func hasLen(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.String, reflect.Array:
+		return true
+	}
+	return false
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v
+}
+
+// addressable returns a pointer to v when possible, so Validate methods with pointer receivers are found
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v.Addr()
+	}
+	return v
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -210,6 +210,34 @@ func (c *AdminClient) GetAssignmentsForRole(roleId string, customer *Customer) (
 	return &roleAssignment, nil
 }
 
+/*
+ * # Create a Role Assignment
+ * /admin/directory/v1/customer/{customer}/roleassignments
+ * - https://developers.google.com/admin-sdk/directory/reference/rest/v1/roleAssignments/insert
+ * - `AssignedTo` is the unique ID of the user (or group), not their email
+ */
+func (c *AdminClient) CreateRoleAssignment(customer *Customer, assignment *RoleAssignment) (*RoleAssignment, error) {
+	url := c.BuildURL(DirectoryRoleAssignments, customer)
+
+	if assignment.ScopeType == "" {
+		assignment.ScopeType = "CUSTOMER"
+	}
+
+	return do[*RoleAssignment](c.Client, "POST", url, nil, assignment)
+}
+
+/*
+ * # Delete a Role Assignment
+ * /admin/directory/v1/customer/{customer}/roleassignments/{roleAssignmentId}
+ * - https://developers.google.com/admin-sdk/directory/reference/rest/v1/roleAssignments/delete
+ */
+func (c *AdminClient) DeleteRoleAssignment(customer *Customer, roleAssignmentID string) error {
+	url := c.BuildURL(DirectoryRoleAssignments, customer, roleAssignmentID)
+
+	_, err := do[interface{}](c.Client, "DELETE", url, nil, nil)
+	return err
+}
+
 /*
  * Create user list from a role's assignments
  * /admin/directory/v1/customer/{customer}/roleassignments
@@ -547,12 +575,12 @@ func createPolicyModificationRequests(policies []*ResolvedPolicy, targetOU *OrgU
 				fields = nil
 			}
 		}
+		target := OrgUnitTarget(targetOU.ID)
+		target.AdditionalTargetKeys = policy.TargetKey.AdditionalTargetKeys // e.g. the app of app policies
 		requests = append(requests, &PolicyModificationRequest{
-			PolicyTargetKey: PolicyTargetKey{
-				TargetResource: fmt.Sprintf("orgunits/%s", strings.TrimPrefix(targetOU.ID, "id:")),
-			},
-			PolicyValue: policy.Value,
-			UpdateMask:  updateMask,
+			PolicyTargetKey: target,
+			PolicyValue:     policy.Value,
+			UpdateMask:      updateMask,
 		})
 	}
 	return requests
@@ -0,0 +1,104 @@
+/*
+# Google Workspace - Admin Audit
+
+This package initializes all the methods for searching the Admin audit activity from the Reports API:
+https://developers.google.com/admin-sdk/reports/v1/appendix/activity/admin-event-names
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/audit.go
+package google
+
+import (
+	"strings"
+	"time"
+)
+
+/*
+ * # List the Activities affecting a target
+ * /admin/reports/v1/activity/users/all/applications/{applicationName}
+ * - https://developers.google.com/admin-sdk/reports/reference/rest/v1/activities/list
+ * - The Reports API cannot filter on arbitrary parameters, so events are matched locally on any parameter equal to `target`
+ *   (e.g. USER_EMAIL, GROUP_EMAIL, ORG_UNIT_NAME, DOMAIN_NAME)
+ * - Only matching events are kept on each returned activity
+ */
+func (c *AdminClient) ListTargetActivities(application, target string, start, end time.Time) ([]Report, error) {
+	matches := []Report{}
+	err := c.Reports().StreamActivities(application, start, end, nil, func(page []Report) error {
+		for _, activity := range page {
+			events := []Event{}
+			for _, event := range activity.Events {
+				if parametersMention(event.Parameters, target) {
+					events = append(events, event)
+				}
+			}
+			if len(events) > 0 {
+				activity.Events = events
+				matches = append(matches, activity)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+// parametersMention reports whether any (nested) parameter value equals the target
+func parametersMention(params []ReportParameter, target string) bool {
+	for _, p := range params {
+		if strings.EqualFold(p.Value, target) {
+			return true
+		}
+		for _, v := range p.MultiValue {
+			if strings.EqualFold(v, target) {
+				return true
+			}
+		}
+		if parametersMention(p.MessageValue, target) || parametersMention(p.MultiMessageValue, target) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+ * # Last Activity of each Group
+ * /admin/reports/v1/activity/users/all/applications/groups
+ * - https://developers.google.com/admin-sdk/reports/v1/appendix/activity/groups
+ * - Returns the time of the latest Groups audit event (membership, settings, moderation) between `start` and `end`, by
+ *   lowercase group email; groups without any event are absent
+ */
+func (c *AdminClient) LastGroupActivity(start, end time.Time) (map[string]time.Time, error) {
+	last := map[string]time.Time{}
+	err := c.Reports().StreamActivities("groups", start, end, nil, func(page []Report) error {
+		for _, activity := range page {
+			at, err := time.Parse(time.RFC3339, activity.ID.Time)
+			if err != nil {
+				continue
+			}
+			for _, event := range activity.Events {
+				for _, p := range event.Parameters {
+					if !strings.EqualFold(p.Name, "group_email") {
+						continue
+					}
+					email := strings.ToLower(p.Value)
+					if at.After(last[email]) {
+						last[email] = at
+					}
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return last, nil
+}
@@ -0,0 +1,154 @@
+/*
+# Google Workspace - Batch
+
+This package initializes the methods for sending multiple API calls in a single HTTP request:
+https://developers.google.com/admin-sdk/directory/v1/guides/batch
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/batch.go
+package google
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var (
+	DirectoryBatch = fmt.Sprintf("%s/batch/admin/directory_v1", AdminBaseURL) // https://developers.google.com/admin-sdk/directory/v1/guides/batch
+)
+
+// BatchSize is the number of calls sent per batch request. The API accepts up to 1000, but large batches hit per-user quotas.
+var BatchSize = 100
+
+// BatchCall is a single API call within a batch request
+type BatchCall struct {
+	Method string      // HTTP method of the call
+	URL    string      // Full URL of the call, e.g. built with BuildURL
+	Body   interface{} // JSON body of the call, if any
+}
+
+// BatchResult is the outcome of a single call within a batch request
+type BatchResult struct {
+	Call       *BatchCall
+	StatusCode int    // HTTP status of the call
+	Body       []byte // Raw response body of the call
+	Err        error  // Set when the call failed (status >= 400), with the API's error detail when available
+}
+
+/*
+ * # Batch
+ * Sends the calls in a multipart/mixed batch request, returning one result per call in the same order
+ * The returned error is only set when the batch itself failed; check each result's Err for partial failures
+ * - https://developers.google.com/admin-sdk/directory/v1/guides/batch
+ */
+func (c *Client) Batch(batchURL string, calls []*BatchCall) ([]*BatchResult, error) {
+	var payload bytes.Buffer
+	w := multipart.NewWriter(&payload)
+
+	for i, call := range calls {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", "application/http")
+		header.Set("Content-ID", fmt.Sprintf("<item-%d>", i))
+
+		part, err := w.CreatePart(header)
+		if err != nil {
+			return nil, err
+		}
+
+		u, err := url.Parse(call.URL)
+		if err != nil {
+			return nil, fmt.Errorf("batch call %d: %w", i, err)
+		}
+
+		fmt.Fprintf(part, "%s %s\r\n", call.Method, u.RequestURI())
+		if call.Body != nil {
+			body, err := json.Marshal(call.Body)
+			if err != nil {
+				return nil, fmt.Errorf("batch call %d: %w", i, err)
+			}
+			fmt.Fprintf(part, "Content-Type: application/json\r\nContent-Length: %d\r\n\r\n%s\r\n", len(body), body)
+		} else {
+			fmt.Fprint(part, "\r\n")
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	res, body, err := c.HTTP.DoBody("POST", batchURL, "multipart/mixed; boundary="+w.Boundary(), payload.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("batch request: %w", err)
+	}
+
+	return parseBatchResponse(res.Header.Get("Content-Type"), body, calls)
+}
+
+// parseBatchResponse matches each part of a multipart/mixed batch response to its call by Content-ID
+func parseBatchResponse(contentType string, body []byte, calls []*BatchCall) ([]*BatchResult, error) {
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil || params["boundary"] == "" {
+		return nil, fmt.Errorf("batch response is not multipart: %s", contentType)
+	}
+
+	results := make([]*BatchResult, len(calls))
+	r := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := r.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading batch response: %w", err)
+		}
+
+		// Responses are identified as <response-item-N>
+		id := strings.Trim(part.Header.Get("Content-ID"), "<>")
+		i, err := strconv.Atoi(id[strings.LastIndex(id, "-")+1:])
+		if err != nil || i < 0 || i >= len(calls) {
+			return nil, fmt.Errorf("unexpected batch response part: %s", id)
+		}
+
+		res, err := http.ReadResponse(bufio.NewReader(part), nil)
+		if err != nil {
+			return nil, fmt.Errorf("reading batch response part %s: %w", id, err)
+		}
+		partBody, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading batch response part %s: %w", id, err)
+		}
+
+		result := &BatchResult{Call: calls[i], StatusCode: res.StatusCode, Body: partBody}
+		if res.StatusCode >= 400 {
+			var googleError ErrorResponse
+			if json.Unmarshal(partBody, &googleError) == nil && googleError.Error != nil {
+				result.Err = googleError.Error
+			} else {
+				result.Err = fmt.Errorf("%s", res.Status)
+			}
+		}
+		results[i] = result
+	}
+
+	for i, result := range results {
+		if result == nil {
+			results[i] = &BatchResult{Call: calls[i], Err: fmt.Errorf("no response in batch")}
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,155 @@
+/*
+# Google Workspace - Calendar
+
+This package initializes all the methods for functions which interact with the Google Calendar API and calendar resources (rooms):
+- https://developers.google.com/calendar/api/v3/reference
+- https://developers.google.com/admin-sdk/directory/reference/rest/v1/resources.calendars
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/calendar.go
+package google
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+	"github.com/gemini-oss/rego/pkg/common/schema"
+)
+
+var (
+	CalendarBaseURL   = fmt.Sprintf("%s/calendar/v3", BaseURL)                       // https://developers.google.com/calendar/api/v3/reference
+	CalendarEvents    = fmt.Sprintf("%s/calendars/%s/events", CalendarBaseURL, "%s") // https://developers.google.com/calendar/api/v3/reference/events
+	DirectoryCalendar = fmt.Sprintf("%s/calendars", DirectoryResources)              // https://developers.google.com/admin-sdk/directory/reference/rest/v1/resources.calendars
+)
+
+// CalendarClient for chaining methods
+type CalendarClient struct {
+	*Client
+}
+
+// Entry point for calendar-related operations
+func (c *Client) Calendar() *CalendarClient {
+	cc := &CalendarClient{
+		Client: c,
+	}
+
+	// https://developers.google.com/calendar/api/guides/quota
+	cc.HTTP.RateLimiter.Available = 600
+	cc.HTTP.RateLimiter.Limit = 600
+	cc.HTTP.RateLimiter.Interval = 1 * time.Minute
+	cc.HTTP.RateLimiter.Log.Verbosity = c.Log.Verbosity
+
+	return cc
+}
+
+/*
+ * Query Parameters for Calendar Resources
+ * Reference: https://developers.google.com/admin-sdk/directory/reference/rest/v1/resources.calendars/list#query-parameters
+ */
+type CalendarResourceQuery struct {
+	MaxResults int    `url:"maxResults,omitempty"` // Maximum number of results to return. Max allowed value is 500.
+	PageToken  string `url:"pageToken,omitempty"`  // Token to specify the next page in the list.
+	Query      string `url:"query,omitempty"`      // String query used to filter results, e.g. `buildingId="HQ"`
+}
+
+/*
+ * # List all Calendar Resources (Rooms)
+ * /admin/directory/v1/customer/{customer}/resources/calendars
+ * - https://developers.google.com/admin-sdk/directory/reference/rest/v1/resources.calendars/list
+ */
+func (c *CalendarClient) ListAllCalendarResources(customer *Customer) (*CalendarResources, error) {
+	url := c.BuildURL(DirectoryCalendar, customer)
+
+	q := &CalendarResourceQuery{
+		MaxResults: 500,
+	}
+
+	resources, err := requests.PaginatedDo(c.HTTP, "GET", url, q, requests.Pagination[*CalendarResource]{
+		Items: requests.ItemsField[*CalendarResource]("items"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CalendarResources{Items: resources}, nil
+}
+
+/*
+ * Query Parameters for Calendar Events
+ * Reference: https://developers.google.com/calendar/api/v3/reference/events/list#parameters
+ */
+type CalendarEventQuery struct {
+	MaxResults   int    `url:"maxResults,omitempty"`   // Maximum number of events returned on one result page. Max allowed value is 2500.
+	OrderBy      string `url:"orderBy,omitempty"`      // `startTime` (only with singleEvents) or `updated`
+	PageToken    string `url:"pageToken,omitempty"`    // Token specifying which result page to return.
+	ShowDeleted  bool   `url:"showDeleted,omitempty"`  // Whether to include deleted events (with status equals "cancelled") in the result.
+	SingleEvents bool   `url:"singleEvents,omitempty"` // Whether to expand recurring events into instances and only return single one-off events and instances of recurring events.
+	TimeMax      string `url:"timeMax,omitempty"`      // Upper bound (exclusive) for an event's start time to filter by. RFC3339 timestamp.
+	TimeMin      string `url:"timeMin,omitempty"`      // Lower bound (exclusive) for an event's end time to filter by. RFC3339 timestamp.
+}
+
+/*
+ * # List all Events of a Calendar
+ * /calendar/v3/calendars/{calendarId}/events
+ * - https://developers.google.com/calendar/api/v3/reference/events/list
+ * - The calendar ID of a room is its resource email
+ */
+func (c *CalendarClient) ListAllEvents(calendarID string, q *CalendarEventQuery) (*CalendarEventList, error) {
+	url := fmt.Sprintf(CalendarEvents, url.PathEscape(calendarID))
+
+	if q == nil {
+		q = &CalendarEventQuery{}
+	}
+	if q.MaxResults == 0 {
+		q.MaxResults = 2500
+	}
+
+	// Every page carries the summary and time zone of the calendar
+	events := &CalendarEventList{}
+	items, err := requests.PaginatedDo(c.HTTP, "GET", url, q, requests.Pagination[*CalendarEvent]{
+		Items: func(body []byte) ([]*CalendarEvent, error) {
+			var page CalendarEventList
+			if err := schema.Unmarshal(body, &page); err != nil {
+				return nil, err
+			}
+			events.Summary, events.TimeZone = page.Summary, page.TimeZone
+			return page.Items, nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events.Items = items
+	return events, nil
+}
+
+/*
+ * # Delete an Event from a Calendar
+ * /calendar/v3/calendars/{calendarId}/events/{eventId}
+ * - https://developers.google.com/calendar/api/v3/reference/events/delete
+ * - Deleting a booking from a room's calendar releases the room; use the recurringEventId to release a whole series
+ */
+func (c *CalendarClient) DeleteEvent(calendarID string, eventID string) error {
+	url := fmt.Sprintf("%s/%s", fmt.Sprintf(CalendarEvents, url.PathEscape(calendarID)), url.PathEscape(eventID))
+
+	_, err := do[interface{}](c.Client, "DELETE", url, nil, nil)
+	return err
+}
+
+// Time returns the instant of a CalendarTime; all-day events start at midnight UTC
+func (t *CalendarTime) Time() (time.Time, error) {
+	if t == nil {
+		return time.Time{}, fmt.Errorf("no time")
+	}
+	if t.DateTime != "" {
+		return time.Parse(time.RFC3339, t.DateTime)
+	}
+	return time.Parse("2006-01-02", t.Date)
+}
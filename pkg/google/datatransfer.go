@@ -0,0 +1,113 @@
+/*
+# Google Workspace - Data Transfer
+
+This package initializes all the methods for functions which interact with the Admin SDK Data Transfer API:
+https://developers.google.com/admin-sdk/data-transfer/reference/rest
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/datatransfer.go
+package google
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	AdminDataTransfer     = fmt.Sprintf("%s/admin/datatransfer/v1", AdminBaseURL) // https://developers.google.com/admin-sdk/data-transfer/reference/rest
+	DataTransferApps      = fmt.Sprintf("%s/applications", AdminDataTransfer)     // https://developers.google.com/admin-sdk/data-transfer/reference/rest/v1/applications
+	DataTransferTransfers = fmt.Sprintf("%s/transfers", AdminDataTransfer)        // https://developers.google.com/admin-sdk/data-transfer/reference/rest/v1/transfers
+)
+
+// Names of the applications supporting data transfers
+const (
+	DataTransferDrive    = "Drive and Docs"
+	DataTransferCalendar = "Calendar"
+)
+
+// DataTransferClient for chaining methods
+type DataTransferClient struct {
+	*Client
+}
+
+// Entry point for data-transfer-related operations
+func (c *Client) DataTransfer() *DataTransferClient {
+	return &DataTransferClient{
+		Client: c,
+	}
+}
+
+/*
+ * # List the Applications supporting Data Transfers
+ * /admin/datatransfer/v1/applications
+ * - https://developers.google.com/admin-sdk/data-transfer/reference/rest/v1/applications/list
+ */
+func (c *DataTransferClient) ListApplications() (*DataTransferApplications, error) {
+	apps, err := do[DataTransferApplications](c.Client, "GET", DataTransferApps, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apps, nil
+}
+
+/*
+ * # Transfer the Data of an Application
+ * /admin/datatransfer/v1/transfers
+ * - https://developers.google.com/admin-sdk/data-transfer/reference/rest/v1/transfers/insert
+ * - `application` is the name of the application, e.g. DataTransferDrive; `params` are its transfer parameters, e.g. PRIVACY_LEVEL
+ */
+func (c *DataTransferClient) Transfer(application, oldOwnerEmail, newOwnerEmail string, params []*ApplicationTransferParam) (*DataTransfer, error) {
+	apps, err := c.ListApplications()
+	if err != nil {
+		return nil, err
+	}
+
+	var app *DataTransferApplication
+	for _, a := range apps.Applications {
+		if strings.EqualFold(a.Name, application) {
+			app = a
+			break
+		}
+	}
+	if app == nil {
+		return nil, fmt.Errorf("no data transfer application named %q", application)
+	}
+
+	oldOwner, err := c.Users().GetUser(oldOwnerEmail)
+	if err != nil {
+		return nil, err
+	}
+	newOwner, err := c.Users().GetUser(newOwnerEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer := &DataTransfer{
+		OldOwnerUserID: oldOwner.ID,
+		NewOwnerUserID: newOwner.ID,
+		ApplicationDataTransfers: []*ApplicationDataTransfer{
+			{
+				ApplicationID:             app.ID,
+				ApplicationTransferParams: params,
+			},
+		},
+	}
+
+	return do[*DataTransfer](c.Client, "POST", DataTransferTransfers, nil, transfer)
+}
+
+/*
+ * # Get a Data Transfer
+ * /admin/datatransfer/v1/transfers/{dataTransferId}
+ * - https://developers.google.com/admin-sdk/data-transfer/reference/rest/v1/transfers/get
+ */
+func (c *DataTransferClient) GetTransfer(id string) (*DataTransfer, error) {
+	url := c.BuildURL(DataTransferTransfers, nil, id)
+
+	return do[*DataTransfer](c.Client, "GET", url, nil, nil)
+}
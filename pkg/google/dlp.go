@@ -0,0 +1,348 @@
+/*
+# Google Workspace - Drive Content Scanner
+
+This package initializes all the methods for scanning Google Drive file content for sensitive data using the Google Drive API:
+https://developers.google.com/drive/api/reference/rest/v3/files
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/dlp.go
+package google
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+)
+
+// DLPDetector inspects file content and returns every sensitive match it finds
+type DLPDetector interface {
+	Name() string
+	Find(content []byte) []string
+}
+
+// RegexDetector matches content against a regular expression
+type RegexDetector struct {
+	Label   string
+	Pattern *regexp.Regexp
+}
+
+func (d *RegexDetector) Name() string {
+	return d.Label
+}
+
+func (d *RegexDetector) Find(content []byte) []string {
+	matches := []string{}
+	for _, m := range d.Pattern.FindAll(content, -1) {
+		matches = append(matches, string(m))
+	}
+	return matches
+}
+
+// LuhnDetector matches 13-19 digit card numbers which pass the Luhn checksum
+type LuhnDetector struct {
+	Label string
+}
+
+var luhnCandidate = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+
+func (d *LuhnDetector) Name() string {
+	if d.Label == "" {
+		return "Credit Card Number"
+	}
+	return d.Label
+}
+
+func (d *LuhnDetector) Find(content []byte) []string {
+	matches := []string{}
+	for _, m := range luhnCandidate.FindAll(content, -1) {
+		if LuhnValid(string(m)) {
+			matches = append(matches, string(m))
+		}
+	}
+	return matches
+}
+
+/*
+ * # Luhn Checksum
+ * Reports whether a number (spaces and dashes are ignored) passes the Luhn check
+ * - https://en.wikipedia.org/wiki/Luhn_algorithm
+ */
+func LuhnValid(number string) bool {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(number)
+	if len(digits) < 2 {
+		return false
+	}
+
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		n := int(digits[i] - '0')
+		if n < 0 || n > 9 {
+			return false
+		}
+		if double {
+			n *= 2
+			if n > 9 {
+				n -= 9
+			}
+		}
+		sum += n
+		double = !double
+	}
+
+	return sum%10 == 0
+}
+
+// DefaultDetectors returns the detectors used when a scan does not specify any
+func DefaultDetectors() []DLPDetector {
+	return []DLPDetector{
+		&RegexDetector{Label: "US Social Security Number", Pattern: regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`)},
+		&LuhnDetector{Label: "Credit Card Number"},
+		&RegexDetector{Label: "AWS Access Key ID", Pattern: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+		&RegexDetector{Label: "Private Key", Pattern: regexp.MustCompile(`-----BEGIN (?:RSA |EC |DSA |OPENSSH )?PRIVATE KEY-----`)},
+	}
+}
+
+/*
+ * Options for a Drive content scan
+ * **ReGo only**
+ */
+type DLPScanOptions struct {
+	Query     *DriveFileQuery // Query selecting the candidate files. Defaults to all non-trashed files visible to the caller.
+	Detectors []DLPDetector   // Detectors to run against each file. Defaults to DefaultDetectors().
+	Domains   []string        // Internal domains; permissions granted outside of these are reported as `external`.
+	MaxBytes  int64           // Files larger than this are skipped. Default: 10MB.
+}
+
+// Google editor files are exported to a text format before scanning
+var dlpExportFormats = map[string]string{
+	"application/vnd.google-apps.document":     "text/plain",
+	"application/vnd.google-apps.spreadsheet":  "text/csv",
+	"application/vnd.google-apps.presentation": "text/plain",
+}
+
+/*
+ * # Scan Drive Files for Sensitive Content
+ * Lists the files matching the query, exports/downloads their content and runs each detector against it
+ * - https://developers.google.com/drive/api/reference/rest/v3/files/export
+ * - https://developers.google.com/drive/api/guides/manage-downloads
+ */
+func (c *DriveClient) ScanFiles(opts *DLPScanOptions) ([]*DLPFinding, error) {
+	if opts == nil {
+		opts = &DLPScanOptions{}
+	}
+	if len(opts.Detectors) == 0 {
+		opts.Detectors = DefaultDetectors()
+	}
+	if opts.MaxBytes == 0 {
+		opts.MaxBytes = 10 << 20
+	}
+
+	q := DriveFileQuery{Q: "trashed = false"}
+	if opts.Query != nil {
+		q = *opts.Query
+	}
+	q.Fields = "nextPageToken, files(id, name, mimeType, size, owners, permissions, shared, webViewLink)"
+	q.PageSize = 1000
+
+	files, err := requests.PaginatedDo(c.HTTP, "GET", c.BuildURL(DriveFiles, nil), q, requests.Pagination[*File]{
+		Strategy: requests.TokenPages{SizeParam: "pageSize"},
+		Items:    requests.ItemsField[*File]("files"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.Log.Printf("Scanning %d candidate files", len(files))
+
+	var findings []*DLPFinding
+	var findingsMutex sync.Mutex
+
+	sem := make(chan struct{}, 10)
+	var wg sync.WaitGroup
+
+	for _, file := range files {
+		wg.Add(1)
+		go func(file *File) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			content, err := c.fileContent(file, opts.MaxBytes)
+			if err != nil {
+				c.Log.Errorf("Error reading content of %s (%s): %v", file.Name, file.ID, err)
+				return
+			}
+			if len(content) == 0 {
+				return
+			}
+
+			fileFindings := []*DLPFinding{}
+			for _, detector := range opts.Detectors {
+				matches := detector.Find(content)
+				if len(matches) == 0 {
+					continue
+				}
+				fileFindings = append(fileFindings, newDLPFinding(file, detector.Name(), matches, opts.Domains))
+			}
+			if len(fileFindings) == 0 {
+				return
+			}
+
+			path, err := c.GetFilePath(file.ID)
+			if err != nil {
+				path = file.Name
+			}
+			for _, f := range fileFindings {
+				f.Path = path
+			}
+
+			findingsMutex.Lock()
+			findings = append(findings, fileFindings...)
+			findingsMutex.Unlock()
+		}(file)
+	}
+
+	wg.Wait()
+
+	c.Log.Printf("Found %d findings across %d files", len(findings), len(files))
+	return findings, nil
+}
+
+/*
+ * # Get File Content
+ * Exports Google editor files to text and downloads text-like binary files; other files return no content
+ * drive/v3/files/{fileId}/export
+ * drive/v3/files/{fileId}?alt=media
+ */
+func (c *DriveClient) fileContent(file *File, maxBytes int64) ([]byte, error) {
+	var url string
+	var q interface{}
+
+	if format, ok := dlpExportFormats[file.MimeType]; ok {
+		url = c.BuildURL(DriveFiles, nil, file.ID, "export")
+		q = struct {
+			MimeType string `url:"mimeType"`
+		}{format}
+	} else if isScannableMimeType(file.MimeType) {
+		if size, err := strconv.ParseInt(file.Size, 10, 64); err == nil && size > maxBytes {
+			c.Log.Debugf("Skipping %s (%s): %d bytes exceeds scan limit", file.Name, file.ID, size)
+			return nil, nil
+		}
+		url = c.BuildURL(DriveFiles, nil, file.ID)
+		q = struct {
+			Alt               string `url:"alt"`
+			SupportsAllDrives bool   `url:"supportsAllDrives"`
+		}{"media", true}
+	} else {
+		return nil, nil
+	}
+
+	res, body, err := c.HTTP.DoRequest("GET", url, q, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode >= 400 {
+		return nil, fmt.Errorf("unexpected status: %s", res.Status)
+	}
+	if int64(len(body)) > maxBytes {
+		body = body[:maxBytes]
+	}
+
+	return body, nil
+}
+
+func isScannableMimeType(mimeType string) bool {
+	if strings.HasPrefix(mimeType, "text/") {
+		return true
+	}
+	switch mimeType {
+	case "application/json", "application/xml", "application/x-yaml", "application/csv":
+		return true
+	}
+	return false
+}
+
+func newDLPFinding(file *File, detector string, matches []string, domains []string) *DLPFinding {
+	finding := &DLPFinding{
+		FileID:      file.ID,
+		FileName:    file.Name,
+		MimeType:    file.MimeType,
+		WebViewLink: file.WebViewLink,
+		Sharing:     sharingStatus(file, domains),
+		Detector:    detector,
+		Matches:     len(matches),
+	}
+	if len(file.Owners) > 0 {
+		finding.Owner = file.Owners[0].EmailAddress
+	}
+
+	for i, m := range matches {
+		if i == 3 {
+			break
+		}
+		finding.Samples = append(finding.Samples, redact(m))
+	}
+
+	return finding
+}
+
+// Sharing audiences, ordered from narrowest to widest
+var sharingRank = map[string]int{"private": 0, "internal": 1, "domain": 2, "external": 3, "anyone": 4}
+
+// sharingStatus reports the widest audience a file is shared with
+func sharingStatus(file *File, domains []string) string {
+	internal := func(email string) bool {
+		email = strings.ToLower(email)
+		for _, d := range domains {
+			if strings.HasSuffix(email, "@"+strings.ToLower(d)) {
+				return true
+			}
+		}
+		return len(domains) == 0
+	}
+
+	status := "private"
+	if file.Shared {
+		status = "internal"
+	}
+
+	for _, p := range file.Permissions {
+		audience := ""
+		switch p.Type {
+		case "anyone":
+			audience = "anyone"
+		case "domain":
+			audience = "domain"
+		case "user", "group":
+			if p.Role == "owner" {
+				continue
+			}
+			audience = "internal"
+			if !internal(p.EmailAddress) {
+				audience = "external"
+			}
+		}
+		if sharingRank[audience] > sharingRank[status] {
+			status = audience
+		}
+	}
+
+	return status
+}
+
+// redact masks all but the last four characters of a match
+func redact(s string) string {
+	if len(s) <= 4 {
+		return strings.Repeat("*", len(s))
+	}
+	return strings.Repeat("*", len(s)-4) + s[len(s)-4:]
+}
@@ -14,8 +14,14 @@ package google
 
 import (
 	"fmt"
+	"io"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/export"
+	"github.com/gemini-oss/rego/pkg/common/requests"
 )
 
 var (
@@ -24,6 +30,7 @@ var (
 	DriveChanges     = fmt.Sprintf("%s/changes", DriveBaseURL)     // https://developers.google.com/drive/api/v3/reference/changes
 	DriveChannels    = fmt.Sprintf("%s/channels", DriveBaseURL)    // https://developers.google.com/drive/api/v3/reference/channels
 	DriveComments    = fmt.Sprintf("%s/comments", DriveBaseURL)    // https://developers.google.com/drive/api/v3/reference/comments
+	DriveDrives      = fmt.Sprintf("%s/drives", DriveBaseURL)      // https://developers.google.com/drive/api/v3/reference/drives
 	DriveFiles       = fmt.Sprintf("%s/files", DriveBaseURL)       // https://developers.google.com/drive/api/v3/reference/files
 	DrivePermissions = fmt.Sprintf("%s/permissions", DriveBaseURL) // https://developers.google.com/drive/api/v3/reference/permissions
 	DriveReplies     = fmt.Sprintf("%s/replies", DriveBaseURL)     // https://developers.google.com/drive/api/v3/reference/replies
@@ -322,27 +329,27 @@ func (c *DriveClient) processFileList(q *DriveFileQuery, parentPath string, allF
 
 	var wg sync.WaitGroup
 
-	for {
-		filesPage, err := c.fetchFilesPage(*q)
-		if err != nil {
-			return err
-		}
-
-		for _, file := range *filesPage.Files {
-			file.Path = parentPath + "/" + file.Name
-			c.Log.Println("File Path:", file.Path)
-			*allFiles.Files = append(*allFiles.Files, file)
-
-			if file.MimeType == "application/vnd.google-apps.folder" {
-				wg.Add(1)
-				go c.fetchSubFiles(file, file.Path, sem, filesChannel, filesErrChannel, &wg)
+	// Folders are walked while the following pages are read
+	_, err := requests.PaginatedDo(c.HTTP, "GET", c.BuildURL(DriveFiles, nil), q, requests.Pagination[*File]{
+		Strategy: requests.TokenPages{SizeParam: "pageSize"},
+		Items:    requests.ItemsField[*File]("files"),
+		Discard:  true,
+		OnPage: func(page int, files []*File) bool {
+			for _, file := range files {
+				file.Path = parentPath + "/" + file.Name
+				c.Log.Println("File Path:", file.Path)
+				*allFiles.Files = append(*allFiles.Files, file)
+
+				if file.MimeType == "application/vnd.google-apps.folder" {
+					wg.Add(1)
+					go c.fetchSubFiles(file, file.Path, sem, filesChannel, filesErrChannel, &wg)
+				}
 			}
-		}
-
-		if filesPage.NextPageToken == "" {
-			break
-		}
-		q.PageToken = filesPage.NextPageToken
+			return true
+		},
+	})
+	if err != nil {
+		return err
 	}
 
 	go func() {
@@ -393,20 +400,74 @@ func (c *DriveClient) SaveFileListToSheet(fileList *FileList, sheetID string, he
 }
 
 /*
- * # Fetch Files Page
- * Fetches a page of files
+ * # Stream Files
+ * Lists files page by page, handing each page to `fn` as it arrives instead of accumulating them
+ * Use for exports of every file in a domain or shared drive, where the full list doesn't fit in memory
  * drive/v3/files
+ * @param {DriveFileQuery} q - The query parameters to use (e.g. Corpora: "domain", Q: "trashed = false")
+ * @param {func} fn - Called with each page of files; returning an error stops the listing
  * https://developers.google.com/drive/api/v3/reference/files/list
  */
-func (c *DriveClient) fetchFilesPage(q DriveFileQuery) (*FileList, error) {
-	url := c.BuildURL(DriveFiles, nil)
+func (c *DriveClient) StreamFiles(q *DriveFileQuery, fn func(files []*File) error) error {
+	if q.IsEmpty() {
+		q = &DriveFileQuery{
+			Fields:                    "nextPageToken, files(id, name, mimeType, owners, parents, size, modifiedTime, shared, webViewLink)",
+			IncludeItemsFromAllDrives: true,
+			PageSize:                  1000,
+			Q:                         "trashed = false",
+			SupportsAllDrives:         true,
+		}
+	}
+	if err := q.ValidateQuery(); err != nil {
+		return err
+	}
 
-	filesPage, err := do[FileList](c.Client, "GET", url, q, nil)
+	var fnErr error
+	_, err := requests.PaginatedDo(c.HTTP, "GET", c.BuildURL(DriveFiles, nil), q, requests.Pagination[*File]{
+		Strategy: requests.TokenPages{SizeParam: "pageSize"},
+		Items:    requests.ItemsField[*File]("files"),
+		Discard:  true,
+		OnPage: func(page int, files []*File) bool {
+			if files != nil {
+				fnErr = fn(files)
+			}
+			c.Log.Debugf("Streamed page %d of files", page)
+			return fnErr == nil
+		},
+	})
 	if err != nil {
-		return nil, err
+		return err
+	}
+	return fnErr
+}
+
+/*
+ * # Export Files to CSV
+ * Streams the file listing into a CSV, one page at a time
+ * @param {io.Writer} w - Destination of the CSV (closed afterwards if it is an io.Closer)
+ * @param {DriveFileQuery} q - The query parameters to use
+ */
+func (c *DriveClient) ExportFilesCSV(w io.Writer, q *DriveFileQuery) (int, error) {
+	headers := []string{"id", "name", "mimeType", "owners", "parents", "size", "modifiedTime", "shared", "webViewLink"}
+
+	rows, err := export.Stream(export.NewCSVSink(w), headers,
+		func(yield func([]*File) error) error {
+			return c.StreamFiles(q, yield)
+		},
+		func(f *File) []string {
+			owners := make([]string, 0, len(f.Owners))
+			for _, owner := range f.Owners {
+				owners = append(owners, owner.EmailAddress)
+			}
+			return []string{f.ID, f.Name, f.MimeType, strings.Join(owners, ";"), strings.Join(f.Parents, ";"), f.Size, f.ModifiedTime, strconv.FormatBool(f.Shared), f.WebViewLink}
+		},
+	)
+	if err != nil {
+		return rows, err
 	}
 
-	return &filesPage, nil
+	c.Log.Printf("Exported %d file(s) to CSV", rows)
+	return rows, nil
 }
 
 /*
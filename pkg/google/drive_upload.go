@@ -0,0 +1,343 @@
+/*
+# Google Workspace - Drive Uploads
+
+This package uploads file content to Google Drive, with a single multipart request for small files and the resumable
+upload protocol (chunked, resuming interrupted chunks) for larger ones:
+https://developers.google.com/drive/api/guides/manage-uploads
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/drive_upload.go
+package google
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+
+	"github.com/gemini-oss/rego/pkg/common/retry"
+	"github.com/gemini-oss/rego/pkg/common/schema"
+)
+
+var (
+	DriveUploadFiles = fmt.Sprintf("%s/upload/drive/v3/files", BaseURL) // https://developers.google.com/drive/api/reference/rest/v3/files/create
+)
+
+const (
+	ResumableThreshold     = 5 * 1024 * 1024 // Files larger than this (or of unknown size) use the resumable protocol
+	UploadChunkSize        = 8 * 1024 * 1024 // Default chunk size of resumable uploads
+	uploadChunkUnit        = 256 * 1024      // Chunks must be a multiple of 256 KiB, except the last one
+	statusResumeIncomplete = 308             // Status of a resumable upload which expects more content
+)
+
+// UploadOptions configures a Drive upload
+type UploadOptions struct {
+	MimeType  string                        // MIME type of the content; detected by Drive when empty
+	ChunkSize int                           // Bytes per chunk of a resumable upload, rounded up to 256 KiB. Default: UploadChunkSize
+	Progress  func(sent int64, total int64) // Called after every confirmed chunk; total is -1 while the size is unknown
+}
+
+/*
+ * # Upload a New File to Google Drive
+ * /upload/drive/v3/files
+ * - https://developers.google.com/drive/api/reference/rest/v3/files/create
+ * - `file` holds the metadata (name, parents, ...); `size` may be -1 when unknown
+ */
+func (c *DriveClient) UploadFile(file *File, content io.Reader, size int64, opts *UploadOptions) (*File, error) {
+	return c.upload("POST", DriveUploadFiles, file, content, size, opts)
+}
+
+/*
+ * # Replace the Content of a Google Drive File
+ * /upload/drive/v3/files/{fileId}
+ * - https://developers.google.com/drive/api/reference/rest/v3/files/update
+ * - `file` may carry metadata changes, or be nil to only upload a new revision
+ */
+func (c *DriveClient) UpdateFileContent(fileID string, file *File, content io.Reader, size int64, opts *UploadOptions) (*File, error) {
+	return c.upload("PATCH", fmt.Sprintf("%s/%s", DriveUploadFiles, fileID), file, content, size, opts)
+}
+
+func (c *DriveClient) upload(method string, url string, file *File, content io.Reader, size int64, opts *UploadOptions) (*File, error) {
+	if file == nil {
+		file = &File{}
+	}
+	if opts == nil {
+		opts = &UploadOptions{}
+	}
+	if opts.MimeType == "" {
+		opts.MimeType = file.MimeType
+	}
+
+	if size >= 0 && size <= ResumableThreshold {
+		return c.uploadMultipart(method, url, file, content, size, opts)
+	}
+	return c.uploadResumable(method, url, file, content, size, opts)
+}
+
+// uploadMultipart sends the metadata and content in a single `multipart/related` request
+func (c *DriveClient) uploadMultipart(method string, url string, file *File, content io.Reader, size int64, opts *UploadOptions) (*File, error) {
+	metadata, err := json.Marshal(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+
+	part, err := w.CreatePart(textproto.MIMEHeader{"Content-Type": {"application/json; charset=UTF-8"}})
+	if err != nil {
+		return nil, err
+	}
+	part.Write(metadata)
+
+	mimeType := opts.MimeType
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+	part, err = w.CreatePart(textproto.MIMEHeader{"Content-Type": {mimeType}})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, content); err != nil {
+		return nil, fmt.Errorf("reading content: %w", err)
+	}
+	w.Close()
+
+	_, res, err := c.HTTP.DoBody(method, url+"?uploadType=multipart&supportsAllDrives=true", "multipart/related; boundary="+w.Boundary(), body.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if opts.Progress != nil {
+		opts.Progress(size, size)
+	}
+
+	return decodeUploadedFile(res)
+}
+
+/*
+ * uploadResumable starts a resumable session and sends the content in chunks
+ * A chunk which fails (network error or 5xx) is retried from the last byte the session confirmed, up to retry.MaxRetries times
+ */
+func (c *DriveClient) uploadResumable(method string, url string, file *File, content io.Reader, size int64, opts *UploadOptions) (*File, error) {
+	session, err := c.startUploadSession(method, url, file, size, opts.MimeType)
+	if err != nil {
+		return nil, err
+	}
+
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = UploadChunkSize
+	}
+	chunkSize = (chunkSize + uploadChunkUnit - 1) / uploadChunkUnit * uploadChunkUnit
+
+	chunk := make([]byte, chunkSize)
+	var offset int64 // First byte of the current chunk
+	for {
+		n, readErr := io.ReadFull(content, chunk)
+		if readErr != nil && readErr != io.EOF && readErr != io.ErrUnexpectedEOF {
+			return nil, fmt.Errorf("reading content: %w", readErr)
+		}
+		last := readErr != nil || (size >= 0 && offset+int64(n) >= size)
+
+		total := size
+		if last {
+			total = offset + int64(n)
+		}
+
+		res, body, err := c.sendChunk(session, chunk[:n], offset, total)
+		if err != nil {
+			return nil, err
+		}
+		if res.StatusCode != statusResumeIncomplete {
+			if opts.Progress != nil {
+				opts.Progress(total, total)
+			}
+			return decodeUploadedFile(body)
+		}
+		if last {
+			return nil, fmt.Errorf("upload session expected more than the %d bytes of content", total)
+		}
+
+		offset += int64(n)
+		if opts.Progress != nil {
+			opts.Progress(offset, size)
+		}
+	}
+}
+
+// startUploadSession creates a resumable upload session, returning its URI
+func (c *DriveClient) startUploadSession(method string, url string, file *File, size int64, mimeType string) (string, error) {
+	metadata, err := json.Marshal(file)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := c.HTTP.CreateRequest(method, url+"?uploadType=resumable&supportsAllDrives=true")
+	if err != nil {
+		return "", err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(metadata))
+	req.ContentLength = int64(len(metadata))
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	if mimeType != "" {
+		req.Header.Set("X-Upload-Content-Type", mimeType)
+	}
+	if size >= 0 {
+		req.Header.Set("X-Upload-Content-Length", strconv.FormatInt(size, 10))
+	}
+
+	res, body, err := c.HTTP.Send(req)
+	if err != nil {
+		return "", err
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("starting upload session: %s: %s", res.Status, body)
+	}
+
+	session := res.Header.Get("Location")
+	if session == "" {
+		return "", fmt.Errorf("starting upload session: no session URI returned")
+	}
+	return session, nil
+}
+
+/*
+ * sendChunk uploads the bytes of a chunk starting at `offset`; `total` is -1 until the last chunk
+ * - A 308 whose Range ends before the chunk does means only its head was persisted: the tail is resent right away
+ * - When the request fails, the session is asked how much it received and only the remainder of the chunk is resent
+ */
+func (c *DriveClient) sendChunk(session string, chunk []byte, offset int64, total int64) (*http.Response, []byte, error) {
+	end := offset + int64(len(chunk)) // First byte after the chunk
+	sent := 0                         // Bytes of the chunk the session has confirmed
+	var lastErr error
+	for attempt := 0; attempt < retry.MaxRetries; attempt++ {
+		if lastErr != nil {
+			retry.RealTime{}.Sleep(retry.BackoffWithJitter(attempt - 1))
+
+			confirmed, err := c.uploadStatus(session, total)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			if confirmed < offset || confirmed > end {
+				return nil, nil, fmt.Errorf("upload session confirmed %d bytes; expected between %d and %d", confirmed, offset, end)
+			}
+			sent = int(confirmed - offset)
+		}
+
+		res, body, err := c.putChunk(session, chunk[sent:], offset+int64(sent), total)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		switch {
+		case res.StatusCode == statusResumeIncomplete:
+			confirmed, err := persistedBytes(res)
+			if err != nil {
+				return nil, nil, err
+			}
+			if confirmed < offset || confirmed > end {
+				return nil, nil, fmt.Errorf("upload session confirmed %d bytes; expected between %d and %d", confirmed, offset, end)
+			}
+			if confirmed == end {
+				return res, body, nil
+			}
+			if confirmed > offset+int64(sent) {
+				c.Log.Debugf("Upload session persisted %d of bytes %d-%d; resending the rest", confirmed-offset-int64(sent), offset+int64(sent), end-1)
+				sent, lastErr = int(confirmed-offset), nil
+				continue
+			}
+			lastErr = fmt.Errorf("upload session persisted none of bytes %d-%d", offset+int64(sent), end-1)
+		case res.StatusCode == http.StatusOK, res.StatusCode == http.StatusCreated:
+			return res, body, nil
+		case res.StatusCode == http.StatusNotFound:
+			return nil, nil, fmt.Errorf("upload session expired; the upload must be restarted")
+		case res.StatusCode >= 500:
+			lastErr = fmt.Errorf("uploading bytes %d-%d: %s", offset+int64(sent), end-1, res.Status)
+		default:
+			return nil, nil, fmt.Errorf("uploading bytes %d-%d: %s: %s", offset+int64(sent), end-1, res.Status, body)
+		}
+		c.Log.Warningf("Chunk at %d failed (attempt %d/%d): %v", offset, attempt+1, retry.MaxRetries, lastErr)
+	}
+
+	if lastErr == nil {
+		lastErr = fmt.Errorf("upload session persisted bytes %d-%d only partly after %d attempts", offset, end-1, retry.MaxRetries)
+	}
+	return nil, nil, lastErr
+}
+
+func (c *DriveClient) putChunk(session string, data []byte, start int64, total int64) (*http.Response, []byte, error) {
+	req, err := c.HTTP.CreateRequest("PUT", session)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	req.ContentLength = int64(len(data))
+	req.Header.Del("Content-Type")
+	req.Header.Set("Content-Range", contentRange(start, int64(len(data)), total))
+
+	return c.HTTP.Send(req)
+}
+
+// uploadStatus asks a session how many bytes it has persisted
+func (c *DriveClient) uploadStatus(session string, total int64) (int64, error) {
+	req, err := c.HTTP.CreateRequest("PUT", session)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Del("Content-Type")
+	req.Header.Set("Content-Range", contentRange(0, 0, total))
+
+	res, body, err := c.HTTP.Send(req)
+	if err != nil {
+		return 0, err
+	}
+	if res.StatusCode != statusResumeIncomplete {
+		return 0, fmt.Errorf("checking upload status: %s: %s", res.Status, body)
+	}
+
+	return persistedBytes(res)
+}
+
+// persistedBytes reads how many bytes a session has persisted from the Range header of a 308
+func persistedBytes(res *http.Response) (int64, error) {
+	// e.g. `Range: bytes=0-524287`; absent when nothing was persisted yet
+	r := res.Header.Get("Range")
+	if r == "" {
+		return 0, nil
+	}
+	end, err := strconv.ParseInt(r[strings.LastIndex(r, "-")+1:], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing upload range %q: %w", r, err)
+	}
+	return end + 1, nil
+}
+
+// contentRange formats the Content-Range of a chunk, e.g. `bytes 0-262143/1000000`, or `bytes */1000000` without content
+func contentRange(start, length, total int64) string {
+	size := "*"
+	if total >= 0 {
+		size = strconv.FormatInt(total, 10)
+	}
+	if length == 0 {
+		return fmt.Sprintf("bytes */%s", size)
+	}
+	return fmt.Sprintf("bytes %d-%d/%s", start, start+length-1, size)
+}
+
+func decodeUploadedFile(body []byte) (*File, error) {
+	file := &File{}
+	if err := schema.Unmarshal(body, file); err != nil {
+		return nil, fmt.Errorf("unmarshalling error: %w", err)
+	}
+	return file, nil
+}
@@ -12,6 +12,7 @@ This package contains many structs for handling responses from the Google API:
 package google
 
 import (
+	"encoding/base64"
 	"fmt"
 
 	"github.com/gemini-oss/rego/pkg/common/auth"
@@ -37,15 +38,17 @@ type GoogleConfig struct {
 }
 
 type Client struct {
-	Auth     AuthCredentials   // Credentials to use for authentication
-	BaseURL  string            // Base URL to use for API calls
-	OAuth    *auth.OAuthConfig // OAuth Config
-	JWT      *jwt.Config       // JWT Config
-	HTTP     *requests.Client  // HTTP Client
-	Error    *ErrorResponse    // Error
-	Log      *log.Logger       // Logger
-	Cache    *cache.Cache      // Cache
-	Customer *Customer         // Google Workspace Account
+	Auth      AuthCredentials     // Credentials to use for authentication
+	BaseURL   string              // Base URL to use for API calls
+	OAuth     *auth.OAuthConfig   // OAuth Config
+	JWT       *jwt.Config         // JWT Config
+	HTTP      *requests.Client    // HTTP Client
+	Error     *ErrorResponse      // Error
+	Log       *log.Logger         // Logger
+	Cache     *cache.Cache        // Cache
+	UserCache *cache.Typed[*User] // Typed cache for hot user lookups
+	Customer  *Customer           // Google Workspace Account
+	tokens    *tokenCache         // Tokens of the subjects impersonated by the service account, shared by copies of the client
 }
 
 // Customer represents a Google Workspace account.
@@ -95,7 +98,9 @@ type ErrorResponse struct {
 type ErrorDetail struct {
 	Code    int          `json:"code,omitempty"`    // The HTTP status code for the error.
 	Message string       `json:"message,omitempty"` // The error message.
+	Status  string       `json:"status,omitempty"`  // The canonical status of the error, e.g. `RESOURCE_EXHAUSTED`.
 	Errors  []*ErrorItem `json:"errors,omitempty"`  // An array of more detailed error items.
+	Details []*ErrorItem `json:"details,omitempty"` // Error details of newer APIs, e.g. an ErrorInfo with its reason.
 }
 
 // Implement the error interface for ErrorDetail.
@@ -106,6 +111,19 @@ func (e *ErrorDetail) Error() string {
 	return fmt.Sprintf("code: %d, message: %s", e.Code, e.Message)
 }
 
+// Reason returns the first reason of the error items or details, e.g. `userRateLimitExceeded`
+func (e *ErrorDetail) Reason() string {
+	if e == nil {
+		return ""
+	}
+	for _, item := range append(e.Errors, e.Details...) {
+		if item != nil && item.Reason != "" {
+			return item.Reason
+		}
+	}
+	return ""
+}
+
 // ErrorItem contains detailed information about an individual error.
 type ErrorItem struct {
 	Domain  string `json:"domain,omitempty"`  // The domain of the error.
@@ -135,7 +153,7 @@ type OrgUnit struct {
 	Name              string     `json:"name,omitempty"`              // The organizational unit's path name.
 	Description       string     `json:"description,omitempty"`       // Description of the organizational unit.
 	Etag              string     `json:"etag,omitempty"`              // ETag of the resource.
-	BlockInheritance  bool       `json:"blockInheritance,omitempty"`  // Determines if sub-organizational units can inherit the settings of the parent organization. This field is deprecated.
+	BlockInheritance  *bool      `json:"blockInheritance,omitempty"`  // Determines if sub-organizational units can inherit the settings of the parent organization. This field is deprecated.
 	ID                string     `json:"orgUnitId,omitempty"`         // The unique ID of the organizational unit.
 	Path              string     `json:"orgUnitPath,omitempty"`       // The full path to the organizational unit.
 	ParentID          string     `json:"parentOrgUnitId,omitempty"`   // The unique ID of the parent organizational unit.
@@ -143,6 +161,26 @@ type OrgUnit struct {
 	OrganizationUnits []*OrgUnit `json:"organizationUnits,omitempty"` // List of sub-organizational units.
 }
 
+// https://developers.google.com/admin-sdk/directory/reference/rest/v1/orgunits/list#response-body
+type OrgUnits struct {
+	Kind              string     `json:"kind,omitempty"`              // The type of the API resource.
+	Etag              string     `json:"etag,omitempty"`              // ETag of the resource.
+	OrganizationUnits []*OrgUnit `json:"organizationUnits,omitempty"` // A list of organizational unit objects.
+}
+
+// OrgUnitMoves is the outcome of OrgUnitsClient.MoveUsers. **ReGo only**
+type OrgUnitMoves struct {
+	OrgUnitPath string                // Organizational unit the users were moved to
+	Moved       []string              // Users moved
+	Failed      []*OrgUnitMoveFailure // Users which could not be moved
+}
+
+// OrgUnitMoveFailure is a single user which could not be moved. **ReGo only**
+type OrgUnitMoveFailure struct {
+	User string // Key of the user, as passed to MoveUsers
+	Err  error  // Error returned by the API
+}
+
 // https://developers.google.com/admin-sdk/reports/reference/rest/v1/activities/list#Activity
 type Report struct {
 	Kind          string     `json:"kind,omitempty"`          // The type of API resource
@@ -254,6 +292,19 @@ type RoleReport struct {
 	Users []*User
 }
 
+// CollaborationUsage aggregates Meet and Calendar usage for a single department. **ReGo only**
+type CollaborationUsage struct {
+	Department            string  `json:"department"`            // Department (from the user's primary organization); "Unknown" if unset
+	ActiveUsers           int     `json:"activeUsers"`           // Distinct users with Meet or Calendar activity
+	MeetingsOrganized     int     `json:"meetingsOrganized"`     // Distinct Meet conferences organized by the department
+	MeetingParticipants   int     `json:"meetingParticipants"`   // Participant sessions joined by the department
+	MeetingMinutes        float64 `json:"meetingMinutes"`        // Total minutes spent in Meet calls by the department
+	CalendarEventsCreated int     `json:"calendarEventsCreated"` // Calendar events created by the department
+}
+
+// CollaborationReport is a per-department collection of CollaborationUsage. **ReGo only**
+type CollaborationReport map[string]*CollaborationUsage
+
 // END OF GOOGLE ADMIN SDK STRUCTS
 //---------------------------------------------------------------------
 
@@ -515,9 +566,181 @@ type Field struct {
 	ValueType  string   `json:"valueType,omitempty"`  // The field type. While new values may be supported in the future, the following are currently allowed: dateString, integer, selection, text, user.
 }
 
+// https://developers.google.com/drive/api/reference/rest/v3/drives/list#response-body
+type SharedDriveList struct {
+	Kind          string         `json:"kind,omitempty"`          // drive#driveList
+	Drives        []*SharedDrive `json:"drives,omitempty"`        // The list of shared drives. If nextPageToken is populated, then this list may be incomplete and an additional page of results should be fetched.
+	NextPageToken string         `json:"nextPageToken,omitempty"` // The page token for the next page of shared drives.
+}
+
+// https://developers.google.com/drive/api/reference/rest/v3/drives#resource:-drive
+type SharedDrive struct {
+	Kind                string                   `json:"kind,omitempty"`                // drive#drive
+	ID                  string                   `json:"id,omitempty"`                  // The ID of this shared drive which is also the ID of the top level folder of this shared drive.
+	Name                string                   `json:"name,omitempty"`                // The name of this shared drive.
+	ColorRgb            string                   `json:"colorRgb,omitempty"`            // The color of this shared drive as an RGB hex string.
+	BackgroundImageLink string                   `json:"backgroundImageLink,omitempty"` // A short-lived link to this shared drive's background image.
+	ThemeID             string                   `json:"themeId,omitempty"`             // The ID of the theme from which the background image and color will be set. Only settable on create.
+	CreatedTime         string                   `json:"createdTime,omitempty"`         // The time at which the shared drive was created (RFC 3339 date-time).
+	Hidden              bool                     `json:"hidden,omitempty"`              // Whether the shared drive is hidden from default view.
+	OrgUnitID           string                   `json:"orgUnitId,omitempty"`           // The organizational unit of this shared drive. Only populated on drives.list responses when the useDomainAdminAccess parameter is set to true.
+	Capabilities        map[string]bool          `json:"capabilities,omitempty"`        // Capabilities the current user has on this shared drive, e.g. `canAddChildren`.
+	Restrictions        *SharedDriveRestrictions `json:"restrictions,omitempty"`        // A set of restrictions that apply to this shared drive or items inside this shared drive.
+}
+
+// SharedDriveRestrictions are optional (see pkg/common/optional): a nil restriction is left unchanged by UpdateSharedDrive
+type SharedDriveRestrictions struct {
+	AdminManagedRestrictions                  *bool `json:"adminManagedRestrictions,omitempty"`                  // Whether administrative privileges on this shared drive are required to modify restrictions.
+	CopyRequiresWriterPermission              *bool `json:"copyRequiresWriterPermission,omitempty"`              // Whether the options to copy, print, or download files inside this shared drive, should be disabled for readers and commenters.
+	DomainUsersOnly                           *bool `json:"domainUsersOnly,omitempty"`                           // Whether access to this shared drive and items inside this shared drive is restricted to users of the domain to which this shared drive belongs.
+	DriveMembersOnly                          *bool `json:"driveMembersOnly,omitempty"`                          // Whether access to items inside this shared drive is restricted to its members.
+	SharingFoldersRequiresOrganizerPermission *bool `json:"sharingFoldersRequiresOrganizerPermission,omitempty"` // If true, only users with the organizer role can share folders.
+}
+
+/*
+ * DLPFinding is a single detector hit within a scanned Drive file
+ * **ReGo only**
+ */
+type DLPFinding struct {
+	FileID      string   `json:"file_id,omitempty"`       // The ID of the file the finding was raised for.
+	FileName    string   `json:"file_name,omitempty"`     // The name of the file.
+	Path        string   `json:"path,omitempty"`          // The full Drive path of the file.
+	Owner       string   `json:"owner,omitempty"`         // The email address of the file owner.
+	MimeType    string   `json:"mime_type,omitempty"`     // The MIME type of the file.
+	WebViewLink string   `json:"web_view_link,omitempty"` // A link for opening the file in a browser.
+	Sharing     string   `json:"sharing,omitempty"`       // The widest audience the file is shared with. {anyone, domain, external, internal, private}
+	Detector    string   `json:"detector,omitempty"`      // The name of the detector which matched.
+	Matches     int      `json:"matches,omitempty"`       // The number of matches within the file content.
+	Samples     []string `json:"samples,omitempty"`       // Redacted samples of the matched content.
+}
+
 // END OF GOOGLE DRIVE STRUCTS
 //---------------------------------------------------------------------
 
+// ### Drive Labels Structs
+// ---------------------------------------------------------------------
+// https://developers.google.com/drive/labels/reference/rest/v2/labels/list#response-body
+type DriveLabels struct {
+	Labels        []*DriveLabel `json:"labels,omitempty"`        // Labels.
+	NextPageToken string        `json:"nextPageToken,omitempty"` // The token of the next page in the response.
+}
+
+// https://developers.google.com/drive/labels/reference/rest/v2/labels#Label
+type DriveLabel struct {
+	Name                string               `json:"name,omitempty"`                // Output only. Resource name of the label. Will be in the form of either: labels/{id} or labels/{id}@{revisionId}.
+	ID                  string               `json:"id,omitempty"`                  // Output only. Globally unique identifier of this label.
+	RevisionID          string               `json:"revisionId,omitempty"`          // Output only. Revision ID of the label.
+	LabelType           string               `json:"labelType,omitempty"`           // Required. The type of label. {SHARED, ADMIN}
+	CreateTime          string               `json:"createTime,omitempty"`          // Output only. The time this label was created.
+	PublishTime         string               `json:"publishTime,omitempty"`         // Output only. The time this label was published.
+	PublishedRevisionID string               `json:"publishedRevisionId,omitempty"` // Output only. The latest published revision ID of the label.
+	Properties          *DriveLabelProps     `json:"properties,omitempty"`          // Required. The basic properties of the label.
+	Lifecycle           *DriveLabelLifecycle `json:"lifecycle,omitempty"`           // Output only. The lifecycle state of the label including whether it's published, deprecated, and has draft changes.
+	Fields              []*DriveLabelField   `json:"fields,omitempty"`              // List of fields in descending priority order.
+}
+
+// https://developers.google.com/drive/labels/reference/rest/v2/labels#Label.Properties
+type DriveLabelProps struct {
+	Title       string `json:"title,omitempty"`       // Required. Title of the label.
+	Description string `json:"description,omitempty"` // The description of the label.
+}
+
+// https://developers.google.com/drive/labels/reference/rest/v2/labels#Lifecycle
+type DriveLabelLifecycle struct {
+	State                 string `json:"state,omitempty"`                 // Output only. The state of the object associated with this lifecycle. {UNPUBLISHED_DRAFT, PUBLISHED, DISABLED, DELETED}
+	HasUnpublishedChanges bool   `json:"hasUnpublishedChanges,omitempty"` // Output only. Whether the object associated with this lifecycle has unpublished changes.
+}
+
+// https://developers.google.com/drive/labels/reference/rest/v2/labels#Field
+type DriveLabelField struct {
+	ID               string                    `json:"id,omitempty"`               // Output only. The key of a field, unique within a label or library.
+	QueryKey         string                    `json:"queryKey,omitempty"`         // Output only. The key to use when constructing Drive search queries to find files based on values defined for this field on files.
+	Properties       *DriveLabelFieldProps     `json:"properties,omitempty"`       // The basic properties of the field.
+	Lifecycle        *DriveLabelLifecycle      `json:"lifecycle,omitempty"`        // Output only. The lifecycle of this field.
+	TextOptions      interface{}               `json:"textOptions,omitempty"`      // Text field options.
+	IntegerOptions   interface{}               `json:"integerOptions,omitempty"`   // Integer field options.
+	DateOptions      interface{}               `json:"dateOptions,omitempty"`      // Date field options.
+	UserOptions      interface{}               `json:"userOptions,omitempty"`      // User field options.
+	SelectionOptions *DriveLabelSelectionField `json:"selectionOptions,omitempty"` // Selection field options.
+}
+
+// https://developers.google.com/drive/labels/reference/rest/v2/labels#Field.Properties
+type DriveLabelFieldProps struct {
+	DisplayName string `json:"displayName,omitempty"` // Required. The display text to show in the UI identifying this field.
+	Required    bool   `json:"required,omitempty"`    // Whether the field should be marked as required.
+}
+
+// https://developers.google.com/drive/labels/reference/rest/v2/labels#SelectionOptions
+type DriveLabelSelectionField struct {
+	ListOptions interface{}         `json:"listOptions,omitempty"` // When specified, indicates this field supports a list of values. Once the field is published, this cannot be changed.
+	Choices     []*DriveLabelChoice `json:"choices,omitempty"`     // The options available for this selection field. The list order is consistent, and modified with insertBeforeChoice.
+}
+
+// https://developers.google.com/drive/labels/reference/rest/v2/labels#Choice
+type DriveLabelChoice struct {
+	ID         string                 `json:"id,omitempty"`         // The unique value of the choice. This ID is autogenerated.
+	Properties *DriveLabelChoiceProps `json:"properties,omitempty"` // Basic properties of the choice.
+	Lifecycle  *DriveLabelLifecycle   `json:"lifecycle,omitempty"`  // Output only. Lifecycle of the choice.
+}
+
+// https://developers.google.com/drive/labels/reference/rest/v2/labels#Choice.Properties
+type DriveLabelChoiceProps struct {
+	DisplayName string `json:"displayName,omitempty"` // Required. The display text to show in the UI identifying this field.
+	Description string `json:"description,omitempty"` // The description of this label.
+}
+
+// https://developers.google.com/drive/labels/reference/rest/v2/labels/delta#request-body
+type DriveLabelDeltaRequest struct {
+	UseAdminAccess bool                     `json:"useAdminAccess,omitempty"` // Set to true in order to use the user's admin credentials.
+	View           string                   `json:"view,omitempty"`           // When specified, only certain fields belonging to the indicated view will be returned.
+	Requests       []*DriveLabelDeltaUpdate `json:"requests,omitempty"`       // A list of updates to apply to the label. Requests will be applied in the order they are specified.
+}
+
+// https://developers.google.com/drive/labels/reference/rest/v2/labels/delta#Request
+type DriveLabelDeltaUpdate struct {
+	UpdateLabel           interface{} `json:"updateLabel,omitempty"`           // Updates the Label properties.
+	CreateField           interface{} `json:"createField,omitempty"`           // Creates a new Field.
+	UpdateField           interface{} `json:"updateField,omitempty"`           // Updates basic properties of a Field.
+	DeleteField           interface{} `json:"deleteField,omitempty"`           // Deletes a Field from the label.
+	CreateSelectionChoice interface{} `json:"createSelectionChoice,omitempty"` // Creates Choice within a Selection field.
+	DeleteSelectionChoice interface{} `json:"deleteSelectionChoice,omitempty"` // Delete a Choice within a Selection Field.
+}
+
+// https://developers.google.com/drive/api/reference/rest/v3/files/modifyLabels#request-body
+type ModifyLabelsRequest struct {
+	Kind               string               `json:"kind,omitempty"`               // This is always drive#modifyLabelsRequest.
+	LabelModifications []*LabelModification `json:"labelModifications,omitempty"` // The list of modifications to apply to the labels on the file.
+}
+
+// https://developers.google.com/drive/api/reference/rest/v3/files/modifyLabels#LabelModification
+type LabelModification struct {
+	Kind               string               `json:"kind,omitempty"`               // This is always drive#labelModification.
+	LabelID            string               `json:"labelId,omitempty"`            // The ID of the label to modify.
+	FieldModifications []*FieldModification `json:"fieldModifications,omitempty"` // The list of modifications to this label's fields.
+	RemoveLabel        bool                 `json:"removeLabel,omitempty"`        // If true, the label will be removed from the file.
+}
+
+// https://developers.google.com/drive/api/reference/rest/v3/files/modifyLabels#FieldModification
+type FieldModification struct {
+	Kind               string   `json:"kind,omitempty"`               // This is always drive#labelFieldModification.
+	FieldID            string   `json:"fieldId,omitempty"`            // The ID of the field to be modified.
+	SetDateValues      []string `json:"setDateValues,omitempty"`      // Replaces the value of a dateString Field with these new values.
+	SetTextValues      []string `json:"setTextValues,omitempty"`      // Sets the value of a text field.
+	SetSelectionValues []string `json:"setSelectionValues,omitempty"` // Replaces a selection field with these new values.
+	SetIntegerValues   []string `json:"setIntegerValues,omitempty"`   // Replaces the value of an `integer` field with these new values.
+	SetUserValues      []string `json:"setUserValues,omitempty"`      // Replaces a user field with these new values. The values must be valid email addresses.
+	UnsetValues        bool     `json:"unsetValues,omitempty"`        // Unsets the values for this field.
+}
+
+// https://developers.google.com/drive/api/reference/rest/v3/files/modifyLabels#response-body
+type ModifyLabelsResponse struct {
+	Kind           string   `json:"kind,omitempty"`           // This is always drive#modifyLabelsResponse.
+	ModifiedLabels []*Label `json:"modifiedLabels,omitempty"` // The list of labels which were added or updated by the request.
+}
+
+// END OF DRIVE LABELS STRUCTS
+//---------------------------------------------------------------------
+
 // ### Spreadsheet Structs
 // ---------------------------------------------------------------------
 // Spreadsheet represents a spreadsheet.
@@ -764,10 +987,24 @@ type ErrorValue interface{}
 // DataValidationRule represents a rule for data validation.
 // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/other#ExtendedValue
 type DataValidationRule struct {
-	Condition    bool   `json:"condition,omitempty"`    // Condition for the data validation
-	InputMessage string `json:"inputMessage,omitempty"` // Input message for the data validation
-	Strict       bool   `json:"strict,omitempty"`       // Whether the data validation is strict
-	ShowCustomUi bool   `json:"showCustomUi,omitempty"` // Whether to show a custom UI for the data validation
+	Condition    *BooleanCondition `json:"condition,omitempty"`    // Condition for the data validation
+	InputMessage string            `json:"inputMessage,omitempty"` // Input message for the data validation
+	Strict       bool              `json:"strict,omitempty"`       // Whether the data validation is strict
+	ShowCustomUi bool              `json:"showCustomUi,omitempty"` // Whether to show a custom UI for the data validation
+}
+
+// BooleanCondition is a condition evaluated to true or false, e.g. `ONE_OF_LIST` with the values allowed.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/other#booleancondition
+type BooleanCondition struct {
+	Type   string           `json:"type,omitempty"`   // Type of the condition, e.g. NUMBER_GREATER, TEXT_CONTAINS, ONE_OF_LIST, CUSTOM_FORMULA
+	Values []ConditionValue `json:"values,omitempty"` // Values of the condition; how many depends on its type
+}
+
+// ConditionValue is a value of a BooleanCondition.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/other#conditionvalue
+type ConditionValue struct {
+	RelativeDate     string `json:"relativeDate,omitempty"`     // A date relative to today, e.g. PAST_WEEK, TODAY
+	UserEnteredValue string `json:"userEnteredValue,omitempty"` // A value, parsed as if entered by the user; formulas start with '='
 }
 
 // PivotTable represents a pivot table.
@@ -937,8 +1174,15 @@ type ConditionalFormatRule struct {
 }
 
 // SortSpec represents a sort specification.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/other#sortspec
 type SortSpec struct {
-	// ...
+	DimensionIndex            int                        `json:"dimensionIndex,omitempty"`            // Index of the dimension (column) to sort by
+	SortOrder                 SortOrder                  `json:"sortOrder,omitempty"`                 // Order to sort by. {ASCENDING, DESCENDING}
+	ForegroundColor           *Color                     `json:"foregroundColor,omitempty"`           // Text color to sort by; cells of this color are sorted first
+	ForegroundColorStyle      *ColorStyle                `json:"foregroundColorStyle,omitempty"`      // Text color style to sort by
+	BackgroundColor           *Color                     `json:"backgroundColor,omitempty"`           // Fill color to sort by; cells of this color are sorted first
+	BackgroundColorStyle      *ColorStyle                `json:"backgroundColorStyle,omitempty"`      // Fill color style to sort by
+	DataSourceColumnReference *DataSourceColumnReference `json:"dataSourceColumnReference,omitempty"` // Reference to a data source column to sort by
 }
 
 // FilterCriteria represents filter criteria.
@@ -1057,75 +1301,76 @@ type SheetBatchRequest struct {
 // SheetRequest represents a single kind of update to apply to a spreadsheet.
 // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#Request
 type SheetRequest struct {
-	AddChart                     interface{}                       `json:"addChart,omitempty"`                     // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#addchartrequest
-	AddConditionalFormatRule     interface{}                       `json:"addConditionalFormatRule,omitempty"`     // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#addconditionalformatrulerequest
-	AddDataSource                interface{}                       `json:"addDataSource,omitempty"`                // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#adddatasourcerequest
-	AddDimensionGroup            interface{}                       `json:"addDimensionGroup,omitempty"`            // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#adddimensiongrouprequest
-	AddFilterView                interface{}                       `json:"addFilterView,omitempty"`                // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#addfilterviewrequest
-	AddNamedRange                interface{}                       `json:"addNamedRange,omitempty"`                // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#addnamedrangerequest
-	AddProtectedRange            interface{}                       `json:"addProtectedRange,omitempty"`            // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#addprotectedrangerequest
-	AddSheet                     interface{}                       `json:"addSheet,omitempty"`                     // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#addsheetrequest
-	AddSlicer                    interface{}                       `json:"addSlicer,omitempty"`                    // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#addslicerrequest
-	AppendCells                  interface{}                       `json:"appendCells,omitempty"`                  // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#appendcellsrequest
-	AppendDimension              interface{}                       `json:"appendDimension,omitempty"`              // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#appenddimensionrequest
-	AutoFill                     interface{}                       `json:"autoFill,omitempty"`                     // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#autofillrequest
-	AutoResizeDimensions         interface{}                       `json:"autoResizeDimensions,omitempty"`         // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#autoresizedimensionsrequest
-	ClearBasicFilter             interface{}                       `json:"clearBasicFilter,omitempty"`             // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#clearbasicfilterrequest
-	CopyPaste                    interface{}                       `json:"copyPaste,omitempty"`                    // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#copypasterequest
-	CreateDeveloperMetadata      interface{}                       `json:"createDeveloperMetadata,omitempty"`      // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#createdevelopermetadatarequest
-	CutPaste                     interface{}                       `json:"cutPaste,omitempty"`                     // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#cutpasterequest
-	DeleteBanding                interface{}                       `json:"deleteBanding,omitempty"`                // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deletebandingrequest
-	DeleteConditionalFormatRule  interface{}                       `json:"deleteConditionalFormatRule,omitempty"`  // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deleteconditionalformatrulerequest
-	DeleteDataSource             interface{}                       `json:"deleteDataSource,omitempty"`             // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deletedatasourcerequest
-	DeleteDeveloperMetadata      interface{}                       `json:"deleteDeveloperMetadata,omitempty"`      // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deletedevelopermetadatarequest
-	DeleteDimension              interface{}                       `json:"deleteDimension,omitempty"`              // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deletedimensionrequest
-	DeleteDimensionGroup         interface{}                       `json:"deleteDimensionGroup,omitempty"`         // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deletedimensiongrouprequest
-	DeleteDuplicates             interface{}                       `json:"deleteDuplicates,omitempty"`             // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deleteduplicatesrequest
-	DeleteEmbeddedObject         interface{}                       `json:"deleteEmbeddedObject,omitempty"`         // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deleteembeddedobjectrequest
-	DeleteFilterView             interface{}                       `json:"deleteFilterView,omitempty"`             // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deletefilterviewrequest
-	DeleteNamedRange             interface{}                       `json:"deleteNamedRange,omitempty"`             // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deletenamedrangerequest
-	DeleteProtectedRange         interface{}                       `json:"deleteProtectedRange,omitempty"`         // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deleteprotectedrangerequest
-	DeleteRange                  interface{}                       `json:"deleteRange,omitempty"`                  // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deleterangerequest
-	DeleteSheet                  interface{}                       `json:"deleteSheet,omitempty"`                  // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deletesheetrequest
-	DuplicateFilterView          interface{}                       `json:"duplicateFilterView,omitempty"`          // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#duplicatefilterviewrequest
-	DuplicateSheet               interface{}                       `json:"duplicateSheet,omitempty"`               // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#duplicatesheetrequest
-	FindReplace                  interface{}                       `json:"findReplace,omitempty"`                  // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#findreplacerequest
-	InsertDimension              interface{}                       `json:"insertDimension,omitempty"`              // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#insertdimensionrequest
-	InsertRange                  interface{}                       `json:"insertRange,omitempty"`                  // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#insertrangerequest
-	MergeCells                   interface{}                       `json:"mergeCells,omitempty"`                   // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#mergecellsrequest
-	MoveDimension                interface{}                       `json:"moveDimension,omitempty"`                // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#movedimensionrequest
-	PasteData                    interface{}                       `json:"pasteData,omitempty"`                    // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#pastedatarequest
-	RandomizeRange               interface{}                       `json:"randomizeRange,omitempty"`               // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#randomizerangerequest
-	RefreshDataSource            interface{}                       `json:"refreshDataSource,omitempty"`            // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#refreshdatasourcerequest
-	RepeatCell                   *RepeatCellRequest                `json:"repeatCell,omitempty"`                   // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#repeatcellrequest
-	SetBasicFilter               *SetBasicFilterRequest            `json:"setBasicFilter,omitempty"`               // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#setbasicfilterrequest
-	SetDataValidation            interface{}                       `json:"setDataValidation,omitempty"`            // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#setdatavalidationrequest
-	SortRange                    interface{}                       `json:"sortRange,omitempty"`                    // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#sortrangerequest
-	TextToColumns                interface{}                       `json:"textToColumns,omitempty"`                // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#texttocolumnsrequest
-	TrimWhitespace               interface{}                       `json:"trimWhitespace,omitempty"`               // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#trimwhitespacerequest
-	UpdateBanding                interface{}                       `json:"updateBanding,omitempty"`                // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatebandingrequest
-	UpdateBorders                interface{}                       `json:"updateBorders,omitempty"`                // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatebordersrequest
-	UpdateCells                  interface{}                       `json:"updateCells,omitempty"`                  // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatecellsrequest
-	UpdateChartSpec              interface{}                       `json:"updateChartSpec,omitempty"`              // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatechartspecrequest
-	UpdateConditionalFormatRule  interface{}                       `json:"updateConditionalFormatRule,omitempty"`  // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updateconditionalformatrulerequest
-	UpdateDataSource             interface{}                       `json:"updateDataSource,omitempty"`             // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatedatasourcerequest
-	UpdateDeveloperMetadata      interface{}                       `json:"updateDeveloperMetadata,omitempty"`      // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatedevelopermetadatarequest
-	UpdateDimensionGroup         interface{}                       `json:"updateDimensionGroup,omitempty"`         // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatedimensiongrouprequest
-	UpdateDimensionProperties    *UpdateDimensionPropertiesRequest `json:"updateDimensionProperties,omitempty"`    // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatedimensionpropertiesrequest
-	UpdateEmbeddedObjectBorder   interface{}                       `json:"updateEmbeddedObjectBorder,omitempty"`   // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updateembeddedobjectborderrequest
-	UpdateEmbeddedObjectPosition interface{}                       `json:"updateEmbeddedObjectPosition,omitempty"` // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updateembeddedobjectpositionrequest
-	UpdateFilterView             interface{}                       `json:"updateFilterView,omitempty"`             // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatefilterviewrequest
-	UpdateNamedRange             interface{}                       `json:"updateNamedRange,omitempty"`             // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatenamedrangerequest
-	UpdateProtectedRange         interface{}                       `json:"updateProtectedRange,omitempty"`         // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updateprotectedrangerequest
-	UpdateSheetProperties        interface{}                       `json:"updateSheetProperties,omitempty"`        // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatesheetpropertiesrequest
-	UpdateSlicerSpec             interface{}                       `json:"updateSlicerSpec,omitempty"`             // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updateslicerspecrequest
+	AddChart                     interface{}                         `json:"addChart,omitempty"`                     // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#addchartrequest
+	AddConditionalFormatRule     *AddConditionalFormatRuleRequest    `json:"addConditionalFormatRule,omitempty"`     // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#addconditionalformatrulerequest
+	AddDataSource                interface{}                         `json:"addDataSource,omitempty"`                // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#adddatasourcerequest
+	AddDimensionGroup            interface{}                         `json:"addDimensionGroup,omitempty"`            // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#adddimensiongrouprequest
+	AddFilterView                *AddFilterViewRequest               `json:"addFilterView,omitempty"`                // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#addfilterviewrequest
+	AddNamedRange                *AddNamedRangeRequest               `json:"addNamedRange,omitempty"`                // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#addnamedrangerequest
+	AddProtectedRange            *AddProtectedRangeRequest           `json:"addProtectedRange,omitempty"`            // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#addprotectedrangerequest
+	AddSheet                     *AddSheetRequest                    `json:"addSheet,omitempty"`                     // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#addsheetrequest
+	AddSlicer                    interface{}                         `json:"addSlicer,omitempty"`                    // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#addslicerrequest
+	AppendCells                  *AppendCellsRequest                 `json:"appendCells,omitempty"`                  // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#appendcellsrequest
+	AppendDimension              *AppendDimensionRequest             `json:"appendDimension,omitempty"`              // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#appenddimensionrequest
+	AutoFill                     interface{}                         `json:"autoFill,omitempty"`                     // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#autofillrequest
+	AutoResizeDimensions         *AutoResizeDimensionsRequest        `json:"autoResizeDimensions,omitempty"`         // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#autoresizedimensionsrequest
+	ClearBasicFilter             *ClearBasicFilterRequest            `json:"clearBasicFilter,omitempty"`             // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#clearbasicfilterrequest
+	CopyPaste                    *CopyPasteRequest                   `json:"copyPaste,omitempty"`                    // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#copypasterequest
+	CreateDeveloperMetadata      interface{}                         `json:"createDeveloperMetadata,omitempty"`      // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#createdevelopermetadatarequest
+	CutPaste                     *CutPasteRequest                    `json:"cutPaste,omitempty"`                     // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#cutpasterequest
+	DeleteBanding                interface{}                         `json:"deleteBanding,omitempty"`                // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deletebandingrequest
+	DeleteConditionalFormatRule  *DeleteConditionalFormatRuleRequest `json:"deleteConditionalFormatRule,omitempty"`  // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deleteconditionalformatrulerequest
+	DeleteDataSource             interface{}                         `json:"deleteDataSource,omitempty"`             // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deletedatasourcerequest
+	DeleteDeveloperMetadata      interface{}                         `json:"deleteDeveloperMetadata,omitempty"`      // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deletedevelopermetadatarequest
+	DeleteDimension              *DeleteDimensionRequest             `json:"deleteDimension,omitempty"`              // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deletedimensionrequest
+	DeleteDimensionGroup         interface{}                         `json:"deleteDimensionGroup,omitempty"`         // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deletedimensiongrouprequest
+	DeleteDuplicates             *DeleteDuplicatesRequest            `json:"deleteDuplicates,omitempty"`             // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deleteduplicatesrequest
+	DeleteEmbeddedObject         interface{}                         `json:"deleteEmbeddedObject,omitempty"`         // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deleteembeddedobjectrequest
+	DeleteFilterView             *DeleteFilterViewRequest            `json:"deleteFilterView,omitempty"`             // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deletefilterviewrequest
+	DeleteNamedRange             *DeleteNamedRangeRequest            `json:"deleteNamedRange,omitempty"`             // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deletenamedrangerequest
+	DeleteProtectedRange         *DeleteProtectedRangeRequest        `json:"deleteProtectedRange,omitempty"`         // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deleteprotectedrangerequest
+	DeleteRange                  *DeleteRangeRequest                 `json:"deleteRange,omitempty"`                  // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deleterangerequest
+	DeleteSheet                  *DeleteSheetRequest                 `json:"deleteSheet,omitempty"`                  // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deletesheetrequest
+	DuplicateFilterView          interface{}                         `json:"duplicateFilterView,omitempty"`          // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#duplicatefilterviewrequest
+	DuplicateSheet               *DuplicateSheetRequest              `json:"duplicateSheet,omitempty"`               // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#duplicatesheetrequest
+	FindReplace                  *FindReplaceRequest                 `json:"findReplace,omitempty"`                  // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#findreplacerequest
+	InsertDimension              *InsertDimensionRequest             `json:"insertDimension,omitempty"`              // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#insertdimensionrequest
+	InsertRange                  *InsertRangeRequest                 `json:"insertRange,omitempty"`                  // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#insertrangerequest
+	MergeCells                   *MergeCellsRequest                  `json:"mergeCells,omitempty"`                   // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#mergecellsrequest
+	MoveDimension                *MoveDimensionRequest               `json:"moveDimension,omitempty"`                // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#movedimensionrequest
+	PasteData                    interface{}                         `json:"pasteData,omitempty"`                    // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#pastedatarequest
+	RandomizeRange               interface{}                         `json:"randomizeRange,omitempty"`               // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#randomizerangerequest
+	RefreshDataSource            interface{}                         `json:"refreshDataSource,omitempty"`            // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#refreshdatasourcerequest
+	RepeatCell                   *RepeatCellRequest                  `json:"repeatCell,omitempty"`                   // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#repeatcellrequest
+	SetBasicFilter               *SetBasicFilterRequest              `json:"setBasicFilter,omitempty"`               // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#setbasicfilterrequest
+	SetDataValidation            *SetDataValidationRequest           `json:"setDataValidation,omitempty"`            // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#setdatavalidationrequest
+	SortRange                    *SortRangeRequest                   `json:"sortRange,omitempty"`                    // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#sortrangerequest
+	TextToColumns                interface{}                         `json:"textToColumns,omitempty"`                // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#texttocolumnsrequest
+	TrimWhitespace               *TrimWhitespaceRequest              `json:"trimWhitespace,omitempty"`               // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#trimwhitespacerequest
+	UnmergeCells                 *UnmergeCellsRequest                `json:"unmergeCells,omitempty"`                 // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#unmergecellsrequest
+	UpdateBanding                interface{}                         `json:"updateBanding,omitempty"`                // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatebandingrequest
+	UpdateBorders                *UpdateBordersRequest               `json:"updateBorders,omitempty"`                // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatebordersrequest
+	UpdateCells                  *UpdateCellsRequest                 `json:"updateCells,omitempty"`                  // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatecellsrequest
+	UpdateChartSpec              interface{}                         `json:"updateChartSpec,omitempty"`              // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatechartspecrequest
+	UpdateConditionalFormatRule  interface{}                         `json:"updateConditionalFormatRule,omitempty"`  // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updateconditionalformatrulerequest
+	UpdateDataSource             interface{}                         `json:"updateDataSource,omitempty"`             // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatedatasourcerequest
+	UpdateDeveloperMetadata      interface{}                         `json:"updateDeveloperMetadata,omitempty"`      // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatedevelopermetadatarequest
+	UpdateDimensionGroup         interface{}                         `json:"updateDimensionGroup,omitempty"`         // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatedimensiongrouprequest
+	UpdateDimensionProperties    *UpdateDimensionPropertiesRequest   `json:"updateDimensionProperties,omitempty"`    // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatedimensionpropertiesrequest
+	UpdateEmbeddedObjectBorder   interface{}                         `json:"updateEmbeddedObjectBorder,omitempty"`   // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updateembeddedobjectborderrequest
+	UpdateEmbeddedObjectPosition interface{}                         `json:"updateEmbeddedObjectPosition,omitempty"` // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updateembeddedobjectpositionrequest
+	UpdateFilterView             interface{}                         `json:"updateFilterView,omitempty"`             // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatefilterviewrequest
+	UpdateNamedRange             interface{}                         `json:"updateNamedRange,omitempty"`             // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatenamedrangerequest
+	UpdateProtectedRange         interface{}                         `json:"updateProtectedRange,omitempty"`         // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updateprotectedrangerequest
+	UpdateSheetProperties        *UpdateSheetPropertiesRequest       `json:"updateSheetProperties,omitempty"`        // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatesheetpropertiesrequest
+	UpdateSlicerSpec             interface{}                         `json:"updateSlicerSpec,omitempty"`             // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updateslicerspecrequest
 }
 
 // AutoResizeDimensionsRequest represents a request to auto resize dimensions.
 // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#autoresizedimensionsrequest
 type AutoResizeDimensionsRequest struct {
-	Dimensions                interface{} `json:"dimensions,omitempty"`                // The dimensions to resize on the sheet
-	DataSourceSheetDimensions interface{} `json:"dataSourceSheetDimensions,omitempty"` // The dimensions to resize on the data source sheet
+	Dimensions                *DimensionRange                `json:"dimensions,omitempty"`                // The dimensions to resize on the sheet
+	DataSourceSheetDimensions *DataSourceSheetDimensionRange `json:"dataSourceSheetDimensions,omitempty"` // The dimensions to resize on the data source sheet
 }
 
 // DataSourceSheetDimensionRange represents the data source sheet dimension range object
@@ -1158,6 +1403,294 @@ type UpdateDimensionPropertiesRequest struct {
 	DataSourceSheetRange *DataSourceSheetDimensionRange `json:"dataSourceSheetRange,omitempty"` // Range of the dataSource sheet dimension to update
 }
 
+// GridCoordinate is a single cell of a sheet, by zero-based index.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/other#gridcoordinate
+type GridCoordinate struct {
+	SheetID     int `json:"sheetId,omitempty"`     // ID of the sheet of the cell
+	RowIndex    int `json:"rowIndex,omitempty"`    // Row of the cell
+	ColumnIndex int `json:"columnIndex,omitempty"` // Column of the cell
+}
+
+// Border is a border along a cell.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/cells#border
+type Border struct {
+	Style      string      `json:"style,omitempty"`      // Style of the border. {DOTTED, DASHED, SOLID, SOLID_MEDIUM, SOLID_THICK, NONE, DOUBLE}
+	Color      *Color      `json:"color,omitempty"`      // Color of the border
+	ColorStyle *ColorStyle `json:"colorStyle,omitempty"` // Color style of the border; takes precedence over Color
+}
+
+// AddSheetRequest adds a new sheet; a title and ID are generated when not set.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#addsheetrequest
+type AddSheetRequest struct {
+	Properties *SheetProperties `json:"properties,omitempty"` // Properties of the new sheet
+}
+
+// DeleteSheetRequest deletes a sheet.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deletesheetrequest
+type DeleteSheetRequest struct {
+	SheetID int `json:"sheetId,omitempty"` // ID of the sheet to delete
+}
+
+// DuplicateSheetRequest duplicates the contents of a sheet.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#duplicatesheetrequest
+type DuplicateSheetRequest struct {
+	SourceSheetID    int    `json:"sourceSheetId,omitempty"`    // ID of the sheet to duplicate
+	InsertSheetIndex int    `json:"insertSheetIndex,omitempty"` // Zero-based index of the new sheet
+	NewSheetID       int    `json:"newSheetId,omitempty"`       // ID of the new sheet; generated when not set
+	NewSheetName     string `json:"newSheetName,omitempty"`     // Name of the new sheet; generated when not set
+}
+
+// UpdateSheetPropertiesRequest updates the properties of the sheet with the ID of Properties.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatesheetpropertiesrequest
+type UpdateSheetPropertiesRequest struct {
+	Properties *SheetProperties `json:"properties,omitempty"` // Properties to update
+	Fields     string           `json:"fields,omitempty"`     // Fields of Properties to update, e.g. `title,gridProperties.frozenRowCount`
+}
+
+// UpdateCellsRequest updates the cells of a range, or the cells starting at a coordinate.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatecellsrequest
+type UpdateCellsRequest struct {
+	Rows   []RowData       `json:"rows,omitempty"`   // Data to write
+	Fields string          `json:"fields,omitempty"` // Fields of the cells to update, e.g. `userEnteredValue`, or `*` for all
+	Start  *GridCoordinate `json:"start,omitempty"`  // First cell to write; exclusive with Range
+	Range  *GridRange      `json:"range,omitempty"`  // Range to write; exclusive with Start
+}
+
+// AppendCellsRequest adds rows after the last row with data in a sheet.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#appendcellsrequest
+type AppendCellsRequest struct {
+	SheetID int       `json:"sheetId,omitempty"` // ID of the sheet to append to
+	Rows    []RowData `json:"rows,omitempty"`    // Data to append
+	Fields  string    `json:"fields,omitempty"`  // Fields of the cells to update, e.g. `userEnteredValue`, or `*` for all
+}
+
+// AppendDimensionRequest appends rows or columns to the end of a sheet.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#appenddimensionrequest
+type AppendDimensionRequest struct {
+	SheetID   int    `json:"sheetId,omitempty"`   // ID of the sheet to append to
+	Dimension string `json:"dimension,omitempty"` // Whether to append rows or columns. {ROWS, COLUMNS}
+	Length    int    `json:"length,omitempty"`    // Number of rows or columns to append
+}
+
+// InsertDimensionRequest inserts rows or columns at an index.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#insertdimensionrequest
+type InsertDimensionRequest struct {
+	Range             *DimensionRange `json:"range,omitempty"`             // Rows or columns to insert
+	InheritFromBefore bool            `json:"inheritFromBefore,omitempty"` // Inherit the properties of the dimension before the range, instead of after
+}
+
+// DeleteDimensionRequest deletes rows or columns.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deletedimensionrequest
+type DeleteDimensionRequest struct {
+	Range *DimensionRange `json:"range,omitempty"` // Rows or columns to delete
+}
+
+// MoveDimensionRequest moves rows or columns to another index.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#movedimensionrequest
+type MoveDimensionRequest struct {
+	Source           *DimensionRange `json:"source,omitempty"`           // Rows or columns to move
+	DestinationIndex int             `json:"destinationIndex,omitempty"` // Zero-based index to move them to, before the move
+}
+
+// InsertRangeRequest inserts empty cells in a range, shifting the existing cells.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#insertrangerequest
+type InsertRangeRequest struct {
+	Range          *GridRange `json:"range,omitempty"`          // Range to insert
+	ShiftDimension string     `json:"shiftDimension,omitempty"` // Direction the existing cells are shifted. {ROWS, COLUMNS}
+}
+
+// DeleteRangeRequest deletes the cells of a range, shifting the remaining cells.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deleterangerequest
+type DeleteRangeRequest struct {
+	Range          *GridRange `json:"range,omitempty"`          // Range to delete
+	ShiftDimension string     `json:"shiftDimension,omitempty"` // Direction the remaining cells are shifted. {ROWS, COLUMNS}
+}
+
+// SortRangeRequest sorts the rows of a range.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#sortrangerequest
+type SortRangeRequest struct {
+	Range     *GridRange `json:"range,omitempty"`     // Range to sort
+	SortSpecs []SortSpec `json:"sortSpecs,omitempty"` // Sort order per column; later specs break the ties of earlier ones
+}
+
+// MergeCellsRequest merges the cells of a range.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#mergecellsrequest
+type MergeCellsRequest struct {
+	Range     *GridRange `json:"range,omitempty"`     // Range to merge
+	MergeType string     `json:"mergeType,omitempty"` // How the cells are merged. {MERGE_ALL, MERGE_COLUMNS, MERGE_ROWS}
+}
+
+// UnmergeCellsRequest unmerges the merged cells of a range.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#unmergecellsrequest
+type UnmergeCellsRequest struct {
+	Range *GridRange `json:"range,omitempty"` // Range to unmerge
+}
+
+// FindReplaceRequest finds and replaces text in a range, a sheet, or every sheet.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#findreplacerequest
+type FindReplaceRequest struct {
+	Find            string     `json:"find,omitempty"`            // Value to search for
+	Replacement     string     `json:"replacement,omitempty"`     // Value to replace it with
+	MatchCase       bool       `json:"matchCase,omitempty"`       // Whether the search is case sensitive
+	MatchEntireCell bool       `json:"matchEntireCell,omitempty"` // Whether the value must match the entire cell
+	SearchByRegex   bool       `json:"searchByRegex,omitempty"`   // Whether Find is a regular expression
+	IncludeFormulas bool       `json:"includeFormulas,omitempty"` // Whether formulas are searched too
+	Range           *GridRange `json:"range,omitempty"`           // Range to search; exclusive with SheetID and AllSheets
+	SheetID         int        `json:"sheetId,omitempty"`         // Sheet to search; exclusive with Range and AllSheets
+	AllSheets       bool       `json:"allSheets,omitempty"`       // Search every sheet; exclusive with Range and SheetID
+}
+
+// AddNamedRangeRequest adds a named range.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#addnamedrangerequest
+type AddNamedRangeRequest struct {
+	NamedRange *NamedRange `json:"namedRange,omitempty"` // Named range to add; its ID is generated when not set
+}
+
+// DeleteNamedRangeRequest deletes a named range.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deletenamedrangerequest
+type DeleteNamedRangeRequest struct {
+	NamedRangeID string `json:"namedRangeId,omitempty"` // ID of the named range to delete
+}
+
+// AddProtectedRangeRequest adds a protected range.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#addprotectedrangerequest
+type AddProtectedRangeRequest struct {
+	ProtectedRange *ProtectedRange `json:"protectedRange,omitempty"` // Protected range to add; its ID is generated when not set
+}
+
+// DeleteProtectedRangeRequest deletes a protected range.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deleteprotectedrangerequest
+type DeleteProtectedRangeRequest struct {
+	ProtectedRangeID int `json:"protectedRangeId,omitempty"` // ID of the protected range to delete
+}
+
+// AddConditionalFormatRuleRequest adds a conditional format rule at an index.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#addconditionalformatrulerequest
+type AddConditionalFormatRuleRequest struct {
+	Rule  *ConditionalFormatRule `json:"rule,omitempty"`  // Rule to add
+	Index int                    `json:"index,omitempty"` // Zero-based index of the rule
+}
+
+// DeleteConditionalFormatRuleRequest deletes the conditional format rule at an index.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deleteconditionalformatrulerequest
+type DeleteConditionalFormatRuleRequest struct {
+	SheetID int `json:"sheetId,omitempty"` // ID of the sheet of the rule
+	Index   int `json:"index,omitempty"`   // Zero-based index of the rule
+}
+
+// AddFilterViewRequest adds a filter view.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#addfilterviewrequest
+type AddFilterViewRequest struct {
+	Filter *FilterView `json:"filter,omitempty"` // Filter view to add; its ID is generated when not set
+}
+
+// DeleteFilterViewRequest deletes a filter view.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deletefilterviewrequest
+type DeleteFilterViewRequest struct {
+	FilterID int `json:"filterId,omitempty"` // ID of the filter view to delete
+}
+
+// ClearBasicFilterRequest clears the basic filter of a sheet.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#clearbasicfilterrequest
+type ClearBasicFilterRequest struct {
+	SheetID int `json:"sheetId,omitempty"` // ID of the sheet
+}
+
+// CopyPasteRequest copies the data of a range to another.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#copypasterequest
+type CopyPasteRequest struct {
+	Source           *GridRange `json:"source,omitempty"`           // Range to copy
+	Destination      *GridRange `json:"destination,omitempty"`      // Range to paste to; the source is repeated to fill it
+	PasteType        string     `json:"pasteType,omitempty"`        // What to paste. {PASTE_NORMAL, PASTE_VALUES, PASTE_FORMAT, PASTE_NO_BORDERS, PASTE_FORMULA, PASTE_DATA_VALIDATION, PASTE_CONDITIONAL_FORMATTING}
+	PasteOrientation string     `json:"pasteOrientation,omitempty"` // How the data is oriented when pasting. {NORMAL, TRANSPOSE}
+}
+
+// CutPasteRequest moves the data of a range to a coordinate.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#cutpasterequest
+type CutPasteRequest struct {
+	Source      *GridRange      `json:"source,omitempty"`      // Range to cut
+	Destination *GridCoordinate `json:"destination,omitempty"` // Top-left cell to paste to
+	PasteType   string          `json:"pasteType,omitempty"`   // What to paste. {PASTE_NORMAL, PASTE_VALUES, PASTE_FORMAT, ...}
+}
+
+// SetDataValidationRequest sets, or clears when Rule is nil, the data validation of a range.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#setdatavalidationrequest
+type SetDataValidationRequest struct {
+	Range *GridRange          `json:"range,omitempty"` // Range the rule applies to
+	Rule  *DataValidationRule `json:"rule,omitempty"`  // Rule to set
+}
+
+// TrimWhitespaceRequest trims the whitespace at the start and end of the cells of a range.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#trimwhitespacerequest
+type TrimWhitespaceRequest struct {
+	Range *GridRange `json:"range,omitempty"` // Range to trim
+}
+
+// DeleteDuplicatesRequest removes the rows of a range duplicating an earlier row.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#deleteduplicatesrequest
+type DeleteDuplicatesRequest struct {
+	Range             *GridRange       `json:"range,omitempty"`             // Range to remove duplicates from
+	ComparisonColumns []DimensionRange `json:"comparisonColumns,omitempty"` // Columns compared to find duplicates; every column when empty
+}
+
+// UpdateBordersRequest updates the borders of a range; borders left nil are unchanged.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/request#updatebordersrequest
+type UpdateBordersRequest struct {
+	Range           *GridRange `json:"range,omitempty"`           // Range whose borders are updated
+	Top             *Border    `json:"top,omitempty"`             // Border at the top of the range
+	Bottom          *Border    `json:"bottom,omitempty"`          // Border at the bottom of the range
+	Left            *Border    `json:"left,omitempty"`            // Border at the left of the range
+	Right           *Border    `json:"right,omitempty"`           // Border at the right of the range
+	InnerHorizontal *Border    `json:"innerHorizontal,omitempty"` // Horizontal borders within the range
+	InnerVertical   *Border    `json:"innerVertical,omitempty"`   // Vertical borders within the range
+}
+
+// BatchUpdateSpreadsheetResponse is the response of a batch update, with one reply per request.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/batchUpdate#response-body
+type BatchUpdateSpreadsheetResponse struct {
+	SpreadsheetID      string          `json:"spreadsheetId,omitempty"`      // ID of the spreadsheet updated
+	Replies            []SheetResponse `json:"replies,omitempty"`            // Replies, in the order of the requests; empty for requests without a reply
+	UpdatedSpreadsheet *Spreadsheet    `json:"updatedSpreadsheet,omitempty"` // Spreadsheet after the update, when requested
+}
+
+// SheetResponse is the reply to a single request of a batch update.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/response
+type SheetResponse struct {
+	AddSheet          *SheetPropertiesResponse  `json:"addSheet,omitempty"`          // Properties of the sheet added
+	DuplicateSheet    *SheetPropertiesResponse  `json:"duplicateSheet,omitempty"`    // Properties of the sheet created
+	AddNamedRange     *AddNamedRangeRequest     `json:"addNamedRange,omitempty"`     // Named range added
+	AddProtectedRange *AddProtectedRangeRequest `json:"addProtectedRange,omitempty"` // Protected range added
+	AddFilterView     *AddFilterViewRequest     `json:"addFilterView,omitempty"`     // Filter view added
+	FindReplace       *FindReplaceResponse      `json:"findReplace,omitempty"`       // Number of values changed
+	DeleteDuplicates  *DeleteDuplicatesResponse `json:"deleteDuplicates,omitempty"`  // Number of rows removed
+	TrimWhitespace    *TrimWhitespaceResponse   `json:"trimWhitespace,omitempty"`    // Number of cells changed
+}
+
+// SheetPropertiesResponse is the reply to AddSheetRequest and DuplicateSheetRequest.
+type SheetPropertiesResponse struct {
+	Properties *SheetProperties `json:"properties,omitempty"` // Properties of the new sheet
+}
+
+// FindReplaceResponse is the reply to FindReplaceRequest.
+// https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/response#findreplaceresponse
+type FindReplaceResponse struct {
+	ValuesChanged      int `json:"valuesChanged,omitempty"`      // Number of non-formula cells changed
+	FormulasChanged    int `json:"formulasChanged,omitempty"`    // Number of formula cells changed
+	RowsChanged        int `json:"rowsChanged,omitempty"`        // Number of rows changed
+	SheetsChanged      int `json:"sheetsChanged,omitempty"`      // Number of sheets changed
+	OccurrencesChanged int `json:"occurrencesChanged,omitempty"` // Number of occurrences changed
+}
+
+// DeleteDuplicatesResponse is the reply to DeleteDuplicatesRequest.
+type DeleteDuplicatesResponse struct {
+	DuplicatesRemovedCount int `json:"duplicatesRemovedCount,omitempty"` // Number of rows removed
+}
+
+// TrimWhitespaceResponse is the reply to TrimWhitespaceRequest.
+type TrimWhitespaceResponse struct {
+	CellsChangedCount int `json:"cellsChangedCount,omitempty"` // Number of cells changed
+}
+
 // END OF SPREADSHEET STRUCTS
 //---------------------------------------------------------------------------------------
 
@@ -1228,6 +1761,25 @@ type User struct {
 	Websites                   []Website      `json:"websites,omitempty"`                   // The list of the user's websites
 }
 
+// NewUser is the body of UsersClient.InsertUser; unlike User, the password is a plain string, as the API expects
+// https://developers.google.com/admin-sdk/directory/reference/rest/v1/users/insert
+type NewUser struct {
+	PrimaryEmail               string         `json:"primaryEmail"`                         // User's primary email
+	Name                       UserName       `json:"name"`                                 // User's name; givenName and familyName are required
+	Password                   string         `json:"password,omitempty"`                   // User's password; generated by InsertUser when empty
+	HashFunction               string         `json:"hashFunction,omitempty"`               // Hash function of a pre-hashed password, e.g. `SHA-1` or `crypt`
+	ChangePasswordAtNextLogin  bool           `json:"changePasswordAtNextLogin,omitempty"`  // Force the user to change their password at next login
+	OrgUnitPath                string         `json:"orgUnitPath,omitempty"`                // User's organizational unit path. Default: `/`
+	IncludeInGlobalAddressList *bool          `json:"includeInGlobalAddressList,omitempty"` // List the user in the global address list. Default: true
+	Suspended                  bool           `json:"suspended,omitempty"`                  // Create the user suspended
+	RecoveryEmail              string         `json:"recoveryEmail,omitempty"`              // User's recovery email
+	RecoveryPhone              string         `json:"recoveryPhone,omitempty"`              // User's recovery phone number, in E.164 format
+	ExternalIds                []ExternalID   `json:"externalIds,omitempty"`                // User's external IDs, e.g. their employee ID
+	Organizations              []Organization `json:"organizations,omitempty"`              // User's organizations
+	Phones                     []Phone        `json:"phones,omitempty"`                     // User's phone numbers
+	Relations                  []Relation     `json:"relations,omitempty"`                  // User's relations, e.g. their manager
+}
+
 type Email struct {
 	Address    string `json:"address,omitempty"`    // The user's email address
 	CustomType string `json:"customType,omitempty"` // The custom value if the email address type is custom
@@ -1343,6 +1895,38 @@ type Website struct {
 	Value      string `json:"value,omitempty"`      // The URL of the website
 }
 
+// https://developers.google.com/admin-sdk/directory/reference/rest/v1/users.photos
+type UserPhoto struct {
+	Kind         string `json:"kind,omitempty"`         // The type of the API resource
+	ETag         string `json:"etag,omitempty"`         // ETag of the resource
+	ID           string `json:"id,omitempty"`           // The ID the API uses to uniquely identify the user
+	PrimaryEmail string `json:"primaryEmail,omitempty"` // The user's primary email address
+	MimeType     string `json:"mimeType,omitempty"`     // The photo's MIME type, e.g. `image/jpeg`
+	Height       int    `json:"height,omitempty"`       // Height of the photo in pixels
+	Width        int    `json:"width,omitempty"`        // Width of the photo in pixels
+	PhotoData    string `json:"photoData,omitempty"`    // The photo, web-safe base64 encoded
+}
+
+// Image decodes the photo's data
+func (p *UserPhoto) Image() ([]byte, error) {
+	return base64.URLEncoding.DecodeString(p.PhotoData)
+}
+
+// https://developers.google.com/admin-sdk/directory/reference/rest/v1/users.aliases/list
+type UserAliases struct {
+	Kind    string       `json:"kind,omitempty"`    // The type of the API resource
+	ETag    string       `json:"etag,omitempty"`    // ETag of the resource
+	Aliases []*UserAlias `json:"aliases,omitempty"` // The user's aliases
+}
+
+type UserAlias struct {
+	Kind         string `json:"kind,omitempty"`         // The type of the API resource
+	ETag         string `json:"etag,omitempty"`         // ETag of the resource
+	ID           string `json:"id,omitempty"`           // The unique ID of the user
+	PrimaryEmail string `json:"primaryEmail,omitempty"` // The user's primary email address
+	Alias        string `json:"alias,omitempty"`        // The alias email address
+}
+
 // END OF USER STRUCTS
 //-----------------------------------------------------------------------------
 
@@ -1522,6 +2106,117 @@ type ScreenshotFile struct {
 	Type        string `json:"type,omitempty"`        // File type.
 }
 
+// ChromeOSStatusChanges is the outcome of a batch change of the status of ChromeOS devices
+// https://developers.google.com/admin-sdk/directory/reference/rest/v1/customer.devices.chromeos/batchChangeStatus#response-body
+type ChromeOSStatusChanges struct {
+	Results []*ChromeOSStatusChange `json:"changeChromeOsDeviceStatusResults,omitempty"` // Result of each device
+}
+
+// ChromeOSStatusChange is the result of the status change of a single ChromeOS device
+type ChromeOSStatusChange struct {
+	DeviceID string       `json:"deviceId,omitempty"` // ID of the device
+	Error    *ErrorDetail `json:"error,omitempty"`    // Set when the status of the device could not be changed
+}
+
+// ChromeOSMoves is the outcome of DeviceClient.MoveChromeOSToOU. **ReGo only**
+type ChromeOSMoves struct {
+	OrgUnitPath string   // Organizational unit the devices were moved to
+	Moved       []string // IDs of the devices moved
+	Failed      []string // IDs of the devices of the requests which failed
+}
+
+// CloudDevices is a page of devices from the Cloud Identity Devices API
+// https://cloud.google.com/identity/docs/reference/rest/v1/devices/list
+type CloudDevices struct {
+	Devices       []*CloudDevice `json:"devices,omitempty"`       // Devices of the page
+	NextPageToken string         `json:"nextPageToken,omitempty"` // Token for the next page of results
+}
+
+// CloudDevice represents a device (mobile, ChromeOS or desktop) enrolled in the domain.
+// https://cloud.google.com/identity/docs/reference/rest/v1/devices#Device
+type CloudDevice struct {
+	AssetTag         string   `json:"assetTag,omitempty"`         // Asset tag of the device; the annotated asset ID of ChromeOS devices.
+	Brand            string   `json:"brand,omitempty"`            // Brand of the device, e.g. `google`.
+	CompromisedState string   `json:"compromisedState,omitempty"` // Whether the device is compromised (rooted/jailbroken).
+	CreateTime       string   `json:"createTime,omitempty"`       // When the device first registered with the domain.
+	DeviceID         string   `json:"deviceId,omitempty"`         // Unique identifier assigned by the platform.
+	DeviceType       string   `json:"deviceType,omitempty"`       // Type of the device, e.g. `ANDROID` or `CHROME_OS`.
+	Imei             string   `json:"imei,omitempty"`             // IMEI of the device.
+	LastSyncTime     string   `json:"lastSyncTime,omitempty"`     // Most recent time the device synced.
+	ManagementState  string   `json:"managementState,omitempty"`  // Management state of the device, e.g. `APPROVED`.
+	Manufacturer     string   `json:"manufacturer,omitempty"`     // Manufacturer of the device.
+	Meid             string   `json:"meid,omitempty"`             // MEID of the device.
+	Model            string   `json:"model,omitempty"`            // Model of the device.
+	Name             string   `json:"name,omitempty"`             // Resource name of the device, `devices/{device}`.
+	OsVersion        string   `json:"osVersion,omitempty"`        // OS version of the device.
+	OwnerType        string   `json:"ownerType,omitempty"`        // Who owns the device: `COMPANY` or `BYOD`.
+	SerialNumber     string   `json:"serialNumber,omitempty"`     // Serial number of the device.
+	WifiMacAddresses []string `json:"wifiMacAddresses,omitempty"` // WiFi MAC addresses of the device.
+}
+
+// MobileDevices is a page of mobile devices from the Directory API
+// https://developers.google.com/admin-sdk/directory/reference/rest/v1/mobiledevices/list#response-body
+type MobileDevices struct {
+	Kind          string          `json:"kind,omitempty"`          // The kind of the response
+	Etag          string          `json:"etag,omitempty"`          // ETag of the resource
+	MobileDevices []*MobileDevice `json:"mobiledevices,omitempty"` // List of mobile devices
+	NextPageToken string          `json:"nextPageToken,omitempty"` // Token for the next page of results
+}
+
+// MobileDevice represents a mobile device managed by Google endpoint management.
+// https://developers.google.com/admin-sdk/directory/reference/rest/v1/mobiledevices#MobileDevice
+type MobileDevice struct {
+	AdbStatus                      bool                 `json:"adbStatus,omitempty"`                      // Whether adb (USB debugging) is enabled.
+	Applications                   []*MobileApplication `json:"applications,omitempty"`                   // Applications installed on the device (Android only).
+	BasebandVersion                string               `json:"basebandVersion,omitempty"`                // Baseband version of the device.
+	BootloaderVersion              string               `json:"bootloaderVersion,omitempty"`              // Bootloader version of the device.
+	Brand                          string               `json:"brand,omitempty"`                          // Brand of the device.
+	BuildNumber                    string               `json:"buildNumber,omitempty"`                    // Build number of the device.
+	DefaultLanguage                string               `json:"defaultLanguage,omitempty"`                // Default locale of the device.
+	DeveloperOptionsStatus         bool                 `json:"developerOptionsStatus,omitempty"`         // Whether developer options are enabled.
+	DeviceCompromisedStatus        string               `json:"deviceCompromisedStatus,omitempty"`        // Whether the device is compromised (rooted/jailbroken).
+	DeviceID                       string               `json:"deviceId,omitempty"`                       // Serial number of the device, for Android devices.
+	DevicePasswordStatus           string               `json:"devicePasswordStatus,omitempty"`           // Password status of the device.
+	Email                          []string             `json:"email,omitempty"`                          // Emails of the owners of the device.
+	EncryptionStatus               string               `json:"encryptionStatus,omitempty"`               // Encryption status of the device.
+	Etag                           string               `json:"etag,omitempty"`                           // ETag of the resource.
+	FirstSync                      string               `json:"firstSync,omitempty"`                      // First time the device synced.
+	Hardware                       string               `json:"hardware,omitempty"`                       // Hardware of the device.
+	HardwareID                     string               `json:"hardwareId,omitempty"`                     // Hardware ID of the device, e.g. its IMEI or serial number.
+	Imei                           string               `json:"imei,omitempty"`                           // IMEI of the device.
+	KernelVersion                  string               `json:"kernelVersion,omitempty"`                  // Kernel version of the device.
+	Kind                           string               `json:"kind,omitempty"`                           // Kind of the resource.
+	LastSync                       string               `json:"lastSync,omitempty"`                       // Last time the device synced.
+	ManagedAccountIsOnOwnerProfile bool                 `json:"managedAccountIsOnOwnerProfile,omitempty"` // Whether the managed account is on the owner or the work profile.
+	Manufacturer                   string               `json:"manufacturer,omitempty"`                   // Manufacturer of the device.
+	Meid                           string               `json:"meid,omitempty"`                           // MEID of the device.
+	Model                          string               `json:"model,omitempty"`                          // Model of the device.
+	Name                           []string             `json:"name,omitempty"`                           // Names of the owners of the device.
+	NetworkOperator                string               `json:"networkOperator,omitempty"`                // Mobile network operator of the device.
+	OS                             string               `json:"os,omitempty"`                             // Operating system of the device.
+	OtherAccountsInfo              []string             `json:"otherAccountsInfo,omitempty"`              // Other accounts on the device.
+	Privilege                      string               `json:"privilege,omitempty"`                      // DMAgentPermission (Android only).
+	ReleaseVersion                 string               `json:"releaseVersion,omitempty"`                 // OS release version of the device.
+	ResourceID                     string               `json:"resourceId,omitempty"`                     // Unique identifier of the device, used by the API.
+	SecurityPatchLevel             string               `json:"securityPatchLevel,omitempty"`             // Security patch level of the device.
+	SerialNumber                   string               `json:"serialNumber,omitempty"`                   // Serial number of the device.
+	Status                         string               `json:"status,omitempty"`                         // Status of the device, e.g. `APPROVED`, `PENDING`, `BLOCKED` or `WIPED`.
+	SupportsWorkProfile            bool                 `json:"supportsWorkProfile,omitempty"`            // Whether the device supports a work profile.
+	Type                           string               `json:"type,omitempty"`                           // Type of the device, e.g. `ANDROID` or `IOS_SYNC`.
+	UnknownSourcesStatus           bool                 `json:"unknownSourcesStatus,omitempty"`           // Whether apps from unknown sources can be installed.
+	UserAgent                      string               `json:"userAgent,omitempty"`                      // User agent of the device.
+	WifiMacAddress                 string               `json:"wifiMacAddress,omitempty"`                 // WiFi MAC address of the device.
+}
+
+// MobileApplication is an application installed on a mobile device.
+type MobileApplication struct {
+	DisplayName string   `json:"displayName,omitempty"` // Display name of the application.
+	PackageName string   `json:"packageName,omitempty"` // Package name of the application.
+	Permission  []string `json:"permission,omitempty"`  // Permissions of the application.
+	VersionCode int      `json:"versionCode,omitempty"` // Version code of the application.
+	VersionName string   `json:"versionName,omitempty"` // Version name of the application.
+}
+
 // END OF DEVICE STRUCTS
 //----------------------------------------------------------------------
 
@@ -1586,6 +2281,17 @@ type PolicyModificationRequest struct {
 	UpdateMask      string          `json:"updateMask,omitempty"`      // The field mask to restrict which fields are updated. Must be set if the policy value is being updated.
 }
 
+// https://developers.google.com/chrome/policy/reference/rest/v1/customers.policies.orgunits/batchInherit#request-body
+type PolicyInheritRequests struct {
+	Requests []*PolicyInheritRequest `json:"requests,omitempty"` // The list of requests.
+}
+
+// https://developers.google.com/chrome/policy/reference/rest/v1/customers.policies.orgunits/batchInherit#inheritorgunitpolicyrequest
+type PolicyInheritRequest struct {
+	PolicyTargetKey PolicyTargetKey `json:"policyTargetKey,omitempty"` // https://developers.google.com/chrome/policy/reference/rest/v1/PolicyTargetKey
+	PolicySchema    string          `json:"policySchema,omitempty"`    // The fully qualified name of the policy schema to inherit, e.g. `chrome.users.IncognitoMode`.
+}
+
 // https://developers.google.com/chrome/policy/reference/rest/v1/customers.policies/resolve#ResolvedPolicy
 type ResolvedPolicy struct {
 	TargetKey      PolicyTargetKey `json:"targetKey,omitempty"`      // The target resource for which the resolved policy value applies.
@@ -1778,6 +2484,779 @@ const (
 // END OF CHROME POLICY STRUCTS
 //----------------------------------------------------------------------
 
+// ### Forms Structs
+// ---------------------------------------------------------------------
+// https://developers.google.com/forms/api/reference/rest/v1/forms#Form
+type Form struct {
+	FormID        string      `json:"formId,omitempty"`        // Output only. The form ID.
+	Info          *FormInfo   `json:"info,omitempty"`          // Required. The title and description of the form.
+	Items         []*FormItem `json:"items,omitempty"`         // Required. A list of the form's items, which can include section headers, questions, embedded media, etc.
+	LinkedSheetID string      `json:"linkedSheetId,omitempty"` // Output only. The ID of the linked Google Sheet which is accumulating responses from this Form (if such a Sheet exists).
+	ResponderURI  string      `json:"responderUri,omitempty"`  // Output only. The form URI to share with responders.
+	RevisionID    string      `json:"revisionId,omitempty"`    // Output only. The revision ID of the form.
+}
+
+// https://developers.google.com/forms/api/reference/rest/v1/forms#Info
+type FormInfo struct {
+	Title         string `json:"title,omitempty"`         // Required. The title of the form which is visible to responders.
+	DocumentTitle string `json:"documentTitle,omitempty"` // Output only. The title of the document which is visible in Drive.
+	Description   string `json:"description,omitempty"`   // The description of the form.
+}
+
+// https://developers.google.com/forms/api/reference/rest/v1/forms#Item
+type FormItem struct {
+	ItemID            string            `json:"itemId,omitempty"`            // The item ID.
+	Title             string            `json:"title,omitempty"`             // The title of the item.
+	Description       string            `json:"description,omitempty"`       // The description of the item.
+	QuestionItem      *FormQuestionItem `json:"questionItem,omitempty"`      // Poses a question to the user.
+	QuestionGroupItem interface{}       `json:"questionGroupItem,omitempty"` // Poses one or more questions to the user with a single major prompt.
+	PageBreakItem     interface{}       `json:"pageBreakItem,omitempty"`     // Starts a new page with a title.
+	TextItem          interface{}       `json:"textItem,omitempty"`          // Displays a title and description on the page.
+	ImageItem         interface{}       `json:"imageItem,omitempty"`         // Displays an image on the page.
+	VideoItem         interface{}       `json:"videoItem,omitempty"`         // Displays a video on the page.
+}
+
+// https://developers.google.com/forms/api/reference/rest/v1/forms#QuestionItem
+type FormQuestionItem struct {
+	Question *FormQuestion `json:"question,omitempty"` // Required. The displayed question.
+}
+
+// https://developers.google.com/forms/api/reference/rest/v1/forms#Question
+type FormQuestion struct {
+	QuestionID string `json:"questionId,omitempty"` // Read only. The question ID.
+	Required   bool   `json:"required,omitempty"`   // Whether the question must be answered in order for a respondent to submit their response.
+}
+
+// https://developers.google.com/forms/api/reference/rest/v1/forms.responses/list#response-body
+type FormResponses struct {
+	Responses     []*FormResponse `json:"responses,omitempty"`     // The returned form responses.
+	NextPageToken string          `json:"nextPageToken,omitempty"` // If set, there are more responses. To get the next page of responses, provide this as the pageToken in a future request.
+}
+
+// https://developers.google.com/forms/api/reference/rest/v1/forms.responses#FormResponse
+type FormResponse struct {
+	FormID            string                 `json:"formId,omitempty"`            // Output only. The form ID.
+	ResponseID        string                 `json:"responseId,omitempty"`        // Output only. The response ID.
+	CreateTime        string                 `json:"createTime,omitempty"`        // Output only. Timestamp for the first time the response was submitted.
+	LastSubmittedTime string                 `json:"lastSubmittedTime,omitempty"` // Output only. Timestamp for the most recent time the response was submitted.
+	RespondentEmail   string                 `json:"respondentEmail,omitempty"`   // Output only. The email address (if collected) for the respondent.
+	Answers           map[string]*FormAnswer `json:"answers,omitempty"`           // Output only. The actual answers to the questions, keyed by questionId.
+	TotalScore        float64                `json:"totalScore,omitempty"`        // Output only. The total number of points the respondent received for their submission.
+}
+
+// https://developers.google.com/forms/api/reference/rest/v1/forms.responses#Answer
+type FormAnswer struct {
+	QuestionID  string           `json:"questionId,omitempty"`  // Output only. The question's ID.
+	TextAnswers *FormTextAnswers `json:"textAnswers,omitempty"` // Output only. The specific answers as text.
+}
+
+// https://developers.google.com/forms/api/reference/rest/v1/forms.responses#TextAnswers
+type FormTextAnswers struct {
+	Answers []*FormTextAnswer `json:"answers,omitempty"` // Output only. Answers to a question.
+}
+
+// https://developers.google.com/forms/api/reference/rest/v1/forms.responses#TextAnswer
+type FormTextAnswer struct {
+	Value string `json:"value,omitempty"` // Output only. The answer value.
+}
+
+// END OF FORMS STRUCTS
+//---------------------------------------------------------------------
+
+// ### Apps Script Structs
+// ---------------------------------------------------------------------
+// https://developers.google.com/apps-script/api/reference/rest/v1/projects#Project
+type ScriptProject struct {
+	ScriptID       string      `json:"scriptId,omitempty"`       // The script project's Drive ID.
+	Title          string      `json:"title,omitempty"`          // The title for the project.
+	ParentID       string      `json:"parentId,omitempty"`       // The parent's Drive ID that the script will be attached to. This is usually the ID of a Google Document or Google Sheet.
+	CreateTime     string      `json:"createTime,omitempty"`     // When the script was created.
+	UpdateTime     string      `json:"updateTime,omitempty"`     // When the script was last updated.
+	Creator        *GoogleUser `json:"creator,omitempty"`        // User who originally created the script.
+	LastModifyUser *GoogleUser `json:"lastModifyUser,omitempty"` // User who last modified the script.
+}
+
+// https://developers.google.com/apps-script/api/reference/rest/v1/projects#GoogleAppsScriptTypeUser
+type GoogleUser struct {
+	Domain   string `json:"domain,omitempty"`   // The user's domain.
+	Email    string `json:"email,omitempty"`    // The user's identifying email address.
+	Name     string `json:"name,omitempty"`     // The user's display name.
+	PhotoURL string `json:"photoUrl,omitempty"` // The user's photo.
+}
+
+// https://developers.google.com/apps-script/api/reference/rest/v1/Content
+type ScriptContent struct {
+	ScriptID string        `json:"scriptId,omitempty"` // The script project's Drive ID.
+	Files    []*ScriptFile `json:"files,omitempty"`    // The list of script project files. One of the files is a script manifest; it must be named "appsscript", must have type of JSON, and include the manifest configurations for the project.
+}
+
+// https://developers.google.com/apps-script/api/reference/rest/v1/File
+type ScriptFile struct {
+	Name       string `json:"name,omitempty"`       // The name of the file.
+	Type       string `json:"type,omitempty"`       // The type of the file. {SERVER_JS, HTML, JSON}
+	Source     string `json:"source,omitempty"`     // The file content.
+	CreateTime string `json:"createTime,omitempty"` // Creation date timestamp.
+	UpdateTime string `json:"updateTime,omitempty"` // Last modified date timestamp.
+}
+
+// https://developers.google.com/apps-script/api/reference/rest/v1/projects.versions#Version
+type ScriptVersion struct {
+	ScriptID      string `json:"scriptId,omitempty"`      // The script project's Drive ID.
+	VersionNumber int    `json:"versionNumber,omitempty"` // The incremental ID that is created by Apps Script when a version is created.
+	Description   string `json:"description,omitempty"`   // The description for this version.
+	CreateTime    string `json:"createTime,omitempty"`    // When the version was created.
+}
+
+// https://developers.google.com/apps-script/api/reference/rest/v1/projects.deployments/list#response-body
+type ScriptDeployments struct {
+	Deployments   []*ScriptDeployment `json:"deployments,omitempty"`   // The list of deployments.
+	NextPageToken string              `json:"nextPageToken,omitempty"` // The token that can be used in the next call to get the next page of results.
+}
+
+// https://developers.google.com/apps-script/api/reference/rest/v1/projects.deployments#Deployment
+type ScriptDeployment struct {
+	DeploymentID     string                  `json:"deploymentId,omitempty"`     // The deployment ID for this deployment.
+	DeploymentConfig *ScriptDeploymentConfig `json:"deploymentConfig,omitempty"` // The deployment configuration.
+	UpdateTime       string                  `json:"updateTime,omitempty"`       // Last modified date time stamp.
+	EntryPoints      []interface{}           `json:"entryPoints,omitempty"`      // The deployment's entry points.
+}
+
+// https://developers.google.com/apps-script/api/reference/rest/v1/projects.deployments#DeploymentConfig
+type ScriptDeploymentConfig struct {
+	ScriptID         string `json:"scriptId,omitempty"`         // The script project's Drive ID.
+	VersionNumber    int    `json:"versionNumber,omitempty"`    // The version number on which this deployment is based.
+	ManifestFileName string `json:"manifestFileName,omitempty"` // The manifest file name for this deployment.
+	Description      string `json:"description,omitempty"`      // The description for this deployment.
+}
+
+// https://developers.google.com/apps-script/api/reference/rest/v1/projects.deployments/update#request-body
+type ScriptUpdateDeploymentRequest struct {
+	DeploymentConfig *ScriptDeploymentConfig `json:"deploymentConfig,omitempty"` // The deployment configuration.
+}
+
+// END OF APPS SCRIPT STRUCTS
+//---------------------------------------------------------------------
+
+// ### Slides Structs
+// ---------------------------------------------------------------------
+// https://developers.google.com/slides/api/reference/rest/v1/presentations#Presentation
+type Presentation struct {
+	PresentationID string       `json:"presentationId,omitempty"` // The ID of the presentation.
+	Title          string       `json:"title,omitempty"`          // The title of the presentation.
+	Locale         string       `json:"locale,omitempty"`         // The locale of the presentation, as an IETF BCP 47 language tag.
+	RevisionID     string       `json:"revisionId,omitempty"`     // Output only. The revision ID of the presentation.
+	Slides         []*SlidePage `json:"slides,omitempty"`         // The slides in the presentation.
+	Layouts        []*SlidePage `json:"layouts,omitempty"`        // The layouts in the presentation.
+	Masters        []*SlidePage `json:"masters,omitempty"`        // The slide masters in the presentation.
+	PageSize       *SlidesSize  `json:"pageSize,omitempty"`       // The size of pages in the presentation.
+}
+
+// https://developers.google.com/slides/api/reference/rest/v1/presentations.pages#Page
+type SlidePage struct {
+	ObjectID     string        `json:"objectId,omitempty"`     // The object ID for this page.
+	PageType     string        `json:"pageType,omitempty"`     // The type of the page. {SLIDE, MASTER, LAYOUT, NOTES, NOTES_MASTER}
+	PageElements []interface{} `json:"pageElements,omitempty"` // The page elements rendered on the page.
+	RevisionID   string        `json:"revisionId,omitempty"`   // Output only. The revision ID of the presentation.
+}
+
+// https://developers.google.com/slides/api/reference/rest/v1/Size
+type SlidesSize struct {
+	Width  *SlidesDimension `json:"width,omitempty"`  // The width of the object.
+	Height *SlidesDimension `json:"height,omitempty"` // The height of the object.
+}
+
+// https://developers.google.com/slides/api/reference/rest/v1/Dimension
+type SlidesDimension struct {
+	Magnitude float64 `json:"magnitude,omitempty"` // The magnitude.
+	Unit      string  `json:"unit,omitempty"`      // The units for magnitude. {EMU, PT}
+}
+
+// https://developers.google.com/slides/api/reference/rest/v1/presentations/batchUpdate#request-body
+type SlidesBatchRequest struct {
+	Requests []*SlidesRequest `json:"requests,omitempty"` // A list of updates to apply to the presentation.
+}
+
+// https://developers.google.com/slides/api/reference/rest/v1/presentations/batchUpdate#response-body
+type SlidesBatchResponse struct {
+	PresentationID string        `json:"presentationId,omitempty"` // The presentation the updates were applied to.
+	Replies        []interface{} `json:"replies,omitempty"`        // The reply of the updates. This maps 1:1 with the updates, although replies to some requests may be empty.
+}
+
+// SlidesRequest represents a single kind of update to apply to a presentation.
+// https://developers.google.com/slides/api/reference/rest/v1/presentations/request#Request
+type SlidesRequest struct {
+	CreateSlide       *CreateSlideRequest       `json:"createSlide,omitempty"`       // Creates a new slide.
+	CreateTable       *CreateTableRequest       `json:"createTable,omitempty"`       // Creates a new table.
+	CreateSheetsChart *CreateSheetsChartRequest `json:"createSheetsChart,omitempty"` // Creates an embedded Google Sheets chart.
+	DeleteObject      *DeleteObjectRequest      `json:"deleteObject,omitempty"`      // Deletes a page or page element from the presentation.
+	InsertText        *InsertTextRequest        `json:"insertText,omitempty"`        // Inserts text into a shape or table cell.
+}
+
+// https://developers.google.com/slides/api/reference/rest/v1/presentations/request#CreateSlideRequest
+type CreateSlideRequest struct {
+	ObjectID              string                  `json:"objectId,omitempty"`              // A user-supplied object ID. Must be 5-50 characters.
+	InsertionIndex        int                     `json:"insertionIndex,omitempty"`        // The optional zero-based index indicating where to insert the slides.
+	SlideLayoutReference  *LayoutReference        `json:"slideLayoutReference,omitempty"`  // Layout reference of the slide to be inserted.
+	PlaceholderIDMappings []*LayoutPlaceholderMap `json:"placeholderIdMappings,omitempty"` // An optional list of object ID mappings from the placeholder(s) on the layout to the placeholders that are created on the slide.
+}
+
+// https://developers.google.com/slides/api/reference/rest/v1/presentations/request#LayoutReference
+type LayoutReference struct {
+	PredefinedLayout string `json:"predefinedLayout,omitempty"` // Predefined layout. {BLANK, TITLE, TITLE_AND_BODY, TITLE_ONLY, SECTION_HEADER, ...}
+	LayoutID         string `json:"layoutId,omitempty"`         // Layout ID: the object ID of one of the layouts in the presentation.
+}
+
+// https://developers.google.com/slides/api/reference/rest/v1/presentations/request#LayoutPlaceholderIdMapping
+type LayoutPlaceholderMap struct {
+	LayoutPlaceholder *SlidesPlaceholder `json:"layoutPlaceholder,omitempty"` // The placeholder on a layout that will be applied to a slide.
+	ObjectID          string             `json:"objectId,omitempty"`          // A user-supplied object ID for the placeholder identified above that to be created onto a slide.
+}
+
+// https://developers.google.com/slides/api/reference/rest/v1/presentations.pages/other#Page.Placeholder
+type SlidesPlaceholder struct {
+	Type  string `json:"type,omitempty"`  // The type of the placeholder. {TITLE, BODY, SUBTITLE, CENTERED_TITLE, ...}
+	Index int    `json:"index,omitempty"` // The index of the placeholder.
+}
+
+// https://developers.google.com/slides/api/reference/rest/v1/presentations/request#CreateTableRequest
+type CreateTableRequest struct {
+	ObjectID          string                 `json:"objectId,omitempty"`          // A user-supplied object ID. Must be 5-50 characters.
+	ElementProperties *PageElementProperties `json:"elementProperties,omitempty"` // The element properties for the table.
+	Rows              int                    `json:"rows,omitempty"`              // Number of rows in the table.
+	Columns           int                    `json:"columns,omitempty"`           // Number of columns in the table.
+}
+
+// https://developers.google.com/slides/api/reference/rest/v1/presentations/request#CreateSheetsChartRequest
+type CreateSheetsChartRequest struct {
+	ObjectID          string                 `json:"objectId,omitempty"`          // A user-supplied object ID. Must be 5-50 characters.
+	ElementProperties *PageElementProperties `json:"elementProperties,omitempty"` // The element properties for the chart.
+	SpreadsheetID     string                 `json:"spreadsheetId,omitempty"`     // The ID of the Google Sheets spreadsheet that contains the chart.
+	ChartID           int                    `json:"chartId,omitempty"`           // The ID of the specific chart in the Google Sheets spreadsheet.
+	LinkingMode       string                 `json:"linkingMode,omitempty"`       // The mode with which the chart is linked to the source spreadsheet. {NOT_LINKED_IMAGE, LINKED}
+}
+
+// https://developers.google.com/slides/api/reference/rest/v1/presentations/request#PageElementProperties
+type PageElementProperties struct {
+	PageObjectID string      `json:"pageObjectId,omitempty"` // The object ID of the page where the element is located.
+	Size         *SlidesSize `json:"size,omitempty"`         // The size of the element.
+}
+
+// https://developers.google.com/slides/api/reference/rest/v1/presentations/request#DeleteObjectRequest
+type DeleteObjectRequest struct {
+	ObjectID string `json:"objectId,omitempty"` // The object ID of the page or page element to delete.
+}
+
+// https://developers.google.com/slides/api/reference/rest/v1/presentations/request#InsertTextRequest
+type InsertTextRequest struct {
+	ObjectID       string             `json:"objectId,omitempty"`       // The object ID of the shape or table where the text will be inserted.
+	CellLocation   *TableCellLocation `json:"cellLocation,omitempty"`   // The optional table cell location if the text is to be inserted into a table cell.
+	Text           string             `json:"text,omitempty"`           // The text to be inserted.
+	InsertionIndex int                `json:"insertionIndex,omitempty"` // The index where the text will be inserted, in Unicode code units.
+}
+
+// https://developers.google.com/slides/api/reference/rest/v1/presentations.pages/other#Page.TableCellLocation
+type TableCellLocation struct {
+	RowIndex    int `json:"rowIndex"`    // The 0-based row index.
+	ColumnIndex int `json:"columnIndex"` // The 0-based column index.
+}
+
+// SlideSection is a single slide of a generated deck: a title with an optional table and/or Sheets chart. **ReGo only**
+type SlideSection struct {
+	Title         string     // Title of the slide
+	Headers       []string   // Table header row; no table is created if both Headers and Rows are empty
+	Rows          [][]string // Table body rows
+	SpreadsheetID string     // Spreadsheet holding a chart to embed (optional)
+	ChartID       int        // ID of the chart within SpreadsheetID (optional)
+}
+
+// END OF SLIDES STRUCTS
+//---------------------------------------------------------------------
+
+// ### Tasks Structs
+// ---------------------------------------------------------------------
+// https://developers.google.com/tasks/reference/rest/v1/tasklists/list#response-body
+type TaskLists struct {
+	Kind          string      `json:"kind,omitempty"`          // Type of the resource. This is always "tasks#taskLists".
+	Etag          string      `json:"etag,omitempty"`          // ETag of the resource.
+	NextPageToken string      `json:"nextPageToken,omitempty"` // Token that can be used to request the next page of this result.
+	Items         []*TaskList `json:"items,omitempty"`         // Collection of task lists.
+}
+
+// https://developers.google.com/tasks/reference/rest/v1/tasklists#TaskList
+type TaskList struct {
+	Kind     string  `json:"kind,omitempty"`     // Type of the resource. This is always "tasks#taskList".
+	ID       string  `json:"id,omitempty"`       // Task list identifier.
+	Etag     string  `json:"etag,omitempty"`     // ETag of the resource.
+	Title    string  `json:"title,omitempty"`    // Title of the task list.
+	Updated  string  `json:"updated,omitempty"`  // Last modification time of the task list (as a RFC 3339 timestamp).
+	SelfLink string  `json:"selfLink,omitempty"` // URL pointing to this task list.
+	Tasks    []*Task `json:"tasks,omitempty"`    // Tasks within the list. **ReGo only** (populated by ExportUserTasks)
+}
+
+// https://developers.google.com/tasks/reference/rest/v1/tasks/list#response-body
+type Tasks struct {
+	Kind          string  `json:"kind,omitempty"`          // Type of the resource. This is always "tasks#tasks".
+	Etag          string  `json:"etag,omitempty"`          // ETag of the resource.
+	NextPageToken string  `json:"nextPageToken,omitempty"` // Token used to access the next page of this result.
+	Items         []*Task `json:"items,omitempty"`         // Collection of tasks.
+}
+
+// https://developers.google.com/tasks/reference/rest/v1/tasks#Task
+type Task struct {
+	Kind        string      `json:"kind,omitempty"`        // Type of the resource. This is always "tasks#task".
+	ID          string      `json:"id,omitempty"`          // Task identifier.
+	Etag        string      `json:"etag,omitempty"`        // ETag of the resource.
+	Title       string      `json:"title,omitempty"`       // Title of the task.
+	Updated     string      `json:"updated,omitempty"`     // Last modification time of the task (as a RFC 3339 timestamp).
+	SelfLink    string      `json:"selfLink,omitempty"`    // URL pointing to this task.
+	Parent      string      `json:"parent,omitempty"`      // Parent task identifier. This field is omitted if it is a top-level task.
+	Position    string      `json:"position,omitempty"`    // String indicating the position of the task among its sibling tasks under the same parent task or at the top level.
+	Notes       string      `json:"notes,omitempty"`       // Notes describing the task.
+	Status      string      `json:"status,omitempty"`      // Status of the task. This is either "needsAction" or "completed".
+	Due         string      `json:"due,omitempty"`         // Due date of the task (as a RFC 3339 timestamp).
+	Completed   string      `json:"completed,omitempty"`   // Completion date of the task (as a RFC 3339 timestamp).
+	Deleted     bool        `json:"deleted,omitempty"`     // Flag indicating whether the task has been deleted.
+	Hidden      bool        `json:"hidden,omitempty"`      // Flag indicating whether the task is hidden.
+	Links       []*TaskLink `json:"links,omitempty"`       // Collection of links.
+	WebViewLink string      `json:"webViewLink,omitempty"` // An absolute link to the task in the Google Tasks Web UI.
+}
+
+// https://developers.google.com/tasks/reference/rest/v1/tasks#Task
+type TaskLink struct {
+	Type        string `json:"type,omitempty"`        // Type of the link, e.g. "email".
+	Description string `json:"description,omitempty"` // The description.
+	Link        string `json:"link,omitempty"`        // The URL.
+}
+
+// END OF TASKS STRUCTS
+//---------------------------------------------------------------------
+
+// ### Keep Structs
+// ---------------------------------------------------------------------
+// https://developers.google.com/keep/api/reference/rest/v1/notes/list#response-body
+type KeepNotes struct {
+	Notes         []*KeepNote `json:"notes,omitempty"`         // A page of notes.
+	NextPageToken string      `json:"nextPageToken,omitempty"` // Next page's pageToken field.
+}
+
+// https://developers.google.com/keep/api/reference/rest/v1/notes#Note
+type KeepNote struct {
+	Name        string            `json:"name,omitempty"`        // Output only. The resource name of this note.
+	CreateTime  string            `json:"createTime,omitempty"`  // Output only. When this note was created.
+	UpdateTime  string            `json:"updateTime,omitempty"`  // Output only. When this note was last modified.
+	TrashTime   string            `json:"trashTime,omitempty"`   // Output only. When this note was trashed.
+	Trashed     bool              `json:"trashed,omitempty"`     // Output only. true if this note has been trashed.
+	Attachments []*KeepAttachment `json:"attachments,omitempty"` // Output only. The attachments attached to this note.
+	Permissions []*KeepPermission `json:"permissions,omitempty"` // Output only. The list of permissions set on the note.
+	Title       string            `json:"title,omitempty"`       // The title of the note. Length must be less than 1,000 characters.
+	Body        *KeepNoteSection  `json:"body,omitempty"`        // The body of the note.
+}
+
+// https://developers.google.com/keep/api/reference/rest/v1/notes#Section
+type KeepNoteSection struct {
+	Text *struct {
+		Text string `json:"text,omitempty"` // The text of the note.
+	} `json:"text,omitempty"` // Used if this section's content is a block of text.
+	List *struct {
+		ListItems []*KeepNoteListItem `json:"listItems,omitempty"` // The items in the list.
+	} `json:"list,omitempty"` // Used if this section's content is a list.
+}
+
+// https://developers.google.com/keep/api/reference/rest/v1/notes#ListItem
+type KeepNoteListItem struct {
+	ChildListItems []*KeepNoteListItem `json:"childListItems,omitempty"` // If set, list of list items nested under this list item.
+	Text           *struct {
+		Text string `json:"text,omitempty"` // The text of this item.
+	} `json:"text,omitempty"` // The text of this item.
+	Checked bool `json:"checked,omitempty"` // Whether this item has been checked off or not.
+}
+
+// https://developers.google.com/keep/api/reference/rest/v1/notes#Attachment
+type KeepAttachment struct {
+	Name     string   `json:"name,omitempty"`     // The resource name.
+	MimeType []string `json:"mimeType,omitempty"` // The MIME types (IANA media types) in which the attachment is available.
+}
+
+// https://developers.google.com/keep/api/reference/rest/v1/notes#Permission
+type KeepPermission struct {
+	Name    string `json:"name,omitempty"`    // Output only. The resource name.
+	Role    string `json:"role,omitempty"`    // The role granted by this permission. {OWNER, WRITER}
+	Email   string `json:"email,omitempty"`   // The email associated with the member.
+	Deleted bool   `json:"deleted,omitempty"` // Output only. Whether this member has been deleted.
+}
+
+// END OF KEEP STRUCTS
+//---------------------------------------------------------------------
+
+// ### Calendar Structs
+// ---------------------------------------------------------------------
+// https://developers.google.com/admin-sdk/directory/reference/rest/v1/resources.calendars/list#response-body
+type CalendarResources struct {
+	Etag          string              `json:"etag,omitempty"`          // ETag of the resource.
+	Items         []*CalendarResource `json:"items,omitempty"`         // The CalendarResources in this page of results.
+	Kind          string              `json:"kind,omitempty"`          // Identifies this as a collection of CalendarResources.
+	NextPageToken string              `json:"nextPageToken,omitempty"` // The continuation token, used to page through large result sets.
+}
+
+// https://developers.google.com/admin-sdk/directory/reference/rest/v1/resources.calendars#CalendarResource
+type CalendarResource struct {
+	BuildingID             string `json:"buildingId,omitempty"`             // Unique ID for the building a resource is located in.
+	Capacity               int    `json:"capacity,omitempty"`               // Capacity of a resource, number of seats in a room.
+	FloorName              string `json:"floorName,omitempty"`              // Name of the floor a resource is located on.
+	GeneratedResourceName  string `json:"generatedResourceName,omitempty"`  // The read-only auto-generated name of the calendar resource.
+	ResourceCategory       string `json:"resourceCategory,omitempty"`       // The category of the calendar resource. {CONFERENCE_ROOM, OTHER}
+	ResourceEmail          string `json:"resourceEmail,omitempty"`          // The read-only email for the calendar resource. Generated as part of creating a new calendar resource.
+	ResourceID             string `json:"resourceId,omitempty"`             // The unique ID for the calendar resource.
+	ResourceName           string `json:"resourceName,omitempty"`           // The name of the calendar resource. For example, "Training Room 1A".
+	ResourceType           string `json:"resourceType,omitempty"`           // The type of the calendar resource, intended for non-room resources.
+	UserVisibleDescription string `json:"userVisibleDescription,omitempty"` // Description of the resource, visible to users and admins.
+}
+
+// https://developers.google.com/calendar/api/v3/reference/events/list#response
+type CalendarEventList struct {
+	Items         []*CalendarEvent `json:"items,omitempty"`         // List of events on the calendar.
+	NextPageToken string           `json:"nextPageToken,omitempty"` // Token used to access the next page of this result.
+	Summary       string           `json:"summary,omitempty"`       // Title of the calendar.
+	TimeZone      string           `json:"timeZone,omitempty"`      // The time zone of the calendar.
+}
+
+// https://developers.google.com/calendar/api/v3/reference/events#resource
+type CalendarEvent struct {
+	Attendees        []*CalendarAttendee `json:"attendees,omitempty"`        // The attendees of the event.
+	Created          string              `json:"created,omitempty"`          // Creation time of the event (as a RFC3339 timestamp).
+	Creator          *CalendarPerson     `json:"creator,omitempty"`          // The creator of the event.
+	End              *CalendarTime       `json:"end,omitempty"`              // The (exclusive) end time of the event.
+	HtmlLink         string              `json:"htmlLink,omitempty"`         // An absolute link to this event in the Google Calendar Web UI.
+	ID               string              `json:"id,omitempty"`               // Opaque identifier of the event.
+	Organizer        *CalendarPerson     `json:"organizer,omitempty"`        // The organizer of the event.
+	Recurrence       []string            `json:"recurrence,omitempty"`       // List of RRULE, EXRULE, RDATE and EXDATE lines for a recurring event.
+	RecurringEventID string              `json:"recurringEventId,omitempty"` // For an instance of a recurring event, the id of the recurring event to which this instance belongs.
+	Start            *CalendarTime       `json:"start,omitempty"`            // The (inclusive) start time of the event.
+	Status           string              `json:"status,omitempty"`           // Status of the event. {confirmed, tentative, cancelled}
+	Summary          string              `json:"summary,omitempty"`          // Title of the event.
+	Transparency     string              `json:"transparency,omitempty"`     // Whether the event blocks time on the calendar. {opaque, transparent}
+	Updated          string              `json:"updated,omitempty"`          // Last modification time of the event (as a RFC3339 timestamp).
+}
+
+// https://developers.google.com/calendar/api/v3/reference/events#resource
+type CalendarAttendee struct {
+	DisplayName    string `json:"displayName,omitempty"`    // The attendee's name, if available.
+	Email          string `json:"email,omitempty"`          // The attendee's email address, if available.
+	Optional       bool   `json:"optional,omitempty"`       // Whether this is an optional attendee.
+	Organizer      bool   `json:"organizer,omitempty"`      // Whether the attendee is the organizer of the event.
+	Resource       bool   `json:"resource,omitempty"`       // Whether the attendee is a resource, e.g. a room.
+	ResponseStatus string `json:"responseStatus,omitempty"` // The attendee's response status. {needsAction, declined, tentative, accepted}
+	Self           bool   `json:"self,omitempty"`           // Whether this entry represents the calendar on which this copy of the event appears.
+}
+
+// https://developers.google.com/calendar/api/v3/reference/events#resource
+type CalendarPerson struct {
+	DisplayName string `json:"displayName,omitempty"` // The person's name, if available.
+	Email       string `json:"email,omitempty"`       // The person's email address, if available.
+	Self        bool   `json:"self,omitempty"`        // Whether the person corresponds to the calendar on which this copy of the event appears.
+}
+
+// https://developers.google.com/calendar/api/v3/reference/events#resource
+type CalendarTime struct {
+	Date     string `json:"date,omitempty"`     // The date, in the format "yyyy-mm-dd", if this is an all-day event.
+	DateTime string `json:"dateTime,omitempty"` // The time, as a combined date-time value (formatted according to RFC3339).
+	TimeZone string `json:"timeZone,omitempty"` // The time zone in which the time is specified.
+}
+
+// END OF CALENDAR STRUCTS
+//---------------------------------------------------------------------
+
+// ### Licensing Structs
+// ---------------------------------------------------------------------
+// https://developers.google.com/admin-sdk/licensing/reference/rest/v1/licenseAssignments/listForProductAndSku#response-body
+type LicenseAssignments struct {
+	Kind          string               `json:"kind,omitempty"`          // Identifies the resource as a collection of license assignments: licensing#licenseAssignmentList
+	Etag          string               `json:"etag,omitempty"`          // ETag of the resource.
+	Items         []*LicenseAssignment `json:"items,omitempty"`         // The LicenseAssignments in this page of results.
+	NextPageToken string               `json:"nextPageToken,omitempty"` // The token that you must submit in a subsequent request to retrieve additional license results.
+}
+
+// https://developers.google.com/admin-sdk/licensing/reference/rest/v1/licenseAssignments#LicenseAssignment
+type LicenseAssignment struct {
+	Kind        string `json:"kind,omitempty"`        // Identifies the resource as a LicenseAssignment: licensing#licenseAssignment
+	Etags       string `json:"etags,omitempty"`       // ETag of the resource.
+	SelfLink    string `json:"selfLink,omitempty"`    // Link to this page.
+	UserID      string `json:"userId,omitempty"`      // The user's current primary email address.
+	ProductID   string `json:"productId,omitempty"`   // A product's unique identifier.
+	ProductName string `json:"productName,omitempty"` // Display Name of the product.
+	SkuID       string `json:"skuId,omitempty"`       // A product SKU's unique identifier.
+	SkuName     string `json:"skuName,omitempty"`     // Display Name of the sku of the product.
+}
+
+// LicenseChanges is the outcome of LicensingClient.ReconcileLicenses. **ReGo only**
+type LicenseChanges struct {
+	ProductID  string            // Product reconciled
+	SkuID      string            // SKU reconciled
+	Assigned   []string          // Users assigned the SKU
+	Reassigned []string          // Users moved to the SKU from another SKU of the product
+	Removed    []string          // Users whose license was removed
+	Failed     []*LicenseFailure // Changes which could not be applied
+}
+
+// LicenseFailure is a single license change which could not be applied. **ReGo only**
+type LicenseFailure struct {
+	User   string // User the change applied to
+	Action string // assign, reassign or remove
+	Err    error  // Error returned by the API
+}
+
+// END OF LICENSING STRUCTS
+//---------------------------------------------------------------------
+
+// ### Groups Structs
+// ---------------------------------------------------------------------
+// https://developers.google.com/admin-sdk/directory/reference/rest/v1/groups/list#response-body
+type Groups struct {
+	Kind          string   `json:"kind,omitempty"`          // Kind of resource this is: admin#directory#groups
+	Etag          string   `json:"etag,omitempty"`          // ETag of the resource.
+	Groups        []*Group `json:"groups,omitempty"`        // A list of group objects.
+	NextPageToken string   `json:"nextPageToken,omitempty"` // Token used to access next page of this result.
+}
+
+// https://developers.google.com/admin-sdk/directory/reference/rest/v1/groups#Group
+type Group struct {
+	Kind               string   `json:"kind,omitempty"`               // The type of the API resource: admin#directory#group
+	Etag               string   `json:"etag,omitempty"`               // ETag of the resource.
+	ID                 string   `json:"id,omitempty"`                 // The unique ID of a group.
+	Email              string   `json:"email,omitempty"`              // The group's email address.
+	Name               string   `json:"name,omitempty"`               // The group's display name.
+	Description        string   `json:"description,omitempty"`        // An extended description to help users determine the purpose of a group.
+	DirectMembersCount string   `json:"directMembersCount,omitempty"` // The number of users that are direct members of the group.
+	AdminCreated       bool     `json:"adminCreated,omitempty"`       // Value is true if this group was created by an administrator rather than a user.
+	Aliases            []string `json:"aliases,omitempty"`            // A list of a group's alias email addresses.
+	NonEditableAliases []string `json:"nonEditableAliases,omitempty"` // A list of the group's non-editable alias email addresses that are outside of the account's primary domain or subdomains.
+}
+
+// https://developers.google.com/admin-sdk/groups-settings/v1/reference/groups#resource
+// Settings are strings, e.g. "true" or "ALL_MEMBERS_CAN_POST"; unset settings are left unchanged on update
+type GroupSettings struct {
+	Kind                 string `json:"kind,omitempty"`                 // The type of the resource: groupsSettings#groups
+	Email                string `json:"email,omitempty"`                // The group's email address.
+	Name                 string `json:"name,omitempty"`                 // Name of the group.
+	Description          string `json:"description,omitempty"`          // Description of the group.
+	ArchiveOnly          string `json:"archiveOnly,omitempty"`          // Whether the group is archive-only: no new messages can be posted. {true, false}
+	IsArchived           string `json:"isArchived,omitempty"`           // Whether the contents of the group are archived. {true, false}
+	AllowExternalMembers string `json:"allowExternalMembers,omitempty"` // Whether members external to the organization can join the group. {true, false}
+	WhoCanJoin           string `json:"whoCanJoin,omitempty"`           // Who can join the group. {ANYONE_CAN_JOIN, ALL_IN_DOMAIN_CAN_JOIN, INVITED_CAN_JOIN, CAN_REQUEST_TO_JOIN}
+	WhoCanPostMessage    string `json:"whoCanPostMessage,omitempty"`    // Who can post messages to the group. {NONE_CAN_POST, ALL_MANAGERS_CAN_POST, ALL_MEMBERS_CAN_POST, ALL_OWNERS_CAN_POST, ALL_IN_DOMAIN_CAN_POST, ANYONE_CAN_POST}
+	WhoCanViewMembership string `json:"whoCanViewMembership,omitempty"` // Who can view the members of the group. {ALL_IN_DOMAIN_CAN_VIEW, ALL_MEMBERS_CAN_VIEW, ALL_MANAGERS_CAN_VIEW, ALL_OWNERS_CAN_VIEW}
+	WhoCanViewGroup      string `json:"whoCanViewGroup,omitempty"`      // Who can view the messages of the group. {ANYONE_CAN_VIEW, ALL_IN_DOMAIN_CAN_VIEW, ALL_MEMBERS_CAN_VIEW, ALL_MANAGERS_CAN_VIEW, ALL_OWNERS_CAN_VIEW}
+}
+
+// https://developers.google.com/admin-sdk/directory/reference/rest/v1/members/list#response-body
+type Members struct {
+	Kind          string    `json:"kind,omitempty"`          // Kind of resource this is: admin#directory#members
+	Etag          string    `json:"etag,omitempty"`          // ETag of the resource.
+	Members       []*Member `json:"members,omitempty"`       // A list of member objects.
+	NextPageToken string    `json:"nextPageToken,omitempty"` // Token used to access next page of this result.
+}
+
+// https://developers.google.com/admin-sdk/directory/reference/rest/v1/members#Member
+type Member struct {
+	Kind             string `json:"kind,omitempty"`              // The type of the API resource: admin#directory#member
+	Etag             string `json:"etag,omitempty"`              // ETag of the resource.
+	ID               string `json:"id,omitempty"`                // The unique ID of the group member.
+	Email            string `json:"email,omitempty"`             // The member's email address.
+	Role             string `json:"role,omitempty"`              // The member's role in a group. {OWNER, MANAGER, MEMBER}
+	Type             string `json:"type,omitempty"`              // The type of group member. {CUSTOMER, EXTERNAL, GROUP, USER}
+	Status           string `json:"status,omitempty"`            // Status of member (Immutable).
+	DeliverySettings string `json:"delivery_settings,omitempty"` // Defines mail delivery preferences of member. {ALL_MAIL, DAILY, DIGEST, DISABLED, NONE}
+}
+
+// MembershipChanges is the outcome of GroupsClient.SetMembers. **ReGo only**
+type MembershipChanges struct {
+	Group   string               // Email of the group
+	Added   []string             // Members added (or already present)
+	Removed []string             // Members removed (or already absent)
+	Failed  []*MembershipFailure // Changes which could not be applied
+}
+
+// MembershipFailure is a single membership change which could not be applied. **ReGo only**
+type MembershipFailure struct {
+	Email  string // Member the change applied to
+	Action string // POST (add) or DELETE (remove)
+	Err    error  // Error returned by the API
+}
+
+// https://developers.google.com/admin-sdk/groups-migration/v1/reference/archive/insert#response
+type GroupsMigrationResult struct {
+	Kind         string `json:"kind,omitempty"`         // The kind of insert resource this is, `groupsmigration#groups`
+	ResponseCode string `json:"responseCode,omitempty"` // The status of the insert request, `SUCCESS` when the message was archived
+}
+
+// GroupArchive is the outcome of exporting or importing the archive of a group. **ReGo only**
+type GroupArchive struct {
+	Group    string                 // Email of the group
+	Messages int                    // Messages exported or imported
+	Failed   []*GroupArchiveFailure // Messages which could not be exported or imported
+}
+
+// GroupArchiveFailure is a single message of a group archive which could not be exported or imported. **ReGo only**
+type GroupArchiveFailure struct {
+	Message string // Gmail message ID (export), or position in the mbox (import)
+	Err     error  // Error returned by the API
+}
+
+// END OF GROUPS STRUCTS
+//---------------------------------------------------------------------
+
+// ### Gmail Structs
+// ---------------------------------------------------------------------
+// https://developers.google.com/gmail/api/reference/rest/v1/users.settings.delegates/list#response-body
+type GmailDelegateList struct {
+	Delegates []*GmailDelegate `json:"delegates,omitempty"` // List of the user's delegates (with any verification status).
+}
+
+// https://developers.google.com/gmail/api/reference/rest/v1/users.settings.delegates#Delegate
+type GmailDelegate struct {
+	DelegateEmail      string `json:"delegateEmail,omitempty"`      // The email address of the delegate.
+	VerificationStatus string `json:"verificationStatus,omitempty"` // Indicates whether this address has been verified and can act as a delegate for the account. {accepted, pending, rejected, expired}
+}
+
+// https://developers.google.com/gmail/api/reference/rest/v1/users.messages/list#response-body
+type GmailMessageList struct {
+	Messages           []*GmailMessage `json:"messages,omitempty"`           // List of messages. Each message resource contains only an id and a threadId.
+	NextPageToken      string          `json:"nextPageToken,omitempty"`      // Token to retrieve the next page of results in the list.
+	ResultSizeEstimate int             `json:"resultSizeEstimate,omitempty"` // Estimated total number of results.
+}
+
+// https://developers.google.com/gmail/api/reference/rest/v1/users.messages#Message
+type GmailMessage struct {
+	ID           string   `json:"id,omitempty"`           // The immutable ID of the message.
+	ThreadID     string   `json:"threadId,omitempty"`     // The ID of the thread the message belongs to.
+	LabelIDs     []string `json:"labelIds,omitempty"`     // List of IDs of labels applied to this message.
+	Snippet      string   `json:"snippet,omitempty"`      // A short part of the message text.
+	InternalDate string   `json:"internalDate,omitempty"` // The internal message creation timestamp (epoch ms).
+	SizeEstimate int      `json:"sizeEstimate,omitempty"` // Estimated size in bytes of the message.
+	Raw          string   `json:"raw,omitempty"`          // The entire email message in an RFC 2822 formatted and base64url encoded string. Returned with `format=raw`.
+}
+
+// https://developers.google.com/gmail/api/reference/rest/v1/users.settings.forwardingAddresses/list#response-body
+type GmailForwardingAddressList struct {
+	ForwardingAddresses []*GmailForwardingAddress `json:"forwardingAddresses,omitempty"` // List of addresses that may be used for forwarding.
+}
+
+// https://developers.google.com/gmail/api/reference/rest/v1/users.settings.forwardingAddresses#ForwardingAddress
+type GmailForwardingAddress struct {
+	ForwardingEmail    string `json:"forwardingEmail,omitempty"`    // An email address to which messages can be forwarded.
+	VerificationStatus string `json:"verificationStatus,omitempty"` // Indicates whether this address has been verified and is usable for forwarding. {accepted, pending}
+}
+
+// https://developers.google.com/gmail/api/reference/rest/v1/AutoForwarding
+type GmailAutoForwarding struct {
+	Enabled      *bool  `json:"enabled,omitempty"`      // Whether all incoming mail is automatically forwarded to another address.
+	EmailAddress string `json:"emailAddress,omitempty"` // Email address to which all incoming messages are forwarded. Must be a verified forwarding address.
+	Disposition  string `json:"disposition,omitempty"`  // The state that a message should be left in after it has been forwarded. {leaveInInbox, archive, trash, markRead}
+}
+
+// https://developers.google.com/gmail/api/reference/rest/v1/users.settings/getVacation#VacationSettings
+type GmailVacationSettings struct {
+	EnableAutoReply       *bool  `json:"enableAutoReply,omitempty"`       // Flag that controls whether Gmail automatically replies to messages.
+	ResponseSubject       string `json:"responseSubject,omitempty"`       // Optional text to prepend to the subject line in vacation responses.
+	ResponseBodyPlainText string `json:"responseBodyPlainText,omitempty"` // Response body in plain text format.
+	ResponseBodyHTML      string `json:"responseBodyHtml,omitempty"`      // Response body in HTML format. Takes precedence over the plain text body.
+	RestrictToContacts    *bool  `json:"restrictToContacts,omitempty"`    // Flag that determines whether responses are sent to recipients who are not in the user's list of contacts.
+	RestrictToDomain      *bool  `json:"restrictToDomain,omitempty"`      // Flag that determines whether responses are sent to recipients who are outside of the user's domain.
+	StartTime             int64  `json:"startTime,omitempty,string"`      // Start time for sending auto-replies (epoch ms).
+	EndTime               int64  `json:"endTime,omitempty,string"`        // End time for sending auto-replies (epoch ms).
+}
+
+// https://developers.google.com/gmail/api/reference/rest/v1/users.settings.sendAs/list#response-body
+type GmailSendAsList struct {
+	SendAs []*GmailSendAs `json:"sendAs,omitempty"` // List of send-as aliases.
+}
+
+// https://developers.google.com/gmail/api/reference/rest/v1/users.settings.sendAs#SendAs
+type GmailSendAs struct {
+	SendAsEmail        string        `json:"sendAsEmail,omitempty"`        // The email address that appears in the "From:" header for mail sent using this alias.
+	DisplayName        string        `json:"displayName,omitempty"`        // A name that appears in the "From:" header for mail sent using this alias.
+	ReplyToAddress     string        `json:"replyToAddress,omitempty"`     // An optional email address that is included in a "Reply-To:" header for mail sent using this alias.
+	Signature          string        `json:"signature,omitempty"`          // An optional HTML signature that is included in messages composed with this alias.
+	IsPrimary          bool          `json:"isPrimary,omitempty"`          // Whether this address is the primary address used to login to the account.
+	IsDefault          *bool         `json:"isDefault,omitempty"`          // Whether this address is selected as the default "From:" address.
+	TreatAsAlias       *bool         `json:"treatAsAlias,omitempty"`       // Whether Gmail should treat this address as an alias for the user's primary email address.
+	SMTPMsa            *GmailSMTPMsa `json:"smtpMsa,omitempty"`            // An optional SMTP service that will be used as an outbound relay for mail sent using this alias.
+	VerificationStatus string        `json:"verificationStatus,omitempty"` // Indicates whether this address has been verified for use as a send-as alias. {accepted, pending}
+}
+
+// https://developers.google.com/gmail/api/reference/rest/v1/users.settings.sendAs#SmtpMsa
+type GmailSMTPMsa struct {
+	Host         string `json:"host,omitempty"`         // The hostname of the SMTP service.
+	Port         int    `json:"port,omitempty"`         // The port of the SMTP service.
+	Username     string `json:"username,omitempty"`     // The username that will be used for authentication with the SMTP service.
+	Password     string `json:"password,omitempty"`     // The password that will be used for authentication with the SMTP service. Write-only.
+	SecurityMode string `json:"securityMode,omitempty"` // The protocol that will be used to secure communication with the SMTP service. {none, ssl, starttls}
+}
+
+// END OF GMAIL STRUCTS
+//---------------------------------------------------------------------
+
+// ### Data Transfer Structs
+// ---------------------------------------------------------------------
+// https://developers.google.com/admin-sdk/data-transfer/reference/rest/v1/applications/list#response-body
+type DataTransferApplications struct {
+	Kind          string                     `json:"kind,omitempty"`          // Identifies the resource as a collection of Applications.
+	Etag          string                     `json:"etag,omitempty"`          // ETag of the resource.
+	Applications  []*DataTransferApplication `json:"applications,omitempty"`  // The list of applications that support data transfer and are also installed for the customer.
+	NextPageToken string                     `json:"nextPageToken,omitempty"` // Token to specify the next page in the list.
+}
+
+// https://developers.google.com/admin-sdk/data-transfer/reference/rest/v1/applications#Application
+type DataTransferApplication struct {
+	ID             string                      `json:"id,omitempty"`             // The application's ID.
+	Name           string                      `json:"name,omitempty"`           // The application's name.
+	TransferParams []*ApplicationTransferParam `json:"transferParams,omitempty"` // The list of all possible transfer parameters for this application.
+}
+
+// https://developers.google.com/admin-sdk/data-transfer/reference/rest/v1/applications#ApplicationTransferParam
+type ApplicationTransferParam struct {
+	Key   string   `json:"key,omitempty"`   // The type of the transfer parameter, e.g. `PRIVACY_LEVEL`
+	Value []string `json:"value,omitempty"` // The value of the transfer parameter, e.g. `PRIVATE` and `SHARED`
+}
+
+// https://developers.google.com/admin-sdk/data-transfer/reference/rest/v1/transfers#DataTransfer
+type DataTransfer struct {
+	Kind                      string                     `json:"kind,omitempty"`                                         // Identifies the resource as a DataTransfer request.
+	Etag                      string                     `json:"etag,omitempty"`                                         // ETag of the resource.
+	ID                        string                     `json:"id,omitempty"`                                           // Read-only. The transfer's ID.
+	OldOwnerUserID            string                     `json:"oldOwnerUserId,omitempty" validate:"required"`           // ID of the user whose data is being transferred.
+	NewOwnerUserID            string                     `json:"newOwnerUserId,omitempty" validate:"required"`           // ID of the user to whom the data is being transferred.
+	ApplicationDataTransfers  []*ApplicationDataTransfer `json:"applicationDataTransfers,omitempty" validate:"required"` // The list of per-application data transfer resources.
+	OverallTransferStatusCode string                     `json:"overallTransferStatusCode,omitempty"`                    // Read-only. Overall transfer status. {new, inProgress, completed, failed}
+	RequestTime               string                     `json:"requestTime,omitempty"`                                  // Read-only. The time at which the data transfer was requested.
+}
+
+// https://developers.google.com/admin-sdk/data-transfer/reference/rest/v1/transfers#ApplicationDataTransfer
+type ApplicationDataTransfer struct {
+	ApplicationID             string                      `json:"applicationId,omitempty" validate:"required"` // The application's ID.
+	ApplicationTransferParams []*ApplicationTransferParam `json:"applicationTransferParams,omitempty"`         // The transfer parameters for the application.
+	ApplicationTransferStatus string                      `json:"applicationTransferStatus,omitempty"`         // Read-only. Current status of transfer for this application.
+}
+
+// END OF DATA TRANSFER STRUCTS
+//---------------------------------------------------------------------
+
+// ### Data Inventory Structs
+// ---------------------------------------------------------------------
+// DataInventory sizes the data a user owns, to plan the transfer effort when offboarding. **ReGo only**
+type DataInventory struct {
+	Email            string   // Primary email of the user
+	DriveFiles       int      // Files owned in My Drive (trashed files excluded)
+	DriveBytes       int64    // Storage quota used by the owned files
+	SharedExternally int      // Owned files shared with anyone, or with users, groups or domains outside the user's domain
+	GmailDelegates   []string // Delegates of the mailbox
+	GroupsOwned      []string // Groups where the user is an owner
+	Err              error    // First error while taking the inventory; the counts may be partial
+}
+
+// END OF DATA INVENTORY STRUCTS
+//---------------------------------------------------------------------
+
 // ### Enums
 // ---------------------------------------------------------------------
 // https://developers.google.com/admin-sdk/directory/reference/rest/v1/users/list#event
@@ -0,0 +1,108 @@
+/*
+# Google Workspace - Common Events
+
+This package converts Reports API activities (e.g. the Admin audit log) into the provider neutral (ECS shaped) events of `pkg/common/events`
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/events.go
+package google
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/events"
+)
+
+// applicationCategories maps Reports API applications onto `event.category`
+var applicationCategories = map[string][]string{
+	"admin":         {events.CategoryIAM, events.CategoryConfiguration},
+	"login":         {events.CategoryAuthentication},
+	"saml":          {events.CategoryAuthentication},
+	"token":         {events.CategoryAuthentication},
+	"user_accounts": {events.CategoryIAM},
+	"groups":        {events.CategoryIAM},
+	"drive":         {events.CategoryFile},
+}
+
+/*
+ * # Common Events
+ * Converts an activity into a common event per activity event
+ * - The application becomes the dataset, e.g. `google.admin`, and the event name the action, e.g. `CHANGE_PASSWORD`
+ * - The USER_EMAIL parameter (the user acted upon) becomes `user.target`
+ * - `login_success`/`login_failure` set the outcome; the Reports API does not report one for other events
+ */
+func (r *Report) CommonEvents() []*events.Event {
+	when, _ := time.Parse(time.RFC3339, r.ID.Time)
+
+	out := make([]*events.Event, 0, len(r.Events))
+	for i, e := range r.Events {
+		event := events.New("google", r.ID.ApplicationName, e.Name, when)
+		event.Event.ID = r.ID.UniqueQualifier
+		if len(r.Events) > 1 {
+			event.Event.ID = fmt.Sprintf("%s-%d", r.ID.UniqueQualifier, i)
+		}
+		event.Event.Category = applicationCategories[r.ID.ApplicationName]
+		event.Message = strings.ToLower(strings.ReplaceAll(e.Name, "_", " "))
+
+		switch strings.ToLower(e.Name) {
+		case "login_success":
+			event.Event.Outcome = events.OutcomeSuccess
+		case "login_failure":
+			event.Event.Outcome = events.OutcomeFailure
+		}
+
+		actor := r.Actor.Email
+		if actor == "" {
+			actor = r.Actor.Key
+		}
+		event.User = &events.User{
+			ID:     r.Actor.ProfileID,
+			Name:   actor,
+			Email:  r.Actor.Email,
+			Domain: r.OwnerDomain,
+		}
+
+		target := parameterValue(e.Parameters, "USER_EMAIL")
+		if target != "" {
+			event.User.Target = &events.User{Name: target, Email: target}
+		}
+
+		if r.IPAddress != "" {
+			event.Source = &events.Source{IP: r.IPAddress}
+		}
+		event.Relate([]string{actor, target}, []string{r.IPAddress})
+
+		event.Label("google_event_type", e.Type)
+		event.Label("google_caller_type", r.Actor.CallerType)
+		event.Label("google_customer_id", r.ID.CustomerID)
+
+		out = append(out, event)
+	}
+
+	return out
+}
+
+// CommonEvents converts every activity into common events
+func CommonEvents(activities []Report) []*events.Event {
+	out := []*events.Event{}
+	for i := range activities {
+		out = append(out, activities[i].CommonEvents()...)
+	}
+	return out
+}
+
+// parameterValue returns the value of the named (top level) parameter
+func parameterValue(params []ReportParameter, name string) string {
+	for _, p := range params {
+		if p.Name == name {
+			return p.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,116 @@
+/*
+# Google Workspace - Forms
+
+This package initializes all the methods for functions which interact with the Google Forms API:
+https://developers.google.com/forms/api/reference/rest
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/forms.go
+package google
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+)
+
+var (
+	FormsBaseURL = "https://forms.googleapis.com/v1"
+	Forms        = fmt.Sprintf("%s/forms", FormsBaseURL) // https://developers.google.com/forms/api/reference/rest/v1/forms
+)
+
+// FormsClient for chaining methods
+type FormsClient struct {
+	*Client
+}
+
+// Entry point for forms-related operations
+func (c *Client) Forms() *FormsClient {
+	fc := &FormsClient{
+		Client: c,
+	}
+
+	// https://developers.google.com/forms/api/limits
+	fc.HTTP.RateLimiter.Available = 975
+	fc.HTTP.RateLimiter.Limit = 975
+	fc.HTTP.RateLimiter.Interval = 1 * time.Minute
+	fc.HTTP.RateLimiter.Log.Verbosity = c.Log.Verbosity
+
+	return fc
+}
+
+/*
+ * Query Parameters for Form Responses
+ * Reference: https://developers.google.com/forms/api/reference/rest/v1/forms.responses/list#query-parameters
+ */
+type FormResponseQuery struct {
+	Filter    string `url:"filter,omitempty"`    // Which form responses to return. Currently, the only supported filters are `timestamp > N` and `timestamp >= N`, where N is an RFC3339 timestamp.
+	PageSize  int    `url:"pageSize,omitempty"`  // The maximum number of responses to return. The service may return fewer than this value. If unspecified or zero, at most 5000 responses are returned.
+	PageToken string `url:"pageToken,omitempty"` // A page token returned by a previous list response. If this field is set, the form and the values of the filter must be the same as for the original request.
+}
+
+/*
+ * # Get a Form
+ * /v1/forms/{formId}
+ * - https://developers.google.com/forms/api/reference/rest/v1/forms/get
+ */
+func (c *FormsClient) GetForm(formID string) (*Form, error) {
+	url := c.BuildURL(Forms, nil, formID)
+
+	var cache Form
+	if c.GetCache(url, &cache) {
+		return &cache, nil
+	}
+
+	form, err := do[Form](c.Client, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.SetCache(url, form, 5*time.Minute)
+	return &form, nil
+}
+
+/*
+ * # List all Responses to a Form
+ * /v1/forms/{formId}/responses
+ * - https://developers.google.com/forms/api/reference/rest/v1/forms.responses/list
+ */
+func (c *FormsClient) ListAllResponses(formID string, q *FormResponseQuery) (*FormResponses, error) {
+	url := c.BuildURL(Forms, nil, formID, "responses")
+
+	if q == nil {
+		q = &FormResponseQuery{}
+	}
+
+	responses, err := requests.PaginatedDo(c.HTTP, "GET", url, q, requests.Pagination[*FormResponse]{
+		Strategy: requests.TokenPages{SizeParam: "pageSize"},
+		Items:    requests.ItemsField[*FormResponse]("responses"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &FormResponses{Responses: responses}, nil
+}
+
+/*
+ * # Get a single Response to a Form
+ * /v1/forms/{formId}/responses/{responseId}
+ * - https://developers.google.com/forms/api/reference/rest/v1/forms.responses/get
+ */
+func (c *FormsClient) GetResponse(formID, responseID string) (*FormResponse, error) {
+	url := c.BuildURL(Forms, nil, formID, "responses", responseID)
+
+	response, err := do[FormResponse](c.Client, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,123 @@
+/*
+# Google Workspace - Gmail
+
+This package initializes all the methods for functions which interact with the Gmail settings API:
+https://developers.google.com/gmail/api/reference/rest/v1/users.settings
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/gmail.go
+package google
+
+import (
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+)
+
+var (
+	GmailBaseURL   = fmt.Sprintf("%s/gmail/v1", BaseURL)                               // https://developers.google.com/gmail/api/reference/rest
+	GmailDelegates = fmt.Sprintf("%s/users/%s/settings/delegates", GmailBaseURL, "%s") // https://developers.google.com/gmail/api/reference/rest/v1/users.settings.delegates
+	GmailMessages  = fmt.Sprintf("%s/users/%s/messages", GmailBaseURL, "%s")           // https://developers.google.com/gmail/api/reference/rest/v1/users.messages
+	GmailSettings  = fmt.Sprintf("%s/users/%s/settings", GmailBaseURL, "%s")           // https://developers.google.com/gmail/api/reference/rest/v1/users.settings
+)
+
+// GmailClient for chaining methods
+type GmailClient struct {
+	*Client
+}
+
+// Entry point for gmail-related operations
+//   - The Gmail API only exposes the calling user's mailbox; impersonate the target user (Client.ImpersonateUser) first.
+func (c *Client) Gmail() *GmailClient {
+	gc := &GmailClient{
+		Client: c,
+	}
+
+	// https://developers.google.com/gmail/api/reference/quota
+	gc.HTTP.RateLimiter.Available = 15000
+	gc.HTTP.RateLimiter.Limit = 15000
+	gc.HTTP.RateLimiter.Interval = 1 * time.Minute
+	gc.HTTP.RateLimiter.Log.Verbosity = c.Log.Verbosity
+
+	return gc
+}
+
+/*
+ * # List the Delegates of a Mailbox
+ * /gmail/v1/users/{userId}/settings/delegates
+ * - https://developers.google.com/gmail/api/reference/rest/v1/users.settings.delegates/list
+ * - `userId` may be `me` for the impersonated user
+ */
+func (c *GmailClient) ListDelegates(userID string) (*GmailDelegateList, error) {
+	url := fmt.Sprintf(GmailDelegates, userID)
+
+	delegates, err := do[GmailDelegateList](c.Client, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &delegates, nil
+}
+
+/*
+ * Query Parameters for Gmail Messages
+ * Reference: https://developers.google.com/gmail/api/reference/rest/v1/users.messages/list#query-parameters
+ */
+type GmailMessageQuery struct {
+	IncludeSpamTrash bool   `url:"includeSpamTrash,omitempty"` // Include messages from SPAM and TRASH in the results.
+	MaxResults       int    `url:"maxResults,omitempty"`       // Maximum number of messages to return. Max allowed value is 500.
+	PageToken        string `url:"pageToken,omitempty"`        // Page token to retrieve a specific page of results in the list.
+	Q                string `url:"q,omitempty"`                // Only return messages matching the specified query, e.g. `list:team@example.com`
+}
+
+/*
+ * # List the Messages of a Mailbox
+ * /gmail/v1/users/{userId}/messages
+ * - https://developers.google.com/gmail/api/reference/rest/v1/users.messages/list
+ * - `query` uses the Gmail search syntax; only IDs are returned, see GetRawMessage
+ */
+func (c *GmailClient) ListMessages(userID string, query string) (*GmailMessageList, error) {
+	url := fmt.Sprintf(GmailMessages, userID)
+
+	q := &GmailMessageQuery{
+		MaxResults: 500,
+		Q:          query,
+	}
+
+	messages, err := requests.PaginatedDo(c.HTTP, "GET", url, q, requests.Pagination[*GmailMessage]{
+		Items: requests.ItemsField[*GmailMessage]("messages"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &GmailMessageList{Messages: messages}, nil
+}
+
+/*
+ * # Get the Raw (RFC 2822) Content of a Message
+ * /gmail/v1/users/{userId}/messages/{id}?format=raw
+ * - https://developers.google.com/gmail/api/reference/rest/v1/users.messages/get
+ */
+func (c *GmailClient) GetRawMessage(userID string, messageID string) ([]byte, error) {
+	url := fmt.Sprintf("%s/%s", fmt.Sprintf(GmailMessages, userID), messageID)
+
+	q := struct {
+		Format string `url:"format"`
+	}{
+		Format: "raw",
+	}
+
+	message, err := do[GmailMessage](c.Client, "GET", url, q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return base64.URLEncoding.DecodeString(message.Raw)
+}
@@ -0,0 +1,271 @@
+/*
+# Google Workspace - Gmail Settings
+
+This package initializes the methods managing the delegates, forwarding, vacation responder and send-as aliases of a
+mailbox through the Gmail settings API, e.g. when offboarding a user:
+https://developers.google.com/gmail/api/reference/rest/v1/users.settings
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/gmail_settings.go
+package google
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/optional"
+)
+
+// What Gmail does with a message after forwarding it
+const (
+	ForwardLeaveInInbox = "leaveInInbox"
+	ForwardArchive      = "archive"
+	ForwardTrash        = "trash"
+	ForwardMarkRead     = "markRead"
+)
+
+/*
+ * # Get a Delegate of a Mailbox
+ * /gmail/v1/users/{userId}/settings/delegates/{delegateEmail}
+ * - https://developers.google.com/gmail/api/reference/rest/v1/users.settings.delegates/get
+ */
+func (c *GmailClient) GetDelegate(userID, delegateEmail string) (*GmailDelegate, error) {
+	url := c.BuildURL(fmt.Sprintf(GmailDelegates, userID), nil, delegateEmail)
+
+	return do[*GmailDelegate](c.Client, "GET", url, nil, nil)
+}
+
+/*
+ * # Add a Delegate to a Mailbox
+ * /gmail/v1/users/{userId}/settings/delegates
+ * - https://developers.google.com/gmail/api/reference/rest/v1/users.settings.delegates/create
+ * - The delegate must be in the same organization; delegates added by an administrator are accepted without verification
+ */
+func (c *GmailClient) AddDelegate(userID, delegateEmail string) (*GmailDelegate, error) {
+	url := fmt.Sprintf(GmailDelegates, userID)
+
+	return do[*GmailDelegate](c.Client, "POST", url, nil, &GmailDelegate{DelegateEmail: delegateEmail})
+}
+
+/*
+ * # Remove a Delegate from a Mailbox
+ * /gmail/v1/users/{userId}/settings/delegates/{delegateEmail}
+ * - https://developers.google.com/gmail/api/reference/rest/v1/users.settings.delegates/delete
+ */
+func (c *GmailClient) RemoveDelegate(userID, delegateEmail string) error {
+	url := c.BuildURL(fmt.Sprintf(GmailDelegates, userID), nil, delegateEmail)
+
+	_, err := do[interface{}](c.Client, "DELETE", url, nil, nil)
+	return err
+}
+
+/*
+ * # List the Forwarding Addresses of a Mailbox
+ * /gmail/v1/users/{userId}/settings/forwardingAddresses
+ * - https://developers.google.com/gmail/api/reference/rest/v1/users.settings.forwardingAddresses/list
+ */
+func (c *GmailClient) ListForwardingAddresses(userID string) (*GmailForwardingAddressList, error) {
+	url := c.BuildURL(fmt.Sprintf(GmailSettings, userID), nil, "forwardingAddresses")
+
+	return do[*GmailForwardingAddressList](c.Client, "GET", url, nil, nil)
+}
+
+/*
+ * # Add a Forwarding Address to a Mailbox
+ * /gmail/v1/users/{userId}/settings/forwardingAddresses
+ * - https://developers.google.com/gmail/api/reference/rest/v1/users.settings.forwardingAddresses/create
+ * - Addresses outside the organization stay `pending` until their owner confirms the verification email
+ */
+func (c *GmailClient) AddForwardingAddress(userID, forwardingEmail string) (*GmailForwardingAddress, error) {
+	url := c.BuildURL(fmt.Sprintf(GmailSettings, userID), nil, "forwardingAddresses")
+
+	return do[*GmailForwardingAddress](c.Client, "POST", url, nil, &GmailForwardingAddress{ForwardingEmail: forwardingEmail})
+}
+
+/*
+ * # Remove a Forwarding Address from a Mailbox
+ * /gmail/v1/users/{userId}/settings/forwardingAddresses/{forwardingEmail}
+ * - https://developers.google.com/gmail/api/reference/rest/v1/users.settings.forwardingAddresses/delete
+ * - Disables auto-forwarding when it forwards to this address
+ */
+func (c *GmailClient) RemoveForwardingAddress(userID, forwardingEmail string) error {
+	url := c.BuildURL(fmt.Sprintf(GmailSettings, userID), nil, "forwardingAddresses", forwardingEmail)
+
+	_, err := do[interface{}](c.Client, "DELETE", url, nil, nil)
+	return err
+}
+
+/*
+ * # Get the Auto-Forwarding Setting of a Mailbox
+ * /gmail/v1/users/{userId}/settings/autoForwarding
+ * - https://developers.google.com/gmail/api/reference/rest/v1/users.settings/getAutoForwarding
+ */
+func (c *GmailClient) GetAutoForwarding(userID string) (*GmailAutoForwarding, error) {
+	url := c.BuildURL(fmt.Sprintf(GmailSettings, userID), nil, "autoForwarding")
+
+	return do[*GmailAutoForwarding](c.Client, "GET", url, nil, nil)
+}
+
+/*
+ * # Update the Auto-Forwarding Setting of a Mailbox
+ * /gmail/v1/users/{userId}/settings/autoForwarding
+ * - https://developers.google.com/gmail/api/reference/rest/v1/users.settings/updateAutoForwarding
+ * - The forwarding address must already be `accepted`, see AddForwardingAddress
+ */
+func (c *GmailClient) UpdateAutoForwarding(userID string, forwarding *GmailAutoForwarding) (*GmailAutoForwarding, error) {
+	url := c.BuildURL(fmt.Sprintf(GmailSettings, userID), nil, "autoForwarding")
+
+	return do[*GmailAutoForwarding](c.Client, "PUT", url, nil, forwarding)
+}
+
+/*
+ * # Forward a Mailbox
+ * Adds `forwardingEmail` as a forwarding address (when missing), then forwards every new message to it
+ * - `disposition` is what happens to the original message, e.g. ForwardArchive; ForwardLeaveInInbox when empty
+ */
+func (c *GmailClient) ForwardMailbox(userID, forwardingEmail, disposition string) (*GmailAutoForwarding, error) {
+	if disposition == "" {
+		disposition = ForwardLeaveInInbox
+	}
+
+	addresses, err := c.ListForwardingAddresses(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var address *GmailForwardingAddress
+	for _, a := range addresses.ForwardingAddresses {
+		if strings.EqualFold(a.ForwardingEmail, forwardingEmail) {
+			address = a
+		}
+	}
+	if address == nil {
+		address, err = c.AddForwardingAddress(userID, forwardingEmail)
+		if err != nil {
+			return nil, fmt.Errorf("adding forwarding address %s: %w", forwardingEmail, err)
+		}
+	}
+	if address.VerificationStatus != "accepted" {
+		return nil, fmt.Errorf("forwarding address %s is %s; it must be verified before forwarding", forwardingEmail, address.VerificationStatus)
+	}
+
+	c.Log.Printf("Forwarding the mailbox of %s to %s", userID, forwardingEmail)
+	return c.UpdateAutoForwarding(userID, &GmailAutoForwarding{Enabled: optional.Of(true), EmailAddress: forwardingEmail, Disposition: disposition})
+}
+
+/*
+ * # Get the Vacation Responder of a Mailbox
+ * /gmail/v1/users/{userId}/settings/vacation
+ * - https://developers.google.com/gmail/api/reference/rest/v1/users.settings/getVacation
+ */
+func (c *GmailClient) GetVacation(userID string) (*GmailVacationSettings, error) {
+	url := c.BuildURL(fmt.Sprintf(GmailSettings, userID), nil, "vacation")
+
+	return do[*GmailVacationSettings](c.Client, "GET", url, nil, nil)
+}
+
+/*
+ * # Update the Vacation Responder of a Mailbox
+ * /gmail/v1/users/{userId}/settings/vacation
+ * - https://developers.google.com/gmail/api/reference/rest/v1/users.settings/updateVacation
+ */
+func (c *GmailClient) UpdateVacation(userID string, vacation *GmailVacationSettings) (*GmailVacationSettings, error) {
+	url := c.BuildURL(fmt.Sprintf(GmailSettings, userID), nil, "vacation")
+
+	return do[*GmailVacationSettings](c.Client, "PUT", url, nil, vacation)
+}
+
+/*
+ * # Set an Auto-Reply on a Mailbox
+ * Enables the vacation responder with a plain text message; a zero `end` leaves it on until disabled
+ */
+func (c *GmailClient) SetAutoReply(userID, subject, body string, start, end time.Time) (*GmailVacationSettings, error) {
+	vacation := &GmailVacationSettings{
+		EnableAutoReply:       optional.Of(true),
+		ResponseSubject:       subject,
+		ResponseBodyPlainText: body,
+	}
+	if !start.IsZero() {
+		vacation.StartTime = start.UnixMilli()
+	}
+	if !end.IsZero() {
+		vacation.EndTime = end.UnixMilli()
+	}
+
+	return c.UpdateVacation(userID, vacation)
+}
+
+/*
+ * # Disable the Auto-Reply of a Mailbox
+ * /gmail/v1/users/{userId}/settings/vacation
+ * - https://developers.google.com/gmail/api/reference/rest/v1/users.settings/updateVacation
+ */
+func (c *GmailClient) DisableAutoReply(userID string) (*GmailVacationSettings, error) {
+	return c.UpdateVacation(userID, &GmailVacationSettings{EnableAutoReply: optional.Of(false)})
+}
+
+/*
+ * # List the Send-As Aliases of a Mailbox
+ * /gmail/v1/users/{userId}/settings/sendAs
+ * - https://developers.google.com/gmail/api/reference/rest/v1/users.settings.sendAs/list
+ * - Includes the primary address of the mailbox
+ */
+func (c *GmailClient) ListSendAs(userID string) (*GmailSendAsList, error) {
+	url := c.BuildURL(fmt.Sprintf(GmailSettings, userID), nil, "sendAs")
+
+	return do[*GmailSendAsList](c.Client, "GET", url, nil, nil)
+}
+
+/*
+ * # Get a Send-As Alias of a Mailbox
+ * /gmail/v1/users/{userId}/settings/sendAs/{sendAsEmail}
+ * - https://developers.google.com/gmail/api/reference/rest/v1/users.settings.sendAs/get
+ */
+func (c *GmailClient) GetSendAs(userID, sendAsEmail string) (*GmailSendAs, error) {
+	url := c.BuildURL(fmt.Sprintf(GmailSettings, userID), nil, "sendAs", sendAsEmail)
+
+	return do[*GmailSendAs](c.Client, "GET", url, nil, nil)
+}
+
+/*
+ * # Create a Send-As Alias on a Mailbox
+ * /gmail/v1/users/{userId}/settings/sendAs
+ * - https://developers.google.com/gmail/api/reference/rest/v1/users.settings.sendAs/create
+ * - Aliases outside the organization need an SmtpMsa, and stay `pending` until verified
+ */
+func (c *GmailClient) CreateSendAs(userID string, sendAs *GmailSendAs) (*GmailSendAs, error) {
+	url := c.BuildURL(fmt.Sprintf(GmailSettings, userID), nil, "sendAs")
+
+	return do[*GmailSendAs](c.Client, "POST", url, nil, sendAs)
+}
+
+/*
+ * # Update a Send-As Alias of a Mailbox
+ * /gmail/v1/users/{userId}/settings/sendAs/{sendAsEmail}
+ * - https://developers.google.com/gmail/api/reference/rest/v1/users.settings.sendAs/patch
+ * - Only the fields set in `sendAs` are changed, e.g. the Signature or DisplayName
+ * - IsDefault and TreatAsAlias are optional, so they can be turned off: `TreatAsAlias: optional.Of(false)`
+ */
+func (c *GmailClient) UpdateSendAs(userID, sendAsEmail string, sendAs *GmailSendAs) (*GmailSendAs, error) {
+	url := c.BuildURL(fmt.Sprintf(GmailSettings, userID), nil, "sendAs", sendAsEmail)
+
+	return do[*GmailSendAs](c.Client, "PATCH", url, nil, sendAs)
+}
+
+/*
+ * # Delete a Send-As Alias of a Mailbox
+ * /gmail/v1/users/{userId}/settings/sendAs/{sendAsEmail}
+ * - https://developers.google.com/gmail/api/reference/rest/v1/users.settings.sendAs/delete
+ * - The primary address cannot be deleted
+ */
+func (c *GmailClient) DeleteSendAs(userID, sendAsEmail string) error {
+	url := c.BuildURL(fmt.Sprintf(GmailSettings, userID), nil, "sendAs", sendAsEmail)
+
+	_, err := do[interface{}](c.Client, "DELETE", url, nil, nil)
+	return err
+}
@@ -26,6 +26,8 @@ import (
 	"github.com/gemini-oss/rego/pkg/common/log"
 	"github.com/gemini-oss/rego/pkg/common/ratelimit"
 	"github.com/gemini-oss/rego/pkg/common/requests"
+	"github.com/gemini-oss/rego/pkg/common/schema"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 )
 
@@ -73,6 +75,23 @@ func (c *Client) BuildURL(endpoint string, customer *Customer, parameters ...str
 	return url
 }
 
+/*
+ * WithContext returns a copy of the client whose requests end when ctx is done, e.g. to cancel a long pagination
+ */
+func (c *Client) WithContext(ctx context.Context) *Client {
+	clone := *c
+	clone.HTTP = c.HTTP.WithContext(ctx)
+	clone.Log = c.Log.WithContext(ctx)
+	return &clone
+}
+
+// UseCache() enables caching, including the typed cache of GetUser, for the calls of the client and its copies
+func (c *Client) UseCache() *Client {
+	c.Cache.Enabled = true
+	c.UserCache.SetEnabled(true)
+	return c
+}
+
 /*
  * SetCache stores a Google API response in the cache
  */
@@ -111,60 +130,63 @@ func (c *Client) GetCache(key string, target interface{}) bool {
  * https://developers.google.com/identity/protocols/oauth2/service-account#jwt-auth
  */
 func (c *Client) GenerateJWT(data []byte) (*requests.Client, error) {
-	ctx := context.Background()
+	ctx := oauthContext()
 
 	c.Log.Println("Generating JWT Config")
 	jwtConfig, err := google.JWTConfigFromJSON(data, c.Auth.Scopes...)
-	jwtConfig.Subject = c.Auth.Subject
 	if err != nil {
-		c.Log.Printf("Unable to parse client secret file to config: %v", err)
+		return nil, fmt.Errorf("unable to parse the service account credentials: %w", err)
 	}
+	jwtConfig.Subject = c.Auth.Subject
+	c.JWT = jwtConfig
+	c.tokens = newTokenCache(jwtConfig)
 	c.Log.Printf("JWT Config Successfully Generated")
 
 	c.Log.Println("Generating JWT Token")
-	t, err := jwtConfig.TokenSource(ctx).Token()
+	t, err := c.tokens.Token(jwtConfig.Subject)
 	if err != nil {
-		c.Log.Printf("Unable to generate token: %v", err)
+		return nil, err
 	}
 	c.Log.Printf("Token Successfully Generated")
 
+	// Tokens are refreshed through the cache, before they expire
 	c.Log.Println("Reconfiguring HTTP Client")
-	type contextKey string
-	jwtClient := jwtConfig.Client(context.WithValue(ctx, contextKey("token"), t))
+	jwtClient := oauth2.NewClient(ctx, c.tokens.Source(jwtConfig.Subject))
 	headers := requests.Headers{
 		"Accept":        requests.JSON,
 		"Content-Type":  requests.JSON,
 		"Authorization": "Bearer " + t.AccessToken,
 	}
 
-	return requests.NewClient(jwtClient, headers, c.HTTP.RateLimiter), nil
+	return requests.NewClient(jwtClient, headers, c.HTTP.RateLimiter, requests.WithBackoff(Backoff)), nil
 }
 
+/*
+ * ImpersonateUser switches the client to another user of the domain; see ForSubject to impersonate users concurrently
+ * Tokens are cached per user, so switching back and forth does not mint new tokens
+ */
 func (c *Client) ImpersonateUser(email string) error {
-	// Update the JWT config to impersonate a new user
-	c.JWT.Subject = email
+	if c.JWT == nil || c.tokens == nil {
+		return fmt.Errorf("impersonating %s requires a service account", email)
+	}
 
-	// Create a new token for the new user
-	ctx := context.Background()
-	t, err := c.JWT.TokenSource(ctx).Token()
+	// Fail early when the service account cannot act as the user
+	t, err := c.tokens.Token(email)
 	if err != nil {
-		return fmt.Errorf("unable to generate token: %v", err)
+		return err
 	}
 
-	// Create a new HTTP client with the new token
-	type contextKey string
-	jwtClient := c.JWT.Client(context.WithValue(ctx, contextKey("token"), t))
-
-	// Update the headers to use the new token
+	// Update the headers to use the new token; the token source sets the current token on each request
 	headers := requests.Headers{
 		"Accept":        requests.JSON,
 		"Content-Type":  requests.JSON,
 		"Authorization": "Bearer " + t.AccessToken,
 	}
 
-	// Update the HTTP client of the client object
-	c.HTTP = requests.NewClient(jwtClient, headers, nil)
+	// Update the HTTP client of the client object, keeping its rate limiter
+	c.HTTP = requests.NewClient(oauth2.NewClient(oauthContext(), c.tokens.Source(email)), headers, c.HTTP.RateLimiter, requests.WithBackoff(Backoff))
 	c.HTTP.BodyType = requests.JSON
+	c.JWT.Subject = email
 
 	return nil
 }
@@ -247,30 +269,38 @@ func (c *Client) ImpersonateUser(email string) error {
 
 ```
 */
-func NewClient(ac AuthCredentials, verbosity int) (*Client, error) {
+func NewClient(ac AuthCredentials, verbosity int, opts ...config.Option) (*Client, error) {
 	log := log.NewLogger("{google}", verbosity)
+	profile := config.NewProfile(opts...)
 
 	// Look into `Functional Options` patterns for a better way to handle this (and other clients while we're at it)
-	encryptionKey := []byte(config.GetEnv("REGO_ENCRYPTION_KEY"))
+	encryptionKey := []byte(profile.GetEnv("REGO_ENCRYPTION_KEY"))
 	if len(encryptionKey) == 0 {
 		log.Fatal("REGO_ENCRYPTION_KEY is not set")
 	}
 
-	cache, err := cache.NewCache(encryptionKey, "rego_cache_google.gob", 1000000)
+	userCache := cache.NewTyped[*User](15*time.Minute, 3*time.Minute, 10000)
+
+	cache, err := cache.NewCache(encryptionKey, "rego_cache_google.gob", 1000000, profile)
 	if err != nil {
 		panic(err)
 	}
 
 	// https://developers.google.com/drive/api/guides/limits
-	rl := ratelimit.NewRateLimiter(12000, 75*time.Second)
+	limit := 12000
+	if l := profile.GetEnvAsInt("GOOGLE_RATE_LIMIT"); l > 0 {
+		limit = l
+	}
+	rl := ratelimit.NewRateLimiter(limit, 75*time.Second)
 	rl.Log.Verbosity = verbosity
 
 	c := &Client{
-		Auth:    ac,
-		BaseURL: BaseURL,
-		Log:     log,
-		Cache:   cache,
-		HTTP:    requests.NewClient(nil, nil, rl),
+		Auth:      ac,
+		BaseURL:   BaseURL,
+		Log:       log,
+		Cache:     cache,
+		UserCache: userCache,
+		HTTP:      requests.NewClient(nil, nil, rl, requests.WithBackoff(Backoff)),
 	}
 
 	log.Println("Initializing Google Client")
@@ -303,12 +333,12 @@ func NewClient(ac AuthCredentials, verbosity int) (*Client, error) {
 		log.Println("Detected CICD Environment: Reading Credentials from Environment Variables")
 		switch c.Auth.Type {
 		case API_KEY:
-			headers["Authorization"] = "Bearer " + config.GetEnv("GOOGLE_API_KEY")
+			headers["Authorization"] = "Bearer " + profile.GetEnv("GOOGLE_API_KEY")
 			if len(headers["Authorization"]) <= 7 {
 				return nil, fmt.Errorf("GOOGLE_API_KEY is not set")
 			}
 		case OAUTH_CLIENT:
-			b64 := config.GetEnv("GOOGLE_OAUTH_CLIENT")
+			b64 := profile.GetEnv("GOOGLE_OAUTH_CLIENT")
 			if len(b64) == 0 {
 				return nil, fmt.Errorf("GOOGLE_OAUTH_CLIENT is not set")
 			}
@@ -325,7 +355,7 @@ func NewClient(ac AuthCredentials, verbosity int) (*Client, error) {
 				return nil, err
 			}
 		case SERVICE_ACCOUNT:
-			b64 := config.GetEnv("GOOGLE_SERVICE_ACCOUNT")
+			b64 := profile.GetEnv("GOOGLE_SERVICE_ACCOUNT")
 			if len(b64) == 0 {
 				return nil, fmt.Errorf("GOOGLE_SERVICE_ACCOUNT is not set")
 			}
@@ -412,7 +442,12 @@ func do[T any](c *Client, method string, url string, query interface{}, data int
 		return *new(T), googleError.Error
 	}
 
-	err = json.Unmarshal(body, &result)
+	// Deletes answer 204 without a body
+	if len(body) == 0 {
+		return result, nil
+	}
+
+	err = schema.Unmarshal(body, &result)
 	if err != nil {
 		return *new(T), fmt.Errorf("unmarshalling error: %w", err)
 	}
@@ -0,0 +1,369 @@
+/*
+# Google Workspace - Groups
+
+This package initializes all the methods for functions which interact with the Google Directory Groups and Members API:
+- https://developers.google.com/admin-sdk/directory/reference/rest/v1/groups
+- https://developers.google.com/admin-sdk/directory/reference/rest/v1/members
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/groups.go
+package google
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+)
+
+var (
+	GroupsSettings = fmt.Sprintf("%s/groups/v1/groups", BaseURL) // https://developers.google.com/admin-sdk/groups-settings/v1/reference/groups
+)
+
+// GroupsClient for chaining methods
+type GroupsClient struct {
+	*Client
+}
+
+// Entry point for group-related operations
+func (c *Client) Groups() *GroupsClient {
+	return &GroupsClient{
+		Client: c,
+	}
+}
+
+/*
+ * Query Parameters for Group Members
+ * Reference: https://developers.google.com/admin-sdk/directory/reference/rest/v1/members/list#query-parameters
+ */
+type MemberQuery struct {
+	IncludeDerivedMembership bool   `url:"includeDerivedMembership,omitempty"` // Whether to list indirect memberships.
+	MaxResults               int    `url:"maxResults,omitempty"`               // Maximum number of results to return. Max allowed value is 200.
+	PageToken                string `url:"pageToken,omitempty"`                // Token to specify next page in the list.
+	Roles                    string `url:"roles,omitempty"`                    // The roles query parameter allows you to retrieve group members by role. Allowed values are OWNER, MANAGER, and MEMBER.
+}
+
+/*
+ * # List all Members of a Group
+ * /admin/directory/v1/groups/{groupKey}/members
+ * - https://developers.google.com/admin-sdk/directory/reference/rest/v1/members/list
+ */
+func (c *GroupsClient) ListAllMembers(groupKey string) (*Members, error) {
+	url := fmt.Sprintf(DirectoryMembers, groupKey)
+
+	q := &MemberQuery{
+		MaxResults: 200,
+	}
+
+	members, err := requests.PaginatedDo(c.HTTP, "GET", url, q, requests.Pagination[*Member]{
+		Items: requests.ItemsField[*Member]("members"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Members{Members: members}, nil
+}
+
+/*
+ * Query Parameters for Groups
+ * Reference: https://developers.google.com/admin-sdk/directory/reference/rest/v1/groups/list#query-parameters
+ */
+type GroupQuery struct {
+	Customer   string `url:"customer,omitempty"`   // The unique ID for the customer's Google Workspace account. Use `my_customer` for the account of the caller.
+	Domain     string `url:"domain,omitempty"`     // The domain name. Use this field to get groups from only one domain.
+	MaxResults int    `url:"maxResults,omitempty"` // Maximum number of results to return. Max allowed value is 200.
+	PageToken  string `url:"pageToken,omitempty"`  // Token to specify next page in the list.
+	Query      string `url:"query,omitempty"`      // Query string search, e.g. `email:admins*`
+	UserKey    string `url:"userKey,omitempty"`    // Email or immutable ID of the user if only those groups are to be listed the given user is a member of.
+}
+
+/*
+ * Query Parameters for Group Settings
+ * Reference: https://developers.google.com/admin-sdk/groups-settings/v1/reference/groups/get#parameters
+ */
+type GroupSettingsQuery struct {
+	Alt string `url:"alt,omitempty"` // Format of the response; the API answers in Atom unless `json` is requested.
+}
+
+/*
+ * # List Groups
+ * /admin/directory/v1/groups
+ * - https://developers.google.com/admin-sdk/directory/reference/rest/v1/groups/list
+ * - `q` needs one of Customer, Domain or UserKey; every page is returned
+ */
+func (c *GroupsClient) ListGroups(q *GroupQuery) (*Groups, error) {
+	if q.MaxResults == 0 {
+		q.MaxResults = 200
+	}
+
+	groups, err := requests.PaginatedDo(c.HTTP, "GET", DirectoryGroups, q, requests.Pagination[*Group]{
+		Items: requests.ItemsField[*Group]("groups"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Groups{Groups: groups}, nil
+}
+
+/*
+ * # List all Groups of the Customer
+ * /admin/directory/v1/groups?customer={customer}
+ * - https://developers.google.com/admin-sdk/directory/reference/rest/v1/groups/list
+ */
+func (c *GroupsClient) ListAllGroups(customer *Customer) (*Groups, error) {
+	if customer == nil {
+		customer = &Customer{}
+	}
+
+	return c.ListGroups(&GroupQuery{Customer: customer.String()})
+}
+
+/*
+ * # List the Groups of a User
+ * /admin/directory/v1/groups?userKey={userKey}
+ * - https://developers.google.com/admin-sdk/directory/reference/rest/v1/groups/list
+ */
+func (c *GroupsClient) ListUserGroups(userKey string) (*Groups, error) {
+	return c.ListGroups(&GroupQuery{UserKey: userKey})
+}
+
+/*
+ * # Get a Group
+ * /admin/directory/v1/groups/{groupKey}
+ * - https://developers.google.com/admin-sdk/directory/reference/rest/v1/groups/get
+ * - `groupKey` is the group's email address, alias or unique ID
+ */
+func (c *GroupsClient) GetGroup(groupKey string) (*Group, error) {
+	url := c.BuildURL(DirectoryGroups, nil, groupKey)
+
+	return do[*Group](c.Client, "GET", url, nil, nil)
+}
+
+/*
+ * # Create a Group
+ * /admin/directory/v1/groups
+ * - https://developers.google.com/admin-sdk/directory/reference/rest/v1/groups/insert
+ * - Only Email is required; Name and Description are optional
+ */
+func (c *GroupsClient) CreateGroup(group *Group) (*Group, error) {
+	return do[*Group](c.Client, "POST", DirectoryGroups, nil, group)
+}
+
+/*
+ * # Update a Group
+ * /admin/directory/v1/groups/{groupKey}
+ * - https://developers.google.com/admin-sdk/directory/reference/rest/v1/groups/patch
+ * - Only the fields set on `group` are changed
+ */
+func (c *GroupsClient) UpdateGroup(groupKey string, group *Group) (*Group, error) {
+	url := c.BuildURL(DirectoryGroups, nil, groupKey)
+
+	return do[*Group](c.Client, "PATCH", url, nil, group)
+}
+
+/*
+ * # Delete a Group
+ * /admin/directory/v1/groups/{groupKey}
+ * - https://developers.google.com/admin-sdk/directory/reference/rest/v1/groups/delete
+ * - The group's archive is deleted with it; see GroupsMigrationClient.ExportArchive to keep it
+ */
+func (c *GroupsClient) DeleteGroup(groupKey string) error {
+	url := c.BuildURL(DirectoryGroups, nil, groupKey)
+
+	_, err := do[interface{}](c.Client, "DELETE", url, nil, nil)
+	return err
+}
+
+/*
+ * # Get a Member of a Group
+ * /admin/directory/v1/groups/{groupKey}/members/{memberKey}
+ * - https://developers.google.com/admin-sdk/directory/reference/rest/v1/members/get
+ */
+func (c *GroupsClient) GetMember(groupKey, memberKey string) (*Member, error) {
+	url := c.BuildURL(fmt.Sprintf(DirectoryMembers, groupKey), nil, memberKey)
+
+	return do[*Member](c.Client, "GET", url, nil, nil)
+}
+
+/*
+ * # Update a Member of a Group
+ * /admin/directory/v1/groups/{groupKey}/members/{memberKey}
+ * - https://developers.google.com/admin-sdk/directory/reference/rest/v1/members/patch
+ */
+func (c *GroupsClient) UpdateMember(groupKey, memberKey string, member *Member) (*Member, error) {
+	url := c.BuildURL(fmt.Sprintf(DirectoryMembers, groupKey), nil, memberKey)
+
+	return do[*Member](c.Client, "PATCH", url, nil, member)
+}
+
+/*
+ * # List the Groups owned by a User
+ * Lists the user's (direct) groups, then keeps those where the user's role is `OWNER`
+ */
+func (c *GroupsClient) ListOwnedGroups(userKey string) ([]*Group, error) {
+	groups, err := c.ListUserGroups(userKey)
+	if err != nil {
+		return nil, err
+	}
+
+	owned := []*Group{}
+	for _, group := range groups.Groups {
+		member, err := c.GetMember(group.Email, userKey)
+		if err != nil {
+			return nil, fmt.Errorf("getting the role of %s in %s: %w", userKey, group.Email, err)
+		}
+		if member.Role == "OWNER" {
+			owned = append(owned, group)
+		}
+	}
+
+	return owned, nil
+}
+
+/*
+ * # Add a Member to a Group
+ * /admin/directory/v1/groups/{groupKey}/members
+ * - https://developers.google.com/admin-sdk/directory/reference/rest/v1/members/insert
+ */
+func (c *GroupsClient) AddMember(groupKey string, member *Member) (*Member, error) {
+	url := fmt.Sprintf(DirectoryMembers, groupKey)
+
+	return do[*Member](c.Client, "POST", url, nil, member)
+}
+
+/*
+ * # Remove a Member from a Group
+ * /admin/directory/v1/groups/{groupKey}/members/{memberKey}
+ * - https://developers.google.com/admin-sdk/directory/reference/rest/v1/members/delete
+ */
+func (c *GroupsClient) RemoveMember(groupKey, memberKey string) error {
+	url := c.BuildURL(fmt.Sprintf(DirectoryMembers, groupKey), nil, memberKey)
+
+	_, err := do[interface{}](c.Client, "DELETE", url, nil, nil)
+	return err
+}
+
+/*
+ * # Set the Members of a Group
+ * Computes the difference between the group's direct `MEMBER`s and `desired`, then applies it with batch requests of BatchSize calls
+ * - Owners and managers are never removed, and are left in their role when listed in `desired`
+ * - Only USER and GROUP members are managed: members without an email (e.g. the CUSTOMER of "all users") are left alone
+ * - Emails are compared case-insensitively
+ * - Adding an existing member (409) or removing a missing one (404) counts as applied
+ * The returned MembershipChanges lists every failed call; err is only set when the current members could not be listed or a batch request failed
+ * - https://developers.google.com/admin-sdk/directory/v1/guides/batch
+ */
+func (c *GroupsClient) SetMembers(groupEmail string, desired []string) (*MembershipChanges, error) {
+	current, err := c.ListAllMembers(groupEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]*Member, len(current.Members))
+	for _, m := range current.Members {
+		if m.Email == "" || (m.Type != "USER" && m.Type != "GROUP") {
+			continue
+		}
+		existing[strings.ToLower(m.Email)] = m
+	}
+
+	want := make(map[string]bool, len(desired))
+	changes := &MembershipChanges{Group: groupEmail}
+	calls := []*BatchCall{}
+	url := fmt.Sprintf(DirectoryMembers, groupEmail)
+
+	for _, email := range desired {
+		email = strings.ToLower(strings.TrimSpace(email))
+		if email == "" || want[email] {
+			continue
+		}
+		want[email] = true
+
+		if _, ok := existing[email]; !ok {
+			calls = append(calls, &BatchCall{Method: "POST", URL: url, Body: &Member{Email: email, Role: "MEMBER"}})
+		}
+	}
+
+	for email, m := range existing {
+		if want[email] || m.Role != "MEMBER" {
+			continue
+		}
+		calls = append(calls, &BatchCall{Method: "DELETE", URL: c.BuildURL(url, nil, email)})
+	}
+
+	c.Log.Printf("Setting members of %s: %d current, %d desired, %d change(s)", groupEmail, len(existing), len(want), len(calls))
+
+	for start := 0; start < len(calls); start += BatchSize {
+		end := min(start+BatchSize, len(calls))
+
+		results, err := c.Batch(DirectoryBatch, calls[start:end])
+		if err != nil {
+			return changes, err
+		}
+
+		for _, result := range results {
+			email := memberEmail(result.Call)
+			switch {
+			case result.Call.Method == "POST" && (result.Err == nil || result.StatusCode == http.StatusConflict):
+				changes.Added = append(changes.Added, email)
+			case result.Call.Method == "DELETE" && (result.Err == nil || result.StatusCode == http.StatusNotFound):
+				changes.Removed = append(changes.Removed, email)
+			default:
+				changes.Failed = append(changes.Failed, &MembershipFailure{Email: email, Action: result.Call.Method, Err: result.Err})
+			}
+		}
+	}
+
+	if len(changes.Failed) > 0 {
+		c.Log.Warningf("%d membership change(s) to %s failed", len(changes.Failed), groupEmail)
+	}
+
+	return changes, nil
+}
+
+// memberEmail returns the member a batch call adds or removes
+func memberEmail(call *BatchCall) string {
+	if m, ok := call.Body.(*Member); ok {
+		return m.Email
+	}
+	return call.URL[strings.LastIndex(call.URL, "/")+1:]
+}
+
+/*
+ * # Get the Settings of a Group
+ * /groups/v1/groups/{groupUniqueId}
+ * - https://developers.google.com/admin-sdk/groups-settings/v1/reference/groups/get
+ */
+func (c *GroupsClient) GetGroupSettings(groupKey string) (*GroupSettings, error) {
+	url := c.BuildURL(GroupsSettings, nil, groupKey)
+
+	return do[*GroupSettings](c.Client, "GET", url, &GroupSettingsQuery{Alt: "json"}, nil)
+}
+
+/*
+ * # Update the Settings of a Group
+ * /groups/v1/groups/{groupUniqueId}
+ * - https://developers.google.com/admin-sdk/groups-settings/v1/reference/groups/patch
+ * - Only the settings set in `settings` are changed
+ */
+func (c *GroupsClient) UpdateGroupSettings(groupKey string, settings *GroupSettings) (*GroupSettings, error) {
+	url := c.BuildURL(GroupsSettings, nil, groupKey)
+
+	return do[*GroupSettings](c.Client, "PATCH", url, &GroupSettingsQuery{Alt: "json"}, settings)
+}
+
+/*
+ * # Archive a Group
+ * Makes the group archive-only: its messages are kept, but no new messages can be posted
+ * - https://developers.google.com/admin-sdk/groups-settings/v1/reference/groups#archiveOnly
+ */
+func (c *GroupsClient) ArchiveGroup(groupKey string) (*GroupSettings, error) {
+	return c.UpdateGroupSettings(groupKey, &GroupSettings{ArchiveOnly: "true"})
+}
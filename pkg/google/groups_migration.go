@@ -0,0 +1,194 @@
+/*
+# Google Workspace - Groups Migration
+
+This package initializes all the methods for functions which interact with the Groups Migration API, and exports group
+archives to mbox files so mailing list history can be preserved before a group is deleted (e.g. domain consolidations):
+https://developers.google.com/admin-sdk/groups-migration/v1/reference/archive
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/groups_migration.go
+package google
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/schema"
+)
+
+var (
+	GroupsMigrationBaseURL = "https://groupsmigration.googleapis.com"                                           // https://developers.google.com/admin-sdk/groups-migration/v1/reference
+	GroupsMigrationArchive = fmt.Sprintf("%s/upload/groups/v1/groups/%s/archive", GroupsMigrationBaseURL, "%s") // https://developers.google.com/admin-sdk/groups-migration/v1/reference/archive/insert
+)
+
+const (
+	RFC822                 = "message/rfc822" // RFC-822 (https://www.rfc-editor.org/rfc/rfc822.html)
+	MaxGroupsMigrationSize = 25 * 1024 * 1024 // Largest message accepted by the Groups Migration API
+)
+
+// mboxFrom matches the separator lines of an mbox, and (with leading `>`) the lines escaped so they are not mistaken for one
+var mboxFrom = regexp.MustCompile(`^>*From `)
+
+// GroupsMigrationClient for chaining methods
+type GroupsMigrationClient struct {
+	*Client
+}
+
+// Entry point for groups migration-related operations
+func (c *Client) GroupsMigration() *GroupsMigrationClient {
+	gc := &GroupsMigrationClient{
+		Client: c,
+	}
+
+	// https://developers.google.com/admin-sdk/groups-migration/v1/limits
+	gc.HTTP.RateLimiter.Available = 10
+	gc.HTTP.RateLimiter.Limit = 10
+	gc.HTTP.RateLimiter.Interval = 1 * time.Second
+	gc.HTTP.RateLimiter.Log.Verbosity = c.Log.Verbosity
+
+	return gc
+}
+
+/*
+ * # Insert a Message into the Archive of a Group
+ * /upload/groups/v1/groups/{groupId}/archive?uploadType=media
+ * - https://developers.google.com/admin-sdk/groups-migration/v1/reference/archive/insert
+ * - `message` is a full RFC 822 message, headers included
+ */
+func (c *GroupsMigrationClient) Insert(groupID string, message []byte) (*GroupsMigrationResult, error) {
+	if len(message) > MaxGroupsMigrationSize {
+		return nil, fmt.Errorf("message of %d bytes exceeds the %d byte limit of the Groups Migration API", len(message), MaxGroupsMigrationSize)
+	}
+
+	url := fmt.Sprintf(GroupsMigrationArchive, groupID) + "?uploadType=media"
+
+	_, body, err := c.HTTP.DoBody("POST", url, RFC822, message)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &GroupsMigrationResult{}
+	if err := schema.Unmarshal(body, result); err != nil {
+		return nil, fmt.Errorf("unmarshalling error: %w", err)
+	}
+	if result.ResponseCode != "SUCCESS" {
+		return result, fmt.Errorf("archiving message in %s: %s", groupID, result.ResponseCode)
+	}
+
+	return result, nil
+}
+
+/*
+ * # Import an mbox into the Archive of a Group
+ * - Every message of the mbox is inserted; messages which fail are reported in GroupArchive.Failed
+ */
+func (c *GroupsMigrationClient) ImportMbox(groupID string, r io.Reader) (*GroupArchive, error) {
+	archive := &GroupArchive{Group: groupID}
+
+	err := readMbox(r, func(position int, message []byte) {
+		if _, err := c.Insert(groupID, message); err != nil {
+			c.Log.Warningf("unable to archive message %d in %s: %v", position, groupID, err)
+			archive.Failed = append(archive.Failed, &GroupArchiveFailure{Message: fmt.Sprint(position), Err: err})
+			return
+		}
+		archive.Messages++
+	})
+	if err != nil {
+		return archive, fmt.Errorf("reading mbox: %w", err)
+	}
+
+	return archive, nil
+}
+
+/*
+ * # Export the Archive of a Group to an mbox
+ * - The messages are read from a mailbox which received the group's mail, through the Gmail API (query `list:{group}`);
+ *   impersonate that mailbox (Client.ImpersonateUser) first, and use `me` or its address as `mailbox`
+ * - Messages which fail to download are reported in GroupArchive.Failed
+ */
+func (c *GroupsMigrationClient) ExportArchive(group string, mailbox string, w io.Writer) (*GroupArchive, error) {
+	gmail := c.Gmail()
+
+	messages, err := gmail.ListMessages(mailbox, fmt.Sprintf("list:%s", group))
+	if err != nil {
+		return nil, fmt.Errorf("listing messages of %s in %s: %w", group, mailbox, err)
+	}
+
+	archive := &GroupArchive{Group: group}
+	mbox := bufio.NewWriter(w)
+	for _, m := range messages.Messages {
+		raw, err := gmail.GetRawMessage(mailbox, m.ID)
+		if err != nil {
+			c.Log.Warningf("unable to export message %s of %s: %v", m.ID, group, err)
+			archive.Failed = append(archive.Failed, &GroupArchiveFailure{Message: m.ID, Err: err})
+			continue
+		}
+		if err := writeMbox(mbox, raw); err != nil {
+			return archive, err
+		}
+		archive.Messages++
+	}
+
+	return archive, mbox.Flush()
+}
+
+// writeMbox appends a message to an mbox (mboxrd), escaping body lines which would be read as separators
+func writeMbox(w *bufio.Writer, message []byte) error {
+	if _, err := fmt.Fprintf(w, "From MAILER-DAEMON %s\n", time.Unix(0, 0).UTC().Format(time.ANSIC)); err != nil {
+		return err
+	}
+
+	message = bytes.ReplaceAll(message, []byte("\r\n"), []byte("\n"))
+	for _, line := range bytes.Split(bytes.TrimRight(message, "\n"), []byte("\n")) {
+		if mboxFrom.Match(line) {
+			w.WriteByte('>')
+		}
+		w.Write(line)
+		w.WriteByte('\n')
+	}
+
+	return w.WriteByte('\n')
+}
+
+// readMbox calls fn with every message of an mbox (mboxrd), numbered from 1, with escaped lines restored
+func readMbox(r io.Reader, fn func(position int, message []byte)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), MaxGroupsMigrationSize)
+
+	var message bytes.Buffer
+	position := 0
+	flush := func() {
+		if position > 0 {
+			fn(position, bytes.TrimRight(message.Bytes(), "\r\n"))
+		}
+		message.Reset()
+	}
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if bytes.HasPrefix(line, []byte("From ")) {
+			flush()
+			position++
+			continue
+		}
+		if mboxFrom.Match(line) {
+			line = line[1:]
+		}
+		message.Write(line)
+		message.WriteString("\r\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	flush()
+	return nil
+}
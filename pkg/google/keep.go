@@ -0,0 +1,158 @@
+/*
+# Google Workspace - Keep
+
+This package initializes all the methods for functions which interact with the Google Keep API (enterprise):
+https://developers.google.com/keep/api/reference/rest
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/keep.go
+package google
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+)
+
+var (
+	KeepBaseURL  = "https://keep.googleapis.com/v1"
+	KeepNotesURL = fmt.Sprintf("%s/notes", KeepBaseURL) // https://developers.google.com/keep/api/reference/rest/v1/notes
+)
+
+// KeepClient for chaining methods
+type KeepClient struct {
+	*Client
+}
+
+// Entry point for keep-related operations
+//   - The Keep API only exposes the calling user's notes; impersonate the target user (Client.ImpersonateUser) first.
+func (c *Client) Keep() *KeepClient {
+	kc := &KeepClient{
+		Client: c,
+	}
+
+	// https://developers.google.com/keep/api/limits
+	kc.HTTP.RateLimiter.Available = 60
+	kc.HTTP.RateLimiter.Limit = 60
+	kc.HTTP.RateLimiter.Interval = 1 * time.Minute
+	kc.HTTP.RateLimiter.Log.Verbosity = c.Log.Verbosity
+
+	return kc
+}
+
+/*
+ * Query Parameters for Keep Notes
+ * Reference: https://developers.google.com/keep/api/reference/rest/v1/notes/list#query-parameters
+ */
+type KeepNoteQuery struct {
+	Filter    string `url:"filter,omitempty"`    // Filter for list results. Valid fields: create_time, update_time, trash_time, trashed. e.g. `trashed = true`
+	PageSize  int    `url:"pageSize,omitempty"`  // The maximum number of results to return.
+	PageToken string `url:"pageToken,omitempty"` // The previous page's nextPageToken field.
+}
+
+/*
+ * # List all Notes of the current user
+ * /v1/notes
+ * - https://developers.google.com/keep/api/reference/rest/v1/notes/list
+ */
+func (c *KeepClient) ListAllNotes(q *KeepNoteQuery) (*KeepNotes, error) {
+	if q == nil {
+		q = &KeepNoteQuery{}
+	}
+
+	notes, err := requests.PaginatedDo(c.HTTP, "GET", KeepNotesURL, q, requests.Pagination[*KeepNote]{
+		Strategy: requests.TokenPages{SizeParam: "pageSize"},
+		Items:    requests.ItemsField[*KeepNote]("notes"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeepNotes{Notes: notes}, nil
+}
+
+/*
+ * # Get a Note
+ * /v1/notes/{noteId}
+ * - https://developers.google.com/keep/api/reference/rest/v1/notes/get
+ */
+func (c *KeepClient) GetNote(name string) (*KeepNote, error) {
+	url := c.BuildURL(KeepBaseURL, nil, name)
+
+	note, err := do[KeepNote](c.Client, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &note, nil
+}
+
+/*
+ * # Delete a Note
+ * /v1/notes/{noteId}
+ * - https://developers.google.com/keep/api/reference/rest/v1/notes/delete
+ */
+func (c *KeepClient) DeleteNote(name string) error {
+	url := c.BuildURL(KeepBaseURL, nil, name)
+
+	_, err := do[struct{}](c.Client, "DELETE", url, nil, nil)
+	return err
+}
+
+/*
+ * # Download a Note Attachment
+ * /v1/notes/{noteId}/attachments/{attachmentId}?alt=media
+ * - https://developers.google.com/keep/api/reference/rest/v1/media/download
+ */
+func (c *KeepClient) DownloadAttachment(a *KeepAttachment, directory string) error {
+	if len(a.MimeType) == 0 {
+		return fmt.Errorf("attachment %s has no downloadable MIME type", a.Name)
+	}
+
+	url := fmt.Sprintf("%s/%s?alt=media&mimeType=%s", KeepBaseURL, a.Name, a.MimeType[0])
+	c.Log.Debug("url:", url)
+
+	filename := strings.ReplaceAll(a.Name, "/", "_")
+	return c.HTTP.DownloadFile(url, directory, filename, false)
+}
+
+/*
+ * # Export all Notes for a User
+ * - Impersonates `email`, lists every note (including trashed notes), and optionally downloads attachments into `directory`.
+ */
+func (c *KeepClient) ExportUserNotes(email, directory string, attachments bool) (*KeepNotes, error) {
+	err := c.ImpersonateUser(email)
+	if err != nil {
+		return nil, err
+	}
+
+	notes, err := c.ListAllNotes(&KeepNoteQuery{})
+	if err != nil {
+		return nil, err
+	}
+
+	trashed, err := c.ListAllNotes(&KeepNoteQuery{Filter: "trashed = true"})
+	if err != nil {
+		return nil, err
+	}
+	notes.Notes = append(notes.Notes, trashed.Notes...)
+
+	if attachments {
+		for _, note := range notes.Notes {
+			for _, a := range note.Attachments {
+				if err := c.DownloadAttachment(a, directory); err != nil {
+					return nil, fmt.Errorf("downloading attachment %s: %w", a.Name, err)
+				}
+			}
+		}
+	}
+
+	c.Log.Printf("Exported %d notes for %s", len(notes.Notes), email)
+	return notes, nil
+}
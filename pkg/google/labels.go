@@ -0,0 +1,296 @@
+/*
+# Google Workspace - Drive Labels
+
+This package initializes all the methods for functions which interact with the Google Drive Labels API:
+https://developers.google.com/drive/labels/reference/rest
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/labels.go
+package google
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+)
+
+var (
+	DriveLabelsBaseURL = "https://drivelabels.googleapis.com/v2"
+	DriveLabelsURL     = fmt.Sprintf("%s/labels", DriveLabelsBaseURL) // https://developers.google.com/drive/labels/reference/rest/v2/labels
+)
+
+// LabelsClient for chaining methods
+type LabelsClient struct {
+	*Client
+}
+
+// Entry point for Drive label-related operations
+func (c *Client) Labels() *LabelsClient {
+	lc := &LabelsClient{
+		Client: c,
+	}
+
+	// https://developers.google.com/drive/labels/limits
+	lc.HTTP.RateLimiter.Available = 600
+	lc.HTTP.RateLimiter.Limit = 600
+	lc.HTTP.RateLimiter.Interval = 1 * time.Minute
+	lc.HTTP.RateLimiter.Log.Verbosity = c.Log.Verbosity
+
+	return lc
+}
+
+/*
+ * Query Parameters for Drive Labels
+ * Reference: https://developers.google.com/drive/labels/reference/rest/v2/labels/list#query-parameters
+ */
+type DriveLabelQuery struct {
+	UseAdminAccess bool   `url:"useAdminAccess,omitempty"` // Set to true in order to use the user's admin credentials. This will return all Labels within the customer.
+	PublishedOnly  bool   `url:"publishedOnly,omitempty"`  // Whether to include only published labels in the results.
+	MinimumRole    string `url:"minimumRole,omitempty"`    // Specifies the level of access the user must have on the returned Labels. {READER, APPLIER, ORGANIZER, EDITOR}
+	LanguageCode   string `url:"languageCode,omitempty"`   // The BCP-47 language code to use for evaluating localized field labels.
+	PageSize       int    `url:"pageSize,omitempty"`       // Maximum number of labels to return per page. Default: 50. Max: 200.
+	PageToken      string `url:"pageToken,omitempty"`      // The token of the page to return.
+	View           string `url:"view,omitempty"`           // When specified, only certain fields belonging to the indicated view are returned. {LABEL_VIEW_BASIC, LABEL_VIEW_FULL}
+}
+
+// labelName normalizes a label ID into its `labels/{id}` resource name
+func labelName(id string) string {
+	if strings.HasPrefix(id, "labels/") {
+		return id
+	}
+	return "labels/" + id
+}
+
+/*
+ * # List all Drive Labels
+ * /v2/labels
+ * - https://developers.google.com/drive/labels/reference/rest/v2/labels/list
+ */
+func (c *LabelsClient) ListAllLabels(q *DriveLabelQuery) (*DriveLabels, error) {
+	if q == nil {
+		q = &DriveLabelQuery{
+			UseAdminAccess: true,
+			View:           "LABEL_VIEW_FULL",
+		}
+	}
+	q.PageSize = 200
+
+	labels, err := requests.PaginatedDo(c.HTTP, "GET", DriveLabelsURL, q, requests.Pagination[*DriveLabel]{
+		Strategy: requests.TokenPages{SizeParam: "pageSize"},
+		Items:    requests.ItemsField[*DriveLabel]("labels"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DriveLabels{Labels: labels}, nil
+}
+
+/*
+ * # Get a Drive Label
+ * /v2/labels/{id}
+ * - https://developers.google.com/drive/labels/reference/rest/v2/labels/get
+ */
+func (c *LabelsClient) GetLabel(id string) (*DriveLabel, error) {
+	url := c.BuildURL(DriveLabelsBaseURL, nil, labelName(id))
+
+	q := &DriveLabelQuery{
+		UseAdminAccess: true,
+		View:           "LABEL_VIEW_FULL",
+	}
+
+	label, err := do[DriveLabel](c.Client, "GET", url, q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &label, nil
+}
+
+/*
+ * # Create a Drive Label (taxonomy)
+ * /v2/labels
+ * - https://developers.google.com/drive/labels/reference/rest/v2/labels/create
+ * - Labels are created as an unpublished draft; call PublishLabel to make them available.
+ */
+func (c *LabelsClient) CreateLabel(label *DriveLabel) (*DriveLabel, error) {
+	q := &DriveLabelQuery{
+		UseAdminAccess: true,
+	}
+
+	created, err := do[DriveLabel](c.Client, "POST", DriveLabelsURL, q, label)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+/*
+ * # Update a Drive Label (delta)
+ * /v2/labels/{id}:delta
+ * - https://developers.google.com/drive/labels/reference/rest/v2/labels/delta
+ * - Changes create a new draft revision; call PublishLabel to publish it.
+ */
+func (c *LabelsClient) UpdateLabel(id string, req *DriveLabelDeltaRequest) (*DriveLabel, error) {
+	url := c.BuildURL(DriveLabelsBaseURL, nil, labelName(id), ":delta")
+
+	req.UseAdminAccess = true
+	req.View = "LABEL_VIEW_FULL"
+
+	res, err := do[struct {
+		UpdatedLabel *DriveLabel `json:"updatedLabel,omitempty"`
+	}](c.Client, "POST", url, nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.UpdatedLabel, nil
+}
+
+/*
+ * # Publish a Drive Label revision
+ * /v2/labels/{id}:publish
+ * - https://developers.google.com/drive/labels/reference/rest/v2/labels/publish
+ */
+func (c *LabelsClient) PublishLabel(id string) (*DriveLabel, error) {
+	return c.labelLifecycle(id, ":publish")
+}
+
+/*
+ * # Disable a published Drive Label
+ * /v2/labels/{id}:disable
+ * - https://developers.google.com/drive/labels/reference/rest/v2/labels/disable
+ */
+func (c *LabelsClient) DisableLabel(id string) (*DriveLabel, error) {
+	return c.labelLifecycle(id, ":disable")
+}
+
+/*
+ * # Enable a disabled Drive Label
+ * /v2/labels/{id}:enable
+ * - https://developers.google.com/drive/labels/reference/rest/v2/labels/enable
+ */
+func (c *LabelsClient) EnableLabel(id string) (*DriveLabel, error) {
+	return c.labelLifecycle(id, ":enable")
+}
+
+func (c *LabelsClient) labelLifecycle(id, action string) (*DriveLabel, error) {
+	url := c.BuildURL(DriveLabelsBaseURL, nil, labelName(id), action)
+
+	req := map[string]interface{}{
+		"useAdminAccess": true,
+	}
+
+	label, err := do[DriveLabel](c.Client, "POST", url, nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &label, nil
+}
+
+/*
+ * # Delete a Drive Label
+ * /v2/labels/{id}
+ * - https://developers.google.com/drive/labels/reference/rest/v2/labels/delete
+ * - Only draft or disabled labels may be deleted.
+ */
+func (c *LabelsClient) DeleteLabel(id string) error {
+	url := c.BuildURL(DriveLabelsBaseURL, nil, labelName(id))
+
+	q := &DriveLabelQuery{
+		UseAdminAccess: true,
+	}
+
+	_, err := do[struct{}](c.Client, "DELETE", url, q, nil)
+	return err
+}
+
+/*
+ * # List the Labels applied to a File
+ * /drive/v3/files/{fileId}/listLabels
+ * - https://developers.google.com/drive/api/reference/rest/v3/files/listLabels
+ */
+func (c *LabelsClient) GetFileLabels(fileID string) (*LabelInfo, error) {
+	url := c.BuildURL(DriveFiles, nil, fileID, "listLabels")
+
+	labels, err := do[LabelInfo](c.Client, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &labels, nil
+}
+
+/*
+ * # Modify the Labels applied to a File
+ * /drive/v3/files/{fileId}/modifyLabels
+ * - https://developers.google.com/drive/api/reference/rest/v3/files/modifyLabels
+ */
+func (c *LabelsClient) ModifyFileLabels(fileID string, mods ...*LabelModification) (*ModifyLabelsResponse, error) {
+	url := c.BuildURL(DriveFiles, nil, fileID, "modifyLabels")
+
+	req := &ModifyLabelsRequest{
+		Kind:               "drive#modifyLabelsRequest",
+		LabelModifications: mods,
+	}
+
+	res, err := do[ModifyLabelsResponse](c.Client, "POST", url, nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+/*
+ * # Apply a Label modification to many Files
+ * - Runs ModifyFileLabels concurrently and returns the per-file errors (an empty map means every file succeeded).
+ */
+func (c *LabelsClient) BulkModifyFileLabels(fileIDs []string, mods ...*LabelModification) map[string]error {
+	failures := make(map[string]error)
+	var failuresMutex sync.Mutex
+
+	sem := make(chan struct{}, 10)
+	var wg sync.WaitGroup
+
+	for _, fileID := range fileIDs {
+		wg.Add(1)
+		go func(fileID string) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if _, err := c.ModifyFileLabels(fileID, mods...); err != nil {
+				failuresMutex.Lock()
+				failures[fileID] = err
+				failuresMutex.Unlock()
+			}
+		}(fileID)
+	}
+
+	wg.Wait()
+
+	c.Log.Printf("Modified labels on %d/%d files", len(fileIDs)-len(failures), len(fileIDs))
+	return failures
+}
+
+/*
+ * # Remove a Label from many Files
+ */
+func (c *LabelsClient) BulkRemoveLabel(fileIDs []string, labelID string) map[string]error {
+	return c.BulkModifyFileLabels(fileIDs, &LabelModification{
+		Kind:        "drive#labelModification",
+		LabelID:     labelID,
+		RemoveLabel: true,
+	})
+}
@@ -0,0 +1,212 @@
+/*
+# Google Workspace - Licensing
+
+This package initializes all the methods for functions which interact with the Enterprise License Manager API:
+https://developers.google.com/admin-sdk/licensing/reference/rest
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/licensing.go
+package google
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+)
+
+var (
+	LicensingBaseURL = "https://licensing.googleapis.com/apps/licensing/v1"
+	LicensingProduct = fmt.Sprintf("%s/product", LicensingBaseURL) // https://developers.google.com/admin-sdk/licensing/reference/rest/v1/licenseAssignments
+)
+
+// LicensingClient for chaining methods
+type LicensingClient struct {
+	*Client
+}
+
+// Entry point for license-related operations
+func (c *Client) Licensing() *LicensingClient {
+	return &LicensingClient{
+		Client: c,
+	}
+}
+
+/*
+ * Query Parameters for License Assignments
+ * Reference: https://developers.google.com/admin-sdk/licensing/reference/rest/v1/licenseAssignments/listForProductAndSku#query-parameters
+ */
+type LicenseAssignmentQuery struct {
+	CustomerID string `url:"customerId,omitempty"` // The customer's unique ID as defined in the Admin console, or the primary domain name.
+	MaxResults int    `url:"maxResults,omitempty"` // The maxResults query string determines how many entries are returned on each page. Default: 100. Max: 1000.
+	PageToken  string `url:"pageToken,omitempty"`  // Token to fetch the next page of data.
+}
+
+/*
+ * # List all License Assignments for a Product SKU
+ * /apps/licensing/v1/product/{productId}/sku/{skuId}/users
+ * - https://developers.google.com/admin-sdk/licensing/reference/rest/v1/licenseAssignments/listForProductAndSku
+ * - Product and SKU IDs: https://developers.google.com/admin-sdk/licensing/v1/how-tos/products
+ */
+func (c *LicensingClient) ListAllLicenseAssignments(customer *Customer, productID, skuID string) (*LicenseAssignments, error) {
+	url := c.BuildURL(LicensingProduct, nil, productID, "sku", skuID, "users")
+
+	var cache LicenseAssignments
+	if c.GetCache(url, &cache) {
+		return &cache, nil
+	}
+
+	assignments, err := c.listLicenseAssignments(url, customer)
+	if err != nil {
+		return nil, err
+	}
+
+	c.SetCache(url, assignments, 30*time.Minute)
+	return assignments, nil
+}
+
+/*
+ * # List all License Assignments for a Product
+ * /apps/licensing/v1/product/{productId}/users
+ * - https://developers.google.com/admin-sdk/licensing/reference/rest/v1/licenseAssignments/listForProduct
+ * - Unlike ListAllLicenseAssignments, the result is never cached
+ */
+func (c *LicensingClient) ListProductLicenseAssignments(customer *Customer, productID string) (*LicenseAssignments, error) {
+	url := c.BuildURL(LicensingProduct, nil, productID, "users")
+
+	return c.listLicenseAssignments(url, customer)
+}
+
+// listLicenseAssignments follows the pages of a license assignment listing
+func (c *LicensingClient) listLicenseAssignments(url string, customer *Customer) (*LicenseAssignments, error) {
+	q := &LicenseAssignmentQuery{
+		CustomerID: customer.ID,
+		MaxResults: 1000,
+	}
+
+	assignments, err := requests.PaginatedDo(c.HTTP, "GET", url, q, requests.Pagination[*LicenseAssignment]{
+		Items: requests.ItemsField[*LicenseAssignment]("items"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &LicenseAssignments{Items: assignments}, nil
+}
+
+/*
+ * # Get a User's License Assignment
+ * /apps/licensing/v1/product/{productId}/sku/{skuId}/user/{userId}
+ * - https://developers.google.com/admin-sdk/licensing/reference/rest/v1/licenseAssignments/get
+ */
+func (c *LicensingClient) GetLicenseAssignment(productID, skuID, userID string) (*LicenseAssignment, error) {
+	url := c.BuildURL(LicensingProduct, nil, productID, "sku", skuID, "user", userID)
+
+	return do[*LicenseAssignment](c.Client, "GET", url, nil, nil)
+}
+
+/*
+ * # Assign a License to a User
+ * /apps/licensing/v1/product/{productId}/sku/{skuId}/user
+ * - https://developers.google.com/admin-sdk/licensing/reference/rest/v1/licenseAssignments/insert
+ * - Fails when the user already holds another SKU of the product; see ReassignLicense
+ */
+func (c *LicensingClient) AssignLicense(productID, skuID, userID string) (*LicenseAssignment, error) {
+	url := c.BuildURL(LicensingProduct, nil, productID, "sku", skuID, "user")
+
+	return do[*LicenseAssignment](c.Client, "POST", url, nil, &LicenseAssignment{UserID: userID})
+}
+
+/*
+ * # Reassign a User to another SKU of a Product
+ * /apps/licensing/v1/product/{productId}/sku/{skuId}/user/{userId}
+ * - https://developers.google.com/admin-sdk/licensing/reference/rest/v1/licenseAssignments/patch
+ */
+func (c *LicensingClient) ReassignLicense(productID, currentSkuID, newSkuID, userID string) (*LicenseAssignment, error) {
+	url := c.BuildURL(LicensingProduct, nil, productID, "sku", currentSkuID, "user", userID)
+
+	return do[*LicenseAssignment](c.Client, "PATCH", url, nil, &LicenseAssignment{SkuID: newSkuID})
+}
+
+/*
+ * # Remove a License from a User
+ * /apps/licensing/v1/product/{productId}/sku/{skuId}/user/{userId}
+ * - https://developers.google.com/admin-sdk/licensing/reference/rest/v1/licenseAssignments/delete
+ */
+func (c *LicensingClient) RemoveLicense(productID, skuID, userID string) error {
+	url := c.BuildURL(LicensingProduct, nil, productID, "sku", skuID, "user", userID)
+
+	_, err := do[interface{}](c.Client, "DELETE", url, nil, nil)
+	return err
+}
+
+/*
+ * # Reconcile the License Assignments of a SKU
+ * Computes the difference between the users holding `skuID` and `desired`, then applies it:
+ * - Users missing the SKU are assigned it, or reassigned from another SKU of the same product they hold
+ * - Users holding the SKU who are not desired lose it
+ * - Emails are compared case-insensitively
+ * The returned LicenseChanges lists every failed change; err is only set when the current assignments could not be listed
+ */
+func (c *LicensingClient) ReconcileLicenses(customer *Customer, productID, skuID string, desired []string) (*LicenseChanges, error) {
+	current, err := c.ListProductLicenseAssignments(customer, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	held := make(map[string]string, len(current.Items)) // User -> SKU of the product
+	for _, assignment := range current.Items {
+		held[strings.ToLower(assignment.UserID)] = assignment.SkuID
+	}
+
+	changes := &LicenseChanges{ProductID: productID, SkuID: skuID}
+	want := make(map[string]bool, len(desired))
+
+	for _, user := range desired {
+		user = strings.ToLower(strings.TrimSpace(user))
+		if user == "" || want[user] {
+			continue
+		}
+		want[user] = true
+
+		switch sku, ok := held[user]; {
+		case ok && sku == skuID:
+			continue
+		case ok:
+			if _, err := c.ReassignLicense(productID, sku, skuID, user); err != nil {
+				changes.Failed = append(changes.Failed, &LicenseFailure{User: user, Action: "reassign", Err: err})
+				continue
+			}
+			changes.Reassigned = append(changes.Reassigned, user)
+		default:
+			if _, err := c.AssignLicense(productID, skuID, user); err != nil {
+				changes.Failed = append(changes.Failed, &LicenseFailure{User: user, Action: "assign", Err: err})
+				continue
+			}
+			changes.Assigned = append(changes.Assigned, user)
+		}
+	}
+
+	for user, sku := range held {
+		if sku != skuID || want[user] {
+			continue
+		}
+		if err := c.RemoveLicense(productID, skuID, user); err != nil {
+			changes.Failed = append(changes.Failed, &LicenseFailure{User: user, Action: "remove", Err: err})
+			continue
+		}
+		changes.Removed = append(changes.Removed, user)
+	}
+
+	c.Log.Printf("Reconciled %s/%s: %d assigned, %d reassigned, %d removed, %d failed", productID, skuID, len(changes.Assigned), len(changes.Reassigned), len(changes.Removed), len(changes.Failed))
+	if len(changes.Failed) > 0 {
+		c.Log.Warningf("%d license change(s) to %s/%s failed", len(changes.Failed), productID, skuID)
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,258 @@
+/*
+# Google Workspace - Organizational Units
+
+This package initializes all the methods for functions which interact with the Google Directory OrgUnits API, and moves
+users between organizational units:
+https://developers.google.com/admin-sdk/directory/reference/rest/v1/orgunits
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/orgunits.go
+package google
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+)
+
+// OrgUnitsClient for chaining methods
+type OrgUnitsClient struct {
+	*Client
+}
+
+// Entry point for organizational unit-related operations
+func (c *Client) OrgUnits() *OrgUnitsClient {
+	return &OrgUnitsClient{
+		Client: c,
+	}
+}
+
+// Which organizational units ListOrgUnits returns
+const (
+	OrgUnitTypeAll                = "ALL"                  // Every descendant of orgUnitPath
+	OrgUnitTypeChildren           = "CHILDREN"             // The immediate children of orgUnitPath only
+	OrgUnitTypeAllIncludingParent = "ALL_INCLUDING_PARENT" // orgUnitPath and every descendant
+)
+
+/*
+ * Query Parameters for Organizational Units
+ * Reference: https://developers.google.com/admin-sdk/directory/reference/rest/v1/orgunits/list#query-parameters
+ */
+type OrgUnitQuery struct {
+	OrgUnitPath string `url:"orgUnitPath,omitempty"` // The full path to the organizational unit or its unique ID. Returns the children of the specified organizational unit.
+	Type        string `url:"type,omitempty"`        // Whether to return all sub-organizations or just immediate children. Default: CHILDREN
+}
+
+/*
+ * # List Organizational Units
+ * /admin/directory/v1/customer/{customerId}/orgunits
+ * - https://developers.google.com/admin-sdk/directory/reference/rest/v1/orgunits/list
+ * - The API returns every match in a single response
+ */
+func (c *OrgUnitsClient) ListOrgUnits(customer *Customer, q *OrgUnitQuery) (*OrgUnits, error) {
+	url := c.BuildURL(DirectoryOrgUnits, customer)
+
+	return do[*OrgUnits](c.Client, "GET", url, q, nil)
+}
+
+/*
+ * # List all Organizational Units of the Customer
+ * /admin/directory/v1/customer/{customerId}/orgunits?type=ALL
+ * - https://developers.google.com/admin-sdk/directory/reference/rest/v1/orgunits/list
+ */
+func (c *OrgUnitsClient) ListAllOrgUnits(customer *Customer) (*OrgUnits, error) {
+	return c.ListOrgUnits(customer, &OrgUnitQuery{OrgUnitPath: "/", Type: OrgUnitTypeAll})
+}
+
+/*
+ * # Get the Tree of an Organizational Unit
+ * Lists `orgUnitPath` and all of its descendants, and nests every unit under its parent's OrganizationUnits, sorted by path
+ * - Use "/" for the whole customer; traverse the tree with WalkOrgUnits
+ * - https://developers.google.com/admin-sdk/directory/reference/rest/v1/orgunits/list
+ */
+func (c *OrgUnitsClient) OrgUnitTree(customer *Customer, orgUnitPath string) (*OrgUnit, error) {
+	root, err := c.GetOrgUnit(customer, orgUnitPath)
+	if err != nil {
+		return nil, err
+	}
+
+	descendants, err := c.ListOrgUnits(customer, &OrgUnitQuery{OrgUnitPath: root.Path, Type: OrgUnitTypeAll})
+	if err != nil {
+		return nil, err
+	}
+
+	units := map[string]*OrgUnit{root.ID: root}
+	root.OrganizationUnits = nil
+	for _, ou := range descendants.OrganizationUnits {
+		ou.OrganizationUnits = nil
+		units[ou.ID] = ou
+	}
+
+	for _, ou := range descendants.OrganizationUnits {
+		parent, ok := units[ou.ParentID]
+		if !ok {
+			return nil, fmt.Errorf("parent %s of %s is not under %s", ou.ParentID, ou.Path, root.Path)
+		}
+		parent.OrganizationUnits = append(parent.OrganizationUnits, ou)
+	}
+
+	for _, ou := range units {
+		sort.Slice(ou.OrganizationUnits, func(i, j int) bool {
+			return ou.OrganizationUnits[i].Path < ou.OrganizationUnits[j].Path
+		})
+	}
+
+	c.Log.Printf("Built the tree of %s: %d organizational unit(s)", root.Path, len(units))
+	return root, nil
+}
+
+/*
+ * WalkOrgUnits visits `root` and its descendants depth-first, parents before their children (see OrgUnitTree)
+ * `depth` is 0 for `root`; the walk stops at the first error returned by `fn`
+ */
+func WalkOrgUnits(root *OrgUnit, fn func(ou *OrgUnit, depth int) error) error {
+	return walkOrgUnits(root, 0, fn)
+}
+
+func walkOrgUnits(ou *OrgUnit, depth int, fn func(ou *OrgUnit, depth int) error) error {
+	if err := fn(ou, depth); err != nil {
+		return err
+	}
+	for _, child := range ou.OrganizationUnits {
+		if err := walkOrgUnits(child, depth+1, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+ * # Get an Organizational Unit
+ * /admin/directory/v1/customer/{customerId}/orgunits/{orgUnitPath=**}
+ * - https://developers.google.com/admin-sdk/directory/reference/rest/v1/orgunits/get
+ * - `orgUnitPath` is the full path of the unit, or `id:` followed by its ID; unlike AdminClient.GetOU, the result is never cached
+ */
+func (c *OrgUnitsClient) GetOrgUnit(customer *Customer, orgUnitPath string) (*OrgUnit, error) {
+	url := c.BuildURL(DirectoryOrgUnits, customer, strings.TrimPrefix(orgUnitPath, "/"))
+
+	return do[*OrgUnit](c.Client, "GET", url, nil, nil)
+}
+
+/*
+ * # Create an Organizational Unit
+ * /admin/directory/v1/customer/{customerId}/orgunits
+ * - https://developers.google.com/admin-sdk/directory/reference/rest/v1/orgunits/insert
+ * - `ou` needs a Name, and a ParentPath (or ParentID)
+ */
+func (c *OrgUnitsClient) CreateOrgUnit(customer *Customer, ou *OrgUnit) (*OrgUnit, error) {
+	url := c.BuildURL(DirectoryOrgUnits, customer)
+
+	return do[*OrgUnit](c.Client, "POST", url, nil, ou)
+}
+
+/*
+ * # Update an Organizational Unit
+ * /admin/directory/v1/customer/{customerId}/orgunits/{orgUnitPath=**}
+ * - https://developers.google.com/admin-sdk/directory/reference/rest/v1/orgunits/patch
+ * - Only the fields set in `ou` are changed; setting ParentPath moves the unit, and its descendants, under another parent
+ */
+func (c *OrgUnitsClient) UpdateOrgUnit(customer *Customer, orgUnitPath string, ou *OrgUnit) (*OrgUnit, error) {
+	url := c.BuildURL(DirectoryOrgUnits, customer, strings.TrimPrefix(orgUnitPath, "/"))
+
+	return do[*OrgUnit](c.Client, "PATCH", url, nil, ou)
+}
+
+/*
+ * # Delete an Organizational Unit
+ * /admin/directory/v1/customer/{customerId}/orgunits/{orgUnitPath=**}
+ * - https://developers.google.com/admin-sdk/directory/reference/rest/v1/orgunits/delete
+ * - Only empty units can be deleted: move their users (see MoveUsers) and delete their children first
+ */
+func (c *OrgUnitsClient) DeleteOrgUnit(customer *Customer, orgUnitPath string) error {
+	url := c.BuildURL(DirectoryOrgUnits, customer, strings.TrimPrefix(orgUnitPath, "/"))
+
+	_, err := do[interface{}](c.Client, "DELETE", url, nil, nil)
+	return err
+}
+
+/*
+ * # List the Users of an Organizational Unit
+ * /admin/directory/v1/users?query=orgUnitPath='{orgUnitPath}'
+ * - https://developers.google.com/admin-sdk/directory/v1/guides/search-users
+ * - Only the users directly in `orgUnitPath` are returned, not those of its descendants
+ */
+func (c *OrgUnitsClient) ListOrgUnitUsers(orgUnitPath string) (*Users, error) {
+	q := &UserQuery{
+		Query:      fmt.Sprintf("orgUnitPath='%s'", strings.ReplaceAll(orgUnitPath, "'", "\\'")),
+		MaxResults: 500,
+		Projection: BASIC,
+	}
+	if err := q.ValidateQuery(); err != nil {
+		return nil, err
+	}
+
+	found, err := requests.PaginatedDo(c.HTTP, "GET", DirectoryUsers, q, requests.Pagination[*User]{
+		Items: requests.ItemsField[*User]("users"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	users := &Users{}
+	for _, user := range found {
+		if strings.EqualFold(user.OrgUnitPath, orgUnitPath) {
+			users.Users = append(users.Users, user)
+		}
+	}
+
+	return users, nil
+}
+
+/*
+ * # Move Users to an Organizational Unit
+ * Sets the orgUnitPath of every user with batch requests of BatchSize calls
+ * The returned OrgUnitMoves lists every failed move; err is only set when a batch request failed
+ * - https://developers.google.com/admin-sdk/directory/reference/rest/v1/users/update
+ */
+func (c *OrgUnitsClient) MoveUsers(orgUnitPath string, userKeys ...string) (*OrgUnitMoves, error) {
+	moves := &OrgUnitMoves{OrgUnitPath: orgUnitPath}
+	body := map[string]string{"orgUnitPath": orgUnitPath}
+
+	calls := make([]*BatchCall, 0, len(userKeys))
+	for _, key := range userKeys {
+		calls = append(calls, &BatchCall{Method: "PUT", URL: c.BuildURL(DirectoryUsers, nil, key), Body: body})
+	}
+
+	c.Log.Printf("Moving %d user(s) to %s", len(calls), orgUnitPath)
+
+	for start := 0; start < len(calls); start += BatchSize {
+		end := min(start+BatchSize, len(calls))
+
+		results, err := c.Batch(DirectoryBatch, calls[start:end])
+		if err != nil {
+			return moves, err
+		}
+
+		for _, result := range results {
+			key := result.Call.URL[strings.LastIndex(result.Call.URL, "/")+1:]
+			c.forgetUsers(key)
+			if result.Err != nil {
+				moves.Failed = append(moves.Failed, &OrgUnitMoveFailure{User: key, Err: result.Err})
+				continue
+			}
+			moves.Moved = append(moves.Moved, key)
+		}
+	}
+
+	if len(moves.Failed) > 0 {
+		c.Log.Warningf("%d user(s) could not be moved to %s", len(moves.Failed), orgUnitPath)
+	}
+
+	return moves, nil
+}
@@ -42,6 +42,7 @@ func (c *Client) Permissions() *PermissionsClient {
  */
 type PermissionsQuery struct {
 	EmailMessage              string `url:"emailMessage,omitempty"`              // A plain text custom message to include in the notification email.
+	Fields                    string `url:"fields,omitempty"`                    // Selector specifying which fields to include in a partial response.
 	IncludePermissionsForView string `url:"includePermissionsForView,omitempty"` // Specifies which additional view's permissions to include in the response.
 	PageSize                  int    `url:"pageSize,omitempty"`                  // The maximum number of permissions to return per page.
 	PageToken                 string `url:"pageToken,omitempty"`                 // The token for continuing a previous list request on the next page.
@@ -57,6 +58,7 @@ type PermissionsQuery struct {
  */
 func (d *PermissionsQuery) IsEmpty() bool {
 	return d.EmailMessage == "" &&
+		d.Fields == "" &&
 		d.IncludePermissionsForView == "" &&
 		d.PageSize == 0 &&
 		d.PageToken == "" &&
@@ -142,3 +144,21 @@ func (c *PermissionsClient) TransferOwnership(driveID string, newOwner string) (
 
 	return permission, nil
 }
+
+/*
+ * # Delete Google Drive File Permission
+ * drive/v3/files/{fileId}/permissions/{permissionId}
+ * @param {string} fileId - The ID of the file or shared drive.
+ * @param {string} permissionId - The ID of the permission.
+ * https://developers.google.com/drive/api/reference/rest/v3/permissions/delete
+ */
+func (c *PermissionsClient) DeletePermission(fileID string, permissionID string) error {
+	url := c.BuildURL(DriveFiles, nil, fileID, "permissions", permissionID)
+
+	q := PermissionsQuery{
+		SupportsAllDrives: true,
+	}
+
+	_, err := do[interface{}](c.Client, "DELETE", url, q, nil)
+	return err
+}
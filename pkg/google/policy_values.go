@@ -0,0 +1,191 @@
+/*
+# Google Workspace - Typed Chrome Policies
+
+This package converts between the untyped PolicyValue payloads of the Chrome Policy API and the typed structs generated
+from policy schemas (see pkg/internal/policygen):
+https://developers.google.com/chrome/policy/reference/rest/v1/PolicyValue
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/policy_values.go
+package google
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gemini-oss/rego/pkg/common/validate"
+)
+
+// TypedPolicy is implemented by the structs generated from Chrome policy schemas
+type TypedPolicy interface {
+	PolicySchema() string // Fully qualified name of the schema, e.g. `chrome.users.MaxConnectionsPerProxy`
+}
+
+/*
+ * NewPolicyValue validates a typed policy and converts it to the PolicyValue sent to the Chrome Policy API
+ */
+func NewPolicyValue(p TypedPolicy) (PolicyValue, error) {
+	if err := validate.Struct(p); err != nil {
+		return PolicyValue{}, fmt.Errorf("invalid %s policy: %w", p.PolicySchema(), err)
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		return PolicyValue{}, err
+	}
+
+	value := map[string]interface{}{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return PolicyValue{}, err
+	}
+
+	return PolicyValue{PolicySchema: p.PolicySchema(), Value: value}, nil
+}
+
+/*
+ * NewPolicyModificationRequest converts a typed policy to a request setting it on an organizational unit
+ * The update mask lists every field set on the policy, so fields left empty keep their current value
+ */
+func NewPolicyModificationRequest(orgUnitID string, p TypedPolicy, additionalTargetKeys map[string]string) (*PolicyModificationRequest, error) {
+	value, err := NewPolicyValue(p)
+	if err != nil {
+		return nil, err
+	}
+	if len(value.Value) == 0 {
+		return nil, fmt.Errorf("no field of the %s policy is set", p.PolicySchema())
+	}
+
+	fields := make([]string, 0, len(value.Value))
+	for field := range value.Value {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	target := OrgUnitTarget(orgUnitID)
+	target.AdditionalTargetKeys = additionalTargetKeys
+
+	return &PolicyModificationRequest{
+		PolicyTargetKey: target,
+		PolicyValue:     value,
+		UpdateMask:      strings.Join(fields, ","),
+	}, nil
+}
+
+/*
+ * Decode converts a PolicyValue (e.g. of a ResolvedPolicy) to the typed policy of the same schema
+ */
+func (v PolicyValue) Decode(p TypedPolicy) error {
+	if v.PolicySchema != p.PolicySchema() {
+		return fmt.Errorf("policy value of %s cannot be decoded as %s", v.PolicySchema, p.PolicySchema())
+	}
+
+	data, err := json.Marshal(v.Value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, p)
+}
+
+// PolicyInt64 is an integer policy field, which the Chrome Policy API may encode as a JSON number or string
+type PolicyInt64 int64
+
+func (i *PolicyInt64) UnmarshalJSON(data []byte) error {
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	parsed, err := strconv.ParseInt(n.String(), 10, 64)
+	if err != nil {
+		return err
+	}
+	*i = PolicyInt64(parsed)
+	return nil
+}
+
+// ### Common Policies
+// ----------------------------------------------------------------------------
+// Hand-written typed policies for frequently-managed schemas; generate others with pkg/internal/policygen
+
+// Values of IncognitoMode.Availability
+const (
+	IncognitoModeAvailable   = "INCOGNITO_MODE_AVAILABILITY_ENUM_AVAILABLE"
+	IncognitoModeUnavailable = "INCOGNITO_MODE_AVAILABILITY_ENUM_UNAVAILABLE"
+	IncognitoModeForced      = "INCOGNITO_MODE_AVAILABILITY_ENUM_FORCED"
+)
+
+// IncognitoMode is the chrome.users.IncognitoMode policy
+type IncognitoMode struct {
+	Availability string `json:"incognitoModeAvailability,omitempty" validate:"enum=INCOGNITO_MODE_AVAILABILITY_ENUM_AVAILABLE|INCOGNITO_MODE_AVAILABILITY_ENUM_UNAVAILABLE|INCOGNITO_MODE_AVAILABILITY_ENUM_FORCED"`
+}
+
+// PolicySchema implements TypedPolicy
+func (*IncognitoMode) PolicySchema() string {
+	return "chrome.users.IncognitoMode"
+}
+
+// Values of SafeBrowsingProtectionLevel.Level
+const (
+	SafeBrowsingNoProtection       = "SAFE_BROWSING_PROTECTION_LEVEL_ENUM_NO_PROTECTION"
+	SafeBrowsingStandardProtection = "SAFE_BROWSING_PROTECTION_LEVEL_ENUM_STANDARD_PROTECTION"
+	SafeBrowsingEnhancedProtection = "SAFE_BROWSING_PROTECTION_LEVEL_ENUM_ENHANCED_PROTECTION"
+)
+
+// SafeBrowsingProtectionLevel is the chrome.users.SafeBrowsingProtectionLevel policy
+type SafeBrowsingProtectionLevel struct {
+	Level string `json:"safeBrowsingProtectionLevel,omitempty" validate:"enum=SAFE_BROWSING_PROTECTION_LEVEL_ENUM_NO_PROTECTION|SAFE_BROWSING_PROTECTION_LEVEL_ENUM_STANDARD_PROTECTION|SAFE_BROWSING_PROTECTION_LEVEL_ENUM_ENHANCED_PROTECTION"`
+}
+
+// PolicySchema implements TypedPolicy
+func (*SafeBrowsingProtectionLevel) PolicySchema() string {
+	return "chrome.users.SafeBrowsingProtectionLevel"
+}
+
+// PasswordManager is the chrome.users.PasswordManager policy; false is sent, so the password manager can be disabled
+type PasswordManager struct {
+	Enabled bool `json:"passwordManagerEnabled"`
+}
+
+// PolicySchema implements TypedPolicy
+func (*PasswordManager) PolicySchema() string {
+	return "chrome.users.PasswordManager"
+}
+
+// MaxConnectionsPerProxy is the chrome.users.MaxConnectionsPerProxy policy
+type MaxConnectionsPerProxy struct {
+	MaxConnections PolicyInt64 `json:"maxConnectionsPerProxy,omitempty"`
+}
+
+// PolicySchema implements TypedPolicy
+func (*MaxConnectionsPerProxy) PolicySchema() string {
+	return "chrome.users.MaxConnectionsPerProxy"
+}
+
+// Values of AppInstallType.InstallType
+const (
+	AppInstallAllowed   = "ALLOWED"
+	AppInstallBlocked   = "BLOCKED"
+	AppInstallForced    = "FORCED"
+	AppInstallForcedPin = "FORCED_AND_PIN_TO_TOOLBAR"
+)
+
+// AppInstallType is the chrome.users.apps.InstallType policy; target the app with AppTarget
+type AppInstallType struct {
+	InstallType string `json:"appInstallType,omitempty" validate:"enum=ALLOWED|BLOCKED|FORCED|FORCED_AND_PIN_TO_TOOLBAR"`
+}
+
+// PolicySchema implements TypedPolicy
+func (*AppInstallType) PolicySchema() string {
+	return "chrome.users.apps.InstallType"
+}
+
+// AppTarget returns the additional target keys of the app policies of a Chrome Web Store extension or app
+func AppTarget(extensionID string) map[string]string {
+	return map[string]string{"app_id": "chrome:" + extensionID}
+}
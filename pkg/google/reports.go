@@ -0,0 +1,145 @@
+/*
+# Google Workspace - Reports
+
+This package initializes all the methods for functions which fetch the activity events of the Reports API over
+arbitrary time windows:
+https://developers.google.com/admin-sdk/reports/reference/rest/v1/activities
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/reports.go
+package google
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+)
+
+// DefaultActivityWindow is the span of each request made by ReportsClient; large windows are split into windows of this span
+const DefaultActivityWindow = 24 * time.Hour
+
+// ReportsClient for chaining methods
+type ReportsClient struct {
+	*Client
+	Window time.Duration // Span of each request. Default: DefaultActivityWindow
+}
+
+// Entry point for reports-related operations
+func (c *Client) Reports() *ReportsClient {
+	return &ReportsClient{
+		Client: c,
+		Window: DefaultActivityWindow,
+	}
+}
+
+/*
+ * # Get all Activities
+ * /admin/reports/v1/activity/users/all/applications/{applicationName}
+ * - https://developers.google.com/admin-sdk/reports/reference/rest/v1/activities/list
+ * - Returns every activity of an application (e.g. admin, drive, login, token) between `start` and `end`, oldest window first
+ */
+func (c *ReportsClient) GetAllActivities(application string, start, end time.Time) ([]Report, error) {
+	activities := []Report{}
+	err := c.StreamActivities(application, start, end, nil, func(page []Report) error {
+		activities = append(activities, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return activities, nil
+}
+
+/*
+ * # Stream Activities
+ * /admin/reports/v1/activity/users/{userKey}/applications/{applicationName}
+ * - https://developers.google.com/admin-sdk/reports/reference/rest/v1/activities/list
+ * @param {string} application - Application of the activities, e.g. admin, drive, login, token
+ * @param {time.Time} start, end - Time window of the activities; `end` defaults to now
+ * @param {ReportsQuery} q - Optional filters (e.g. EventName, Filters, UserKey); its time window and page token are ignored
+ * @param {func} fn - Called with each page of activities; returning an error stops the listing
+ * - The window is split into windows of c.Window, each paginated with nextPageToken (see requests.PaginatedDo)
+ * - Activities are deduplicated by ID, as consecutive windows share their boundary
+ */
+func (c *ReportsClient) StreamActivities(application string, start, end time.Time, q *ReportsQuery, fn func(activities []Report) error) error {
+	if end.IsZero() {
+		end = time.Now()
+	}
+	if !start.Before(end) {
+		return fmt.Errorf("invalid activity window: %s is not before %s", start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+
+	window := c.Window
+	if window <= 0 {
+		window = DefaultActivityWindow
+	}
+
+	query := ReportsQuery{}
+	if q != nil {
+		query = *q
+	}
+	if query.MaxResults == 0 {
+		query.MaxResults = 1000
+	}
+	userKey := query.UserKey
+	if userKey == "" {
+		userKey = "all"
+	}
+	query.UserKey = ""
+
+	url := fmt.Sprintf(ReportsActivities, userKey, application)
+	c.Log.Debug("url:", url)
+
+	seen := map[ActivityID]struct{}{}
+	total := 0
+	for from := start; from.Before(end); from = from.Add(window) {
+		to := from.Add(window)
+		if to.After(end) {
+			to = end
+		}
+
+		query.StartTime = from.UTC().Format(time.RFC3339)
+		query.EndTime = to.UTC().Format(time.RFC3339)
+		query.PageToken = ""
+
+		var streamErr error
+		_, err := requests.PaginatedDo(c.HTTP, "GET", url, query, requests.Pagination[Report]{
+			Items:   requests.ItemsField[Report]("items"),
+			Discard: true,
+			OnPage: func(_ int, page []Report) bool {
+				activities := make([]Report, 0, len(page))
+				for _, activity := range page {
+					if _, ok := seen[activity.ID]; ok {
+						continue
+					}
+					seen[activity.ID] = struct{}{}
+					activities = append(activities, activity)
+				}
+
+				if len(activities) > 0 {
+					if streamErr = fn(activities); streamErr != nil {
+						return false
+					}
+					total += len(activities)
+				}
+				return true
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("listing %s activities from %s to %s: %w", application, query.StartTime, query.EndTime, err)
+		}
+		if streamErr != nil {
+			return streamErr
+		}
+
+		c.Log.Debugf("Streamed %s activities up to %s (%d so far)", application, query.EndTime, total)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+/*
+# Google Workspace - Retries
+
+This package decides which failed Google API requests are retried, and how long to wait before each retry:
+https://developers.google.com/drive/api/guides/limits#exponential
+https://developers.google.com/admin-sdk/directory/v1/limits
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/retry.go
+package google
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/crypt"
+	"github.com/gemini-oss/rego/pkg/common/requests"
+)
+
+// MaxBackoff caps the exponential wait between retries; a Retry-After header is honored up to requests.MaxRetryAfter
+const MaxBackoff = 32 * time.Second
+
+// rateLimitReasons are the error reasons of (per-minute) rate limits, which Drive and the Admin SDK return with a 403
+var rateLimitReasons = map[string]bool{
+	"rateLimitExceeded":     true,
+	"userRateLimitExceeded": true,
+	"RATE_LIMIT_EXCEEDED":   true,
+}
+
+/*
+ * Backoff is the retry policy of the Google clients (see requests.WithBackoff):
+ * - 429, 5xx, and 403s whose reason is a rate limit (e.g. `userRateLimitExceeded`) are retried, as are network errors
+ * - other client errors (e.g. 404, or a 403 for a missing permission or an exhausted daily quota) fail immediately
+ * - responses over the client's maximum size (requests.ErrResponseTooLarge) fail immediately
+ * - the wait honors Retry-After, and otherwise doubles from 1 second (plus up to 1 second of jitter) up to MaxBackoff
+ */
+func Backoff(attempt int, err error) (time.Duration, bool) {
+	if errors.Is(err, requests.ErrResponseTooLarge) {
+		return 0, false
+	}
+
+	var statusErr *requests.StatusError
+	if !errors.As(err, &statusErr) {
+		return backoff(attempt), true
+	}
+
+	switch {
+	case statusErr.StatusCode == http.StatusTooManyRequests, statusErr.StatusCode >= 500:
+	case statusErr.StatusCode == http.StatusForbidden && rateLimitReasons[ErrorReason(statusErr.Body)]:
+	default:
+		return 0, false
+	}
+
+	if statusErr.RetryAfter > 0 {
+		return statusErr.RetryAfter, true
+	}
+	return backoff(attempt), true
+}
+
+// backoff returns 2^attempt seconds plus up to 1 second of jitter, capped at MaxBackoff
+func backoff(attempt int) time.Duration {
+	wait := time.Duration(1<<min(attempt, 5)) * time.Second
+	if jitter, err := crypt.SecureRandomInt(1000); err == nil {
+		wait += time.Duration(jitter) * time.Millisecond
+	}
+	return min(wait, MaxBackoff)
+}
+
+// ErrorReason returns the reason of a Google API error response body, e.g. `userRateLimitExceeded`; empty when there is none
+func ErrorReason(body []byte) string {
+	var response ErrorResponse
+	if err := json.Unmarshal(body, &response); err != nil || response.Error == nil {
+		return ""
+	}
+	return response.Error.Reason()
+}
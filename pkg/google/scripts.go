@@ -0,0 +1,177 @@
+/*
+# Google Workspace - Apps Script
+
+This package initializes all the methods for functions which interact with the Google Apps Script API:
+https://developers.google.com/apps-script/api/reference/rest
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/scripts.go
+package google
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+)
+
+var (
+	ScriptBaseURL  = "https://script.googleapis.com/v1"
+	ScriptProjects = fmt.Sprintf("%s/projects", ScriptBaseURL) // https://developers.google.com/apps-script/api/reference/rest/v1/projects
+)
+
+// ScriptsClient for chaining methods
+type ScriptsClient struct {
+	*Client
+}
+
+// Entry point for Apps Script-related operations
+func (c *Client) Scripts() *ScriptsClient {
+	sc := &ScriptsClient{
+		Client: c,
+	}
+
+	// https://developers.google.com/apps-script/guides/services/quotas
+	sc.HTTP.RateLimiter.Available = 60
+	sc.HTTP.RateLimiter.Limit = 60
+	sc.HTTP.RateLimiter.Interval = 1 * time.Minute
+	sc.HTTP.RateLimiter.Log.Verbosity = c.Log.Verbosity
+
+	return sc
+}
+
+/*
+ * # Get a Script Project
+ * /v1/projects/{scriptId}
+ * - https://developers.google.com/apps-script/api/reference/rest/v1/projects/get
+ */
+func (c *ScriptsClient) GetProject(scriptID string) (*ScriptProject, error) {
+	url := c.BuildURL(ScriptProjects, nil, scriptID)
+
+	project, err := do[ScriptProject](c.Client, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+/*
+ * # Get the Content of a Script Project
+ * /v1/projects/{scriptId}/content
+ * - https://developers.google.com/apps-script/api/reference/rest/v1/projects/getContent
+ */
+func (c *ScriptsClient) GetProjectContent(scriptID string) (*ScriptContent, error) {
+	url := c.BuildURL(ScriptProjects, nil, scriptID, "content")
+
+	content, err := do[ScriptContent](c.Client, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &content, nil
+}
+
+/*
+ * # Update the Content of a Script Project
+ * /v1/projects/{scriptId}/content
+ * - https://developers.google.com/apps-script/api/reference/rest/v1/projects/updateContent
+ */
+func (c *ScriptsClient) UpdateProjectContent(scriptID string, content *ScriptContent) (*ScriptContent, error) {
+	url := c.BuildURL(ScriptProjects, nil, scriptID, "content")
+
+	updated, err := do[ScriptContent](c.Client, "PUT", url, nil, content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+/*
+ * # Create an immutable Version of a Script Project
+ * /v1/projects/{scriptId}/versions
+ * - https://developers.google.com/apps-script/api/reference/rest/v1/projects.versions/create
+ */
+func (c *ScriptsClient) CreateVersion(scriptID, description string) (*ScriptVersion, error) {
+	url := c.BuildURL(ScriptProjects, nil, scriptID, "versions")
+
+	version, err := do[ScriptVersion](c.Client, "POST", url, nil, &ScriptVersion{Description: description})
+	if err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+/*
+ * # List all Deployments of a Script Project
+ * /v1/projects/{scriptId}/deployments
+ * - https://developers.google.com/apps-script/api/reference/rest/v1/projects.deployments/list
+ */
+func (c *ScriptsClient) ListAllDeployments(scriptID string) (*ScriptDeployments, error) {
+	url := c.BuildURL(ScriptProjects, nil, scriptID, "deployments")
+
+	deployments, err := requests.PaginatedDo(c.HTTP, "GET", url, nil, requests.Pagination[*ScriptDeployment]{
+		Strategy: requests.TokenPages{SizeParam: "pageSize"},
+		Items:    requests.ItemsField[*ScriptDeployment]("deployments"),
+		PageSize: 50,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ScriptDeployments{Deployments: deployments}, nil
+}
+
+/*
+ * # Create a Deployment of a Script Project
+ * /v1/projects/{scriptId}/deployments
+ * - https://developers.google.com/apps-script/api/reference/rest/v1/projects.deployments/create
+ */
+func (c *ScriptsClient) CreateDeployment(scriptID string, config *ScriptDeploymentConfig) (*ScriptDeployment, error) {
+	url := c.BuildURL(ScriptProjects, nil, scriptID, "deployments")
+
+	deployment, err := do[ScriptDeployment](c.Client, "POST", url, nil, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deployment, nil
+}
+
+/*
+ * # Update a Deployment of a Script Project
+ * /v1/projects/{scriptId}/deployments/{deploymentId}
+ * - https://developers.google.com/apps-script/api/reference/rest/v1/projects.deployments/update
+ */
+func (c *ScriptsClient) UpdateDeployment(scriptID, deploymentID string, config *ScriptDeploymentConfig) (*ScriptDeployment, error) {
+	url := c.BuildURL(ScriptProjects, nil, scriptID, "deployments", deploymentID)
+
+	req := &ScriptUpdateDeploymentRequest{
+		DeploymentConfig: config,
+	}
+
+	deployment, err := do[ScriptDeployment](c.Client, "PUT", url, nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deployment, nil
+}
+
+/*
+ * # Delete a Deployment of a Script Project
+ * /v1/projects/{scriptId}/deployments/{deploymentId}
+ * - https://developers.google.com/apps-script/api/reference/rest/v1/projects.deployments/delete
+ */
+func (c *ScriptsClient) DeleteDeployment(scriptID, deploymentID string) error {
+	url := c.BuildURL(ScriptProjects, nil, scriptID, "deployments", deploymentID)
+
+	_, err := do[struct{}](c.Client, "DELETE", url, nil, nil)
+	return err
+}
@@ -0,0 +1,280 @@
+/*
+# Google Workspace - Shared Drives
+
+This package initializes all the methods for functions which interact with shared drives (formerly Team Drives), their
+members, and their content, through the Google Drive API:
+https://developers.google.com/drive/api/reference/rest/v3/drives
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/shared_drives.go
+package google
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+)
+
+/*
+ * Query Parameters for Shared Drives
+ * Reference: https://developers.google.com/drive/api/reference/rest/v3/drives/list#query-parameters
+ */
+type SharedDriveQuery struct {
+	AllowItemDeletion    bool   `url:"allowItemDeletion,omitempty"`    // Delete the items of the shared drive along with it. Requires UseDomainAdminAccess.
+	PageSize             int    `url:"pageSize,omitempty"`             // Maximum number of shared drives to return per page. Default: 10. Max: 100.
+	PageToken            string `url:"pageToken,omitempty"`            // Page token for shared drives.
+	Q                    string `url:"q,omitempty"`                    // Query string for searching shared drives, e.g. `name contains 'Finance'`.
+	RequestID            string `url:"requestId,omitempty"`            // An ID making the creation of a shared drive idempotent.
+	UseDomainAdminAccess bool   `url:"useDomainAdminAccess,omitempty"` // Issue the request as a domain administrator, returning every shared drive of the domain.
+}
+
+/*
+ * # List Shared Drives
+ * drive/v3/drives
+ * - https://developers.google.com/drive/api/reference/rest/v3/drives/list
+ * - Lists every shared drive of the domain with `q.UseDomainAdminAccess`; only those the caller is a member of otherwise
+ */
+func (c *DriveClient) ListSharedDrives(q *SharedDriveQuery) (*SharedDriveList, error) {
+	url := c.BuildURL(DriveDrives, nil)
+
+	if q == nil {
+		q = &SharedDriveQuery{UseDomainAdminAccess: true}
+	}
+	query := *q
+	if query.PageSize == 0 {
+		query.PageSize = 100
+	}
+
+	drives, err := requests.PaginatedDo(c.HTTP, "GET", url, query, requests.Pagination[*SharedDrive]{
+		Strategy: requests.TokenPages{SizeParam: "pageSize"},
+		Items:    requests.ItemsField[*SharedDrive]("drives"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SharedDriveList{Drives: drives}, nil
+}
+
+/*
+ * # Get a Shared Drive
+ * drive/v3/drives/{driveId}
+ * - https://developers.google.com/drive/api/reference/rest/v3/drives/get
+ */
+func (c *DriveClient) GetSharedDrive(driveID string) (*SharedDrive, error) {
+	url := c.BuildURL(DriveDrives, nil, driveID)
+
+	return do[*SharedDrive](c.Client, "GET", url, &SharedDriveQuery{UseDomainAdminAccess: true}, nil)
+}
+
+/*
+ * # Create a Shared Drive
+ * drive/v3/drives
+ * - https://developers.google.com/drive/api/reference/rest/v3/drives/create
+ * - The caller becomes the organizer of the shared drive; add members with AddSharedDrivePermission
+ */
+func (c *DriveClient) CreateSharedDrive(drive *SharedDrive) (*SharedDrive, error) {
+	url := c.BuildURL(DriveDrives, nil)
+
+	requestID, err := newRequestID()
+	if err != nil {
+		return nil, err
+	}
+
+	return do[*SharedDrive](c.Client, "POST", url, &SharedDriveQuery{RequestID: requestID}, drive)
+}
+
+/*
+ * # Update a Shared Drive
+ * drive/v3/drives/{driveId}
+ * - https://developers.google.com/drive/api/reference/rest/v3/drives/update
+ * - Only the restrictions set are changed, including those turned off, e.g. `DomainUsersOnly: optional.Of(false)`
+ */
+func (c *DriveClient) UpdateSharedDrive(driveID string, drive *SharedDrive) (*SharedDrive, error) {
+	url := c.BuildURL(DriveDrives, nil, driveID)
+
+	return do[*SharedDrive](c.Client, "PATCH", url, &SharedDriveQuery{UseDomainAdminAccess: true}, drive)
+}
+
+/*
+ * # Delete a Shared Drive
+ * drive/v3/drives/{driveId}
+ * - https://developers.google.com/drive/api/reference/rest/v3/drives/delete
+ * - The shared drive must be empty, unless `deleteItems` is set
+ */
+func (c *DriveClient) DeleteSharedDrive(driveID string, deleteItems bool) error {
+	url := c.BuildURL(DriveDrives, nil, driveID)
+
+	q := &SharedDriveQuery{
+		AllowItemDeletion:    deleteItems,
+		UseDomainAdminAccess: true,
+	}
+
+	_, err := do[interface{}](c.Client, "DELETE", url, q, nil)
+	return err
+}
+
+/*
+ * # List the Permissions of a Shared Drive
+ * drive/v3/files/{driveId}/permissions
+ * - https://developers.google.com/drive/api/reference/rest/v3/permissions/list
+ * - Members of a shared drive are the permissions on its root folder, whose ID is the ID of the drive
+ */
+func (c *DriveClient) ListSharedDrivePermissions(driveID string) (*PermissionList, error) {
+	url := c.BuildURL(DriveFiles, nil, driveID, "permissions")
+
+	q := PermissionsQuery{
+		Fields:               "nextPageToken, permissions(id, type, role, emailAddress, domain, displayName, deleted, permissionDetails)",
+		PageSize:             100,
+		SupportsAllDrives:    true,
+		UseDomainAdminAccess: true,
+	}
+
+	permissions, err := requests.PaginatedDo(c.HTTP, "GET", url, q, requests.Pagination[Permission]{
+		Strategy: requests.TokenPages{SizeParam: "pageSize"},
+		Items:    requests.ItemsField[Permission]("permissions"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &PermissionList{Permissions: permissions}, nil
+}
+
+/*
+ * # Add a Permission to a Shared Drive
+ * drive/v3/files/{driveId}/permissions
+ * - https://developers.google.com/drive/api/reference/rest/v3/permissions/create
+ * - `role` is one of organizer, fileOrganizer, writer, commenter or reader
+ */
+func (c *DriveClient) AddSharedDrivePermission(driveID string, permission *Permission) (*Permission, error) {
+	url := c.BuildURL(DriveFiles, nil, driveID, "permissions")
+
+	q := PermissionsQuery{
+		SupportsAllDrives:    true,
+		UseDomainAdminAccess: true,
+	}
+
+	return do[*Permission](c.Client, "POST", url, q, permission)
+}
+
+/*
+ * # Update a Permission of a Shared Drive
+ * drive/v3/files/{driveId}/permissions/{permissionId}
+ * - https://developers.google.com/drive/api/reference/rest/v3/permissions/update
+ */
+func (c *DriveClient) UpdateSharedDrivePermission(driveID, permissionID, role string) (*Permission, error) {
+	url := c.BuildURL(DriveFiles, nil, driveID, "permissions", permissionID)
+
+	q := PermissionsQuery{
+		SupportsAllDrives:    true,
+		UseDomainAdminAccess: true,
+	}
+
+	return do[*Permission](c.Client, "PATCH", url, q, &Permission{Role: role})
+}
+
+/*
+ * # Remove a Permission from a Shared Drive
+ * drive/v3/files/{driveId}/permissions/{permissionId}
+ * - https://developers.google.com/drive/api/reference/rest/v3/permissions/delete
+ */
+func (c *DriveClient) RemoveSharedDrivePermission(driveID, permissionID string) error {
+	url := c.BuildURL(DriveFiles, nil, driveID, "permissions", permissionID)
+
+	q := PermissionsQuery{
+		SupportsAllDrives:    true,
+		UseDomainAdminAccess: true,
+	}
+
+	_, err := do[interface{}](c.Client, "DELETE", url, q, nil)
+	return err
+}
+
+/*
+ * # List the Content of a Shared Drive
+ * Lists every file and folder of a shared drive, populating their Path (e.g. `Finance/Invoices/2024.pdf`)
+ * drive/v3/files
+ * - https://developers.google.com/drive/api/reference/rest/v3/files/list
+ * - Files whose parent cannot be found (e.g. trashed folders) are placed at the root of the drive
+ */
+func (c *DriveClient) ListSharedDriveFiles(driveID string) (*FileList, error) {
+	drive, err := c.GetSharedDrive(driveID)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &DriveFileQuery{
+		Corpora:                   "drive",
+		DriveID:                   driveID,
+		Fields:                    "nextPageToken, files(id, name, mimeType, parents, driveId, size, md5Checksum, createdTime, modifiedTime, lastModifyingUser, webViewLink)",
+		IncludeItemsFromAllDrives: true,
+		PageSize:                  1000,
+		Q:                         "trashed = false",
+		SupportsAllDrives:         true,
+	}
+
+	files := []*File{}
+	err = c.StreamFiles(q, func(page []*File) error {
+		files = append(files, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	SharedDrivePaths(drive, files)
+
+	c.Log.Printf("Listed %d file(s) in shared drive %s", len(files), drive.Name)
+	return &FileList{Files: &files}, nil
+}
+
+/*
+ * SharedDrivePaths sets the Path of files listed from a shared drive, starting with the name of the drive
+ * Only the first parent of a file is followed, as with GetFilePath
+ */
+func SharedDrivePaths(drive *SharedDrive, files []*File) {
+	byID := make(map[string]*File, len(files))
+	for _, file := range files {
+		byID[file.ID] = file
+	}
+
+	var resolve func(file *File, depth int) string
+	resolve = func(file *File, depth int) string {
+		if file.Path != "" {
+			return file.Path
+		}
+
+		var parent *File
+		if len(file.Parents) > 0 && file.Parents[0] != drive.ID {
+			parent = byID[file.Parents[0]]
+		}
+
+		// Guard against parent cycles, which Drive does not allow but a partial listing could suggest
+		if parent == nil || depth > len(files) {
+			file.Path = drive.Name + "/" + file.Name
+		} else {
+			file.Path = resolve(parent, depth+1) + "/" + file.Name
+		}
+		return file.Path
+	}
+
+	for _, file := range files {
+		resolve(file, 0)
+	}
+}
+
+// newRequestID returns a random ID for requests which must be idempotent, e.g. creating a shared drive
+func newRequestID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating a request ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -24,6 +24,7 @@ var (
 	SheetsBaseURL          = "https://sheets.googleapis.com/v4"
 	Sheets                 = fmt.Sprintf("%s/spreadsheets", SheetsBaseURL)             // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets
 	SheetByID              = fmt.Sprintf("%s/%s", Sheets, "%s")                        // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/get
+	SheetBatchUpdate       = fmt.Sprintf("%s/%s:batchUpdate", Sheets, "%s")            // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/batchUpdate
 	SheetValuesRange       = fmt.Sprintf("%s/%s/values/%s", Sheets, "%s", "%s")        // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets.values/get
 	SheetValuesBatchGet    = fmt.Sprintf("%s/%s/values:batchGet", Sheets, "%s")        // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets.values/batchGet
 	SheetValuesBatchUpdate = fmt.Sprintf("%s/%s/values:batchUpdate", Sheets, "%s")     // https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets.values/batchUpdate
@@ -210,13 +211,9 @@ func (c *SheetsClient) AppendSpreadsheet(spreadsheetID string, vr *ValueRange) e
  * - Sets the header row to bold and green, and auto-sizes all columns
  */
 func (c *SheetsClient) FormatHeaderAndAutoSize(spreadsheetID string, sheet *Sheet, rows, columns int) error {
-	url := fmt.Sprintf("%s/%s:batchUpdate", Sheets, spreadsheetID)
-
-	format := &SheetBatchRequest{}
-
-	// Set the header row to bold and green
-	format.Requests = append(format.Requests, &SheetRequest{
-		RepeatCell: &RepeatCellRequest{
+	_, err := c.BatchUpdate(spreadsheetID).
+		// Set the header row to bold and green
+		RepeatCell(&RepeatCellRequest{
 			Range: &GridRange{
 				SheetID:          sheet.Properties.SheetID,
 				StartRowIndex:    0,
@@ -239,12 +236,9 @@ func (c *SheetsClient) FormatHeaderAndAutoSize(spreadsheetID string, sheet *Shee
 				},
 			},
 			Fields: "userEnteredFormat(backgroundColor,textFormat)",
-		},
-	})
-
-	// Add a filter view for the header row
-	format.Requests = append(format.Requests, &SheetRequest{
-		SetBasicFilter: &SetBasicFilterRequest{
+		}).
+		// Add a filter view for the header row
+		SetBasicFilter(&SetBasicFilterRequest{
 			Filter: &BasicFilter{
 				Range: &GridRange{
 					SheetID:          sheet.Properties.SheetID,
@@ -254,28 +248,19 @@ func (c *SheetsClient) FormatHeaderAndAutoSize(spreadsheetID string, sheet *Shee
 					EndColumnIndex:   columns,
 				},
 			},
-		},
-	})
-
-	// Auto resize all columns
-	format.Requests = append(format.Requests, &SheetRequest{
-		AutoResizeDimensions: &AutoResizeDimensionsRequest{
+		}).
+		// Auto resize all columns
+		AutoResizeDimensions(&AutoResizeDimensionsRequest{
 			Dimensions: &DimensionRange{
 				SheetID:    sheet.Properties.SheetID,
 				Dimension:  "COLUMNS",
 				StartIndex: 0,
 				EndIndex:   columns,
 			},
-		},
-	})
+		}).
+		Do()
 
-	// Execute the batchUpdate request
-	_, err := do[any](c.Client, "POST", url, nil, format)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return err
 }
 
 /*
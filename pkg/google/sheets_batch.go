@@ -0,0 +1,580 @@
+/*
+# Google Workspace - Sheets Batch Updates
+
+This package initializes a builder for Google Sheets batch updates, assembling typed requests and validating them
+before they are sent:
+https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/batchUpdate
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/sheets_batch.go
+package google
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+/*
+ * BatchUpdate assembles the requests of a spreadsheet batch update, e.g.
+ *
+ *	resp, err := c.Sheets().BatchUpdate(spreadsheetID).
+ *		AddSheet(&AddSheetRequest{Properties: &SheetProperties{Title: "Inventory"}}).
+ *		SortRange(&SortRangeRequest{Range: rows, SortSpecs: []SortSpec{{DimensionIndex: 0, SortOrder: ASCENDING}}}).
+ *		Do()
+ *
+ * The requests are applied in order, atomically: when one fails, none is applied
+ * **ReGo only**
+ */
+type BatchUpdate struct {
+	client        *SheetsClient
+	spreadsheetID string
+	request       SheetBatchRequest
+}
+
+// Entry point for a batch update of a spreadsheet
+func (c *SheetsClient) BatchUpdate(spreadsheetID string) *BatchUpdate {
+	return &BatchUpdate{
+		client:        c,
+		spreadsheetID: spreadsheetID,
+	}
+}
+
+// Request adds a request which has no typed builder method, e.g. AddChart
+func (b *BatchUpdate) Request(r *SheetRequest) *BatchUpdate {
+	b.request.Requests = append(b.request.Requests, r)
+	return b
+}
+
+// IncludeSpreadsheet returns the updated spreadsheet in the response, with its grid data when `gridData` is set
+func (b *BatchUpdate) IncludeSpreadsheet(gridData bool, ranges ...string) *BatchUpdate {
+	b.request.IncludeSpreadsheetInResponse = true
+	b.request.ResponseIncludeGridData = gridData
+	b.request.ResponseRanges = ranges
+	return b
+}
+
+func (b *BatchUpdate) AddSheet(r *AddSheetRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{AddSheet: r})
+}
+
+func (b *BatchUpdate) DeleteSheet(r *DeleteSheetRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{DeleteSheet: r})
+}
+
+func (b *BatchUpdate) DuplicateSheet(r *DuplicateSheetRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{DuplicateSheet: r})
+}
+
+func (b *BatchUpdate) UpdateSheetProperties(r *UpdateSheetPropertiesRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{UpdateSheetProperties: r})
+}
+
+func (b *BatchUpdate) UpdateCells(r *UpdateCellsRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{UpdateCells: r})
+}
+
+func (b *BatchUpdate) AppendCells(r *AppendCellsRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{AppendCells: r})
+}
+
+func (b *BatchUpdate) RepeatCell(r *RepeatCellRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{RepeatCell: r})
+}
+
+func (b *BatchUpdate) AppendDimension(r *AppendDimensionRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{AppendDimension: r})
+}
+
+func (b *BatchUpdate) InsertDimension(r *InsertDimensionRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{InsertDimension: r})
+}
+
+func (b *BatchUpdate) DeleteDimension(r *DeleteDimensionRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{DeleteDimension: r})
+}
+
+func (b *BatchUpdate) MoveDimension(r *MoveDimensionRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{MoveDimension: r})
+}
+
+func (b *BatchUpdate) AutoResizeDimensions(r *AutoResizeDimensionsRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{AutoResizeDimensions: r})
+}
+
+func (b *BatchUpdate) UpdateDimensionProperties(r *UpdateDimensionPropertiesRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{UpdateDimensionProperties: r})
+}
+
+func (b *BatchUpdate) InsertRange(r *InsertRangeRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{InsertRange: r})
+}
+
+func (b *BatchUpdate) DeleteRange(r *DeleteRangeRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{DeleteRange: r})
+}
+
+func (b *BatchUpdate) SortRange(r *SortRangeRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{SortRange: r})
+}
+
+func (b *BatchUpdate) MergeCells(r *MergeCellsRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{MergeCells: r})
+}
+
+func (b *BatchUpdate) UnmergeCells(r *UnmergeCellsRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{UnmergeCells: r})
+}
+
+func (b *BatchUpdate) FindReplace(r *FindReplaceRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{FindReplace: r})
+}
+
+func (b *BatchUpdate) CopyPaste(r *CopyPasteRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{CopyPaste: r})
+}
+
+func (b *BatchUpdate) CutPaste(r *CutPasteRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{CutPaste: r})
+}
+
+func (b *BatchUpdate) AddNamedRange(r *AddNamedRangeRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{AddNamedRange: r})
+}
+
+func (b *BatchUpdate) DeleteNamedRange(r *DeleteNamedRangeRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{DeleteNamedRange: r})
+}
+
+func (b *BatchUpdate) AddProtectedRange(r *AddProtectedRangeRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{AddProtectedRange: r})
+}
+
+func (b *BatchUpdate) DeleteProtectedRange(r *DeleteProtectedRangeRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{DeleteProtectedRange: r})
+}
+
+func (b *BatchUpdate) AddConditionalFormatRule(r *AddConditionalFormatRuleRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{AddConditionalFormatRule: r})
+}
+
+func (b *BatchUpdate) DeleteConditionalFormatRule(r *DeleteConditionalFormatRuleRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{DeleteConditionalFormatRule: r})
+}
+
+func (b *BatchUpdate) SetBasicFilter(r *SetBasicFilterRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{SetBasicFilter: r})
+}
+
+func (b *BatchUpdate) ClearBasicFilter(r *ClearBasicFilterRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{ClearBasicFilter: r})
+}
+
+func (b *BatchUpdate) AddFilterView(r *AddFilterViewRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{AddFilterView: r})
+}
+
+func (b *BatchUpdate) DeleteFilterView(r *DeleteFilterViewRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{DeleteFilterView: r})
+}
+
+func (b *BatchUpdate) SetDataValidation(r *SetDataValidationRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{SetDataValidation: r})
+}
+
+func (b *BatchUpdate) TrimWhitespace(r *TrimWhitespaceRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{TrimWhitespace: r})
+}
+
+func (b *BatchUpdate) DeleteDuplicates(r *DeleteDuplicatesRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{DeleteDuplicates: r})
+}
+
+func (b *BatchUpdate) UpdateBorders(r *UpdateBordersRequest) *BatchUpdate {
+	return b.Request(&SheetRequest{UpdateBorders: r})
+}
+
+// Len returns the number of requests in the batch
+func (b *BatchUpdate) Len() int {
+	return len(b.request.Requests)
+}
+
+/*
+ * Validate checks that every request of the batch sets exactly one kind of update, and that typed updates have the
+ * fields the Sheets API requires, returning every problem found
+ */
+func (b *BatchUpdate) Validate() error {
+	if len(b.request.Requests) == 0 {
+		return fmt.Errorf("batch update of %s has no requests", b.spreadsheetID)
+	}
+
+	var errs []error
+	for i, r := range b.request.Requests {
+		if err := validateSheetRequest(r); err != nil {
+			errs = append(errs, fmt.Errorf("request %d: %w", i, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+/*
+ * # Spreadsheet: Batch Update
+ * Validates the requests, then applies them to the spreadsheet
+ * spreadsheets/{spreadsheetId}:batchUpdate
+ * https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets/batchUpdate
+ */
+func (b *BatchUpdate) Do() (*BatchUpdateSpreadsheetResponse, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf(SheetBatchUpdate, b.spreadsheetID)
+
+	return do[*BatchUpdateSpreadsheetResponse](b.client.Client, "POST", url, nil, &b.request)
+}
+
+// sheetValidator is implemented by the typed requests with required fields
+type sheetValidator interface {
+	validate() error
+}
+
+// validateSheetRequest checks that a request sets exactly one kind of update, and validates that update
+func validateSheetRequest(r *SheetRequest) error {
+	if r == nil {
+		return fmt.Errorf("request is nil")
+	}
+
+	set := []string{}
+	var update reflect.Value
+	v := reflect.ValueOf(r).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if v.Field(i).IsNil() {
+			continue
+		}
+		set = append(set, strings.Split(v.Type().Field(i).Tag.Get("json"), ",")[0])
+		update = v.Field(i)
+	}
+
+	switch len(set) {
+	case 0:
+		return fmt.Errorf("request sets no update")
+	case 1:
+	default:
+		return fmt.Errorf("request sets %d updates (%s); use one request per update", len(set), strings.Join(set, ", "))
+	}
+
+	if validator, ok := update.Interface().(sheetValidator); ok {
+		if err := validator.validate(); err != nil {
+			return fmt.Errorf("%s: %w", set[0], err)
+		}
+	}
+	return nil
+}
+
+// validateGridRange checks that a range is set, with non-negative indexes and each end after its start
+func validateGridRange(name string, r *GridRange) error {
+	if r == nil {
+		return fmt.Errorf("%s is required", name)
+	}
+	if r.StartRowIndex < 0 || r.EndRowIndex < 0 || r.StartColumnIndex < 0 || r.EndColumnIndex < 0 {
+		return fmt.Errorf("%s has a negative index", name)
+	}
+	// A zero end index is omitted, leaving the range unbounded
+	if r.EndRowIndex != 0 && r.EndRowIndex <= r.StartRowIndex {
+		return fmt.Errorf("%s ends at row %d, before or at its start %d", name, r.EndRowIndex, r.StartRowIndex)
+	}
+	if r.EndColumnIndex != 0 && r.EndColumnIndex <= r.StartColumnIndex {
+		return fmt.Errorf("%s ends at column %d, before or at its start %d", name, r.EndColumnIndex, r.StartColumnIndex)
+	}
+	return nil
+}
+
+// validateDimensionRange checks that a dimension range is set, with a valid dimension and a non-empty span
+func validateDimensionRange(name string, r *DimensionRange) error {
+	if r == nil {
+		return fmt.Errorf("%s is required", name)
+	}
+	if err := validateDimension(name+".dimension", r.Dimension); err != nil {
+		return err
+	}
+	if r.StartIndex < 0 || r.EndIndex <= r.StartIndex {
+		return fmt.Errorf("%s spans [%d, %d), which is empty", name, r.StartIndex, r.EndIndex)
+	}
+	return nil
+}
+
+func validateDimension(name, dimension string) error {
+	if dimension != "ROWS" && dimension != "COLUMNS" {
+		return fmt.Errorf("%s must be ROWS or COLUMNS, not %q", name, dimension)
+	}
+	return nil
+}
+
+func requireFields(fields string) error {
+	if fields == "" {
+		return fmt.Errorf("fields is required; use `*` to update every field")
+	}
+	return nil
+}
+
+func (r *UpdateSheetPropertiesRequest) validate() error {
+	if r.Properties == nil {
+		return fmt.Errorf("properties is required")
+	}
+	return requireFields(r.Fields)
+}
+
+func (r *UpdateCellsRequest) validate() error {
+	if (r.Start == nil) == (r.Range == nil) {
+		return fmt.Errorf("exactly one of start and range is required")
+	}
+	if r.Range != nil {
+		if err := validateGridRange("range", r.Range); err != nil {
+			return err
+		}
+	}
+	return requireFields(r.Fields)
+}
+
+func (r *AppendCellsRequest) validate() error {
+	if len(r.Rows) == 0 {
+		return fmt.Errorf("rows is required")
+	}
+	return requireFields(r.Fields)
+}
+
+func (r *RepeatCellRequest) validate() error {
+	if err := validateGridRange("range", r.Range); err != nil {
+		return err
+	}
+	if r.Cell == nil {
+		return fmt.Errorf("cell is required")
+	}
+	return requireFields(r.Fields)
+}
+
+func (r *AppendDimensionRequest) validate() error {
+	if err := validateDimension("dimension", r.Dimension); err != nil {
+		return err
+	}
+	if r.Length <= 0 {
+		return fmt.Errorf("length must be positive")
+	}
+	return nil
+}
+
+func (r *InsertDimensionRequest) validate() error {
+	return validateDimensionRange("range", r.Range)
+}
+
+func (r *DeleteDimensionRequest) validate() error {
+	return validateDimensionRange("range", r.Range)
+}
+
+func (r *MoveDimensionRequest) validate() error {
+	if err := validateDimensionRange("source", r.Source); err != nil {
+		return err
+	}
+	if r.DestinationIndex < 0 {
+		return fmt.Errorf("destinationIndex is negative")
+	}
+	return nil
+}
+
+func (r *AutoResizeDimensionsRequest) validate() error {
+	if (r.Dimensions == nil) == (r.DataSourceSheetDimensions == nil) {
+		return fmt.Errorf("exactly one of dimensions and dataSourceSheetDimensions is required")
+	}
+	if r.Dimensions != nil {
+		return validateDimensionRange("dimensions", r.Dimensions)
+	}
+	return nil
+}
+
+func (r *UpdateDimensionPropertiesRequest) validate() error {
+	if r.Properties == nil {
+		return fmt.Errorf("properties is required")
+	}
+	if (r.Range == nil) == (r.DataSourceSheetRange == nil) {
+		return fmt.Errorf("exactly one of range and dataSourceSheetRange is required")
+	}
+	if r.Range != nil {
+		if err := validateDimensionRange("range", r.Range); err != nil {
+			return err
+		}
+	}
+	return requireFields(r.Fields)
+}
+
+func (r *InsertRangeRequest) validate() error {
+	if err := validateGridRange("range", r.Range); err != nil {
+		return err
+	}
+	return validateDimension("shiftDimension", r.ShiftDimension)
+}
+
+func (r *DeleteRangeRequest) validate() error {
+	if err := validateGridRange("range", r.Range); err != nil {
+		return err
+	}
+	return validateDimension("shiftDimension", r.ShiftDimension)
+}
+
+func (r *SortRangeRequest) validate() error {
+	if err := validateGridRange("range", r.Range); err != nil {
+		return err
+	}
+	if len(r.SortSpecs) == 0 {
+		return fmt.Errorf("sortSpecs is required")
+	}
+	for i, spec := range r.SortSpecs {
+		if spec.SortOrder != "" && spec.SortOrder != ASCENDING && spec.SortOrder != DESCENDING {
+			return fmt.Errorf("sortSpecs[%d].sortOrder must be ASCENDING or DESCENDING, not %q", i, spec.SortOrder)
+		}
+		if spec.DimensionIndex < 0 {
+			return fmt.Errorf("sortSpecs[%d].dimensionIndex is negative", i)
+		}
+	}
+	return nil
+}
+
+func (r *MergeCellsRequest) validate() error {
+	if err := validateGridRange("range", r.Range); err != nil {
+		return err
+	}
+	switch r.MergeType {
+	case "MERGE_ALL", "MERGE_COLUMNS", "MERGE_ROWS":
+		return nil
+	default:
+		return fmt.Errorf("mergeType must be MERGE_ALL, MERGE_COLUMNS or MERGE_ROWS, not %q", r.MergeType)
+	}
+}
+
+func (r *UnmergeCellsRequest) validate() error {
+	return validateGridRange("range", r.Range)
+}
+
+func (r *FindReplaceRequest) validate() error {
+	if r.Find == "" {
+		return fmt.Errorf("find is required")
+	}
+	if r.Range != nil && r.AllSheets {
+		return fmt.Errorf("range and allSheets are exclusive")
+	}
+	if r.Range != nil {
+		return validateGridRange("range", r.Range)
+	}
+	return nil
+}
+
+func (r *CopyPasteRequest) validate() error {
+	if err := validateGridRange("source", r.Source); err != nil {
+		return err
+	}
+	return validateGridRange("destination", r.Destination)
+}
+
+func (r *CutPasteRequest) validate() error {
+	if err := validateGridRange("source", r.Source); err != nil {
+		return err
+	}
+	if r.Destination == nil {
+		return fmt.Errorf("destination is required")
+	}
+	return nil
+}
+
+func (r *AddNamedRangeRequest) validate() error {
+	if r.NamedRange == nil || r.NamedRange.Name == "" {
+		return fmt.Errorf("namedRange.name is required")
+	}
+	return validateGridRange("namedRange.range", r.NamedRange.Range)
+}
+
+func (r *DeleteNamedRangeRequest) validate() error {
+	if r.NamedRangeID == "" {
+		return fmt.Errorf("namedRangeId is required")
+	}
+	return nil
+}
+
+func (r *AddProtectedRangeRequest) validate() error {
+	if r.ProtectedRange == nil {
+		return fmt.Errorf("protectedRange is required")
+	}
+	if r.ProtectedRange.NamedRangeID != "" {
+		return nil
+	}
+	return validateGridRange("protectedRange.range", r.ProtectedRange.Range)
+}
+
+func (r *AddConditionalFormatRuleRequest) validate() error {
+	if r.Rule == nil || len(r.Rule.Ranges) == 0 {
+		return fmt.Errorf("rule.ranges is required")
+	}
+	if (r.Rule.BooleanRule == nil) == (r.Rule.GradientRule == nil) {
+		return fmt.Errorf("exactly one of rule.booleanRule and rule.gradientRule is required")
+	}
+	for i := range r.Rule.Ranges {
+		if err := validateGridRange(fmt.Sprintf("rule.ranges[%d]", i), &r.Rule.Ranges[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *SetBasicFilterRequest) validate() error {
+	if r.Filter == nil {
+		return fmt.Errorf("filter is required")
+	}
+	return validateGridRange("filter.range", r.Filter.Range)
+}
+
+func (r *AddFilterViewRequest) validate() error {
+	if r.Filter == nil {
+		return fmt.Errorf("filter is required")
+	}
+	return validateGridRange("filter.range", r.Filter.Range)
+}
+
+func (r *SetDataValidationRequest) validate() error {
+	if err := validateGridRange("range", r.Range); err != nil {
+		return err
+	}
+	if r.Rule != nil && (r.Rule.Condition == nil || r.Rule.Condition.Type == "") {
+		return fmt.Errorf("rule.condition.type is required; leave rule nil to clear the validation")
+	}
+	return nil
+}
+
+func (r *TrimWhitespaceRequest) validate() error {
+	return validateGridRange("range", r.Range)
+}
+
+func (r *DeleteDuplicatesRequest) validate() error {
+	if err := validateGridRange("range", r.Range); err != nil {
+		return err
+	}
+	for i := range r.ComparisonColumns {
+		if err := validateDimensionRange(fmt.Sprintf("comparisonColumns[%d]", i), &r.ComparisonColumns[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *UpdateBordersRequest) validate() error {
+	if err := validateGridRange("range", r.Range); err != nil {
+		return err
+	}
+	if r.Top == nil && r.Bottom == nil && r.Left == nil && r.Right == nil && r.InnerHorizontal == nil && r.InnerVertical == nil {
+		return fmt.Errorf("at least one border is required")
+	}
+	return nil
+}
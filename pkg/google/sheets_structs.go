@@ -0,0 +1,492 @@
+/*
+# Google Workspace - Sheets (Structs)
+
+This package contains helpers to write a slice of structs to a Google Sheet, with one column per field, and to read
+them back:
+https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets.values/update
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/sheets_structs.go
+package google
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultWriteChunkSize is the number of rows sent per request by WriteStructs
+const DefaultWriteChunkSize = 10000
+
+// WriteStructsOptions configures WriteStructs; the zero value writes every column, in field order
+type WriteStructsOptions struct {
+	Headers      []string // Columns to write, by header, and their order. Default: every field
+	ChunkSize    int      // Rows sent per request. Default: DefaultWriteChunkSize
+	FormatHeader bool     // Bold the header row, add a filter and auto-size the columns; the range must start at A1
+}
+
+// sheetColumn is a column of the sheet, and the path to the struct field it is read from
+type sheetColumn struct {
+	header string
+	index  []int
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+/*
+ * # Write Structs
+ * - Writes a slice (or array) of structs, or pointers to structs, to a sheet, with a header row first
+ * - Headers come from the `sheet` tag of each field, then its `json` tag, then its name; `sheet:"-"` skips a field
+ * - Embedded structs are flattened; other nested structs become `Parent.Field` columns
+ * - Rows are written in chunks of opts.ChunkSize, one request each, starting at the top-left cell of rangeNotation
+ * - Returns the number of rows written, excluding the header
+ *
+ *	type Row struct {
+ *		Email    string    `sheet:"Email"`
+ *		LastSeen time.Time `sheet:"Last Seen"`
+ *		internal string    `sheet:"-"`
+ *	}
+ *	n, err := c.Sheets().WriteStructs(spreadsheetID, "Report!A1", rows, &google.WriteStructsOptions{FormatHeader: true})
+ */
+func (c *SheetsClient) WriteStructs(spreadsheetID, rangeNotation string, rows interface{}, opts *WriteStructsOptions) (int, error) {
+	if opts == nil {
+		opts = &WriteStructsOptions{}
+	}
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultWriteChunkSize
+	}
+
+	values, err := StructValues(rows, opts.Headers)
+	if err != nil {
+		return 0, err
+	}
+
+	sheetName, column, row, err := parseRangeStart(rangeNotation)
+	if err != nil {
+		return 0, err
+	}
+	if opts.FormatHeader && (column != 0 || row != 1) {
+		return 0, fmt.Errorf("cannot format the header of %q: the range must start at A1", rangeNotation)
+	}
+
+	prefix := ""
+	if sheetName != "" {
+		prefix = sheetName + "!"
+	}
+	lastColumn := columnName(column + len(values[0]) - 1)
+
+	for start := 0; start < len(values); start += chunkSize {
+		end := min(start+chunkSize, len(values))
+		vr := &ValueRange{
+			Range:          fmt.Sprintf("%s%s%d:%s%d", prefix, columnName(column), row+start, lastColumn, row+end-1),
+			MajorDimension: "ROWS",
+			Values:         values[start:end],
+		}
+		if err := c.UpdateSpreadsheet(spreadsheetID, vr); err != nil {
+			return max(start-1, 0), fmt.Errorf("writing rows %d-%d: %w", row+start, row+end-1, err)
+		}
+		c.Log.Debugf("Wrote rows %d-%d of %d to %s", start+1, end, len(values), spreadsheetID)
+	}
+
+	if opts.FormatHeader {
+		spreadsheet, err := c.GetSpreadsheet(spreadsheetID)
+		if err != nil {
+			return len(values) - 1, err
+		}
+
+		title := unquoteSheetName(sheetName)
+		for i, sheet := range spreadsheet.Sheets {
+			if sheet.Properties == nil || (title != "" && sheet.Properties.Title != title) {
+				continue
+			}
+			err = c.FormatHeaderAndAutoSize(spreadsheetID, &spreadsheet.Sheets[i], len(values), len(values[0]))
+			return len(values) - 1, err
+		}
+		return len(values) - 1, fmt.Errorf("sheet %q not found in %s", title, spreadsheetID)
+	}
+
+	return len(values) - 1, nil
+}
+
+/*
+ * # Struct Values
+ * - Converts a slice (or array) of structs, or pointers to structs, to rows of cells, with a header row first
+ * - headers selects and orders the columns; every field is a column when it is empty
+ * - Nil pointers are written as empty cells, times as RFC 3339, and slices as comma-separated values
+ */
+func StructValues(rows interface{}, headers []string) ([][]string, error) {
+	val := reflect.ValueOf(rows)
+	for val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return nil, fmt.Errorf("expected a slice of structs, got a nil %s", val.Type())
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected a slice of structs, got %s", val.Kind())
+	}
+
+	elem := val.Type().Elem()
+	for elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a slice of structs, got a slice of %s", elem.Kind())
+	}
+
+	columns := structColumns(elem, "", nil)
+	if len(headers) > 0 {
+		byHeader := make(map[string]sheetColumn, len(columns))
+		for _, column := range columns {
+			byHeader[column.header] = column
+		}
+
+		selected := make([]sheetColumn, 0, len(headers))
+		for _, header := range headers {
+			column, ok := byHeader[header]
+			if !ok {
+				return nil, fmt.Errorf("%s has no column %q", elem, header)
+			}
+			selected = append(selected, column)
+		}
+		columns = selected
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("%s has no exported fields to write", elem)
+	}
+
+	values := make([][]string, 0, val.Len()+1)
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.header
+	}
+	values = append(values, header)
+
+	for i := 0; i < val.Len(); i++ {
+		item := val.Index(i)
+		for item.Kind() == reflect.Pointer && !item.IsNil() {
+			item = item.Elem()
+		}
+
+		row := make([]string, len(columns))
+		if item.Kind() == reflect.Struct {
+			for j, column := range columns {
+				field, err := item.FieldByIndexErr(column.index)
+				if err != nil {
+					continue // A nil embedded or nested pointer leaves its columns empty
+				}
+				row[j] = cellValue(field)
+			}
+		}
+		values = append(values, row)
+	}
+
+	return values, nil
+}
+
+// structColumns lists the columns of a struct type, recursing into nested structs
+func structColumns(typ reflect.Type, prefix string, index []int) []sheetColumn {
+	columns := make([]sheetColumn, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		header := sheetHeader(field)
+		if header == "-" {
+			continue
+		}
+
+		fieldIndex := append(append([]int{}, index...), i)
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+
+		switch {
+		case fieldType.Kind() == reflect.Struct && fieldType != timeType && field.Anonymous && field.Tag.Get("sheet") == "":
+			columns = append(columns, structColumns(fieldType, prefix, fieldIndex)...)
+		case fieldType.Kind() == reflect.Struct && fieldType != timeType:
+			columns = append(columns, structColumns(fieldType, prefix+header+".", fieldIndex)...)
+		default:
+			columns = append(columns, sheetColumn{header: prefix + header, index: fieldIndex})
+		}
+	}
+	return columns
+}
+
+// sheetHeader returns the header of a field: its `sheet` tag, then its `json` tag, then its name
+func sheetHeader(field reflect.StructField) string {
+	if tag := field.Tag.Get("sheet"); tag != "" {
+		return tag
+	}
+	if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag != "" {
+		return tag
+	}
+	return field.Name
+}
+
+// cellValue formats a field as the content of a cell
+func cellValue(val reflect.Value) string {
+	for val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return ""
+		}
+		val = val.Elem()
+	}
+
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+		if val.Type().Elem().Kind() == reflect.Uint8 {
+			return fmt.Sprintf("%s", val.Interface())
+		}
+		items := make([]string, val.Len())
+		for i := range items {
+			items[i] = cellValue(val.Index(i))
+		}
+		return strings.Join(items, ", ")
+	case reflect.Struct:
+		if t, ok := val.Interface().(time.Time); ok {
+			if t.IsZero() {
+				return ""
+			}
+			return t.Format(time.RFC3339)
+		}
+	}
+	return fmt.Sprint(val.Interface())
+}
+
+var a1Cell = regexp.MustCompile(`^([A-Za-z]*)(\d*)$`)
+
+/*
+ * parseRangeStart returns the sheet name, and the zero-based column and one-based row, of the top-left cell of a range in A1 notation
+ * e.g. `'Q1 Report'!B2:F` => `'Q1 Report'`, 1, 2; an empty range starts at A1 of the first sheet
+ */
+func parseRangeStart(rangeNotation string) (string, int, int, error) {
+	sheetName, cells := "", rangeNotation
+	if i := strings.LastIndex(rangeNotation, "!"); i >= 0 {
+		sheetName, cells = rangeNotation[:i], rangeNotation[i+1:]
+	}
+
+	start := strings.Split(cells, ":")[0]
+	match := a1Cell.FindStringSubmatch(start)
+	if match == nil {
+		return "", 0, 0, fmt.Errorf("invalid A1 range %q", rangeNotation)
+	}
+
+	column := 0
+	for _, r := range strings.ToUpper(match[1]) {
+		column = column*26 + int(r-'A') + 1
+	}
+	if column > 0 {
+		column--
+	}
+
+	row := 1
+	if match[2] != "" {
+		n, err := strconv.Atoi(match[2])
+		if err != nil || n < 1 {
+			return "", 0, 0, fmt.Errorf("invalid A1 range %q", rangeNotation)
+		}
+		row = n
+	}
+
+	return sheetName, column, row, nil
+}
+
+// columnName returns the A1 name of a zero-based column, e.g. 0 => A, 26 => AA
+func columnName(column int) string {
+	name := ""
+	for column++; column > 0; column = (column - 1) / 26 {
+		name = string(rune('A'+(column-1)%26)) + name
+	}
+	return name
+}
+
+// unquoteSheetName returns the title of a sheet from its A1 notation, e.g. `'Q1 Report'` => `Q1 Report`; doubled quotes are unescaped
+func unquoteSheetName(name string) string {
+	if len(name) >= 2 && strings.HasPrefix(name, "'") && strings.HasSuffix(name, "'") {
+		return strings.ReplaceAll(name[1:len(name)-1], "''", "'")
+	}
+	return name
+}
+
+/*
+ * # Read Structs
+ * - Reads a range of a sheet into a slice of T, mapping the header row (the first row of the range) to the fields of T
+ * - Fields are matched by header as in WriteStructs; columns without a field, and fields without a column, are ignored
+ * - Cells are coerced to the type of their field; see ParseStructs
+ *
+ *	rows, err := google.ReadStructs[Row](c.Sheets(), spreadsheetID, "Report!A:Z")
+ */
+func ReadStructs[T any](c *SheetsClient, spreadsheetID, rangeNotation string) ([]T, error) {
+	vr, err := c.ReadSpreadsheetValues(spreadsheetID, rangeNotation)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseStructs[T](vr.Values)
+}
+
+/*
+ * # Parse Structs
+ * - Converts rows of cells, with a header row first, to a slice of T
+ * - Numbers may use thousands separators, booleans are parsed by strconv.ParseBool, times are RFC 3339 (or a date),
+ *   durations are parsed by time.ParseDuration, and slices are comma-separated
+ * - Empty cells leave their field at its zero value (nil, for pointers)
+ */
+func ParseStructs[T any](values [][]string) ([]T, error) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	elem := typ
+	for elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct type, got %s", typ)
+	}
+	if len(values) == 0 {
+		return []T{}, nil
+	}
+
+	byHeader := make(map[string]sheetColumn)
+	for _, column := range structColumns(elem, "", nil) {
+		byHeader[column.header] = column
+	}
+	columns := make([]*sheetColumn, len(values[0]))
+	for i, header := range values[0] {
+		if column, ok := byHeader[strings.TrimSpace(header)]; ok {
+			columns[i] = &column
+		}
+	}
+
+	items := make([]T, 0, len(values)-1)
+	for r, row := range values[1:] {
+		item := reflect.New(elem).Elem()
+		for i, cell := range row {
+			if i >= len(columns) || columns[i] == nil || strings.TrimSpace(cell) == "" {
+				continue
+			}
+			if err := setCell(fieldByIndexAlloc(item, columns[i].index), strings.TrimSpace(cell)); err != nil {
+				return nil, fmt.Errorf("row %d, column %q: %w", r+2, columns[i].header, err)
+			}
+		}
+
+		// Wrap the struct back in as many pointers as T has
+		val := item
+		for t := elem; t != typ; {
+			ptr := reflect.New(val.Type())
+			ptr.Elem().Set(val)
+			val = ptr
+			t = reflect.PointerTo(t)
+		}
+		items = append(items, val.Interface().(T))
+	}
+
+	return items, nil
+}
+
+// fieldByIndexAlloc returns the nested field at index, allocating the nil pointers on the way
+func fieldByIndexAlloc(val reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 {
+			for val.Kind() == reflect.Pointer {
+				if val.IsNil() {
+					val.Set(reflect.New(val.Type().Elem()))
+				}
+				val = val.Elem()
+			}
+		}
+		val = val.Field(x)
+	}
+	return val
+}
+
+// setCell parses the content of a cell into a field, according to its type
+func setCell(field reflect.Value, cell string) error {
+	if field.Kind() == reflect.Pointer {
+		value := reflect.New(field.Type().Elem())
+		if err := setCell(value.Elem(), cell); err != nil {
+			return err
+		}
+		field.Set(value)
+		return nil
+	}
+
+	switch field.Type() {
+	case timeType:
+		for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"} {
+			if t, err := time.Parse(layout, cell); err == nil {
+				field.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid time %q", cell)
+	case reflect.TypeOf(time.Duration(0)):
+		d, err := time.ParseDuration(cell)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	number := strings.ReplaceAll(cell, ",", "")
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(cell)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(cell)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(number, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(number, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(number, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() == reflect.Uint8 {
+			field.SetBytes([]byte(cell))
+			return nil
+		}
+		items := strings.Split(cell, ",")
+		slice := reflect.MakeSlice(field.Type(), 0, len(items))
+		for _, item := range items {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			value := reflect.New(field.Type().Elem()).Elem()
+			if err := setCell(value, item); err != nil {
+				return err
+			}
+			slice = reflect.Append(slice, value)
+		}
+		field.Set(slice)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
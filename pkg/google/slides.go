@@ -0,0 +1,311 @@
+/*
+# Google Workspace - Slides
+
+This package initializes all the methods for functions which interact with the Google Slides API:
+https://developers.google.com/slides/api/reference/rest
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/slides.go
+package google
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var (
+	SlidesBaseURL = "https://slides.googleapis.com/v1"
+	Presentations = fmt.Sprintf("%s/presentations", SlidesBaseURL) // https://developers.google.com/slides/api/reference/rest/v1/presentations
+)
+
+// SlidesClient for chaining methods
+type SlidesClient struct {
+	*Client
+}
+
+// Entry point for slides-related operations
+func (c *Client) Slides() *SlidesClient {
+	sc := &SlidesClient{
+		Client: c,
+	}
+
+	// https://developers.google.com/slides/api/limits
+	sc.HTTP.RateLimiter.Available = 60
+	sc.HTTP.RateLimiter.Limit = 60
+	sc.HTTP.RateLimiter.Interval = 1 * time.Minute
+	sc.HTTP.RateLimiter.Log.Verbosity = c.Log.Verbosity
+
+	return sc
+}
+
+/*
+ * # Presentation: Create
+ * /v1/presentations
+ * - https://developers.google.com/slides/api/reference/rest/v1/presentations/create
+ */
+func (c *SlidesClient) CreatePresentation(p *Presentation) (*Presentation, error) {
+	presentation, err := do[Presentation](c.Client, "POST", Presentations, nil, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return &presentation, nil
+}
+
+/*
+ * # Presentation: Get
+ * /v1/presentations/{presentationId}
+ * - https://developers.google.com/slides/api/reference/rest/v1/presentations/get
+ */
+func (c *SlidesClient) GetPresentation(presentationID string) (*Presentation, error) {
+	url := c.BuildURL(Presentations, nil, presentationID)
+
+	presentation, err := do[Presentation](c.Client, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &presentation, nil
+}
+
+/*
+ * # Presentation: Batch Update
+ * /v1/presentations/{presentationId}:batchUpdate
+ * - https://developers.google.com/slides/api/reference/rest/v1/presentations/batchUpdate
+ */
+func (c *SlidesClient) BatchUpdate(presentationID string, req *SlidesBatchRequest) (*SlidesBatchResponse, error) {
+	url := c.BuildURL(Presentations, nil, presentationID, ":batchUpdate")
+
+	res, err := do[SlidesBatchResponse](c.Client, "POST", url, nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+/*
+ * # Generate a Slide Deck
+ * - Creates a new presentation with one TITLE_ONLY slide per section.
+ * - Each section may carry a table (Headers/Rows) and/or an embedded Sheets chart (SpreadsheetID/ChartID).
+ * - The default blank slide created with the presentation is removed.
+ */
+func (c *SlidesClient) GenerateDeck(title string, sections []*SlideSection) (*Presentation, error) {
+	presentation, err := c.CreatePresentation(&Presentation{Title: title})
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &SlidesBatchRequest{}
+	for _, slide := range presentation.Slides {
+		batch.Requests = append(batch.Requests, &SlidesRequest{
+			DeleteObject: &DeleteObjectRequest{ObjectID: slide.ObjectID},
+		})
+	}
+
+	for i, section := range sections {
+		batch.Requests = append(batch.Requests, slideSectionRequests(i, section)...)
+	}
+
+	_, err = c.BatchUpdate(presentation.PresentationID, batch)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Log.Printf("Generated slide deck with %d slides: https://docs.google.com/presentation/d/%s", len(sections), presentation.PresentationID)
+	return c.GetPresentation(presentation.PresentationID)
+}
+
+/*
+ * # Report to Slides
+ * - Converts a report struct to the sections of GenerateDeck, one slide per field, in field order
+ * - Titles come from the `slide` tag of each field, then its headers as in WriteStructs (`sheet`, `json`, name); `slide:"-"` skips a field
+ * - Slices of structs become a table with a column per field, as in StructValues
+ * - Nested structs become a two-column table of their fields and values
+ * - SlideSection fields (e.g. to embed a chart) are used as is, titled after the field when they have no title
+ * - Other fields are gathered on a first `Summary` slide
+ *
+ *	type OpsReview struct {
+ *		Devices   int              `slide:"Managed Devices"`
+ *		Offboards []Offboarding    `slide:"Offboardings"`
+ *		Trend     *google.SlideSection
+ *	}
+ *	sections, err := google.ToSlides(review)
+ *	deck, err := c.Slides().GenerateDeck("Monthly Ops Review", sections)
+ */
+func ToSlides(report interface{}) ([]*SlideSection, error) {
+	val := reflect.ValueOf(report)
+	for val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return nil, fmt.Errorf("expected a report struct, got a nil %s", val.Type())
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a report struct, got %s", val.Kind())
+	}
+
+	summary := &SlideSection{Title: "Summary", Headers: []string{"Metric", "Value"}}
+	sections := []*SlideSection{summary}
+
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		title := slideTitle(field)
+		if title == "-" {
+			continue
+		}
+
+		value := val.Field(i)
+		for value.Kind() == reflect.Pointer && !value.IsNil() {
+			value = value.Elem()
+		}
+
+		switch {
+		case value.Type() == slideSectionType:
+			section := value.Interface().(SlideSection)
+			if section.Title == "" {
+				section.Title = title
+			}
+			sections = append(sections, &section)
+
+		case value.Kind() == reflect.Pointer && value.Type().Elem() == slideSectionType:
+			continue // No slide for a nil section
+
+		case (value.Kind() == reflect.Slice || value.Kind() == reflect.Array) && isStructType(value.Type().Elem()):
+			values, err := StructValues(value.Interface(), nil)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", field.Name, err)
+			}
+			sections = append(sections, &SlideSection{Title: title, Headers: values[0], Rows: values[1:]})
+
+		case value.Kind() == reflect.Struct && value.Type() != timeType:
+			section := &SlideSection{Title: title, Headers: []string{"Field", "Value"}}
+			for _, column := range structColumns(value.Type(), "", nil) {
+				cell, err := value.FieldByIndexErr(column.index)
+				if err != nil {
+					continue // A nil nested pointer has no value
+				}
+				section.Rows = append(section.Rows, []string{column.header, cellValue(cell)})
+			}
+			sections = append(sections, section)
+
+		default:
+			summary.Rows = append(summary.Rows, []string{title, cellValue(value)})
+		}
+	}
+
+	if len(summary.Rows) == 0 {
+		sections = sections[1:]
+	}
+	return sections, nil
+}
+
+var slideSectionType = reflect.TypeOf(SlideSection{})
+
+// slideTitle returns the title of a field's slide: its `slide` tag, then its header as a sheet column
+func slideTitle(field reflect.StructField) string {
+	if tag := field.Tag.Get("slide"); tag != "" {
+		return tag
+	}
+	return sheetHeader(field)
+}
+
+// isStructType reports whether typ is a struct (other than time.Time), or a pointer to one
+func isStructType(typ reflect.Type) bool {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ.Kind() == reflect.Struct && typ != timeType
+}
+
+// slideSectionRequests builds the batchUpdate requests needed to render a single SlideSection
+func slideSectionRequests(index int, section *SlideSection) []*SlidesRequest {
+	slideID := fmt.Sprintf("rego_slide_%03d", index)
+	titleID := fmt.Sprintf("rego_title_%03d", index)
+
+	requests := []*SlidesRequest{
+		{
+			CreateSlide: &CreateSlideRequest{
+				ObjectID:             slideID,
+				InsertionIndex:       index,
+				SlideLayoutReference: &LayoutReference{PredefinedLayout: "TITLE_ONLY"},
+				PlaceholderIDMappings: []*LayoutPlaceholderMap{
+					{
+						LayoutPlaceholder: &SlidesPlaceholder{Type: "TITLE"},
+						ObjectID:          titleID,
+					},
+				},
+			},
+		},
+	}
+
+	if section.Title != "" {
+		requests = append(requests, &SlidesRequest{
+			InsertText: &InsertTextRequest{ObjectID: titleID, Text: section.Title},
+		})
+	}
+
+	if len(section.Headers) > 0 || len(section.Rows) > 0 {
+		tableID := fmt.Sprintf("rego_table_%03d", index)
+
+		table := [][]string{}
+		if len(section.Headers) > 0 {
+			table = append(table, section.Headers)
+		}
+		table = append(table, section.Rows...)
+
+		columns := 0
+		for _, row := range table {
+			if len(row) > columns {
+				columns = len(row)
+			}
+		}
+
+		requests = append(requests, &SlidesRequest{
+			CreateTable: &CreateTableRequest{
+				ObjectID:          tableID,
+				ElementProperties: &PageElementProperties{PageObjectID: slideID},
+				Rows:              len(table),
+				Columns:           columns,
+			},
+		})
+
+		for r, row := range table {
+			for col, value := range row {
+				if value == "" {
+					continue
+				}
+				requests = append(requests, &SlidesRequest{
+					InsertText: &InsertTextRequest{
+						ObjectID:     tableID,
+						CellLocation: &TableCellLocation{RowIndex: r, ColumnIndex: col},
+						Text:         value,
+					},
+				})
+			}
+		}
+	}
+
+	if section.SpreadsheetID != "" {
+		requests = append(requests, &SlidesRequest{
+			CreateSheetsChart: &CreateSheetsChartRequest{
+				ObjectID:          fmt.Sprintf("rego_chart_%03d", index),
+				ElementProperties: &PageElementProperties{PageObjectID: slideID},
+				SpreadsheetID:     section.SpreadsheetID,
+				ChartID:           section.ChartID,
+				LinkingMode:       "LINKED",
+			},
+		})
+	}
+
+	return requests
+}
@@ -0,0 +1,144 @@
+/*
+# Google Workspace - Tasks
+
+This package initializes all the methods for functions which interact with the Google Tasks API:
+https://developers.google.com/tasks/reference/rest
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/tasks.go
+package google
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+)
+
+var (
+	TasksBaseURL   = "https://tasks.googleapis.com/tasks/v1"
+	TasksUserLists = fmt.Sprintf("%s/users/@me/lists", TasksBaseURL)      // https://developers.google.com/tasks/reference/rest/v1/tasklists
+	TasksListTasks = fmt.Sprintf("%s/lists/%s/tasks", TasksBaseURL, "%s") // https://developers.google.com/tasks/reference/rest/v1/tasks
+)
+
+// TasksClient for chaining methods
+type TasksClient struct {
+	*Client
+}
+
+// Entry point for tasks-related operations
+//   - The Tasks API only exposes the calling user's data; impersonate the target user (Client.ImpersonateUser) first.
+func (c *Client) Tasks() *TasksClient {
+	tc := &TasksClient{
+		Client: c,
+	}
+
+	// https://developers.google.com/tasks/limits
+	tc.HTTP.RateLimiter.Available = 500
+	tc.HTTP.RateLimiter.Limit = 500
+	tc.HTTP.RateLimiter.Interval = 1 * time.Minute
+	tc.HTTP.RateLimiter.Log.Verbosity = c.Log.Verbosity
+
+	return tc
+}
+
+/*
+ * Query Parameters for Tasks
+ * Reference: https://developers.google.com/tasks/reference/rest/v1/tasks/list#query-parameters
+ */
+type TaskQuery struct {
+	MaxResults    int    `url:"maxResults,omitempty"`    // Maximum number of tasks returned on one page. Default: 20. Max: 100.
+	PageToken     string `url:"pageToken,omitempty"`     // Token specifying the result page to return.
+	ShowCompleted bool   `url:"showCompleted,omitempty"` // Flag indicating whether completed tasks are returned in the result.
+	ShowDeleted   bool   `url:"showDeleted,omitempty"`   // Flag indicating whether deleted tasks are returned in the result.
+	ShowHidden    bool   `url:"showHidden,omitempty"`    // Flag indicating whether hidden tasks are returned in the result.
+	UpdatedMin    string `url:"updatedMin,omitempty"`    // Lower bound for a task's last modification time (as a RFC 3339 timestamp) to filter by.
+}
+
+/*
+ * # List all Task Lists of the current user
+ * /tasks/v1/users/@me/lists
+ * - https://developers.google.com/tasks/reference/rest/v1/tasklists/list
+ */
+func (c *TasksClient) ListAllTaskLists() (*TaskLists, error) {
+	q := &TaskQuery{
+		MaxResults: 100,
+	}
+
+	lists, err := requests.PaginatedDo(c.HTTP, "GET", TasksUserLists, q, requests.Pagination[*TaskList]{
+		Items: requests.ItemsField[*TaskList]("items"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TaskLists{Items: lists}, nil
+}
+
+/*
+ * # List all Tasks in a Task List
+ * /tasks/v1/lists/{tasklist}/tasks
+ * - https://developers.google.com/tasks/reference/rest/v1/tasks/list
+ */
+func (c *TasksClient) ListAllTasks(taskListID string) (*Tasks, error) {
+	url := fmt.Sprintf(TasksListTasks, taskListID)
+	c.Log.Debug("url:", url)
+
+	q := &TaskQuery{
+		MaxResults:    100,
+		ShowCompleted: true,
+		ShowHidden:    true,
+	}
+
+	tasks, err := requests.PaginatedDo(c.HTTP, "GET", url, q, requests.Pagination[*Task]{
+		Items: requests.ItemsField[*Task]("items"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Tasks{Items: tasks}, nil
+}
+
+/*
+ * # Delete a Task List
+ * /tasks/v1/users/@me/lists/{tasklist}
+ * - https://developers.google.com/tasks/reference/rest/v1/tasklists/delete
+ */
+func (c *TasksClient) DeleteTaskList(taskListID string) error {
+	url := c.BuildURL(TasksUserLists, nil, taskListID)
+
+	_, err := do[struct{}](c.Client, "DELETE", url, nil, nil)
+	return err
+}
+
+/*
+ * # Export all Tasks for a User
+ * - Impersonates `email`, then enumerates every task list and its tasks (including completed and hidden tasks).
+ */
+func (c *TasksClient) ExportUserTasks(email string) (*TaskLists, error) {
+	err := c.ImpersonateUser(email)
+	if err != nil {
+		return nil, err
+	}
+
+	lists, err := c.ListAllTaskLists()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, list := range lists.Items {
+		tasks, err := c.ListAllTasks(list.ID)
+		if err != nil {
+			return nil, fmt.Errorf("listing tasks for %s: %w", list.Title, err)
+		}
+		list.Tasks = tasks.Items
+	}
+
+	c.Log.Printf("Exported %d task lists for %s", len(lists.Items), email)
+	return lists, nil
+}
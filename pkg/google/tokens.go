@@ -0,0 +1,132 @@
+/*
+# Google Workspace - Delegated Tokens
+
+This package caches the access tokens of the subjects impersonated by a service account with domain-wide delegation:
+https://developers.google.com/identity/protocols/oauth2/service-account#delegatingauthority
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/tokens.go
+package google
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+)
+
+// pooled carries the requests of every oauth2 client, and the token exchanges, over one pooled transport
+var pooled = &http.Client{Transport: requests.NewTransport(nil)}
+
+// oauthContext makes oauth2 send its requests through the pooled transport, rather than http.DefaultTransport
+func oauthContext() context.Context {
+	return context.WithValue(context.Background(), oauth2.HTTPClient, pooled)
+}
+
+// TokenRefreshMargin is how long before their expiry cached tokens are refreshed, so requests never carry an expiring token
+const TokenRefreshMargin = 5 * time.Minute
+
+// tokenCache keeps an access token per impersonated subject, shared by every copy of a Client and safe for concurrent use
+type tokenCache struct {
+	config   jwt.Config               // Service account; the subject is set per token
+	subjects map[string]*subjectToken // Keyed by lowercase subject
+	mutex    sync.Mutex
+}
+
+// subjectToken is the cached token of a subject; its mutex makes concurrent callers wait for a single refresh
+type subjectToken struct {
+	token *oauth2.Token
+	mutex sync.Mutex
+}
+
+func newTokenCache(config *jwt.Config) *tokenCache {
+	return &tokenCache{
+		config:   *config,
+		subjects: make(map[string]*subjectToken),
+	}
+}
+
+// Token returns the cached token of a subject, minting a new one when there is none or it expires within TokenRefreshMargin
+func (t *tokenCache) Token(subject string) (*oauth2.Token, error) {
+	t.mutex.Lock()
+	cached, ok := t.subjects[strings.ToLower(subject)]
+	if !ok {
+		cached = &subjectToken{}
+		t.subjects[strings.ToLower(subject)] = cached
+	}
+	t.mutex.Unlock()
+
+	cached.mutex.Lock()
+	defer cached.mutex.Unlock()
+
+	if cached.token != nil && (cached.token.Expiry.IsZero() || time.Until(cached.token.Expiry) > TokenRefreshMargin) {
+		return cached.token, nil
+	}
+
+	config := t.config
+	config.Subject = subject
+	token, err := config.TokenSource(oauthContext()).Token()
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate a token for %s: %w", subject, err)
+	}
+
+	cached.token = token
+	return token, nil
+}
+
+// Source returns a token source of a subject backed by the cache, for oauth2.NewClient
+func (t *tokenCache) Source(subject string) oauth2.TokenSource {
+	return &subjectTokenSource{cache: t, subject: subject}
+}
+
+type subjectTokenSource struct {
+	cache   *tokenCache
+	subject string
+}
+
+func (s *subjectTokenSource) Token() (*oauth2.Token, error) {
+	return s.cache.Token(s.subject)
+}
+
+/*
+ * # Get the Token of a Subject
+ * - Returns the cached access token of a user impersonated by the service account, minting one when needed
+ */
+func (c *Client) SubjectToken(subject string) (*oauth2.Token, error) {
+	if c.tokens == nil {
+		return nil, fmt.Errorf("impersonating %s requires a service account", subject)
+	}
+	return c.tokens.Token(subject)
+}
+
+/*
+ * # Get a Client Impersonating a Subject
+ * - Unlike ImpersonateUser, the client is left as is: the returned copy impersonates the subject, so goroutines can each
+ *   act as a different user. Tokens are cached per subject and shared by every copy of the client:
+ *
+ *	for _, email := range emails {
+ *		go func(email string) {
+ *			user, _ := c.ForSubject(email)
+ *			tasks, _ := user.Tasks().ListAllTasks("@default")
+ *		}(email)
+ *	}
+ */
+func (c *Client) ForSubject(subject string) (*Client, error) {
+	if _, err := c.SubjectToken(subject); err != nil {
+		return nil, err
+	}
+
+	clone := *c
+	clone.HTTP = c.HTTP.WithHTTPClient(oauth2.NewClient(oauthContext(), c.tokens.Source(subject)))
+	return &clone, nil
+}
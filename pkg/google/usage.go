@@ -0,0 +1,145 @@
+/*
+# Google Workspace - Usage Analytics
+
+This package initializes all the methods for functions which aggregate Meet and Calendar usage from the Reports API:
+https://developers.google.com/admin-sdk/reports/reference/rest
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/usage.go
+package google
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+ * # List all Activities for an application
+ * /admin/reports/v1/activity/users/all/applications/{applicationName}
+ * - https://developers.google.com/admin-sdk/reports/reference/rest/v1/activities/list
+ * - Collects the activities streamed by ReportsClient.StreamActivities between `start` and `end`, filtered by `q`
+ */
+func (c *AdminClient) listActivities(application string, start, end time.Time, q *ReportsQuery) ([]Report, error) {
+	activities := []Report{}
+	err := c.Reports().StreamActivities(application, start, end, q, func(page []Report) error {
+		activities = append(activities, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return activities, nil
+}
+
+/*
+ * # Collaboration Usage Report
+ * - Aggregates Meet (call_ended) and Calendar (create_event) activity between `start` and `end` per department.
+ * - Departments are taken from each user's primary organization in the Directory.
+ */
+func (c *AdminClient) CollaborationUsageReport(start, end time.Time) (CollaborationReport, error) {
+	users, err := c.Users().ListAllUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	departments := make(map[string]string, len(users.Users))
+	for _, user := range users.Users {
+		departments[strings.ToLower(user.PrimaryEmail)] = userDepartment(user)
+	}
+
+	report := CollaborationReport{}
+	activeUsers := map[string]map[string]struct{}{}
+	conferences := map[string]map[string]struct{}{}
+
+	department := func(email string) *CollaborationUsage {
+		name, ok := departments[strings.ToLower(email)]
+		if !ok || name == "" {
+			name = "Unknown"
+		}
+		if _, ok := report[name]; !ok {
+			report[name] = &CollaborationUsage{Department: name}
+			activeUsers[name] = map[string]struct{}{}
+			conferences[name] = map[string]struct{}{}
+		}
+		activeUsers[name][strings.ToLower(email)] = struct{}{}
+		return report[name]
+	}
+
+	meet, err := c.listActivities("meet", start, end, &ReportsQuery{EventName: "call_ended"})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, activity := range meet {
+		for _, event := range activity.Events {
+			params := reportParameters(event.Parameters)
+
+			participant := params["identifier"].Value
+			if participant == "" {
+				participant = activity.Actor.Email
+			}
+			if participant == "" {
+				continue
+			}
+
+			usage := department(participant)
+			usage.MeetingParticipants++
+			if seconds, err := strconv.ParseFloat(params["duration_seconds"].IntValue, 64); err == nil {
+				usage.MeetingMinutes += seconds / 60
+			}
+
+			organizer, conference := params["organizer_email"].Value, params["conference_id"].Value
+			if organizer != "" && conference != "" {
+				orgUsage := department(organizer)
+				conferences[orgUsage.Department][conference] = struct{}{}
+			}
+		}
+	}
+
+	calendar, err := c.listActivities("calendar", start, end, &ReportsQuery{EventName: "create_event"})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, activity := range calendar {
+		if activity.Actor.Email == "" {
+			continue
+		}
+		department(activity.Actor.Email).CalendarEventsCreated += len(activity.Events)
+	}
+
+	for name, usage := range report {
+		usage.ActiveUsers = len(activeUsers[name])
+		usage.MeetingsOrganized = len(conferences[name])
+	}
+
+	return report, nil
+}
+
+// userDepartment returns the department of a user's primary (or first) organization
+func userDepartment(u *User) string {
+	for _, org := range u.Organizations {
+		if org.Primary {
+			return org.Department
+		}
+	}
+	if len(u.Organizations) > 0 {
+		return u.Organizations[0].Department
+	}
+	return ""
+}
+
+// reportParameters indexes event parameters by name
+func reportParameters(params []ReportParameter) map[string]ReportParameter {
+	m := make(map[string]ReportParameter, len(params))
+	for _, p := range params {
+		m[p.Name] = p
+	}
+	return m
+}
@@ -0,0 +1,169 @@
+/*
+# Google Workspace - User Patches
+
+This package computes the minimal PATCH body between two versions of a Directory user, so fields set back to their zero
+value (false, "", an emptied list) are cleared instead of silently dropped by `omitempty`:
+https://developers.google.com/admin-sdk/directory/reference/rest/v1/users/patch
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/user_patch.go
+package google
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UserPatch is a PATCH body for a Directory user, keyed by the API's field names
+type UserPatch map[string]interface{}
+
+// userReadOnlyFields are output only, or changed through their own endpoint (e.g. makeAdmin, aliases), and never patched
+var userReadOnlyFields = map[string]bool{
+	"agreedToTerms":      true,
+	"aliases":            true, // InsertUserAlias, DeleteUserAlias
+	"creationTime":       true,
+	"customerId":         true,
+	"deletionTime":       true,
+	"etag":               true,
+	"id":                 true,
+	"isAdmin":            true, // SetAdmin
+	"isDelegatedAdmin":   true,
+	"isEnforcedIn2Sv":    true,
+	"isEnrolledIn2Sv":    true,
+	"isMailboxSetup":     true,
+	"kind":               true,
+	"lastLoginTime":      true,
+	"nonEditableAliases": true,
+	"roles":              true,
+	"suspensionReason":   true,
+	"thumbnailPhotoEtag": true, // UpdateUserPhoto
+	"thumbnailPhotoUrl":  true,
+}
+
+/*
+ * # Diff two Users
+ * Returns the fields of `after` that differ from `before`, e.g. to apply the edits of a workflow to the fetched user:
+ *
+ *	before, _ := c.GetUser(email)
+ *	after := *before
+ *	after.Suspended = false
+ *	after.Organizations = nil
+ *	patch := google.DiffUsers(before, &after) // {"organizations": null, "suspended": false}
+ *
+ * - changed scalars are sent as is, including their zero value, so `false` and `""` clear the field
+ * - lists are replaced as a whole; an emptied list is sent as null, which clears it
+ * - objects (name, gender, notes) are merged by the API, so only their changed fields are sent; an emptied object is null
+ * - read-only fields, and those with their own endpoint (isAdmin, aliases, the photo), are ignored
+ * - a changed password is sent as its plain value; it is never cleared
+ */
+func DiffUsers(before, after *User) UserPatch {
+	if before == nil {
+		before = &User{}
+	}
+	if after == nil {
+		after = &User{}
+	}
+
+	patch := UserPatch(diffObjects(reflect.ValueOf(*before), reflect.ValueOf(*after), userReadOnlyFields))
+
+	delete(patch, "password")
+	if after.Password.Value != "" && after.Password.Value != before.Password.Value {
+		patch["password"] = after.Password.Value
+	}
+
+	return patch
+}
+
+// Fields returns the names of the patched fields, sorted, e.g. for a dry run or an audit line
+func (p UserPatch) Fields() []string {
+	fields := make([]string, 0, len(p))
+	for field := range p {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// String lists the patched fields, without their values, so passwords never reach the logs
+func (p UserPatch) String() string {
+	return fmt.Sprintf("[%s]", strings.Join(p.Fields(), ", "))
+}
+
+/*
+ * Apply the Changes between two Versions of a User
+ * /admin/directory/v1/users/{userKey}
+ * https://developers.google.com/admin-sdk/directory/reference/rest/v1/users/patch
+ * - Sends only the fields DiffUsers finds changed; without changes, no request is made and `before` is returned
+ */
+func (c *UsersClient) PatchUserChanges(userKey string, before, after *User) (*User, error) {
+	patch := DiffUsers(before, after)
+	if len(patch) == 0 {
+		c.Log.Debugf("No changes to patch on user %s", userKey)
+		return before, nil
+	}
+
+	c.Log.Printf("Patching %s on user %s", patch, userKey)
+	return c.PatchUser(userKey, patch)
+}
+
+// diffObjects returns the JSON fields of two structs of the same type whose values differ
+func diffObjects(before, after reflect.Value, skip map[string]bool) map[string]interface{} {
+	patch := map[string]interface{}{}
+
+	for i := 0; i < after.NumField(); i++ {
+		field := after.Type().Field(i)
+		name := jsonName(field)
+		if name == "" || skip[name] {
+			continue
+		}
+
+		old, cur := before.Field(i), after.Field(i)
+		if reflect.DeepEqual(old.Interface(), cur.Interface()) {
+			continue
+		}
+		if (cur.Kind() == reflect.Slice || cur.Kind() == reflect.Map) && old.Len() == 0 && cur.Len() == 0 {
+			continue // nil and empty are the same list to the API
+		}
+
+		switch cur.Kind() {
+		case reflect.Struct:
+			if cur.IsZero() {
+				patch[name] = nil
+			} else {
+				patch[name] = diffObjects(old, cur, nil)
+			}
+		case reflect.Slice, reflect.Map, reflect.Pointer:
+			if cur.IsNil() || (cur.Kind() != reflect.Pointer && cur.Len() == 0) {
+				patch[name] = nil
+			} else {
+				patch[name] = cur.Interface()
+			}
+		default:
+			patch[name] = cur.Interface()
+		}
+	}
+
+	return patch
+}
+
+// jsonName returns the JSON name of an exported field, or "" when it is not marshalled
+func jsonName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
@@ -13,8 +13,13 @@ https://developers.google.com/admin-sdk/directory/reference/rest/v1/users
 package google
 
 import (
+	"crypto/rand"
+	"encoding/base64"
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
 )
 
 // UsersClient for chaining methods
@@ -117,27 +122,14 @@ func (c *UsersClient) ListAllUsers() (*Users, error) {
 	q.MaxResults = 500
 	q.Projection = BASIC
 
-	users, err := do[Users](c.Client, "GET", url, q, nil)
+	found, err := requests.PaginatedDo(c.HTTP, "GET", url, q, requests.Pagination[*User]{
+		Items: requests.ItemsField[*User]("users"),
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	for users.NextPageToken != "" {
-		q = UserQuery{
-			Customer:   q.Customer,
-			Domain:     q.Domain,
-			MaxResults: 500,
-			PageToken:  users.NextPageToken,
-		}
-
-		usersPage, err := do[Users](c.Client, "GET", url, q, nil)
-		if err != nil {
-			return nil, err
-		}
-		users.Users = append(users.Users, usersPage.Users...)
-		users.NextPageToken = usersPage.NextPageToken
-	}
-
+	users := Users{Users: found}
 	c.SetCache(url, users, 30*time.Minute)
 	return &users, nil
 }
@@ -168,17 +160,45 @@ func (c *UsersClient) SearchUsers(q *UserQuery) (*Users, error) {
  * Retrieves a User's Profile
  * /admin/directory/v1/users/{userKey}
  * https://developers.google.com/admin-sdk/directory/v1/reference/users/get
+ * - After UseCache, users are cached for 15 minutes by ID and primary email; writes through this client clear them
  */
 func (c *UsersClient) GetUser(userKey string) (*User, error) {
 	url := fmt.Sprintf(DirectoryUsers+"/%s", userKey)
 	c.Log.Debug("url:", url)
 
-	user, err := do[User](c.Client, "GET", url, nil, nil)
-	if err != nil {
-		return nil, err
-	}
+	return c.UserCache.GetOrLoad(userCacheKey(userKey), func() (*User, error) {
+		user, err := do[User](c.Client, "GET", url, nil, nil)
+		if err != nil {
+			return nil, err
+		}
 
-	return &user, nil
+		// Lookups by ID and by primary email share the entry; GetOrLoad stores the key of this lookup
+		for _, key := range []string{user.ID, user.PrimaryEmail} {
+			if key != "" && userCacheKey(key) != userCacheKey(userKey) {
+				c.UserCache.Set(userCacheKey(key), &user)
+			}
+		}
+		return &user, nil
+	})
+}
+
+// userCacheKey is the key of a user in UserCache; emails are case-insensitive, so every spelling shares the entry
+func userCacheKey(userKey string) string {
+	return strings.ToLower(userKey)
+}
+
+// forgetUsers removes users from UserCache after a write, under every key (ID, primary email) they were cached by
+func (c *Client) forgetUsers(userKeys ...string) {
+	for _, userKey := range userKeys {
+		if userKey == "" {
+			continue
+		}
+		if user, found := c.UserCache.Get(userCacheKey(userKey)); found {
+			c.UserCache.Delete(userCacheKey(user.ID))
+			c.UserCache.Delete(userCacheKey(user.PrimaryEmail))
+		}
+		c.UserCache.Delete(userCacheKey(userKey))
+	}
 }
 
 /*
@@ -195,5 +215,268 @@ func (c *UsersClient) UpdateUser(userKey string, u *User) (*User, error) {
 		return nil, err
 	}
 
+	c.forgetUsers(userKey, user.ID)
+	return &user, nil
+}
+
+/*
+ * Suspend a User
+ * /admin/directory/v1/users/{userKey}
+ * https://developers.google.com/admin-sdk/directory/reference/rest/v1/users/update
+ */
+func (c *UsersClient) SuspendUser(userKey string) (*User, error) {
+	url := fmt.Sprintf(DirectoryUsers+"/%s", userKey)
+
+	user, err := do[User](c.Client, "PUT", url, nil, map[string]bool{"suspended": true})
+	if err != nil {
+		return nil, err
+	}
+
+	c.forgetUsers(userKey, user.ID)
+	return &user, nil
+}
+
+/*
+ * Unsuspend a User
+ * /admin/directory/v1/users/{userKey}
+ * https://developers.google.com/admin-sdk/directory/reference/rest/v1/users/update
+ */
+func (c *UsersClient) UnsuspendUser(userKey string) (*User, error) {
+	url := fmt.Sprintf(DirectoryUsers+"/%s", userKey)
+
+	user, err := do[User](c.Client, "PUT", url, nil, map[string]bool{"suspended": false})
+	if err != nil {
+		return nil, err
+	}
+
+	c.forgetUsers(userKey, user.ID)
 	return &user, nil
 }
+
+/*
+ * Create a User
+ * /admin/directory/v1/users
+ * https://developers.google.com/admin-sdk/directory/reference/rest/v1/users/insert
+ * - A random password is generated (and set on `u`) when u.Password is empty; pair it with ChangePasswordAtNextLogin,
+ *   or with SSO, as nobody knows it
+ */
+func (c *UsersClient) InsertUser(u *NewUser) (*User, error) {
+	if u.PrimaryEmail == "" || u.Name.GivenName == "" || u.Name.FamilyName == "" {
+		return nil, fmt.Errorf("creating a user requires a primary email, a given name and a family name")
+	}
+
+	if u.Password == "" {
+		b := make([]byte, 24)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		u.Password = base64.RawURLEncoding.EncodeToString(b)
+	}
+
+	c.Log.Printf("Creating user %s", u.PrimaryEmail)
+	user, err := do[User](c.Client, "POST", DirectoryUsers, nil, u)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+/*
+ * Patch a User's Profile
+ * /admin/directory/v1/users/{userKey}
+ * https://developers.google.com/admin-sdk/directory/reference/rest/v1/users/patch
+ * - Only the fields of `patch` are changed, e.g. `map[string]interface{}{"orgUnitPath": "/Alumni", "includeInGlobalAddressList": false}`
+ * - To clear a field, use a map (or DiffUsers): zero-valued fields of a struct patch are dropped from the body, whatever
+ *   their tags, while the values of a map, `false` and `""` included, are sent as is
+ */
+func (c *UsersClient) PatchUser(userKey string, patch interface{}) (*User, error) {
+	url := fmt.Sprintf(DirectoryUsers+"/%s", userKey)
+
+	user, err := do[User](c.Client, "PATCH", url, nil, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	c.forgetUsers(userKey, user.ID)
+	return &user, nil
+}
+
+/*
+ * Delete a User
+ * /admin/directory/v1/users/{userKey}
+ * https://developers.google.com/admin-sdk/directory/reference/rest/v1/users/delete
+ * - Deleted users can be restored with UndeleteUser for 20 days
+ */
+func (c *UsersClient) DeleteUser(userKey string) error {
+	url := fmt.Sprintf(DirectoryUsers+"/%s", userKey)
+
+	c.Log.Printf("Deleting user %s", userKey)
+	_, err := do[any](c.Client, "DELETE", url, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	c.forgetUsers(userKey)
+	return nil
+}
+
+/*
+ * List the Users deleted in the last 20 days
+ * /admin/directory/v1/users?showDeleted=true
+ * https://developers.google.com/admin-sdk/directory/reference/rest/v1/users/list
+ * - Their IDs are the keys UndeleteUser expects
+ */
+func (c *UsersClient) ListDeletedUsers() (*Users, error) {
+	q := &UserQuery{MaxResults: 500, ShowDeleted: "true"}
+	if err := q.ValidateQuery(); err != nil {
+		return nil, err
+	}
+
+	users, err := requests.PaginatedDo(c.HTTP, "GET", DirectoryUsers, q, requests.Pagination[*User]{
+		Items: requests.ItemsField[*User]("users"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Users{Users: users}, nil
+}
+
+/*
+ * Undelete a User
+ * /admin/directory/v1/users/{userKey}/undelete
+ * https://developers.google.com/admin-sdk/directory/reference/rest/v1/users/undelete
+ * @param userID string - ID of the deleted user (see ListDeletedUsers); emails are not accepted
+ * @param orgUnitPath string - Organizational unit the user is restored to. Default: `/`
+ */
+func (c *UsersClient) UndeleteUser(userID, orgUnitPath string) error {
+	url := fmt.Sprintf(DirectoryUsers+"/%s/undelete", userID)
+	if orgUnitPath == "" {
+		orgUnitPath = "/"
+	}
+
+	c.Log.Printf("Restoring user %s to %s", userID, orgUnitPath)
+	_, err := do[any](c.Client, "POST", url, nil, map[string]string{"orgUnitPath": orgUnitPath})
+	return err
+}
+
+/*
+ * Make a User a Super Administrator, or Revoke it
+ * /admin/directory/v1/users/{userKey}/makeAdmin
+ * https://developers.google.com/admin-sdk/directory/reference/rest/v1/users/makeAdmin
+ */
+func (c *UsersClient) SetAdmin(userKey string, admin bool) error {
+	url := fmt.Sprintf(DirectoryUsers+"/%s/makeAdmin", userKey)
+
+	c.Log.Printf("Setting the super administrator status of %s to %t", userKey, admin)
+	_, err := do[any](c.Client, "POST", url, nil, map[string]bool{"status": admin})
+	if err != nil {
+		return err
+	}
+
+	c.forgetUsers(userKey)
+	return nil
+}
+
+/*
+ * Get a User's Photo
+ * /admin/directory/v1/users/{userKey}/photos/thumbnail
+ * https://developers.google.com/admin-sdk/directory/reference/rest/v1/users.photos/get
+ * - The image is in PhotoData (web-safe base64); see UserPhoto.Image
+ */
+func (c *UsersClient) GetUserPhoto(userKey string) (*UserPhoto, error) {
+	url := fmt.Sprintf(DirectoryUsers+"/%s/photos/thumbnail", userKey)
+
+	return do[*UserPhoto](c.Client, "GET", url, nil, nil)
+}
+
+/*
+ * Update a User's Photo
+ * /admin/directory/v1/users/{userKey}/photos/thumbnail
+ * https://developers.google.com/admin-sdk/directory/reference/rest/v1/users.photos/update
+ * @param image []byte - JPEG, PNG, GIF, BMP or TIFF image; Google resizes it to 96x96
+ * @param mimeType string - e.g. `image/png`, as returned by http.DetectContentType
+ */
+func (c *UsersClient) UpdateUserPhoto(userKey string, image []byte, mimeType string) (*UserPhoto, error) {
+	url := fmt.Sprintf(DirectoryUsers+"/%s/photos/thumbnail", userKey)
+
+	photo := &UserPhoto{
+		MimeType:  mimeType,
+		PhotoData: base64.URLEncoding.EncodeToString(image),
+	}
+
+	photo, err := do[*UserPhoto](c.Client, "PUT", url, nil, photo)
+	if err != nil {
+		return nil, err
+	}
+
+	c.forgetUsers(userKey)
+	return photo, nil
+}
+
+/*
+ * Delete a User's Photo
+ * /admin/directory/v1/users/{userKey}/photos/thumbnail
+ * https://developers.google.com/admin-sdk/directory/reference/rest/v1/users.photos/delete
+ */
+func (c *UsersClient) DeleteUserPhoto(userKey string) error {
+	url := fmt.Sprintf(DirectoryUsers+"/%s/photos/thumbnail", userKey)
+
+	_, err := do[any](c.Client, "DELETE", url, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	c.forgetUsers(userKey)
+	return nil
+}
+
+/*
+ * List a User's Aliases
+ * /admin/directory/v1/users/{userKey}/aliases
+ * https://developers.google.com/admin-sdk/directory/reference/rest/v1/users.aliases/list
+ */
+func (c *UsersClient) ListUserAliases(userKey string) (*UserAliases, error) {
+	url := fmt.Sprintf(DirectoryUsers+"/%s/aliases", userKey)
+
+	return do[*UserAliases](c.Client, "GET", url, nil, nil)
+}
+
+/*
+ * Add an Alias to a User
+ * /admin/directory/v1/users/{userKey}/aliases
+ * https://developers.google.com/admin-sdk/directory/reference/rest/v1/users.aliases/insert
+ * - Changing a user's primary email (e.g. `PatchUser(key, map[string]string{"primaryEmail": new})`) already keeps the
+ *   previous address as an alias; add the others, e.g. the address on a new domain after a rebranding
+ */
+func (c *UsersClient) InsertUserAlias(userKey, alias string) (*UserAlias, error) {
+	url := fmt.Sprintf(DirectoryUsers+"/%s/aliases", userKey)
+
+	c.Log.Printf("Adding alias %s to user %s", alias, userKey)
+	created, err := do[*UserAlias](c.Client, "POST", url, nil, &UserAlias{Alias: alias})
+	if err != nil {
+		return nil, err
+	}
+
+	c.forgetUsers(userKey)
+	return created, nil
+}
+
+/*
+ * Delete an Alias of a User
+ * /admin/directory/v1/users/{userKey}/aliases/{alias}
+ * https://developers.google.com/admin-sdk/directory/reference/rest/v1/users.aliases/delete
+ */
+func (c *UsersClient) DeleteUserAlias(userKey, alias string) error {
+	url := fmt.Sprintf(DirectoryUsers+"/%s/aliases/%s", userKey, alias)
+
+	c.Log.Printf("Removing alias %s from user %s", alias, userKey)
+	_, err := do[any](c.Client, "DELETE", url, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	c.forgetUsers(userKey)
+	return nil
+}
@@ -0,0 +1,328 @@
+/*
+# Google Workspace - Vault Entities
+
+This package contains the entities of the Google Vault API:
+https://developers.google.com/vault/reference/rest
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/vault/entities.go
+package vault
+
+import (
+	"fmt"
+)
+
+// Views of matters and holds
+const (
+	ViewBasic = "BASIC"
+	ViewFull  = "FULL"
+)
+
+// States of a matter
+const (
+	MatterOpen    = "OPEN"
+	MatterClosed  = "CLOSED"
+	MatterDeleted = "DELETED"
+)
+
+// Roles on a matter
+const (
+	RoleCollaborator = "COLLABORATOR"
+	RoleOwner        = "OWNER"
+)
+
+// Corpora (services) of holds and exports
+const (
+	CorpusDrive    = "DRIVE"
+	CorpusMail     = "MAIL"
+	CorpusGroups   = "GROUPS"
+	CorpusChat     = "HANGOUTS_CHAT"
+	CorpusVoice    = "VOICE"
+	CorpusCalendar = "CALENDAR"
+)
+
+// Data scopes of an export query
+const (
+	DataScopeAll         = "ALL_DATA"
+	DataScopeHeld        = "HELD_DATA"
+	DataScopeUnprocessed = "UNPROCESSED_DATA"
+)
+
+// Search methods of an export query
+const (
+	SearchAccount     = "ACCOUNT"
+	SearchOrgUnit     = "ORG_UNIT"
+	SearchSharedDrive = "SHARED_DRIVE"
+	SearchEntireOrg   = "ENTIRE_ORG"
+)
+
+// Formats of mail and groups exports
+const (
+	FormatMBOX = "MBOX"
+	FormatPST  = "PST"
+	FormatICS  = "ICS" // Calendar only
+)
+
+// Statuses of an export
+const (
+	ExportInProgress = "IN_PROGRESS"
+	ExportCompleted  = "COMPLETED"
+	ExportFailed     = "FAILED"
+)
+
+// ### Matters
+// ----------------------------------------------------------------------------
+
+// https://developers.google.com/vault/reference/rest/v1/matters/list#response-body
+type MatterList struct {
+	Matters       []*Matter `json:"matters,omitempty"`       // List of matters.
+	NextPageToken string    `json:"nextPageToken,omitempty"` // Page token to retrieve the next page of results in the list.
+}
+
+// https://developers.google.com/vault/reference/rest/v1/matters#Matter
+type Matter struct {
+	MatterID          string              `json:"matterId,omitempty"`          // The matter ID, generated by the server.
+	Name              string              `json:"name,omitempty"`              // The name of the matter.
+	Description       string              `json:"description,omitempty"`       // An optional description for the matter.
+	State             string              `json:"state,omitempty"`             // The state of the matter, e.g. `OPEN`.
+	MatterPermissions []*MatterPermission `json:"matterPermissions,omitempty"` // Lists the users and their permission for the matter (FULL view only).
+}
+
+// https://developers.google.com/vault/reference/rest/v1/matters#MatterPermission
+type MatterPermission struct {
+	Role      string `json:"role,omitempty"`      // The user's role for the matter, `COLLABORATOR` or `OWNER`.
+	AccountID string `json:"accountId,omitempty"` // The account ID, as provided by the Admin SDK.
+}
+
+// Response of the close and reopen methods
+type MatterResponse struct {
+	Matter *Matter `json:"matter,omitempty"` // The updated matter.
+}
+
+// END OF MATTER STRUCTS
+//-----------------------------------------------------------------------------
+
+// ### Holds
+// ----------------------------------------------------------------------------
+
+// https://developers.google.com/vault/reference/rest/v1/matters.holds/list#response-body
+type HoldList struct {
+	Holds         []*Hold `json:"holds,omitempty"`         // The list of holds.
+	NextPageToken string  `json:"nextPageToken,omitempty"` // Page token to retrieve the next page of results in the list.
+}
+
+// https://developers.google.com/vault/reference/rest/v1/matters.holds#Hold
+type Hold struct {
+	HoldID     string         `json:"holdId,omitempty"`     // The unique immutable ID of the hold, assigned when it's created.
+	Name       string         `json:"name,omitempty"`       // The name of the hold.
+	UpdateTime string         `json:"updateTime,omitempty"` // The last time this hold was modified.
+	Accounts   []*HeldAccount `json:"accounts,omitempty"`   // Accounts covered by the hold; exclusive with OrgUnit.
+	OrgUnit    *HeldOrgUnit   `json:"orgUnit,omitempty"`    // Organizational unit covered by the hold; exclusive with Accounts.
+	Corpus     string         `json:"corpus,omitempty"`     // The service to be searched, e.g. `MAIL`.
+	Query      *CorpusQuery   `json:"query,omitempty"`      // Service-specific options; only the one of the Corpus is set.
+}
+
+// https://developers.google.com/vault/reference/rest/v1/matters.holds.accounts#HeldAccount
+type HeldAccount struct {
+	AccountID string `json:"accountId,omitempty"` // The account ID, as provided by the Admin SDK.
+	Email     string `json:"email,omitempty"`     // The primary email address of the account; either it or AccountID is used when creating a hold.
+	FirstName string `json:"firstName,omitempty"` // Output only. The first name of the account holder.
+	LastName  string `json:"lastName,omitempty"`  // Output only. The last name of the account holder.
+	HoldTime  string `json:"holdTime,omitempty"`  // Output only. When the account was put on hold.
+}
+
+// https://developers.google.com/vault/reference/rest/v1/matters.holds#HeldOrgUnit
+type HeldOrgUnit struct {
+	OrgUnitID string `json:"orgUnitId,omitempty"` // The organizational unit's immutable ID, as provided by the Admin SDK.
+	HoldTime  string `json:"holdTime,omitempty"`  // When the organizational unit was put on hold.
+}
+
+// https://developers.google.com/vault/reference/rest/v1/matters.holds#CorpusQuery
+type CorpusQuery struct {
+	DriveQuery  *HeldDriveQuery  `json:"driveQuery,omitempty"`  // Service-specific options for Drive holds.
+	MailQuery   *HeldMailQuery   `json:"mailQuery,omitempty"`   // Service-specific options for Gmail holds.
+	GroupsQuery *HeldGroupsQuery `json:"groupsQuery,omitempty"` // Service-specific options for Groups holds.
+}
+
+type HeldDriveQuery struct {
+	IncludeSharedDriveFiles bool `json:"includeSharedDriveFiles,omitempty"` // To include files in shared drives in the hold.
+}
+
+type HeldMailQuery struct {
+	Terms     string `json:"terms,omitempty"`     // The search operators used to refine the messages covered by the hold.
+	StartTime string `json:"startTime,omitempty"` // The start time for the query, RFC 3339.
+	EndTime   string `json:"endTime,omitempty"`   // The end time for the query, RFC 3339.
+}
+
+type HeldGroupsQuery struct {
+	Terms     string `json:"terms,omitempty"`     // The search operators used to refine the messages covered by the hold.
+	StartTime string `json:"startTime,omitempty"` // The start time for the query, RFC 3339.
+	EndTime   string `json:"endTime,omitempty"`   // The end time for the query, RFC 3339.
+}
+
+// https://developers.google.com/vault/reference/rest/v1/matters.holds/addHeldAccounts#response-body
+type HeldAccountResponses struct {
+	Responses []*HeldAccountResponse `json:"responses,omitempty"` // The list of responses, in the same order as the batch request.
+}
+
+type HeldAccountResponse struct {
+	Account *HeldAccount `json:"account,omitempty"` // If present, the account was successfully created.
+	Status  *Status      `json:"status,omitempty"`  // Reports the request status; a failure when Code is not 0.
+}
+
+// https://developers.google.com/vault/reference/rest/v1/matters.holds/removeHeldAccounts#response-body
+type RemovedHeldAccounts struct {
+	Statuses []*Status `json:"statuses,omitempty"` // A list of statuses for the deleted accounts, in the same order as the request.
+}
+
+// https://developers.google.com/vault/reference/rest/v1/Status
+type Status struct {
+	Code    int    `json:"code,omitempty"`    // The status code, 0 on success.
+	Message string `json:"message,omitempty"` // A developer-facing error message.
+}
+
+// END OF HOLD STRUCTS
+//-----------------------------------------------------------------------------
+
+// ### Exports
+// ----------------------------------------------------------------------------
+
+// https://developers.google.com/vault/reference/rest/v1/matters.exports/list#response-body
+type ExportList struct {
+	Exports       []*Export `json:"exports,omitempty"`       // The list of exports.
+	NextPageToken string    `json:"nextPageToken,omitempty"` // Page token to retrieve the next page of results in the list.
+}
+
+// https://developers.google.com/vault/reference/rest/v1/matters.exports#Export
+type Export struct {
+	ID               string            `json:"id,omitempty"`               // Output only. The generated export ID.
+	MatterID         string            `json:"matterId,omitempty"`         // Output only. The matter ID.
+	Name             string            `json:"name,omitempty"`             // The export name; unique within the matter.
+	Query            *Query            `json:"query,omitempty"`            // The query parameters used to create the export.
+	ExportOptions    *ExportOptions    `json:"exportOptions,omitempty"`    // Additional export options.
+	CreateTime       string            `json:"createTime,omitempty"`       // Output only. The time when the export was created.
+	Status           string            `json:"status,omitempty"`           // Output only. The status of the export, e.g. `COMPLETED`.
+	Stats            *ExportStats      `json:"stats,omitempty"`            // Output only. Details about the export progress and size.
+	CloudStorageSink *CloudStorageSink `json:"cloudStorageSink,omitempty"` // Output only. The sink for export files in Cloud Storage.
+	Requester        *UserInfo         `json:"requester,omitempty"`        // Output only. The requester of the export.
+}
+
+// https://developers.google.com/vault/reference/rest/v1/Query
+type Query struct {
+	Corpus          string                `json:"corpus,omitempty"`              // The Google Workspace service to search, e.g. `MAIL`.
+	DataScope       string                `json:"dataScope,omitempty"`           // The data source to search, e.g. `ALL_DATA`.
+	SearchMethod    string                `json:"method,omitempty"`              // The entity to search, e.g. `ACCOUNT`.
+	AccountInfo     *AccountInfo          `json:"accountInfo,omitempty"`         // Required when SearchMethod is `ACCOUNT`.
+	OrgUnitInfo     *OrgUnitInfo          `json:"orgUnitInfo,omitempty"`         // Required when SearchMethod is `ORG_UNIT`.
+	SharedDriveInfo *SharedDriveInfo      `json:"sharedDriveInfo,omitempty"`     // Required when SearchMethod is `SHARED_DRIVE`.
+	Terms           string                `json:"terms,omitempty"`               // Service-specific search operators to filter search results.
+	StartTime       string                `json:"startTime,omitempty"`           // The start time for the search query, RFC 3339.
+	EndTime         string                `json:"endTime,omitempty"`             // The end time for the search query, RFC 3339.
+	TimeZone        string                `json:"timeZone,omitempty"`            // The time zone name, e.g. `America/New_York`.
+	MailOptions     *MailQueryOptions     `json:"mailOptions,omitempty"`         // Set Gmail search-specific options.
+	DriveOptions    *DriveQueryOptions    `json:"driveOptions,omitempty"`        // Set Drive search-specific options.
+	HangoutsOptions *ChatQueryOptions     `json:"hangoutsChatOptions,omitempty"` // Set Chat search-specific options.
+	VoiceOptions    *VoiceQueryOptions    `json:"voiceOptions,omitempty"`        // Set Voice search-specific options.
+	CalendarOptions *CalendarQueryOptions `json:"calendarOptions,omitempty"`     // Set Calendar search-specific options.
+}
+
+type AccountInfo struct {
+	Emails []string `json:"emails,omitempty"` // A set of accounts to search.
+}
+
+type OrgUnitInfo struct {
+	OrgUnitID string `json:"orgUnitId,omitempty"` // The name of the organizational unit to search, as provided by the Admin SDK.
+}
+
+type SharedDriveInfo struct {
+	SharedDriveIDs []string `json:"sharedDriveIds,omitempty"` // A list of shared drive IDs, as provided by the Drive API.
+}
+
+type MailQueryOptions struct {
+	ExcludeDrafts bool `json:"excludeDrafts,omitempty"` // Set to true to exclude drafts.
+}
+
+type DriveQueryOptions struct {
+	IncludeSharedDrives bool   `json:"includeSharedDrives,omitempty"` // Set to true to include shared drives.
+	VersionDate         string `json:"versionDate,omitempty"`         // Search the current version of the Drive file, but export the contents of the last version saved before this time.
+}
+
+type ChatQueryOptions struct {
+	IncludeRooms bool `json:"includeRooms,omitempty"` // For searches by account or organizational unit, set to true to include rooms.
+}
+
+type VoiceQueryOptions struct {
+	CoveredData []string `json:"coveredData,omitempty"` // Datatypes to search, e.g. `TEXT_MESSAGES`, `VOICEMAILS`, `CALL_LOGS`.
+}
+
+type CalendarQueryOptions struct {
+	LocationQuery []string `json:"locationQuery,omitempty"` // Matches only events whose location contains all of the words.
+	PeopleQuery   []string `json:"peopleQuery,omitempty"`   // Matches only events in which the custodian was invited by one of these people.
+	MinusWords    []string `json:"minusWords,omitempty"`    // Matches only events that do not contain any of the words.
+}
+
+// https://developers.google.com/vault/reference/rest/v1/matters.exports#ExportOptions
+type ExportOptions struct {
+	MailOptions     *MailExportOptions     `json:"mailOptions,omitempty"`     // Options for Gmail exports.
+	DriveOptions    *DriveExportOptions    `json:"driveOptions,omitempty"`    // Options for Drive exports.
+	GroupsOptions   *GroupsExportOptions   `json:"groupsOptions,omitempty"`   // Options for Groups exports.
+	CalendarOptions *CalendarExportOptions `json:"calendarOptions,omitempty"` // Options for Calendar exports.
+	Region          string                 `json:"region,omitempty"`          // The requested data region for the export, e.g. `US`, `EUROPE` or `ANY`.
+}
+
+type MailExportOptions struct {
+	ExportFormat                string `json:"exportFormat,omitempty"`                // The file format for exported messages, `MBOX` or `PST`.
+	ShowConfidentialModeContent bool   `json:"showConfidentialModeContent,omitempty"` // To export confidential mode content, set to true.
+	UseNewExport                bool   `json:"useNewExport,omitempty"`                // To use the new export system, set to true.
+}
+
+type DriveExportOptions struct {
+	IncludeAccessInfo bool `json:"includeAccessInfo,omitempty"` // To include access level information for users with indirect access to files, set to true.
+}
+
+type GroupsExportOptions struct {
+	ExportFormat string `json:"exportFormat,omitempty"` // The file format for exported messages, `MBOX` or `PST`.
+}
+
+type CalendarExportOptions struct {
+	ExportFormat string `json:"exportFormat,omitempty"` // The file format for exported events, `ICS` or `PST`.
+}
+
+// https://developers.google.com/vault/reference/rest/v1/matters.exports#ExportStats
+type ExportStats struct {
+	ExportedArtifactCount int64 `json:"exportedArtifactCount,omitempty,string"` // The number of messages or files successfully exported.
+	TotalArtifactCount    int64 `json:"totalArtifactCount,omitempty,string"`    // The number of messages or files to be exported.
+	SizeInBytes           int64 `json:"sizeInBytes,omitempty,string"`           // The size of the export in bytes.
+}
+
+// String summarizes the progress of an export, e.g. `120/300 artifacts, 52428800 bytes`
+func (s *ExportStats) String() string {
+	if s == nil {
+		return "no stats yet"
+	}
+	return fmt.Sprintf("%d/%d artifacts, %d bytes", s.ExportedArtifactCount, s.TotalArtifactCount, s.SizeInBytes)
+}
+
+// https://developers.google.com/vault/reference/rest/v1/matters.exports#CloudStorageSink
+type CloudStorageSink struct {
+	Files []*CloudStorageFile `json:"files,omitempty"` // The exported files in Cloud Storage.
+}
+
+type CloudStorageFile struct {
+	BucketName string `json:"bucketName,omitempty"`  // The name of the Cloud Storage bucket for the export file.
+	ObjectName string `json:"objectName,omitempty"`  // The name of the Cloud Storage object for the export file.
+	Size       int64  `json:"size,omitempty,string"` // The export file size.
+	MD5Hash    string `json:"md5Hash,omitempty"`     // The base64-encoded MD5 hash of the export file.
+}
+
+type UserInfo struct {
+	Email       string `json:"email,omitempty"`       // The email address of the user.
+	DisplayName string `json:"displayName,omitempty"` // The displayed name of the user.
+}
+
+// END OF EXPORT STRUCTS
+//-----------------------------------------------------------------------------
@@ -0,0 +1,220 @@
+/*
+# Google Workspace - Vault Exports
+
+This package contains the methods to export the data of a matter, wait for the export to complete, and download its
+files from Cloud Storage to disk, checking each against the MD5 hash reported by Vault:
+https://developers.google.com/vault/reference/rest/v1/matters.exports
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/vault/exports.go
+package vault
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+	"github.com/gemini-oss/rego/pkg/google"
+)
+
+var (
+	StorageObjects = "https://storage.googleapis.com/storage/v1/b/%s/o/%s" // https://cloud.google.com/storage/docs/json_api/v1/objects/get
+)
+
+// DefaultPollInterval is the wait between two checks of WaitForExport; exports take minutes to hours
+const DefaultPollInterval = 30 * time.Second
+
+/*
+ * # List all Exports of a Matter
+ * /v1/matters/{matterId}/exports
+ * - https://developers.google.com/vault/reference/rest/v1/matters.exports/list
+ */
+func (c *Client) ListAllExports(matterID string) (*ExportList, error) {
+	url := c.BuildURL(Matters, nil, matterID, "exports")
+
+	exports, err := requests.PaginatedDo(c.HTTP, "GET", url, nil, requests.Pagination[*Export]{
+		Strategy: tokenPages,
+		Items:    requests.ItemsField[*Export]("exports"),
+		PageSize: 100,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExportList{Exports: exports}, nil
+}
+
+/*
+ * # Get an Export
+ * /v1/matters/{matterId}/exports/{exportId}
+ * - https://developers.google.com/vault/reference/rest/v1/matters.exports/get
+ */
+func (c *Client) GetExport(matterID, exportID string) (*Export, error) {
+	url := c.BuildURL(Matters, nil, matterID, "exports", exportID)
+
+	return do[*Export](c, "GET", url, nil, nil)
+}
+
+/*
+ * # Create an Export
+ * /v1/matters/{matterId}/exports
+ * - https://developers.google.com/vault/reference/rest/v1/matters.exports/create
+ * - Export names must be unique within the matter; a matter runs at most 20 exports at a time
+ *
+ *	export, err := v.CreateExport(matterID, &vault.Export{
+ *		Name: "Custodians - Mail - 2024-07-01",
+ *		Query: &vault.Query{
+ *			Corpus:       vault.CorpusMail,
+ *			DataScope:    vault.DataScopeAll,
+ *			SearchMethod: vault.SearchAccount,
+ *			AccountInfo:  &vault.AccountInfo{Emails: []string{"ada@example.com"}},
+ *		},
+ *		ExportOptions: &vault.ExportOptions{MailOptions: &vault.MailExportOptions{ExportFormat: vault.FormatMBOX}},
+ *	})
+ */
+func (c *Client) CreateExport(matterID string, export *Export) (*Export, error) {
+	if export.Query == nil {
+		return nil, fmt.Errorf("export %q needs a query", export.Name)
+	}
+
+	url := c.BuildURL(Matters, nil, matterID, "exports")
+
+	c.Log.Printf("Creating export %q on matter %s", export.Name, matterID)
+	return do[*Export](c, "POST", url, nil, export)
+}
+
+/*
+ * # Delete an Export
+ * /v1/matters/{matterId}/exports/{exportId}
+ * - https://developers.google.com/vault/reference/rest/v1/matters.exports/delete
+ * - Exports are deleted by Vault after 15 days anyway; download them first
+ */
+func (c *Client) DeleteExport(matterID, exportID string) error {
+	url := c.BuildURL(Matters, nil, matterID, "exports", exportID)
+
+	c.Log.Printf("Deleting export %s of matter %s", exportID, matterID)
+	_, err := do[interface{}](c, "DELETE", url, nil, nil)
+	return err
+}
+
+/*
+ * # Wait for an Export
+ * Polls the export every `interval` (DefaultPollInterval when 0) until it completes or fails, or the context of the
+ * client is done (see WithContext)
+ * - A failed export is returned along with an error
+ */
+func (c *Client) WaitForExport(matterID, exportID string, interval time.Duration) (*Export, error) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	ctx := c.HTTP.Context()
+
+	for {
+		export, err := c.GetExport(matterID, exportID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch export.Status {
+		case ExportCompleted:
+			c.Log.Printf("Export %q completed: %s", export.Name, export.Stats)
+			return export, nil
+		case ExportFailed:
+			return export, fmt.Errorf("export %q of matter %s failed", export.Name, matterID)
+		}
+
+		c.Log.Debugf("Export %q is %s: %s", export.Name, export.Status, export.Stats)
+		select {
+		case <-ctx.Done():
+			return export, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+/*
+ * # Download an Export
+ * Downloads every file of a completed export into `dir` (created if needed), and returns their paths
+ * - https://developers.google.com/vault/guides/exports#download_an_export
+ * - each file is checked against its MD5 hash, and removed when it does not match
+ * - files are written with owner-only permissions, as they hold the exported data
+ */
+func (c *Client) DownloadExport(export *Export, dir string) ([]string, error) {
+	if export.Status != ExportCompleted || export.CloudStorageSink == nil {
+		return nil, fmt.Errorf("export %q is %s, and has no files to download", export.Name, export.Status)
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(export.CloudStorageSink.Files))
+	for _, file := range export.CloudStorageSink.Files {
+		path := filepath.Join(dir, filepath.Base(file.ObjectName))
+
+		c.Log.Printf("Downloading %s (%d bytes) to %s", file.ObjectName, file.Size, path)
+		if err := c.downloadFile(file, path); err != nil {
+			return paths, fmt.Errorf("downloading %s: %w", file.ObjectName, err)
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+// downloadFile streams a Cloud Storage object to path, removing the file when the download fails or its hash differs
+func (c *Client) downloadFile(file *CloudStorageFile, path string) (err error) {
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(path)
+		}
+	}()
+
+	req, err := c.HTTP.CreateRequest("GET", fmt.Sprintf(StorageObjects, url.PathEscape(file.BucketName), url.PathEscape(file.ObjectName)))
+	if err != nil {
+		return err
+	}
+	q := req.URL.Query()
+	q.Set("alt", "media")
+	req.URL.RawQuery = q.Encode()
+
+	hash := md5.New()
+	_, _, err = c.HTTP.Stream(req, io.MultiWriter(out, hash))
+	if err != nil {
+		var statusErr *requests.StatusError
+		if errors.As(err, &statusErr) {
+			var googleError google.ErrorResponse
+			if json.Unmarshal(statusErr.Body, &googleError) == nil && googleError.Error != nil {
+				return googleError.Error
+			}
+		}
+		return err
+	}
+
+	if file.MD5Hash != "" {
+		if sum := base64.StdEncoding.EncodeToString(hash.Sum(nil)); sum != file.MD5Hash {
+			return fmt.Errorf("MD5 hash %s does not match the hash %s reported by Vault", sum, file.MD5Hash)
+		}
+	}
+
+	return nil
+}
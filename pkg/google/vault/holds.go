@@ -0,0 +1,139 @@
+/*
+# Google Workspace - Vault Holds
+
+This package contains the methods to place, extend and release legal holds on the accounts or organizational units of
+a matter:
+https://developers.google.com/vault/reference/rest/v1/matters.holds
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/vault/holds.go
+package vault
+
+import (
+	"fmt"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+)
+
+/*
+ * # List all Holds of a Matter
+ * /v1/matters/{matterId}/holds
+ * - https://developers.google.com/vault/reference/rest/v1/matters.holds/list
+ */
+func (c *Client) ListAllHolds(matterID string) (*HoldList, error) {
+	url := c.BuildURL(Matters, nil, matterID, "holds")
+
+	q := struct {
+		View string `url:"view,omitempty"`
+	}{ViewFull}
+
+	holds, err := requests.PaginatedDo(c.HTTP, "GET", url, q, requests.Pagination[*Hold]{
+		Strategy: tokenPages,
+		Items:    requests.ItemsField[*Hold]("holds"),
+		PageSize: 100,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &HoldList{Holds: holds}, nil
+}
+
+/*
+ * # Get a Hold
+ * /v1/matters/{matterId}/holds/{holdId}
+ * - https://developers.google.com/vault/reference/rest/v1/matters.holds/get
+ */
+func (c *Client) GetHold(matterID, holdID string) (*Hold, error) {
+	url := c.BuildURL(Matters, nil, matterID, "holds", holdID)
+
+	q := struct {
+		View string `url:"view"`
+	}{ViewFull}
+
+	return do[*Hold](c, "GET", url, q, nil)
+}
+
+/*
+ * # Create a Hold
+ * /v1/matters/{matterId}/holds
+ * - https://developers.google.com/vault/reference/rest/v1/matters.holds/create
+ * - A hold covers either Accounts or an OrgUnit, for a single Corpus:
+ *
+ *	hold := &vault.Hold{
+ *		Name:     "Custodians - Mail",
+ *		Corpus:   vault.CorpusMail,
+ *		Accounts: vault.HeldAccounts("ada@example.com", "bob@example.com"),
+ *	}
+ */
+func (c *Client) CreateHold(matterID string, hold *Hold) (*Hold, error) {
+	if (len(hold.Accounts) == 0) == (hold.OrgUnit == nil) {
+		return nil, fmt.Errorf("hold %q needs either accounts or an organizational unit", hold.Name)
+	}
+
+	url := c.BuildURL(Matters, nil, matterID, "holds")
+
+	c.Log.Printf("Creating hold %q on matter %s", hold.Name, matterID)
+	return do[*Hold](c, "POST", url, nil, hold)
+}
+
+/*
+ * # Delete a Hold
+ * /v1/matters/{matterId}/holds/{holdId}
+ * - https://developers.google.com/vault/reference/rest/v1/matters.holds/delete
+ * - Releases the data of the held accounts, unless another hold or a retention rule covers it
+ */
+func (c *Client) DeleteHold(matterID, holdID string) error {
+	url := c.BuildURL(Matters, nil, matterID, "holds", holdID)
+
+	c.Log.Printf("Releasing hold %s of matter %s", holdID, matterID)
+	_, err := do[interface{}](c, "DELETE", url, nil, nil)
+	return err
+}
+
+/*
+ * # Add Accounts to a Hold
+ * /v1/matters/{matterId}/holds/{holdId}:addHeldAccounts
+ * - https://developers.google.com/vault/reference/rest/v1/matters.holds/addHeldAccounts
+ * - Each account succeeds or fails on its own; check the Status of every response
+ */
+func (c *Client) AddHeldAccounts(matterID, holdID string, emails ...string) (*HeldAccountResponses, error) {
+	url := c.BuildURL(Matters, nil, matterID, "holds", holdID+":addHeldAccounts")
+
+	body := struct {
+		Emails []string `json:"emails"`
+	}{emails}
+
+	c.Log.Printf("Adding %d accounts to hold %s of matter %s", len(emails), holdID, matterID)
+	return do[*HeldAccountResponses](c, "POST", url, nil, body)
+}
+
+/*
+ * # Remove Accounts from a Hold
+ * /v1/matters/{matterId}/holds/{holdId}:removeHeldAccounts
+ * - https://developers.google.com/vault/reference/rest/v1/matters.holds/removeHeldAccounts
+ * - `accountIDs` are Directory IDs, as in HeldAccount.AccountID
+ */
+func (c *Client) RemoveHeldAccounts(matterID, holdID string, accountIDs ...string) (*RemovedHeldAccounts, error) {
+	url := c.BuildURL(Matters, nil, matterID, "holds", holdID+":removeHeldAccounts")
+
+	body := struct {
+		AccountIDs []string `json:"accountIds"`
+	}{accountIDs}
+
+	c.Log.Printf("Removing %d accounts from hold %s of matter %s", len(accountIDs), holdID, matterID)
+	return do[*RemovedHeldAccounts](c, "POST", url, nil, body)
+}
+
+// HeldAccounts builds the accounts of a hold from their emails
+func HeldAccounts(emails ...string) []*HeldAccount {
+	accounts := make([]*HeldAccount, 0, len(emails))
+	for _, email := range emails {
+		accounts = append(accounts, &HeldAccount{Email: email})
+	}
+	return accounts
+}
@@ -0,0 +1,192 @@
+/*
+# Google Workspace - Vault
+
+This package initializes all the methods for functions which interact with the Google Vault API, for legal hold and
+eDiscovery workflows: matters, holds and exports:
+https://developers.google.com/vault/reference/rest
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/google/vault/vault.go
+package vault
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+	"github.com/gemini-oss/rego/pkg/common/schema"
+	"github.com/gemini-oss/rego/pkg/google"
+)
+
+var (
+	BaseURL = "https://vault.googleapis.com/v1"
+	Matters = fmt.Sprintf("%s/matters", BaseURL) // https://developers.google.com/vault/reference/rest/v1/matters
+)
+
+// tokenPages is how every list of the Vault API is paginated
+var tokenPages = requests.TokenPages{SizeParam: "pageSize"}
+
+// Client for chaining Vault methods, sharing the authentication, rate limiter and logger of a Google client
+type Client struct {
+	*google.Client
+}
+
+/*
+ * # Generate Vault Client
+ * - `g` needs the `Google Vault API` scopes, and `https://www.googleapis.com/auth/devstorage.read_only` to download exports
+ * - the subject of `g` needs the Vault privileges of the workflow (e.g. Manage Holds, Manage Exports), and access to the
+ *   matters: created by them, shared with them, or the View All Matters privilege
+ *
+ *	g, err := google.NewClient(google.AuthCredentials{...}, log.INFO)
+ *	v := vault.NewClient(g)
+ *	matter, err := v.CreateMatter("2024-07 Litigation", "Outside counsel request")
+ */
+func NewClient(g *google.Client) *Client {
+	return &Client{Client: g}
+}
+
+// WithContext returns a copy of the client whose requests (and waits, see WaitForExport) end when ctx is done
+func (c *Client) WithContext(ctx context.Context) *Client {
+	return &Client{Client: c.Client.WithContext(ctx)}
+}
+
+/*
+ * Perform a generic request to the Vault API
+ */
+func do[T any](c *Client, method string, url string, query interface{}, data interface{}) (T, error) {
+	var result T
+	res, body, err := c.HTTP.DoRequest(method, url, query, data)
+	if err != nil {
+		return *new(T), err
+	}
+
+	c.Log.Println("Response Status:", res.Status)
+	c.Log.Debug("Response Body:", string(body))
+
+	if len(body) == 0 {
+		return result, nil
+	}
+
+	err = schema.Unmarshal(body, &result)
+	if err != nil {
+		return *new(T), fmt.Errorf("unmarshalling error: %w", err)
+	}
+
+	return result, nil
+}
+
+/*
+ * Query Parameters for Matters
+ * Reference: https://developers.google.com/vault/reference/rest/v1/matters/list#query-parameters
+ */
+type MatterQuery struct {
+	PageSize  int    `url:"pageSize,omitempty"`  // The number of matters to return in the response. Default and maximum are 100.
+	PageToken string `url:"pageToken,omitempty"` // The pagination token as returned in the response.
+	State     string `url:"state,omitempty"`     // If set, lists only matters with the specified state, e.g. `OPEN`
+	View      string `url:"view,omitempty"`      // Specifies how much information about the matter to return, e.g. `FULL` for the permissions
+}
+
+/*
+ * # List all Matters
+ * /v1/matters
+ * - https://developers.google.com/vault/reference/rest/v1/matters/list
+ * - `state` is MatterOpen, MatterClosed, MatterDeleted, or empty for every matter
+ */
+func (c *Client) ListAllMatters(state string) (*MatterList, error) {
+	q := &MatterQuery{State: state, View: ViewFull}
+
+	matters, err := requests.PaginatedDo(c.HTTP, "GET", Matters, q, requests.Pagination[*Matter]{
+		Strategy: tokenPages,
+		Items:    requests.ItemsField[*Matter]("matters"),
+		PageSize: 100,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MatterList{Matters: matters}, nil
+}
+
+/*
+ * # Get a Matter
+ * /v1/matters/{matterId}
+ * - https://developers.google.com/vault/reference/rest/v1/matters/get
+ */
+func (c *Client) GetMatter(matterID string) (*Matter, error) {
+	url := c.BuildURL(Matters, nil, matterID)
+
+	q := struct {
+		View string `url:"view"`
+	}{ViewFull}
+
+	return do[*Matter](c, "GET", url, q, nil)
+}
+
+/*
+ * # Create a Matter
+ * /v1/matters
+ * - https://developers.google.com/vault/reference/rest/v1/matters/create
+ * - The caller becomes the owner of the matter; share it with AddMatterPermission
+ */
+func (c *Client) CreateMatter(name, description string) (*Matter, error) {
+	c.Log.Printf("Creating matter %q", name)
+
+	return do[*Matter](c, "POST", Matters, nil, &Matter{Name: name, Description: description})
+}
+
+/*
+ * # Close a Matter
+ * /v1/matters/{matterId}:close
+ * - https://developers.google.com/vault/reference/rest/v1/matters/close
+ * - the holds must be removed first
+ */
+func (c *Client) CloseMatter(matterID string) (*Matter, error) {
+	url := c.BuildURL(Matters, nil, matterID, ":close")
+
+	c.Log.Printf("Closing matter %s", matterID)
+	response, err := do[*MatterResponse](c, "POST", url, nil, struct{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Matter, nil
+}
+
+/*
+ * # Reopen a Matter
+ * /v1/matters/{matterId}:reopen
+ * - https://developers.google.com/vault/reference/rest/v1/matters/reopen
+ */
+func (c *Client) ReopenMatter(matterID string) (*Matter, error) {
+	url := c.BuildURL(Matters, nil, matterID, ":reopen")
+
+	c.Log.Printf("Reopening matter %s", matterID)
+	response, err := do[*MatterResponse](c, "POST", url, nil, struct{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Matter, nil
+}
+
+/*
+ * # Share a Matter
+ * /v1/matters/{matterId}:addPermissions
+ * - https://developers.google.com/vault/reference/rest/v1/matters/addPermissions
+ * - `accountID` is the Directory ID of the user; `role` is RoleCollaborator or RoleOwner
+ */
+func (c *Client) AddMatterPermission(matterID, accountID, role string) (*MatterPermission, error) {
+	url := c.BuildURL(Matters, nil, matterID, ":addPermissions")
+
+	body := struct {
+		MatterPermission *MatterPermission `json:"matterPermission"`
+		SendEmails       bool              `json:"sendEmails"`
+	}{&MatterPermission{AccountID: accountID, Role: role}, false}
+
+	c.Log.Printf("Sharing matter %s with %s as %s", matterID, accountID, role)
+	return do[*MatterPermission](c, "POST", url, nil, body)
+}
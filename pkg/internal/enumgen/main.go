@@ -0,0 +1,213 @@
+/*
+# Enum Generator
+
+Generates typed string enums (a type, its constants and a list of every value) from provider metadata, so constant
+blocks stay in sync with the providers instead of being maintained by hand. Run through `go generate`:
+
+	//go:generate go run ../internal/enumgen -in metadata/event_types.json -out event_types.go
+
+Two metadata formats are understood:
+- `enums` (default): {"enums": [{"type", "prefix", "doc", "source", "values": [{"value", "name", "doc"}]}]}
+- `google-policy-schemas`: a saved response of Devices().ListAllDevicePolicySchemas(), producing `PolicySchemaName`
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/internal/enumgen/main.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Metadata is the `enums` input format
+type Metadata struct {
+	Enums []*Enum `json:"enums"`
+}
+
+// Enum is a single generated type
+type Enum struct {
+	Type   string   `json:"type"`             // Name of the Go type, e.g. `EventType`
+	Prefix string   `json:"prefix,omitempty"` // Prefix of the constant names; defaults to Type
+	List   string   `json:"list,omitempty"`   // Name of the slice of every value; defaults to the plural of Type
+	Doc    string   `json:"doc,omitempty"`    // Documentation of the type
+	Source string   `json:"source,omitempty"` // Link to the provider's documentation
+	Values []*Value `json:"values"`
+}
+
+// Value is a single generated constant
+type Value struct {
+	Value string `json:"value"`          // Value sent to/received from the provider
+	Name  string `json:"name,omitempty"` // Name of the constant, without the prefix; derived from Value when empty
+	Doc   string `json:"doc,omitempty"`  // Documentation of the value
+}
+
+// googlePolicySchemas is the subset of a policy schema list read by the `google-policy-schemas` format
+type googlePolicySchemas struct {
+	PolicySchemas []struct {
+		SchemaName        string `json:"schemaName"`
+		PolicyDescription string `json:"policyDescription"`
+	} `json:"policySchemas"`
+}
+
+func main() {
+	in := flag.String("in", "", "metadata file")
+	out := flag.String("out", "", "generated Go file")
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "package of the generated file; set by go generate")
+	inputFormat := flag.String("format", "enums", "format of the metadata: enums, google-policy-schemas")
+	flag.Parse()
+
+	if *in == "" || *out == "" || *pkg == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	data, err := os.ReadFile(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var meta *Metadata
+	switch *inputFormat {
+	case "enums":
+		meta = &Metadata{}
+		err = json.Unmarshal(data, meta)
+	case "google-policy-schemas":
+		meta, err = fromGooglePolicySchemas(data)
+	default:
+		err = fmt.Errorf("unknown format %q", *inputFormat)
+	}
+	if err != nil {
+		log.Fatalf("reading %s: %v", *in, err)
+	}
+
+	src, err := generate(*pkg, filepath.ToSlash(*in), filepath.ToSlash(filepath.Join("pkg", *pkg, filepath.Base(*out))), meta)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func fromGooglePolicySchemas(data []byte) (*Metadata, error) {
+	var schemas googlePolicySchemas
+	if err := json.Unmarshal(data, &schemas); err != nil {
+		return nil, err
+	}
+
+	enum := &Enum{
+		Type:   "PolicySchemaName",
+		Prefix: "Policy",
+		List:   "PolicySchemaNames",
+		Doc:    "PolicySchemaName is the name of a Chrome policy schema, e.g. for PolicySchemaFilter",
+		Source: "https://developers.google.com/chrome/policy/guides/policy-schemas",
+	}
+	for _, schema := range schemas.PolicySchemas {
+		enum.Values = append(enum.Values, &Value{Value: schema.SchemaName, Doc: schema.PolicyDescription})
+	}
+	return &Metadata{Enums: []*Enum{enum}}, nil
+}
+
+func generate(pkg, source, path string, meta *Metadata) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by enumgen from %s; DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(&buf, "// %s\n", path)
+	fmt.Fprintf(&buf, "package %s\n", pkg)
+
+	for _, enum := range meta.Enums {
+		if enum.Type == "" {
+			return nil, fmt.Errorf("enum without a type")
+		}
+		prefix := enum.Prefix
+		if prefix == "" {
+			prefix = enum.Type
+		}
+		list := enum.List
+		if list == "" {
+			list = enum.Type + "s"
+		}
+
+		values := append([]*Value(nil), enum.Values...)
+		sort.Slice(values, func(i, j int) bool { return values[i].Value < values[j].Value })
+
+		names := map[string]string{}
+		fmt.Fprintf(&buf, "\n")
+		if enum.Doc != "" {
+			fmt.Fprintf(&buf, "// %s\n", oneLine(enum.Doc))
+		}
+		if enum.Source != "" {
+			fmt.Fprintf(&buf, "// - %s\n", enum.Source)
+		}
+		fmt.Fprintf(&buf, "type %s string\n\nconst (\n", enum.Type)
+		for _, v := range values {
+			name := v.Name
+			if name == "" {
+				name = identifier(v.Value)
+			}
+			name = prefix + name
+			if other, ok := names[name]; ok {
+				return nil, fmt.Errorf("%s: %q and %q both generate %s; set a name for one of them", enum.Type, other, v.Value, name)
+			}
+			names[name] = v.Value
+			v.Name = name
+
+			fmt.Fprintf(&buf, "\t%s %s = %q", name, enum.Type, v.Value)
+			if v.Doc != "" {
+				fmt.Fprintf(&buf, " // %s", oneLine(v.Doc))
+			}
+			fmt.Fprintf(&buf, "\n")
+		}
+		fmt.Fprintf(&buf, ")\n\n")
+
+		fmt.Fprintf(&buf, "// %s lists every known %s\n", list, enum.Type)
+		fmt.Fprintf(&buf, "var %s = []%s{\n", list, enum.Type)
+		for _, v := range values {
+			fmt.Fprintf(&buf, "\t%s,\n", v.Name)
+		}
+		fmt.Fprintf(&buf, "}\n\n")
+
+		fmt.Fprintf(&buf, "// Known reports whether the %s is listed in %s\n", enum.Type, list)
+		fmt.Fprintf(&buf, "func (v %s) Known() bool {\n\tfor _, known := range %s {\n\t\tif v == known {\n\t\t\treturn true\n\t\t}\n\t}\n\treturn false\n}\n", enum.Type, list)
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// identifier converts a value to a Go name, e.g. `user.session.start` to `UserSessionStart`
+func identifier(value string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range value {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	name := b.String()
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "V" + name
+	}
+	return name
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
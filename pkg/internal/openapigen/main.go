@@ -0,0 +1,395 @@
+/*
+# OpenAPI Generator
+
+Generates typed Go entities (and path constants) from an OpenAPI 3 document, such as the schema every Jamf Pro server
+publishes at https://{server}/api/schema, so endpoints without hand-written structs can still be called with types:
+
+	//go:generate go run ../../internal/openapigen -in schema.json -out entities_gen.go -exclude Computer,MobileDevice
+
+Schemas which are already hand-written can be skipped with `-exclude`, or generation limited with `-include`; both take
+comma separated schema name prefixes. Required properties carry `validate:"required"`, and enums `validate:"enum=..."`.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/internal/openapigen/main.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Document is the subset of an OpenAPI 3 document used by the generator
+type Document struct {
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+// Operation is a single method of a path
+type Operation struct {
+	OperationID string `json:"operationId"`
+	Summary     string `json:"summary"`
+	Deprecated  bool   `json:"deprecated"`
+}
+
+// Schema is the subset of an OpenAPI schema object used by the generator
+type Schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Description          string             `json:"description"`
+	Enum                 []interface{}      `json:"enum"`
+	Properties           map[string]*Schema `json:"properties"`
+	Required             []string           `json:"required"`
+	Items                *Schema            `json:"items"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+	AllOf                []*Schema          `json:"allOf"`
+	OneOf                []*Schema          `json:"oneOf"`
+	AnyOf                []*Schema          `json:"anyOf"`
+}
+
+type generator struct {
+	doc     *Document
+	buf     bytes.Buffer
+	include []string
+	exclude []string
+}
+
+func main() {
+	in := flag.String("in", "", "OpenAPI document: a file, or an http(s) URL")
+	out := flag.String("out", "", "generated Go file")
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "package of the generated file; set by go generate")
+	include := flag.String("include", "", "comma separated schema name prefixes to generate; every schema when empty")
+	exclude := flag.String("exclude", "", "comma separated schema name prefixes to skip, e.g. hand-written entities")
+	flag.Parse()
+
+	if *in == "" || *out == "" || *pkg == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	data, err := read(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	doc := &Document{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		log.Fatalf("decoding %s: %v", *in, err)
+	}
+
+	g := &generator{doc: doc, include: split(*include), exclude: split(*exclude)}
+	fmt.Fprintf(&g.buf, "// Code generated by openapigen from %s; DO NOT EDIT.\n\n", filepath.ToSlash(*in))
+	fmt.Fprintf(&g.buf, "// %s\n", modulePath(*out))
+	fmt.Fprintf(&g.buf, "package %s\n", *pkg)
+
+	g.paths()
+	count, err := g.schemas()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %v", err)
+	}
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("generated %d schema(s) and %d path(s) in %s", count, len(doc.Paths), *out)
+}
+
+func read(in string) ([]byte, error) {
+	if !strings.HasPrefix(in, "http://") && !strings.HasPrefix(in, "https://") {
+		return os.ReadFile(in)
+	}
+
+	res, err := http.Get(in)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: %s", in, res.Status)
+	}
+	return io.ReadAll(res.Body)
+}
+
+// paths generates a constant for every path, documented with its operations
+func (g *generator) paths() {
+	paths := make([]string, 0, len(g.doc.Paths))
+	for path := range g.doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	if len(paths) == 0 {
+		return
+	}
+
+	fmt.Fprintf(&g.buf, "\n// Paths of the API, relative to the base URL\nconst (\n")
+	for _, path := range paths {
+		methods := make([]string, 0, len(g.doc.Paths[path]))
+		for method, op := range g.doc.Paths[path] {
+			if op == nil {
+				continue
+			}
+			m := strings.ToUpper(method)
+			if op.Deprecated {
+				m += " (deprecated)"
+			}
+			methods = append(methods, m)
+		}
+		sort.Strings(methods)
+		fmt.Fprintf(&g.buf, "\tPath%s = %q // %s\n", identifier(path), path, strings.Join(methods, ", "))
+	}
+	fmt.Fprintf(&g.buf, ")\n")
+}
+
+// schemas generates a struct (or named type) for every selected component schema
+func (g *generator) schemas() (int, error) {
+	names := make([]string, 0, len(g.doc.Components.Schemas))
+	for name := range g.doc.Components.Schemas {
+		if g.selected(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		schema := g.doc.Components.Schemas[name]
+		fmt.Fprintf(&g.buf, "\n")
+		if schema.Description != "" {
+			fmt.Fprintf(&g.buf, "// %s - %s\n", identifier(name), oneLine(schema.Description))
+		}
+
+		properties, required := g.flatten(schema)
+		if len(properties) == 0 {
+			fmt.Fprintf(&g.buf, "type %s %s\n", identifier(name), g.goType(schema))
+			continue
+		}
+
+		fmt.Fprintf(&g.buf, "type %s struct {\n", identifier(name))
+		keys := make([]string, 0, len(properties))
+		for key := range properties {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			g.field(key, properties[key], required[key])
+		}
+		fmt.Fprintf(&g.buf, "}\n")
+	}
+	return len(names), nil
+}
+
+// flatten merges the properties of allOf compositions into a single set
+func (g *generator) flatten(schema *Schema) (map[string]*Schema, map[string]bool) {
+	properties := map[string]*Schema{}
+	required := map[string]bool{}
+
+	var walk func(s *Schema, depth int)
+	walk = func(s *Schema, depth int) {
+		if s == nil || depth > 10 {
+			return
+		}
+		if s.Ref != "" {
+			walk(g.resolve(s.Ref), depth+1)
+			return
+		}
+		for key, property := range s.Properties {
+			properties[key] = property
+		}
+		for _, key := range s.Required {
+			required[key] = true
+		}
+		for _, part := range s.AllOf {
+			walk(part, depth+1)
+		}
+	}
+	walk(schema, 0)
+
+	return properties, required
+}
+
+func (g *generator) field(key string, property *Schema, required bool) {
+	goType := g.goType(property)
+
+	tags := []string{}
+	if required {
+		tags = append(tags, "required")
+	}
+	if enum := enumValues(property); len(enum) > 0 {
+		tags = append(tags, "enum="+strings.Join(enum, "|"))
+	}
+	validate := ""
+	if len(tags) > 0 {
+		validate = fmt.Sprintf(` validate:"%s"`, strings.Join(tags, ","))
+	}
+
+	fmt.Fprintf(&g.buf, "\t%s %s `json:\"%s,omitempty\"%s`", identifier(key), goType, key, validate)
+	if property.Description != "" {
+		fmt.Fprintf(&g.buf, " // %s", oneLine(property.Description))
+	}
+	fmt.Fprintf(&g.buf, "\n")
+}
+
+// goType maps a schema to a Go type; references to unselected schemas fall back to their underlying type
+func (g *generator) goType(s *Schema) string {
+	if s == nil {
+		return "interface{}"
+	}
+	if s.Ref != "" {
+		name := refName(s.Ref)
+		if g.selected(name) {
+			if g.isObject(g.resolve(s.Ref)) {
+				return "*" + identifier(name)
+			}
+			return identifier(name)
+		}
+		return g.goType(g.resolve(s.Ref))
+	}
+	if len(s.AllOf) == 1 {
+		return g.goType(s.AllOf[0])
+	}
+	if len(s.OneOf) > 0 || len(s.AnyOf) > 0 || len(s.AllOf) > 1 {
+		return "interface{}"
+	}
+
+	switch s.Type {
+	case "string":
+		return "string"
+	case "boolean":
+		return "bool"
+	case "integer":
+		if s.Format == "int64" {
+			return "int64"
+		}
+		return "int"
+	case "number":
+		return "float64"
+	case "array":
+		return "[]" + strings.TrimPrefix(g.goType(s.Items), "*")
+	case "object", "":
+		if len(s.Properties) > 0 {
+			return "map[string]interface{}" // Inline objects are not named; reference a component schema for a struct
+		}
+		if len(s.AdditionalProperties) > 0 && s.AdditionalProperties[0] == '{' {
+			var values Schema
+			if json.Unmarshal(s.AdditionalProperties, &values) == nil && (values.Type != "" || values.Ref != "") {
+				return "map[string]" + g.goType(&values)
+			}
+		}
+		return "map[string]interface{}"
+	}
+	return "interface{}"
+}
+
+func (g *generator) isObject(s *Schema) bool {
+	properties, _ := g.flatten(s)
+	return len(properties) > 0
+}
+
+func (g *generator) resolve(ref string) *Schema {
+	return g.doc.Components.Schemas[refName(ref)]
+}
+
+func (g *generator) selected(name string) bool {
+	if _, ok := g.doc.Components.Schemas[name]; !ok {
+		return false
+	}
+	for _, prefix := range g.exclude {
+		if strings.HasPrefix(name, prefix) {
+			return false
+		}
+	}
+	if len(g.include) == 0 {
+		return true
+	}
+	for _, prefix := range g.include {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+func enumValues(s *Schema) []string {
+	values := []string{}
+	for _, v := range s.Enum {
+		if str, ok := v.(string); ok && str != "" && !strings.ContainsAny(str, "|,\"`") {
+			values = append(values, str)
+		}
+	}
+	return values
+}
+
+func split(s string) []string {
+	parts := []string{}
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// identifier converts a name to an exported Go name, e.g. `/v1/computers-inventory/{id}` to `V1ComputersInventoryId`
+func identifier(value string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range value {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	name := b.String()
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "X" + name
+	}
+	return name
+}
+
+// modulePath returns the path of a file relative to the root of the module, e.g. `pkg/jamf/gen/entities_gen.go`
+func modulePath(file string) string {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+	for dir := filepath.Dir(abs); dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			if rel, err := filepath.Rel(dir, abs); err == nil {
+				return filepath.ToSlash(rel)
+			}
+		}
+	}
+	return filepath.ToSlash(file)
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,309 @@
+/*
+# Chrome Policy Generator
+
+Generates typed Go structs from the FileDescriptorProto definitions of Chrome policy schemas, so frequently-used
+policies can be read and written as validated structs instead of `map[string]interface{}` PolicyValues:
+
+	//go:generate go run ../internal/policygen -in metadata/policy_schemas.json -out policies_gen.go -schemas chrome.users.MaxConnectionsPerProxy
+
+The input is a saved response of Devices().ListAllDevicePolicySchemas() (or a JSON list of its policy schemas).
+Every struct implements google.TypedPolicy; convert with google.NewPolicyValue and PolicyValue.Decode.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/internal/policygen/main.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/gemini-oss/rego/pkg/google"
+)
+
+// enumDescriptor is the subset of an EnumDescriptorProto needed to list the valid values of an enum field
+type enumDescriptor struct {
+	Name  string `json:"name"`
+	Value []struct {
+		Name string `json:"name"`
+	} `json:"value"`
+}
+
+// scalars maps protobuf field types to Go types; int64 values are accepted as JSON numbers or strings
+var scalars = map[string]string{
+	"TYPE_STRING": "string",
+	"TYPE_BOOL":   "bool",
+	"TYPE_INT32":  "int32",
+	"TYPE_UINT32": "uint32",
+	"TYPE_INT64":  "PolicyInt64",
+	"TYPE_UINT64": "PolicyInt64",
+	"TYPE_DOUBLE": "float64",
+	"TYPE_FLOAT":  "float32",
+	"TYPE_BYTES":  "[]byte",
+}
+
+type generator struct {
+	pkg      string
+	buf      bytes.Buffer
+	messages map[string]google.DescriptorProto // Keyed by fully qualified name, e.g. `.chrome.users.Foo.Bar`
+	enums    map[string]enumDescriptor         // Keyed by fully qualified name
+	done     map[string]bool                   // Go types already generated
+}
+
+func main() {
+	in := flag.String("in", "", "saved policy schema list")
+	out := flag.String("out", "", "generated Go file")
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "package of the generated file; set by go generate")
+	names := flag.String("schemas", "", "comma separated schema names to generate; every schema when empty")
+	flag.Parse()
+
+	if *in == "" || *out == "" || *pkg == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	data, err := os.ReadFile(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	schemas, err := readSchemas(data)
+	if err != nil {
+		log.Fatalf("reading %s: %v", *in, err)
+	}
+
+	wanted := map[string]bool{}
+	for _, name := range strings.Split(*names, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			wanted[name] = true
+		}
+	}
+
+	g := &generator{pkg: *pkg, done: map[string]bool{}}
+	fmt.Fprintf(&g.buf, "// Code generated by policygen from %s; DO NOT EDIT.\n\n", filepath.ToSlash(*in))
+	fmt.Fprintf(&g.buf, "// %s\n", filepath.ToSlash(filepath.Join("pkg", *pkg, filepath.Base(*out))))
+	fmt.Fprintf(&g.buf, "package %s\n", *pkg)
+	if *pkg != "google" {
+		fmt.Fprintf(&g.buf, "\nimport \"github.com/gemini-oss/rego/pkg/google\"\n")
+		scalars["TYPE_INT64"], scalars["TYPE_UINT64"] = "google.PolicyInt64", "google.PolicyInt64"
+	}
+
+	generated := 0
+	for _, schema := range schemas {
+		if len(wanted) > 0 && !wanted[schema.SchemaName] {
+			continue
+		}
+		if err := g.schema(schema); err != nil {
+			log.Fatalf("%s: %v", schema.SchemaName, err)
+		}
+		delete(wanted, schema.SchemaName)
+		generated++
+	}
+	for name := range wanted {
+		log.Fatalf("schema %s was not found in %s", name, *in)
+	}
+
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %v", err)
+	}
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("generated %d policy schema(s) in %s", generated, *out)
+}
+
+// readSchemas accepts a PolicySchemas response, or a plain list of policy schemas
+func readSchemas(data []byte) ([]*google.PolicySchema, error) {
+	var list google.PolicySchemas
+	if err := json.Unmarshal(data, &list); err == nil && list.PolicySchemas != nil {
+		return sortSchemas(*list.PolicySchemas), nil
+	}
+
+	var schemas []*google.PolicySchema
+	if err := json.Unmarshal(data, &schemas); err != nil {
+		return nil, err
+	}
+	return sortSchemas(schemas), nil
+}
+
+func sortSchemas(schemas []*google.PolicySchema) []*google.PolicySchema {
+	sort.Slice(schemas, func(i, j int) bool { return schemas[i].SchemaName < schemas[j].SchemaName })
+	return schemas
+}
+
+// schema generates the struct of a policy schema, and of every message it references
+func (g *generator) schema(schema *google.PolicySchema) error {
+	def := schema.Definition
+	g.messages = map[string]google.DescriptorProto{}
+	g.enums = map[string]enumDescriptor{}
+	for _, message := range def.MessageType {
+		g.index("."+def.Package, message)
+	}
+	for _, enum := range def.EnumType {
+		g.indexEnum("."+def.Package, enum)
+	}
+
+	// The message describing the policy is named after the last part of the schema name
+	parts := strings.Split(schema.SchemaName, ".")
+	root := fmt.Sprintf(".%s.%s", def.Package, parts[len(parts)-1])
+	message, ok := g.messages[root]
+	if !ok {
+		return fmt.Errorf("no message %s in the schema definition", root)
+	}
+
+	descriptions := map[string]string{}
+	for _, field := range schema.FieldDescriptions {
+		descriptions[field.Field] = field.FieldDescription
+		if descriptions[field.Field] == "" {
+			descriptions[field.Field] = field.Description
+		}
+	}
+
+	name := identifier(schema.SchemaName) + "Policy"
+	doc := fmt.Sprintf("is the %s policy. %s", schema.SchemaName, schema.PolicyDescription)
+	if err := g.message(name, message, doc, descriptions); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(&g.buf, "\n// PolicySchema implements TypedPolicy\n")
+	fmt.Fprintf(&g.buf, "func (*%s) PolicySchema() string {\n\treturn %q\n}\n", name, schema.SchemaName)
+	return nil
+}
+
+func (g *generator) index(scope string, message google.DescriptorProto) {
+	name := scope + "." + message.Name
+	g.messages[name] = message
+	for _, nested := range message.NestedType {
+		g.index(name, nested)
+	}
+	for _, enum := range message.EnumType {
+		g.indexEnum(name, enum)
+	}
+}
+
+func (g *generator) indexEnum(scope string, raw interface{}) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return
+	}
+	var enum enumDescriptor
+	if json.Unmarshal(data, &enum) == nil && enum.Name != "" {
+		g.enums[scope+"."+enum.Name] = enum
+	}
+}
+
+// message generates a struct for a message, then the structs of the messages its fields reference
+func (g *generator) message(name string, message google.DescriptorProto, doc string, descriptions map[string]string) error {
+	if g.done[name] {
+		return nil
+	}
+	g.done[name] = true
+
+	type nested struct {
+		name    string
+		message google.DescriptorProto
+	}
+	var pending []nested
+
+	fmt.Fprintf(&g.buf, "\n")
+	if doc != "" {
+		fmt.Fprintf(&g.buf, "// %s %s\n", name, oneLine(doc))
+	}
+	fmt.Fprintf(&g.buf, "type %s struct {\n", name)
+	for _, field := range message.Field {
+		jsonName := field.JsonName
+		if jsonName == "" {
+			jsonName = lowerCamel(field.Name)
+		}
+
+		var goType, tag string
+		switch field.Type {
+		case "TYPE_MESSAGE":
+			ref, ok := g.messages[field.TypeName]
+			if !ok {
+				return fmt.Errorf("field %s references unknown message %s", field.Name, field.TypeName)
+			}
+			goType = "*" + name + ref.Name
+			pending = append(pending, nested{name + ref.Name, ref})
+		case "TYPE_ENUM":
+			goType = "string"
+			if enum, ok := g.enums[field.TypeName]; ok {
+				values := make([]string, 0, len(enum.Value))
+				for _, v := range enum.Value {
+					values = append(values, v.Name)
+				}
+				tag = fmt.Sprintf(` validate:"enum=%s"`, strings.Join(values, "|"))
+			}
+		default:
+			scalar, ok := scalars[field.Type]
+			if !ok {
+				return fmt.Errorf("field %s has unsupported type %s", field.Name, field.Type)
+			}
+			goType = scalar
+		}
+
+		if field.Label == "LABEL_REPEATED" {
+			goType = "[]" + strings.TrimPrefix(goType, "*")
+		} else if !strings.HasPrefix(goType, "*") && !strings.HasPrefix(goType, "[]") {
+			// Pointers tell an unset field apart from `false`, `0` and `""`, which are meaningful policy values
+			goType = "*" + goType
+		}
+
+		fmt.Fprintf(&g.buf, "\t%s %s `json:\"%s,omitempty\"%s`", identifier(field.Name), goType, jsonName, tag)
+		if description := descriptions[jsonName]; description != "" {
+			fmt.Fprintf(&g.buf, " // %s", oneLine(description))
+		}
+		fmt.Fprintf(&g.buf, "\n")
+	}
+	fmt.Fprintf(&g.buf, "}\n")
+
+	for _, n := range pending {
+		if err := g.message(n.name, n.message, "", descriptions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// identifier converts a name to an exported Go name, e.g. `chrome.users.MaxConnectionsPerProxy` to `ChromeUsersMaxConnectionsPerProxy`
+func identifier(value string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range value {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// lowerCamel converts a protobuf field name to its JSON name, e.g. `max_connections` to `maxConnections`
+func lowerCamel(name string) string {
+	id := identifier(name)
+	if id == "" {
+		return id
+	}
+	return strings.ToLower(id[:1]) + id[1:]
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -322,3 +322,44 @@ func TestCacheExpirationUpdateOnAccess(t *testing.T) {
 		t.Error("Expected the first key to be updated and not evicted, but it was evicted")
 	}
 }
+
+func TestCacheDelete(t *testing.T) {
+	encryptionKey := []byte("32~Byte-long_passphrase-key-1234")
+	c, _ := cache.NewCache(encryptionKey, true)
+
+	c.Set("key", []byte("value"), time.Minute)
+	if err := c.Delete("key"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, exists := c.Get("key"); exists {
+		t.Error("Expected the deleted key to be missing")
+	}
+
+	// The same value can be cached again once deleted
+	c.Set("key", []byte("value"), time.Minute)
+	if _, exists := c.Get("key"); !exists {
+		t.Error("Expected the key to be cached again after Delete")
+	}
+
+	if err := c.Delete("missing"); err != nil {
+		t.Errorf("Delete() of a missing key error = %v", err)
+	}
+}
+
+func TestCacheDeleteKeys(t *testing.T) {
+	encryptionKey := []byte("32~Byte-long_passphrase-key-1234")
+	c, _ := cache.NewCache(encryptionKey, true)
+
+	c.Set("a", []byte("1"), time.Minute)
+	c.Set("b", []byte("2"), time.Minute)
+	c.Set("c", []byte("3"), time.Minute)
+
+	if err := c.Delete("a", "missing", "c"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	for key, want := range map[string]bool{"a": false, "b": true, "c": false} {
+		if _, exists := c.Get(key); exists != want {
+			t.Errorf("Get(%q) exists = %v, want %v", key, exists, want)
+		}
+	}
+}
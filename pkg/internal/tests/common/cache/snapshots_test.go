@@ -0,0 +1,90 @@
+// pkg/internal/tests/common/cache/snapshots_test.go
+package cache_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/cache"
+)
+
+func TestSnapshotsAsOf(t *testing.T) {
+	encryptionKey := []byte("32~Byte-long_passphrase-key-1234")
+	name := "rego_test_snapshots.gob"
+	path := filepath.Join(os.TempDir(), name)
+	defer os.Remove(path)
+	defer os.Remove(path + ".snapshots")
+
+	c, err := cache.NewCache(encryptionKey, name)
+	if err != nil {
+		t.Fatalf("Failed to create cache: %v", err)
+	}
+
+	now := time.Now()
+	q1 := now.Add(-48 * time.Hour)
+	if err := c.SnapshotAt("members", q1.Add(-time.Hour), []string{"alice"}); err != nil {
+		t.Fatalf("SnapshotAt() error = %v", err)
+	}
+	if err := c.SnapshotAt("members", q1.Add(time.Hour), []string{"alice", "bob"}); err != nil {
+		t.Fatalf("SnapshotAt() error = %v", err)
+	}
+
+	// Snapshots persist, and are read back by a new cache
+	c, err = cache.NewCache(encryptionKey, name)
+	if err != nil {
+		t.Fatalf("Failed to reopen cache: %v", err)
+	}
+
+	members, taken, err := cache.AsOfValue[[]string](c, "members", q1)
+	if err != nil {
+		t.Fatalf("AsOf() error = %v", err)
+	}
+	if len(members) != 1 || !taken.Equal(q1.Add(-time.Hour)) {
+		t.Errorf("AsOf(q1) = %v taken %s; want [alice] taken an hour before", members, taken)
+	}
+
+	members, _, err = cache.AsOfValue[[]string](c, "members", now)
+	if err != nil || len(members) != 2 {
+		t.Errorf("AsOf(now) = %v, %v; want [alice bob]", members, err)
+	}
+
+	if _, _, err := cache.AsOfValue[[]string](c, "members", q1.Add(-2*time.Hour)); !errors.Is(err, cache.ErrNoSnapshot) {
+		t.Errorf("AsOf() before the first snapshot error = %v; want ErrNoSnapshot", err)
+	}
+}
+
+func TestSnapshotsRetention(t *testing.T) {
+	encryptionKey := []byte("32~Byte-long_passphrase-key-1234")
+	c, _ := cache.NewCache(encryptionKey, true)
+	if err := c.SetRetention(cache.Retention{KeepAll: 24 * time.Hour, KeepDaily: 10 * 24 * time.Hour, KeepMonthly: 100 * 24 * time.Hour}); err != nil {
+		t.Fatalf("SetRetention() error = %v", err)
+	}
+
+	day := 24 * time.Hour
+	now := time.Now()
+	y, m, d := now.Add(-5 * day).Date()
+	morning := time.Date(y, m, d, 9, 0, 0, 0, now.Location())
+	taken := []time.Time{
+		now.Add(-200 * day),        // Beyond KeepMonthly: deleted
+		morning,                    // Within KeepDaily: only the last of the day is kept
+		morning.Add(2 * time.Hour), // Kept
+		now.Add(-2 * time.Hour),    // Within KeepAll: kept
+		now.Add(-time.Hour),        // Kept
+	}
+	for i, at := range taken {
+		if err := c.SnapshotAt("inventory", at, i); err != nil {
+			t.Fatalf("SnapshotAt() error = %v", err)
+		}
+	}
+
+	snapshots, _ := c.Snapshots("inventory")
+	if len(snapshots) != 3 {
+		t.Fatalf("Snapshots() = %v; want 3 snapshots after pruning", snapshots)
+	}
+	if value, _, _ := cache.AsOfValue[int](c, "inventory", now.Add(-4*day)); value != 2 {
+		t.Errorf("AsOf() = %d; want the last snapshot of the day (2)", value)
+	}
+}
@@ -0,0 +1,164 @@
+// pkg/internal/tests/common/cache/typed_test.go
+package cache_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/cache"
+)
+
+func TestTypedSetAndGet(t *testing.T) {
+	c := cache.NewTyped[*struct{ Name string }](1*time.Minute, 0, 10)
+	c.Enabled = true
+
+	c.Set("user", &struct{ Name string }{"rego"})
+
+	value, exists := c.Get("user")
+	if !exists {
+		t.Fatalf("Get() exist = %v, want %v", exists, true)
+	}
+	if value.Name != "rego" {
+		t.Errorf("Get() value = %v, want %v", value.Name, "rego")
+	}
+}
+
+func TestTypedExpiration(t *testing.T) {
+	c := cache.NewTyped[string](50*time.Millisecond, 0, 10)
+	c.Enabled = true
+
+	c.Set("key", "value")
+	time.Sleep(100 * time.Millisecond)
+
+	if _, exists := c.Get("key"); exists {
+		t.Error("Expected key to have expired")
+	}
+}
+
+func TestTypedSingleflight(t *testing.T) {
+	c := cache.NewTyped[int](1*time.Minute, 0, 10)
+	c.Enabled = true
+
+	var loads int32
+	load := func() (int, error) {
+		atomic.AddInt32(&loads, 1)
+		time.Sleep(50 * time.Millisecond)
+		return 42, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := c.GetOrLoad("key", load)
+			if err != nil || value != 42 {
+				t.Errorf("GetOrLoad() = %v, %v; want 42, nil", value, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if loads != 1 {
+		t.Errorf("load called %d times, want 1", loads)
+	}
+}
+
+func TestTypedRefreshAhead(t *testing.T) {
+	c := cache.NewTyped[int](100*time.Millisecond, 80*time.Millisecond, 10)
+	c.Enabled = true
+
+	var loads int32
+	load := func() (int, error) {
+		return int(atomic.AddInt32(&loads, 1)), nil
+	}
+
+	if value, _ := c.GetOrLoad("key", load); value != 1 {
+		t.Fatalf("GetOrLoad() = %v, want 1", value)
+	}
+
+	// Inside the refresh-ahead window the stale value is served while a refresh runs in the background
+	time.Sleep(40 * time.Millisecond)
+	if value, _ := c.GetOrLoad("key", load); value != 1 {
+		t.Errorf("GetOrLoad() = %v, want stale value 1", value)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if value, exists := c.Get("key"); !exists || value != 2 {
+		t.Errorf("Get() = %v, %v; want refreshed value 2", value, exists)
+	}
+}
+
+func TestTypedDisabledByDefault(t *testing.T) {
+	c := cache.NewTyped[int](1*time.Minute, 0, 10)
+
+	var loads int32
+	load := func() (int, error) {
+		return int(atomic.AddInt32(&loads, 1)), nil
+	}
+
+	c.GetOrLoad("key", load)
+	if value, _ := c.GetOrLoad("key", load); value != 2 {
+		t.Errorf("GetOrLoad() = %v, want 2 (every call loads while disabled)", value)
+	}
+	if _, exists := c.Get("key"); exists {
+		t.Error("Get() found a key in a disabled cache")
+	}
+}
+
+func TestTypedReturnsCopies(t *testing.T) {
+	type user struct {
+		Name   string
+		Emails []string
+		Labels map[string]string
+	}
+
+	c := cache.NewTyped[*user](1*time.Minute, 0, 10)
+	c.Enabled = true
+
+	original := &user{Name: "rego", Emails: []string{"rego@example.com"}, Labels: map[string]string{"team": "it"}}
+	c.Set("user", original)
+	original.Name = "changed by the caller that set it"
+
+	first, _ := c.Get("user")
+	first.Name = "changed"
+	first.Emails[0] = "changed@example.com"
+	first.Labels["team"] = "changed"
+
+	second, _ := c.GetOrLoad("user", func() (*user, error) { return nil, nil })
+	if second == first {
+		t.Fatal("Get() and GetOrLoad() returned the same pointer")
+	}
+	if second.Name != "rego" || second.Emails[0] != "rego@example.com" || second.Labels["team"] != "it" {
+		t.Errorf("cached value = %+v, want the edits of other callers not to reach it", second)
+	}
+}
+
+func TestTypedSetEnabled(t *testing.T) {
+	c := cache.NewTyped[string](1*time.Minute, 0, 10)
+
+	// Enabling the cache while it is in use must not race with its readers
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Set("key", "value")
+			c.Get("key")
+		}()
+	}
+	c.SetEnabled(true)
+	wg.Wait()
+
+	c.Set("key", "value")
+	if value, exists := c.Get("key"); !exists || value != "value" {
+		t.Errorf("Get() = %q, %v after SetEnabled(true), want value", value, exists)
+	}
+
+	c.SetEnabled(false)
+	if _, exists := c.Get("key"); exists {
+		t.Error("Get() hit after SetEnabled(false), want a miss")
+	}
+}
@@ -0,0 +1,80 @@
+// pkg/internal/tests/common/config/environment_test.go
+package config_test
+
+import (
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/common/config"
+)
+
+func TestWithEnvironment(t *testing.T) {
+	t.Setenv("REGO_ENVIRONMENT", "")
+	t.Setenv("OKTA_API_TOKEN", "production token")
+	t.Setenv("OKTA_SANDBOX_API_TOKEN", "sandbox token")
+	t.Setenv("OKTA_ORG_NAME", "gemini")
+
+	prod := config.NewProfile()
+	sandbox := config.NewProfile(config.WithEnvironment(" Sandbox "))
+
+	if value := prod.GetEnv("OKTA_API_TOKEN"); value != "production token" {
+		t.Errorf("GetEnv(\"OKTA_API_TOKEN\") = %s; want \"production token\"", value)
+	}
+	if value := sandbox.GetEnv("OKTA_API_TOKEN"); value != "sandbox token" {
+		t.Errorf("GetEnv(\"OKTA_API_TOKEN\") = %s; want \"sandbox token\"", value)
+	}
+
+	// Variables without a profile variant fall back to the default
+	if value := sandbox.GetEnv("OKTA_ORG_NAME"); value != "gemini" {
+		t.Errorf("GetEnv(\"OKTA_ORG_NAME\") = %s; want \"gemini\"", value)
+	}
+
+	if name := sandbox.FileName("rego_cache_okta.gob"); name != "rego_cache_okta_sandbox.gob" {
+		t.Errorf("FileName() = %s; want \"rego_cache_okta_sandbox.gob\"", name)
+	}
+
+	// The option never changes the default of other clients
+	if value := config.GetEnv("OKTA_API_TOKEN"); value != "production token" {
+		t.Errorf("GetEnv(\"OKTA_API_TOKEN\") = %s; want \"production token\"", value)
+	}
+	if name := config.ProfileFileName("rego_cache_okta.gob"); name != "rego_cache_okta.gob" {
+		t.Errorf("ProfileFileName() = %s; want \"rego_cache_okta.gob\"", name)
+	}
+}
+
+func TestDefaultEnvironment(t *testing.T) {
+	t.Setenv("REGO_ENVIRONMENT", "staging")
+	t.Setenv("OKTA_API_TOKEN", "production token")
+	t.Setenv("OKTA_STAGING_API_TOKEN", "staging token")
+
+	if value := config.GetEnv("OKTA_API_TOKEN"); value != "staging token" {
+		t.Errorf("GetEnv(\"OKTA_API_TOKEN\") = %s; want \"staging token\"", value)
+	}
+	if value := config.NewProfile(config.WithEnvironment("prod")).GetEnv("OKTA_API_TOKEN"); value != "production token" {
+		t.Errorf("GetEnv(\"OKTA_API_TOKEN\") = %s; want \"production token\"", value)
+	}
+}
+
+func TestUsingTenant(t *testing.T) {
+	t.Setenv("OKTA_API_TOKEN", "default token")
+	t.Setenv("OKTA_ACME_API_TOKEN", "acme token")
+
+	err := config.UsingTenant("Acme", func() error {
+		if value := config.GetEnv("OKTA_API_TOKEN"); value != "acme token" {
+			t.Errorf("GetEnv(\"OKTA_API_TOKEN\") = %s; want \"acme token\"", value)
+		}
+		if name := config.ProfileFileName("rego_cache_okta.gob"); name != "rego_cache_okta_acme.gob" {
+			t.Errorf("ProfileFileName() = %s; want \"rego_cache_okta_acme.gob\"", name)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tenant := config.Tenant(); tenant != "" {
+		t.Errorf("Tenant() = %s after UsingTenant; want the default", tenant)
+	}
+	if value := config.GetEnv("OKTA_API_TOKEN"); value != "default token" {
+		t.Errorf("GetEnv(\"OKTA_API_TOKEN\") = %s; want \"default token\"", value)
+	}
+}
@@ -0,0 +1,78 @@
+// pkg/internal/tests/common/dataset/dataset_test.go
+package dataset_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/common/dataset"
+)
+
+type user struct {
+	Email     string
+	Suspended bool
+}
+
+type device struct {
+	Serial string
+	Owner  string
+	Model  string
+}
+
+var (
+	users = []user{
+		{"ada@example.com", true},
+		{"bob@example.com", false},
+		{"eve@example.com", true},
+	}
+	devices = []device{
+		{"C01", "ada@example.com", "MacBook Pro"},
+		{"C02", "bob@example.com", "MacBook Air"},
+		{"C03", "ada@example.com", "MacBook Air"},
+		{"C04", "", "Mac mini"},
+	}
+)
+
+func owner(d device) string { return d.Owner }
+func email(u user) string   { return u.Email }
+
+func TestJoin(t *testing.T) {
+	suspended := dataset.From(users).Where(func(u user) bool { return u.Suspended })
+	serials := dataset.Map(dataset.Join(dataset.From(devices), suspended, owner, email), func(p dataset.Pair[device, user]) string {
+		return p.Left.Serial
+	})
+
+	if want := []string{"C01", "C03"}; !reflect.DeepEqual(serials.Items, want) {
+		t.Errorf("Join() = %v; want %v", serials.Items, want)
+	}
+}
+
+func TestLeftJoinAndAntiJoin(t *testing.T) {
+	pairs := dataset.LeftJoin(dataset.From(devices), dataset.From(users), owner, email)
+	if pairs.Count() != len(devices) {
+		t.Fatalf("LeftJoin() returned %d rows; want %d", pairs.Count(), len(devices))
+	}
+	if last := pairs.Items[3]; last.Matched {
+		t.Errorf("an unassigned device should not match: %+v", last)
+	}
+
+	idle := dataset.AntiJoin(dataset.From(users), dataset.From(devices), email, owner)
+	if idle.Count() != 1 || idle.Items[0].Email != "eve@example.com" {
+		t.Errorf("AntiJoin() = %v; want only eve@example.com", idle.Items)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	groups := dataset.GroupBy(dataset.From(devices), func(d device) string { return d.Model })
+
+	got := map[string]int{}
+	for _, g := range groups.Items {
+		got[g.Key] = len(g.Items)
+	}
+	if want := map[string]int{"MacBook Pro": 1, "MacBook Air": 2, "Mac mini": 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupBy() = %v; want %v", got, want)
+	}
+	if first, _ := groups.First(); first.Key != "MacBook Pro" {
+		t.Errorf("groups should keep first-appearance order, got %s first", first.Key)
+	}
+}
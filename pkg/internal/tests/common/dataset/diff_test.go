@@ -0,0 +1,89 @@
+// pkg/internal/tests/common/dataset/diff_test.go
+package dataset_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/cache"
+	"github.com/gemini-oss/rego/pkg/common/dataset"
+)
+
+type admin struct {
+	ID        string   `json:"id"`
+	Email     string   `json:"email"`
+	Roles     []string `json:"roles"`
+	LastLogin string   `json:"lastLogin"`
+}
+
+func adminID(a admin) string { return a.ID }
+
+func TestDiff(t *testing.T) {
+	previous := dataset.From([]admin{
+		{"1", "ada@example.com", []string{"super"}, "monday"},
+		{"2", "bob@example.com", []string{"groups"}, "monday"},
+		{"3", "eve@example.com", []string{"super"}, "monday"},
+	})
+	current := dataset.From([]admin{
+		{"4", "mal@example.com", []string{"super"}, "tuesday"},
+		{"1", "ada@example.com", []string{"super"}, "tuesday"},
+		{"2", "bob@example.com", []string{"groups", "super"}, "tuesday"},
+		{"", "orphan@example.com", nil, ""},
+	})
+
+	report, err := dataset.Diff(previous, current, adminID, "lastLogin")
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+
+	if len(report.Added) != 1 || report.Added[0].ID != "4" {
+		t.Errorf("Added = %v, want admin 4", report.Added)
+	}
+	if len(report.Removed) != 1 || report.Removed[0].ID != "3" {
+		t.Errorf("Removed = %v, want admin 3", report.Removed)
+	}
+
+	want := []dataset.FieldChange{{Field: "roles", Before: `["groups"]`, After: `["groups","super"]`}}
+	if len(report.Modified) != 1 || report.Modified[0].Key != "2" || !reflect.DeepEqual(report.Modified[0].Fields, want) {
+		t.Errorf("Modified = %+v, want admin 2 with %v", report.Modified, want)
+	}
+
+	_, rows := report.Rows(adminID)
+	if len(rows) != 3 {
+		t.Errorf("Rows() = %v, want 3 rows", rows)
+	}
+}
+
+func TestChanges(t *testing.T) {
+	encryptionKey := []byte("32~Byte-long_passphrase-key-1234")
+	name := "rego_test_changes.gob"
+	path := filepath.Join(os.TempDir(), name)
+	defer os.Remove(path)
+	defer os.Remove(path + ".snapshots")
+
+	c, err := cache.NewCache(encryptionKey, name)
+	if err != nil {
+		t.Fatalf("Failed to create cache: %v", err)
+	}
+
+	first := dataset.From([]admin{{ID: "1", Email: "ada@example.com"}})
+	report, err := dataset.Changes(c, "admins", first, adminID, time.Now())
+	if err != nil {
+		t.Fatalf("Changes() error = %v", err)
+	}
+	if !report.Since.IsZero() || !report.Empty() {
+		t.Errorf("first run = %v, want an empty baseline", report)
+	}
+
+	second := dataset.From([]admin{{ID: "1", Email: "ada@example.com"}, {ID: "2", Email: "bob@example.com"}})
+	report, err = dataset.Changes(c, "admins", second, adminID, time.Now())
+	if err != nil {
+		t.Fatalf("Changes() error = %v", err)
+	}
+	if report.Since.IsZero() || len(report.Added) != 1 || report.Added[0].ID != "2" || len(report.Modified) != 0 {
+		t.Errorf("second run = %v (%+v), want admin 2 added", report, report.Added)
+	}
+}
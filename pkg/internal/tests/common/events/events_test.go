@@ -0,0 +1,38 @@
+// pkg/internal/tests/common/events/events_test.go
+package events_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/events"
+)
+
+func TestEventSchema(t *testing.T) {
+	event := events.New("okta", "system", "user.session.start", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	event.Event.Outcome = events.Outcome("ALLOW")
+	event.Relate([]string{"alice@example.com", "", "alice@example.com"}, []string{"10.0.0.1"})
+	event.Label("empty", "")
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		`"@timestamp":"2024-01-02T03:04:05Z"`,
+		`"dataset":"okta.system"`,
+		`"outcome":"success"`,
+		`"related":{"user":["alice@example.com"],"ip":["10.0.0.1"]}`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("json.Marshal() = %s; want it to contain %s", data, want)
+		}
+	}
+
+	if event.Labels != nil {
+		t.Errorf("Label() kept an empty value: %v", event.Labels)
+	}
+}
@@ -0,0 +1,90 @@
+// pkg/internal/tests/common/events/exporter_test.go
+package events_test
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/events"
+)
+
+// readLines decompresses a request body into its lines
+func readLines(t *testing.T, r *http.Request) []string {
+	gz, err := gzip.NewReader(r.Body)
+	if err != nil {
+		t.Fatalf("request body is not gzipped: %v", err)
+	}
+	lines := []string{}
+	scanner := bufio.NewScanner(gz)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines
+}
+
+func TestForwarderSplunkHEC(t *testing.T) {
+	var mu sync.Mutex
+	batches := [][]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/collector/event" || r.Header.Get("Authorization") != "Splunk token" {
+			t.Errorf("unexpected request %s with %q", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		mu.Lock()
+		batches = append(batches, readLines(t, r))
+		mu.Unlock()
+		w.Write([]byte(`{"text":"Success","code":0}`))
+	}))
+	defer server.Close()
+
+	f := events.NewForwarder(&events.SplunkHEC{URL: server.URL, Token: "token"}, events.ForwarderOptions{BatchSize: 2, FlushInterval: time.Hour})
+	for i := 0; i < 3; i++ {
+		f.Send(context.Background(), events.New("okta", "system", fmt.Sprintf("action.%d", i), time.Now()))
+	}
+	f.Close()
+
+	if len(batches) != 2 || len(batches[0]) != 2 || len(batches[1]) != 1 {
+		t.Fatalf("batches = %v; want a full batch of 2 and a flushed batch of 1", batches)
+	}
+	if !strings.Contains(batches[1][0], `"action":"action.2"`) || !strings.Contains(batches[1][0], `"sourcetype":"_json"`) {
+		t.Errorf("event = %s; want the HEC envelope around the event", batches[1][0])
+	}
+}
+
+func TestElasticsearchBulkRetries(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		lines := readLines(t, r)
+		items := []map[string]map[string]int{}
+		for i := 0; i < len(lines)/2; i++ {
+			status := 201
+			if attempts == 1 && i == 1 {
+				status = 429
+			}
+			items = append(items, map[string]map[string]int{"create": {"status": status}})
+		}
+		if attempts == 2 && len(items) != 1 {
+			t.Errorf("retry sent %d documents; want only the rejected one", len(items))
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"errors": attempts == 1, "items": items})
+	}))
+	defer server.Close()
+
+	es := &events.Elasticsearch{URL: server.URL}
+	batch := []*events.Event{events.New("google", "admin", "A", time.Now()), events.New("google", "admin", "B", time.Now())}
+	if err := es.Export(batch); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d; want 2", attempts)
+	}
+}
@@ -0,0 +1,60 @@
+// pkg/internal/tests/common/export/export_test.go
+package export_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/common/export"
+)
+
+func TestStreamCSV(t *testing.T) {
+	var buf bytes.Buffer
+
+	pages := func(yield func([]int) error) error {
+		for page := 0; page < 3; page++ {
+			if err := yield([]int{page * 2, page*2 + 1}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	rows, err := export.Stream(export.NewCSVSink(&buf), []string{"n", "square"}, pages, func(n int) []string {
+		return []string{fmt.Sprint(n), fmt.Sprint(n * n)}
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rows != 6 {
+		t.Errorf("Stream() wrote %d rows; want 6", rows)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 7 || lines[0] != "n,square" || lines[6] != "5,25" {
+		t.Errorf("unexpected csv:\n%s", buf.String())
+	}
+}
+
+func TestStreamStopsOnPageError(t *testing.T) {
+	var buf bytes.Buffer
+	fetchErr := errors.New("page 2 failed")
+
+	pages := func(yield func([]string) error) error {
+		if err := yield([]string{"a", "b"}); err != nil {
+			return err
+		}
+		return fetchErr
+	}
+
+	rows, err := export.Stream(export.NewCSVSink(&buf), []string{"value"}, pages, func(s string) []string { return []string{s} })
+	if !errors.Is(err, fetchErr) {
+		t.Errorf("Stream() error = %v; want %v", err, fetchErr)
+	}
+	if rows != 2 || !strings.Contains(buf.String(), "b") {
+		t.Errorf("rows written before the failure should be flushed, got %d:\n%s", rows, buf.String())
+	}
+}
@@ -0,0 +1,96 @@
+// pkg/internal/tests/common/flags/flags_test.go
+package flags_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/flags"
+)
+
+func TestPrecedence(t *testing.T) {
+	t.Setenv("REGO_DISABLED_AUTOMATIONS", "snipeit-expiry-alerts")
+	t.Setenv("REGO_FLAG_AUTO_SUSPEND_INACTIVE", "true")
+
+	path := filepath.Join(t.TempDir(), "flags.json")
+	if err := os.WriteFile(path, []byte(`{"auto-suspend-inactive": false, "device-compliance-sync": false}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	set := flags.New(&flags.EnvProvider{}, flags.NewFileProvider(path))
+
+	tests := map[string]bool{
+		"snipeit-expiry-alerts":  false, // Disabled in the environment
+		"auto-suspend-inactive":  true,  // Environment overrides the file
+		"device-compliance-sync": false, // Disabled in the file
+		"okta-role-report":       true,  // Undefined flags are enabled
+	}
+	for name, want := range tests {
+		if got := set.Enabled(name); got != want {
+			t.Errorf("Enabled(%q) = %t; want %t", name, got, want)
+		}
+	}
+
+	if err := set.Check("Snipe-IT Expiry Alerts"); err != nil {
+		t.Errorf("unexpected error for an unknown flag: %v", err)
+	}
+	if err := set.Check("snipeit_expiry_alerts"); !errors.Is(err, flags.ErrDisabled) {
+		t.Errorf("Check() = %v; want ErrDisabled", err)
+	}
+}
+
+func TestHTTPProviderKeepsLastKnownValues(t *testing.T) {
+	fail := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"auto-suspend-inactive": false}`)
+	}))
+	defer server.Close()
+
+	provider := flags.NewHTTPProvider(server.URL, 0)
+	set := flags.New(provider)
+
+	if set.Enabled("auto-suspend-inactive") {
+		t.Fatal("expected the flag to be disabled")
+	}
+
+	fail = true
+	provider.TTL = -1 // Force a refresh
+	if set.Enabled("auto-suspend-inactive") {
+		t.Error("a failing provider should keep serving its last known values")
+	}
+}
+
+func TestHTTPProviderCachesFailures(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	provider := flags.NewHTTPProvider(server.URL, time.Hour)
+	set := flags.New(provider)
+
+	for i := 0; i < 5; i++ {
+		if !set.Enabled("auto-suspend-inactive") {
+			t.Error("a provider which never answered should leave the flag enabled")
+		}
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("the failing URL was requested %d times, want once per TTL", n)
+	}
+	if _, err := provider.Flags(); err == nil {
+		t.Error("Flags() = nil error within the TTL of a failure, want the failure")
+	}
+}
@@ -0,0 +1,70 @@
+// pkg/internal/tests/common/lint/lint_test.go
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/common/lint"
+)
+
+func TestCheck(t *testing.T) {
+	policy := &lint.Policy{
+		Name:               "teams",
+		Prefixes:           []string{"team-", "dl-"},
+		Lowercase:          true,
+		MaxLength:          20,
+		Forbidden:          []string{"test"},
+		RequireDescription: true,
+		MinOwners:          1,
+	}
+
+	tests := []struct {
+		name       string
+		group      *lint.Group
+		rules      []string
+		suggestion string
+	}{
+		{"Compliant", &lint.Group{Name: "team-infra", Description: "Infrastructure", Owners: []string{"a@example.com"}}, nil, ""},
+		{"Owners not looked up", &lint.Group{Name: "dl-all", Description: "Everyone"}, nil, ""},
+		{"Naming", &lint.Group{Name: "Security Team", Description: "Security", Owners: []string{"a@example.com"}}, []string{lint.RulePrefix, lint.RuleLowercase}, "team-security-team"},
+		{"Forbidden word", &lint.Group{Name: "team-test_infra", Description: "Infra", Owners: []string{"a@example.com"}}, []string{lint.RuleForbidden}, "team-infra"},
+		{"Too long", &lint.Group{Name: "team-a-very-long-group-name", Description: "Long", Owners: []string{"a@example.com"}}, []string{lint.RuleLength}, "team-a-very-long-gro"},
+		{"Metadata", &lint.Group{Name: "team-ops", Owners: []string{}}, []string{lint.RuleDescription, lint.RuleOwners}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations, err := policy.Check(tt.group)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(violations) != len(tt.rules) {
+				t.Fatalf("Check(%q) = %d violation(s); want %v", tt.group.Name, len(violations), tt.rules)
+			}
+			for i, violation := range violations {
+				if violation.Rule != tt.rules[i] {
+					t.Errorf("violation %d = %s; want %s", i, violation.Rule, tt.rules[i])
+				}
+				if violation.Rule != lint.RuleDescription && violation.Rule != lint.RuleOwners && violation.Suggestion != tt.suggestion {
+					t.Errorf("Suggestion = %q; want %q", violation.Suggestion, tt.suggestion)
+				}
+			}
+		})
+	}
+}
+
+func TestPolicyScope(t *testing.T) {
+	policy := &lint.Policy{Name: "okta apps", Providers: []string{"okta"}, Match: "^app-", Lowercase: true}
+
+	violations, err := lint.Check([]*lint.Policy{policy}, []*lint.Group{
+		{Provider: "okta", Name: "app-Salesforce"},
+		{Provider: "okta", Name: "Engineering"},
+		{Provider: "google", Name: "app-Drive"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) != 1 || violations[0].Group.Name != "app-Salesforce" {
+		t.Fatalf("Check = %v; want a single violation of app-Salesforce", violations)
+	}
+}
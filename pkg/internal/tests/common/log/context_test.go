@@ -0,0 +1,54 @@
+// pkg/internal/tests/common/log/context_test.go
+package log_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/common/log"
+)
+
+func TestContextFields(t *testing.T) {
+	ctx := log.WithRunID(context.Background(), "run-1")
+	ctx = log.WithStep(ctx, "fetch")
+	ctx = log.WithStep(ctx, "export users")
+
+	if got := log.RunID(ctx); got != "run-1" {
+		t.Errorf("RunID = %q, want %q", got, "run-1")
+	}
+	if got := log.Step(ctx); got != "export users" {
+		t.Errorf("Step = %q, want %q", got, "export users")
+	}
+	if got, want := log.ContextString(ctx), `run=run-1 step="export users"`; got != want {
+		t.Errorf("ContextString = %q, want %q", got, want)
+	}
+	if got := log.ContextString(context.Background()); got != "" {
+		t.Errorf("ContextString of an empty context = %q, want empty", got)
+	}
+
+	if id := log.RunID(log.WithRunID(context.Background(), "")); id == "" {
+		t.Error("WithRunID should generate an ID when none is given")
+	}
+}
+
+func TestLoggerWithContext(t *testing.T) {
+	var buf bytes.Buffer
+	l := log.NewLogger("{test}", log.INFO)
+	l.Color = false
+	l.SetOutput(&buf)
+	defer l.Delete()
+
+	ctx := log.WithStep(log.WithRunID(context.Background(), "run-1"), "sync")
+	l.WithContext(ctx).Println("Bound message")
+	if output := buf.String(); !strings.Contains(output, "{test} run=run-1 step=sync {") || !strings.Contains(output, "Bound message") {
+		t.Errorf("Expected the context fields in %q", output)
+	}
+
+	buf.Reset()
+	l.Println("Unbound message")
+	if output := buf.String(); strings.Contains(output, "run=") {
+		t.Errorf("The original logger should not carry the context fields, got %q", output)
+	}
+}
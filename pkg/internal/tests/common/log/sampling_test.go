@@ -0,0 +1,71 @@
+// pkg/internal/tests/common/log/sampling_test.go
+package log_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/log"
+)
+
+func TestLoggerEvery(t *testing.T) {
+	var buf bytes.Buffer
+	l := log.NewLogger("{test}", log.DEBUG)
+	l.Color = false
+	l.SetOutput(&buf)
+	defer l.Delete()
+
+	sampled := l.Every(10)
+	for i := 0; i < 25; i++ {
+		sampled.Debugf("item %d", i)
+	}
+	sampled.Warning("always written")
+
+	output := buf.String()
+	if got := strings.Count(output, "DEBUG - item"); got != 3 {
+		t.Errorf("Expected 3 sampled lines out of 25, got %d:\n%s", got, output)
+	}
+	if !strings.Contains(output, "item 0\n") || !strings.Contains(output, "item 20\n") {
+		t.Errorf("Expected items 0, 10 and 20, got:\n%s", output)
+	}
+	if !strings.Contains(output, "WARNING - always written") {
+		t.Errorf("Warnings should never be sampled, got:\n%s", output)
+	}
+
+	buf.Reset()
+	for i := 0; i < 5; i++ {
+		l.Debugf("unsampled %d", i)
+	}
+	if got := strings.Count(buf.String(), "unsampled"); got != 5 {
+		t.Errorf("The original logger should not be sampled, got %d lines", got)
+	}
+}
+
+func TestLoggerLimit(t *testing.T) {
+	var buf bytes.Buffer
+	l := log.NewLogger("{test}", log.DEBUG)
+	l.Color = false
+	l.SetOutput(&buf)
+	defer l.Delete()
+
+	limited := l.Limit(3, 50*time.Millisecond)
+	for i := 0; i < 10; i++ {
+		limited.Debugf("burst %d", i)
+	}
+	limited.Error("not limited")
+	if got := strings.Count(buf.String(), "DEBUG - burst"); got != 3 {
+		t.Errorf("Expected 3 lines in the burst, got %d:\n%s", got, buf.String())
+	}
+	if !strings.Contains(buf.String(), "ERROR - not limited") {
+		t.Errorf("Errors should never be limited, got:\n%s", buf.String())
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	buf.Reset()
+	limited.Debug("next window")
+	if output := buf.String(); !strings.Contains(output, "next window (7 lines suppressed)") {
+		t.Errorf("Expected the suppressed lines to be reported, got %q", output)
+	}
+}
@@ -0,0 +1,106 @@
+// pkg/internal/tests/common/notify/notify_test.go
+package notify_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/common/notify"
+)
+
+type recorder struct {
+	name     string
+	received []*notify.Message
+	err      error
+}
+
+func (r *recorder) Name() string { return r.name }
+
+func (r *recorder) Notify(m *notify.Message) error {
+	r.received = append(r.received, m)
+	return r.err
+}
+
+func TestRouterSeverity(t *testing.T) {
+	all := &recorder{name: "all"}
+	pager := &recorder{name: "pager"}
+	router := notify.NewRouter().
+		Route(notify.Info, all).
+		Route(notify.Critical, pager)
+
+	if err := router.Notify(&notify.Message{Severity: notify.Warning, Title: "warning"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := router.Notify(&notify.Message{Severity: notify.Critical, Title: "critical"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(all.received) != 2 {
+		t.Errorf("expected 2 messages on the info route, got %d", len(all.received))
+	}
+	if len(pager.received) != 1 || pager.received[0].Title != "critical" {
+		t.Errorf("expected only the critical message on the critical route, got %v", pager.received)
+	}
+}
+
+func TestRouterJoinsErrors(t *testing.T) {
+	failing := &recorder{name: "failing", err: errors.New("boom")}
+	ok := &recorder{name: "ok"}
+	router := notify.NewRouter().Route(notify.Info, failing).Route(notify.Info, ok)
+
+	err := router.Notify(&notify.Message{Title: "test"})
+	if err == nil {
+		t.Fatal("expected an error from the failing notifier")
+	}
+	if len(ok.received) != 1 {
+		t.Error("a failing notifier should not stop delivery to the others")
+	}
+}
+
+func TestNewMessage(t *testing.T) {
+	m, err := notify.NewMessage(notify.Error, "Sync failed", "{{.Count}} users failed to sync", map[string]int{"Count": 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Body != "3 users failed to sync" {
+		t.Errorf("unexpected body: %q", m.Body)
+	}
+}
+
+func TestPagerDutyNotifier(t *testing.T) {
+	var event map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&event)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	original := notify.PagerDutyEventsURL
+	notify.PagerDutyEventsURL = server.URL
+	defer func() { notify.PagerDutyEventsURL = original }()
+
+	n := &notify.PagerDutyNotifier{RoutingKey: "key"}
+	if err := n.Notify(&notify.Message{Severity: notify.Critical, Title: "Okta sync down", DedupKey: "okta-sync"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	payload := event["payload"].(map[string]interface{})
+	if event["routing_key"] != "key" || event["dedup_key"] != "okta-sync" || payload["severity"] != "critical" {
+		t.Errorf("unexpected event: %v", event)
+	}
+}
+
+func TestGoogleChatNotifierError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid webhook", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	n := &notify.GoogleChatNotifier{WebhookURL: server.URL}
+	if err := n.Notify(&notify.Message{Title: "test"}); err == nil {
+		t.Error("expected an error for a 400 response")
+	}
+}
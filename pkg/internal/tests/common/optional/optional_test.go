@@ -0,0 +1,114 @@
+// pkg/internal/tests/common/optional/optional_test.go
+package optional_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/common/optional"
+	"github.com/gemini-oss/rego/pkg/common/requests"
+	"github.com/gemini-oss/rego/pkg/google"
+	"github.com/gemini-oss/rego/pkg/snipeit"
+)
+
+type settings struct {
+	Enabled *bool   `json:"enabled,omitempty"`
+	Label   *string `json:"label,omitempty"`
+}
+
+func TestOptionalMarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		in   settings
+		want string
+	}{
+		{"unset fields are omitted", settings{}, `{}`},
+		{"zero values are sent", settings{Enabled: optional.Of(false), Label: optional.Of("")}, `{"enabled":false,"label":""}`},
+		{"values are sent", settings{Enabled: optional.Of(true)}, `{"enabled":true}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.in)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptionalValue(t *testing.T) {
+	var unset *bool
+	if optional.Value(unset) || optional.IsSet(unset) {
+		t.Error("Value() of an unset field should be false, and IsSet() false")
+	}
+	if !optional.Or(unset, true) {
+		t.Error("Or() of an unset field should return the fallback")
+	}
+
+	set := optional.Of(false)
+	if optional.Value(set) || !optional.IsSet(set) || optional.Or(set, true) {
+		t.Error("a field set to false should read false, and be set")
+	}
+}
+
+func TestOptionalPayload(t *testing.T) {
+	tests := []struct {
+		name string
+		in   interface{}
+		want string
+	}{
+		{"auto-reply disabled", &google.GmailVacationSettings{EnableAutoReply: optional.Of(false)}, `{"enableAutoReply":false}`},
+		{"auto-reply left as is", &google.GmailVacationSettings{ResponseSubject: "Away"}, `{"responseSubject":"Away"}`},
+		{"forwarding disabled", &google.GmailAutoForwarding{Enabled: optional.Of(false)}, `{"enabled":false}`},
+		{"inheritance unblocked", &google.OrgUnit{BlockInheritance: optional.Of(false)}, `{"blockInheritance":false}`},
+		{"shared drive opened", &google.SharedDrive{Restrictions: &google.SharedDriveRestrictions{DomainUsersOnly: optional.Of(false)}}, `{"restrictions":{"domainUsersOnly":false}}`},
+		{"send-as not default", &google.GmailSendAs{IsDefault: optional.Of(false)}, `{"isDefault":false}`},
+		{"hardware not BYOD", &snipeit.Hardware{BYOD: optional.Of(false)}, `{"byod":false}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("PATCH", "https://example.com", nil)
+			if err := requests.SetJSONPayload(req, tt.in); err != nil {
+				t.Fatalf("SetJSONPayload() error = %v", err)
+			}
+			got, err := io.ReadAll(req.Body)
+			if err != nil {
+				t.Fatalf("reading the body: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("SetJSONPayload() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptionalQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		activate *bool
+		want     string
+	}{
+		{"unset", nil, ""},
+		{"false", optional.Of(false), "activate=false"},
+		{"true", optional.Of(true), "activate=true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "https://example.com/api/v1/users", nil)
+			requests.SetQueryParams(req, struct {
+				Activate *bool `url:"activate"`
+			}{tt.activate})
+			if req.URL.RawQuery != tt.want {
+				t.Errorf("SetQueryParams() = %q, want %q", req.URL.RawQuery, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// pkg/internal/tests/common/requests/backoff_test.go
+package requests_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Duration{
+		"":                              0,
+		"30":                            30 * time.Second,
+		"-5":                            0,
+		"86400":                         requests.MaxRetryAfter,
+		"Mon, 01 Jan 2024 12:01:00 GMT": time.Minute,
+		"Mon, 01 Jan 2024 11:00:00 GMT": 0,
+		"soon":                          0,
+	}
+	for value, want := range tests {
+		if got := requests.ParseRetryAfter(value, now); got != want {
+			t.Errorf("ParseRetryAfter(%q) = %s, want %s", value, got, want)
+		}
+	}
+}
+
+func TestWithBackoff(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("<html>Down for maintenance</html>"))
+	}))
+	defer server.Close()
+
+	var seen *requests.StatusError
+	client := requests.NewClient(nil, requests.Headers{"Content-Type": requests.JSON}, nil, requests.WithBackoff(func(attempt int, err error) (time.Duration, bool) {
+		errors.As(err, &seen)
+		return 0, false
+	}))
+
+	_, _, err := client.DoRequest("GET", server.URL, nil, nil)
+	if requests.StatusCode(err) != http.StatusServiceUnavailable {
+		t.Fatalf("DoRequest() error = %v, want a 503 StatusError", err)
+	}
+	if calls != 1 {
+		t.Errorf("server was called %d times, want 1 when the backoff stops retrying", calls)
+	}
+	if seen == nil || seen.RetryAfter != 2*time.Minute {
+		t.Errorf("backoff saw %+v, want a StatusError with RetryAfter = 2m", seen)
+	}
+}
+
+func TestDoBodyRetries(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch calls {
+		case 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":{"code":400,"message":"Invalid batch"}}`))
+		}
+	}))
+	defer server.Close()
+
+	var seen []int
+	client := requests.NewClient(nil, requests.Headers{}, nil, requests.WithBackoff(func(attempt int, err error) (time.Duration, bool) {
+		code := requests.StatusCode(err)
+		seen = append(seen, code)
+		return 0, code == http.StatusTooManyRequests || code >= 500
+	}))
+
+	_, body, err := client.DoBody("POST", server.URL, "multipart/mixed; boundary=batch", []byte("--batch--"))
+	if requests.StatusCode(err) != http.StatusBadRequest {
+		t.Fatalf("DoBody() error = %v, want a 400 StatusError", err)
+	}
+	if string(body) != `{"error":{"code":400,"message":"Invalid batch"}}` {
+		t.Errorf("DoBody() body = %s, want the error response", body)
+	}
+	if calls != 3 || len(seen) != 3 || seen[0] != http.StatusTooManyRequests || seen[1] != http.StatusBadGateway {
+		t.Errorf("backoff saw %v over %d calls, want [429 502 400] over 3", seen, calls)
+	}
+}
@@ -0,0 +1,81 @@
+// pkg/internal/tests/common/requests/compression_test.go
+package requests_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+)
+
+func compress(t *testing.T, encoding, data string) []byte {
+	var buffer bytes.Buffer
+	var w io.WriteCloser
+	switch encoding {
+	case requests.Gzip:
+		w = gzip.NewWriter(&buffer)
+	case requests.Deflate:
+		w = zlib.NewWriter(&buffer)
+	}
+	if _, err := w.Write([]byte(data)); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	return buffer.Bytes()
+}
+
+func TestDecompressResponse(t *testing.T) {
+	for _, encoding := range []string{requests.Gzip, requests.Deflate} {
+		t.Run(encoding, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Accept-Encoding") != requests.AcceptEncoding {
+					t.Errorf("Accept-Encoding = %q, want %q", r.Header.Get("Accept-Encoding"), requests.AcceptEncoding)
+				}
+				w.Header().Set("Content-Encoding", encoding)
+				w.Write(compress(t, encoding, `{"id":"1"}`))
+			}))
+			defer server.Close()
+
+			client := requests.NewClient(nil, requests.Headers{"Content-Type": requests.JSON}, nil)
+			_, body, err := client.DoRequest("GET", server.URL, nil, nil)
+			if err != nil {
+				t.Fatalf("DoRequest() error = %v", err)
+			}
+			if string(body) != `{"id":"1"}` {
+				t.Errorf("DoRequest() body = %s, want %s", body, `{"id":"1"}`)
+			}
+		})
+	}
+}
+
+func TestCompressRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Encoding") != requests.Gzip {
+			t.Errorf("Content-Encoding = %q, want %q", r.Header.Get("Content-Encoding"), requests.Gzip)
+		}
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			t.Fatalf("request body is not gzipped: %v", err)
+		}
+		payload, _ := io.ReadAll(gz)
+		w.Write(payload)
+	}))
+	defer server.Close()
+
+	client := requests.NewClient(nil, requests.Headers{"Content-Type": requests.JSON}, nil)
+	client.BodyType = requests.JSON
+	client.CompressRequests = true
+
+	_, body, err := client.DoRequest("POST", server.URL, nil, map[string]interface{}{"field1": "value1"})
+	if err != nil {
+		t.Fatalf("DoRequest() error = %v", err)
+	}
+	if string(body) != `{"field1":"value1"}` {
+		t.Errorf("DoRequest() body = %s, want %s", body, `{"field1":"value1"}`)
+	}
+}
@@ -0,0 +1,94 @@
+// pkg/internal/tests/common/requests/limits_test.go
+package requests_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+)
+
+func TestMaxResponseSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/chunked" {
+			// Flushing before writing drops the Content-Length header
+			w.(http.Flusher).Flush()
+		}
+		w.Write([]byte(strings.Repeat("a", 2048)))
+	}))
+	defer server.Close()
+
+	client := requests.NewClient(nil, requests.Headers{}, nil, requests.WithMaxResponseSize(1024))
+	for _, path := range []string{"/sized", "/chunked"} {
+		if _, _, err := client.DoBody("GET", server.URL+path, requests.JSON, nil); !errors.Is(err, requests.ErrResponseTooLarge) {
+			t.Errorf("DoBody(%s) error = %v; want ErrResponseTooLarge", path, err)
+		}
+	}
+
+	client = requests.NewClient(nil, requests.Headers{}, nil, requests.WithMaxResponseSize(4096))
+	if _, body, err := client.DoBody("GET", server.URL+"/sized", requests.JSON, nil); err != nil || len(body) != 2048 {
+		t.Errorf("DoBody() = %d bytes, %v; want the full 2048 byte body", len(body), err)
+	}
+}
+
+func TestResponseTooLargeNotRetried(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Write([]byte(strings.Repeat("a", 2048)))
+	}))
+	defer server.Close()
+
+	client := requests.NewClient(nil, requests.Headers{}, nil, requests.WithMaxResponseSize(1024))
+	if _, _, err := client.DoBody("GET", server.URL, requests.JSON, nil); !errors.Is(err, requests.ErrResponseTooLarge) {
+		t.Errorf("DoBody() error = %v; want ErrResponseTooLarge", err)
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("server received %d requests; want 1, as a response too large is not retried", n)
+	}
+}
+
+func TestStreamIgnoresMaxResponseSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"not found"}`))
+			return
+		}
+		w.Write([]byte(strings.Repeat("a", 2048)))
+	}))
+	defer server.Close()
+
+	client := requests.NewClient(nil, requests.Headers{}, nil, requests.WithMaxResponseSize(1024))
+
+	req, _ := client.CreateRequest("GET", server.URL+"/file")
+	var out bytes.Buffer
+	if _, n, err := client.Stream(req, &out); err != nil || n != 2048 || out.Len() != 2048 {
+		t.Errorf("Stream() = %d bytes (%d written), %v; want the full 2048 byte body", n, out.Len(), err)
+	}
+
+	req, _ = client.CreateRequest("GET", server.URL+"/missing")
+	out.Reset()
+	if _, _, err := client.Stream(req, &out); requests.StatusCode(err) != http.StatusNotFound || out.Len() != 0 {
+		t.Errorf("Stream(/missing) error = %v with %d bytes written; want a 404 StatusError and nothing written", err, out.Len())
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := map[string]int64{
+		"1048576": 1 << 20,
+		"512KB":   512000,
+		"1.5MiB":  3 << 19,
+		" 2gib ":  2 << 30,
+	}
+	for input, want := range tests {
+		if got, err := requests.ParseSize(input); err != nil || got != want {
+			t.Errorf("ParseSize(%q) = %d, %v; want %d", input, got, err, want)
+		}
+	}
+}
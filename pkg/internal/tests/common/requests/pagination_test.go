@@ -0,0 +1,102 @@
+// pkg/internal/tests/common/requests/pagination_test.go
+package requests_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+)
+
+func TestPageLimitProbing(t *testing.T) {
+	requested := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit := r.URL.Query().Get("limit")
+		requested = append(requested, limit)
+
+		if size, _ := strconv.Atoi(limit); size > 200 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errorSummary":"Invalid limit: must be less than or equal to 200"}`))
+			return
+		}
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	client := requests.NewClient(nil, requests.Headers{"Content-Type": requests.JSON}, nil)
+	query := map[string]interface{}{"limit": 500}
+
+	if _, _, err := client.DoRequest("GET", server.URL+"/api/v1/users", query, nil); err != nil {
+		t.Fatalf("DoRequest() error = %v", err)
+	}
+	if limit, ok := client.PageLimit(server.URL + "/api/v1/users"); !ok || limit != 200 {
+		t.Errorf("PageLimit() = %d, %v; want 200, true", limit, ok)
+	}
+
+	// Later requests are clamped before they are sent
+	if _, _, err := client.DoRequest("GET", server.URL+"/api/v1/users", query, nil); err != nil {
+		t.Fatalf("DoRequest() error = %v", err)
+	}
+
+	want := []string{"500", "200", "200"}
+	if len(requested) != len(want) {
+		t.Fatalf("requested limits = %v, want %v", requested, want)
+	}
+	for i := range want {
+		if requested[i] != want[i] {
+			t.Errorf("requested limits = %v, want %v", requested, want)
+			break
+		}
+	}
+}
+
+func TestPaginatedDoOffset(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		items := []int{}
+		for i := offset; i < offset+limit && i < 25; i++ {
+			items = append(items, i)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"total": 25, "rows": items})
+	}))
+	defer server.Close()
+
+	client := requests.NewClient(nil, requests.Headers{"Content-Type": requests.JSON}, nil)
+	pagination := requests.Pagination[int]{
+		Strategy: requests.OffsetPages{},
+		Items:    requests.ItemsField[int]("rows"),
+		PageSize: 10,
+	}
+
+	rows, err := requests.PaginatedDo(client, "GET", server.URL+"/api/v1/hardware", nil, pagination)
+	if err != nil {
+		t.Fatalf("PaginatedDo() error = %v", err)
+	}
+	if len(rows) != 25 || rows[24] != 24 {
+		t.Errorf("PaginatedDo() = %v; want 0 through 24", rows)
+	}
+
+	// Stops at the page limit, and when the callback asks to
+	pagination.MaxPages = 2
+	if rows, _ := requests.PaginatedDo(client, "GET", server.URL+"/api/v1/hardware", nil, pagination); len(rows) != 20 {
+		t.Errorf("PaginatedDo() with MaxPages = %d rows; want 20", len(rows))
+	}
+	pagination.MaxPages = 0
+	pagination.OnPage = func(page int, items []int) bool { return page < 1 }
+	if rows, _ := requests.PaginatedDo(client, "GET", server.URL+"/api/v1/hardware", nil, pagination); len(rows) != 10 {
+		t.Errorf("PaginatedDo() with OnPage = %d rows; want 10", len(rows))
+	}
+
+	// Discarded pages are only handed to the callback
+	streamed := 0
+	pagination.OnPage = func(page int, items []int) bool { streamed += len(items); return true }
+	pagination.Discard = true
+	if rows, _ := requests.PaginatedDo(client, "GET", server.URL+"/api/v1/hardware", nil, pagination); len(rows) != 0 || streamed != 25 {
+		t.Errorf("PaginatedDo() with Discard = %d rows, %d streamed; want 0 rows, 25 streamed", len(rows), streamed)
+	}
+}
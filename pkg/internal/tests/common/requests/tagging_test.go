@@ -0,0 +1,51 @@
+// pkg/internal/tests/common/requests/tagging_test.go
+package requests_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/common/log"
+	"github.com/gemini-oss/rego/pkg/common/requests"
+)
+
+func TestRequestTagging(t *testing.T) {
+	var userAgent, requestID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent, requestID = r.UserAgent(), r.Header.Get(requests.RequestIDHeader)
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	client := requests.NewClient(nil, requests.Headers{}, nil, requests.WithUserAgent("acme-rego/1.0"))
+	ctx := log.WithStep(log.WithRunID(context.Background(), "run-1"), "suspend users")
+
+	if _, _, err := client.WithContext(ctx).DoRequest("GET", server.URL, nil, nil); err != nil {
+		t.Fatalf("DoRequest() error = %v", err)
+	}
+	if want := "acme-rego/1.0 run/run-1 step/suspend_users"; userAgent != want {
+		t.Errorf("User-Agent = %q; want %q", userAgent, want)
+	}
+	if !strings.HasPrefix(requestID, "run-1.") || len(requestID) <= len("run-1.") {
+		t.Errorf("X-Request-ID = %q; want a random ID prefixed with the run", requestID)
+	}
+
+	first := requestID
+	if _, _, err := client.WithContext(ctx).DoRequest("GET", server.URL, nil, nil); err != nil {
+		t.Fatalf("DoRequest() error = %v", err)
+	}
+	if requestID == first {
+		t.Errorf("X-Request-ID should differ between requests, got %q twice", requestID)
+	}
+
+	untagged := requests.NewClient(nil, requests.Headers{"User-Agent": "custom"}, nil, requests.WithRequestIDs(nil))
+	if _, _, err := untagged.DoRequest("GET", server.URL, nil, nil); err != nil {
+		t.Fatalf("DoRequest() error = %v", err)
+	}
+	if userAgent != "custom" || requestID != "" {
+		t.Errorf("User-Agent = %q, X-Request-ID = %q; want the header of the client and no request ID", userAgent, requestID)
+	}
+}
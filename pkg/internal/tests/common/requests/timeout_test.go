@@ -0,0 +1,74 @@
+// pkg/internal/tests/common/requests/timeout_test.go
+package requests_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+)
+
+func TestEndpointTimeouts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(200 * time.Millisecond):
+		case <-r.Context().Done():
+		}
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	client := requests.NewClient(nil, requests.Headers{}, nil,
+		requests.WithTimeout(time.Second),
+		requests.WithEndpointTimeout("/api/v1/users", 50*time.Millisecond),
+		requests.WithEndpointTimeout("/api/v1/users/export", 2*time.Second),
+	)
+
+	if d := client.Timeout("/api/v1/users/export/all"); d != 2*time.Second {
+		t.Errorf("Timeout() = %s; want the longest matching prefix (2s)", d)
+	}
+
+	_, _, err := client.DoBody("GET", server.URL+"/api/v1/users/123", requests.JSON, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("DoBody() error = %v; want a deadline exceeded error", err)
+	}
+
+	if _, _, err := client.DoBody("GET", server.URL+"/api/v1/users/export", requests.JSON, nil); err != nil {
+		t.Errorf("DoBody() error = %v; want the export to finish within its deadline", err)
+	}
+}
+
+func TestContextCancelsRetries(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := requests.NewClient(nil, requests.Headers{}, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, _, err := client.WithContext(ctx).DoRequest("GET", server.URL+"/api/v1/users", nil, nil)
+	if err == nil {
+		t.Fatal("DoRequest() error = nil; want the 503")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("DoRequest() took %s; want the retries to end with the context", elapsed)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d; want 1", attempts)
+	}
+
+	// The original client is not bound to the context
+	if client.Context().Err() != nil {
+		t.Errorf("Context().Err() = %v; want the original client unaffected", client.Context().Err())
+	}
+}
@@ -0,0 +1,76 @@
+// pkg/internal/tests/common/requests/transport_test.go
+package requests_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+	"golang.org/x/oauth2"
+)
+
+func TestNewTransport(t *testing.T) {
+	transport := requests.NewTransport(&requests.TransportOptions{
+		MaxIdleConnsPerHost: 50,
+		IdleConnTimeout:     30 * time.Second,
+		DisableHTTP2:        true,
+	})
+
+	if transport.MaxIdleConnsPerHost != 50 {
+		t.Errorf("MaxIdleConnsPerHost = %d, want %d", transport.MaxIdleConnsPerHost, 50)
+	}
+	if transport.ForceAttemptHTTP2 {
+		t.Error("ForceAttemptHTTP2 = true, want false when HTTP/2 is disabled")
+	}
+	if transport.TLSNextProto == nil {
+		t.Error("TLSNextProto = nil, want an empty map when HTTP/2 is disabled")
+	}
+
+	if !requests.NewTransport(nil).ForceAttemptHTTP2 {
+		t.Error("default transport should attempt HTTP/2")
+	}
+}
+
+func TestTuneTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := requests.NewClient(nil, requests.Headers{"Content-Type": requests.JSON}, nil)
+	client.TuneTransport(&requests.TransportOptions{MaxIdleConnsPerHost: 10, DisableKeepAlives: true})
+
+	_, body, err := client.DoRequest("GET", server.URL, nil, nil)
+	if err != nil {
+		t.Fatalf("DoRequest() error = %v", err)
+	}
+	if string(body) != "ok" {
+		t.Errorf("DoRequest() body = %s, want %s", body, "ok")
+	}
+}
+
+func TestTuneTransportOAuth2(t *testing.T) {
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	transport := &oauth2.Transport{Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"})}
+	client := requests.NewClient(&http.Client{Transport: transport}, requests.Headers{"Content-Type": requests.JSON}, nil)
+	client.TuneTransport(&requests.TransportOptions{MaxIdleConnsPerHost: 10})
+
+	if _, ok := transport.Base.(*http.Transport); !ok {
+		t.Errorf("oauth2 Base = %T, want *http.Transport", transport.Base)
+	}
+
+	if _, _, err := client.DoRequest("GET", server.URL, nil, nil); err != nil {
+		t.Fatalf("DoRequest() error = %v", err)
+	}
+	if auth != "Bearer token" {
+		t.Errorf("Authorization = %q, want the token of the oauth2 transport", auth)
+	}
+}
@@ -0,0 +1,49 @@
+// pkg/internal/tests/common/schema/decode_test.go
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/common/schema"
+)
+
+func TestUnmarshalModes(t *testing.T) {
+	doc := []byte(`{"id": "1", "address": "a@gemini.com", "primary": true}`)
+	defer schema.SetDecodeMode(schema.Lenient)
+	defer schema.DefaultCollector.Reset()
+
+	tests := []struct {
+		name        string
+		mode        schema.DecodeMode
+		wantErr     bool
+		wantCounted int
+	}{
+		{"Lenient", schema.Lenient, false, 0},
+		{"Report", schema.Report, false, 1},
+		{"Strict", schema.Strict, true, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema.SetDecodeMode(tt.mode)
+
+			email := &Email{}
+			err := schema.Unmarshal(doc, email)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && email.Address != "a@gemini.com" {
+				t.Errorf("Unmarshal() address = %s, want %s", email.Address, "a@gemini.com")
+			}
+
+			if got := schema.DefaultCollector.Occurrences("schema_test.Email", "primary"); got != tt.wantCounted {
+				t.Errorf("Occurrences(primary) = %d, want %d", got, tt.wantCounted)
+			}
+		})
+	}
+
+	report := schema.DefaultCollector.Report()
+	if len(report) != 1 || report[0].Type != "schema_test.Email" || len(report[0].Unmapped) != 2 {
+		t.Errorf("Report() = %v, want schema_test.Email with [id primary]", report)
+	}
+}
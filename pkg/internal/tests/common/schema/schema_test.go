@@ -0,0 +1,82 @@
+// pkg/internal/tests/common/schema/schema_test.go
+package schema_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/schema"
+)
+
+type Base struct {
+	ID string `json:"id"`
+}
+
+type Email struct {
+	Address string `json:"address"`
+}
+
+type User struct {
+	Base
+	Name     string                 `json:"name,omitempty"`
+	Created  time.Time              `json:"created"`
+	Emails   []*Email               `json:"emails"`
+	Labels   map[string]*Email      `json:"labels"`
+	Extra    map[string]interface{} `json:"extra"`
+	Internal string                 `json:"-"`
+	Title    string
+}
+
+func TestDiff(t *testing.T) {
+	doc := `{
+		"id": "1",
+		"name": "rego",
+		"title": "Engineer",
+		"created": "2024-01-01T00:00:00Z",
+		"emails": [{"address": "a@gemini.com", "primary": true}],
+		"labels": {"work": {"address": "b@gemini.com", "verified": true}},
+		"extra": {"anything": {"goes": 1}},
+		"Internal": "dropped",
+		"department": "IT"
+	}`
+
+	drift, err := schema.Diff([]byte(doc), &User{})
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+
+	want := []string{"Internal", "department", "emails[].primary", "labels{}.verified"}
+	if !reflect.DeepEqual(drift.Unmapped, want) {
+		t.Errorf("Diff() unmapped = %v, want %v", drift.Unmapped, want)
+	}
+	if drift.Type != "schema_test.User" {
+		t.Errorf("Diff() type = %s, want %s", drift.Type, "schema_test.User")
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	doc := `{"name": "rego", "count": 42, "active": true, "created": "2024-05-06T07:08:09Z", "items": [1, 2, 3, 4, 5]}`
+
+	sanitized, err := schema.Sanitize([]byte(doc))
+	if err != nil {
+		t.Fatalf("Sanitize() error = %v", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(sanitized, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]interface{}{
+		"name":    schema.Redacted,
+		"count":   float64(0),
+		"active":  true,
+		"created": "1970-01-01T00:00:00Z",
+		"items":   []interface{}{float64(0), float64(0), float64(0)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Sanitize() = %v, want %v", got, want)
+	}
+}
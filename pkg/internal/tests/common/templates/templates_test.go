@@ -0,0 +1,80 @@
+// pkg/internal/tests/common/templates/templates_test.go
+package templates_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/templates"
+)
+
+func TestTenantOverride(t *testing.T) {
+	dir := t.TempDir()
+	lib := templates.New(dir)
+	lib.Register("greeting", "Hello {{ .Name }}")
+
+	write := func(path, text string) {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		tenant string
+		want   string
+		setup  func()
+	}{
+		{"Built-in", "subsidiary", "Hello Ada", func() {}},
+		{"Global override", "subsidiary", "Hi Ada", func() { write(filepath.Join(dir, "greeting.tmpl"), "Hi {{ .Name }}") }},
+		{"Tenant override", "subsidiary", "Hey ADA", func() { write(filepath.Join(dir, "subsidiary", "greeting.tmpl"), "Hey {{ upper .Name }}") }},
+		{"Other tenant", "other", "Hi Ada", func() {}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			got, err := lib.RenderFor(tt.tenant, "greeting", map[string]string{"Name": "Ada"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RenderFor(%q) = %q; want %q", tt.tenant, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := lib.RenderFor("../etc", "greeting", nil); err == nil {
+		t.Error("expected an error for a tenant escaping the template directory")
+	}
+}
+
+func TestFuncs(t *testing.T) {
+	data := map[string]interface{}{
+		"Emails":  []string{"a@example.com", "b@example.com"},
+		"Count":   1,
+		"Manager": "",
+		"When":    time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	got, err := templates.RenderString("funcs", `{{ .Emails | join ", " }}|{{ plural "user" "users" .Count }}|{{ .Manager | default "none" }}|{{ date "2006-01-02" .When }}`, data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "a@example.com, b@example.com|user|none|2024-03-01"
+	if got != want {
+		t.Errorf("RenderString() = %q; want %q", got, want)
+	}
+}
+
+func TestEmbeddedDefaults(t *testing.T) {
+	if _, _, err := templates.New("").Lookup("", "email"); err != nil {
+		t.Errorf("expected the embedded email template: %v", err)
+	}
+}
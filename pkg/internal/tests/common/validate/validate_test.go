@@ -0,0 +1,93 @@
+// pkg/internal/tests/common/validate/validate_test.go
+package validate_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/common/validate"
+	"github.com/gemini-oss/rego/pkg/google"
+	"github.com/gemini-oss/rego/pkg/snipeit"
+)
+
+type contact struct {
+	Email   string `json:"email,omitempty" validate:"required,format=email"`
+	Website string `json:"website,omitempty" validate:"format=url"`
+}
+
+type account struct {
+	Kind     string     `json:"kind" validate:"enum=user|service"`
+	Contacts []*contact `json:"contacts" validate:"required"`
+	Start    string     `json:"start,omitempty"`
+	End      string     `json:"end,omitempty"`
+}
+
+func (a *account) Validate() error {
+	if a.Start != "" && a.End != "" && a.End < a.Start {
+		return errors.New("end is before start")
+	}
+	return nil
+}
+
+func TestStruct(t *testing.T) {
+	err := validate.Struct(&account{
+		Kind:     "robot",
+		Contacts: []*contact{{Email: "ada@example.com"}, {Website: "not a url"}},
+		Start:    "2024-02-01",
+		End:      "2024-01-01",
+	})
+
+	var errs validate.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Struct() error = %v, want validate.Errors", err)
+	}
+
+	want := map[string]string{
+		"(root)":              "validate",
+		"kind":                "enum",
+		"contacts[1].email":   "required",
+		"contacts[1].website": "format",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("Struct() = %v, want failures on %v", errs, want)
+	}
+	for _, e := range errs {
+		if want[e.Field] != e.Rule {
+			t.Errorf("unexpected failure %s (%s)", e.Field, e.Rule)
+		}
+	}
+}
+
+func TestStructValid(t *testing.T) {
+	valid := []interface{}{
+		nil,
+		map[string]interface{}{"limit": 200},
+		&account{Kind: "user", Contacts: []*contact{{Email: "ada@example.com", Website: "https://example.com"}}},
+		&snipeit.CheckoutRequest{AssignedTo: 1},
+	}
+	for _, v := range valid {
+		if err := validate.Struct(v); err != nil {
+			t.Errorf("Struct(%#v) = %v, want nil", v, err)
+		}
+	}
+}
+
+func TestProviderRequests(t *testing.T) {
+	err := validate.Struct(&google.DataTransfer{
+		OldOwnerUserID:           "123",
+		ApplicationDataTransfers: []*google.ApplicationDataTransfer{{}},
+	})
+	if err == nil {
+		t.Fatal("Struct() = nil for a transfer without a new owner")
+	}
+	for _, field := range []string{"newOwnerUserId", "applicationDataTransfers[0].applicationId"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("Struct() = %q, want it to name %s", err, field)
+		}
+	}
+
+	if err := validate.Struct(&snipeit.CheckoutRequest{CheckoutToType: "desk"}); err == nil {
+		t.Error("Struct() = nil for an unknown checkout_to_type")
+	}
+}
@@ -0,0 +1,79 @@
+// pkg/internal/tests/google/drive_upload_test.go
+package google_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/google"
+)
+
+// uploadSession fakes a resumable upload session, which persists only half of the chunk of the `partial`th PUT
+type uploadSession struct {
+	t        *testing.T
+	partial  int
+	puts     int
+	received []byte
+}
+
+func (s *uploadSession) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" {
+		w.Header().Set("Location", "https://www.googleapis.com/upload/session")
+		return
+	}
+
+	s.puts++
+	data, _ := io.ReadAll(r.Body)
+
+	// e.g. `bytes 262144-524287/*`, or `bytes 524288-614399/614400` for the last chunk
+	start, total := int64(-1), int64(-1)
+	fmt.Sscanf(r.Header.Get("Content-Range"), "bytes %d-", &start)
+	if i := strings.LastIndex(r.Header.Get("Content-Range"), "/"); i >= 0 {
+		total, _ = strconv.ParseInt(r.Header.Get("Content-Range")[i+1:], 10, 64)
+	}
+	if start != int64(len(s.received)) {
+		s.t.Errorf("PUT %d starts at byte %d; the session persisted %d", s.puts, start, len(s.received))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if s.puts == s.partial {
+		data = data[:len(data)/2]
+	}
+	s.received = append(s.received, data...)
+
+	if total >= 0 && int64(len(s.received)) == total {
+		fmt.Fprint(w, `{"id": "f1"}`)
+		return
+	}
+	w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", len(s.received)-1))
+	w.WriteHeader(308)
+}
+
+func TestUploadResendsUnpersistedTail(t *testing.T) {
+	for _, partial := range []int{2, 3} { // A middle chunk, then the last one
+		session := &uploadSession{t: t, partial: partial}
+		c := newServedClient(t, session)
+
+		content := bytes.Repeat([]byte("0123456789"), 60*1024) // 600 KiB: chunks of 256, 256 and 88 KiB
+		file, err := c.Drive().UploadFile(&google.File{Name: "report.csv"}, bytes.NewReader(content), -1, &google.UploadOptions{ChunkSize: 256 * 1024})
+		if err != nil {
+			t.Fatalf("UploadFile() (partial PUT %d) error = %v", partial, err)
+		}
+
+		if file.ID != "f1" {
+			t.Errorf("UploadFile() (partial PUT %d) ID = %q, want f1", partial, file.ID)
+		}
+		if !bytes.Equal(session.received, content) {
+			t.Errorf("session (partial PUT %d) received %d bytes, want the %d bytes of content", partial, len(session.received), len(content))
+		}
+		if session.puts != 4 {
+			t.Errorf("session (partial PUT %d) received %d PUTs, want 4: three chunks and the tail of the partial one", partial, session.puts)
+		}
+	}
+}
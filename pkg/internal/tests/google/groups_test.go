@@ -0,0 +1,141 @@
+// pkg/internal/tests/google/groups_test.go
+package google_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"path"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/google"
+)
+
+// membersAPI serves the members of a group, and answers batch requests with a status per member
+type membersAPI struct {
+	t       *testing.T
+	members string
+	status  map[string]int // Status of the calls for a member; 200 when unset
+	batches [][]string     // Calls of each batch request, e.g. `POST ada@example.com`
+}
+
+func (api *membersAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/batch/admin/directory_v1" {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, api.members)
+		return
+	}
+
+	_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		api.t.Errorf("batch Content-Type: %v", err)
+		return
+	}
+
+	var calls []string
+	var response bytes.Buffer
+	mw := multipart.NewWriter(&response)
+
+	mr := multipart.NewReader(r.Body, params["boundary"])
+	for i := 0; ; i++ {
+		part, err := mr.NextPart()
+		if err != nil {
+			break
+		}
+
+		// Each call is a request line without the HTTP version, its headers, then its body
+		tp := textproto.NewReader(bufio.NewReader(part))
+		line, err := tp.ReadLine()
+		if err != nil {
+			api.t.Errorf("batch call %d: %v", i, err)
+			return
+		}
+		if _, err := tp.ReadMIMEHeader(); err != nil {
+			api.t.Errorf("batch call %d headers: %v", i, err)
+			return
+		}
+		method, uri, _ := strings.Cut(line, " ")
+
+		// Added members are in the body of the call, removed ones at the end of its URL
+		member := path.Base(uri)
+		if method == "POST" {
+			var m google.Member
+			if err := json.NewDecoder(tp.R).Decode(&m); err != nil {
+				api.t.Errorf("batch call %d body: %v", i, err)
+			}
+			member = m.Email
+		}
+		calls = append(calls, method+" "+member)
+
+		status := http.StatusOK
+		if code, ok := api.status[member]; ok {
+			status = code
+		}
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", "application/http")
+		header.Set("Content-ID", fmt.Sprintf("<response-item-%d>", i))
+		p, _ := mw.CreatePart(header)
+		fmt.Fprintf(p, "HTTP/1.1 %d %s\r\nContent-Type: application/json\r\n\r\n{}", status, http.StatusText(status))
+	}
+	mw.Close()
+	api.batches = append(api.batches, calls)
+
+	w.Header().Set("Content-Type", "multipart/mixed; boundary="+mw.Boundary())
+	w.Write(response.Bytes())
+}
+
+func TestSetMembers(t *testing.T) {
+	batchSize := google.BatchSize
+	google.BatchSize = 3
+	t.Cleanup(func() { google.BatchSize = batchSize })
+
+	api := &membersAPI{
+		t: t,
+		members: `{"members": [
+			{"email": "ada@example.com", "role": "MEMBER", "type": "USER"},
+			{"email": "bob@example.com", "role": "MEMBER", "type": "USER"},
+			{"email": "carol@example.com", "role": "OWNER", "type": "USER"},
+			{"id": "C0123", "role": "MEMBER", "type": "CUSTOMER"},
+			{"email": "", "role": "MEMBER", "type": "USER"}
+		]}`,
+		status: map[string]int{"erin@example.com": http.StatusConflict, "frank@example.com": http.StatusForbidden},
+	}
+	c := newServedClient(t, api)
+
+	desired := []string{"ADA@example.com", "dave@example.com", " erin@example.com", "team@example.com", "frank@example.com", "dave@example.com"}
+	changes, err := c.Groups().SetMembers("eng@example.com", desired)
+	if err != nil {
+		t.Fatalf("SetMembers() error = %v", err)
+	}
+
+	// 4 additions and 1 removal, in batches of 3; the owner, the customer and the member without an email are left alone
+	want := [][]string{
+		{"POST dave@example.com", "POST erin@example.com", "POST team@example.com"},
+		{"POST frank@example.com", "DELETE bob@example.com"},
+	}
+	if len(api.batches) != len(want) {
+		t.Fatalf("batches = %v, want %v", api.batches, want)
+	}
+	for i := range want {
+		if !slices.Equal(api.batches[i], want[i]) {
+			t.Errorf("batch %d = %v, want %v", i, api.batches[i], want[i])
+		}
+	}
+
+	if !slices.Equal(changes.Added, []string{"dave@example.com", "erin@example.com", "team@example.com"}) {
+		t.Errorf("Added = %v, want dave, erin (already a member) and team", changes.Added)
+	}
+	if !slices.Equal(changes.Removed, []string{"bob@example.com"}) {
+		t.Errorf("Removed = %v, want bob", changes.Removed)
+	}
+	if len(changes.Failed) != 1 || changes.Failed[0].Email != "frank@example.com" || changes.Failed[0].Action != "POST" {
+		t.Errorf("Failed = %v, want the addition of frank", changes.Failed)
+	}
+}
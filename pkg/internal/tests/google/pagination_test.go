@@ -0,0 +1,293 @@
+// pkg/internal/tests/google/pagination_test.go
+package google_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/log"
+	"github.com/gemini-oss/rego/pkg/common/ratelimit"
+	"github.com/gemini-oss/rego/pkg/common/requests"
+	"github.com/gemini-oss/rego/pkg/google"
+)
+
+// pages serves a list of two pages on a path: the first page answers without a pageToken, the second with `next`
+type pages struct {
+	t     *testing.T
+	path  string
+	first string
+	last  string
+	seen  []url.Values
+}
+
+func (p *pages) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != p.path {
+		p.t.Errorf("path = %s, want %s", r.URL.Path, p.path)
+	}
+	p.seen = append(p.seen, r.URL.Query())
+
+	w.Header().Set("Content-Type", "application/json")
+	if r.URL.Query().Get("pageToken") == "next" {
+		fmt.Fprint(w, p.last)
+		return
+	}
+	fmt.Fprint(w, p.first)
+}
+
+// redirect sends every request to the test server, keeping its path
+type redirect struct {
+	target *url.URL
+}
+
+func (rt redirect) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	r.URL.Scheme, r.URL.Host, r.Host = rt.target.Scheme, rt.target.Host, rt.target.Host
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+// newPagedClient returns a Google client whose requests are all answered by p
+func newPagedClient(t *testing.T, p *pages) *google.Client {
+	t.Helper()
+	return newServedClient(t, p)
+}
+
+// newServedClient returns a Google client whose requests are all answered by h
+func newServedClient(t *testing.T, h http.Handler) *google.Client {
+	t.Helper()
+	t.Setenv("REGO_ENCRYPTION_KEY", "32~Byte-long_passphrase-key-1234")
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	target, _ := url.Parse(srv.URL)
+
+	noRetry := requests.WithBackoff(func(int, error) (time.Duration, bool) { return 0, false })
+	headers := requests.Headers{"Accept": requests.JSON, "Content-Type": requests.JSON}
+	limiter := ratelimit.NewRateLimiter(1_000_000, time.Minute)
+
+	return &google.Client{
+		HTTP: requests.NewClient(&http.Client{Transport: redirect{target}}, headers, limiter, noRetry),
+		Log:  log.NewLogger("{test}", log.ERROR),
+	}
+}
+
+// checkPages verifies that both pages were requested, the second one with the token of the first
+func checkPages(t *testing.T, p *pages) {
+	t.Helper()
+
+	if len(p.seen) != 2 {
+		t.Fatalf("requested %d pages, want 2", len(p.seen))
+	}
+	if token := p.seen[0].Get("pageToken"); token != "" {
+		t.Errorf("first page pageToken = %q, want none", token)
+	}
+	if token := p.seen[1].Get("pageToken"); token != "next" {
+		t.Errorf("second page pageToken = %q, want %q", token, "next")
+	}
+}
+
+func TestListAllEventsPages(t *testing.T) {
+	p := &pages{
+		t:     t,
+		path:  "/calendar/v3/calendars/room@example.com/events",
+		first: `{"summary": "Room", "timeZone": "UTC", "items": [{"id": "e1"}], "nextPageToken": "next"}`,
+		last:  `{"summary": "Room", "timeZone": "UTC", "items": [{"id": "e2"}]}`,
+	}
+	c := newPagedClient(t, p)
+
+	events, err := c.Calendar().ListAllEvents("room@example.com", &google.CalendarEventQuery{SingleEvents: true})
+	if err != nil {
+		t.Fatalf("ListAllEvents() error = %v", err)
+	}
+
+	checkPages(t, p)
+	if got := p.seen[1].Get("singleEvents"); got != "true" {
+		t.Errorf("second page singleEvents = %q, want the query on every page", got)
+	}
+	if len(events.Items) != 2 || events.Items[0].ID != "e1" || events.Items[1].ID != "e2" {
+		t.Errorf("Items = %+v, want e1 and e2", events.Items)
+	}
+	if events.Summary != "Room" || events.TimeZone != "UTC" {
+		t.Errorf("Summary, TimeZone = %q, %q, want Room, UTC", events.Summary, events.TimeZone)
+	}
+}
+
+func TestListAllCalendarResourcesPages(t *testing.T) {
+	p := &pages{
+		t:     t,
+		path:  "/admin/directory/v1/customer/my_customer/resources/calendars",
+		first: `{"items": [{"resourceId": "r1"}], "nextPageToken": "next"}`,
+		last:  `{"items": [{"resourceId": "r2"}]}`,
+	}
+	c := newPagedClient(t, p)
+
+	resources, err := c.Calendar().ListAllCalendarResources(nil)
+	if err != nil {
+		t.Fatalf("ListAllCalendarResources() error = %v", err)
+	}
+
+	checkPages(t, p)
+	if len(resources.Items) != 2 || resources.Items[1].ResourceID != "r2" {
+		t.Errorf("Items = %+v, want r1 and r2", resources.Items)
+	}
+}
+
+func TestListSharedDrivesPages(t *testing.T) {
+	p := &pages{
+		t:     t,
+		path:  "/drive/v3/drives",
+		first: `{"drives": [{"id": "d1"}], "nextPageToken": "next"}`,
+		last:  `{"drives": [{"id": "d2"}]}`,
+	}
+	c := newPagedClient(t, p)
+
+	drives, err := c.Drive().ListSharedDrives(nil)
+	if err != nil {
+		t.Fatalf("ListSharedDrives() error = %v", err)
+	}
+
+	checkPages(t, p)
+	if got := p.seen[1].Get("useDomainAdminAccess"); got != "true" {
+		t.Errorf("second page useDomainAdminAccess = %q, want the query on every page", got)
+	}
+	if len(drives.Drives) != 2 || drives.Drives[1].ID != "d2" {
+		t.Errorf("Drives = %+v, want d1 and d2", drives.Drives)
+	}
+}
+
+func TestListSharedDrivePermissionsPages(t *testing.T) {
+	p := &pages{
+		t:     t,
+		path:  "/drive/v3/files/d1/permissions",
+		first: `{"permissions": [{"id": "p1"}], "nextPageToken": "next"}`,
+		last:  `{"permissions": [{"id": "p2"}]}`,
+	}
+	c := newPagedClient(t, p)
+
+	permissions, err := c.Drive().ListSharedDrivePermissions("d1")
+	if err != nil {
+		t.Fatalf("ListSharedDrivePermissions() error = %v", err)
+	}
+
+	checkPages(t, p)
+	if len(permissions.Permissions) != 2 || permissions.Permissions[1].ID != "p2" {
+		t.Errorf("Permissions = %+v, want p1 and p2", permissions.Permissions)
+	}
+}
+
+func TestListOrgUnitUsersPages(t *testing.T) {
+	p := &pages{
+		t:     t,
+		path:  "/admin/directory/v1/users",
+		first: `{"users": [{"primaryEmail": "ada@example.com", "orgUnitPath": "/Eng"}], "nextPageToken": "next"}`,
+		last:  `{"users": [{"primaryEmail": "grace@example.com", "orgUnitPath": "/Eng/Infra"}, {"primaryEmail": "alan@example.com", "orgUnitPath": "/eng"}]}`,
+	}
+	c := newPagedClient(t, p)
+
+	users, err := c.OrgUnits().ListOrgUnitUsers("/Eng")
+	if err != nil {
+		t.Fatalf("ListOrgUnitUsers() error = %v", err)
+	}
+
+	checkPages(t, p)
+	if got := p.seen[1].Get("query"); got != "orgUnitPath='/Eng'" {
+		t.Errorf("second page query = %q, want the query on every page", got)
+	}
+	if len(users.Users) != 2 || users.Users[1].PrimaryEmail != "alan@example.com" {
+		t.Errorf("Users = %+v, want ada and alan, without the users of child units", users.Users)
+	}
+}
+
+func TestListAllMobilePages(t *testing.T) {
+	p := &pages{
+		t:     t,
+		path:  "/admin/directory/v1/customer/my_customer/devices/mobile",
+		first: `{"mobiledevices": [{"resourceId": "m1"}], "nextPageToken": "next"}`,
+		last:  `{"mobiledevices": [{"resourceId": "m2"}]}`,
+	}
+	c := newPagedClient(t, p)
+
+	devices, err := c.Devices().ListAllMobile(nil, &google.MobileDeviceQuery{Query: "status:blocked"})
+	if err != nil {
+		t.Fatalf("ListAllMobile() error = %v", err)
+	}
+
+	checkPages(t, p)
+	if got := p.seen[1].Get("query"); got != "status:blocked" {
+		t.Errorf("second page query = %q, want the query on every page", got)
+	}
+	if len(devices.MobileDevices) != 2 || devices.MobileDevices[1].ResourceID != "m2" {
+		t.Errorf("MobileDevices = %+v, want m1 and m2", devices.MobileDevices)
+	}
+}
+
+func TestListAllCloudDevicesPages(t *testing.T) {
+	p := &pages{
+		t:     t,
+		path:  "/v1/devices",
+		first: `{"devices": [{"deviceId": "c1"}], "nextPageToken": "next"}`,
+		last:  `{"devices": [{"deviceId": "c2"}]}`,
+	}
+	c := newPagedClient(t, p)
+
+	devices, err := c.Devices().ListAllCloudDevices(&google.CloudDeviceQuery{View: "COMPANY_INVENTORY"})
+	if err != nil {
+		t.Fatalf("ListAllCloudDevices() error = %v", err)
+	}
+
+	checkPages(t, p)
+	if got := p.seen[1].Get("view"); got != "COMPANY_INVENTORY" {
+		t.Errorf("second page view = %q, want the query on every page", got)
+	}
+	if len(devices.Devices) != 2 || devices.Devices[1].DeviceID != "c2" {
+		t.Errorf("Devices = %+v, want c1 and c2", devices.Devices)
+	}
+}
+
+func TestListAllMembersPages(t *testing.T) {
+	p := &pages{
+		t:     t,
+		path:  "/admin/directory/v1/groups/eng@example.com/members",
+		first: `{"members": [{"email": "ada@example.com"}], "nextPageToken": "next"}`,
+		last:  `{"members": [{"email": "grace@example.com"}]}`,
+	}
+	c := newPagedClient(t, p)
+
+	members, err := c.Groups().ListAllMembers("eng@example.com")
+	if err != nil {
+		t.Fatalf("ListAllMembers() error = %v", err)
+	}
+
+	checkPages(t, p)
+	if len(members.Members) != 2 || members.Members[1].Email != "grace@example.com" {
+		t.Errorf("Members = %+v, want ada and grace", members.Members)
+	}
+}
+
+func TestStreamFilesStops(t *testing.T) {
+	p := &pages{
+		t:     t,
+		path:  "/drive/v3/files",
+		first: `{"files": [{"id": "f1"}], "nextPageToken": "next"}`,
+		last:  `{"files": [{"id": "f2"}]}`,
+	}
+	c := newPagedClient(t, p)
+
+	var streamed []string
+	err := c.Drive().StreamFiles(&google.DriveFileQuery{}, func(files []*google.File) error {
+		for _, file := range files {
+			streamed = append(streamed, file.ID)
+		}
+		return fmt.Errorf("stop")
+	})
+
+	if err == nil || err.Error() != "stop" {
+		t.Errorf("StreamFiles() error = %v, want the error of fn", err)
+	}
+	if len(p.seen) != 1 || len(streamed) != 1 || streamed[0] != "f1" {
+		t.Errorf("streamed %v over %d pages, want f1 over 1 page", streamed, len(p.seen))
+	}
+}
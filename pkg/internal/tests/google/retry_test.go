@@ -0,0 +1,101 @@
+// pkg/internal/tests/google/retry_test.go
+package google_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+	"github.com/gemini-oss/rego/pkg/google"
+)
+
+// googleError returns the body of a Google API error with the given reason, in the format of the Drive and Admin SDK APIs
+func googleError(code int, reason string) []byte {
+	return []byte(fmt.Sprintf(`{"error": {"code": %d, "message": "%s", "errors": [{"domain": "usageLimits", "reason": "%s"}]}}`, code, reason, reason))
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		retry bool
+		wait  time.Duration // Exact wait; 0 for the exponential backoff of the attempt
+	}{
+		{"403 userRateLimitExceeded", &requests.StatusError{StatusCode: http.StatusForbidden, Body: googleError(403, "userRateLimitExceeded")}, true, 0},
+		{"403 rateLimitExceeded", &requests.StatusError{StatusCode: http.StatusForbidden, Body: googleError(403, "rateLimitExceeded")}, true, 0},
+		{"403 RATE_LIMIT_EXCEEDED detail", &requests.StatusError{StatusCode: http.StatusForbidden, Body: []byte(`{"error": {"code": 403, "details": [{"reason": "RATE_LIMIT_EXCEEDED"}]}}`)}, true, 0},
+		{"403 forbidden", &requests.StatusError{StatusCode: http.StatusForbidden, Body: googleError(403, "forbidden")}, false, 0},
+		{"403 insufficientPermissions", &requests.StatusError{StatusCode: http.StatusForbidden, Body: googleError(403, "insufficientPermissions")}, false, 0},
+		{"403 dailyLimitExceeded", &requests.StatusError{StatusCode: http.StatusForbidden, Body: googleError(403, "dailyLimitExceeded")}, false, 0},
+		{"403 quotaExceeded", &requests.StatusError{StatusCode: http.StatusForbidden, Body: googleError(403, "quotaExceeded")}, false, 0},
+		{"403 without a body", &requests.StatusError{StatusCode: http.StatusForbidden}, false, 0},
+		{"404", &requests.StatusError{StatusCode: http.StatusNotFound, Body: googleError(404, "notFound")}, false, 0},
+		{"400", &requests.StatusError{StatusCode: http.StatusBadRequest, Body: googleError(400, "invalid")}, false, 0},
+		{"429", &requests.StatusError{StatusCode: http.StatusTooManyRequests}, true, 0},
+		{"500", &requests.StatusError{StatusCode: http.StatusInternalServerError}, true, 0},
+		{"502", &requests.StatusError{StatusCode: http.StatusBadGateway}, true, 0},
+		{"503", &requests.StatusError{StatusCode: http.StatusServiceUnavailable}, true, 0},
+		{"429 with Retry-After", &requests.StatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: 7 * time.Second}, true, 7 * time.Second},
+		{"503 with Retry-After", &requests.StatusError{StatusCode: http.StatusServiceUnavailable, RetryAfter: 45 * time.Second}, true, 45 * time.Second},
+		{"403 rate limit with Retry-After", &requests.StatusError{StatusCode: http.StatusForbidden, Body: googleError(403, "userRateLimitExceeded"), RetryAfter: 3 * time.Second}, true, 3 * time.Second},
+		{"404 with Retry-After", &requests.StatusError{StatusCode: http.StatusNotFound, RetryAfter: 3 * time.Second}, false, 0},
+		{"wrapped 500", fmt.Errorf("GET /admin/directory/v1/users: %w", &requests.StatusError{StatusCode: http.StatusInternalServerError}), true, 0},
+		{"network error", errors.New("connection reset by peer"), true, 0},
+	}
+
+	for _, tt := range tests {
+		for _, attempt := range []int{0, 2, 10} {
+			wait, retry := google.Backoff(attempt, tt.err)
+			if retry != tt.retry {
+				t.Errorf("Backoff(%d, %s) retry = %v, want %v", attempt, tt.name, retry, tt.retry)
+				continue
+			}
+			if !retry {
+				continue
+			}
+
+			if tt.wait > 0 {
+				if wait != tt.wait {
+					t.Errorf("Backoff(%d, %s) = %s, want the Retry-After of %s", attempt, tt.name, wait, tt.wait)
+				}
+				continue
+			}
+			low := min(time.Duration(1<<min(attempt, 5))*time.Second, google.MaxBackoff)
+			if wait < low || wait > min(low+time.Second, google.MaxBackoff) {
+				t.Errorf("Backoff(%d, %s) = %s, want %s plus up to 1s of jitter, up to %s", attempt, tt.name, wait, low, google.MaxBackoff)
+			}
+		}
+	}
+}
+
+func TestBackoffResponseTooLarge(t *testing.T) {
+	err := fmt.Errorf("GET /drive/v3/files: %w (1024 bytes)", requests.ErrResponseTooLarge)
+	if _, retry := google.Backoff(0, err); retry {
+		t.Error("Backoff() retries a response too large; want no retry")
+	}
+}
+
+func TestErrorReason(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"errors", string(googleError(403, "userRateLimitExceeded")), "userRateLimitExceeded"},
+		{"details", `{"error": {"code": 429, "status": "RESOURCE_EXHAUSTED", "details": [{"reason": "RATE_LIMIT_EXCEEDED"}]}}`, "RATE_LIMIT_EXCEEDED"},
+		{"first reason", `{"error": {"errors": [{"message": "no reason"}, {"reason": "dailyLimitExceeded"}], "details": [{"reason": "other"}]}}`, "dailyLimitExceeded"},
+		{"no reason", `{"error": {"code": 404, "message": "Not Found"}}`, ""},
+		{"no error", `{}`, ""},
+		{"HTML", `<html><body>Bad Gateway</body></html>`, ""},
+		{"empty", ``, ""},
+	}
+
+	for _, tt := range tests {
+		if got := google.ErrorReason([]byte(tt.body)); got != tt.want {
+			t.Errorf("ErrorReason(%s) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+/*
+# Google Workspace - Slides - Test
+
+This package tests the conversion of report structs to the sections of a slide deck:
+https://developers.google.com/slides/api/reference/rest
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC, see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/internal/tests/google/slides_test.go
+package google_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/google"
+)
+
+type offboarding struct {
+	Email string    `sheet:"Email"`
+	Date  time.Time `sheet:"Date"`
+	Steps []string  `json:"steps"`
+}
+
+type coverage struct {
+	Okta    int `slide:"ignored on nested fields"`
+	Jamf    int
+	Missing *int `json:"missing"`
+}
+
+type opsReview struct {
+	Devices    int                  `slide:"Managed Devices"`
+	Compliance float64              `json:"compliance"`
+	Offboards  []*offboarding       `slide:"Offboardings"`
+	Coverage   coverage             `slide:"Coverage"`
+	Trend      *google.SlideSection `slide:"Trend"`
+	Empty      *google.SlideSection
+	Internal   string `slide:"-"`
+	private    string
+}
+
+func TestToSlides(t *testing.T) {
+	date := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+	review := opsReview{
+		Devices:    120,
+		Compliance: 0.98,
+		Offboards:  []*offboarding{{Email: "ada@example.com", Date: date, Steps: []string{"okta", "google"}}},
+		Coverage:   coverage{Okta: 118, Jamf: 97},
+		Trend:      &google.SlideSection{SpreadsheetID: "sheet-1", ChartID: 7},
+		Internal:   "hidden",
+		private:    "hidden",
+	}
+
+	sections, err := google.ToSlides(&review)
+	if err != nil {
+		t.Fatalf("ToSlides() error = %v", err)
+	}
+
+	want := []*google.SlideSection{
+		{
+			Title:   "Summary",
+			Headers: []string{"Metric", "Value"},
+			Rows:    [][]string{{"Managed Devices", "120"}, {"compliance", "0.98"}},
+		},
+		{
+			Title:   "Offboardings",
+			Headers: []string{"Email", "Date", "steps"},
+			Rows:    [][]string{{"ada@example.com", "2024-07-01T09:00:00Z", "okta, google"}},
+		},
+		{
+			Title:   "Coverage",
+			Headers: []string{"Field", "Value"},
+			Rows:    [][]string{{"Okta", "118"}, {"Jamf", "97"}, {"missing", ""}},
+		},
+		{Title: "Trend", SpreadsheetID: "sheet-1", ChartID: 7},
+	}
+	if !reflect.DeepEqual(sections, want) {
+		for i, section := range sections {
+			t.Logf("section %d: %+v", i, *section)
+		}
+		t.Errorf("ToSlides() returned %d sections, want %d as above", len(sections), len(want))
+	}
+
+	if review.Trend.Title != "" {
+		t.Errorf("ToSlides() titled the section of the report: %q", review.Trend.Title)
+	}
+}
+
+func TestToSlidesErrors(t *testing.T) {
+	var nilReview *opsReview
+	for name, report := range map[string]interface{}{"nil": nilReview, "slice": []opsReview{}, "string": "report"} {
+		if _, err := google.ToSlides(report); err == nil {
+			t.Errorf("ToSlides(%s) error = nil, want an error", name)
+		}
+	}
+
+	// Without scalar fields, the deck has no summary slide
+	sections, err := google.ToSlides(struct {
+		Offboards []offboarding
+	}{})
+	if err != nil {
+		t.Fatalf("ToSlides() error = %v", err)
+	}
+	if len(sections) != 1 || sections[0].Title != "Offboards" || len(sections[0].Rows) != 0 {
+		t.Errorf("ToSlides() = %+v, want a single empty Offboards table", sections)
+	}
+}
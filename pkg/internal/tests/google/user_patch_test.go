@@ -0,0 +1,79 @@
+// pkg/internal/tests/google/user_patch_test.go
+package google_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/google"
+)
+
+func TestDiffUsers(t *testing.T) {
+	base := func() *google.User {
+		return &google.User{
+			ID:            "1",
+			PrimaryEmail:  "ada@example.com",
+			OrgUnitPath:   "/Eng",
+			Suspended:     true,
+			Name:          google.UserName{GivenName: "Ada", FamilyName: "Lovelace", FullName: "Ada Lovelace"},
+			Organizations: []google.Organization{{Department: "Engineering", CostCenter: "42"}},
+			Emails:        []google.Email{{Address: "ada@example.com", Primary: true}},
+			Aliases:       []string{"al@example.com"},
+			Password:      google.Password{Value: "old-secret"},
+		}
+	}
+
+	tests := []struct {
+		name string
+		edit func(u *google.User)
+		want string // JSON of the patch
+	}{
+		{"no change", func(u *google.User) {}, `{}`},
+		{"true to false", func(u *google.User) { u.Suspended = false }, `{"suspended":false}`},
+		{"false to true", func(u *google.User) { u.ChangePasswordAtNextLogin = true }, `{"changePasswordAtNextLogin":true}`},
+		{"string emptied", func(u *google.User) { u.OrgUnitPath = "" }, `{"orgUnitPath":""}`},
+		{"string changed", func(u *google.User) { u.OrgUnitPath = "/Eng/Infra" }, `{"orgUnitPath":"/Eng/Infra"}`},
+		{"list emptied", func(u *google.User) { u.Organizations = nil }, `{"organizations":null}`},
+		{"list emptied, not nil", func(u *google.User) { u.Organizations = []google.Organization{} }, `{"organizations":null}`},
+		{"list changed", func(u *google.User) { u.Organizations[0].CostCenter = "7" }, `{"organizations":[{"costCenter":"7","department":"Engineering"}]}`},
+		{"nested object partly changed", func(u *google.User) { u.Name.GivenName = "Augusta"; u.Name.FullName = "" }, `{"name":{"fullName":"","givenName":"Augusta"}}`},
+		{"nested object emptied", func(u *google.User) { u.Name = google.UserName{} }, `{"name":null}`},
+		{"password changed", func(u *google.User) { u.Password.Value = "new-secret" }, `{"password":"new-secret"}`},
+		{"password cleared", func(u *google.User) { u.Password.Value = "" }, `{}`},
+		{"read-only fields", func(u *google.User) {
+			u.ID = "2"
+			u.IsAdmin = true
+			u.Aliases = nil
+			u.LastLoginTime = "2024-01-01T00:00:00Z"
+			u.CustomerID = "C0"
+		}, `{}`},
+	}
+
+	for _, tt := range tests {
+		before, after := base(), base()
+		tt.edit(after)
+
+		patch := google.DiffUsers(before, after)
+		got, err := json.Marshal(patch)
+		if err != nil {
+			t.Fatalf("%s: json.Marshal() error = %v", tt.name, err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("DiffUsers(%s) = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestUserPatchString(t *testing.T) {
+	before := &google.User{Password: google.Password{Value: "old-secret"}}
+	after := &google.User{Password: google.Password{Value: "new-secret"}, Suspended: true}
+
+	patch := google.DiffUsers(before, after)
+	if got := patch.String(); got != "[password, suspended]" {
+		t.Errorf("String() = %s, want [password, suspended]", got)
+	}
+	if strings.Contains(patch.String(), "secret") {
+		t.Error("String() leaks the password")
+	}
+}
@@ -0,0 +1,125 @@
+// pkg/internal/tests/jamf/maintenance_test.go
+package jamf_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/cache"
+	"github.com/gemini-oss/rego/pkg/common/log"
+	"github.com/gemini-oss/rego/pkg/common/requests"
+	"github.com/gemini-oss/rego/pkg/jamf"
+)
+
+const maintenancePage = `<html><body>Jamf Pro is undergoing maintenance</body></html>`
+
+// newMaintenanceClient returns a Jamf client of a test server, which pauses for up to a second during maintenance windows
+func newMaintenanceClient(t *testing.T, serverURL string) *jamf.Client {
+	t.Helper()
+	t.Setenv("REGO_ENCRYPTION_KEY", "32~Byte-long_passphrase-key-1234")
+
+	noRetry := requests.WithBackoff(func(int, error) (time.Duration, bool) { return 0, false })
+	return &jamf.Client{
+		BaseURL:       serverURL + "/api",
+		HTTP:          requests.NewClient(nil, requests.Headers{"Accept": requests.JSON}, nil, noRetry),
+		Log:           log.NewLogger("{jamf}", log.ERROR),
+		ComputerCache: cache.NewTyped[*jamf.Computer](time.Minute, 0, 10),
+		Maintenance: &jamf.MaintenancePolicy{
+			WaitForHealthy: true,
+			PollInterval:   10 * time.Millisecond,
+			MaxWait:        time.Second,
+		},
+	}
+}
+
+func TestIsMaintenance(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"maintenance page", &requests.StatusError{StatusCode: http.StatusServiceUnavailable, Body: []byte(maintenancePage)}, true},
+		{"initializing", &requests.StatusError{StatusCode: http.StatusServiceUnavailable, Body: []byte("Initializing...")}, true},
+		{"wrapped", fmt.Errorf("page 1: %w", &requests.StatusError{StatusCode: http.StatusServiceUnavailable, Body: []byte(maintenancePage)}), true},
+		{"503 from the API", &requests.StatusError{StatusCode: http.StatusServiceUnavailable, Body: []byte(`{"errors": []}`)}, false},
+		{"500 page", &requests.StatusError{StatusCode: http.StatusInternalServerError, Body: []byte(maintenancePage)}, false},
+		{"network error", errors.New("connection refused"), false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		if got := jamf.IsMaintenance(tt.err); got != tt.want {
+			t.Errorf("IsMaintenance(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMaintenancePause(t *testing.T) {
+	var requestsSent, healthChecks atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthCheck.html":
+			// Initializing twice, then healthy
+			if healthChecks.Add(1) <= 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				fmt.Fprint(w, `[{"healthCode": 4, "httpCode": 503, "description": "Initializing"}]`)
+				return
+			}
+			fmt.Fprint(w, `[]`)
+		case "/api/v1/computers-inventory-detail/1":
+			if requestsSent.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				fmt.Fprint(w, maintenancePage)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"id": "1", "udid": "123"}`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newMaintenanceClient(t, server.URL)
+
+	computer, err := client.Devices().GetComputerDetails("1")
+	if err != nil {
+		t.Fatalf("GetComputerDetails() error = %v", err)
+	}
+	if computer.ID != "1" {
+		t.Errorf("GetComputerDetails() ID = %q, want 1", computer.ID)
+	}
+	if n := healthChecks.Load(); n != 3 {
+		t.Errorf("health checks = %d, want 3 (paused until healthy)", n)
+	}
+	if n := requestsSent.Load(); n != 2 {
+		t.Errorf("requests = %d, want 2 (retried once after the pause)", n)
+	}
+}
+
+func TestWaitUntilHealthyCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `[{"healthCode": 4, "httpCode": 503, "description": "Initializing"}]`)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client := newMaintenanceClient(t, server.URL).WithContext(ctx)
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	err := client.WaitUntilHealthy(time.Minute, time.Hour)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("WaitUntilHealthy() error = %v, want context.Canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("WaitUntilHealthy() returned after %s, want right after the cancellation", elapsed)
+	}
+}
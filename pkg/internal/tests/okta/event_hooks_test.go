@@ -0,0 +1,204 @@
+/*
+# Okta Event Hooks - Test
+
+This package tests the Okta event hook receiver: its authentication, the one-time verification challenge, and the
+cached memberships each delivery drops:
+https://developer.okta.com/docs/concepts/event-hooks/
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/internal/tests/okta/event_hooks_test.go
+package okta_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/cache"
+	"github.com/gemini-oss/rego/pkg/common/log"
+	"github.com/gemini-oss/rego/pkg/okta"
+)
+
+const hookSecret = "s3cr3t"
+
+// setupHookClient returns an Okta client with an in-memory cache holding the memberships of user u1 and group g1
+func setupHookClient(t *testing.T) *okta.Client {
+	t.Helper()
+
+	c, err := cache.NewCache([]byte("32~Byte-long_passphrase-key-1234"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &okta.Client{
+		BaseURL: "https://example.okta.com/api/v1",
+		Log:     log.NewLogger("{okta}", log.ERROR),
+		Cache:   c,
+	}
+
+	for _, key := range []string{
+		client.BuildURL(okta.OktaUsers, "u1", "groups"),
+		client.BuildURL(okta.OktaUsers, "u2", "groups"),
+		client.BuildURL(okta.OktaGroups),
+		client.BuildURL(okta.OktaGroups, "g1"),
+		client.BuildURL(okta.OktaGroups, "g1", "users"),
+	} {
+		c.Set(key, []byte(key), time.Minute) // Values are deduplicated, so each entry needs its own
+	}
+
+	return client
+}
+
+func cached(client *okta.Client, endpoint string, identifiers ...string) bool {
+	_, exists := client.Cache.Get(client.BuildURL(endpoint, identifiers...))
+	return exists
+}
+
+func TestEventHookAuthorization(t *testing.T) {
+	client := setupHookClient(t)
+
+	tests := []struct {
+		name   string
+		hook   *okta.EventHook
+		header string
+		value  string
+		want   int
+	}{
+		{"Valid secret", &okta.EventHook{Client: client, Secret: hookSecret}, "Authorization", hookSecret, http.StatusOK},
+		{"Wrong secret", &okta.EventHook{Client: client, Secret: hookSecret}, "Authorization", "guess", http.StatusUnauthorized},
+		{"Missing secret", &okta.EventHook{Client: client, Secret: hookSecret}, "", "", http.StatusUnauthorized},
+		{"Hook without a secret", &okta.EventHook{Client: client}, "Authorization", "", http.StatusUnauthorized},
+		{"Custom header", &okta.EventHook{Client: client, Secret: hookSecret, Header: "X-Hook-Secret"}, "X-Hook-Secret", hookSecret, http.StatusOK},
+		{"Custom header, secret in Authorization", &okta.EventHook{Client: client, Secret: hookSecret, Header: "X-Hook-Secret"}, "Authorization", hookSecret, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/hooks/okta", strings.NewReader(`{"data": {"events": []}}`))
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			rec := httptest.NewRecorder()
+
+			tt.hook.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("ServeHTTP() status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventHookVerification(t *testing.T) {
+	hook := &okta.EventHook{Client: setupHookClient(t), Secret: hookSecret}
+
+	req := httptest.NewRequest(http.MethodGet, "/hooks/okta", nil)
+	req.Header.Set("Authorization", hookSecret)
+	req.Header.Set(okta.EventHookVerificationHeader, "challenge-1")
+	rec := httptest.NewRecorder()
+
+	hook.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ServeHTTP() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if got := strings.TrimSpace(rec.Body.String()); got != `{"verification":"challenge-1"}` {
+		t.Errorf("ServeHTTP() body = %s, want the challenge echoed", got)
+	}
+
+	// Without the challenge header, the GET is not a verification
+	req = httptest.NewRequest(http.MethodGet, "/hooks/okta", nil)
+	req.Header.Set("Authorization", hookSecret)
+	rec = httptest.NewRecorder()
+
+	hook.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("ServeHTTP() without a challenge status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestEventHookDelivery(t *testing.T) {
+	client := setupHookClient(t)
+
+	seen := []string{}
+	hook := &okta.EventHook{Client: client, Secret: hookSecret, OnEvent: func(event *okta.LogEvent) {
+		seen = append(seen, event.EventType)
+	}}
+
+	body := `{"eventType": "com.okta.event_hook", "data": {"events": [
+		{"eventType": "group.user_membership.add", "target": [{"id": "u1", "type": "User"}, {"id": "g1", "type": "UserGroup"}]},
+		{"eventType": "user.session.start", "target": [{"id": "u2", "type": "User"}]}
+	]}}`
+	req := httptest.NewRequest(http.MethodPost, "/hooks/okta", strings.NewReader(body))
+	req.Header.Set("Authorization", hookSecret)
+	rec := httptest.NewRecorder()
+
+	hook.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ServeHTTP() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if len(seen) != 2 {
+		t.Errorf("OnEvent() called for %v, want both events", seen)
+	}
+
+	if cached(client, okta.OktaUsers, "u1", "groups") || cached(client, okta.OktaGroups, "g1", "users") {
+		t.Error("Expected the membership of u1 in g1 to be dropped")
+	}
+	if !cached(client, okta.OktaUsers, "u2", "groups") || !cached(client, okta.OktaGroups) {
+		t.Error("Expected the entries unrelated to the membership change to be kept")
+	}
+}
+
+func TestApplyEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   *okta.LogEvent
+		dropped [][]string // Endpoint and identifiers of the entries the event drops
+		kept    [][]string
+	}{
+		{
+			name: "Membership removed",
+			event: &okta.LogEvent{EventType: string(okta.EventGroupUserMembershipRemove), Target: []*okta.LogActor{
+				{ID: "u1", Type: "User"}, {ID: "g1", Type: "UserGroup"},
+			}},
+			dropped: [][]string{{okta.OktaUsers, "u1", "groups"}, {okta.OktaGroups, "g1", "users"}},
+			kept:    [][]string{{okta.OktaGroups}, {okta.OktaGroups, "g1"}, {okta.OktaUsers, "u2", "groups"}},
+		},
+		{
+			name: "Group deleted",
+			event: &okta.LogEvent{EventType: string(okta.EventGroupLifecycleDelete), Target: []*okta.LogActor{
+				{ID: "g1", Type: "UserGroup"},
+			}},
+			dropped: [][]string{{okta.OktaGroups}, {okta.OktaGroups, "g1"}, {okta.OktaGroups, "g1", "users"}},
+			kept:    [][]string{{okta.OktaUsers, "u1", "groups"}},
+		},
+		{
+			name: "Unrelated event",
+			event: &okta.LogEvent{EventType: "user.session.start", Target: []*okta.LogActor{
+				{ID: "u1", Type: "User"}, nil,
+			}},
+			kept: [][]string{{okta.OktaGroups}, {okta.OktaGroups, "g1", "users"}, {okta.OktaUsers, "u1", "groups"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := setupHookClient(t)
+			client.ApplyEvent(tt.event)
+
+			for _, entry := range tt.dropped {
+				if cached(client, entry[0], entry[1:]...) {
+					t.Errorf("Expected %v to be dropped", entry)
+				}
+			}
+			for _, entry := range tt.kept {
+				if !cached(client, entry[0], entry[1:]...) {
+					t.Errorf("Expected %v to be kept", entry)
+				}
+			}
+		})
+	}
+}
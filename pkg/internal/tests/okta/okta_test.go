@@ -17,6 +17,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/gemini-oss/rego/pkg/common/config"
 	"github.com/gemini-oss/rego/pkg/common/log"
 	"github.com/gemini-oss/rego/pkg/okta"
 )
@@ -42,3 +43,30 @@ func setupTestClient(serverURL string) *okta.Client {
 
 	return client
 }
+
+func TestNewClientEnvironment(t *testing.T) {
+	t.Setenv("REGO_ENVIRONMENT", "")
+	t.Setenv("REGO_ENCRYPTION_KEY", "32~Byte-long_passphrase-key-1234")
+	t.Setenv("OKTA_ORG_NAME", "gemini")
+	t.Setenv("OKTA_BASE_URL", "okta.com")
+	t.Setenv("OKTA_SANDBOX_BASE_URL", "oktapreview.com")
+	t.Setenv("OKTA_API_TOKEN", "production token")
+	t.Setenv("OKTA_SANDBOX_API_TOKEN", "sandbox token")
+
+	// Both clients are used side by side, each against its own org
+	sandbox := okta.NewClient(log.DEBUG, config.WithEnvironment("sandbox"))
+	prod := okta.NewClient(log.DEBUG)
+
+	if prod.BaseURL != "https://gemini.okta.com/api/v1" {
+		t.Errorf("production BaseURL = %s", prod.BaseURL)
+	}
+	if sandbox.BaseURL != "https://gemini.oktapreview.com/api/v1" {
+		t.Errorf("sandbox BaseURL = %s", sandbox.BaseURL)
+	}
+	if auth := prod.HTTP.Headers["Authorization"]; auth != "SSWS production token" {
+		t.Errorf("production Authorization = %s", auth)
+	}
+	if auth := sandbox.HTTP.Headers["Authorization"]; auth != "SSWS sandbox token" {
+		t.Errorf("sandbox Authorization = %s", auth)
+	}
+}
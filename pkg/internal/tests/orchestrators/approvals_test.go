@@ -0,0 +1,64 @@
+// pkg/internal/tests/orchestrators/approvals_test.go
+package orchestrators_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/orchestrators"
+)
+
+func TestNotifierGate(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "approvals.json")
+	request := &orchestrators.ApprovalRequest{
+		ID:        "laptop-42",
+		Approver:  "grace@example.com",
+		Requester: "ada@example.com",
+		Title:     "Laptop request",
+	}
+
+	notifier := &recorder{}
+	gate, err := orchestrators.NewNotifierGate(notifier, stateFile)
+	if err != nil {
+		t.Fatalf("NewNotifierGate() error = %v", err)
+	}
+	if decision, err := gate.Decide(request); err != nil || decision != orchestrators.ApprovalPending {
+		t.Fatalf("Decide() = %q, %v, want pending", decision, err)
+	}
+
+	// A restarted gate remembers the request, and does not ask the approver again
+	restarted, err := orchestrators.NewNotifierGate(notifier, stateFile)
+	if err != nil {
+		t.Fatalf("NewNotifierGate() error = %v", err)
+	}
+	if decision, err := restarted.Decide(request); err != nil || decision != orchestrators.ApprovalPending {
+		t.Errorf("Decide() after a restart = %q, %v, want pending", decision, err)
+	}
+	if len(notifier.messages) != 1 {
+		t.Errorf("approver was asked %d times, want once", len(notifier.messages))
+	}
+	if pending := restarted.Pending(); len(pending) != 1 || pending[0].ID != request.ID {
+		t.Errorf("Pending() after a restart = %v, want %s", pending, request.ID)
+	}
+
+	// Only the approver of the request may decide on it
+	for _, approver := range []string{"ada@example.com", ""} {
+		if err := restarted.Approve(request.ID, approver); err == nil {
+			t.Errorf("Approve(%q) succeeded, want an error for anyone but the approver", approver)
+		}
+	}
+	if err := restarted.Approve(request.ID, "Grace@Example.com"); err != nil {
+		t.Fatalf("Approve() by the approver error = %v", err)
+	}
+	if err := restarted.Deny(request.ID, "grace@example.com"); err == nil {
+		t.Error("Deny() of an approved request succeeded, want an error")
+	}
+
+	reloaded, err := orchestrators.NewNotifierGate(notifier, stateFile)
+	if err != nil {
+		t.Fatalf("NewNotifierGate() error = %v", err)
+	}
+	if decision, err := reloaded.Decide(request); err != nil || decision != orchestrators.ApprovalApproved {
+		t.Errorf("Decide() after the approval = %q, %v, want approved", decision, err)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+# Orchestrators - Break-Glass Monitoring - Test
+
+This package tests the alerts on break-glass account activity: a failed alert is retried by the next poll, and an event
+is alerted on once, even without a readable time.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/internal/tests/orchestrators/break_glass_test.go
+package orchestrators_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/orchestrators"
+)
+
+const (
+	breakGlassAccount = "breakglass@example.com"
+
+	oktaLogs        = oktaHost + "/api/v1/logs"
+	loginActivities = googleHost + "/admin/reports/v1/activity/users/" + breakGlassAccount + "/applications/login"
+)
+
+func TestBreakGlassAlertRetried(t *testing.T) {
+	api, srv := newFakeAPI(t)
+	published := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+	api.on("GET", oktaLogs, http.StatusOK, `[{
+		"uuid": "evt-1", "published": "`+published+`", "eventType": "user.session.start",
+		"actor": {"alternateId": "`+breakGlassAccount+`", "type": "User"}
+	}]`)
+	c := newClient(t, srv, "okta")
+
+	notifier := &recorder{err: errors.New("pager down")}
+	monitor, err := c.BreakGlassMonitor(&orchestrators.BreakGlassOptions{OktaAccounts: []string{breakGlassAccount}, Notifier: notifier})
+	if err != nil {
+		t.Fatalf("BreakGlassMonitor() error = %v", err)
+	}
+
+	if _, err := monitor.Poll(); err == nil {
+		t.Fatal("Poll() error = nil, want the failed notification")
+	}
+
+	// The pager is back: the event is alerted on again, then never more
+	notifier.err = nil
+	if events, err := monitor.Poll(); err != nil || len(events) != 1 {
+		t.Fatalf("Poll() = %d event(s), error %v; want the event again", len(events), err)
+	}
+	if events, err := monitor.Poll(); err != nil || len(events) != 0 {
+		t.Fatalf("Poll() = %d event(s), error %v; want none", len(events), err)
+	}
+	if len(notifier.messages) != 2 {
+		t.Errorf("notifier got %d messages, want the failed alert and its retry", len(notifier.messages))
+	}
+}
+
+func TestBreakGlassUnreadableTime(t *testing.T) {
+	api, srv := newFakeAPI(t)
+	api.on("GET", loginActivities, http.StatusOK, `{"items": [{
+		"id": {"time": "yesterday", "uniqueQualifier": "1", "applicationName": "login"},
+		"actor": {"email": "`+breakGlassAccount+`"},
+		"events": [{"name": "login_success"}]
+	}]}`)
+	c := newClient(t, srv, "google")
+
+	notifier := &recorder{}
+	monitor, err := c.BreakGlassMonitor(&orchestrators.BreakGlassOptions{
+		GoogleAccounts:     []string{breakGlassAccount},
+		GoogleApplications: []string{"login"},
+		Notifier:           notifier,
+	})
+	if err != nil {
+		t.Fatalf("BreakGlassMonitor() error = %v", err)
+	}
+
+	for poll := 1; poll <= 3; poll++ {
+		if _, err := monitor.Poll(); err != nil {
+			t.Fatalf("Poll() #%d error = %v", poll, err)
+		}
+	}
+	if len(notifier.messages) != 1 {
+		t.Errorf("notifier got %d messages, want a single alert", len(notifier.messages))
+	}
+}
@@ -0,0 +1,132 @@
+/*
+# Orchestrators - Device Compliance Sync - Test
+
+This package tests the Jamf compliance to Okta sync: the webhook secret, and the state seeded from Jamf and Okta after
+a restart.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/internal/tests/orchestrators/device_compliance_test.go
+package orchestrators_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/orchestrators"
+)
+
+const (
+	complianceGroup = "00g-noncompliant"
+	smartGroupID    = 7
+
+	smartGroupMembers = jamfHost + "/api/v2/computer-groups/smart-group-membership/7"
+	computerDetail    = jamfHost + "/api/v1/computers-inventory-detail/1"
+	groupUsers        = oktaHost + "/api/v1/groups/" + complianceGroup + "/users"
+	staleUser         = oktaHost + "/api/v1/users/bob@example.com"
+	staleMembership   = groupUsers + "/00u2"
+	adaMembership     = groupUsers + "/00u1"
+)
+
+// fakeCompliance serves a smart group holding Ada's computer, and an Okta group holding Ada and Bob, whose computer
+// became compliant while the sync was down
+func fakeCompliance(api *fakeAPI) {
+	api.on("GET", smartGroupMembers, http.StatusOK, `{"members": [1]}`)
+	api.on("GET", computerDetail, http.StatusOK, `{"id": "1", "userAndLocation": {"email": "Ada@example.com"}}`)
+	api.on("GET", groupUsers, http.StatusOK, `[
+		{"id": "00u1", "profile": {"login": "ada@example.com"}},
+		{"id": "00u2", "profile": {"login": "bob@example.com"}}
+	]`)
+	api.on("GET", oktaHost+"/api/v1/users/ada@example.com", http.StatusOK, `{"id": "00u1", "profile": {"login": "ada@example.com"}}`)
+	api.on("GET", staleUser, http.StatusOK, `{"id": "00u2", "profile": {"login": "bob@example.com"}}`)
+	api.on("DELETE", staleMembership, http.StatusNoContent, ``)
+	api.on("DELETE", adaMembership, http.StatusNoContent, ``)
+}
+
+// complianceWebhook takes Ada's computer out of the non-compliant smart group
+const complianceWebhook = `{
+	"webhook": {"id": 1, "name": "compliance", "webhookEvent": "SmartGroupComputerMembershipChange"},
+	"event": {"computer": true, "jssid": 7, "smartGroup": true, "groupAddedDevicesIds": [], "groupRemovedDevicesIds": [1]}
+}`
+
+func TestDeviceComplianceWebhookAuth(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret string // Secret of the sync
+		header string // Value of the Authorization header
+		want   int
+	}{
+		{name: "Valid secret", secret: "Basic amFtZjpzZWNyZXQ=", header: "Basic amFtZjpzZWNyZXQ=", want: http.StatusAccepted},
+		{name: "Missing header", secret: "Basic amFtZjpzZWNyZXQ=", want: http.StatusUnauthorized},
+		{name: "Wrong secret", secret: "Basic amFtZjpzZWNyZXQ=", header: "Basic Zm9yZ2VkOmZvcmdlZA==", want: http.StatusUnauthorized},
+		{name: "No secret configured", header: "Basic amFtZjpzZWNyZXQ=", want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, srv := newFakeAPI(t)
+			sync := newClient(t, srv, "jamf", "okta").DeviceComplianceSync(complianceGroup, smartGroupID)
+			sync.WebhookSecret = tt.secret
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/jamf/compliance", strings.NewReader(complianceWebhook))
+			if tt.header != "" {
+				req.Header.Set(orchestrators.ComplianceWebhookAuthHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			sync.HandleWebhook(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("HandleWebhook() = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeviceComplianceSeed(t *testing.T) {
+	api, srv := newFakeAPI(t)
+	fakeCompliance(api)
+	sync := newClient(t, srv, "jamf", "okta").DeviceComplianceSync(complianceGroup, smartGroupID)
+	sync.WebhookSecret = "secret"
+
+	start := time.Now()
+	if err := sync.Seed(start); err != nil {
+		t.Fatalf("Seed() error = %v", err)
+	}
+
+	// Nothing changes before the states settle
+	if err := sync.Reconcile(start); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(api.called("DELETE", staleMembership)) != 0 {
+		t.Error("Bob was removed before settling")
+	}
+
+	// Bob has no non-compliant computer left; Ada's computer is still in the smart group
+	if err := sync.Reconcile(start.Add(sync.CompliantAfter)); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(api.called("DELETE", staleMembership)) != 1 {
+		t.Error("Bob was not removed from the Okta group")
+	}
+	if len(api.called("DELETE", adaMembership)) != 0 || len(api.called("PUT", adaMembership)) != 0 {
+		t.Error("Ada's membership was changed, though Ada's computer is still non-compliant")
+	}
+
+	// Once Ada's computer leaves the smart group, Ada is removed too
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/jamf/compliance", strings.NewReader(complianceWebhook))
+	req.Header.Set(orchestrators.ComplianceWebhookAuthHeader, "secret")
+	sync.HandleWebhook(httptest.NewRecorder(), req)
+
+	if err := sync.Reconcile(time.Now().Add(sync.CompliantAfter)); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(api.called("DELETE", adaMembership)) != 1 {
+		t.Error("Ada was not removed from the Okta group")
+	}
+}
@@ -0,0 +1,135 @@
+/*
+# Orchestrators - External Sharing - Test
+
+This package tests the external sharing enforcement: the violations found in Drive files, shared drives and groups,
+their removal (applied, dry run, or with the flag switched off), and the resources which could not be checked.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/internal/tests/orchestrators/external_sharing_test.go
+package orchestrators_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/orchestrators"
+)
+
+const (
+	driveHost = "www.googleapis.com"
+
+	driveFiles       = driveHost + "/drive/v3/files"
+	drivePermission  = driveFiles + "/f1/permissions/p1"
+	sharedDrives     = driveHost + "/drive/v3/drives"
+	sharedDriveUsers = driveFiles + "/d1/permissions"
+	sharedDriveGrant = sharedDriveUsers + "/p5"
+	teamMembers      = googleGroups + "/team@example.com/members"
+	teamMember       = teamMembers + "/mallory@evil.com"
+	brokenMembers    = googleGroups + "/broken@example.com/members"
+)
+
+// fakeSharing serves a file, a shared drive and a group each shared with evil.com, and a group whose members cannot be listed
+func fakeSharing(api *fakeAPI) {
+	api.on("GET", driveFiles, http.StatusOK, `{"files": [{"id": "f1", "name": "Plan", "webViewLink": "https://docs.google.com/f1", "permissions": [
+		{"id": "p1", "type": "user", "role": "writer", "emailAddress": "eve@evil.com"},
+		{"id": "p2", "type": "user", "role": "reader", "emailAddress": "bob@partner.com"},
+		{"id": "p3", "type": "user", "role": "owner", "emailAddress": "ada@example.com"}
+	]}]}`)
+	api.on("GET", sharedDrives, http.StatusOK, `{"drives": [{"id": "d1", "name": "Finance"}]}`)
+	api.on("GET", sharedDriveUsers, http.StatusOK, `{"permissions": [{"id": "p5", "type": "domain", "role": "reader", "domain": "Evil.com"}]}`)
+	api.on("GET", googleGroups, http.StatusOK, `{"groups": [{"email": "team@example.com"}, {"email": "broken@example.com"}]}`)
+	api.on("GET", teamMembers, http.StatusOK, `{"members": [
+		{"email": "mallory@evil.com", "role": "MEMBER", "type": "USER"},
+		{"email": "carol@sub.partner.com", "role": "MEMBER", "type": "USER"},
+		{"id": "C01", "role": "MEMBER", "type": "CUSTOMER"}
+	]}`)
+	api.on("GET", brokenMembers, http.StatusInternalServerError, `{"error": {"code": 500, "message": "Backend Error"}}`)
+
+	api.on("DELETE", drivePermission, http.StatusNoContent, ``)
+	api.on("DELETE", sharedDriveGrant, http.StatusNoContent, ``)
+	api.on("DELETE", teamMember, http.StatusNoContent, ``)
+}
+
+func TestExternalSharingEnforcement(t *testing.T) {
+	removals := []string{drivePermission, sharedDriveGrant, teamMember}
+
+	tests := []struct {
+		name     string
+		remove   bool
+		dryRun   bool
+		disabled string // REGO_DISABLED_AUTOMATIONS
+		applied  bool
+	}{
+		{name: "Flag only"},
+		{name: "Remove", remove: true, applied: true},
+		{name: "Remove under dry run", remove: true, dryRun: true},
+		{name: "Remove with the flag switched off", remove: true, disabled: orchestrators.FlagExternalSharing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("REGO_DISABLED_AUTOMATIONS", tt.disabled)
+			api, srv := newFakeAPI(t)
+			fakeSharing(api)
+			c := newClient(t, srv, "google")
+			c.PartialResults = true
+
+			notifier := &recorder{}
+			report, err := c.ExternalSharingEnforcement(&orchestrators.ExternalSharingOptions{
+				Domains:   []string{"example.com"},
+				Allowlist: []string{"partner.com"},
+				Remove:    tt.remove,
+				DryRun:    tt.dryRun,
+				Notifier:  notifier,
+			})
+
+			// The broken group is reported, without stopping the other groups from being checked
+			var partial *orchestrators.PartialError
+			if !errors.As(err, &partial) {
+				t.Fatalf("ExternalSharingEnforcement() error = %v, want a partial error", err)
+			}
+			if len(partial.Sources) != 1 || partial.Sources[0].Source != "groups (broken@example.com)" {
+				t.Errorf("partial sources = %v, want the broken group only", partial.Sources)
+			}
+
+			if report.Resources != 3 {
+				t.Errorf("Resources = %d, want 3", report.Resources)
+			}
+			if report.Domains["evil.com"] != 3 || report.Domains["partner.com"] != 1 || report.Domains["sub.partner.com"] != 1 {
+				t.Errorf("Domains = %v, want evil.com 3, partner.com 1 and sub.partner.com 1", report.Domains)
+			}
+
+			want := map[string]string{
+				orchestrators.SharingSourceDrive:        "eve@evil.com",
+				orchestrators.SharingSourceSharedDrives: "Evil.com",
+				orchestrators.SharingSourceGroups:       "mallory@evil.com",
+			}
+			if len(report.Violations) != len(want) {
+				t.Fatalf("Violations = %d, want %d", len(report.Violations), len(want))
+			}
+			for _, violation := range report.Violations {
+				if want[violation.Source] != violation.Principal {
+					t.Errorf("%s violation of %s, want %s", violation.Source, violation.Principal, want[violation.Source])
+				}
+				if violation.Applied != tt.applied || violation.Err != nil {
+					t.Errorf("%s violation applied %t (err %v), want %t", violation.Source, violation.Applied, violation.Err, tt.applied)
+				}
+			}
+
+			for _, removal := range removals {
+				if got := len(api.called("DELETE", removal)); (got == 1) != tt.applied {
+					t.Errorf("DELETE %s called %d time(s), applied %t", removal, got, tt.applied)
+				}
+			}
+
+			if len(notifier.messages) != 1 {
+				t.Errorf("notifier got %d messages, want the report", len(notifier.messages))
+			}
+		})
+	}
+}
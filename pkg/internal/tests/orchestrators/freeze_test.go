@@ -0,0 +1,201 @@
+/*
+# Orchestrators - Freeze User - Test
+
+This package tests the freeze user action: the outcome of each service, the computers locked and their PIN, and a
+failed service not stopping the others.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/internal/tests/orchestrators/freeze_test.go
+package orchestrators_test
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/orchestrators"
+)
+
+const (
+	frozenUser = "ada@example.com"
+
+	googleUser     = googleHost + "/admin/directory/v1/users/" + frozenUser
+	oktaUser       = oktaHost + "/api/v1/users/" + frozenUser
+	oktaSessions   = oktaHost + "/api/v1/users/00u1/sessions"
+	oktaClients    = oktaHost + "/api/v1/users/00u1/clients"
+	oktaTokens     = oktaHost + "/api/v1/users/00u1/clients/c1/tokens"
+	oktaGrants     = oktaHost + "/api/v1/users/00u1/grants"
+	oktaExpiration = oktaHost + "/api/v1/users/00u1/lifecycle/expire_password"
+)
+
+// fakeFreeze serves a user known to Google and Okta, with one of the two Jamf computers assigned to them
+func fakeFreeze(api *fakeAPI) {
+	api.on("PUT", googleUser, http.StatusOK, `{"id": "g-1", "primaryEmail": "`+frozenUser+`", "suspended": true}`)
+
+	api.on("GET", oktaUser, http.StatusOK, `{"id": "00u1", "status": "ACTIVE", "profile": {"email": "`+frozenUser+`", "login": "`+frozenUser+`"}}`)
+	api.on("DELETE", oktaSessions, http.StatusNoContent, ``)
+	api.on("GET", oktaClients, http.StatusOK, `[{"client_id": "c1", "client_name": "Slack"}]`)
+	api.on("DELETE", oktaTokens, http.StatusNoContent, ``)
+	api.on("DELETE", oktaGrants, http.StatusNoContent, ``)
+	api.on("POST", oktaExpiration, http.StatusOK, `{"id": "00u1", "status": "PASSWORD_EXPIRED"}`)
+
+	api.on("GET", jamfComputers, http.StatusOK, `{"totalCount": 2, "results": [
+		{"id": "1", "general": {"name": "Ada's Mac", "managementId": "mgmt-1"}, "userAndLocation": {"email": "Ada@example.com"}},
+		{"id": "2", "general": {"name": "Bob's Mac", "managementId": "mgmt-2"}, "userAndLocation": {"email": "bob@example.com"}}
+	]}`)
+	api.on("POST", jamfCommands, http.StatusCreated, `[{"id": "cmd-1", "href": "/v2/mdm/commands/cmd-1"}]`)
+}
+
+func TestFreezeUser(t *testing.T) {
+	pin := regexp.MustCompile(`\(PIN (\d{6})\)`)
+
+	tests := []struct {
+		name     string
+		fake     func(api *fakeAPI)
+		opts     *orchestrators.FreezeOptions
+		outcomes map[string]string // Action of each service, or "failed"
+		wantErr  bool
+		check    func(t *testing.T, api *fakeAPI, outcomes map[string]*orchestrators.FreezeOutcome)
+	}{
+		{
+			name: "Every service is frozen",
+			fake: fakeFreeze,
+			opts: &orchestrators.FreezeOptions{ExpirePassword: true, LockDevices: true, LockPIN: "424242", LockMessage: "Call IT"},
+			outcomes: map[string]string{
+				"Google": "suspended",
+				"Okta":   "revoked sessions",
+				"Jamf":   "locked devices",
+			},
+			check: func(t *testing.T, api *fakeAPI, outcomes map[string]*orchestrators.FreezeOutcome) {
+				if detail := outcomes["Okta"].Detail; detail != "sessions=true clients=1 grants=true password expired=true" {
+					t.Errorf("Okta detail = %q", detail)
+				}
+				if detail := outcomes["Jamf"].Detail; detail != "Ada's Mac (PIN 424242)" {
+					t.Errorf("Jamf detail = %q, want only the computer assigned to the user", detail)
+				}
+				commands := api.called("POST", jamfCommands)
+				if len(commands) != 1 || !strings.Contains(commands[0], "mgmt-1") || strings.Contains(commands[0], "mgmt-2") || !strings.Contains(commands[0], "Call IT") {
+					t.Errorf("MDM commands = %v, want one DEVICE_LOCK of mgmt-1", commands)
+				}
+			},
+		},
+		{
+			name: "Lock PIN is generated",
+			fake: fakeFreeze,
+			opts: &orchestrators.FreezeOptions{LockDevices: true},
+			outcomes: map[string]string{
+				"Google": "suspended",
+				"Okta":   "revoked sessions",
+				"Jamf":   "locked devices",
+			},
+			check: func(t *testing.T, api *fakeAPI, outcomes map[string]*orchestrators.FreezeOutcome) {
+				match := pin.FindStringSubmatch(outcomes["Jamf"].Detail)
+				if match == nil {
+					t.Fatalf("Jamf detail = %q, want the generated PIN", outcomes["Jamf"].Detail)
+				}
+				if commands := api.called("POST", jamfCommands); len(commands) != 1 || !strings.Contains(commands[0], match[1]) {
+					t.Errorf("MDM commands = %v, want the PIN %s", commands, match[1])
+				}
+				if len(api.called("POST", oktaExpiration)) != 0 {
+					t.Error("password expired without ExpirePassword")
+				}
+			},
+		},
+		{
+			name: "Computers are only locked when asked",
+			fake: fakeFreeze,
+			opts: nil,
+			outcomes: map[string]string{
+				"Google": "suspended",
+				"Okta":   "revoked sessions",
+			},
+			check: func(t *testing.T, api *fakeAPI, outcomes map[string]*orchestrators.FreezeOutcome) {
+				if len(api.called("GET", jamfComputers)) != 0 || len(api.called("POST", jamfCommands)) != 0 {
+					t.Error("Jamf called without LockDevices")
+				}
+			},
+		},
+		{
+			name: "User without computers",
+			fake: func(api *fakeAPI) {
+				fakeFreeze(api)
+				api.on("GET", jamfComputers, http.StatusOK, `{"totalCount": 0, "results": []}`)
+			},
+			opts: &orchestrators.FreezeOptions{LockDevices: true},
+			outcomes: map[string]string{
+				"Google": "suspended",
+				"Okta":   "revoked sessions",
+				"Jamf":   "lock devices",
+			},
+			check: func(t *testing.T, api *fakeAPI, outcomes map[string]*orchestrators.FreezeOutcome) {
+				if detail := outcomes["Jamf"].Detail; detail != "no computers assigned" {
+					t.Errorf("Jamf detail = %q", detail)
+				}
+				if len(api.called("POST", jamfCommands)) != 0 {
+					t.Error("MDM command sent without computers")
+				}
+			},
+		},
+		{
+			name: "Failed service does not stop the others",
+			fake: func(api *fakeAPI) {
+				fakeFreeze(api)
+				api.on("GET", oktaUser, http.StatusNotFound, `{"errorCode": "E0000007", "errorSummary": "Not found: Resource not found: ada@example.com (User)"}`)
+			},
+			opts: &orchestrators.FreezeOptions{LockDevices: true, LockPIN: "424242"},
+			outcomes: map[string]string{
+				"Google": "suspended",
+				"Okta":   "failed",
+				"Jamf":   "locked devices",
+			},
+			wantErr: true,
+			check: func(t *testing.T, api *fakeAPI, outcomes map[string]*orchestrators.FreezeOutcome) {
+				if len(api.called("DELETE", oktaSessions)) != 0 {
+					t.Error("sessions revoked for an unknown user")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api, srv := newFakeAPI(t)
+			tt.fake(api)
+			c := newClient(t, srv, "google", "okta", "jamf")
+
+			outcomes, err := c.FreezeUser(" Ada@Example.com ", tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FreezeUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			byService := map[string]*orchestrators.FreezeOutcome{}
+			for _, outcome := range outcomes {
+				byService[outcome.Service] = outcome
+			}
+			if len(byService) != len(tt.outcomes) {
+				t.Fatalf("FreezeUser() returned %d outcomes, want %d", len(byService), len(tt.outcomes))
+			}
+			for service, want := range tt.outcomes {
+				outcome, ok := byService[service]
+				if !ok {
+					t.Errorf("no %s outcome", service)
+					continue
+				}
+				got := outcome.Action
+				if outcome.Err != nil {
+					got = "failed"
+				}
+				if got != want {
+					t.Errorf("%s = %s, want %s (err %v)", service, got, want, outcome.Err)
+				}
+			}
+
+			tt.check(t, api, byService)
+		})
+	}
+}
@@ -0,0 +1,241 @@
+/*
+# Orchestrators - Group Cleanup - Test
+
+This package tests the stale group cleanup: dry runs, the group-cleanup flag, groups inactive in the audit logs, and
+the staged workflow kept in the state file across runs (notify, archive, delete, or recover).
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/internal/tests/orchestrators/group_cleanup_test.go
+package orchestrators_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/orchestrators"
+)
+
+const (
+	staleGroup = "old@example.com"
+
+	googleGroups   = googleHost + "/admin/directory/v1/groups"
+	groupMembers   = googleGroups + "/" + staleGroup + "/members"
+	groupSettings  = "www.googleapis.com/groups/v1/groups/" + staleGroup
+	groupDirectory = googleGroups + "/" + staleGroup
+)
+
+// fakeStaleGroup serves a Google group without owners, with as many members as `members` holds; the only member is an owner
+func fakeStaleGroup(api *fakeAPI, members *atomic.Int32) {
+	api.handle("GET", googleGroups, func(*http.Request, string) (int, string) {
+		count := strconv.Itoa(int(members.Load()))
+		return http.StatusOK, `{"groups": [{"email": "` + staleGroup + `", "name": "Old", "directMembersCount": "` + count + `"}]}`
+	})
+	api.on("GET", groupMembers, http.StatusOK, `{"members": [{"email": "ada@example.com", "role": "OWNER"}]}`)
+	api.on("PATCH", groupSettings, http.StatusOK, `{"email": "`+staleGroup+`", "archiveOnly": "true"}`)
+	api.on("DELETE", groupDirectory, http.StatusNoContent, ``)
+}
+
+// cleanupOptions leaves out the inactivity reason, so the runs never read the audit logs
+func cleanupOptions(t *testing.T, admins *recorder) *orchestrators.GroupCleanupOptions {
+	return &orchestrators.GroupCleanupOptions{
+		Providers:    []string{orchestrators.GroupProviderGoogle},
+		Reasons:      []string{orchestrators.StaleNoOwners, orchestrators.StaleNoMembers},
+		ArchiveAfter: time.Nanosecond,
+		DeleteAfter:  time.Nanosecond,
+		Admins:       admins,
+		StateFile:    filepath.Join(t.TempDir(), "group_cleanup.json"),
+	}
+}
+
+// runCleanup loads the workflow from the state file of opts, as a restart would, and runs it once
+func runCleanup(t *testing.T, c *orchestrators.Client, opts *orchestrators.GroupCleanupOptions) (*orchestrators.GroupCleanup, []*orchestrators.GroupCleanupAction) {
+	t.Helper()
+
+	cleanup, err := c.GroupCleanup(opts)
+	if err != nil {
+		t.Fatalf("GroupCleanup() error = %v", err)
+	}
+	actions, err := cleanup.Run()
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	return cleanup, actions
+}
+
+// wantAction checks that a run took (or planned) a single action
+func wantAction(t *testing.T, actions []*orchestrators.GroupCleanupAction, action string, applied bool) {
+	t.Helper()
+
+	if len(actions) != 1 {
+		t.Fatalf("Run() returned %d actions, want 1", len(actions))
+	}
+	if got := actions[0]; got.Action != action || got.Applied != applied || got.Group.ID != staleGroup {
+		t.Errorf("action = %s %s (applied %t), want %s %s (applied %t)", got.Group.ID, got.Action, got.Applied, staleGroup, action, applied)
+	}
+}
+
+func TestGroupCleanupDryRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		dryRun   bool
+		disabled string // REGO_DISABLED_AUTOMATIONS
+	}{
+		{name: "Dry run option", dryRun: true},
+		{name: "Flag switched off", disabled: orchestrators.FlagGroupCleanup},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("REGO_DISABLED_AUTOMATIONS", tt.disabled)
+			api, srv := newFakeAPI(t)
+			fakeStaleGroup(api, &atomic.Int32{})
+			c := newClient(t, srv, "google")
+
+			admins := &recorder{}
+			opts := cleanupOptions(t, admins)
+			opts.DryRun = tt.dryRun
+
+			cleanup, actions := runCleanup(t, c, opts)
+			wantAction(t, actions, orchestrators.CleanupNotified, false)
+
+			if len(cleanup.Groups()) != 0 {
+				t.Errorf("Groups() = %d, want none after a dry run", len(cleanup.Groups()))
+			}
+			if _, err := os.Stat(opts.StateFile); !errors.Is(err, os.ErrNotExist) {
+				t.Errorf("state file written by a dry run: %v", err)
+			}
+			if len(admins.messages) != 1 || !strings.HasSuffix(admins.messages[0].Title, "[dry run]") {
+				t.Errorf("admin messages = %v, want only the dry run summary", admins.messages)
+			}
+
+			// The next run plans the same action, as nothing was recorded
+			_, actions = runCleanup(t, c, opts)
+			wantAction(t, actions, orchestrators.CleanupNotified, false)
+		})
+	}
+}
+
+func TestGroupCleanupWorkflow(t *testing.T) {
+	api, srv := newFakeAPI(t)
+	fakeStaleGroup(api, &atomic.Int32{})
+	c := newClient(t, srv, "google")
+
+	admins := &recorder{}
+	opts := cleanupOptions(t, admins)
+
+	// Newly stale: the administrators are told, as the group has no owners
+	_, actions := runCleanup(t, c, opts)
+	wantAction(t, actions, orchestrators.CleanupNotified, true)
+	if len(admins.messages) != 2 || !strings.Contains(admins.messages[0].Title, "will be archived") {
+		t.Errorf("admin messages = %v, want the notice then the summary", admins.messages)
+	}
+
+	data, err := os.ReadFile(opts.StateFile)
+	if err != nil {
+		t.Fatalf("reading the state file: %v", err)
+	}
+	states := []*orchestrators.GroupCleanupState{}
+	if err := json.Unmarshal(data, &states); err != nil {
+		t.Fatalf("state file %s: %v", data, err)
+	}
+	if len(states) != 1 || states[0].Stage != orchestrators.CleanupNotified || len(states[0].Reasons) != 2 {
+		t.Fatalf("state file = %s, want the group notified for two reasons", data)
+	}
+
+	// Restarted from the state file, and past ArchiveAfter
+	cleanup, actions := runCleanup(t, c, opts)
+	wantAction(t, actions, orchestrators.CleanupArchived, true)
+	if settings := api.called("PATCH", groupSettings); len(settings) != 1 || !strings.Contains(settings[0], `"archiveOnly":"true"`) {
+		t.Errorf("group settings = %v, want one archive-only update", settings)
+	}
+	if groups := cleanup.Groups(); len(groups) != 1 || groups[0].Stage != orchestrators.CleanupArchived {
+		t.Errorf("Groups() = %v, want the group archived", groups)
+	}
+
+	// Restarted again, and past DeleteAfter
+	cleanup, actions = runCleanup(t, c, opts)
+	wantAction(t, actions, orchestrators.CleanupDeleted, true)
+	if len(api.called("DELETE", groupDirectory)) != 1 {
+		t.Error("group was not deleted")
+	}
+	if len(cleanup.Groups()) != 0 {
+		t.Errorf("Groups() = %v, want none once deleted", cleanup.Groups())
+	}
+
+	reloaded, err := c.GroupCleanup(opts)
+	if err != nil {
+		t.Fatalf("GroupCleanup() error = %v", err)
+	}
+	if len(reloaded.Groups()) != 0 {
+		t.Errorf("state file still holds %v", reloaded.Groups())
+	}
+}
+
+func TestGroupCleanupRecovered(t *testing.T) {
+	api, srv := newFakeAPI(t)
+	members := &atomic.Int32{}
+	fakeStaleGroup(api, members)
+	c := newClient(t, srv, "google")
+
+	opts := cleanupOptions(t, &recorder{})
+	opts.ArchiveAfter = time.Hour
+
+	_, actions := runCleanup(t, c, opts)
+	wantAction(t, actions, orchestrators.CleanupNotified, true)
+
+	// An owner joined before the group was archived
+	members.Store(1)
+	cleanup, actions := runCleanup(t, c, opts)
+	wantAction(t, actions, orchestrators.CleanupRecovered, true)
+	if len(cleanup.Groups()) != 0 {
+		t.Errorf("Groups() = %v, want none once recovered", cleanup.Groups())
+	}
+	if len(api.called("PATCH", groupSettings)) != 0 {
+		t.Error("recovered group was archived")
+	}
+}
+
+func TestGroupCleanupInactive(t *testing.T) {
+	api, srv := newFakeAPI(t)
+	api.on("GET", googleGroups, http.StatusOK, `{"groups": [
+		{"email": "`+staleGroup+`", "name": "Old", "directMembersCount": "0"},
+		{"email": "active@example.com", "name": "Active", "directMembersCount": "0"}
+	]}`)
+
+	// Every daily window answers the same activity, which must only be counted once
+	windows := []string{}
+	activity := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	api.handle("GET", googleHost+"/admin/reports/v1/activity/users/all/applications/groups", func(r *http.Request, _ string) (int, string) {
+		windows = append(windows, r.URL.Query().Get("startTime")+"/"+r.URL.Query().Get("endTime"))
+		return http.StatusOK, `{"items": [{
+			"id": {"time": "` + activity + `", "uniqueQualifier": "1", "applicationName": "groups"},
+			"events": [{"name": "add_user", "parameters": [{"name": "group_email", "value": "Active@example.com"}]}]
+		}]}`
+	})
+	c := newClient(t, srv, "google")
+
+	opts := cleanupOptions(t, &recorder{})
+	opts.Reasons = []string{orchestrators.StaleInactive}
+	opts.InactiveDays = 2
+
+	_, actions := runCleanup(t, c, opts)
+	wantAction(t, actions, orchestrators.CleanupNotified, true)
+	if reasons := actions[0].Group.Reasons; len(reasons) != 1 || reasons[0] != orchestrators.StaleInactive {
+		t.Errorf("reasons = %v, want inactive", reasons)
+	}
+	if len(windows) != 2 || windows[0] == windows[1] {
+		t.Errorf("activity windows = %v, want two daily windows", windows)
+	}
+}
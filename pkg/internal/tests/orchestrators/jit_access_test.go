@@ -0,0 +1,187 @@
+/*
+# Orchestrators - Just-in-Time Access - Test
+
+This package tests the just-in-time access grants: extending an active grant, refusing standing access, the jit-access
+flag, and retrying a failed revocation after a restart.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/internal/tests/orchestrators/jit_access_test.go
+package orchestrators_test
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/flags"
+	"github.com/gemini-oss/rego/pkg/orchestrators"
+)
+
+const (
+	jitGroup   = "oncall@example.com"
+	jitUser    = "ada@example.com"
+	jitMembers = googleGroups + "/" + jitGroup + "/members"
+	jitMember  = jitMembers + "/" + jitUser
+)
+
+// jitRequest asks for a membership of the on-call group
+func jitRequest(duration time.Duration) *orchestrators.JITAccessRequest {
+	return &orchestrators.JITAccessRequest{
+		Kind:      orchestrators.JITGoogleGroup,
+		Target:    jitGroup,
+		User:      jitUser,
+		Duration:  duration,
+		Reason:    "INC-1",
+		GrantedBy: "bob@example.com",
+	}
+}
+
+func TestJITAccessExtend(t *testing.T) {
+	api, srv := newFakeAPI(t)
+	api.on("POST", jitMembers, http.StatusOK, `{"email": "`+jitUser+`", "role": "MEMBER"}`)
+	c := newClient(t, srv, "google")
+
+	notifier := &recorder{}
+	jit, err := c.JITAccess(&orchestrators.JITAccessOptions{Notifier: notifier})
+	if err != nil {
+		t.Fatalf("JITAccess() error = %v", err)
+	}
+
+	grant, err := jit.Grant(jitRequest(time.Hour))
+	if err != nil {
+		t.Fatalf("Grant() error = %v", err)
+	}
+	expires := grant.Expires
+
+	// Asking again extends the grant, without adding the member twice
+	extended, err := jit.Grant(jitRequest(3 * time.Hour))
+	if err != nil {
+		t.Fatalf("Grant() again error = %v", err)
+	}
+	if extended != grant || !grant.Expires.After(expires.Add(time.Hour)) {
+		t.Errorf("grant expires %s, want extended past %s", grant.Expires, expires.Add(time.Hour))
+	}
+
+	// A shorter request never shortens the grant
+	extendedTo := grant.Expires
+	if _, err := jit.Grant(jitRequest(time.Minute)); err != nil {
+		t.Fatalf("Grant() shorter error = %v", err)
+	}
+	if !grant.Expires.Equal(extendedTo) {
+		t.Errorf("grant expires %s, want still %s", grant.Expires, extendedTo)
+	}
+
+	if adds := api.called("POST", jitMembers); len(adds) != 1 {
+		t.Errorf("members added %d times, want once", len(adds))
+	}
+	if active := jit.ActiveGrants(); len(active) != 1 {
+		t.Errorf("ActiveGrants() = %d, want 1", len(active))
+	}
+	if len(notifier.messages) != 1 {
+		t.Errorf("notifier got %d messages, want the grant only", len(notifier.messages))
+	}
+}
+
+func TestJITAccessRefused(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int    // Status of adding the member
+		disabled string // REGO_DISABLED_AUTOMATIONS
+		adds     int    // Members added
+	}{
+		{name: "Standing access", status: http.StatusConflict, adds: 1},
+		{name: "Flag switched off", status: http.StatusOK, disabled: orchestrators.FlagJITAccess},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("REGO_DISABLED_AUTOMATIONS", tt.disabled)
+			api, srv := newFakeAPI(t)
+			api.on("POST", jitMembers, tt.status, `{"email": "`+jitUser+`", "role": "MEMBER"}`)
+			c := newClient(t, srv, "google")
+
+			jit, err := c.JITAccess(nil)
+			if err != nil {
+				t.Fatalf("JITAccess() error = %v", err)
+			}
+
+			grant, err := jit.Grant(jitRequest(time.Hour))
+			if err == nil {
+				t.Fatalf("Grant() = %+v, want an error", grant)
+			}
+			if disabled := errors.Is(err, flags.ErrDisabled); disabled != (tt.disabled != "") {
+				t.Errorf("Grant() error = %v, disabled %t", err, disabled)
+			}
+
+			if got := len(api.called("POST", jitMembers)); got != tt.adds {
+				t.Errorf("members added %d times, want %d", got, tt.adds)
+			}
+			if active := jit.ActiveGrants(); len(active) != 0 {
+				t.Errorf("ActiveGrants() = %v, want none", active)
+			}
+		})
+	}
+}
+
+func TestJITAccessRevokeRetry(t *testing.T) {
+	api, srv := newFakeAPI(t)
+	api.on("POST", jitMembers, http.StatusOK, `{"email": "`+jitUser+`", "role": "MEMBER"}`)
+
+	// The first removal fails; by the retry, the member was already removed by hand
+	removals := &atomic.Int32{}
+	api.handle("DELETE", jitMember, func(*http.Request, string) (int, string) {
+		if removals.Add(1) == 1 {
+			return http.StatusInternalServerError, `{"error": {"code": 500, "message": "Backend Error"}}`
+		}
+		return http.StatusNotFound, `{"error": {"code": 404, "message": "Resource Not Found: memberKey"}}`
+	})
+	c := newClient(t, srv, "google")
+
+	opts := &orchestrators.JITAccessOptions{StateFile: filepath.Join(t.TempDir(), "jit.json")}
+	jit, err := c.JITAccess(opts)
+	if err != nil {
+		t.Fatalf("JITAccess() error = %v", err)
+	}
+	if _, err := jit.Grant(jitRequest(time.Nanosecond)); err != nil {
+		t.Fatalf("Grant() error = %v", err)
+	}
+
+	revoked, err := jit.RevokeExpired()
+	if err == nil {
+		t.Fatalf("RevokeExpired() = %v, want the failed revocation", revoked)
+	}
+	active := jit.ActiveGrants()
+	if len(active) != 1 || active[0].LastError == "" {
+		t.Fatalf("ActiveGrants() = %+v, want the grant still active with its error", active)
+	}
+
+	// Restarted from the state file
+	jit, err = c.JITAccess(opts)
+	if err != nil {
+		t.Fatalf("JITAccess() reload error = %v", err)
+	}
+	if active := jit.ActiveGrants(); len(active) != 1 || active[0].LastError == "" {
+		t.Fatalf("reloaded ActiveGrants() = %+v, want the failed grant", active)
+	}
+
+	revoked, err = jit.RevokeExpired()
+	if err != nil {
+		t.Fatalf("RevokeExpired() retry error = %v", err)
+	}
+	if len(revoked) != 1 || revoked[0].Active() || revoked[0].LastError != "" {
+		t.Errorf("RevokeExpired() = %+v, want the grant revoked without error", revoked)
+	}
+	if removals.Load() != 2 {
+		t.Errorf("member removed %d times, want 2", removals.Load())
+	}
+	if active := jit.ActiveGrants(); len(active) != 0 {
+		t.Errorf("ActiveGrants() = %v, want none", active)
+	}
+}
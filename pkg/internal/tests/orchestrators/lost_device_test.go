@@ -0,0 +1,278 @@
+/*
+# Orchestrators - Lost Device - Test
+
+This package tests the lost/stolen device action: which steps run, are skipped or fail, the rollback recorded for each
+step, and what reaches the security notification.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/internal/tests/orchestrators/lost_device_test.go
+package orchestrators_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/orchestrators"
+	"github.com/gemini-oss/rego/pkg/snipeit"
+)
+
+const (
+	lostSerial = "C02XYZ"
+	lostPIN    = "424242"
+
+	jamfComputers = jamfHost + "/api/v1/computers-inventory"
+	jamfCommands  = jamfHost + "/api/v2/mdm/commands"
+	chromeOS      = googleHost + "/admin/directory/v1/customer/my_customer/devices/chromeos"
+	assetBySerial = snipeitHost + "/api/v1/hardware/byserial/" + lostSerial
+	assetUpdate   = snipeitHost + "/api/v1/hardware/7"
+	statusLabels  = snipeitHost + "/api/v1/statuslabels"
+	oktaDevices   = oktaHost + "/api/v1/devices"
+	oktaSuspend   = oktaHost + "/api/v1/devices/dev-1/lifecycle/suspend"
+)
+
+// fakeLostMac serves a Mac known to Jamf, Snipe-IT and Okta, but not to Google
+func fakeLostMac(api *fakeAPI) {
+	api.on("GET", jamfComputers, http.StatusOK, `{"totalCount": 1, "results": [{
+		"id": "1",
+		"general": {"name": "Ada's Mac", "managementId": "mgmt-1"},
+		"hardware": {"serialNumber": "`+lostSerial+`"},
+		"certificates": [{"commonName": "ada@example.com", "serialNumber": "0A1B", "identity": true}]
+	}]}`)
+	api.on("POST", jamfCommands, http.StatusCreated, `[{"id": "cmd-1", "href": "/v2/mdm/commands/cmd-1"}]`)
+	api.on("GET", chromeOS, http.StatusOK, `{"chromeosdevices": []}`)
+	api.on("GET", assetBySerial, http.StatusOK, `{"total": 1, "rows": [{"id": 7, "asset_tag": "A-7", "status_label": {"id": 1, "name": "Deployed"}}]}`)
+	api.on("GET", statusLabels, http.StatusOK, `{"total": 2, "rows": [{"id": 1, "name": "Deployed"}, {"id": 3, "name": "Stolen"}]}`)
+	api.on("PATCH", assetUpdate, http.StatusOK, `{"status": "success", "payload": {"id": 7, "asset_tag": "A-7"}}`)
+	api.on("GET", oktaDevices, http.StatusOK, `[{"id": "dev-1", "profile": {"serialNumber": "`+lostSerial+`"}}]`)
+	api.on("POST", oktaSuspend, http.StatusOK, `{}`)
+}
+
+// fakeLostChromebook serves a Chromebook known to Google only
+func fakeLostChromebook(api *fakeAPI) {
+	api.on("GET", jamfComputers, http.StatusOK, `{"totalCount": 0, "results": []}`)
+	api.on("GET", chromeOS, http.StatusOK, `{"chromeosdevices": [{"deviceId": "cros-1", "serialNumber": "`+lostSerial+`"}]}`)
+	api.on("POST", chromeOS+"/cros-1/action", http.StatusOK, `{}`)
+	api.on("PATCH", chromeOS+"/cros-1", http.StatusOK, `{"deviceId": "cros-1"}`)
+	api.on("GET", assetBySerial, http.StatusOK, `{"total": 0, "rows": []}`)
+	api.on("GET", oktaDevices, http.StatusOK, `[]`)
+}
+
+// outcome is the status of a step, as the notification reports it
+func outcome(step *orchestrators.LostDeviceStep) string {
+	switch {
+	case step.Skipped:
+		return "skipped"
+	case step.Err != nil:
+		return "failed"
+	}
+	return "done"
+}
+
+func TestLostDevice(t *testing.T) {
+	tests := []struct {
+		name     string
+		fake     func(api *fakeAPI)
+		opts     *orchestrators.LostDeviceOptions
+		outcomes map[string]string // Outcome of each step, by "Service: Action"
+		wantErr  bool
+		check    func(t *testing.T, api *fakeAPI, steps []*orchestrators.LostDeviceStep)
+	}{
+		{
+			name: "Mac is locked, marked stolen and suspended",
+			fake: fakeLostMac,
+			opts: &orchestrators.LostDeviceOptions{PIN: lostPIN, Ticket: "SEC-1"},
+			outcomes: map[string]string{
+				"Jamf: lock computer":               "done",
+				"Jamf: list certificates to revoke": "done",
+				"Google: disable ChromeOS device":   "skipped",
+				"SnipeIT: mark asset stolen":        "done",
+				"Okta: suspend device":              "done",
+				"Notify: notify security":           "done",
+			},
+			check: func(t *testing.T, api *fakeAPI, steps []*orchestrators.LostDeviceStep) {
+				if commands := api.called("POST", jamfCommands); len(commands) != 1 || !strings.Contains(commands[0], lostPIN) {
+					t.Errorf("MDM commands = %v, want one DEVICE_LOCK with the PIN", commands)
+				}
+				if updates := api.called("PATCH", assetUpdate); len(updates) != 1 || !strings.Contains(updates[0], "SEC-1") {
+					t.Errorf("asset updates = %v, want one with the ticket in the notes", updates)
+				}
+
+				lock := steps[0]
+				if lock.PIN != lostPIN {
+					t.Errorf("lock PIN = %q, want %q", lock.PIN, lostPIN)
+				}
+				if strings.Contains(lock.Detail, lostPIN) {
+					t.Errorf("lock detail %q holds the PIN", lock.Detail)
+				}
+				if !strings.Contains(steps[1].Detail, "ada@example.com (serial 0A1B)") {
+					t.Errorf("certificates detail = %q, want the identity certificate", steps[1].Detail)
+				}
+				if !strings.Contains(steps[3].Rollback, "back to Deployed") {
+					t.Errorf("Snipe-IT rollback = %q, want the previous status", steps[3].Rollback)
+				}
+				if !strings.Contains(steps[4].Rollback, "dev-1") {
+					t.Errorf("Okta rollback = %q, want the device ID", steps[4].Rollback)
+				}
+			},
+		},
+		{
+			name: "Wiped Mac gets a generated PIN",
+			fake: fakeLostMac,
+			opts: &orchestrators.LostDeviceOptions{Wipe: true},
+			outcomes: map[string]string{
+				"Jamf: wipe computer":               "done",
+				"Jamf: list certificates to revoke": "done",
+				"Google: disable ChromeOS device":   "skipped",
+				"SnipeIT: mark asset stolen":        "done",
+				"Okta: suspend device":              "done",
+				"Notify: notify security":           "done",
+			},
+			check: func(t *testing.T, api *fakeAPI, steps []*orchestrators.LostDeviceStep) {
+				wipe := steps[0]
+				if len(wipe.PIN) != 6 {
+					t.Fatalf("wipe PIN = %q, want six digits", wipe.PIN)
+				}
+				if commands := api.called("POST", jamfCommands); len(commands) != 1 || !strings.Contains(commands[0], wipe.PIN) {
+					t.Errorf("MDM commands = %v, want one ERASE_DEVICE with the PIN", commands)
+				}
+			},
+		},
+		{
+			name: "Declined steps are skipped",
+			fake: fakeLostMac,
+			opts: &orchestrators.LostDeviceOptions{PIN: lostPIN, Confirm: func(step *orchestrators.LostDeviceStep) bool {
+				return step.Service != "SnipeIT" && step.Service != "Notify"
+			}},
+			outcomes: map[string]string{
+				"Jamf: lock computer":               "done",
+				"Jamf: list certificates to revoke": "done",
+				"Google: disable ChromeOS device":   "skipped",
+				"SnipeIT: mark asset stolen":        "skipped",
+				"Okta: suspend device":              "done",
+				"Notify: notify security":           "skipped",
+			},
+			check: func(t *testing.T, api *fakeAPI, steps []*orchestrators.LostDeviceStep) {
+				if updates := api.called("PATCH", assetUpdate); len(updates) != 0 {
+					t.Errorf("asset updates = %v, want none once declined", updates)
+				}
+				if steps[3].Rollback != "" {
+					t.Errorf("declined step has rollback %q", steps[3].Rollback)
+				}
+			},
+		},
+		{
+			name: "Failed lock does not stop the other steps",
+			fake: func(api *fakeAPI) {
+				fakeLostMac(api)
+				api.on("POST", jamfCommands, http.StatusInternalServerError, `{"httpStatus": 500, "errors": []}`)
+			},
+			opts: &orchestrators.LostDeviceOptions{PIN: lostPIN},
+			outcomes: map[string]string{
+				"Jamf: lock computer":               "failed",
+				"Jamf: list certificates to revoke": "done",
+				"Google: disable ChromeOS device":   "skipped",
+				"SnipeIT: mark asset stolen":        "done",
+				"Okta: suspend device":              "done",
+				"Notify: notify security":           "done",
+			},
+			wantErr: true,
+			check: func(t *testing.T, api *fakeAPI, steps []*orchestrators.LostDeviceStep) {
+				if steps[0].PIN != "" || steps[0].Rollback != "" {
+					t.Errorf("failed lock has PIN %q and rollback %q", steps[0].PIN, steps[0].Rollback)
+				}
+				if len(api.called("POST", oktaSuspend)) != 1 {
+					t.Error("Okta device was not suspended after the failed lock")
+				}
+			},
+		},
+		{
+			name: "Chromebook is disabled and annotated",
+			fake: fakeLostChromebook,
+			opts: &orchestrators.LostDeviceOptions{Ticket: "SEC-2"},
+			outcomes: map[string]string{
+				"Jamf: lock computer":               "skipped",
+				"Jamf: list certificates to revoke": "skipped",
+				"Google: disable ChromeOS device":   "done",
+				"SnipeIT: mark asset stolen":        "skipped",
+				"Okta: suspend device":              "skipped",
+				"Notify: notify security":           "done",
+			},
+			check: func(t *testing.T, api *fakeAPI, steps []*orchestrators.LostDeviceStep) {
+				if actions := api.called("POST", chromeOS+"/cros-1/action"); len(actions) != 1 || !strings.Contains(actions[0], `"disable"`) {
+					t.Errorf("ChromeOS actions = %v, want one disable", actions)
+				}
+				if notes := api.called("PATCH", chromeOS+"/cros-1"); len(notes) != 1 || !strings.Contains(notes[0], "SEC-2") {
+					t.Errorf("ChromeOS annotations = %v, want the ticket", notes)
+				}
+				if len(api.called("POST", jamfCommands)) != 0 {
+					t.Error("MDM command sent for a device unknown to Jamf")
+				}
+				if !strings.Contains(steps[2].Rollback, "reenable") {
+					t.Errorf("Google rollback = %q, want the reenable action", steps[2].Rollback)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api, srv := newFakeAPI(t)
+			tt.fake(api)
+			c := newClient(t, srv, "jamf", "google", "snipeit", "okta")
+
+			notifier := &recorder{}
+			tt.opts.Notifier = notifier
+
+			steps, err := c.LostDevice(lostSerial, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LostDevice() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if len(steps) != len(tt.outcomes) {
+				t.Fatalf("LostDevice() returned %d steps, want %d", len(steps), len(tt.outcomes))
+			}
+			for _, step := range steps {
+				name := step.Service + ": " + step.Action
+				if got, want := outcome(step), tt.outcomes[name]; got != want {
+					t.Errorf("step %q is %s, want %s (detail %q, err %v)", name, got, want, step.Detail, step.Err)
+				}
+			}
+
+			for _, message := range notifier.messages {
+				for _, step := range steps {
+					if step.PIN != "" && strings.Contains(message.Body, step.PIN) {
+						t.Errorf("notification holds the PIN:\n%s", message.Body)
+					}
+				}
+			}
+
+			tt.check(t, api, steps)
+		})
+	}
+}
+
+func TestLostDeviceLifecycle(t *testing.T) {
+	api, srv := newFakeAPI(t)
+	fakeLostMac(api)
+	api.on("GET", assetUpdate, http.StatusOK, `{"id": 7, "asset_tag": "A-7", "status_label": {"id": 1, "name": "Deployed"}}`)
+
+	c := newClient(t, srv, "jamf", "google", "snipeit", "okta")
+	c.SnipeIT.Lifecycle = snipeit.DefaultLifecycle()
+
+	steps, err := c.LostDevice(lostSerial, &orchestrators.LostDeviceOptions{PIN: lostPIN, Notifier: &recorder{}})
+	if err != nil {
+		t.Fatalf("LostDevice() error = %v", err)
+	}
+
+	if step := steps[3]; outcome(step) != "done" {
+		t.Errorf("step %q is %s, want done under the default lifecycle (err %v)", step.Action, outcome(step), step.Err)
+	}
+	if updates := api.called("PATCH", assetUpdate); len(updates) != 1 {
+		t.Errorf("asset updates = %v, want the stolen status", updates)
+	}
+}
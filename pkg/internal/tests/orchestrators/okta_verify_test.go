@@ -0,0 +1,72 @@
+// pkg/internal/tests/orchestrators/okta_verify_test.go
+package orchestrators_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/orchestrators"
+)
+
+func TestOktaVerifyDeviceReport(t *testing.T) {
+	api, srv := newFakeAPI(t)
+
+	api.on("GET", oktaHost+"/api/v1/users", http.StatusOK, `[
+		{"id": "00u1", "status": "ACTIVE", "profile": {"login": "ada@example.com"}},
+		{"id": "00u2", "status": "ACTIVE"}
+	]`)
+	api.on("GET", jamfHost+"/api/v2/mobile-devices", http.StatusOK, `{"totalCount": 1, "results": [
+		{"id": "1", "name": "Ada's iPhone", "serialNumber": "CORP1", "udid": "UDID-1", "username": "ada"}
+	]}`)
+
+	// The Android phone was renamed after the corporate iPhone, but its serial number is its own
+	api.on("GET", oktaHost+"/api/v1/users/00u1/factors", http.StatusOK, `[
+		{"id": "f1", "factorType": "push", "provider": "OKTA", "status": "ACTIVE", "profile": {"name": "Ada's iPhone", "platform": "IOS"}},
+		{"id": "f2", "factorType": "signed_nonce", "provider": "OKTA", "status": "ACTIVE", "profile": {"name": "Ada’s iPhone", "platform": "ANDROID"}},
+		{"id": "f3", "factorType": "sms", "provider": "OKTA", "status": "ACTIVE"}
+	]`)
+	api.on("GET", oktaHost+"/api/v1/users/00u1/devices", http.StatusOK, `[
+		{"device": {"id": "d1", "profile": {"displayName": "iPhone", "platform": "iOS", "udid": "udid-1"}}},
+		{"device": {"id": "d2", "profile": {"displayName": "Ada's iPhone", "platform": "ANDROID", "serialNumber": "PERSONAL1"}}},
+		{"device": {"id": "d3", "profile": {"displayName": "MacBook", "platform": "MACOS", "serialNumber": "CORP2"}}}
+	]`)
+
+	// A user without a profile is reported by ID when their devices can't be listed
+	api.on("GET", oktaHost+"/api/v1/users/00u2/factors", http.StatusOK, `[
+		{"id": "f4", "factorType": "push", "provider": "OKTA", "status": "ACTIVE", "profile": {"platform": "IOS"}}
+	]`)
+	api.on("GET", oktaHost+"/api/v1/users/00u2/devices", http.StatusInternalServerError, `{}`)
+
+	c := newClient(t, srv, "okta", "jamf")
+	c.PartialResults = true
+
+	report, err := c.OktaVerifyDeviceReport()
+	partial, ok := orchestrators.AsPartial(err)
+	if !ok {
+		t.Fatalf("OktaVerifyDeviceReport() error = %v, want a partial result", err)
+	}
+	if len(partial.Sources) != 1 || partial.Sources[0].Source != "Okta devices (00u2)" {
+		t.Errorf("Sources = %v, want Okta devices (00u2)", partial.Sources)
+	}
+
+	if len(report) != 2 {
+		t.Fatalf("report = %d enrollments, want 2", len(report))
+	}
+	for _, e := range report {
+		switch e.Factor.ID {
+		case "f1":
+			if e.Device == nil || e.Device.ID != "d1" || e.JamfDevice == nil || !e.Corporate {
+				t.Errorf("iPhone enrollment = %+v, want corporate, matched on its UDID", e)
+			}
+		case "f2":
+			if e.Device == nil || e.Device.ID != "d2" || e.JamfDevice != nil || e.Corporate {
+				t.Errorf("Android enrollment = %+v, want personal, without a Jamf device", e)
+			}
+		default:
+			t.Errorf("unexpected enrollment of factor %s", e.Factor.ID)
+		}
+	}
+	if personal := report.Personal(); len(personal) != 1 || personal[0].Factor.ID != "f2" {
+		t.Errorf("Personal() = %v, want the Android enrollment", personal)
+	}
+}
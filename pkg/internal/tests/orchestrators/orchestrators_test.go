@@ -0,0 +1,220 @@
+/*
+# Orchestrators - Test
+
+This package tests the orchestrators against a fake of the provider APIs, served by a single httptest server:
+requests to every provider host are rewritten to it, and routed on their method, host and path.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/internal/tests/orchestrators/orchestrators_test.go
+package orchestrators_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/cache"
+	"github.com/gemini-oss/rego/pkg/common/flags"
+	"github.com/gemini-oss/rego/pkg/common/log"
+	"github.com/gemini-oss/rego/pkg/common/notify"
+	"github.com/gemini-oss/rego/pkg/common/ratelimit"
+	"github.com/gemini-oss/rego/pkg/common/requests"
+	"github.com/gemini-oss/rego/pkg/google"
+	"github.com/gemini-oss/rego/pkg/jamf"
+	"github.com/gemini-oss/rego/pkg/okta"
+	"github.com/gemini-oss/rego/pkg/orchestrators"
+	"github.com/gemini-oss/rego/pkg/snipeit"
+)
+
+const (
+	oktaHost    = "example.okta.com"
+	jamfHost    = "jamf.example.com"
+	snipeitHost = "snipeit.example.com"
+	googleHost  = "admin.googleapis.com"
+)
+
+// handler answers a request to the fake API with a status code and a JSON body
+type handler func(r *http.Request, body string) (int, string)
+
+// fakeAPI serves the routes of every provider, and records the requests it received
+type fakeAPI struct {
+	t      *testing.T
+	mu     sync.Mutex
+	routes map[string]handler
+	calls  []string
+	bodies map[string][]string
+}
+
+// newFakeAPI starts a fake API; unknown routes answer 404, as the providers do for unknown resources
+func newFakeAPI(t *testing.T) (*fakeAPI, *httptest.Server) {
+	t.Helper()
+	t.Setenv("REGO_ENCRYPTION_KEY", "32~Byte-long_passphrase-key-1234")
+
+	api := &fakeAPI{t: t, routes: map[string]handler{}, bodies: map[string][]string{}}
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+
+	return api, srv
+}
+
+// route is the key of a request: its method, then the host and path it was sent to, e.g. `GET jamf.example.com/api/v1/...`
+func route(method, path string) string {
+	return method + " " + strings.TrimPrefix(path, "/")
+}
+
+// on answers a route with a fixed status and body
+func (api *fakeAPI) on(method, path string, status int, body string) {
+	api.handle(method, path, func(*http.Request, string) (int, string) {
+		return status, body
+	})
+}
+
+// handle answers a route with h
+func (api *fakeAPI) handle(method, path string, h handler) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	api.routes[route(method, path)] = h
+}
+
+// called returns the bodies of the requests received on a route, in order
+func (api *fakeAPI) called(method, path string) []string {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return append([]string(nil), api.bodies[route(method, path)]...)
+}
+
+func (api *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	raw, _ := io.ReadAll(r.Body)
+	key := route(r.Method, r.URL.Path)
+
+	api.mu.Lock()
+	api.calls = append(api.calls, key)
+	api.bodies[key] = append(api.bodies[key], string(raw))
+	h, ok := api.routes[key]
+	api.mu.Unlock()
+
+	status, body := http.StatusNotFound, `{}`
+	if ok {
+		status, body = h(r, string(raw))
+	} else {
+		api.t.Logf("fake API: no route for %s", key)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	fmt.Fprint(w, body)
+}
+
+// rewrite sends the requests of the providers to the fake API, prefixing the path with the host they were meant for
+type rewrite struct {
+	target *url.URL
+}
+
+func (rt rewrite) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	r.URL.Path = "/" + req.URL.Host + req.URL.Path
+	r.URL.RawPath = ""
+	r.URL.Scheme, r.URL.Host, r.Host = rt.target.Scheme, rt.target.Host, rt.target.Host
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+// newHTTP returns a requests client which talks to the fake API, without retries; its rate limiter never waits
+func newHTTP(t *testing.T, srv *httptest.Server) *requests.Client {
+	t.Helper()
+
+	target, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	headers := requests.Headers{"Accept": requests.JSON, "Content-Type": requests.JSON}
+	noRetry := requests.WithBackoff(func(int, error) (time.Duration, bool) { return 0, false })
+	limiter := ratelimit.NewRateLimiter(1_000_000, time.Minute)
+	client := requests.NewClient(&http.Client{Transport: rewrite{target}}, headers, limiter, noRetry)
+	client.BodyType = requests.JSON
+	return client
+}
+
+// newCache returns an in-memory cache, so the tests never share responses
+func newCache(t *testing.T) *cache.Cache {
+	t.Helper()
+
+	c, err := cache.NewCache([]byte("32~Byte-long_passphrase-key-1234"), true, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// newClient returns an orchestrators client for the given providers ("google", "jamf", "okta", "snipeit"), all backed by
+// the fake API; automations are enabled unless REGO_DISABLED_AUTOMATIONS says otherwise
+func newClient(t *testing.T, srv *httptest.Server, providers ...string) *orchestrators.Client {
+	t.Helper()
+
+	logger := log.NewLogger("{test}", log.ERROR)
+	c := &orchestrators.Client{Log: logger, Flags: flags.New(&flags.EnvProvider{})}
+
+	for _, provider := range providers {
+		switch provider {
+		case "google":
+			c.Google = &google.Client{
+				HTTP:      newHTTP(t, srv),
+				Log:       logger,
+				Cache:     newCache(t),
+				UserCache: cache.NewTyped[*google.User](time.Minute, 0, 100),
+			}
+		case "jamf":
+			c.Jamf = &jamf.Client{
+				BaseURL:       "https://" + jamfHost + "/api",
+				HTTP:          newHTTP(t, srv),
+				Log:           logger,
+				Cache:         newCache(t),
+				ComputerCache: cache.NewTyped[*jamf.Computer](time.Minute, 0, 100),
+			}
+		case "okta":
+			c.Okta = &okta.Client{
+				BaseURL: "https://" + oktaHost + "/api/v1",
+				HTTP:    newHTTP(t, srv),
+				Log:     logger,
+				Cache:   newCache(t),
+			}
+		case "snipeit":
+			c.SnipeIT = &snipeit.Client{
+				BaseURL: "https://" + snipeitHost + "/api/v1",
+				HTTP:    newHTTP(t, srv),
+				Log:     logger,
+				Cache:   newCache(t),
+			}
+		default:
+			t.Fatalf("unknown provider %q", provider)
+		}
+	}
+
+	return c
+}
+
+// recorder is a notifier which keeps the messages it was sent
+type recorder struct {
+	mu       sync.Mutex
+	messages []*notify.Message
+	err      error
+}
+
+func (r *recorder) Name() string { return "recorder" }
+
+func (r *recorder) Notify(message *notify.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = append(r.messages, message)
+	return r.err
+}
@@ -0,0 +1,131 @@
+/*
+# Orchestrators - Ownership Transfer - Test
+
+This package tests the execution of an ownership transfer plan: the ownership-transfer flag, steps without a successor,
+and how a successor is made owner of a group.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/internal/tests/orchestrators/ownership_transfer_test.go
+package orchestrators_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/common/flags"
+	"github.com/gemini-oss/rego/pkg/orchestrators"
+)
+
+const (
+	leaver    = "ada@example.com"
+	successor = "bob@example.com"
+
+	transferApps   = googleHost + "/admin/datatransfer/v1/applications"
+	transfers      = googleHost + "/admin/datatransfer/v1/transfers"
+	googleUsers    = googleHost + "/admin/directory/v1/users"
+	newOwnerGroup  = googleGroups + "/new@example.com/members"
+	promotedGroup  = googleGroups + "/promoted@example.com/members"
+	unchangedGroup = googleGroups + "/unchanged@example.com/members"
+)
+
+// transferPlan moves the Drive files of the leaver and three groups to the successor; the calendar step has no successor
+func transferPlan() *orchestrators.TransferPlan {
+	step := func(kind, resource, to string) *orchestrators.TransferStep {
+		return &orchestrators.TransferStep{Kind: kind, Resource: resource, From: leaver, To: to, Reason: "test"}
+	}
+
+	return &orchestrators.TransferPlan{
+		User: leaver,
+		Steps: []*orchestrators.TransferStep{
+			step(orchestrators.TransferDrive, leaver, successor),
+			step(orchestrators.TransferCalendar, leaver, ""),
+			step(orchestrators.TransferGroupOwner, "new@example.com", successor),
+			step(orchestrators.TransferGroupOwner, "promoted@example.com", successor),
+			step(orchestrators.TransferGroupOwner, "unchanged@example.com", successor),
+		},
+	}
+}
+
+// fakeTransfer serves the data transfer applications, both users, and the successor's membership of each group
+func fakeTransfer(api *fakeAPI) {
+	api.on("GET", transferApps, http.StatusOK, `{"applications": [{"id": "55656082996", "name": "Drive and Docs"}, {"id": "435070579839", "name": "Calendar"}]}`)
+	api.on("POST", transfers, http.StatusOK, `{"id": "transfer-1", "overallTransferStatusCode": "new"}`)
+	api.on("GET", googleUsers+"/"+leaver, http.StatusOK, `{"id": "u-ada", "primaryEmail": "`+leaver+`"}`)
+	api.on("GET", googleUsers+"/"+successor, http.StatusOK, `{"id": "u-bob", "primaryEmail": "`+successor+`"}`)
+
+	api.on("GET", newOwnerGroup+"/"+successor, http.StatusNotFound, `{"error": {"code": 404, "message": "Resource Not Found: memberKey"}}`)
+	api.on("POST", newOwnerGroup, http.StatusOK, `{"email": "`+successor+`", "role": "OWNER"}`)
+	api.on("GET", promotedGroup+"/"+successor, http.StatusOK, `{"email": "`+successor+`", "role": "MEMBER", "type": "USER"}`)
+	api.on("PATCH", promotedGroup+"/"+successor, http.StatusOK, `{"email": "`+successor+`", "role": "OWNER"}`)
+	api.on("GET", unchangedGroup+"/"+successor, http.StatusOK, `{"email": "`+successor+`", "role": "OWNER", "type": "USER"}`)
+}
+
+func TestExecuteTransferPlanDisabled(t *testing.T) {
+	t.Setenv("REGO_DISABLED_AUTOMATIONS", orchestrators.FlagOwnershipTransfer)
+	api, srv := newFakeAPI(t)
+	fakeTransfer(api)
+	c := newClient(t, srv, "google")
+
+	outcomes, err := c.ExecuteTransferPlan(transferPlan())
+	if !errors.Is(err, flags.ErrDisabled) {
+		t.Fatalf("ExecuteTransferPlan() error = %v, want %v", err, flags.ErrDisabled)
+	}
+	if outcomes != nil {
+		t.Errorf("ExecuteTransferPlan() = %v, want no outcomes", outcomes)
+	}
+	if len(api.calls) != 0 {
+		t.Errorf("API called with the flag switched off: %v", api.calls)
+	}
+}
+
+func TestExecuteTransferPlan(t *testing.T) {
+	api, srv := newFakeAPI(t)
+	fakeTransfer(api)
+	c := newClient(t, srv, "google")
+
+	outcomes, err := c.ExecuteTransferPlan(transferPlan())
+	if err == nil || !strings.Contains(err.Error(), "calendar "+leaver+": no successor") {
+		t.Fatalf("ExecuteTransferPlan() error = %v, want the calendar step without a successor", err)
+	}
+	if strings.Contains(err.Error(), "\n") {
+		t.Errorf("ExecuteTransferPlan() error = %v, want the calendar step only", err)
+	}
+
+	want := []struct {
+		detail string
+		failed bool
+	}{
+		{detail: "data transfer transfer-1"},
+		{failed: true},
+		{detail: "added as owner"},
+		{detail: "promoted from member"},
+		{detail: "already an owner"},
+	}
+	if len(outcomes) != len(want) {
+		t.Fatalf("ExecuteTransferPlan() returned %d outcomes, want %d", len(outcomes), len(want))
+	}
+	for i, outcome := range outcomes {
+		if outcome.Detail != want[i].detail || (outcome.Err != nil) != want[i].failed {
+			t.Errorf("%s %s = %q (err %v), want %q", outcome.Step.Kind, outcome.Step.Resource, outcome.Detail, outcome.Err, want[i].detail)
+		}
+	}
+
+	if transfers := api.called("POST", transfers); len(transfers) != 1 || !strings.Contains(transfers[0], "55656082996") || !strings.Contains(transfers[0], "u-bob") {
+		t.Errorf("data transfers = %v, want the Drive files only", transfers)
+	}
+	if adds := api.called("POST", newOwnerGroup); len(adds) != 1 || !strings.Contains(adds[0], `"OWNER"`) {
+		t.Errorf("members added = %v, want the successor as owner", adds)
+	}
+	if updates := api.called("PATCH", promotedGroup+"/"+successor); len(updates) != 1 || !strings.Contains(updates[0], `"OWNER"`) {
+		t.Errorf("members updated = %v, want the successor promoted to owner", updates)
+	}
+	if len(api.called("POST", unchangedGroup)) != 0 || len(api.called("PATCH", unchangedGroup+"/"+successor)) != 0 {
+		t.Error("existing owner was changed")
+	}
+}
@@ -0,0 +1,109 @@
+/*
+# Schema - Golden Tests
+
+This package diffs recorded (sanitized) API objects against ReGo's entity structs, flagging fields the structs do not map.
+
+Record fresh golden files against live tenants with:
+	go test ./pkg/internal/tests/schema -run TestRecordGolden -record
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/internal/tests/schema/golden_test.go
+package schema_test
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/common/config"
+	"github.com/gemini-oss/rego/pkg/common/log"
+	"github.com/gemini-oss/rego/pkg/common/schema"
+	"github.com/gemini-oss/rego/pkg/google"
+	"github.com/gemini-oss/rego/pkg/jamf"
+	"github.com/gemini-oss/rego/pkg/okta"
+	"github.com/gemini-oss/rego/pkg/snipeit"
+)
+
+var record = flag.Bool("record", false, "record golden files from the live APIs")
+
+const goldenDir = "testdata/golden"
+
+// golden maps each golden file to the entity struct it must decode into, and how to fetch a live sample of it
+var golden = map[string]struct {
+	entity interface{}
+	fetch  func() ([]byte, error)
+}{
+	"google_users": {&google.Users{}, func() ([]byte, error) {
+		c, err := google.NewClient(google.AuthCredentials{
+			Type:    google.SERVICE_ACCOUNT,
+			CICD:    true,
+			Scopes:  []string{"https://www.googleapis.com/auth/admin.directory.user.readonly"},
+			Subject: config.GetEnv("GOOGLE_ADMIN_SUBJECT"),
+		}, log.INFO)
+		if err != nil {
+			return nil, err
+		}
+		return goldenBody(c.HTTP.DoRequest("GET", google.DirectoryUsers, map[string]interface{}{"customer": "my_customer", "maxResults": 1, "projection": "full"}, nil))
+	}},
+	"okta_user": {&okta.User{}, func() ([]byte, error) {
+		c := okta.NewClient(log.INFO)
+		return goldenBody(c.HTTP.DoRequest("GET", c.BuildURL(okta.OktaUsers), map[string]interface{}{"limit": 1}, nil))
+	}},
+	"okta_application": {&okta.Application{}, func() ([]byte, error) {
+		c := okta.NewClient(log.INFO)
+		return goldenBody(c.HTTP.DoRequest("GET", c.BuildURL(okta.OktaApps), map[string]interface{}{"limit": 1}, nil))
+	}},
+	"jamf_computers": {&jamf.Computers{}, func() ([]byte, error) {
+		c := jamf.NewClient(log.INFO)
+		return goldenBody(c.HTTP.DoRequest("GET", c.BuildURL(jamf.ComputersInventory), map[string]interface{}{"section": "ALL", "page-size": 1}, nil))
+	}},
+	"snipeit_hardware": {&snipeit.HardwareList{}, func() ([]byte, error) {
+		c := snipeit.NewClient(log.INFO)
+		return goldenBody(c.HTTP.DoRequest("GET", c.BuildURL(snipeit.Assets), map[string]interface{}{"limit": 1}, nil))
+	}},
+}
+
+func goldenBody[T any](_ T, body []byte, err error) ([]byte, error) {
+	return body, err
+}
+
+func TestGoldenDrift(t *testing.T) {
+	for name, g := range golden {
+		t.Run(name, func(t *testing.T) {
+			drift, err := schema.CheckGolden(goldenDir, name, g.entity)
+			if errors.Is(err, os.ErrNotExist) {
+				t.Skipf("%s has not been recorded", name)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if drift.HasDrift() {
+				t.Error(drift)
+			}
+		})
+	}
+}
+
+func TestRecordGolden(t *testing.T) {
+	if !*record {
+		t.Skip("run with -record to record golden files from the live APIs")
+	}
+
+	for name, g := range golden {
+		t.Run(name, func(t *testing.T) {
+			body, err := g.fetch()
+			if err != nil {
+				t.Fatalf("fetching %s: %v", name, err)
+			}
+			if err := schema.Record(goldenDir, name, body); err != nil {
+				t.Fatal(err)
+			}
+			t.Logf("Recorded %s/%s.json", goldenDir, name)
+		})
+	}
+}
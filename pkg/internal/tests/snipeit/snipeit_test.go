@@ -1,2 +1,52 @@
 // pkg/internal/tests/snipeit/snipeit_test.go
 package snipeit_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/snipeit"
+)
+
+func TestLifecycleValidate(t *testing.T) {
+	lifecycle := snipeit.DefaultLifecycle()
+
+	asset := func(status string) *snipeit.Hardware {
+		return &snipeit.Hardware{ID: 1, Serial: "C02ABC", AssetTag: "GEM-1", StatusLabel: &snipeit.StatusLabel{Name: status}}
+	}
+	deployed := asset("deployed")
+	deployed.AssignedTo = &snipeit.User{}
+
+	tests := []struct {
+		name    string
+		asset   *snipeit.Hardware
+		to      string
+		changes *snipeit.Hardware
+		want    error
+	}{
+		{"Checked out", deployed, snipeit.StatusDeployed, nil, nil},
+		{"Return", deployed, snipeit.StatusReturned, nil, nil},
+		{"Deploy without checkout", asset(snipeit.StatusInStock), snipeit.StatusDeployed, nil, snipeit.ErrMissingFields},
+		{"Skip return", deployed, snipeit.StatusRetired, nil, snipeit.ErrInvalidTransition},
+		{"Retire without notes", asset(snipeit.StatusReturned), snipeit.StatusRetired, nil, snipeit.ErrMissingFields},
+		{"Retire with notes", asset(snipeit.StatusReturned), snipeit.StatusRetired, &snipeit.Hardware{Notes: "Battery failure"}, nil},
+		{"Leave retirement", asset(snipeit.StatusRetired), snipeit.StatusInStock, nil, snipeit.ErrInvalidTransition},
+		{"Unknown status", asset(snipeit.StatusInStock), "Pending Audit", nil, snipeit.ErrInvalidTransition},
+		{"Stolen while deployed", deployed, snipeit.StatusStolen, &snipeit.Hardware{Notes: "Reported stolen"}, nil},
+		{"Lost from retirement", asset(snipeit.StatusRetired), snipeit.StatusLost, &snipeit.Hardware{Notes: "Missing from storage"}, nil},
+		{"Lost from an unknown status", asset("Pending Audit"), snipeit.StatusLost, &snipeit.Hardware{Notes: "Missing"}, nil},
+		{"Stolen without notes", deployed, snipeit.StatusStolen, nil, snipeit.ErrMissingFields},
+		{"Recovered", asset(snipeit.StatusStolen), snipeit.StatusInStock, nil, nil},
+		{"Redeploy a lost asset", asset(snipeit.StatusLost), snipeit.StatusDeployed, nil, snipeit.ErrInvalidTransition},
+		{"New asset", &snipeit.Hardware{Serial: "C02XYZ", AssetTag: "GEM-2"}, snipeit.StatusInStock, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := lifecycle.Validate(tt.asset, tt.to, tt.changes)
+			if !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+				t.Errorf("Validate(%q) = %v; want %v", tt.to, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// pkg/internal/tests/synthetic/loadtest_test.go
+package synthetic_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+	"github.com/gemini-oss/rego/pkg/synthetic"
+)
+
+func newMock(tb testing.TB, users, throttle int) (*synthetic.MockServer, *httptest.Server, *requests.Client) {
+	tb.Helper()
+	tb.Setenv("REGO_ENCRYPTION_KEY", "32~Byte-long_passphrase-key-1234")
+
+	mock := synthetic.NewMockServer(synthetic.New(7, "example.com").Fleet(users, users))
+	mock.ThrottleEvery = throttle
+	srv := httptest.NewServer(mock.Handler())
+	tb.Cleanup(srv.Close)
+
+	return mock, srv, requests.NewClient(nil, requests.Headers{"Content-Type": requests.JSON}, nil)
+}
+
+func TestMockServerPagination(t *testing.T) {
+	_, srv, client := newMock(t, 250, 0)
+
+	report, err := synthetic.WalkLinkPages(client, srv.URL+synthetic.MockOktaUsers, 100)
+	if err != nil {
+		t.Fatalf("WalkLinkPages() error = %v", err)
+	}
+	if report.Pages != 3 || report.Items != 250 {
+		t.Errorf("WalkLinkPages() = %d pages, %d items; want 3 pages, 250 items", report.Pages, report.Items)
+	}
+
+	report, err = synthetic.WalkTokenPages(client, srv.URL+synthetic.MockGoogleUsers, "users", 100)
+	if err != nil {
+		t.Fatalf("WalkTokenPages() error = %v", err)
+	}
+	if report.Pages != 3 || report.Items != 250 {
+		t.Errorf("WalkTokenPages() = %d pages, %d items; want 3 pages, 250 items", report.Pages, report.Items)
+	}
+}
+
+func benchmarkWalk(b *testing.B, users, throttle int, walk func(*requests.Client, string) (*synthetic.LoadReport, error), path string) {
+	mock, srv, client := newMock(b, users, throttle)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	pages := 0
+	for i := 0; i < b.N; i++ {
+		report, err := walk(client, srv.URL+path)
+		if err != nil {
+			b.Fatal(err)
+		}
+		pages += report.Pages
+	}
+
+	b.StopTimer()
+	_, throttled := mock.Stats()
+	b.ReportMetric(float64(pages)/b.Elapsed().Seconds(), "pages/s")
+	b.ReportMetric(float64(throttled)/float64(b.N), "429s/op")
+}
+
+func BenchmarkOktaPagination(b *testing.B) {
+	benchmarkWalk(b, 2000, 0, func(c *requests.Client, url string) (*synthetic.LoadReport, error) {
+		return synthetic.WalkLinkPages(c, url, 200)
+	}, synthetic.MockOktaUsers)
+}
+
+func BenchmarkGooglePagination(b *testing.B) {
+	benchmarkWalk(b, 2000, 0, func(c *requests.Client, url string) (*synthetic.LoadReport, error) {
+		return synthetic.WalkTokenPages(c, url, "users", 200)
+	}, synthetic.MockGoogleUsers)
+}
+
+// Every 5th request is throttled, so each walk of 10 pages goes through the retry backoff twice
+func BenchmarkOktaPaginationThrottled(b *testing.B) {
+	benchmarkWalk(b, 2000, 5, func(c *requests.Client, url string) (*synthetic.LoadReport, error) {
+		return synthetic.WalkLinkPages(c, url, 200)
+	}, synthetic.MockOktaUsers)
+}
@@ -0,0 +1,48 @@
+// pkg/internal/tests/synthetic/synthetic_test.go
+package synthetic_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gemini-oss/rego/pkg/synthetic"
+)
+
+func TestFleetIsDeterministicAndConsistent(t *testing.T) {
+	fleet := synthetic.New(42, "example.com").Fleet(50, 200)
+	again := synthetic.New(42, "example.com").Fleet(50, 200)
+
+	if !reflect.DeepEqual(fleet.People, again.People) || !reflect.DeepEqual(fleet.OktaEvents, again.OktaEvents) {
+		t.Error("the same seed generated different data")
+	}
+
+	if len(fleet.OktaEvents)+len(fleet.GoogleActivities) != 200 {
+		t.Errorf("generated %d Okta and %d Google events; want 200 in total", len(fleet.OktaEvents), len(fleet.GoogleActivities))
+	}
+
+	computers := *fleet.JamfComputers.Results
+	for i, person := range fleet.People {
+		if fleet.GoogleUsers[i].PrimaryEmail != person.Email ||
+			fleet.OktaUsers[i].Profile.Email != person.Email ||
+			computers[i].UserAndLocation.Email != person.Email ||
+			fleet.SnipeITAssets[i].AssignedTo.Email != person.Email {
+			t.Fatalf("person %d (%s) has inconsistent accounts across providers", i, person.Email)
+		}
+		if computers[i].Hardware.SerialNumber != fleet.SnipeITAssets[i].Serial {
+			t.Fatalf("person %d has a Jamf serial %s but a Snipe-IT serial %s", i, computers[i].Hardware.SerialNumber, fleet.SnipeITAssets[i].Serial)
+		}
+		if len(fleet.OktaUsers[i].ID) != 20 {
+			t.Errorf("Okta ID %q is not 20 characters long", fleet.OktaUsers[i].ID)
+		}
+	}
+
+	dir := t.TempDir()
+	if err := fleet.WriteJSON(dir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "okta_log_events.json")); err != nil {
+		t.Errorf("WriteJSON() did not write the Okta events: %v", err)
+	}
+}
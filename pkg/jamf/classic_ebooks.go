@@ -0,0 +1,85 @@
+/*
+# Jamf - eBooks
+
+This package initializes all the methods for functions which interact with the Jamf Classic API for eBooks:
+- https://developer.jamf.com/jamf-pro/reference/findebooks
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/jamf/classic_ebooks.go
+package jamf
+
+import (
+	"fmt"
+	"time"
+)
+
+var (
+	EbooksURL = fmt.Sprintf("%s/ebooks", "%s") // /ebooks
+)
+
+/*
+ * # List All eBooks
+ * /ebooks
+ * - https://developer.jamf.com/jamf-pro/reference/findebooks
+ */
+func (c *Client) ListAllEbooks() (*Ebooks, error) {
+	url := c.BuildClassicURL(EbooksURL)
+
+	var cache Ebooks
+	if c.GetCache(url, &cache) {
+		return &cache, nil
+	}
+
+	ebooks, err := do[Ebooks](c, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.SetCache(url, ebooks, 5*time.Minute)
+	return &ebooks, nil
+}
+
+/*
+ * # Get eBook by ID
+ * /ebooks/id/{id}
+ * - https://developer.jamf.com/jamf-pro/reference/findebooksbyid
+ */
+func (c *Client) GetEbookDetails(id string) (*Ebook, error) {
+	url := c.BuildClassicURL(EbooksURL, "id", id)
+
+	res, err := do[struct {
+		Ebook *Ebook `json:"ebook"`
+	}](c, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Ebook, nil
+}
+
+/*
+ * # Create an eBook
+ * /ebooks/id/0
+ * - https://developer.jamf.com/jamf-pro/reference/createebookbyid
+ */
+func (c *Client) CreateEbook(ebook *Ebook) (string, error) {
+	url := c.BuildClassicURL(EbooksURL, "id", 0)
+
+	return doClassicWrite(c, "POST", url, ebook)
+}
+
+/*
+ * # Update an eBook by ID
+ * /ebooks/id/{id}
+ * - https://developer.jamf.com/jamf-pro/reference/updateebookbyid
+ */
+func (c *Client) UpdateEbook(id string, ebook *Ebook) error {
+	url := c.BuildClassicURL(EbooksURL, "id", id)
+
+	_, err := doClassicWrite(c, "PUT", url, ebook)
+	return err
+}
@@ -0,0 +1,228 @@
+/*
+# Jamf - Mac App Store Applications
+
+This package initializes all the methods for functions which interact with the Jamf Classic API for Mac App Store applications:
+- https://developer.jamf.com/jamf-pro/reference/findmacapps
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/jamf/classic_macapplications.go
+package jamf
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	MacApplicationsURL = fmt.Sprintf("%s/macapplications", "%s") // /macapplications
+)
+
+/*
+ * # List All Mac App Store Applications
+ * /macapplications
+ * - https://developer.jamf.com/jamf-pro/reference/findmacapps
+ */
+func (c *Client) ListAllMacApplications() (*MacApplications, error) {
+	url := c.BuildClassicURL(MacApplicationsURL)
+
+	var cache MacApplications
+	if c.GetCache(url, &cache) {
+		return &cache, nil
+	}
+
+	apps, err := do[MacApplications](c, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.SetCache(url, apps, 5*time.Minute)
+	return &apps, nil
+}
+
+/*
+ * # Get Mac App Store Application by ID
+ * /macapplications/id/{id}
+ * - https://developer.jamf.com/jamf-pro/reference/findmacappsbyid
+ */
+func (c *Client) GetMacApplicationDetails(id string) (*MacApplication, error) {
+	url := c.BuildClassicURL(MacApplicationsURL, "id", id)
+
+	res, err := do[struct {
+		MacApplication *MacApplication `json:"mac_application"`
+	}](c, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.MacApplication, nil
+}
+
+/*
+ * # Create a Mac App Store Application
+ * /macapplications/id/0
+ * - https://developer.jamf.com/jamf-pro/reference/createmacappbyid
+ */
+func (c *Client) CreateMacApplication(app *MacApplication) (string, error) {
+	url := c.BuildClassicURL(MacApplicationsURL, "id", 0)
+
+	return doClassicWrite(c, "POST", url, app)
+}
+
+/*
+ * # Update a Mac App Store Application by ID
+ * /macapplications/id/{id}
+ * - https://developer.jamf.com/jamf-pro/reference/updatemacappbyid
+ */
+func (c *Client) UpdateMacApplication(id string, app *MacApplication) error {
+	url := c.BuildClassicURL(MacApplicationsURL, "id", id)
+
+	_, err := doClassicWrite(c, "PUT", url, app)
+	return err
+}
+
+/*
+ * # Apply a Self Service App Catalog
+ * Updates the Self Service presentation (description, category, icon, featuring) of each Mac App Store
+ * application in the catalog, only issuing an update where the application has drifted
+ */
+func (c *Client) ApplyAppCatalog(catalog []*AppCatalogEntry) error {
+	apps, err := c.ListAllMacApplications()
+	if err != nil {
+		return err
+	}
+
+	categories, err := c.ListAllCategories()
+	if err != nil {
+		return err
+	}
+	categoryIDs := make(map[string]string)
+	if categories.Results != nil {
+		for _, category := range *categories.Results {
+			categoryIDs[strings.ToLower(category.Name)] = category.ID
+		}
+	}
+
+	var errs []error
+	for _, entry := range catalog {
+		app, err := c.findMacApplication(apps, entry.Name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		// Only the Self Service presentation (and category) is sent; the scope is managed outside of the catalog
+		ss := &CatalogSelfService{}
+		if app.SelfService != nil {
+			*ss = *app.SelfService
+		}
+		desired := &MacApplication{SelfService: ss}
+
+		ss.SelfServiceDescription = entry.Description
+		ss.FeatureOnMainPage = entry.FeatureOnMainPage
+		if entry.InstallButtonText != "" {
+			ss.InstallButtonText = entry.InstallButtonText
+		}
+		if entry.IconID != 0 {
+			ss.SelfServiceIcon = &SelfServiceIcon{ID: entry.IconID}
+		}
+
+		if entry.Category != "" {
+			id, ok := categoryIDs[strings.ToLower(entry.Category)]
+			if !ok {
+				errs = append(errs, fmt.Errorf("%s: category %q does not exist", entry.Name, entry.Category))
+				continue
+			}
+			desired.General = &MacApplicationGeneral{
+				Category: &Category{JamfProperty: &JamfProperty{ID: id, Name: entry.Category}},
+			}
+		}
+
+		if !catalogDrifted(app, desired) {
+			c.Log.Debugf("%s is up to date", entry.Name)
+			continue
+		}
+
+		c.Log.Printf("Updating Self Service presentation of %s", entry.Name)
+		if err := c.UpdateMacApplication(fmt.Sprint(app.General.ID), desired); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", entry.Name, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("error applying app catalog: %v", errs)
+	}
+
+	return nil
+}
+
+// findMacApplication finds a Mac App Store application by name or bundle ID
+func (c *Client) findMacApplication(apps *MacApplications, name string) (*MacApplication, error) {
+	if apps.List != nil {
+		for _, app := range *apps.List {
+			if strings.EqualFold(app.Name, name) {
+				return c.getMacApplication(fmt.Sprint(app.ID))
+			}
+		}
+
+		// Fall back to matching on the bundle ID
+		for _, app := range *apps.List {
+			details, err := c.getMacApplication(fmt.Sprint(app.ID))
+			if err != nil {
+				return nil, err
+			}
+			if strings.EqualFold(details.General.BundleID, name) {
+				return details, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("mac application %q not found", name)
+}
+
+// getMacApplication fetches an application, ensuring its general details are present
+func (c *Client) getMacApplication(id string) (*MacApplication, error) {
+	app, err := c.GetMacApplicationDetails(id)
+	if err != nil {
+		return nil, err
+	}
+	if app == nil || app.General == nil {
+		return nil, fmt.Errorf("mac application %s returned no details", id)
+	}
+	if app.General.JamfProperty == nil {
+		app.General.JamfProperty = &JamfProperty{}
+	}
+	app.General.ID = id
+	return app, nil
+}
+
+// catalogDrifted reports whether the desired Self Service presentation differs from the current one
+func catalogDrifted(current, desired *MacApplication) bool {
+	cur, want := current.SelfService, desired.SelfService
+	if cur == nil {
+		return true
+	}
+
+	if cur.SelfServiceDescription != want.SelfServiceDescription ||
+		cur.FeatureOnMainPage != want.FeatureOnMainPage ||
+		cur.InstallButtonText != want.InstallButtonText {
+		return true
+	}
+
+	if want.SelfServiceIcon != nil && (cur.SelfServiceIcon == nil || cur.SelfServiceIcon.ID != want.SelfServiceIcon.ID) {
+		return true
+	}
+
+	if desired.General != nil && desired.General.Category != nil {
+		if current.General == nil || current.General.Category == nil || current.General.Category.JamfProperty == nil {
+			return true
+		}
+		return !strings.EqualFold(current.General.Category.Name, desired.General.Category.Name)
+	}
+
+	return false
+}
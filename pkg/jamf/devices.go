@@ -19,10 +19,11 @@ import (
 )
 
 var (
-	ComputersInventory       = fmt.Sprintf("%s/computers-inventory", V1)        // /api/v1/computers-inventory
-	ComputersInventoryDetail = fmt.Sprintf("%s/computers-inventory-detail", V1) // /api/v1/computers-inventory-detail
-	ComputerGroups           = fmt.Sprintf("%s/computer-groups", V1)            // /api/v1/computer-groups
-	MobileDev                = fmt.Sprintf("%s/mobile-devices", V2)             // /api/v2/mobile-devices
+	ComputersInventory       = fmt.Sprintf("%s/computers-inventory", V1)                    // /api/v1/computers-inventory
+	ComputersInventoryDetail = fmt.Sprintf("%s/computers-inventory-detail", V1)             // /api/v1/computers-inventory-detail
+	ComputerGroups           = fmt.Sprintf("%s/computer-groups", V1)                        // /api/v1/computer-groups
+	SmartGroupMembers        = fmt.Sprintf("%s/computer-groups/smart-group-membership", V2) // /api/v2/computer-groups/smart-group-membership
+	MobileDev                = fmt.Sprintf("%s/mobile-devices", V2)                         // /api/v2/mobile-devices
 )
 
 // DeviceClient for chaining methods
@@ -174,22 +175,14 @@ func (dc *DeviceClient) ListAllComputers() (*Computers, error) {
  * # Get Computer Details
  * /api/v1/computers-inventory-detail/{id}
  * - https://developer.jamf.com/jamf-pro/reference/get_v1-computers-inventory-detail-id
+ * - After UseCache, details are cached for 5 minutes
  */
 func (dc *DeviceClient) GetComputerDetails(id string) (*Computer, error) {
 	url := dc.client.BuildURL(ComputersInventoryDetail, id)
 
-	var cache Computer
-	if dc.client.GetCache(url, &cache) {
-		return &cache, nil
-	}
-
-	computer, err := do[*Computer](dc.client, "GET", url, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	dc.client.SetCache(url, computer, 5*time.Minute)
-	return computer, nil
+	return dc.client.ComputerCache.GetOrLoad(url, func() (*Computer, error) {
+		return do[*Computer](dc.client, "GET", url, nil, nil)
+	})
 }
 
 /*
@@ -213,6 +206,26 @@ func (dc *DeviceClient) ListAllComputerGroups() (*[]GroupMembership, error) {
 	return groups, nil
 }
 
+/*
+ * # Get the Computers of a Smart Group
+ * /api/v2/computer-groups/smart-group-membership/{id}
+ * - https://developer.jamf.com/jamf-pro/reference/get_v2-computer-groups-smart-group-membership-id
+ */
+func (dc *DeviceClient) ListSmartGroupMembers(groupID int) ([]string, error) {
+	url := dc.client.BuildURL(SmartGroupMembers, groupID)
+
+	membership, err := do[SmartGroupMembership](dc.client, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(membership.Members))
+	for _, id := range membership.Members {
+		ids = append(ids, fmt.Sprint(id))
+	}
+	return ids, nil
+}
+
 /*
  * # Get Mobile Devices
  * /api/v2/mobile-devices
@@ -2,6 +2,7 @@
 package jamf
 
 import (
+	"encoding/xml"
 	"time"
 
 	"github.com/gemini-oss/rego/pkg/common/cache"
@@ -24,11 +25,21 @@ type JamfToken struct {
 }
 
 type Client struct {
-	BaseURL    string           // Base URL for the Jamf Pro API.
-	ClassicURL string           // Base URL for the Jamf Pro Classic API.
-	HTTP       *requests.Client // HTTP client for making requests to the Jamf Pro API.
-	Log        *log.Logger      // Logger for the Jamf Pro client.
-	Cache      *cache.Cache     // Cache for the Jamf Pro client.
+	BaseURL       string                  // Base URL for the Jamf Pro API.
+	ClassicURL    string                  // Base URL for the Jamf Pro Classic API.
+	HTTP          *requests.Client        // HTTP client for making requests to the Jamf Pro API.
+	Log           *log.Logger             // Logger for the Jamf Pro client.
+	Cache         *cache.Cache            // Cache for the Jamf Pro client.
+	ComputerCache *cache.Typed[*Computer] // Typed cache for hot computer lookups.
+	Maintenance   *MaintenancePolicy      // Behavior while Jamf Cloud is in a maintenance window.
+}
+
+// MaintenancePolicy controls how requests answered with a Jamf Cloud maintenance page are retried
+type MaintenancePolicy struct {
+	Backoff        time.Duration // Wait between retries of a request answered with a maintenance page.
+	WaitForHealthy bool          // Pause until the health check passes instead of spending retries, then retry once.
+	PollInterval   time.Duration // Interval between health checks while paused.
+	MaxWait        time.Duration // Longest pause before giving up on the request.
 }
 
 // END OF JAMF CLIENT STRUCTS
@@ -315,6 +326,12 @@ type GroupMembership struct {
 	SmartGroup bool   `json:"smartGroup,omitempty"` // Indicates if the group is a smart group.
 }
 
+// SmartGroupMembership lists the computers of a smart group.
+// https://developer.jamf.com/jamf-pro/reference/get_v2-computer-groups-smart-group-membership-id
+type SmartGroupMembership struct {
+	Members []int `json:"members"` // IDs of the computers in the smart group.
+}
+
 // EnrollmentMethod represents the method of enrollment of a computer.
 type EnrollmentMethod struct {
 	ID         string `json:"id"`         // Identifier of the enrollment method.
@@ -586,6 +603,63 @@ type UDIDsNotProcessed struct {
 // END OF JAMF MANAGEMENT STRUCTS
 //---------------------------------------------------------------------
 
+// ### Jamf FileVault Structs
+// ---------------------------------------------------------------------
+// Response structure for the Jamf Pro API for FileVault inventory
+type FileVaultInventories struct {
+	Results    *[]*FileVaultInventory `json:"results"`    // List of FileVault inventory records.
+	TotalCount int                    `json:"totalCount"` // Total number of FileVault inventory records.
+}
+
+// Total() [FileVaultInventories] returns the total number of FileVault records in generic functions
+func (f FileVaultInventories) Total() int {
+	return f.TotalCount
+}
+
+// Append() [FileVaultInventories] Appends the results of FileVault records in generic functions to an existing list
+func (f FileVaultInventories) Append(result interface{}) {
+	more, ok := result.(*FileVaultInventories)
+	if !ok {
+		return
+	}
+	*f.Results = append(*f.Results, *more.Results...)
+}
+
+// FileVaultInventory represents the FileVault state (and escrowed recovery key) of a computer.
+// https://developer.jamf.com/jamf-pro/reference/get_v1-computers-inventory-id-filevault
+type FileVaultInventory struct {
+	ComputerID                          string              `json:"computerId,omitempty"`                          // Unique identifier for the computer.
+	Name                                string              `json:"name,omitempty"`                                // Name of the computer.
+	PersonalRecoveryKey                 string              `json:"personalRecoveryKey,omitempty"`                 // Escrowed personal recovery key. Only populated by GetRecoveryKey.
+	BootPartitionEncryptionDetails      *FileVaultPartition `json:"bootPartitionEncryptionDetails,omitempty"`      // Encryption details of the boot partition.
+	IndividualRecoveryKeyValidityStatus string              `json:"individualRecoveryKeyValidityStatus,omitempty"` // Validity of the escrowed key. {VALID, INVALID, UNKNOWN, NOT_APPLICABLE}
+	InstitutionalRecoveryKeyPresent     bool                `json:"institutionalRecoveryKeyPresent,omitempty"`     // Indicates if an institutional recovery key is present.
+	DiskEncryptionConfigurationName     string              `json:"diskEncryptionConfigurationName,omitempty"`     // Name of the disk encryption configuration applied to the computer.
+}
+
+// FileVaultPartition represents the encryption state of a partition.
+type FileVaultPartition struct {
+	PartitionName              string `json:"partitionName,omitempty"`              // Name of the partition.
+	PartitionFileVault2State   string `json:"partitionFileVault2State,omitempty"`   // FileVault 2 state of the partition.
+	PartitionFileVault2Percent int    `json:"partitionFileVault2Percent,omitempty"` // FileVault 2 percent of the partition.
+}
+
+// FileVaultAuditEvent describes a single recovery key access attempt. **ReGo only**
+type FileVaultAuditEvent struct {
+	Stage      string    // Stage of the access. {requested, retrieved, failed}
+	ComputerID string    // Jamf ID of the computer whose key is being accessed.
+	Requester  string    // Identity of the person requesting the key.
+	Reason     string    // Justification (e.g. ticket number) for the access.
+	Time       time.Time // Time the event was raised.
+	Error      error     // Error encountered during retrieval, if any.
+}
+
+// FileVaultAuditHook is called for each FileVaultAuditEvent. Returning an error from a `requested` event denies the retrieval. **ReGo only**
+type FileVaultAuditHook func(event *FileVaultAuditEvent) error
+
+// END OF JAMF FILEVAULT STRUCTS
+//---------------------------------------------------------------------
+
 // ### Jamf {Configuration Profile, Policy} Structs
 // ---------------------------------------------------------------------
 // Response structure for the Jamf Pro API for Configuration Profiles
@@ -695,6 +769,277 @@ type Security struct {
 // END OF JAMF {CONFIGURATION PROFILE, POLICY} STRUCTS
 //---------------------------------------------------------------------
 
+// ### Jamf {Mac App Store, eBook, Self Service} Structs
+// ---------------------------------------------------------------------
+// Response structure for the Jamf Classic API for Mac App Store applications
+type MacApplications struct {
+	List *[]*JamfProperty `json:"mac_applications"` // List of Mac App Store applications.
+}
+
+// MacApplication represents a Mac App Store application in the Jamf catalog.
+// https://developer.jamf.com/jamf-pro/reference/findmacappsbyid
+type MacApplication struct {
+	XMLName     xml.Name               `json:"-" xml:"mac_application"`
+	General     *MacApplicationGeneral `json:"general,omitempty" xml:"general,omitempty"`           // General application details.
+	Scope       *Scope                 `json:"scope,omitempty" xml:"scope,omitempty"`               // Scope of the application.
+	SelfService *CatalogSelfService    `json:"self_service,omitempty" xml:"self_service,omitempty"` // Self Service presentation of the application.
+}
+
+// MacApplicationGeneral represents the general details of a Mac App Store application.
+type MacApplicationGeneral struct {
+	*JamfProperty
+	Version        string    `json:"version,omitempty" xml:"version,omitempty"`                 // Version of the application.
+	IsFree         bool      `json:"is_free,omitempty" xml:"is_free,omitempty"`                 // Indicates if the application is free.
+	BundleID       string    `json:"bundle_id,omitempty" xml:"bundle_id,omitempty"`             // Bundle identifier of the application.
+	URL            string    `json:"url,omitempty" xml:"url,omitempty"`                         // App Store URL of the application.
+	Category       *Category `json:"category,omitempty" xml:"category,omitempty"`               // Category of the application.
+	Site           *Site     `json:"site,omitempty" xml:"site,omitempty"`                       // Site of the application.
+	DeploymentType string    `json:"deployment_type,omitempty" xml:"deployment_type,omitempty"` // Deployment type. {Make Available in Self Service, Install Automatically/Prompt Users to Install}
+}
+
+// Response structure for the Jamf Classic API for eBooks
+type Ebooks struct {
+	List *[]*JamfProperty `json:"ebooks"` // List of eBooks.
+}
+
+// Ebook represents an eBook in the Jamf catalog.
+// https://developer.jamf.com/jamf-pro/reference/findebooksbyid
+type Ebook struct {
+	XMLName     xml.Name            `json:"-" xml:"ebook"`
+	General     *EbookGeneral       `json:"general,omitempty" xml:"general,omitempty"`           // General eBook details.
+	Scope       *Scope              `json:"scope,omitempty" xml:"scope,omitempty"`               // Scope of the eBook.
+	SelfService *CatalogSelfService `json:"self_service,omitempty" xml:"self_service,omitempty"` // Self Service presentation of the eBook.
+}
+
+// EbookGeneral represents the general details of an eBook.
+type EbookGeneral struct {
+	*JamfProperty
+	Author         string    `json:"author,omitempty" xml:"author,omitempty"`                   // Author of the eBook.
+	Version        string    `json:"version,omitempty" xml:"version,omitempty"`                 // Version of the eBook.
+	Free           bool      `json:"free,omitempty" xml:"free,omitempty"`                       // Indicates if the eBook is free.
+	URL            string    `json:"url,omitempty" xml:"url,omitempty"`                         // Store URL of the eBook.
+	DeploymentType string    `json:"deployment_type,omitempty" xml:"deployment_type,omitempty"` // Deployment type. {Make Available in Self Service, Install Automatically/Prompt Users to Install}
+	Category       *Category `json:"category,omitempty" xml:"category,omitempty"`               // Category of the eBook.
+	Site           *Site     `json:"site,omitempty" xml:"site,omitempty"`                       // Site of the eBook.
+}
+
+// CatalogSelfService represents the Self Service presentation of a catalog item (Mac App Store app, eBook).
+type CatalogSelfService struct {
+	InstallButtonText           string                 `json:"install_button_text,omitempty" xml:"install_button_text,omitempty"`                         // Text on the install button.
+	SelfServiceDescription      string                 `json:"self_service_description,omitempty" xml:"self_service_description,omitempty"`               // Description shown in Self Service.
+	ForceUsersToViewDescription bool                   `json:"force_users_to_view_description,omitempty" xml:"force_users_to_view_description,omitempty"` // If users are forced to view the description.
+	SelfServiceIcon             *SelfServiceIcon       `json:"self_service_icon,omitempty" xml:"self_service_icon,omitempty"`                             // Icon shown in Self Service.
+	FeatureOnMainPage           bool                   `json:"feature_on_main_page,omitempty" xml:"feature_on_main_page,omitempty"`                       // If featured on the main page.
+	SelfServiceCategories       []*SelfServiceCategory `json:"self_service_categories,omitempty" xml:"self_service_categories>category,omitempty"`        // Categories the item is displayed in.
+	Notification                bool                   `json:"notification,omitempty" xml:"notification,omitempty"`                                       // If a notification is sent when the item is available.
+	NotificationSubject         string                 `json:"notification_subject,omitempty" xml:"notification_subject,omitempty"`                       // Notification subject.
+	NotificationMessage         string                 `json:"notification_message,omitempty" xml:"notification_message,omitempty"`                       // Notification message.
+}
+
+// SelfServiceIcon represents an icon uploaded to Jamf.
+type SelfServiceIcon struct {
+	ID   int    `json:"id,omitempty" xml:"id,omitempty"`     // ID of the icon.
+	URI  string `json:"uri,omitempty" xml:"uri,omitempty"`   // URI of the icon.
+	Data string `json:"data,omitempty" xml:"data,omitempty"` // Base64 encoded icon data.
+}
+
+// SelfServiceCategory represents a category a Self Service item is displayed in.
+type SelfServiceCategory struct {
+	ID        int    `json:"id,omitempty" xml:"id,omitempty"`                 // ID of the category.
+	Name      string `json:"name,omitempty" xml:"name,omitempty"`             // Name of the category.
+	DisplayIn bool   `json:"display_in,omitempty" xml:"display_in,omitempty"` // If the item is displayed in the category.
+	FeatureIn bool   `json:"feature_in,omitempty" xml:"feature_in,omitempty"` // If the item is featured in the category.
+}
+
+// Response structure for the Jamf Pro API for categories
+type Categories struct {
+	Results    *[]*CategoryDetail `json:"results"`    // List of categories.
+	TotalCount int                `json:"totalCount"` // Total number of categories.
+}
+
+// Total() [Categories] returns the total number of categories in generic functions
+func (c Categories) Total() int {
+	return c.TotalCount
+}
+
+// Append() [Categories] Appends the results of Categories in generic functions to an existing list
+func (c Categories) Append(result interface{}) {
+	more, ok := result.(*Categories)
+	if !ok {
+		return
+	}
+	*c.Results = append(*c.Results, *more.Results...)
+}
+
+// CategoryDetail represents a category in the Jamf Pro API.
+// https://developer.jamf.com/jamf-pro/reference/get_v1-categories
+type CategoryDetail struct {
+	ID       string `json:"id,omitempty"`       // ID of the category.
+	Name     string `json:"name,omitempty"`     // Name of the category.
+	Priority int    `json:"priority,omitempty"` // Priority of the category (1-20).
+}
+
+// IconDetail represents an icon in the Jamf Pro API.
+// https://developer.jamf.com/jamf-pro/reference/get_v1-icon-id
+type IconDetail struct {
+	ID   int    `json:"id,omitempty"`   // ID of the icon.
+	Name string `json:"name,omitempty"` // File name of the icon.
+	URL  string `json:"url,omitempty"`  // URL of the icon.
+}
+
+// SelfServiceSettings represents the global Self Service settings.
+// https://developer.jamf.com/jamf-pro/reference/get_v1-self-service-settings
+type SelfServiceSettings struct {
+	InstallSettings *struct {
+		InstallAutomatically bool   `json:"installAutomatically"`      // Install Self Service automatically on managed computers.
+		InstallLocation      string `json:"installLocation,omitempty"` // Location Self Service is installed to.
+	} `json:"installSettings,omitempty"` // Installation settings.
+	LoginSettings *struct {
+		UserLoginLevel  string `json:"userLoginLevel,omitempty"` // Login requirement. {NotRequired, Anonymous, Required}
+		AllowRememberMe bool   `json:"allowRememberMe"`          // Allow users to be remembered.
+		AuthType        string `json:"authType,omitempty"`       // Authentication type. {Basic, Saml}
+	} `json:"loginSettings,omitempty"` // Login settings.
+	ConfigurationSettings *struct {
+		NotificationsEnabled  bool   `json:"notificationsEnabled"`            // Enable Self Service notifications.
+		AlertUserApprovedMdm  bool   `json:"alertUserApprovedMdm"`            // Alert users to approve MDM.
+		DefaultLandingPage    string `json:"defaultLandingPage,omitempty"`    // Default landing page. {HOME, BROWSE, HISTORY, NOTIFICATIONS}
+		DefaultHomeCategoryID int    `json:"defaultHomeCategoryId,omitempty"` // Default category shown on the home page.
+		BookmarksName         string `json:"bookmarksName,omitempty"`         // Name of the bookmarks section.
+	} `json:"configurationSettings,omitempty"` // Configuration settings.
+}
+
+// AppCatalogEntry is the desired Self Service presentation of a catalog item. **ReGo only**
+type AppCatalogEntry struct {
+	Name              string // Name (or bundle ID) of the Mac App Store application.
+	Description       string // Self Service description.
+	Category          string // Name of the Jamf category.
+	IconID            int    // ID of an uploaded icon; ignored when 0.
+	FeatureOnMainPage bool   // Feature the application on the Self Service main page.
+	InstallButtonText string // Text on the install button; ignored when empty.
+}
+
+// END OF JAMF {MAC APP STORE, EBOOK, SELF SERVICE} STRUCTS
+//---------------------------------------------------------------------
+
+// ### Jamf Volume Purchasing Structs
+// ---------------------------------------------------------------------
+type VolumePurchasingLocations struct {
+	Results    *[]*VolumePurchasingLocation `json:"results"`    // List of volume purchasing locations.
+	TotalCount int                          `json:"totalCount"` // Total number of volume purchasing locations.
+}
+
+// Total() [VolumePurchasingLocations] returns the total number of locations in generic functions
+func (v VolumePurchasingLocations) Total() int {
+	return v.TotalCount
+}
+
+// Append() [VolumePurchasingLocations] Appends the results of VolumePurchasingLocations in generic functions to an existing list
+func (v VolumePurchasingLocations) Append(result interface{}) {
+	more, ok := result.(*VolumePurchasingLocations)
+	if !ok {
+		return
+	}
+	*v.Results = append(*v.Results, *more.Results...)
+}
+
+// VolumePurchasingLocation represents a VPP location (content token) in the Jamf Pro API.
+// https://developer.jamf.com/jamf-pro/reference/get_v1-volume-purchasing-locations
+type VolumePurchasingLocation struct {
+	ID                                    string `json:"id,omitempty"`                                    // ID of the location.
+	Name                                  string `json:"name,omitempty"`                                  // Name of the location.
+	AppleID                               string `json:"appleId,omitempty"`                               // Apple ID associated with the content token.
+	OrganizationName                      string `json:"organizationName,omitempty"`                      // Organization the content token belongs to.
+	LocationName                          string `json:"locationName,omitempty"`                          // Apple Business Manager location name.
+	TokenExpiration                       string `json:"tokenExpiration,omitempty"`                       // Expiration date of the content token.
+	CountryCode                           string `json:"countryCode,omitempty"`                           // Country code of the location.
+	AutomaticallyPopulatePurchasedContent bool   `json:"automaticallyPopulatePurchasedContent,omitempty"` // Whether purchased content is added automatically.
+	SendNotificationWhenNoLongerAssigned  bool   `json:"sendNotificationWhenNoLongerAssigned,omitempty"`  // Whether users are notified when content is revoked.
+	AutoRegisterManagedUsers              bool   `json:"autoRegisterManagedUsers,omitempty"`              // Whether managed users are registered automatically.
+	SiteID                                string `json:"siteId,omitempty"`                                // Site the location belongs to.
+	LastSyncTime                          string `json:"lastSyncTime,omitempty"`                          // Time of the last sync with Apple.
+	TotalPurchasedLicenses                int    `json:"totalPurchasedLicenses,omitempty"`                // Total number of purchased licenses.
+	TotalUsedLicenses                     int    `json:"totalUsedLicenses,omitempty"`                     // Total number of licenses in use.
+}
+
+type VolumePurchasingContents struct {
+	Results    *[]*VolumePurchasingContent `json:"results"`    // List of purchased content.
+	TotalCount int                         `json:"totalCount"` // Total number of purchased content items.
+}
+
+// Total() [VolumePurchasingContents] returns the total number of content items in generic functions
+func (v VolumePurchasingContents) Total() int {
+	return v.TotalCount
+}
+
+// Append() [VolumePurchasingContents] Appends the results of VolumePurchasingContents in generic functions to an existing list
+func (v VolumePurchasingContents) Append(result interface{}) {
+	more, ok := result.(*VolumePurchasingContents)
+	if !ok {
+		return
+	}
+	*v.Results = append(*v.Results, *more.Results...)
+}
+
+// VolumePurchasingContent represents an app or book purchased through a VPP location.
+// https://developer.jamf.com/jamf-pro/reference/get_v1-volume-purchasing-locations-id-content
+type VolumePurchasingContent struct {
+	Name                 string   `json:"name,omitempty"`                 // Name of the content.
+	LicenseCountTotal    int      `json:"licenseCountTotal,omitempty"`    // Number of purchased licenses.
+	LicenseCountInUse    int      `json:"licenseCountInUse,omitempty"`    // Number of licenses assigned.
+	LicenseCountReported int      `json:"licenseCountReported,omitempty"` // Number of licenses reported as assigned by Apple.
+	IconURL              string   `json:"iconUrl,omitempty"`              // URL of the content icon.
+	DeviceTypes          []string `json:"deviceTypes,omitempty"`          // Device types the content supports.
+	ContentType          string   `json:"contentType,omitempty"`          // Type of content. {IOS_APP, MAC_APP, BOOK, ...}
+	PricingParam         string   `json:"pricingParam,omitempty"`         // Pricing parameter. {STDQ, PLUS}
+	AdamID               string   `json:"adamId,omitempty"`               // App Store identifier of the content.
+}
+
+// END OF JAMF VOLUME PURCHASING STRUCTS
+//---------------------------------------------------------------------
+
+// ### Jamf MDM Command Structs
+// ---------------------------------------------------------------------
+// MDMCommand is the request body of /api/v2/mdm/commands
+type MDMCommand struct {
+	ClientData  []*MDMClientData `json:"clientData"`  // Devices to send the command to.
+	CommandData *MDMCommandData  `json:"commandData"` // The command and its parameters.
+}
+
+type MDMClientData struct {
+	ManagementID string `json:"managementId"` // Management ID of the device.
+}
+
+type MDMCommandData struct {
+	CommandType string `json:"commandType"`           // Type of command, e.g. DEVICE_LOCK, ERASE_DEVICE, RESTART_DEVICE.
+	Pin         string `json:"pin,omitempty"`         // Six digit PIN required to unlock a locked (or erased) computer.
+	Message     string `json:"message,omitempty"`     // Message displayed on the lock screen.
+	PhoneNumber string `json:"phoneNumber,omitempty"` // Phone number displayed on the lock screen.
+}
+
+// MDMCommandResult identifies a queued command
+type MDMCommandResult struct {
+	ID   string `json:"id"`   // ID of the queued command.
+	Href string `json:"href"` // Link to the command.
+}
+
+// END OF JAMF MDM COMMAND STRUCTS
+//---------------------------------------------------------------------
+
+// ### Jamf Health Structs
+// ---------------------------------------------------------------------
+// HealthCheck is the response of /healthCheck.html; an empty list means the server is healthy
+type HealthCheck []*HealthCheckIssue
+
+// HealthCheckIssue describes why a Jamf Pro server is not ready to serve requests
+type HealthCheckIssue struct {
+	HealthCode  int    `json:"healthCode"`  // Jamf's health code, e.g. 1 (database error), 4 (initializing).
+	HTTPCode    int    `json:"httpCode"`    // Status code the server answers requests with.
+	Description string `json:"description"` // Description of the issue, e.g. `Initializing`.
+}
+
+// END OF JAMF HEALTH STRUCTS
+//---------------------------------------------------------------------
+
 // ### Enums
 // --------------------------------------------------------------------
 // Inteded for Device Query parameters, `Sections` serves as a namespace for valid Computer Detail section constants.
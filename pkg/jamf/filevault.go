@@ -0,0 +1,143 @@
+/*
+# Jamf - FileVault
+
+This package initializes all the methods for functions which interact with the Jamf FileVault endpoints:
+- https://developer.jamf.com/jamf-pro/reference/get_v1-computers-inventory-filevault
+- https://developer.jamf.com/jamf-pro/reference/get_v1-computers-inventory-id-filevault
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/jamf/filevault.go
+package jamf
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+var (
+	ComputersFileVault = fmt.Sprintf("%s/filevault", ComputersInventory) // /api/v1/computers-inventory/filevault
+)
+
+// FileVaultClient for chaining methods
+type FileVaultClient struct {
+	client *Client
+	hooks  []FileVaultAuditHook
+}
+
+// Entry point for FileVault-related operations
+func (c *Client) FileVault() *FileVaultClient {
+	return &FileVaultClient{
+		client: c,
+	}
+}
+
+// AuditHook registers a hook which is called for every recovery key access attempt
+func (fc *FileVaultClient) AuditHook(hook FileVaultAuditHook) *FileVaultClient {
+	fc.hooks = append(fc.hooks, hook)
+	return fc
+}
+
+/*
+ * # Get FileVault Status for all Computers
+ * /api/v1/computers-inventory/filevault
+ * - https://developer.jamf.com/jamf-pro/reference/get_v1-computers-inventory-filevault
+ * - Recovery keys are stripped from the results; use GetRecoveryKey to retrieve one.
+ */
+func (fc *FileVaultClient) ListAllFileVaultStatus() (*FileVaultInventories, error) {
+	url := fc.client.BuildURL(ComputersFileVault)
+
+	q := &DeviceQuery{
+		Page:     0,
+		PageSize: 100,
+	}
+
+	inventory, err := doConcurrent[FileVaultInventories](fc.client, "GET", url, q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if inventory.Results != nil {
+		for _, fv := range *inventory.Results {
+			fv.PersonalRecoveryKey = ""
+		}
+	}
+
+	return inventory, nil
+}
+
+/*
+ * # Get FileVault Recovery Key for a Computer
+ * /api/v1/computers-inventory/{id}/filevault
+ * - https://developer.jamf.com/jamf-pro/reference/get_v1-computers-inventory-id-filevault
+ * - Every attempt is logged and passed to the registered audit hooks; a hook returning an error denies the retrieval.
+ * - Results are never cached.
+ */
+func (fc *FileVaultClient) GetRecoveryKey(id, requester, reason string) (*FileVaultInventory, error) {
+	if requester == "" || reason == "" {
+		return nil, fmt.Errorf("a requester and reason are required to retrieve a FileVault recovery key")
+	}
+
+	event := &FileVaultAuditEvent{
+		Stage:      "requested",
+		ComputerID: id,
+		Requester:  requester,
+		Reason:     reason,
+		Time:       time.Now(),
+	}
+	if err := fc.audit(event); err != nil {
+		return nil, fmt.Errorf("recovery key retrieval denied: %w", err)
+	}
+
+	fv, err := fc.getRecoveryKey(id)
+
+	event.Stage = "retrieved"
+	event.Time = time.Now()
+	event.Error = err
+	if err != nil {
+		event.Stage = "failed"
+	}
+	fc.audit(event)
+
+	return fv, err
+}
+
+func (fc *FileVaultClient) getRecoveryKey(id string) (*FileVaultInventory, error) {
+	url := fc.client.BuildURL(ComputersInventory, id, "filevault")
+
+	res, body, err := fc.client.request("GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	fc.client.Log.Println("Response Status:", res.Status)
+
+	fv := &FileVaultInventory{}
+	err = json.Unmarshal(body, fv)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshalling filevault inventory: %w", err)
+	}
+
+	if fv.PersonalRecoveryKey == "" {
+		return nil, fmt.Errorf("no personal recovery key escrowed for computer %s", id)
+	}
+
+	return fv, nil
+}
+
+// audit logs the event (never the key itself) and runs each registered hook
+func (fc *FileVaultClient) audit(event *FileVaultAuditEvent) error {
+	fc.client.Log.Warningf("FileVault recovery key %s: computer=%s requester=%s reason=%q error=%v", event.Stage, event.ComputerID, event.Requester, event.Reason, event.Error)
+
+	for _, hook := range fc.hooks {
+		if err := hook(event); err != nil {
+			fc.client.Log.Errorf("FileVault audit hook returned an error for computer %s: %v", event.ComputerID, err)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+/*
+# Jamf - Generated Entities
+
+This package contains the entities and paths generated from the Jamf Pro API's OpenAPI schema (see pkg/internal/openapigen),
+for endpoints rego has no hand-written structs for. Call them with jamf.Generated.
+
+To regenerate, save the schema of a Jamf Pro server (served without authentication) next to this file and run `go generate`:
+
+	curl -o schema.json "https://$JSS_URL/api/schema"
+	go generate ./pkg/jamf/gen
+
+Hand-written entities of pkg/jamf are excluded, so each type has a single definition.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/jamf/gen/gen.go
+package gen
+
+//go:generate go run ../../internal/openapigen -in schema.json -out entities_gen.go -exclude ComputerInventory,MobileDevice,AuthToken
@@ -0,0 +1,42 @@
+/*
+# Jamf - Generated Endpoints
+
+This package calls Jamf Pro API endpoints which have no hand-written methods yet, with the entities and paths generated
+from the server's OpenAPI schema into pkg/jamf/gen:
+- https://developer.jamf.com/jamf-pro/reference/jamf-pro-api
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/jamf/generated.go
+package jamf
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+/*
+ * Generated performs a request to a path of the Jamf Pro API, e.g. one of the gen.Path constants,
+ * replacing its `{placeholders}` with params in order and decoding the response into T:
+ *
+ *	groups, err := jamf.Generated[gen.ComputerGroupsSearchResults](c, http.MethodGet, gen.PathV2ComputerGroups, nil, nil)
+ */
+func Generated[T any](c *Client, method string, path string, query interface{}, data interface{}, params ...interface{}) (T, error) {
+	for _, param := range params {
+		start := strings.Index(path, "{")
+		end := strings.Index(path, "}")
+		if start < 0 || end < start {
+			return *new(T), fmt.Errorf("%s has no placeholder for %v", path, param)
+		}
+		path = path[:start] + url.PathEscape(fmt.Sprint(param)) + path[end+1:]
+	}
+	if strings.Contains(path, "{") {
+		return *new(T), fmt.Errorf("%s is missing parameters", path)
+	}
+
+	return do[T](c, method, c.BuildURL("%s"+path), query, data)
+}
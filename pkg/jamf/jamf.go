@@ -14,8 +14,10 @@ This package initializes all the methods for functions which interact with the J
 package jamf
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"strings"
 	"sync"
@@ -24,7 +26,9 @@ import (
 	"github.com/gemini-oss/rego/pkg/common/cache"
 	"github.com/gemini-oss/rego/pkg/common/config"
 	"github.com/gemini-oss/rego/pkg/common/log"
+	"github.com/gemini-oss/rego/pkg/common/ratelimit"
 	"github.com/gemini-oss/rego/pkg/common/requests"
+	"github.com/gemini-oss/rego/pkg/common/schema"
 )
 
 var (
@@ -46,6 +50,16 @@ func (c *Client) BuildURL(endpoint string, identifiers ...interface{}) string {
 	return url
 }
 
+/*
+ * WithContext returns a copy of the client whose requests end when ctx is done, e.g. to cancel a long pagination
+ */
+func (c *Client) WithContext(ctx context.Context) *Client {
+	clone := *c
+	clone.HTTP = c.HTTP.WithContext(ctx)
+	clone.Log = c.Log.WithContext(ctx)
+	return &clone
+}
+
 // BuildClassicURL builds a URL for a given resource and identifiers.
 func (c *Client) BuildClassicURL(endpoint string, identifiers ...interface{}) string {
 	url := fmt.Sprintf(endpoint, c.ClassicURL)
@@ -57,6 +71,13 @@ func (c *Client) BuildClassicURL(endpoint string, identifiers ...interface{}) st
 	return url
 }
 
+// UseCache() enables caching, including the typed cache of GetComputerDetails, for the calls of the client
+func (c *Client) UseCache() *Client {
+	c.Cache.Enabled = true
+	c.ComputerCache.SetEnabled(true)
+	return c
+}
+
 /*
  * SetCache stores a Jamf API response in the cache
  */
@@ -93,12 +114,17 @@ func (c *Client) GetCache(key string, target interface{}) bool {
  * - https://developer.jamf.com/jamf-pro/reference/post_v1-auth-token
  */
 func GetToken(baseURL string) (*JamfToken, error) {
+	return getToken(baseURL, config.NewProfile())
+}
+
+// getToken creates a token with the credentials of a client's profile
+func getToken(baseURL string, profile config.Profile) (*JamfToken, error) {
 	url := fmt.Sprintf(V1_AuthToken, baseURL)
 
 	// Prepare the credentials for Basic Auth
 	creds := Credentials{
-		Username: config.GetEnv("JSS_USERNAME"),
-		Password: config.GetEnv("JSS_PASSWORD"),
+		Username: profile.GetEnv("JSS_USERNAME"),
+		Password: profile.GetEnv("JSS_PASSWORD"),
 	}
 	if len(creds.Username) == 0 || len(creds.Password) == 0 {
 		return nil, fmt.Errorf("JSS_USERNAME or JSS_PASSWORD is not set")
@@ -129,9 +155,10 @@ func GetToken(baseURL string) (*JamfToken, error) {
 /*
  * Create a new Jamf Client
  */
-func NewClient(verbosity int) *Client {
+func NewClient(verbosity int, opts ...config.Option) *Client {
+	profile := config.NewProfile(opts...)
 
-	url := config.GetEnv("JSS_URL") // https://yourserver.jamfcloud.com
+	url := profile.GetEnv("JSS_URL") // https://yourserver.jamfcloud.com
 	if len(url) == 0 {
 		panic("JSS_URL is not set.")
 	}
@@ -143,7 +170,7 @@ func NewClient(verbosity int) *Client {
 	BaseURL := fmt.Sprintf(BaseURL, url)
 	ClassicURL := fmt.Sprintf(ClassicURL, url)
 
-	token, err := GetToken(BaseURL)
+	token, err := getToken(BaseURL, profile)
 	if err != nil {
 		panic(err)
 	}
@@ -157,23 +184,40 @@ func NewClient(verbosity int) *Client {
 	}
 
 	// Look into `Functional Options` patterns for a better way to handle this (and othe clients while we're at it)
-	encryptionKey := []byte(config.GetEnv("REGO_ENCRYPTION_KEY"))
+	encryptionKey := []byte(profile.GetEnv("REGO_ENCRYPTION_KEY"))
 	if len(encryptionKey) == 0 {
 		panic("REGO_ENCRYPTION_KEY is not set.")
 	}
 
-	cache, err := cache.NewCache(encryptionKey, "rego_cache_jamf.gob")
+	computerCache := cache.NewTyped[*Computer](5*time.Minute, 1*time.Minute, 10000)
+
+	cache, err := cache.NewCache(encryptionKey, "rego_cache_jamf.gob", profile)
 	if err != nil {
 		panic(err)
 	}
 
-	return &Client{
-		BaseURL:    BaseURL,
-		ClassicURL: ClassicURL,
-		HTTP:       requests.NewClient(nil, headers, nil),
-		Log:        log.NewLogger("{jamf}", verbosity),
-		Cache:      cache,
+	hc := requests.NewClient(nil, headers, nil)
+	hc.CompressRequests = true
+
+	// Jamf Cloud does not publish rate limits; sandbox servers can be throttled with JSS_RATE_LIMIT (requests per minute)
+	if limit := profile.GetEnvAsInt("JSS_RATE_LIMIT"); limit > 0 {
+		hc.RateLimiter = ratelimit.NewRateLimiter(limit, 1*time.Minute)
 	}
+
+	c := &Client{
+		BaseURL:       BaseURL,
+		ClassicURL:    ClassicURL,
+		HTTP:          hc,
+		Log:           log.NewLogger("{jamf}", verbosity),
+		Cache:         cache,
+		ComputerCache: computerCache,
+		Maintenance:   DefaultMaintenancePolicy(),
+	}
+
+	// Jamf Cloud answers every request with a 503 during maintenance windows; wait those out instead of burning retries
+	hc.Apply(requests.WithBackoff(c.backoff))
+
+	return c
 }
 
 // JamfResult is an interface for Jamf API responses involving pagination
@@ -187,7 +231,7 @@ type JamfAPIResponse interface {
  */
 func do[T any](c *Client, method string, url string, query interface{}, data interface{}) (T, error) {
 	var result T
-	res, body, err := c.HTTP.DoRequest(method, url, query, data)
+	res, body, err := c.request(method, url, query, data)
 	if err != nil {
 		return *new(T), err
 	}
@@ -195,7 +239,7 @@ func do[T any](c *Client, method string, url string, query interface{}, data int
 	c.Log.Println("Response Status:", res.Status)
 	c.Log.Debug("Response Body:", string(body))
 
-	err = json.Unmarshal(body, &result)
+	err = schema.Unmarshal(body, &result)
 	if err != nil {
 		return *new(T), fmt.Errorf("unmarshalling error: %w", err)
 	}
@@ -203,6 +247,30 @@ func do[T any](c *Client, method string, url string, query interface{}, data int
 	return result, nil
 }
 
+/*
+ * Perform a create/update request to the Jamf Classic API
+ * The Classic API accepts XML payloads and responds with the ID of the written object
+ */
+func doClassicWrite(c *Client, method string, url string, data interface{}) (string, error) {
+	res, body, err := c.request(method, url, nil, data)
+	if err != nil {
+		return "", err
+	}
+
+	c.Log.Println("Response Status:", res.Status)
+	c.Log.Debug("Response Body:", string(body))
+
+	var result struct {
+		ID string `xml:"id"`
+	}
+	err = xml.Unmarshal(body, &result)
+	if err != nil {
+		return "", fmt.Errorf("unmarshalling error: %w", err)
+	}
+
+	return result.ID, nil
+}
+
 /*
  * Perform a concurrent generic request to the Jamf API
  */
@@ -0,0 +1,164 @@
+/*
+# Jamf - Maintenance
+
+This package handles Jamf Cloud maintenance windows, during which every request is answered with a 503 maintenance
+page, by backing off for longer than usual or pausing until the server's health check passes:
+- https://learn.jamf.com/bundle/technical-articles/page/Jamf_Pro_Server_Health_Check.html
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/jamf/maintenance.go
+package jamf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/config"
+	"github.com/gemini-oss/rego/pkg/common/requests"
+)
+
+const (
+	DefaultMaintenanceBackoff = 2 * time.Minute  // Wait between retries during a maintenance window
+	DefaultHealthPollInterval = 30 * time.Second // Interval between health checks while paused
+	DefaultMaintenanceWait    = 1 * time.Hour    // Longest pause for a maintenance window to end
+	HealthCheckTimeout        = 10 * time.Second // Longest wait for a single health check
+)
+
+// healthClient sends health checks; a server starting up may accept connections long before it answers them
+var healthClient = &http.Client{Timeout: HealthCheckTimeout}
+
+// maintenanceMarkers are found in the body of the pages Jamf Cloud serves while a server is unavailable
+var maintenanceMarkers = []string{"maintenance", "initializing", "<html"}
+
+/*
+ * DefaultMaintenancePolicy reads the maintenance behavior from the environment
+ * - JSS_MAINTENANCE_BACKOFF: wait between retries, e.g. "5m"
+ * - JSS_MAINTENANCE_WAIT: when set, pause for up to this long (e.g. "2h") until the health check passes
+ */
+func DefaultMaintenancePolicy() *MaintenancePolicy {
+	policy := &MaintenancePolicy{
+		Backoff:      DefaultMaintenanceBackoff,
+		PollInterval: DefaultHealthPollInterval,
+		MaxWait:      DefaultMaintenanceWait,
+	}
+	if backoff, err := time.ParseDuration(config.GetEnv("JSS_MAINTENANCE_BACKOFF")); err == nil && backoff > 0 {
+		policy.Backoff = backoff
+	}
+	if wait, err := time.ParseDuration(config.GetEnv("JSS_MAINTENANCE_WAIT")); err == nil && wait > 0 {
+		policy.WaitForHealthy = true
+		policy.MaxWait = wait
+	}
+	return policy
+}
+
+// IsMaintenance reports whether a request failed because the Jamf server is in a maintenance window
+func IsMaintenance(err error) bool {
+	var statusErr *requests.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable {
+		return false
+	}
+
+	body := strings.ToLower(string(statusErr.Body))
+	for _, marker := range maintenanceMarkers {
+		if strings.Contains(body, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff waits out maintenance windows for longer than the default backoff, or stops retrying when the client pauses instead
+func (c *Client) backoff(attempt int, err error) (time.Duration, bool) {
+	if c.Maintenance == nil || !IsMaintenance(err) {
+		return requests.DefaultBackoff(attempt, err)
+	}
+	if c.Maintenance.WaitForHealthy {
+		return 0, false
+	}
+
+	wait := c.Maintenance.Backoff
+	var statusErr *requests.StatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > wait {
+		wait = statusErr.RetryAfter
+	}
+	c.Log.Warningf("Jamf is in a maintenance window; retrying in %s", wait)
+	return wait, true
+}
+
+/*
+ * # Check the Health of the Jamf Pro Server
+ * /healthCheck.html
+ * - https://learn.jamf.com/bundle/technical-articles/page/Jamf_Pro_Server_Health_Check.html
+ */
+func (c *Client) HealthCheck() (HealthCheck, error) {
+	url := fmt.Sprintf("%s/healthCheck.html", strings.TrimSuffix(c.BaseURL, "/api"))
+
+	// Sent once, without retries; the caller decides how often to poll
+	req, err := http.NewRequestWithContext(c.HTTP.Context(), "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := healthClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	health := HealthCheck{}
+	if err := json.NewDecoder(res.Body).Decode(&health); err != nil {
+		if res.StatusCode != http.StatusOK {
+			return HealthCheck{{HTTPCode: res.StatusCode, Description: res.Status}}, nil
+		}
+		return nil, fmt.Errorf("decoding health check: %w", err)
+	}
+	return health, nil
+}
+
+// WaitUntilHealthy polls the health check until the server reports no issues, or fails after `timeout` or when the client's context is done
+func (c *Client) WaitUntilHealthy(interval, timeout time.Duration) error {
+	ctx := c.HTTP.Context()
+	deadline := time.Now().Add(timeout)
+	for {
+		health, err := c.HealthCheck()
+		if err == nil && len(health) == 0 {
+			return nil
+		}
+		if time.Now().Add(interval).After(deadline) {
+			if err != nil {
+				return fmt.Errorf("jamf did not become healthy within %s: %w", timeout, err)
+			}
+			return fmt.Errorf("jamf did not become healthy within %s: %s", timeout, health[0].Description)
+		}
+		c.Log.Debug("Waiting for Jamf to become healthy:", health, err)
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("waiting for jamf to become healthy: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// request sends a request, pausing until the server is healthy and retrying once if it is in a maintenance window
+func (c *Client) request(method string, url string, query interface{}, data interface{}) (*http.Response, []byte, error) {
+	res, body, err := c.HTTP.DoRequest(method, url, query, data)
+	if err == nil || c.Maintenance == nil || !c.Maintenance.WaitForHealthy || !IsMaintenance(err) {
+		return res, body, err
+	}
+
+	c.Log.Warningf("Jamf is in a maintenance window; pausing for up to %s until it is healthy", c.Maintenance.MaxWait)
+	if waitErr := c.WaitUntilHealthy(c.Maintenance.PollInterval, c.Maintenance.MaxWait); waitErr != nil {
+		return nil, body, fmt.Errorf("%w (%v)", err, waitErr)
+	}
+	return c.HTTP.DoRequest(method, url, query, data)
+}
@@ -39,7 +39,7 @@ func (c *Client) RenewMDMProfile(udids []string) (*ManagementResponse, error) {
 		"udids": udids,
 	}
 
-	res, body, err := c.HTTP.DoRequest("POST", url, nil, payload)
+	res, body, err := c.request("POST", url, nil, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +62,7 @@ func (c *Client) RenewMDMProfile(udids []string) (*ManagementResponse, error) {
 func (c *Client) RepairManagementFramework(id string) (string, error) {
 	url := c.BuildURL(fmt.Sprintf("%s/redeploy/%s", ManagementFramework, id))
 
-	res, body, err := c.HTTP.DoRequest("POST", url, nil, nil)
+	res, body, err := c.request("POST", url, nil, nil)
 	if err != nil {
 		return "", err
 	}
@@ -13,8 +13,74 @@ This package initializes all the methods for functions which interact with the J
 // pkg/jamf/mdm.go
 package jamf
 
+import (
+	"fmt"
+)
+
+var (
+	MDMCommands = fmt.Sprintf("%s/mdm/commands", V2) // /api/v2/mdm/commands
+)
+
 /*
  * # Renew MDM Profile
  * /api/v1/mdm/renew-profile
  * - https://developer.jamf.com/jamf-pro/reference/post_v1-mdm-renew-profile
  */
+
+/*
+ * # Send an MDM Command
+ * /api/v2/mdm/commands
+ * - https://developer.jamf.com/jamf-pro/reference/post_v2-mdm-commands
+ */
+func (c *Client) SendMDMCommand(command *MDMCommand) (*[]MDMCommandResult, error) {
+	url := c.BuildURL(MDMCommands)
+
+	results, err := do[[]MDMCommandResult](c, "POST", url, nil, command)
+	if err != nil {
+		return nil, err
+	}
+
+	return &results, nil
+}
+
+/*
+ * # Lock a Device
+ * Sends the DEVICE_LOCK command to the devices with the given management IDs (see General.ManagementID)
+ * - https://developer.jamf.com/jamf-pro/reference/post_v2-mdm-commands
+ * @param pin string - Six digit PIN needed to unlock a computer (ignored by mobile devices)
+ * @param message string - Message shown on the lock screen
+ */
+func (c *Client) LockDevices(managementIDs []string, pin, message string) (*[]MDMCommandResult, error) {
+	command := &MDMCommand{
+		CommandData: &MDMCommandData{
+			CommandType: "DEVICE_LOCK",
+			Pin:         pin,
+			Message:     message,
+		},
+	}
+	for _, id := range managementIDs {
+		command.ClientData = append(command.ClientData, &MDMClientData{ManagementID: id})
+	}
+
+	return c.SendMDMCommand(command)
+}
+
+/*
+ * # Wipe a Device
+ * Sends the ERASE_DEVICE command to the devices with the given management IDs (see General.ManagementID)
+ * - https://developer.jamf.com/jamf-pro/reference/post_v2-mdm-commands
+ * @param pin string - Six digit PIN needed to unlock an erased computer (ignored by mobile devices)
+ */
+func (c *Client) WipeDevices(managementIDs []string, pin string) (*[]MDMCommandResult, error) {
+	command := &MDMCommand{
+		CommandData: &MDMCommandData{
+			CommandType: "ERASE_DEVICE",
+			Pin:         pin,
+		},
+	}
+	for _, id := range managementIDs {
+		command.ClientData = append(command.ClientData, &MDMClientData{ManagementID: id})
+	}
+
+	return c.SendMDMCommand(command)
+}
@@ -0,0 +1,135 @@
+/*
+# Jamf - Self Service
+
+This package initializes all the methods for functions which interact with the Jamf Pro API for Self Service, categories and icons:
+- https://developer.jamf.com/jamf-pro/reference/get_v1-self-service-settings
+- https://developer.jamf.com/jamf-pro/reference/get_v1-categories
+- https://developer.jamf.com/jamf-pro/reference/get_v1-icon-id
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/jamf/selfservice.go
+package jamf
+
+import (
+	"fmt"
+	"time"
+)
+
+var (
+	SelfServiceSettingsURL = fmt.Sprintf("%s/self-service/settings", V1) // /api/v1/self-service/settings
+	CategoriesURL          = fmt.Sprintf("%s/categories", V1)            // /api/v1/categories
+	IconURL                = fmt.Sprintf("%s/icon", V1)                  // /api/v1/icon
+)
+
+/*
+ * # Get Self Service Settings
+ * /api/v1/self-service/settings
+ * - https://developer.jamf.com/jamf-pro/reference/get_v1-self-service-settings
+ */
+func (c *Client) GetSelfServiceSettings() (*SelfServiceSettings, error) {
+	url := c.BuildURL(SelfServiceSettingsURL)
+
+	settings, err := do[SelfServiceSettings](c, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+/*
+ * # Update Self Service Settings
+ * /api/v1/self-service/settings
+ * - https://developer.jamf.com/jamf-pro/reference/put_v1-self-service-settings
+ */
+func (c *Client) UpdateSelfServiceSettings(settings *SelfServiceSettings) (*SelfServiceSettings, error) {
+	url := c.BuildURL(SelfServiceSettingsURL)
+
+	updated, err := do[SelfServiceSettings](c, "PUT", url, nil, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+/*
+ * # List All Categories
+ * /api/v1/categories
+ * - https://developer.jamf.com/jamf-pro/reference/get_v1-categories
+ */
+func (c *Client) ListAllCategories() (*Categories, error) {
+	url := c.BuildURL(CategoriesURL)
+
+	var cache Categories
+	if c.GetCache(url, &cache) {
+		return &cache, nil
+	}
+
+	q := &DeviceQuery{
+		Page:     0,
+		PageSize: 100,
+	}
+
+	categories, err := doConcurrent[Categories](c, "GET", url, q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.SetCache(url, categories, 5*time.Minute)
+	return categories, nil
+}
+
+/*
+ * # Create a Category
+ * /api/v1/categories
+ * - https://developer.jamf.com/jamf-pro/reference/post_v1-categories
+ */
+func (c *Client) CreateCategory(category *CategoryDetail) (string, error) {
+	url := c.BuildURL(CategoriesURL)
+
+	res, err := do[struct {
+		ID string `json:"id"`
+	}](c, "POST", url, nil, category)
+	if err != nil {
+		return "", err
+	}
+
+	return res.ID, nil
+}
+
+/*
+ * # Update a Category
+ * /api/v1/categories/{id}
+ * - https://developer.jamf.com/jamf-pro/reference/put_v1-categories-id
+ */
+func (c *Client) UpdateCategory(id string, category *CategoryDetail) (*CategoryDetail, error) {
+	url := c.BuildURL(CategoriesURL, id)
+
+	updated, err := do[CategoryDetail](c, "PUT", url, nil, category)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+/*
+ * # Get an Icon
+ * /api/v1/icon/{id}
+ * - https://developer.jamf.com/jamf-pro/reference/get_v1-icon-id
+ */
+func (c *Client) GetIcon(id string) (*IconDetail, error) {
+	url := c.BuildURL(IconURL, id)
+
+	icon, err := do[IconDetail](c, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &icon, nil
+}
@@ -29,7 +29,7 @@ var (
 func (c *Client) GetJamfVersion() (string, error) {
 	url := c.BuildURL(JamfProVersion)
 
-	res, body, err := c.HTTP.DoRequest("GET", url, nil, nil)
+	res, body, err := c.request("GET", url, nil, nil)
 	if err != nil {
 		return "", err
 	}
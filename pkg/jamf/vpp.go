@@ -0,0 +1,76 @@
+/*
+# Jamf - Volume Purchasing
+
+This package initializes all the methods for functions which interact with the Jamf Volume Purchasing (VPP) endpoints:
+- https://developer.jamf.com/jamf-pro/reference/get_v1-volume-purchasing-locations
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/jamf/vpp.go
+package jamf
+
+import (
+	"fmt"
+	"time"
+)
+
+var (
+	VolumePurchasingLocationsURL = fmt.Sprintf("%s/volume-purchasing-locations", V1) // /api/v1/volume-purchasing-locations
+)
+
+/*
+ * # List All Volume Purchasing Locations
+ * /api/v1/volume-purchasing-locations
+ * - https://developer.jamf.com/jamf-pro/reference/get_v1-volume-purchasing-locations
+ */
+func (c *Client) ListAllVolumePurchasingLocations() (*VolumePurchasingLocations, error) {
+	url := c.BuildURL(VolumePurchasingLocationsURL)
+
+	var cache VolumePurchasingLocations
+	if c.GetCache(url, &cache) {
+		return &cache, nil
+	}
+
+	q := &DeviceQuery{
+		Page:     0,
+		PageSize: 100,
+	}
+
+	locations, err := doConcurrent[VolumePurchasingLocations](c, "GET", url, q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.SetCache(url, locations, 30*time.Minute)
+	return locations, nil
+}
+
+/*
+ * # List All Content for a Volume Purchasing Location
+ * /api/v1/volume-purchasing-locations/{id}/content
+ * - https://developer.jamf.com/jamf-pro/reference/get_v1-volume-purchasing-locations-id-content
+ */
+func (c *Client) ListAllVolumePurchasingContent(locationID string) (*VolumePurchasingContents, error) {
+	url := c.BuildURL(VolumePurchasingLocationsURL, locationID, "content")
+
+	var cache VolumePurchasingContents
+	if c.GetCache(url, &cache) {
+		return &cache, nil
+	}
+
+	q := &DeviceQuery{
+		Page:     0,
+		PageSize: 100,
+	}
+
+	content, err := doConcurrent[VolumePurchasingContents](c, "GET", url, q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.SetCache(url, content, 30*time.Minute)
+	return content, nil
+}
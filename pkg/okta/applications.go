@@ -47,7 +47,7 @@ func (c *Client) ListAllApplications() (*Applications, error) {
 		IncludeNonDeleted: false,
 	}
 
-	applications, err := doPaginated[Applications](c, "GET", url, q, nil)
+	applications, err := doPaginated[Applications](c, "GET", url, q)
 	if err != nil {
 		return nil, err
 	}
@@ -75,7 +75,7 @@ func (c *Client) ListAllApplicationUsers(appID string) (*Users, error) {
 		Expand: "user",
 	}
 
-	appUsers, err := doPaginated[Users](c, "GET", url, q, nil)
+	appUsers, err := doPaginated[Users](c, "GET", url, q)
 	if err != nil {
 		return nil, err
 	}
@@ -129,7 +129,7 @@ func (c *Client) GetUserApplications(userID string) (*Applications, error) {
 		Expand: fmt.Sprintf("user/%s", userID),
 	}
 
-	apps, err := doPaginated[Applications](c, "GET", url, q, nil)
+	apps, err := doPaginated[Applications](c, "GET", url, q)
 	if err != nil {
 		return nil, err
 	}
@@ -175,6 +175,29 @@ func (c *Client) ConvertApplicationAssignment(appID string, userID string) (*Use
 	return user, nil
 }
 
+/*
+ * # Assign a User to an Application
+ * /api/v1/apps/{appid}/users
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/ApplicationUsers/#tag/ApplicationUsers/operation/assignUserToApplication
+ * - The user is assigned directly (scope USER), without any app profile
+ */
+func (c *Client) AssignUserToApplication(appID string, userID string) (*User, error) {
+	url := c.BuildURL(OktaApps, appID, "users")
+
+	payload := &map[string]string{
+		"id":    userID,
+		"scope": "USER",
+	}
+
+	user, err := do[*User](c, "POST", url, nil, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	c.SetCache(c.BuildURL(OktaApps, appID, "users", userID), user, 5*time.Minute)
+	return user, nil
+}
+
 /*
  * # Remove Application Assignment
  * Retrieves a user assigned to an application and removes the assignment
@@ -184,14 +207,7 @@ func (c *Client) ConvertApplicationAssignment(appID string, userID string) (*Use
 func (c *Client) RemoveApplicationAssignment(appID string, userID string) error {
 	url := c.BuildURL(OktaApps, appID, "users", userID)
 
+	// The `204 No Content` response is handled by do
 	_, err := do[any](c, "DELETE", url, nil, nil)
-	if err != nil {
-		// If error message is not: "unexpected end of JSON input"
-		// if fmt.Errorf("unexpected end of JSON input").Error() != err.Error() {
-		// 	return err
-		// }
-		return nil
-	}
-
-	return nil
+	return err
 }
@@ -0,0 +1,85 @@
+/*
+# Okta Behavior Detection
+
+This package contains all the methods to interact with the Okta Behavior Detection Rules API, whose detections
+(new location, device, IP or impossible travel) are evaluated on every sign-on:
+https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Behavior/
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/okta/behaviors.go
+package okta
+
+import (
+	"time"
+)
+
+/*
+ * # List all Behavior Detection Rules
+ * /api/v1/behaviors
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Behavior/#tag/Behavior/operation/listBehaviorDetectionRules
+ */
+func (c *Client) ListBehaviors() (*BehaviorRules, error) {
+	url := c.BuildURL(OktaBehaviors)
+
+	var cache BehaviorRules
+	if c.GetCache(url, &cache) {
+		return &cache, nil
+	}
+
+	behaviors, err := doPaginated[BehaviorRules](c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.SetCache(url, behaviors, 5*time.Minute)
+	return behaviors, nil
+}
+
+/*
+ * # Get a Behavior Detection Rule
+ * /api/v1/behaviors/{behaviorId}
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Behavior/#tag/Behavior/operation/getBehaviorDetectionRule
+ */
+func (c *Client) GetBehavior(behaviorID string) (*BehaviorRule, error) {
+	url := c.BuildURL(OktaBehaviors, behaviorID)
+
+	return do[*BehaviorRule](c, "GET", url, nil, nil)
+}
+
+/*
+ * # Replace a Behavior Detection Rule
+ * Updates the name and settings of a rule; the type cannot be changed
+ * /api/v1/behaviors/{behaviorId}
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Behavior/#tag/Behavior/operation/replaceBehaviorDetectionRule
+ */
+func (c *Client) ReplaceBehavior(behaviorID string, behavior *BehaviorRule) (*BehaviorRule, error) {
+	url := c.BuildURL(OktaBehaviors, behaviorID)
+
+	return do[*BehaviorRule](c, "PUT", url, nil, behavior)
+}
+
+/*
+ * # Activate a Behavior Detection Rule
+ * /api/v1/behaviors/{behaviorId}/lifecycle/activate
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Behavior/#tag/Behavior/operation/activateBehaviorDetectionRule
+ */
+func (c *Client) ActivateBehavior(behaviorID string) (*BehaviorRule, error) {
+	url := c.BuildURL(OktaBehaviors, behaviorID, "lifecycle", "activate")
+
+	return do[*BehaviorRule](c, "POST", url, nil, nil)
+}
+
+/*
+ * # Deactivate a Behavior Detection Rule
+ * /api/v1/behaviors/{behaviorId}/lifecycle/deactivate
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Behavior/#tag/Behavior/operation/deactivateBehaviorDetectionRule
+ */
+func (c *Client) DeactivateBehavior(behaviorID string) (*BehaviorRule, error) {
+	url := c.BuildURL(OktaBehaviors, behaviorID, "lifecycle", "deactivate")
+
+	return do[*BehaviorRule](c, "POST", url, nil, nil)
+}
@@ -13,9 +13,14 @@ https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Devic
 package okta
 
 import (
+	"errors"
+	"fmt"
 	"time"
 )
 
+// ErrDeviceNotFound is returned (wrapped) when no Okta device matches a lookup, e.g. by GetDeviceBySerial
+var ErrDeviceNotFound = errors.New("device not found")
+
 /*
 - Query parameters for Devices
 
@@ -59,7 +64,7 @@ func (c *Client) ListAllDevices() (*Devices, error) {
 		return &cache, nil
 	}
 
-	devices, err := doPaginated[Devices](c, "GET", url, nil, nil)
+	devices, err := doPaginated[Devices](c, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +87,7 @@ func (c *Client) ListDevices(q DeviceQuery) (*Devices, error) {
 		return &cache, nil
 	}
 
-	devices, err := doPaginated[Devices](c, "GET", url, q, nil)
+	devices, err := doPaginated[Devices](c, "GET", url, q)
 	if err != nil {
 		return nil, err
 	}
@@ -113,6 +118,29 @@ func (c *Client) ListUsersForDevice(deviceID string) (*DeviceUsers, error) {
 	return &deviceUsers, nil
 }
 
+/*
+ * # List all Devices of a User
+ * Devices are registered when Okta Verify is set up on them, so each Okta Verify enrollment of the user has one
+ * /api/v1/users/{userId}/devices
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/UserResources/#tag/UserResources/operation/listUserDevices
+ */
+func (c *Client) ListUserDevices(userID string) (*UserDevices, error) {
+	url := c.BuildURL(OktaUsers, userID, "devices")
+
+	var cache UserDevices
+	if c.GetCache(url, &cache) {
+		return &cache, nil
+	}
+
+	userDevices, err := do[UserDevices](c, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.SetCache(url, userDevices, 5*time.Minute)
+	return &userDevices, nil
+}
+
 /*
  * # List all non-mobile devices with Managed Status
  * /api/v1/devices
@@ -151,3 +179,52 @@ func (c *Client) ListManagedDevices() (*Devices, error) {
 
 	return &managedDevices, nil
 }
+
+/*
+ * # Get a Device by Serial Number
+ * /api/v1/devices?search=profile.serialNumber eq "{serial}"
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Device/#tag/Device/operation/listDevices
+ * - Returns ErrDeviceNotFound (wrapped) when no device has the serial number
+ */
+func (c *Client) GetDeviceBySerial(serial string) (*Device, error) {
+	url := c.BuildURL(OktaDevices)
+
+	q := DeviceQuery{
+		Search: fmt.Sprintf(`profile.serialNumber eq "%s"`, serial),
+	}
+
+	devices, err := doPaginated[Devices](c, "GET", url, q)
+	if err != nil {
+		return nil, err
+	}
+	if devices == nil || len(*devices) == 0 {
+		return nil, fmt.Errorf("no device with serial number %s: %w", serial, ErrDeviceNotFound)
+	}
+
+	return (*devices)[0], nil
+}
+
+/*
+ * # Suspend a Device
+ * Suspended devices can't be used to satisfy device assurance (e.g. Okta Verify) until they are unsuspended
+ * /api/v1/devices/{deviceId}/lifecycle/suspend
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Device/#tag/Device/operation/suspendDevice
+ */
+func (c *Client) SuspendDevice(deviceID string) error {
+	url := c.BuildURL(OktaDevices, deviceID, "lifecycle", "suspend")
+
+	_, err := do[interface{}](c, "POST", url, nil, nil)
+	return err
+}
+
+/*
+ * # Unsuspend a Device
+ * /api/v1/devices/{deviceId}/lifecycle/unsuspend
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Device/#tag/Device/operation/unsuspendDevice
+ */
+func (c *Client) UnsuspendDevice(deviceID string) error {
+	url := c.BuildURL(OktaDevices, deviceID, "lifecycle", "unsuspend")
+
+	_, err := do[interface{}](c, "POST", url, nil, nil)
+	return err
+}
@@ -14,6 +14,7 @@ package okta
 import (
 	"encoding/json"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gemini-oss/rego/pkg/common/cache"
@@ -29,6 +30,9 @@ type Client struct {
 	Error   *Error           // Error is the error response from the last request made by the client.
 	Log     *log.Logger      // Log is the logger used to log messages.
 	Cache   *cache.Cache     // Cache is the cache used to store responses from the Okta API.
+
+	capabilities      *Capabilities // Discovered features of the org, see Capabilities()
+	capabilitiesMutex sync.Mutex
 }
 
 type Error struct {
@@ -249,6 +253,14 @@ type DeviceUser struct {
 	User             *User     `json:"user,omitempty"`             // The user assigned to the device.
 }
 
+type UserDevices []*UserDevice
+
+type UserDevice struct {
+	Created      string  `json:"created,omitempty"`      // The timestamp when the device was registered for the user.
+	Device       *Device `json:"device,omitempty"`       // The device registered for the user.
+	DeviceUserID string  `json:"deviceUserId,omitempty"` // The ID of the user's registration of the device.
+}
+
 type DeviceEmbedded struct {
 	DeviceUsers *DeviceUsers `json:"users,omitempty"`
 }
@@ -450,6 +462,28 @@ func (u *UserProfile) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Custom marshaller for UserProfile, sending the custom attributes alongside the known fields
+func (u UserProfile) MarshalJSON() ([]byte, error) {
+	base, err := json.Marshal(u.UserProfileBase)
+	if err != nil {
+		return nil, err
+	}
+	if len(u.CustomAttributes) == 0 {
+		return base, nil
+	}
+
+	fields := make(map[string]interface{}, len(u.CustomAttributes))
+	for key, value := range u.CustomAttributes {
+		fields[key] = value
+	}
+	// Known fields win over a custom attribute of the same name
+	if err := json.Unmarshal(base, &fields); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(fields)
+}
+
 type UserType struct {
 	Created       time.Time `json:"created,omitempty"`       // The timestamp when the user type was created.
 	CreatedBy     string    `json:"createdBy,omitempty"`     // The ID of the user who created the user type.
@@ -486,8 +520,13 @@ type Factor struct {
 }
 
 type FactorProfile struct {
+	CredentialID   string `json:"credentialId,omitempty"`   // The credential ID of the factor (Okta Verify: the user's login).
+	DeviceType     string `json:"deviceType,omitempty"`     // The type of device the factor is enrolled on (e.g., SmartPhone_IPhone).
+	Name           string `json:"name,omitempty"`           // The name of the device the factor is enrolled on.
 	PhoneExtension int    `json:"phoneExtension,omitempty"` // The phone extension of the user.
 	PhoneNumber    string `json:"phoneNumber,omitempty"`    // The phone number of the user.
+	Platform       string `json:"platform,omitempty"`       // The platform of the device the factor is enrolled on (e.g., IOS, ANDROID).
+	Version        string `json:"version,omitempty"`        // The OS version of the device the factor is enrolled on.
 }
 
 type FactorTypes struct {
@@ -549,6 +588,19 @@ type GroupProfile struct {
 
 type GroupEmbedded interface{}
 
+type GroupOwners []*GroupOwner
+
+// https://developer.okta.com/docs/api/openapi/okta-management/management/tag/GroupOwner/#tag/GroupOwner/operation/listGroupOwners
+type GroupOwner struct {
+	ID          string    `json:"id,omitempty"`          // The ID of the group owner.
+	DisplayName string    `json:"displayName,omitempty"` // The display name of the group owner.
+	LastUpdated time.Time `json:"lastUpdated,omitempty"` // The last time the group owner was updated.
+	OriginID    string    `json:"originId,omitempty"`    // The ID of the app instance if the originType is APPLICATION.
+	OriginType  string    `json:"originType,omitempty"`  // The source where group ownership is managed. {APPLICATION, OKTA_DIRECTORY}
+	Resolved    bool      `json:"resolved,omitempty"`    // If originType is APPLICATION, this parameter is set to false until the owner's originId is reconciled.
+	Type        string    `json:"type,omitempty"`        // The entity type of the owner. {GROUP, USER}
+}
+
 type GroupRules []*GroupRule
 
 type GroupRule struct {
@@ -592,3 +644,350 @@ type GroupCondition struct {
 
 // END OF OKTA Group STRUCTS
 //---------------------------------------------------------------------
+
+// ### Okta System Log Structs
+// ---------------------------------------------------------------------
+type LogEvents []*LogEvent
+
+// https://developer.okta.com/docs/api/openapi/okta-management/management/tag/SystemLog/#tag/SystemLog/operation/listLogEvents!c=200&path=0&t=response
+type LogEvent struct {
+	UUID                  string                 `json:"uuid,omitempty"`                  // Unique identifier for an individual event.
+	Published             time.Time              `json:"published,omitempty"`             // Timestamp when the event is published.
+	EventType             string                 `json:"eventType,omitempty"`             // Type of event that is published, e.g. `user.lifecycle.deactivate`.
+	Version               string                 `json:"version,omitempty"`               // Versioning indicator.
+	Severity              string                 `json:"severity,omitempty"`              // Indicates how severe the event is. {DEBUG, INFO, WARN, ERROR}
+	LegacyEventType       string                 `json:"legacyEventType,omitempty"`       // Associated Events API Action objectType attribute value.
+	DisplayMessage        string                 `json:"displayMessage,omitempty"`        // The display message for an event.
+	Actor                 *LogActor              `json:"actor,omitempty"`                 // Describes the entity that performs an action.
+	Client                *LogClient             `json:"client,omitempty"`                // The client that requests an action.
+	Outcome               *LogOutcome            `json:"outcome,omitempty"`               // The outcome of an action.
+	Target                []*LogActor            `json:"target,omitempty"`                // The entities that an action is performed on.
+	Transaction           *LogTransaction        `json:"transaction,omitempty"`           // The transaction details of an event.
+	DebugContext          *LogDebugContext       `json:"debugContext,omitempty"`          // Additional debug information about the event.
+	AuthenticationContext map[string]interface{} `json:"authenticationContext,omitempty"` // The authentication data of an action.
+	SecurityContext       map[string]interface{} `json:"securityContext,omitempty"`       // The security data of the request.
+	Request               map[string]interface{} `json:"request,omitempty"`               // The request that initiates an action (IP chain).
+}
+
+// Actor and Target objects share the same shape
+type LogActor struct {
+	ID          string                 `json:"id,omitempty"`          // ID of the actor/target.
+	Type        string                 `json:"type,omitempty"`        // Type of the actor/target, e.g. `User`, `AppInstance`.
+	AlternateID string                 `json:"alternateId,omitempty"` // Alternative ID of the actor/target (login, email, app name).
+	DisplayName string                 `json:"displayName,omitempty"` // Display name of the actor/target.
+	DetailEntry map[string]interface{} `json:"detailEntry,omitempty"` // Further details about the actor/target.
+}
+
+type LogClient struct {
+	ID        string `json:"id,omitempty"`        // For OAuth requests, the ID of the OAuth client.
+	Device    string `json:"device,omitempty"`    // Type of device that the client operates from, e.g. `Computer`.
+	IPAddress string `json:"ipAddress,omitempty"` // IP address that the client is making its request from.
+	Zone      string `json:"zone,omitempty"`      // The name of the network zone the client's request is from.
+	UserAgent *struct {
+		Browser      string `json:"browser,omitempty"`      // The browser that the client uses.
+		OS           string `json:"os,omitempty"`           // The OS that the client uses.
+		RawUserAgent string `json:"rawUserAgent,omitempty"` // The full User-Agent string.
+	} `json:"userAgent,omitempty"` // The user agent of the client.
+	GeographicalContext map[string]interface{} `json:"geographicalContext,omitempty"` // The physical location where the client is making its request from.
+}
+
+type LogOutcome struct {
+	Result string `json:"result,omitempty"` // Result of the action. {SUCCESS, FAILURE, SKIPPED, ALLOW, DENY, CHALLENGE, UNKNOWN}
+	Reason string `json:"reason,omitempty"` // Reason for the result.
+}
+
+type LogTransaction struct {
+	ID     string                 `json:"id,omitempty"`     // Unique identifier for this transaction.
+	Type   string                 `json:"type,omitempty"`   // Type of transaction. {WEB, JOB}
+	Detail map[string]interface{} `json:"detail,omitempty"` // Details for this transaction.
+}
+
+type LogDebugContext struct {
+	DebugData map[string]interface{} `json:"debugData,omitempty"` // Dynamic field that contains miscellaneous information that is dependent on the event type.
+}
+
+// END OF OKTA SYSTEM LOG STRUCTS
+//---------------------------------------------------------------------
+
+// ### Okta Behavior Detection Structs
+// ---------------------------------------------------------------------
+type BehaviorRules []*BehaviorRule
+
+// https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Behavior/
+type BehaviorRule struct {
+	ID          string                 `json:"id,omitempty"`          // ID of the behavior detection rule.
+	Name        string                 `json:"name,omitempty"`        // Name of the behavior detection rule.
+	Type        string                 `json:"type,omitempty"`        // Type of behavior detected. {ANOMALOUS_LOCATION, ANOMALOUS_DEVICE, ANOMALOUS_IP, VELOCITY}
+	Status      string                 `json:"status,omitempty"`      // Whether the rule is evaluated. {ACTIVE, INACTIVE}
+	Settings    *BehaviorSettings      `json:"settings,omitempty"`    // Settings of the rule; which apply depends on the type.
+	Created     *time.Time             `json:"created,omitempty"`     // When the rule was created.
+	LastUpdated *time.Time             `json:"lastUpdated,omitempty"` // When the rule was last updated.
+	Links       map[string]interface{} `json:"_links,omitempty"`      // Link relations.
+}
+
+type BehaviorSettings struct {
+	MaxEventsUsedForEvaluation   int    `json:"maxEventsUsedForEvaluation,omitempty"`   // Number of past sign-ins the behavior is compared against.
+	MinEventsNeededForEvaluation int    `json:"minEventsNeededForEvaluation,omitempty"` // Sign-ins needed before the behavior is evaluated. Not for VELOCITY.
+	Granularity                  string `json:"granularity,omitempty"`                  // Granularity of ANOMALOUS_LOCATION. {LAT_LONG, CITY, COUNTRY, SUBDIVISION}
+	RadiusKilometers             int    `json:"radiusKilometers,omitempty"`             // Radius of ANOMALOUS_LOCATION, with the LAT_LONG granularity.
+	VelocityKph                  int    `json:"velocityKph,omitempty"`                  // Speed above which travel between sign-ins is considered VELOCITY.
+}
+
+// END OF OKTA BEHAVIOR DETECTION STRUCTS
+//---------------------------------------------------------------------
+
+// ### Okta Risk Structs
+// ---------------------------------------------------------------------
+type RiskProviders []*RiskProvider
+
+// https://developer.okta.com/docs/api/openapi/okta-management/management/tag/RiskProvider/
+type RiskProvider struct {
+	ID          string                 `json:"id,omitempty"`          // ID of the risk provider.
+	Name        string                 `json:"name,omitempty"`        // Name of the risk provider.
+	Action      string                 `json:"action,omitempty"`      // What Okta does with the risk events of the provider. {none, log_only, enforce_and_log}
+	ClientID    string                 `json:"clientId,omitempty"`    // ID of the OAuth service app used to send risk events.
+	Created     *time.Time             `json:"created,omitempty"`     // When the provider was created.
+	LastUpdated *time.Time             `json:"lastUpdated,omitempty"` // When the provider was last updated.
+	Links       map[string]interface{} `json:"_links,omitempty"`      // Link relations.
+}
+
+// UserRiskScore summarizes the risk signals of a user over a window, as a feed for anomaly correlation. **ReGo only**
+type UserRiskScore struct {
+	UserID    string    `json:"userId"`              // ID of the user.
+	Login     string    `json:"login"`               // Login of the user.
+	Score     int       `json:"score"`               // Risk score, from 0 to 100.
+	Level     string    `json:"level"`               // Level of the score. {LOW, MEDIUM, HIGH}
+	Events    int       `json:"events"`              // Number of risk events of the user.
+	Reasons   []string  `json:"reasons,omitempty"`   // Distinct reasons given by the events, e.g. `Anomalous Device`.
+	Behaviors []string  `json:"behaviors,omitempty"` // Distinct behaviors detected on sign-on, e.g. `New Geo-Location`.
+	FirstSeen time.Time `json:"firstSeen"`           // Time of the first risk event.
+	LastSeen  time.Time `json:"lastSeen"`            // Time of the last risk event.
+}
+
+// END OF OKTA RISK STRUCTS
+//---------------------------------------------------------------------
+
+// ### Okta Identity Provider Structs
+// ---------------------------------------------------------------------
+type IdentityProviders []*IdentityProvider
+
+// https://developer.okta.com/docs/api/openapi/okta-management/management/tag/IdentityProvider/
+type IdentityProvider struct {
+	ID          string                 `json:"id,omitempty"`          // ID of the identity provider.
+	Name        string                 `json:"name,omitempty"`        // Name of the identity provider.
+	Type        string                 `json:"type,omitempty"`        // Type of the identity provider, e.g. X509 for smart card (certificate) authentication. {GOOGLE, OIDC, SAML2, X509, ...}
+	Status      string                 `json:"status,omitempty"`      // Whether the identity provider is used. {ACTIVE, INACTIVE}
+	Protocol    *IdPProtocol           `json:"protocol,omitempty"`    // Protocol settings of the identity provider.
+	Created     *time.Time             `json:"created,omitempty"`     // When the identity provider was created.
+	LastUpdated *time.Time             `json:"lastUpdated,omitempty"` // When the identity provider was last updated.
+	Links       map[string]interface{} `json:"_links,omitempty"`      // Link relations.
+}
+
+type IdPProtocol struct {
+	Type        string          `json:"type,omitempty"`        // Protocol of the identity provider. {MTLS, OIDC, OAUTH2, SAML2}
+	Credentials *IdPCredentials `json:"credentials,omitempty"` // Credentials of the protocol.
+}
+
+type IdPCredentials struct {
+	Trust *IdPTrust `json:"trust,omitempty"` // Certificate trust of the identity provider.
+}
+
+type IdPTrust struct {
+	Issuer                  string `json:"issuer,omitempty"`                  // Issuer of the trusted certificates.
+	Kid                     string `json:"kid,omitempty"`                     // ID of the IdP key credential (see IdPKey) of the trusted certificate chain.
+	Revocation              string `json:"revocation,omitempty"`              // How certificates are checked for revocation. {CRL, DELTA_CRL, OCSP}
+	RevocationCacheLifetime int    `json:"revocationCacheLifetime,omitempty"` // Minutes revocation results are cached.
+}
+
+type IdPKeys []*IdPKey
+
+// https://developer.okta.com/docs/api/openapi/okta-management/management/tag/IdentityProviderKeys/
+type IdPKey struct {
+	Kid       string     `json:"kid,omitempty"`       // ID of the key.
+	Kty       string     `json:"kty,omitempty"`       // Cryptographic algorithm family of the key. {RSA, EC}
+	Use       string     `json:"use,omitempty"`       // Intended use of the key.
+	X5C       []string   `json:"x5c,omitempty"`       // Base64-encoded X.509 certificate chain, leaf first.
+	X5TS256   string     `json:"x5t#S256,omitempty"`  // SHA-256 thumbprint of the certificate.
+	Created   *time.Time `json:"created,omitempty"`   // When the key was added.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"` // When the certificate expires.
+}
+
+// END OF OKTA IDENTITY PROVIDER STRUCTS
+//---------------------------------------------------------------------
+
+// ### Okta Policy Structs
+// ---------------------------------------------------------------------
+type Policies []*Policy
+
+// https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Policy/
+type Policy struct {
+	ID          string                 `json:"id,omitempty"`          // ID of the policy.
+	Name        string                 `json:"name,omitempty"`        // Name of the policy.
+	Description string                 `json:"description,omitempty"` // Description of the policy.
+	Type        string                 `json:"type,omitempty"`        // Type of the policy. {ACCESS_POLICY, OKTA_SIGN_ON, PASSWORD, MFA_ENROLL, ...}
+	Status      string                 `json:"status,omitempty"`      // Whether the policy is evaluated. {ACTIVE, INACTIVE}
+	Priority    int                    `json:"priority,omitempty"`    // Priority of the policy.
+	System      bool                   `json:"system,omitempty"`      // Whether the policy is the default policy, created by Okta.
+	Created     *time.Time             `json:"created,omitempty"`     // When the policy was created.
+	LastUpdated *time.Time             `json:"lastUpdated,omitempty"` // When the policy was last updated.
+	Links       map[string]interface{} `json:"_links,omitempty"`      // Link relations.
+}
+
+type PolicyRules []*PolicyRule
+
+// https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Policy/#tag/Policy/operation/listPolicyRules
+type PolicyRule struct {
+	ID          string                `json:"id,omitempty"`          // ID of the rule.
+	Name        string                `json:"name,omitempty"`        // Name of the rule.
+	Type        string                `json:"type,omitempty"`        // Type of the rule, matching the type of its policy.
+	Status      string                `json:"status,omitempty"`      // Whether the rule is evaluated. {ACTIVE, INACTIVE}
+	Priority    int                   `json:"priority,omitempty"`    // Order the rule is evaluated in; the first matching rule applies.
+	System      bool                  `json:"system,omitempty"`      // Whether the rule is the catch-all rule of the policy.
+	Conditions  *PolicyRuleConditions `json:"conditions,omitempty"`  // Conditions a sign-on must meet for the rule to apply.
+	Actions     *PolicyRuleActions    `json:"actions,omitempty"`     // Actions of the rule.
+	Created     *time.Time            `json:"created,omitempty"`     // When the rule was created.
+	LastUpdated *time.Time            `json:"lastUpdated,omitempty"` // When the rule was last updated.
+}
+
+type PolicyRuleConditions struct {
+	People      *PolicyPeopleCondition     `json:"people,omitempty"`      // Users and groups the rule applies to.
+	Network     *PolicyNetworkCondition    `json:"network,omitempty"`     // Network zones the rule applies to.
+	RiskScore   *PolicyRiskScoreCondition  `json:"riskScore,omitempty"`   // Risk level the rule applies to.
+	ElCondition *PolicyExpressionCondition `json:"elCondition,omitempty"` // Okta Expression Language condition of the rule.
+}
+
+type PolicyPeopleCondition struct {
+	Users  *PolicyIncludeExclude `json:"users,omitempty"`  // Users included in, or excluded from, the rule.
+	Groups *PolicyIncludeExclude `json:"groups,omitempty"` // Groups included in, or excluded from, the rule.
+}
+
+type PolicyIncludeExclude struct {
+	Include []string `json:"include,omitempty"` // IDs included.
+	Exclude []string `json:"exclude,omitempty"` // IDs excluded.
+}
+
+type PolicyNetworkCondition struct {
+	Connection string   `json:"connection,omitempty"` // Network the rule applies to. {ANYWHERE, ZONE}
+	Include    []string `json:"include,omitempty"`    // IDs of the network zones included.
+	Exclude    []string `json:"exclude,omitempty"`    // IDs of the network zones excluded.
+}
+
+type PolicyRiskScoreCondition struct {
+	Level string `json:"level,omitempty"` // Risk level the rule applies to. {ANY, LOW, MEDIUM, HIGH}
+}
+
+type PolicyExpressionCondition struct {
+	Condition string `json:"condition,omitempty"` // Okta Expression Language expression, e.g. `user.profile.department == "Engineering"`.
+}
+
+type PolicyRuleActions struct {
+	AppSignOn *AppSignOnAction `json:"appSignOn,omitempty"` // Action of an authentication policy rule.
+}
+
+type AppSignOnAction struct {
+	Access             string              `json:"access,omitempty"`             // Whether a matching sign-on is allowed. {ALLOW, DENY}
+	VerificationMethod *VerificationMethod `json:"verificationMethod,omitempty"` // Authentication required by the rule.
+}
+
+// https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Policy/#tag/Policy/operation/createPolicyRule!path=1/actions/appSignOn/verificationMethod
+type VerificationMethod struct {
+	Type             string                    `json:"type,omitempty"`             // Type of the verification method. {ASSURANCE, AUTH_METHOD_CHAIN}
+	FactorMode       string                    `json:"factorMode,omitempty"`       // Number of factors required. {1FA, 2FA}
+	ReauthenticateIn string                    `json:"reauthenticateIn,omitempty"` // How long a session satisfies the rule (ISO 8601), e.g. PT2H; PT0S on every sign-on.
+	Constraints      []AuthenticatorConstraint `json:"constraints,omitempty"`      // Authenticators allowed; any one of the constraints must be met.
+}
+
+type AuthenticatorConstraint struct {
+	Knowledge  *AuthenticatorConstraintMethod `json:"knowledge,omitempty"`  // Knowledge factor (e.g. password) constraint.
+	Possession *AuthenticatorConstraintMethod `json:"possession,omitempty"` // Possession factor (e.g. Okta Verify, FIDO2) constraint.
+}
+
+type AuthenticatorConstraintMethod struct {
+	Required           bool     `json:"required,omitempty"`           // Whether the factor is required.
+	Types              []string `json:"types,omitempty"`              // Types of authenticators allowed, e.g. password, security_key.
+	ReauthenticateIn   string   `json:"reauthenticateIn,omitempty"`   // How long the factor is remembered (ISO 8601).
+	PhishingResistant  string   `json:"phishingResistant,omitempty"`  // Whether the factor must be phishing resistant. {REQUIRED, OPTIONAL}
+	HardwareProtection string   `json:"hardwareProtection,omitempty"` // Whether the factor must be hardware protected. {REQUIRED, OPTIONAL}
+	UserPresence       string   `json:"userPresence,omitempty"`       // Whether the user must be present. {REQUIRED, OPTIONAL}
+	UserVerification   string   `json:"userVerification,omitempty"`   // Whether the user must be verified (e.g. biometrics). {REQUIRED, OPTIONAL}
+}
+
+// END OF OKTA POLICY STRUCTS
+//---------------------------------------------------------------------
+// ### Okta User Session Structs
+// ---------------------------------------------------------------------
+type UserClients []*UserClient
+
+// https://developer.okta.com/docs/api/openapi/okta-management/management/tag/UserResources/#tag/UserResources/operation/listUserClients
+type UserClient struct {
+	ClientID   string                 `json:"client_id,omitempty"`   // Unique key for the client application.
+	ClientName string                 `json:"client_name,omitempty"` // Human-readable string name of the client application.
+	ClientURI  string                 `json:"client_uri,omitempty"`  // URL string of a web page providing information about the client.
+	LogoURI    string                 `json:"logo_uri,omitempty"`    // URL string that references a logo for the client.
+	Links      map[string]interface{} `json:"_links,omitempty"`      // Link relations.
+}
+
+// SessionRevocation records what RevokeAllSessions revoked. **ReGo only**
+type SessionRevocation struct {
+	UserID          string   // ID of the user
+	Sessions        bool     // Sessions (and the tokens issued with them) were revoked
+	Clients         []string // Names of the clients whose refresh tokens were revoked
+	Grants          bool     // The user's grants were revoked
+	PasswordExpired bool     // The password was expired
+}
+
+// END OF OKTA USER SESSION STRUCTS
+//---------------------------------------------------------------------
+
+// ### Okta Org Structs
+// ---------------------------------------------------------------------
+// https://developer.okta.com/docs/api/openapi/okta-management/management/tag/OrgSetting/#tag/OrgSetting/operation/getOrgSettings
+type OrgSetting struct {
+	ID                 string                 `json:"id,omitempty"`                 // Org ID.
+	CompanyName        string                 `json:"companyName,omitempty"`        // Name of the org's company.
+	Subdomain          string                 `json:"subdomain,omitempty"`          // Subdomain of the org.
+	Status             string                 `json:"status,omitempty"`             // Status of the org. {ACTIVE, INACTIVE}
+	Edition            string                 `json:"edition,omitempty"`            // Edition of the org, when reported.
+	Website            string                 `json:"website,omitempty"`            // Website of the org.
+	SupportPhoneNumber string                 `json:"supportPhoneNumber,omitempty"` // Support help phone of the org.
+	Created            *time.Time             `json:"created,omitempty"`            // When the org was created.
+	ExpiresAt          *time.Time             `json:"expiresAt,omitempty"`          // When the org expires, for trial and preview orgs.
+	LastUpdated        *time.Time             `json:"lastUpdated,omitempty"`        // When the org settings were last updated.
+	Links              map[string]interface{} `json:"_links,omitempty"`             // Link relations.
+}
+
+// https://developer.okta.com/docs/api/openapi/okta-management/management/tag/OrgSetting/#tag/OrgSetting/operation/getWellknownOrgMetadata
+type OrgMetadata struct {
+	ID       string                 `json:"id,omitempty"`       // Org ID.
+	Pipeline string                 `json:"pipeline,omitempty"` // Authentication pipeline of the org. {idx (Identity Engine), v1 (Classic Engine)}
+	Links    map[string]interface{} `json:"_links,omitempty"`   // Link relations.
+}
+
+type Features []*Feature
+
+// https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Feature/
+type Feature struct {
+	ID          string                 `json:"id,omitempty"`          // Feature ID.
+	Name        string                 `json:"name,omitempty"`        // Name of the feature.
+	Description string                 `json:"description,omitempty"` // Description of the feature.
+	Stage       *FeatureStage          `json:"stage,omitempty"`       // Release stage of the feature.
+	Status      string                 `json:"status,omitempty"`      // Whether the feature is enabled. {ENABLED, DISABLED}
+	Type        string                 `json:"type,omitempty"`        // Type of the feature. {self-service}
+	Links       map[string]interface{} `json:"_links,omitempty"`      // Link relations.
+}
+
+type FeatureStage struct {
+	State string `json:"state,omitempty"` // State of the release stage. {OPEN, CLOSED}
+	Value string `json:"value,omitempty"` // Release stage. {EA, BETA}
+}
+
+// Capabilities summarizes what an org's SKU and settings make available. **ReGo only**
+type Capabilities struct {
+	Org       *OrgSetting     `json:"org,omitempty"`       // Settings of the org.
+	Pipeline  string          `json:"pipeline,omitempty"`  // Authentication pipeline of the org. {idx, v1}
+	Features  map[string]bool `json:"features,omitempty"`  // Self-service features by name, and whether they are enabled.
+	Endpoints map[string]bool `json:"endpoints,omitempty"` // Optional endpoints (e.g. OktaDevices), and whether the org can call them.
+}
+
+// END OF OKTA ORG STRUCTS
+//---------------------------------------------------------------------
@@ -0,0 +1,188 @@
+/*
+# Okta Event Hooks
+
+This package receives Okta event hooks, and keeps the cached group memberships in step with them, so automations
+reading the cache never act on memberships older than the hook's delivery delay (a few seconds):
+https://developer.okta.com/docs/concepts/event-hooks/
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/okta/event_hooks.go
+package okta
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+)
+
+const (
+	EventHookVerificationHeader = "X-Okta-Verification-Challenge" // Sent once, when the hook is verified in the Admin Console
+	EventHookAuthHeader         = "Authorization"                 // Default header of the hook's shared secret
+)
+
+// EventHookPayload is the body of an event hook delivery; a delivery batches up to 50 events
+// https://developer.okta.com/docs/api/openapi/okta-management/management/tag/EventHook/#tag/EventHook/operation/createEventHook!c=200&path=channel&t=response
+type EventHookPayload struct {
+	EventType          string    `json:"eventType,omitempty"`          // Always `com.okta.event_hook`
+	EventTypeVersion   string    `json:"eventTypeVersion,omitempty"`   // Version of the payload format
+	CloudEventsVersion string    `json:"cloudEventsVersion,omitempty"` // CloudEvents specification version
+	Source             string    `json:"source,omitempty"`             // URL of the event hook in the org
+	EventID            string    `json:"eventId,omitempty"`            // ID of the delivery
+	EventTime          string    `json:"eventTime,omitempty"`          // Time of the delivery
+	ContentType        string    `json:"contentType,omitempty"`        // Always `application/json`
+	Data               EventData `json:"data"`                         // Events of the delivery
+}
+
+type EventData struct {
+	Events []*LogEvent `json:"events"` // System Log events, in the shape of the System Log API
+}
+
+/*
+ * EventHook receives the deliveries of an Okta event hook:
+ *
+ *	hook := &okta.EventHook{Client: o, Secret: config.GetEnv("OKTA_EVENT_HOOK_SECRET")}
+ *	server.StartServer(":8443", map[string]http.HandlerFunc{"/hooks/okta": hook.ServeHTTP})
+ *
+ * Subscribe the hook to `group.user_membership.add`, `group.user_membership.remove` and `group.lifecycle.*`, so every
+ * membership change drops the cached memberships it affects (see InvalidateGroupMembership); they are fetched again on
+ * the next read. Okta expects an answer within 3 seconds, so keep OnEvent short, or hand the events off
+ */
+type EventHook struct {
+	Client  *Client         // Client whose cache is kept in step with the events
+	Secret  string          // Value of the authentication header configured on the hook; deliveries without it are rejected
+	Header  string          // Authentication header configured on the hook. Default: `Authorization`
+	OnEvent func(*LogEvent) // Called for every event, after the cache was updated
+}
+
+// ServeHTTP answers the one-time verification of the hook (GET), and processes its deliveries (POST)
+func (h *EventHook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.authorized(r) {
+		h.Client.Log.Warning("Rejected an event hook request without a valid secret from", r.RemoteAddr)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		challenge := r.Header.Get(EventHookVerificationHeader)
+		if challenge == "" {
+			http.Error(w, "missing verification challenge", http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"verification": challenge})
+
+	case http.MethodPost:
+		payload := &EventHookPayload{}
+		if err := json.NewDecoder(r.Body).Decode(payload); err != nil {
+			http.Error(w, "invalid payload", http.StatusBadRequest)
+			return
+		}
+
+		h.Client.ApplyEvents(payload.Data.Events...)
+		if h.OnEvent != nil {
+			for _, event := range payload.Data.Events {
+				if event != nil {
+					h.OnEvent(event)
+				}
+			}
+		}
+
+		w.WriteHeader(http.StatusOK)
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// authorized compares the authentication header in constant time; a hook without a secret rejects every request
+func (h *EventHook) authorized(r *http.Request) bool {
+	header := h.Header
+	if header == "" {
+		header = EventHookAuthHeader
+	}
+
+	got := r.Header.Get(header)
+	return h.Secret != "" && subtle.ConstantTimeCompare([]byte(got), []byte(h.Secret)) == 1
+}
+
+/*
+ * # Apply an Event to the Cache
+ * - membership changes drop the cached groups of the user and the cached members of the group
+ * - group creations and deletions also drop the cached list of groups, and the group itself
+ * - entries are dropped rather than patched, so events arriving late or out of order never leave stale data behind
+ */
+func (c *Client) ApplyEvent(event *LogEvent) {
+	c.ApplyEvents(event)
+}
+
+// ApplyEvents applies the events of a delivery to the cache at once, so the cache is persisted once rather than per event
+func (c *Client) ApplyEvents(events ...*LogEvent) {
+	keys := []string{}
+	for _, event := range events {
+		if event != nil {
+			keys = append(keys, c.eventKeys(event)...)
+		}
+	}
+	c.invalidate(keys...)
+}
+
+// eventKeys returns the cache keys an event makes stale
+func (c *Client) eventKeys(event *LogEvent) []string {
+	var userIDs, groupIDs []string
+	for _, target := range event.Target {
+		if target == nil {
+			continue
+		}
+		switch target.Type {
+		case "User":
+			userIDs = append(userIDs, target.ID)
+		case "UserGroup":
+			groupIDs = append(groupIDs, target.ID)
+		}
+	}
+
+	keys := []string{}
+	switch EventType(event.EventType) {
+	case EventGroupUserMembershipAdd, EventGroupUserMembershipRemove:
+		for _, groupID := range groupIDs {
+			for _, userID := range userIDs {
+				c.Log.Debugf("Invalidating the cached membership of user %s in group %s", userID, groupID)
+				keys = append(keys, c.membershipKeys(groupID, userID)...)
+			}
+		}
+
+	case EventGroupLifecycleCreate, EventGroupLifecycleDelete:
+		keys = append(keys, c.BuildURL(OktaGroups))
+		for _, groupID := range groupIDs {
+			keys = append(keys, c.BuildURL(OktaGroups, groupID), c.BuildURL(OktaGroups, groupID, "users"))
+		}
+	}
+
+	return keys
+}
+
+// InvalidateGroupMembership drops the cached memberships a change of the user's membership of the group affects
+func (c *Client) InvalidateGroupMembership(groupID, userID string) {
+	c.Log.Debugf("Invalidating the cached membership of user %s in group %s", userID, groupID)
+	c.invalidate(c.membershipKeys(groupID, userID)...)
+}
+
+// membershipKeys are the cached user's groups and group's members
+func (c *Client) membershipKeys(groupID, userID string) []string {
+	return []string{c.BuildURL(OktaUsers, userID, "groups"), c.BuildURL(OktaGroups, groupID, "users")}
+}
+
+func (c *Client) invalidate(keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	if err := c.Cache.Delete(keys...); err != nil {
+		c.Log.Error("Error invalidating cache entries:", err)
+	}
+}
@@ -0,0 +1,127 @@
+// Code generated by enumgen from metadata/event_types.json; DO NOT EDIT.
+
+// pkg/okta/event_types.go
+package okta
+
+// EventType is the `eventType` of a System Log event, e.g. for LogQuery filters
+// - https://developer.okta.com/docs/reference/api/event-types/
+type EventType string
+
+const (
+	EventApplicationLifecycleActivate                 EventType = "application.lifecycle.activate"                     // An application was activated
+	EventApplicationLifecycleCreate                   EventType = "application.lifecycle.create"                       // An application was created
+	EventApplicationLifecycleDeactivate               EventType = "application.lifecycle.deactivate"                   // An application was deactivated
+	EventApplicationLifecycleDelete                   EventType = "application.lifecycle.delete"                       // An application was deleted
+	EventApplicationUserMembershipAdd                 EventType = "application.user_membership.add"                    // A user was assigned to an application
+	EventApplicationUserMembershipRemove              EventType = "application.user_membership.remove"                 // A user was unassigned from an application
+	EventDeviceEnrollmentCreate                       EventType = "device.enrollment.create"                           // A device was enrolled
+	EventDeviceLifecycleDeactivate                    EventType = "device.lifecycle.deactivate"                        // A device was deactivated
+	EventDeviceLifecycleDelete                        EventType = "device.lifecycle.delete"                            // A device was deleted
+	EventDeviceLifecycleSuspend                       EventType = "device.lifecycle.suspend"                           // A device was suspended
+	EventDeviceLifecycleUnsuspend                     EventType = "device.lifecycle.unsuspend"                         // A device was unsuspended
+	EventGroupLifecycleCreate                         EventType = "group.lifecycle.create"                             // A group was created
+	EventGroupLifecycleDelete                         EventType = "group.lifecycle.delete"                             // A group was deleted
+	EventGroupUserMembershipAdd                       EventType = "group.user_membership.add"                          // A user was added to a group
+	EventGroupUserMembershipRemove                    EventType = "group.user_membership.remove"                       // A user was removed from a group
+	EventPolicyEvaluateSignOn                         EventType = "policy.evaluate_sign_on"                            // A sign-on policy was evaluated
+	EventPolicyLifecycleUpdate                        EventType = "policy.lifecycle.update"                            // A policy was updated
+	EventSecurityEventsProviderReceiveEvent           EventType = "security.events.provider.receive_event"             // A security event was received from a risk (shared signals) provider
+	EventSecurityThreatDetected                       EventType = "security.threat.detected"                           // A security threat was detected
+	EventSystemApiTokenCreate                         EventType = "system.api_token.create"                            // An API token was created
+	EventSystemApiTokenRevoke                         EventType = "system.api_token.revoke"                            // An API token was revoked
+	EventSystemOrgRateLimitViolation                  EventType = "system.org.rate_limit.violation"                    // A rate limit was exceeded
+	EventSystemOrgRateLimitWarning                    EventType = "system.org.rate_limit.warning"                      // A rate limit warning threshold was reached
+	EventUserAccountLock                              EventType = "user.account.lock"                                  // A user's account was locked
+	EventUserAccountPrivilegeGrant                    EventType = "user.account.privilege.grant"                       // An admin role was granted to a user
+	EventUserAccountPrivilegeRevoke                   EventType = "user.account.privilege.revoke"                      // An admin role was revoked from a user
+	EventUserAccountReportSuspiciousActivityByEnduser EventType = "user.account.report_suspicious_activity_by_enduser" // A user reported suspicious activity
+	EventUserAccountResetPassword                     EventType = "user.account.reset_password"                        // A user's password was reset
+	EventUserAccountUnlock                            EventType = "user.account.unlock"                                // A user's account was unlocked
+	EventUserAccountUpdatePassword                    EventType = "user.account.update_password"                       // A user updated their password
+	EventUserAuthenticationAuthViaMfa                 EventType = "user.authentication.auth_via_mfa"                   // A user authenticated with MFA
+	EventUserAuthenticationSso                        EventType = "user.authentication.sso"                            // A user signed on to an application
+	EventUserAuthenticationVerify                     EventType = "user.authentication.verify"                         // A user was verified
+	EventUserLifecycleActivate                        EventType = "user.lifecycle.activate"                            // A user was activated
+	EventUserLifecycleCreate                          EventType = "user.lifecycle.create"                              // A user was created
+	EventUserLifecycleDeactivate                      EventType = "user.lifecycle.deactivate"                          // A user was deactivated
+	EventUserLifecycleDeleteInitiated                 EventType = "user.lifecycle.delete.initiated"                    // A user's deletion was initiated
+	EventUserLifecycleReactivate                      EventType = "user.lifecycle.reactivate"                          // A user was reactivated
+	EventUserLifecycleSuspend                         EventType = "user.lifecycle.suspend"                             // A user was suspended
+	EventUserLifecycleUnsuspend                       EventType = "user.lifecycle.unsuspend"                           // A user was unsuspended
+	EventUserMfaFactorActivate                        EventType = "user.mfa.factor.activate"                           // A user enrolled an MFA factor
+	EventUserMfaFactorDeactivate                      EventType = "user.mfa.factor.deactivate"                         // A user's MFA factor was reset
+	EventUserMfaFactorResetAll                        EventType = "user.mfa.factor.reset_all"                          // All of a user's MFA factors were reset
+	EventUserMfaOktaVerifyDenyPush                    EventType = "user.mfa.okta_verify.deny_push"                     // A user denied an Okta Verify push
+	EventUserRiskChange                               EventType = "user.risk.change"                                   // A user's risk level changed
+	EventUserRiskDetect                               EventType = "user.risk.detect"                                   // Risk was detected for a user
+	EventUserSessionClear                             EventType = "user.session.clear"                                 // A user's sessions were cleared
+	EventUserSessionEnd                               EventType = "user.session.end"                                   // A user signed out of Okta
+	EventUserSessionImpersonationInitiate             EventType = "user.session.impersonation.initiate"                // An impersonation session was started
+	EventUserSessionStart                             EventType = "user.session.start"                                 // A user signed in to Okta
+	EventZoneUpdate                                   EventType = "zone.update"                                        // A network zone was updated
+)
+
+// EventTypes lists every known EventType
+var EventTypes = []EventType{
+	EventApplicationLifecycleActivate,
+	EventApplicationLifecycleCreate,
+	EventApplicationLifecycleDeactivate,
+	EventApplicationLifecycleDelete,
+	EventApplicationUserMembershipAdd,
+	EventApplicationUserMembershipRemove,
+	EventDeviceEnrollmentCreate,
+	EventDeviceLifecycleDeactivate,
+	EventDeviceLifecycleDelete,
+	EventDeviceLifecycleSuspend,
+	EventDeviceLifecycleUnsuspend,
+	EventGroupLifecycleCreate,
+	EventGroupLifecycleDelete,
+	EventGroupUserMembershipAdd,
+	EventGroupUserMembershipRemove,
+	EventPolicyEvaluateSignOn,
+	EventPolicyLifecycleUpdate,
+	EventSecurityEventsProviderReceiveEvent,
+	EventSecurityThreatDetected,
+	EventSystemApiTokenCreate,
+	EventSystemApiTokenRevoke,
+	EventSystemOrgRateLimitViolation,
+	EventSystemOrgRateLimitWarning,
+	EventUserAccountLock,
+	EventUserAccountPrivilegeGrant,
+	EventUserAccountPrivilegeRevoke,
+	EventUserAccountReportSuspiciousActivityByEnduser,
+	EventUserAccountResetPassword,
+	EventUserAccountUnlock,
+	EventUserAccountUpdatePassword,
+	EventUserAuthenticationAuthViaMfa,
+	EventUserAuthenticationSso,
+	EventUserAuthenticationVerify,
+	EventUserLifecycleActivate,
+	EventUserLifecycleCreate,
+	EventUserLifecycleDeactivate,
+	EventUserLifecycleDeleteInitiated,
+	EventUserLifecycleReactivate,
+	EventUserLifecycleSuspend,
+	EventUserLifecycleUnsuspend,
+	EventUserMfaFactorActivate,
+	EventUserMfaFactorDeactivate,
+	EventUserMfaFactorResetAll,
+	EventUserMfaOktaVerifyDenyPush,
+	EventUserRiskChange,
+	EventUserRiskDetect,
+	EventUserSessionClear,
+	EventUserSessionEnd,
+	EventUserSessionImpersonationInitiate,
+	EventUserSessionStart,
+	EventZoneUpdate,
+}
+
+// Known reports whether the EventType is listed in EventTypes
+func (v EventType) Known() bool {
+	for _, known := range EventTypes {
+		if v == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+/*
+# Okta System Log - Common Events
+
+This package converts Okta System Log events into the provider neutral (ECS shaped) events of `pkg/common/events`
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/okta/events.go
+package okta
+
+import (
+	"strings"
+
+	"github.com/gemini-oss/rego/pkg/common/events"
+)
+
+// eventCategories maps System Log event type prefixes onto `event.category`
+var eventCategories = []struct {
+	prefix     string
+	categories []string
+}{
+	{"user.session.", []string{events.CategoryAuthentication, events.CategorySession}},
+	{"user.authentication.", []string{events.CategoryAuthentication}},
+	{"user.mfa.", []string{events.CategoryAuthentication}},
+	{"user.account.", []string{events.CategoryIAM}},
+	{"user.lifecycle.", []string{events.CategoryIAM}},
+	{"group.", []string{events.CategoryIAM}},
+	{"application.user_membership.", []string{events.CategoryIAM}},
+	{"policy.", []string{events.CategoryConfiguration}},
+	{"system.", []string{events.CategoryConfiguration}},
+	{"application.", []string{events.CategoryConfiguration}},
+}
+
+/*
+ * # Common Event
+ * Converts a System Log event into a common event
+ * - The first `User` target becomes `user.target`; every actor/target login is added to `related.user`
+ */
+func (e *LogEvent) CommonEvent() *events.Event {
+	event := events.New("okta", "system", e.EventType, e.Published)
+	event.Message = e.DisplayMessage
+	event.Event.ID = e.UUID
+	event.Event.Severity = events.Severity(e.Severity)
+
+	for _, c := range eventCategories {
+		if strings.HasPrefix(e.EventType, c.prefix) {
+			event.Event.Category = c.categories
+			break
+		}
+	}
+
+	if e.Outcome != nil {
+		event.Event.Outcome = events.Outcome(e.Outcome.Result)
+		event.Event.Reason = e.Outcome.Reason
+	}
+
+	related := []string{}
+	if e.Actor != nil {
+		event.User = &events.User{
+			ID:   e.Actor.ID,
+			Name: e.Actor.AlternateID,
+		}
+		if strings.Contains(e.Actor.AlternateID, "@") {
+			event.User.Email = e.Actor.AlternateID
+		}
+		related = append(related, e.Actor.AlternateID)
+	}
+
+	for _, target := range e.Target {
+		if target == nil {
+			continue
+		}
+		if target.Type == "User" {
+			related = append(related, target.AlternateID)
+			if event.User == nil {
+				event.User = &events.User{}
+			}
+			if event.User.Target == nil {
+				event.User.Target = &events.User{ID: target.ID, Name: target.AlternateID}
+			}
+		}
+	}
+
+	ips := []string{}
+	if e.Client != nil {
+		event.Source = &events.Source{IP: e.Client.IPAddress}
+		ips = append(ips, e.Client.IPAddress)
+
+		if geo := e.Client.GeographicalContext; geo != nil {
+			event.Source.Geo = &events.Geo{
+				CityName:    stringValue(geo["city"]),
+				RegionName:  stringValue(geo["state"]),
+				CountryName: stringValue(geo["country"]),
+			}
+		}
+		if ua := e.Client.UserAgent; ua != nil {
+			event.UserAgent = &events.UserAgent{
+				Original: ua.RawUserAgent,
+				Name:     ua.Browser,
+				OS:       ua.OS,
+			}
+		}
+		event.Label("okta_client_zone", e.Client.Zone)
+	}
+	event.Relate(related, ips)
+
+	if e.Transaction != nil {
+		event.Label("okta_transaction_id", e.Transaction.ID)
+	}
+	event.Label("okta_legacy_event_type", e.LegacyEventType)
+
+	return event
+}
+
+// CommonEvents converts every System Log event into a common event
+func (l LogEvents) CommonEvents() []*events.Event {
+	out := make([]*events.Event, 0, len(l))
+	for _, e := range l {
+		if e != nil {
+			out = append(out, e.CommonEvent())
+		}
+	}
+	return out
+}
+
+// stringValue returns `v` when it is a string
+func stringValue(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
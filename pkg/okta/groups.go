@@ -13,6 +13,8 @@ https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Group
 package okta
 
 import (
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -43,7 +45,7 @@ func (c *Client) ListAllGroups() (*Groups, error) {
 		Limit: 10000,
 	}
 
-	groups, err := doPaginated[Groups](c, "GET", url, q, nil)
+	groups, err := doPaginated[Groups](c, "GET", url, q)
 	if err != nil {
 		return nil, err
 	}
@@ -75,6 +77,116 @@ func (c *Client) GetGroup(groupID string) (*Group, error) {
 	return &group, nil
 }
 
+/*
+ * # Find a Group by Name
+ * Returns a nil group (and no error) when no group has the name
+ * /api/v1/groups?search=profile.name eq "{name}"
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Group/#tag/Group/operation/listGroups
+ */
+func (c *Client) FindGroupByName(name string) (*Group, error) {
+	url := c.BuildURL(OktaGroups)
+
+	q := GroupParameters{
+		Search: fmt.Sprintf(`profile.name eq "%s"`, strings.ReplaceAll(name, `"`, `\"`)),
+		Limit:  2,
+	}
+
+	groups, err := do[Groups](c, "GET", url, q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range groups {
+		if strings.EqualFold(group.Profile.Name, name) {
+			return group, nil
+		}
+	}
+
+	return nil, nil
+}
+
+/*
+ * # Create a Group
+ * Creates an Okta (OKTA_GROUP) group
+ * /api/v1/groups
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Group/#tag/Group/operation/addGroup
+ */
+func (c *Client) CreateGroup(profile GroupProfile) (*Group, error) {
+	url := c.BuildURL(OktaGroups)
+
+	body := struct {
+		Profile GroupProfile `json:"profile"`
+	}{profile}
+
+	return do[*Group](c, "POST", url, nil, body)
+}
+
+/*
+ * # Update a Group
+ * Replaces the profile (name and description) of an Okta (OKTA_GROUP) group
+ * /api/v1/groups/{groupId}
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Group/#tag/Group/operation/replaceGroup
+ */
+func (c *Client) UpdateGroup(groupID string, profile GroupProfile) (*Group, error) {
+	url := c.BuildURL(OktaGroups, groupID)
+
+	body := struct {
+		Profile GroupProfile `json:"profile"`
+	}{profile}
+
+	return do[*Group](c, "PUT", url, nil, body)
+}
+
+/*
+ * # Delete a Group
+ * Deletes an Okta (OKTA_GROUP) group
+ * /api/v1/groups/{groupId}
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Group/#tag/Group/operation/deleteGroup
+ */
+func (c *Client) DeleteGroup(groupID string) error {
+	url := c.BuildURL(OktaGroups, groupID)
+
+	_, err := do[interface{}](c, "DELETE", url, nil, nil)
+	return err
+}
+
+/*
+ * # List the Owners of a Group
+ * /api/v1/groups/{groupId}/owners
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/GroupOwner/#tag/GroupOwner/operation/listGroupOwners
+ */
+func (c *Client) ListGroupOwners(groupID string) (*GroupOwners, error) {
+	url := c.BuildURL(OktaGroups, groupID, "owners")
+
+	return doPaginated[GroupOwners](c, "GET", url, nil)
+}
+
+/*
+ * # List all Members of a Group
+ * /api/v1/groups/{groupId}/users
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Group/#tag/Group/operation/listGroupUsers
+ */
+func (c *Client) ListGroupMembers(groupID string) (*Users, error) {
+	url := c.BuildURL(OktaGroups, groupID, "users")
+
+	var cache Users
+	if c.GetCache(url, &cache) {
+		return &cache, nil
+	}
+
+	q := struct {
+		Limit int `url:"limit"`
+	}{1000}
+
+	members, err := doPaginated[Users](c, "GET", url, q)
+	if err != nil {
+		return nil, err
+	}
+
+	c.SetCache(url, members, 5*time.Minute)
+	return members, nil
+}
+
 /*
  * # List All Group Rules
  * /api/v1/groups/rules
@@ -93,7 +205,7 @@ func (c *Client) ListAllGroupRules() (*GroupRules, error) {
 		Limit: 50,
 	}
 
-	groupRules, err := doPaginated[GroupRules](c, "GET", url, q, nil)
+	groupRules, err := doPaginated[GroupRules](c, "GET", url, q)
 	if err != nil {
 		return nil, err
 	}
@@ -102,6 +214,23 @@ func (c *Client) ListAllGroupRules() (*GroupRules, error) {
 	return groupRules, nil
 }
 
+/*
+ * # Assign a User to a Group
+ * /api/v1/groups/{groupId}/users/{userId}
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Group/#tag/Group/operation/assignUserToGroup
+ */
+func (c *Client) AddUserToGroup(groupID string, userID string) error {
+	url := c.BuildURL(OktaGroups, groupID, "users", userID)
+
+	_, err := do[interface{}](c, "PUT", url, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	c.InvalidateGroupMembership(groupID, userID)
+	return nil
+}
+
 /*
  * # Unassign a User from a Group
  * /api/v1/groups/{groupId}/users/{userId}
@@ -115,5 +244,6 @@ func (c *Client) RemoveUserFromGroup(groupID string, userID string) error {
 		return err
 	}
 
+	c.InvalidateGroupMembership(groupID, userID)
 	return nil
 }
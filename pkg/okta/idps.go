@@ -0,0 +1,83 @@
+/*
+# Okta Identity Providers
+
+This package contains all the methods to interact with the Okta Identity Providers API, including the smart card (X509)
+providers used for certificate authentication, and the key credentials holding their trusted certificate chains:
+https://developer.okta.com/docs/api/openapi/okta-management/management/tag/IdentityProvider/
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/okta/idps.go
+package okta
+
+import (
+	"time"
+)
+
+// IdPTypeX509 is the type of the smart card identity providers, which authenticate users with a certificate
+const IdPTypeX509 = "X509"
+
+/*
+ * # List all Identity Providers
+ * /api/v1/idps
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/IdentityProvider/#tag/IdentityProvider/operation/listIdentityProviders
+ * @param idpType string - Only list providers of this type, e.g. IdPTypeX509; all providers when empty
+ */
+func (c *Client) ListIdentityProviders(idpType string) (*IdentityProviders, error) {
+	url := c.BuildURL(OktaIdPs)
+
+	q := struct {
+		Type  string `url:"type,omitempty"`
+		Limit int    `url:"limit,omitempty"`
+	}{idpType, 200}
+
+	return doPaginated[IdentityProviders](c, "GET", url, q)
+}
+
+/*
+ * # Get an Identity Provider
+ * /api/v1/idps/{idpId}
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/IdentityProvider/#tag/IdentityProvider/operation/getIdentityProvider
+ */
+func (c *Client) GetIdentityProvider(idpID string) (*IdentityProvider, error) {
+	url := c.BuildURL(OktaIdPs, idpID)
+
+	return do[*IdentityProvider](c, "GET", url, nil, nil)
+}
+
+/*
+ * # List all IdP Key Credentials
+ * /api/v1/idps/credentials/keys
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/IdentityProviderKeys/#tag/IdentityProviderKeys/operation/listIdentityProviderKeys
+ * - The X.509 certificates trusted by identity providers, shared across providers
+ */
+func (c *Client) ListIdPKeys() (*IdPKeys, error) {
+	url := c.BuildURL(OktaIdPs, "credentials", "keys")
+
+	var cache IdPKeys
+	if c.GetCache(url, &cache) {
+		return &cache, nil
+	}
+
+	keys, err := doPaginated[IdPKeys](c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.SetCache(url, keys, 5*time.Minute)
+	return keys, nil
+}
+
+/*
+ * # Get an IdP Key Credential
+ * /api/v1/idps/credentials/keys/{kid}
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/IdentityProviderKeys/#tag/IdentityProviderKeys/operation/getIdentityProviderKey
+ */
+func (c *Client) GetIdPKey(kid string) (*IdPKey, error) {
+	url := c.BuildURL(OktaIdPs, "credentials", "keys", kid)
+
+	return do[*IdPKey](c, "GET", url, nil, nil)
+}
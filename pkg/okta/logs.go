@@ -0,0 +1,113 @@
+/*
+# Okta System Log
+
+This package contains all the methods to interact with the Okta System Log API:
+https://developer.okta.com/docs/api/openapi/okta-management/management/tag/SystemLog/
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/okta/logs.go
+package okta
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	OktaLogs = "%s/logs" // https://developer.okta.com/docs/api/openapi/okta-management/management/tag/SystemLog/
+)
+
+// EventType constants are generated from metadata/event_types.json; add new event types there
+//go:generate go run ../internal/enumgen -in metadata/event_types.json -out event_types.go
+
+// EventTypeFilter builds a LogQuery filter matching any of the event types, e.g. `eventType eq "user.session.start"`
+func EventTypeFilter(types ...EventType) string {
+	clauses := make([]string, len(types))
+	for i, t := range types {
+		clauses[i] = fmt.Sprintf(`eventType eq "%s"`, t)
+	}
+	return strings.Join(clauses, " or ")
+}
+
+/*
+ * Query Parameters for the System Log
+ */
+type LogQuery struct {
+	Since     string // Filters the lower time bound of the log events `published` property (ISO 8601). Default: 7 days ago
+	Until     string // Filters the upper time bound of the log events `published` property (ISO 8601). Without it, pagination never ends (polling)
+	After     string // Retrieves the next page of results. Obtained from the `Link` response header.
+	Filter    string // Filter expression, e.g. `eventType eq "user.lifecycle.deactivate"`
+	Q         string // Keyword search across the log events
+	Limit     string // Default: 100. Max: 1000. Sets the number of results returned in the response
+	SortOrder string // The order of the returned events sorted by the `published` property. {ASCENDING, DESCENDING}
+}
+
+/*
+ * # List System Log Events
+ * /api/v1/logs
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/SystemLog/#tag/SystemLog/operation/listLogEvents
+ */
+func (c *Client) ListLogEvents(q *LogQuery) (*LogEvents, error) {
+	url := c.BuildURL(OktaLogs)
+
+	if q.Until == "" {
+		q.Until = time.Now().UTC().Format(time.RFC3339)
+	}
+	if q.Limit == "" {
+		q.Limit = "1000"
+	}
+
+	events, err := doPaginated[LogEvents](c, "GET", url, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+/*
+ * # List the System Log Events targeting an object
+ * Matches the object's ID or alternate ID (login, email, app name) between `since` and `until`
+ */
+func (c *Client) ListTargetLogEvents(target string, since, until time.Time) (*LogEvents, error) {
+	target = strings.ReplaceAll(target, `"`, `\"`)
+
+	return c.ListLogEvents(&LogQuery{
+		Since:     since.UTC().Format(time.RFC3339),
+		Until:     until.UTC().Format(time.RFC3339),
+		Filter:    fmt.Sprintf(`target.id eq "%s" or target.alternateId eq "%s"`, target, target),
+		SortOrder: "ASCENDING",
+	})
+}
+
+/*
+ * # Last Activity of each Group
+ * Returns the time of the latest System Log event targeting each group (membership, profile and app assignment changes)
+ * between `since` and `until`, by group ID; groups without any event are absent
+ */
+func (c *Client) LastGroupActivity(since, until time.Time) (map[string]time.Time, error) {
+	events, err := c.ListLogEvents(&LogQuery{
+		Since:  since.UTC().Format(time.RFC3339),
+		Until:  until.UTC().Format(time.RFC3339),
+		Filter: `target.type eq "UserGroup"`,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	last := map[string]time.Time{}
+	for _, event := range *events {
+		for _, target := range event.Target {
+			if target.Type == "UserGroup" && event.Published.After(last[target.ID]) {
+				last[target.ID] = event.Published
+			}
+		}
+	}
+
+	return last, nil
+}
@@ -13,6 +13,7 @@ https://developer.okta.com/docs/api/
 package okta
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -23,6 +24,7 @@ import (
 	"github.com/gemini-oss/rego/pkg/common/log"
 	"github.com/gemini-oss/rego/pkg/common/ratelimit"
 	"github.com/gemini-oss/rego/pkg/common/requests"
+	"github.com/gemini-oss/rego/pkg/common/schema"
 )
 
 var (
@@ -30,13 +32,19 @@ var (
 )
 
 const (
-	OktaApps       = "%s/apps"         // https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Application/
-	OktaGroups     = "%s/groups"       // https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Group/
-	OktaGroupRules = "%s/groups/rules" // https://developer.okta.com/docs/api/openapi/okta-management/management/tag/GroupRule/
-	OktaDevices    = "%s/devices"      // https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Device/
-	OktaUsers      = "%s/users"        // https://developer.okta.com/docs/api/openapi/okta-management/management/tag/User/
-	OktaIAM        = "%s/iam"          // https://developer.okta.com/docs/api/openapi/okta-management/management/tag/RoleAssignment/
-	OktaRoles      = "%s/iam/roles"    // https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Role/
+	OktaApps       = "%s/apps"           // https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Application/
+	OktaGroups     = "%s/groups"         // https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Group/
+	OktaGroupRules = "%s/groups/rules"   // https://developer.okta.com/docs/api/openapi/okta-management/management/tag/GroupRule/
+	OktaDevices    = "%s/devices"        // https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Device/
+	OktaUsers      = "%s/users"          // https://developer.okta.com/docs/api/openapi/okta-management/management/tag/User/
+	OktaIAM        = "%s/iam"            // https://developer.okta.com/docs/api/openapi/okta-management/management/tag/RoleAssignment/
+	OktaRoles      = "%s/iam/roles"      // https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Role/
+	OktaOrg        = "%s/org"            // https://developer.okta.com/docs/api/openapi/okta-management/management/tag/OrgSetting/
+	OktaFeatures   = "%s/features"       // https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Feature/
+	OktaBehaviors  = "%s/behaviors"      // https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Behavior/
+	OktaRisk       = "%s/risk/providers" // https://developer.okta.com/docs/api/openapi/okta-management/management/tag/RiskProvider/
+	OktaIdPs       = "%s/idps"           // https://developer.okta.com/docs/api/openapi/okta-management/management/tag/IdentityProvider/
+	OktaPolicies   = "%s/policies"       // https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Policy/
 )
 
 // BuildURL builds a URL for a given resource and identifiers.
@@ -48,6 +56,25 @@ func (c *Client) BuildURL(endpoint string, identifiers ...string) string {
 	return url
 }
 
+/*
+ * WithContext returns a copy of the client whose requests end when ctx is done, e.g. to cancel a long pagination:
+ *
+ *	users, err := c.WithContext(ctx).Users().ListAllUsers()
+ */
+func (c *Client) WithContext(ctx context.Context) *Client {
+	c.capabilitiesMutex.Lock()
+	defer c.capabilitiesMutex.Unlock()
+
+	return &Client{
+		BaseURL:      c.BaseURL,
+		HTTP:         c.HTTP.WithContext(ctx),
+		Error:        c.Error,
+		Log:          c.Log.WithContext(ctx),
+		Cache:        c.Cache,
+		capabilities: c.capabilities,
+	}
+}
+
 // UseCache() enables caching for the next method call.
 func (c *Client) UseCache() *Client {
 	c.Cache.Enabled = true
@@ -96,14 +123,15 @@ func (c *Client) GetCache(key string, target interface{}) bool {
 ```go
 
 	o := okta.NewClient(log.DEBUG)
+	sandbox := okta.NewClient(log.DEBUG, config.WithEnvironment("sandbox")) // OKTA_SANDBOX_* variables
 
 ```
 */
-func NewClient(verbosity int) *Client {
+func NewClient(verbosity int, opts ...config.Option) *Client {
 	log := log.NewLogger("{okta}", verbosity)
+	profile := config.NewProfile(opts...)
 
-	org_name := config.GetEnv("OKTA_ORG_NAME") // {ORG_NAME}.okta.com
-	//org_name := config.GetEnv("OKTA_SANDBOX_ORG_NAME")
+	org_name := profile.GetEnv("OKTA_ORG_NAME") // {ORG_NAME}.okta.com
 	if len(org_name) == 0 {
 		log.Fatal("OKTA_ORG_NAME is not set")
 	}
@@ -112,8 +140,7 @@ func NewClient(verbosity int) *Client {
 	org_name = strings.TrimPrefix(org_name, "http://")
 	org_name = strings.TrimSuffix(org_name, ".okta.com")
 
-	base := config.GetEnv("OKTA_BASE_URL") // {ORG_NAME}.{BASE_URL}
-	//base := config.GetEnv("OKTA_SANDBOX_BASE_URL") // oktapreview.com
+	base := profile.GetEnv("OKTA_BASE_URL") // {ORG_NAME}.{BASE_URL}
 	if len(base) == 0 {
 		log.Fatal("OKTA_BASE_URL is not set")
 	}
@@ -121,8 +148,7 @@ func NewClient(verbosity int) *Client {
 	base = strings.Trim(base, "./")
 	base = strings.TrimSuffix(base, ".com")
 
-	token := config.GetEnv("OKTA_API_TOKEN")
-	//token := config.GetEnv("OKTA_SANDBOX_API_TOKEN")
+	token := profile.GetEnv("OKTA_API_TOKEN")
 	if len(token) == 0 {
 		log.Fatal("OKTA_API_TOKEN is not set")
 	}
@@ -135,23 +161,32 @@ func NewClient(verbosity int) *Client {
 	}
 	httpClient := requests.NewClient(nil, headers, nil)
 	httpClient.BodyType = requests.JSON
+	httpClient.CompressRequests = true
 
 	// Look into `Functional Options` patterns for a better way to handle this (and other clients while we're at it)
-	encryptionKey := []byte(config.GetEnv("REGO_ENCRYPTION_KEY"))
+	encryptionKey := []byte(profile.GetEnv("REGO_ENCRYPTION_KEY"))
 	if len(encryptionKey) == 0 {
 		log.Fatal("REGO_ENCRYPTION_KEY is not set")
 	}
 
-	cache, err := cache.NewCache(encryptionKey, "rego_cache_okta.gob", 1000000)
+	cache, err := cache.NewCache(encryptionKey, "rego_cache_okta.gob", 1000000, profile)
 	if err != nil {
 		panic(err)
 	}
 
 	// https://developer.okta.com/docs/reference/rl-best-practices/
 	httpClient.RateLimiter = ratelimit.NewRateLimiter()
+	if limit := profile.GetEnvAsInt("OKTA_RATE_LIMIT"); limit > 0 {
+		// Preview (sandbox) orgs have lower limits than production; the limiter still resets from the response headers
+		httpClient.RateLimiter.Limit = limit
+		httpClient.RateLimiter.Available = limit
+	}
 	httpClient.RateLimiter.ResetHeaders = true
 	httpClient.RateLimiter.Log.Verbosity = verbosity
 
+	// 401, 403 and 404 responses never succeed on retry; fail (or skip, see Supports) without burning the backoff
+	httpClient.Apply(requests.WithBackoff(backoff))
+
 	return &Client{
 		BaseURL: BaseURL,
 		HTTP:    httpClient,
@@ -174,7 +209,12 @@ func do[T any](c *Client, method string, url string, query interface{}, data int
 	c.Log.Println("Response Status:", res.Status)
 	c.Log.Debug("Response Body:", string(body))
 
-	err = json.Unmarshal(body, &result)
+	// Lifecycle and membership operations respond with `204 No Content`
+	if len(body) == 0 {
+		return result, nil
+	}
+
+	err = schema.Unmarshal(body, &result)
 	if err != nil {
 		return *new(T), fmt.Errorf("unmarshalling error: %w", err)
 	}
@@ -184,39 +224,22 @@ func do[T any](c *Client, method string, url string, query interface{}, data int
 
 /*
  * Generically perform a paginated request to the Okta API for a slice
+ * - pages are followed through their `rel="next"` Link header, see requests.LinkPages
  */
-func doPaginated[T Slice[E], E any](c *Client, method, url string, query interface{}, data interface{}) (*T, error) {
-	var emptySlice T = make([]E, 0)
-	results := PagedSlice[T, E]{
-		Results:  &emptySlice,
-		OktaPage: &OktaPage{},
+func doPaginated[T Slice[E], E any](c *Client, method, url string, query interface{}) (*T, error) {
+	items, err := requests.PaginatedDo(c.HTTP, method, url, query, requests.Pagination[E]{
+		Strategy: requests.LinkPages{},
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	for {
-		res, body, err := c.HTTP.DoRequest(method, url, query, data)
-		if err != nil {
-			return nil, err
-		}
-
-		c.Log.Println("Response Status:", res.Status)
-		c.Log.Debug("Response Body:", string(body))
-
-		var page []E
-		err = json.Unmarshal(body, &page)
-		if err != nil {
-			return nil, fmt.Errorf("unmarshalling error: %w", err)
-		}
-
-		*results.Results = append(*results.Results, page...)
-
-		url = results.NextPage(res.Header.Values("Link"))
-		query = nil
-		if url == "" {
-			break
-		}
+	results := T(items)
+	if results == nil {
+		results = make(T, 0)
 	}
 
-	return results.Results, nil
+	return &results, nil
 }
 
 /*
@@ -239,7 +262,7 @@ func doPaginatedStruct[T Struct[T]](c *Client, method, url string, query interfa
 		c.Log.Debug("Response Body:", string(body))
 
 		var page T
-		err = json.Unmarshal(body, &page)
+		err = schema.Unmarshal(body, &page)
 		if err != nil {
 			return nil, fmt.Errorf("unmarshalling error: %w", err)
 		}
@@ -0,0 +1,196 @@
+/*
+# Okta Org
+
+This package contains all the methods to discover the settings, features and SKU of an Okta org, so workflows can skip
+endpoints the org does not have instead of failing midway:
+https://developer.okta.com/docs/api/openapi/okta-management/management/tag/OrgSetting/
+
+:Copyright: (c) 2023 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/okta/org.go
+package okta
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+)
+
+// ErrUnsupported is returned (wrapped) when the org's SKU or settings do not include an endpoint
+var ErrUnsupported = errors.New("not available on this Okta org")
+
+// OptionalEndpoints are probed by Capabilities(), as they depend on the org's SKU
+var OptionalEndpoints = []string{
+	OktaDevices, // Identity Engine orgs only
+	OktaRoles,   // Custom admin roles
+	OktaRisk,    // Identity Engine orgs only
+}
+
+/*
+ * # Get Org Settings
+ * /api/v1/org
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/OrgSetting/#tag/OrgSetting/operation/getOrgSettings
+ */
+func (c *Client) GetOrgSettings() (*OrgSetting, error) {
+	url := c.BuildURL(OktaOrg)
+
+	var cache OrgSetting
+	if c.GetCache(url, &cache) {
+		return &cache, nil
+	}
+
+	org, err := do[*OrgSetting](c, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.SetCache(url, org, 1*time.Hour)
+	return org, nil
+}
+
+/*
+ * # Get the Well-Known Org Metadata
+ * /.well-known/okta-organization
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/OrgSetting/#tag/OrgSetting/operation/getWellknownOrgMetadata
+ */
+func (c *Client) GetOrgMetadata() (*OrgMetadata, error) {
+	url := fmt.Sprintf("%s/.well-known/okta-organization", strings.TrimSuffix(c.BaseURL, "/api/v1"))
+
+	return do[*OrgMetadata](c, "GET", url, nil, nil)
+}
+
+/*
+ * # List all Features
+ * Lists the self-service features of the org, and whether they are enabled
+ * /api/v1/features
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Feature/#tag/Feature/operation/listFeatures
+ */
+func (c *Client) ListFeatures() (*Features, error) {
+	url := c.BuildURL(OktaFeatures)
+
+	var cache Features
+	if c.GetCache(url, &cache) {
+		return &cache, nil
+	}
+
+	features, err := doPaginated[Features](c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.SetCache(url, features, 1*time.Hour)
+	return features, nil
+}
+
+/*
+ * # Discover the Capabilities of the Org
+ * Combines the org settings, authentication pipeline and features, and probes the OptionalEndpoints
+ * The result is kept for the lifetime of the client
+ */
+func (c *Client) Capabilities() (*Capabilities, error) {
+	c.capabilitiesMutex.Lock()
+	defer c.capabilitiesMutex.Unlock()
+
+	if c.capabilities != nil {
+		return c.capabilities, nil
+	}
+
+	org, err := c.GetOrgSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	caps := &Capabilities{
+		Org:       org,
+		Features:  map[string]bool{},
+		Endpoints: map[string]bool{},
+	}
+
+	if metadata, err := c.GetOrgMetadata(); err == nil {
+		caps.Pipeline = metadata.Pipeline
+	} else {
+		c.Log.Warning("Unable to determine the org's authentication pipeline:", err)
+	}
+
+	// Listing features needs a super admin token; without it, features are simply unknown
+	if features, err := c.ListFeatures(); err == nil {
+		for _, f := range *features {
+			caps.Features[f.Name] = f.Status == "ENABLED"
+		}
+	} else {
+		c.Log.Warning("Unable to list the org's features:", err)
+	}
+
+	for _, endpoint := range OptionalEndpoints {
+		_, _, err := c.HTTP.DoRequest("GET", c.BuildURL(endpoint), map[string]interface{}{"limit": 1}, nil)
+		switch {
+		case err == nil:
+			caps.Endpoints[endpoint] = true
+		case unsupported(err):
+			caps.Endpoints[endpoint] = false
+		default:
+			return nil, fmt.Errorf("probing %s: %w", c.BuildURL(endpoint), err)
+		}
+	}
+
+	c.Log.Debugf("Okta org %s: pipeline=%s, %d features, endpoints=%v", org.Subdomain, caps.Pipeline, len(caps.Features), caps.Endpoints)
+	c.capabilities = caps
+	return caps, nil
+}
+
+/*
+ * # Check whether the Org Supports an Endpoint
+ * e.g. `c.Supports(okta.OktaDevices)`; endpoints which are not probed, or a failed discovery, count as supported
+ */
+func (c *Client) Supports(endpoint string) bool {
+	caps, err := c.Capabilities()
+	if err != nil {
+		c.Log.Warning("Unable to discover the org's capabilities; assuming", endpoint, "is supported:", err)
+		return true
+	}
+	return caps.Supports(endpoint)
+}
+
+// Supports reports whether the org can call an endpoint; endpoints which were not probed count as supported
+func (caps *Capabilities) Supports(endpoint string) bool {
+	supported, probed := caps.Endpoints[endpoint]
+	return !probed || supported
+}
+
+// FeatureEnabled reports whether a self-service feature is enabled, e.g. `Direct Authentication`
+func (caps *Capabilities) FeatureEnabled(name string) bool {
+	return caps.Features[name]
+}
+
+// IdentityEngine reports whether the org runs Okta Identity Engine, rather than the Classic Engine
+func (caps *Capabilities) IdentityEngine() bool {
+	return caps.Pipeline == "idx"
+}
+
+// unsupported reports whether an error means the org does not have an endpoint, rather than a transient failure
+func unsupported(err error) bool {
+	switch requests.StatusCode(err) {
+	case http.StatusNotFound, http.StatusForbidden:
+		return true
+	case http.StatusUnauthorized:
+		// E0000015: "You do not have permission to access the feature you are requesting"
+		return strings.Contains(err.Error(), "E0000015")
+	}
+	return false
+}
+
+// backoff does not retry errors which cannot succeed on a later attempt
+func backoff(attempt int, err error) (time.Duration, bool) {
+	switch requests.StatusCode(err) {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		return 0, false
+	}
+	return requests.DefaultBackoff(attempt, err)
+}
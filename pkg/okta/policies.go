@@ -0,0 +1,156 @@
+/*
+# Okta Policies
+
+This package contains all the methods to interact with the Okta Policies API, including the authentication (app
+sign-on) policies of Identity Engine orgs, and their rules:
+https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Policy/
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/okta/policies.go
+package okta
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Types of policies
+const (
+	PolicyTypeAccess = "ACCESS_POLICY" // Authentication (app sign-on) policies, Identity Engine orgs only
+	PolicyTypeSignOn = "OKTA_SIGN_ON"  // Global session policies
+)
+
+/*
+ * # List all Policies
+ * /api/v1/policies
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Policy/#tag/Policy/operation/listPolicies
+ * @param policyType string - Type of the policies to list, e.g. PolicyTypeAccess
+ */
+func (c *Client) ListPolicies(policyType string) (*Policies, error) {
+	url := c.BuildURL(OktaPolicies)
+
+	q := struct {
+		Type string `url:"type,omitempty"`
+	}{policyType}
+
+	var cache Policies
+	if c.GetCache(url+"?type="+policyType, &cache) {
+		return &cache, nil
+	}
+
+	policies, err := doPaginated[Policies](c, "GET", url, q)
+	if err != nil {
+		return nil, err
+	}
+
+	c.SetCache(url+"?type="+policyType, policies, 5*time.Minute)
+	return policies, nil
+}
+
+/*
+ * # Get a Policy
+ * /api/v1/policies/{policyId}
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Policy/#tag/Policy/operation/getPolicy
+ */
+func (c *Client) GetPolicy(policyID string) (*Policy, error) {
+	url := c.BuildURL(OktaPolicies, policyID)
+
+	return do[*Policy](c, "GET", url, nil, nil)
+}
+
+/*
+ * # List all Policy Rules
+ * /api/v1/policies/{policyId}/rules
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/Policy/#tag/Policy/operation/listPolicyRules
+ * - Rules are evaluated in the order of their priority; the last is the catch-all rule of the policy
+ */
+func (c *Client) ListPolicyRules(policyID string) (*PolicyRules, error) {
+	url := c.BuildURL(OktaPolicies, policyID, "rules")
+
+	var cache PolicyRules
+	if c.GetCache(url, &cache) {
+		return &cache, nil
+	}
+
+	rules, err := doPaginated[PolicyRules](c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.SetCache(url, rules, 5*time.Minute)
+	return rules, nil
+}
+
+// AccessPolicyID returns the ID of the authentication policy of an application, from its links; empty when it has none
+func (a *Application) AccessPolicyID() string {
+	href := strings.TrimRight(a.Links.AccessPolicy.Href, "/")
+	if href == "" {
+		return ""
+	}
+	return href[strings.LastIndex(href, "/")+1:]
+}
+
+// PhishingResistant reports whether the rule requires a phishing-resistant possession factor, e.g. FastPass or FIDO2
+func (v *VerificationMethod) PhishingResistant() bool {
+	for _, constraint := range v.Constraints {
+		if constraint.Possession != nil && constraint.Possession.PhishingResistant == "REQUIRED" {
+			return true
+		}
+	}
+	return false
+}
+
+// MFA reports whether the rule requires two factors, or an authenticator constraint with more than a password
+func (v *VerificationMethod) MFA() bool {
+	if v.FactorMode == "2FA" {
+		return true
+	}
+	for _, constraint := range v.Constraints {
+		if constraint.Possession != nil && constraint.Knowledge != nil {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+ * Reauthentication returns how long a session satisfies the rule before users must authenticate again
+ * Zero means users authenticate on every sign-on; a negative duration means never (`reauthenticateIn` is not set)
+ */
+func (v *VerificationMethod) Reauthentication() (time.Duration, error) {
+	if v.ReauthenticateIn == "" {
+		return -1, nil
+	}
+	return parseISODuration(v.ReauthenticateIn)
+}
+
+var isoDuration = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseISODuration parses the ISO 8601 durations used by Okta policies, e.g. `PT2H`, `PT0S`, `P1DT12H`
+func parseISODuration(s string) (time.Duration, error) {
+	match := isoDuration.FindStringSubmatch(s)
+	if match == nil || s == "P" || s == "PT" {
+		return 0, fmt.Errorf("invalid ISO 8601 duration %q", s)
+	}
+
+	units := []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, unit := range units {
+		if match[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(match[i+1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid ISO 8601 duration %q: %w", s, err)
+		}
+		d += time.Duration(n) * unit
+	}
+	return d, nil
+}
@@ -0,0 +1,256 @@
+/*
+# Okta Risk
+
+This package contains all the methods to interact with the Okta Risk Providers API, and pulls the risk events of the
+System Log into a per-user risk score feed, for correlation with the anomalies seen by other providers:
+https://developer.okta.com/docs/api/openapi/okta-management/management/tag/RiskProvider/
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/okta/risk.go
+package okta
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Levels of a UserRiskScore
+const (
+	RiskLow    = "LOW"
+	RiskMedium = "MEDIUM"
+	RiskHigh   = "HIGH"
+)
+
+// RiskEventTypes are the System Log events pulled by ListRiskEvents by default
+// Sign-on evaluations (EventPolicyEvaluateSignOn) carry the risk and behaviors of every sign-in, but are high volume; pass them explicitly
+var RiskEventTypes = []EventType{
+	EventUserRiskDetect,
+	EventUserRiskChange,
+	EventSecurityThreatDetected,
+	EventSecurityEventsProviderReceiveEvent,
+	EventUserAccountReportSuspiciousActivityByEnduser,
+}
+
+// Weight of a risk event by its reported level, and of event types which report no level
+var (
+	riskLevelWeights = map[string]int{RiskHigh: 40, RiskMedium: 20, RiskLow: 5}
+	riskEventWeights = map[string]int{
+		string(EventSecurityThreatDetected):                       25,
+		string(EventSecurityEventsProviderReceiveEvent):           15,
+		string(EventUserAccountReportSuspiciousActivityByEnduser): 40,
+	}
+)
+
+const behaviorWeight = 5 // Weight of every behavior detected on a sign-on
+
+/*
+ * # List all Risk Providers
+ * /api/v1/risk/providers
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/RiskProvider/#tag/RiskProvider/operation/listRiskProviders
+ * - Identity Engine orgs only; see Supports(OktaRisk)
+ */
+func (c *Client) ListRiskProviders() (*RiskProviders, error) {
+	url := c.BuildURL(OktaRisk)
+
+	if !c.Supports(OktaRisk) {
+		return nil, fmt.Errorf("risk providers are %w", ErrUnsupported)
+	}
+
+	return doPaginated[RiskProviders](c, "GET", url, nil)
+}
+
+/*
+ * # List the Risk Events of the System Log
+ * /api/v1/logs
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/SystemLog/#tag/SystemLog/operation/listLogEvents
+ * @param types ...EventType - Event types to pull. Default: RiskEventTypes
+ */
+func (c *Client) ListRiskEvents(since, until time.Time, types ...EventType) (*LogEvents, error) {
+	if len(types) == 0 {
+		types = RiskEventTypes
+	}
+
+	return c.ListLogEvents(&LogQuery{
+		Since:     since.UTC().Format(time.RFC3339),
+		Until:     until.UTC().Format(time.RFC3339),
+		Filter:    EventTypeFilter(types...),
+		SortOrder: "ASCENDING",
+	})
+}
+
+/*
+ * # Score the Risk of every User
+ * Pulls the risk events between `since` and `until` (see ListRiskEvents) and scores them per user, riskiest first
+ */
+func (c *Client) UserRiskScores(since, until time.Time, types ...EventType) ([]*UserRiskScore, error) {
+	events, err := c.ListRiskEvents(since, until, types...)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := ScoreRiskEvents(*events)
+	c.Log.Printf("Scored %d risk event(s) across %d user(s)", len(*events), len(scores))
+	return scores, nil
+}
+
+/*
+ * ScoreRiskEvents aggregates risk events into a score per user, riskiest first
+ * Every event adds the weight of its risk level (or of its type when it reports none) and of each behavior it detected;
+ * scores are capped at 100. Events which do not concern a user are ignored
+ */
+func ScoreRiskEvents(events LogEvents) []*UserRiskScore {
+	users := map[string]*UserRiskScore{}
+	reasons := map[string]map[string]bool{}
+	behaviors := map[string]map[string]bool{}
+
+	for _, event := range events {
+		subject := riskSubject(event)
+		if subject == nil {
+			continue
+		}
+
+		score, ok := users[subject.ID]
+		if !ok {
+			score = &UserRiskScore{UserID: subject.ID, Login: subject.AlternateID, FirstSeen: event.Published}
+			users[subject.ID] = score
+			reasons[subject.ID] = map[string]bool{}
+			behaviors[subject.ID] = map[string]bool{}
+		}
+		score.Events++
+		if event.Published.Before(score.FirstSeen) {
+			score.FirstSeen = event.Published
+		}
+		if event.Published.After(score.LastSeen) {
+			score.LastSeen = event.Published
+		}
+
+		level, eventReasons := riskLevel(event)
+		weight, ok := riskLevelWeights[level]
+		if !ok {
+			weight = riskEventWeights[event.EventType]
+		}
+		for _, reason := range eventReasons {
+			reasons[subject.ID][reason] = true
+		}
+		for _, behavior := range detectedBehaviors(event) {
+			behaviors[subject.ID][behavior] = true
+			weight += behaviorWeight
+		}
+		score.Score += weight
+	}
+
+	scores := make([]*UserRiskScore, 0, len(users))
+	for id, score := range users {
+		score.Score = min(score.Score, 100)
+		switch {
+		case score.Score >= 60:
+			score.Level = RiskHigh
+		case score.Score >= 25:
+			score.Level = RiskMedium
+		default:
+			score.Level = RiskLow
+		}
+		score.Reasons = sortedKeys(reasons[id])
+		score.Behaviors = sortedKeys(behaviors[id])
+		scores = append(scores, score)
+	}
+
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return scores[i].Login < scores[j].Login
+	})
+	return scores
+}
+
+// riskSubject returns the user an event is about: its first `User` target, or a `User` actor
+func riskSubject(event *LogEvent) *LogActor {
+	for _, target := range event.Target {
+		if target.Type == "User" && target.ID != "" {
+			return target
+		}
+	}
+	if event.Actor != nil && event.Actor.Type == "User" && event.Actor.ID != "" {
+		return event.Actor
+	}
+	return nil
+}
+
+// riskLevel reads the risk level and reasons of an event, e.g. debugData `risk: {reasons=New Device, New IP, level=HIGH}`
+func riskLevel(event *LogEvent) (string, []string) {
+	if event.DebugContext == nil {
+		return "", nil
+	}
+	data := event.DebugContext.DebugData
+
+	var level, reasons string
+	if risk, ok := data["risk"].(string); ok {
+		fields := parseDebugMap(risk)
+		level, reasons = fields["level"], fields["reasons"]
+	}
+	if level == "" {
+		level, _ = data["riskLevel"].(string)
+	}
+	if reasons == "" {
+		reasons, _ = data["riskReasons"].(string)
+	}
+
+	list := []string{}
+	for _, reason := range strings.Split(reasons, ",") {
+		if reason = strings.TrimSpace(reason); reason != "" {
+			list = append(list, reason)
+		}
+	}
+	return strings.ToUpper(level), list
+}
+
+// detectedBehaviors returns the behaviors detected on a sign-on, e.g. debugData `behaviors: {New Device=POSITIVE, New IP=NEGATIVE}`
+func detectedBehaviors(event *LogEvent) []string {
+	if event.DebugContext == nil {
+		return nil
+	}
+	raw, ok := event.DebugContext.DebugData["behaviors"].(string)
+	if !ok {
+		return nil
+	}
+
+	detected := []string{}
+	for behavior, result := range parseDebugMap(raw) {
+		if result == "POSITIVE" {
+			detected = append(detected, behavior)
+		}
+	}
+	sort.Strings(detected)
+	return detected
+}
+
+// parseDebugMap parses the `{key=value, key=value}` strings of debugData; segments without `=` continue the previous value
+func parseDebugMap(s string) map[string]string {
+	fields := map[string]string{}
+	key := ""
+	for _, segment := range strings.Split(strings.Trim(strings.TrimSpace(s), "{}"), ", ") {
+		if k, v, ok := strings.Cut(segment, "="); ok {
+			key = strings.TrimSpace(k)
+			fields[key] = strings.TrimSpace(v)
+		} else if key != "" {
+			fields[key] += ", " + strings.TrimSpace(segment)
+		}
+	}
+	return fields
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
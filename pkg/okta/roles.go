@@ -164,7 +164,7 @@ func (c *Client) GetUserRoles(userID string) (*Roles, error) {
 		return &cache, nil
 	}
 
-	roles, err := doPaginated[Roles](c, "GET", url, nil, nil)
+	roles, err := doPaginated[Roles](c, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -186,7 +186,7 @@ func (c *Client) ListAllUsersWithRoleAssignments() (*Users, error) {
 		return &cache, nil
 	}
 
-	users, err := doPaginated[Users](c, "GET", url, nil, nil)
+	users, err := doPaginated[Users](c, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -13,7 +13,11 @@ https://developer.okta.com/docs/api/openapi/okta-management/management/tag/User/
 package okta
 
 import (
+	"fmt"
+	"strings"
 	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/optional"
 )
 
 /*
@@ -47,7 +51,7 @@ func (c *Client) ListAllUsers() (*Users, error) {
 		Search: `status eq "STAGED" or status eq "PROVISIONED" or status eq "ACTIVE" or status eq "RECOVERY" or status eq "LOCKED_OUT" or status eq "PASSWORD_EXPIRED" or status eq "SUSPENDED" or status eq "DEPROVISIONED"`,
 	}
 
-	users, err := doPaginated[Users](c, "GET", url, q, nil)
+	users, err := doPaginated[Users](c, "GET", url, q)
 	if err != nil {
 		return nil, err
 	}
@@ -74,7 +78,7 @@ func (c *Client) ListActiveUsers() (*Users, error) {
 		Search: `status eq "ACTIVE"`,
 	}
 
-	users, err := doPaginated[Users](c, "GET", url, q, nil)
+	users, err := doPaginated[Users](c, "GET", url, q)
 	if err != nil {
 		return nil, err
 	}
@@ -105,6 +109,55 @@ func (c *Client) GetUser(userID string) (*User, error) {
 	return &user, nil
 }
 
+/*
+ * # Find a user by login
+ * Returns a nil user (and no error) when no user has the login
+ * /api/v1/users?search=profile.login eq "{login}"
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/User/#tag/User/operation/listUsers
+ */
+func (c *Client) FindUserByLogin(login string) (*User, error) {
+	url := c.BuildURL(OktaUsers)
+
+	q := &UserQuery{
+		Limit:  `2`,
+		Search: fmt.Sprintf(`profile.login eq "%s"`, strings.ReplaceAll(login, `"`, `\"`)),
+	}
+
+	users, err := do[Users](c, "GET", url, q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		if user.Profile != nil && strings.EqualFold(user.Profile.Login, login) {
+			return user, nil
+		}
+	}
+
+	return nil, nil
+}
+
+/*
+ * # Create a User
+ * /api/v1/users?activate={activate}
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/User/#tag/User/operation/createUser
+ * - The user is created without credentials; activating it sends the activation email
+ */
+func (c *Client) CreateUser(profile *UserProfile, activate bool, groupIDs ...string) (*User, error) {
+	url := c.BuildURL(OktaUsers)
+
+	q := struct {
+		Activate *bool `url:"activate"`
+	}{optional.Of(activate)}
+
+	body := struct {
+		Profile  *UserProfile `json:"profile"`
+		GroupIDs []string     `json:"groupIds,omitempty"`
+	}{profile, groupIDs}
+
+	return do[*User](c, "POST", url, q, body)
+}
+
 /*
  * # Update a user's properties by ID
  * /api/v1/users/{userId}
@@ -197,3 +250,121 @@ func (c *Client) RevokeUserSessions(userID string) error {
 
 	return nil
 }
+
+/*
+ * Query Parameters for Revoking User Sessions
+ */
+type UserSessionsQuery struct {
+	OauthTokens bool // Revoke issued OpenID Connect and OAuth refresh and access tokens
+}
+
+/*
+ * # List all Clients with Grants or Tokens for a User
+ * /api/v1/users/{userId}/clients
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/UserResources/#tag/UserResources/operation/listUserClients
+ */
+func (c *Client) ListUserClients(userID string) (*UserClients, error) {
+	url := c.BuildURL(OktaUsers, userID, "clients")
+
+	clients, err := do[UserClients](c, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &clients, nil
+}
+
+/*
+ * # Revoke all Refresh Tokens for a Client
+ * /api/v1/users/{userId}/clients/{clientId}/tokens
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/UserOAuth/#tag/UserOAuth/operation/revokeTokensForUserAndClient
+ */
+func (c *Client) RevokeUserClientTokens(userID, clientID string) error {
+	url := c.BuildURL(OktaUsers, userID, "clients", clientID, "tokens")
+
+	_, err := do[interface{}](c, "DELETE", url, nil, nil)
+	return err
+}
+
+/*
+ * # Revoke all User Grants
+ * /api/v1/users/{userId}/grants
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/UserGrant/#tag/UserGrant/operation/revokeUserGrants
+ */
+func (c *Client) RevokeUserGrants(userID string) error {
+	url := c.BuildURL(OktaUsers, userID, "grants")
+
+	_, err := do[interface{}](c, "DELETE", url, nil, nil)
+	return err
+}
+
+/*
+ * # Expire a User's Password
+ * /api/v1/users/{userId}/lifecycle/expire_password
+ * - https://developer.okta.com/docs/api/openapi/okta-management/management/tag/UserLifecycle/#tag/UserLifecycle/operation/expirePassword
+ */
+func (c *Client) ExpirePassword(userID string) (*User, error) {
+	url := c.BuildURL(OktaUsers, userID, "lifecycle", "expire_password")
+
+	user, err := do[User](c, "POST", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+/*
+ * # Revoke all Sessions and Tokens for a User
+ * The standard compromise response, in one call:
+ * - Clears every session, revoking the OpenID Connect/OAuth tokens issued with them
+ * - Revokes the refresh tokens of every app the user has authorized, and the user's grants
+ * - Optionally expires the password, forcing a reset at the next sign in
+ * Every step is attempted even if an earlier one fails; the result records what was revoked
+ */
+func (c *Client) RevokeAllSessions(userID string, expirePassword bool) (*SessionRevocation, error) {
+	revocation := &SessionRevocation{UserID: userID}
+	var errs []error
+
+	url := c.BuildURL(OktaUsers, userID, "sessions")
+	if _, err := do[interface{}](c, "DELETE", url, &UserSessionsQuery{OauthTokens: true}, nil); err != nil {
+		errs = append(errs, fmt.Errorf("revoking sessions: %w", err))
+	} else {
+		revocation.Sessions = true
+	}
+
+	clients, err := c.ListUserClients(userID)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("listing clients: %w", err))
+	} else {
+		for _, client := range *clients {
+			if err := c.RevokeUserClientTokens(userID, client.ClientID); err != nil {
+				errs = append(errs, fmt.Errorf("revoking tokens for %s: %w", client.ClientName, err))
+				continue
+			}
+			revocation.Clients = append(revocation.Clients, client.ClientName)
+		}
+	}
+
+	if err := c.RevokeUserGrants(userID); err != nil {
+		errs = append(errs, fmt.Errorf("revoking grants: %w", err))
+	} else {
+		revocation.Grants = true
+	}
+
+	if expirePassword {
+		if _, err := c.ExpirePassword(userID); err != nil {
+			errs = append(errs, fmt.Errorf("expiring password: %w", err))
+		} else {
+			revocation.PasswordExpired = true
+		}
+	}
+
+	if len(errs) > 0 {
+		c.Log.Errorf("Incomplete revocation for %s: %v", userID, errs)
+		return revocation, fmt.Errorf("error revoking sessions for %s: %v", userID, errs)
+	}
+
+	c.Log.Printf("Revoked sessions, %d client token set(s) and grants for %s", len(revocation.Clients), userID)
+	return revocation, nil
+}
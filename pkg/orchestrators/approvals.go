@@ -0,0 +1,200 @@
+/*
+# Orchestrators - Approval Gate
+
+This package contains the approval gate holding automated actions until an approver (e.g. the requester's manager)
+has allowed them, so automations can act on requests without acting on their own.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/approvals.go
+package orchestrators
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gemini-oss/rego/pkg/common/notify"
+)
+
+// Decisions of an ApprovalGate
+const (
+	ApprovalPending  = "pending"
+	ApprovalApproved = "approved"
+	ApprovalDenied   = "denied"
+)
+
+// ApprovalRequest asks an approver to allow an action
+type ApprovalRequest struct {
+	ID        string            // Stable identifier of the action; the approver is only asked once per ID
+	Approver  string            // Email of the approver
+	Requester string            // Email of the user the action is for
+	Title     string            // Short summary of the action
+	Body      string            // What will happen once approved
+	Fields    map[string]string // Optional structured details
+}
+
+/*
+ * ApprovalGate holds actions until an approver decides on them
+ * Decide is called on every run of an automation until the action is approved or denied, so it must ask the approver
+ * only once per request ID, and return ApprovalPending, ApprovalApproved or ApprovalDenied
+ */
+type ApprovalGate interface {
+	Decide(request *ApprovalRequest) (string, error)
+}
+
+/*
+ * NotifierGate is an ApprovalGate asking for approval through a notifier, e.g. a Slack channel or a webhook
+ * Decisions are recorded with Approve and Deny (e.g. from a Slack interaction or an HTTP handler) by the approver of the
+ * request, and are kept in StateFile so pending requests are neither forgotten nor asked again after a restart
+ */
+type NotifierGate struct {
+	Notifier  notify.Notifier
+	StateFile string // JSON file keeping the requests and decisions across restarts; in memory only when empty
+	state     notifierGateState
+	mutex     sync.Mutex
+}
+
+// notifierGateState is the state of a NotifierGate kept in its StateFile
+type notifierGateState struct {
+	Requests  map[string]*ApprovalRequest `json:"requests"`  // Requests the approver was asked about, by ID
+	Decisions map[string]string           `json:"decisions"` // Decisions on them, by ID
+}
+
+// NewNotifierGate returns a gate asking through `notifier`; its state is loaded from `stateFile` when it exists
+func NewNotifierGate(notifier notify.Notifier, stateFile string) (*NotifierGate, error) {
+	g := &NotifierGate{
+		Notifier:  notifier,
+		StateFile: stateFile,
+	}
+
+	if stateFile != "" {
+		data, err := os.ReadFile(stateFile)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			return nil, err
+		default:
+			if err := json.Unmarshal(data, &g.state); err != nil {
+				return nil, fmt.Errorf("reading the approval state %s: %w", stateFile, err)
+			}
+		}
+	}
+	if g.state.Requests == nil {
+		g.state.Requests = make(map[string]*ApprovalRequest)
+	}
+	if g.state.Decisions == nil {
+		g.state.Decisions = make(map[string]string)
+	}
+
+	return g, nil
+}
+
+// Decide returns the decision recorded for the request, asking the approver when the request is new
+func (g *NotifierGate) Decide(request *ApprovalRequest) (string, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if decision, ok := g.state.Decisions[request.ID]; ok {
+		return decision, nil
+	}
+	if _, ok := g.state.Requests[request.ID]; ok {
+		return ApprovalPending, nil
+	}
+
+	if err := g.Notifier.Notify(approvalMessage(request)); err != nil {
+		return ApprovalPending, fmt.Errorf("asking %s to approve %s: %w", request.Approver, request.ID, err)
+	}
+	g.state.Requests[request.ID] = request
+
+	if err := g.save(); err != nil {
+		return ApprovalPending, fmt.Errorf("saving the approval request %s: %w", request.ID, err)
+	}
+	return ApprovalPending, nil
+}
+
+// Approve records the approval of a pending request by `approver`, who must be the approver of the request
+func (g *NotifierGate) Approve(id, approver string) error {
+	return g.decide(id, approver, ApprovalApproved)
+}
+
+// Deny records the denial of a pending request by `approver`, who must be the approver of the request
+func (g *NotifierGate) Deny(id, approver string) error {
+	return g.decide(id, approver, ApprovalDenied)
+}
+
+// Pending returns the requests awaiting a decision, by ID
+func (g *NotifierGate) Pending() []*ApprovalRequest {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	pending := []*ApprovalRequest{}
+	for id, request := range g.state.Requests {
+		if _, ok := g.state.Decisions[id]; !ok {
+			pending = append(pending, request)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].ID < pending[j].ID })
+
+	return pending
+}
+
+func (g *NotifierGate) decide(id, approver, decision string) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	request, ok := g.state.Requests[id]
+	if !ok {
+		return fmt.Errorf("no approval was requested for %s", id)
+	}
+	if approver = strings.TrimSpace(approver); approver == "" || !strings.EqualFold(approver, request.Approver) {
+		return fmt.Errorf("%s is not the approver of %s", approver, id)
+	}
+	if previous, ok := g.state.Decisions[id]; ok && previous != decision {
+		return fmt.Errorf("%s was already %s", id, previous)
+	}
+	g.state.Decisions[id] = decision
+
+	return g.save()
+}
+
+// save writes the state to StateFile, if set
+func (g *NotifierGate) save() error {
+	if g.StateFile == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(g.state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(g.StateFile, data, 0o600)
+}
+
+// approvalMessage is the message asking an approver to decide on a request
+func approvalMessage(request *ApprovalRequest) *notify.Message {
+	fields := map[string]string{
+		"approval_id": request.ID,
+		"approver":    request.Approver,
+		"requester":   request.Requester,
+	}
+	for key, value := range request.Fields {
+		fields[key] = value
+	}
+
+	return &notify.Message{
+		Severity: notify.Info,
+		Title:    request.Title,
+		Body:     fmt.Sprintf("%s\n\nApproval requested from %s.", request.Body, request.Approver),
+		Fields:   fields,
+		DedupKey: "rego-approval-" + request.ID,
+	}
+}
@@ -0,0 +1,163 @@
+/*
+# Orchestrators - Change Attribution
+
+This package contains an orchestration answering "who did this": searching the Okta System Log and the Google Admin audit log
+for the actors who changed an object within a time window.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/attribution.go
+package orchestrators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/google"
+	"github.com/gemini-oss/rego/pkg/okta"
+)
+
+// ChangeAttribution is a single audited change to an object
+type ChangeAttribution struct {
+	Provider  string    // Audit log the change was found in
+	Time      time.Time // Time of the change
+	Actor     string    // Login/email of the actor (or the API client/key for automated changes)
+	ActorType string    // Type of actor, e.g. `User`, `PublicClientApp`, `USER`, `KEY`
+	Action    string    // Event type/name, e.g. `group.user_membership.add`, `CHANGE_USER_ORGANIZATION`
+	Detail    string    // Human readable description of the change
+	IPAddress string    // IP address the change was made from
+	Outcome   string    // Result of the change, when reported
+}
+
+/*
+ * Orchestrate the following:
+ * Search the Okta System Log for events targeting the object (by ID or login/email/name)
+ * Search the Google Admin audit log for events mentioning the object
+ * Return every change, oldest first
+ */
+func (c *Client) WhoDidThis(target string, start, end time.Time) ([]*ChangeAttribution, error) {
+	changes := []*ChangeAttribution{}
+	var errs []*SourceError
+
+	if c.Okta != nil {
+		events, err := c.Okta.ListTargetLogEvents(target, start, end)
+		if err != nil {
+			errs = append(errs, &SourceError{Source: "Okta", Err: err})
+		} else {
+			for _, event := range *events {
+				changes = append(changes, oktaChange(event))
+			}
+		}
+	}
+
+	if c.Google != nil {
+		activities, err := c.Google.Admin().ListTargetActivities("admin", target, start, end)
+		if err != nil {
+			errs = append(errs, &SourceError{Source: "Google", Err: err})
+		} else {
+			for _, activity := range activities {
+				changes = append(changes, googleChanges(&activity)...)
+			}
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Time.Before(changes[j].Time)
+	})
+
+	c.Log.Printf("Found %d change(s) to %s between %s and %s", len(changes), target, start.Format(time.RFC3339), end.Format(time.RFC3339))
+
+	return complete(c, "audit log search", changes, errs)
+}
+
+// oktaChange converts a System Log event into a change
+func oktaChange(event *okta.LogEvent) *ChangeAttribution {
+	change := &ChangeAttribution{
+		Provider: "Okta",
+		Time:     event.Published,
+		Action:   event.EventType,
+		Detail:   event.DisplayMessage,
+	}
+	if event.Actor != nil {
+		change.Actor = event.Actor.AlternateID
+		change.ActorType = event.Actor.Type
+	}
+	if event.Client != nil {
+		change.IPAddress = event.Client.IPAddress
+	}
+	if event.Outcome != nil {
+		change.Outcome = event.Outcome.Result
+	}
+	return change
+}
+
+// googleChanges converts a Reports API activity into a change per event
+func googleChanges(activity *google.Report) []*ChangeAttribution {
+	when, _ := time.Parse(time.RFC3339, activity.ID.Time)
+	actor := activity.Actor.Email
+	if actor == "" {
+		actor = activity.Actor.Key
+	}
+
+	changes := make([]*ChangeAttribution, 0, len(activity.Events))
+	for _, event := range activity.Events {
+		changes = append(changes, &ChangeAttribution{
+			Provider:  "Google",
+			Time:      when,
+			Actor:     actor,
+			ActorType: activity.Actor.CallerType,
+			Action:    event.Name,
+			Detail:    event.Type,
+			IPAddress: activity.IPAddress,
+		})
+	}
+	return changes
+}
+
+/*
+ * Summarize the actors behind a set of changes, most active first
+ * e.g. ["admin@gemini.com (3: Okta group.user_membership.add, ...)"]
+ */
+func ChangeActors(changes []*ChangeAttribution) []string {
+	type actor struct {
+		name    string
+		actions []string
+	}
+
+	actors := make(map[string]*actor)
+	for _, change := range changes {
+		name := change.Actor
+		if name == "" {
+			name = "unknown"
+		}
+		a, ok := actors[name]
+		if !ok {
+			a = &actor{name: name}
+			actors[name] = a
+		}
+		a.actions = append(a.actions, fmt.Sprintf("%s %s", change.Provider, change.Action))
+	}
+
+	sorted := make([]*actor, 0, len(actors))
+	for _, a := range actors {
+		sorted = append(sorted, a)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if len(sorted[i].actions) != len(sorted[j].actions) {
+			return len(sorted[i].actions) > len(sorted[j].actions)
+		}
+		return sorted[i].name < sorted[j].name
+	})
+
+	summary := make([]string, 0, len(sorted))
+	for _, a := range sorted {
+		summary = append(summary, fmt.Sprintf("%s (%d: %s)", a.name, len(a.actions), strings.Join(a.actions, ", ")))
+	}
+
+	return summary
+}
@@ -0,0 +1,464 @@
+/*
+# Orchestrators - Break-Glass Monitoring
+
+This package contains the monitoring of break-glass (emergency access) accounts: any authentication or action of these
+accounts in the Okta System Log or the Google audit logs raises a critical alert, and the accounts are periodically
+verified to still be configured as expected.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/break_glass.go
+package orchestrators
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/notify"
+	"github.com/gemini-oss/rego/pkg/common/requests"
+	"github.com/gemini-oss/rego/pkg/google"
+	"github.com/gemini-oss/rego/pkg/okta"
+)
+
+// BreakGlassOptions configures the monitoring of the break-glass accounts
+type BreakGlassOptions struct {
+	GoogleAccounts     []string        // Emails of the Google break-glass accounts
+	OktaAccounts       []string        // Logins of the Okta break-glass accounts
+	GoogleApplications []string        // Google audit logs searched for activity of the accounts. Default: login, admin, token, saml
+	GoogleOrgUnit      string          // Organizational unit the Google accounts must be in; not verified when empty
+	Lookback           time.Duration   // Window searched by the first poll, e.g. to cover a restart. Default: 15 minutes
+	OktaOverlap        time.Duration   // Overlap between consecutive Okta polls, for late events. Default: 2 minutes
+	GoogleOverlap      time.Duration   // Overlap between consecutive Google polls; Google audit events can take a while to appear. Default: 1 hour
+	VerifyInterval     time.Duration   // Time between verifications of the accounts, under Schedule. Default: 1 hour
+	Notifier           notify.Notifier // Receives the alerts. Required
+}
+
+// BreakGlassEvent is an authentication or action involving a break-glass account
+type BreakGlassEvent struct {
+	Account string // Break-glass account involved, as an actor or a target
+	ChangeAttribution
+	key    string    // Key of the audit event, marked as seen once alerted on
+	logged time.Time // Time of the audit event, to forget its key once out of every window
+}
+
+// BreakGlassFinding is a break-glass account which is no longer configured as expected
+type BreakGlassFinding struct {
+	Provider string // Okta or Google
+	Account  string
+	Issue    string // What is wrong, e.g. `suspended`, `not enrolled in 2SV`
+}
+
+// BreakGlassMonitor polls the audit logs for activity of the break-glass accounts
+type BreakGlassMonitor struct {
+	client   *Client
+	opts     *BreakGlassOptions
+	cursors  map[string]time.Time // Provider -> end of the last window polled
+	seen     map[string]time.Time // Event key -> time of the event, to alert once on overlapping windows
+	verified time.Time
+	mutex    sync.Mutex
+}
+
+// Entry point for the break-glass monitoring
+func (c *Client) BreakGlassMonitor(opts *BreakGlassOptions) (*BreakGlassMonitor, error) {
+	if opts == nil || len(opts.GoogleAccounts)+len(opts.OktaAccounts) == 0 {
+		return nil, fmt.Errorf("no break-glass accounts to monitor")
+	}
+	if opts.Notifier == nil {
+		return nil, fmt.Errorf("break-glass monitoring needs a notifier")
+	}
+	if len(opts.GoogleAccounts) > 0 && c.Google == nil {
+		return nil, fmt.Errorf("google break-glass accounts configured without a google client")
+	}
+	if len(opts.OktaAccounts) > 0 && c.Okta == nil {
+		return nil, fmt.Errorf("okta break-glass accounts configured without an okta client")
+	}
+	if len(opts.GoogleApplications) == 0 {
+		opts.GoogleApplications = []string{"login", "admin", "token", "saml"}
+	}
+	if opts.Lookback == 0 {
+		opts.Lookback = 15 * time.Minute
+	}
+	if opts.OktaOverlap == 0 {
+		opts.OktaOverlap = 2 * time.Minute
+	}
+	if opts.GoogleOverlap == 0 {
+		opts.GoogleOverlap = time.Hour
+	}
+	if opts.VerifyInterval == 0 {
+		opts.VerifyInterval = time.Hour
+	}
+
+	return &BreakGlassMonitor{
+		client:  c,
+		opts:    opts,
+		cursors: make(map[string]time.Time),
+		seen:    make(map[string]time.Time),
+	}, nil
+}
+
+/*
+ * Orchestrate the following:
+ * Search the Okta System Log for events with a break-glass account as actor or target, since the last poll
+ * Search the Google audit logs for activities of the break-glass accounts, since the last poll
+ * Send a critical alert for every event not alerted on yet
+ */
+func (m *BreakGlassMonitor) Poll() ([]*BreakGlassEvent, error) {
+	c := m.client
+	if err := c.checkFlag(FlagBreakGlassMonitor); err != nil {
+		return nil, err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+	found := []*BreakGlassEvent{}
+	var errs []*SourceError
+
+	if len(m.opts.OktaAccounts) > 0 {
+		events, err := m.oktaEvents(m.since("Okta", m.opts.OktaOverlap, now), now)
+		if err != nil {
+			errs = append(errs, &SourceError{Source: "Okta", Err: err})
+		} else {
+			found = append(found, events...)
+			m.cursors["Okta"] = now
+		}
+	}
+
+	if len(m.opts.GoogleAccounts) > 0 {
+		events, err := m.googleEvents(m.since("Google", m.opts.GoogleOverlap, now), now)
+		if err != nil {
+			errs = append(errs, &SourceError{Source: "Google", Err: err})
+		} else {
+			found = append(found, events...)
+			m.cursors["Google"] = now
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].Time.Before(found[j].Time)
+	})
+
+	// An event is only seen once alerted on; a failed alert is retried by the next poll, which starts before it
+	failed := map[string]bool{}
+	for _, event := range found {
+		c.Log.Warningf("Break-glass account %s used: %s %s from %s", event.Account, event.Provider, event.Action, event.IPAddress)
+		if err := m.opts.Notifier.Notify(breakGlassMessage(event)); err != nil {
+			errs = append(errs, &SourceError{Source: "Notifications", Err: err})
+			failed[event.key] = true
+			if cursor, ok := m.cursors[event.Provider]; ok && event.logged.Before(cursor) {
+				m.cursors[event.Provider] = event.logged
+			}
+		}
+	}
+	for _, event := range found {
+		if !failed[event.key] {
+			m.seen[event.key] = event.logged
+		}
+	}
+
+	// Forget the events older than any window still to be polled
+	for key, when := range m.seen {
+		if now.Sub(when) > 2*max(m.opts.GoogleOverlap, m.opts.OktaOverlap, m.opts.Lookback) {
+			delete(m.seen, key)
+		}
+	}
+
+	return complete(c, "break-glass monitoring", found, errs)
+}
+
+/*
+ * Orchestrate the following:
+ * Verify that each Google break-glass account exists, is active, is a super admin, is enrolled in 2SV and sits in opts.GoogleOrgUnit
+ * Verify that each Okta break-glass account exists, is active, is a super administrator and has an active factor
+ * Alert on every account which is no longer configured as expected
+ */
+func (m *BreakGlassMonitor) Verify() ([]*BreakGlassFinding, error) {
+	c := m.client
+	if err := c.checkFlag(FlagBreakGlassMonitor); err != nil {
+		return nil, err
+	}
+
+	findings := []*BreakGlassFinding{}
+	var errs []*SourceError
+
+	for _, account := range m.opts.GoogleAccounts {
+		issues, err := m.verifyGoogle(account)
+		if err != nil {
+			errs = append(errs, &SourceError{Source: "Google (" + account + ")", Err: err})
+			continue
+		}
+		for _, issue := range issues {
+			findings = append(findings, &BreakGlassFinding{Provider: "Google", Account: account, Issue: issue})
+		}
+	}
+
+	for _, account := range m.opts.OktaAccounts {
+		issues, err := m.verifyOkta(account)
+		if err != nil {
+			errs = append(errs, &SourceError{Source: "Okta (" + account + ")", Err: err})
+			continue
+		}
+		for _, issue := range issues {
+			findings = append(findings, &BreakGlassFinding{Provider: "Okta", Account: account, Issue: issue})
+		}
+	}
+
+	if len(findings) > 0 {
+		var b strings.Builder
+		for _, finding := range findings {
+			fmt.Fprintf(&b, "  - [%s] %s: %s\n", finding.Provider, finding.Account, finding.Issue)
+		}
+		err := m.opts.Notifier.Notify(&notify.Message{
+			Severity: notify.Error,
+			Title:    fmt.Sprintf("{Break Glass} %d configuration issue(s) with the break-glass accounts", len(findings)),
+			Body:     b.String(),
+			DedupKey: "rego-break-glass-verification",
+		})
+		if err != nil {
+			errs = append(errs, &SourceError{Source: "Notifications", Err: err})
+		}
+	}
+
+	m.mutex.Lock()
+	m.verified = time.Now()
+	m.mutex.Unlock()
+
+	c.Log.Printf("Verified %d break-glass account(s): %d issue(s)", len(m.opts.GoogleAccounts)+len(m.opts.OktaAccounts), len(findings))
+	return complete(c, "break-glass verification", findings, errs)
+}
+
+/*
+ * Orchestrate the following:
+ * Poll the audit logs immediately, then on every interval (e.g. every 30 seconds) until stopped
+ * Verify the accounts on the first poll, then every opts.VerifyInterval
+ */
+func (m *BreakGlassMonitor) Schedule(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := m.Poll(); err != nil {
+			m.client.Log.Error("Error polling the break-glass accounts:", err)
+		}
+
+		m.mutex.Lock()
+		due := time.Since(m.verified) >= m.opts.VerifyInterval
+		m.mutex.Unlock()
+		if due {
+			if _, err := m.Verify(); err != nil {
+				m.client.Log.Error("Error verifying the break-glass accounts:", err)
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// since returns the start of the next window polled for a provider
+func (m *BreakGlassMonitor) since(provider string, overlap time.Duration, now time.Time) time.Time {
+	cursor, ok := m.cursors[provider]
+	if !ok {
+		return now.Add(-m.opts.Lookback)
+	}
+	return cursor.Add(-overlap)
+}
+
+// oktaEvents returns the new System Log events with a break-glass account as actor or target
+func (m *BreakGlassMonitor) oktaEvents(since, until time.Time) ([]*BreakGlassEvent, error) {
+	clauses := make([]string, 0, 2*len(m.opts.OktaAccounts))
+	for _, account := range m.opts.OktaAccounts {
+		account = strings.ReplaceAll(account, `"`, `\"`)
+		clauses = append(clauses, fmt.Sprintf(`actor.alternateId eq "%s" or target.alternateId eq "%s"`, account, account))
+	}
+
+	events, err := m.client.Okta.ListLogEvents(&okta.LogQuery{
+		Since:     since.UTC().Format(time.RFC3339),
+		Until:     until.UTC().Format(time.RFC3339),
+		Filter:    strings.Join(clauses, " or "),
+		SortOrder: "ASCENDING",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	found := []*BreakGlassEvent{}
+	for _, event := range *events {
+		key := "okta/" + event.UUID
+		if _, ok := m.seen[key]; ok {
+			continue
+		}
+
+		found = append(found, &BreakGlassEvent{Account: m.oktaAccount(event), ChangeAttribution: *oktaChange(event), key: key, logged: event.Published})
+	}
+
+	return found, nil
+}
+
+// oktaAccount returns the break-glass account an event involves, preferring its actor
+func (m *BreakGlassMonitor) oktaAccount(event *okta.LogEvent) string {
+	involved := []*okta.LogActor{event.Actor}
+	involved = append(involved, event.Target...)
+	for _, entity := range involved {
+		if entity == nil {
+			continue
+		}
+		for _, account := range m.opts.OktaAccounts {
+			if strings.EqualFold(entity.AlternateID, account) {
+				return account
+			}
+		}
+	}
+	return ""
+}
+
+// googleEvents returns the new audit activities of the Google break-glass accounts
+func (m *BreakGlassMonitor) googleEvents(since, until time.Time) ([]*BreakGlassEvent, error) {
+	reports := m.client.Google.Reports()
+
+	found := []*BreakGlassEvent{}
+	for _, account := range m.opts.GoogleAccounts {
+		for _, application := range m.opts.GoogleApplications {
+			err := reports.StreamActivities(application, since, until, &google.ReportsQuery{UserKey: account}, func(activities []google.Report) error {
+				for i := range activities {
+					activity := &activities[i]
+					key := fmt.Sprintf("google/%s/%s/%s", activity.ID.ApplicationName, activity.ID.Time, activity.ID.UniqueQualifier)
+					if _, ok := m.seen[key]; ok {
+						continue
+					}
+					// An unreadable time would be forgotten on the next poll, and alerted on again
+					when, err := time.Parse(time.RFC3339, activity.ID.Time)
+					if err != nil {
+						when = until
+					}
+
+					for _, change := range googleChanges(activity) {
+						found = append(found, &BreakGlassEvent{Account: account, ChangeAttribution: *change, key: key, logged: when})
+					}
+				}
+				return nil
+			})
+			// Accounts which no longer exist are reported by Verify
+			if err != nil && requests.StatusCode(err) != http.StatusNotFound {
+				return nil, fmt.Errorf("searching the %s activities of %s: %w", application, account, err)
+			}
+		}
+	}
+
+	return found, nil
+}
+
+// verifyGoogle returns the configuration issues of a Google break-glass account
+func (m *BreakGlassMonitor) verifyGoogle(account string) ([]string, error) {
+	user, err := m.client.Google.Users().GetUser(account)
+	if requests.StatusCode(err) == http.StatusNotFound {
+		return []string{"account not found"}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	issues := []string{}
+	if user.Suspended {
+		issues = append(issues, "suspended")
+	}
+	if user.Archived {
+		issues = append(issues, "archived")
+	}
+	if !user.IsAdmin {
+		issues = append(issues, "not a super admin")
+	}
+	if !user.IsEnrolledIn2Sv {
+		issues = append(issues, "not enrolled in 2SV")
+	}
+	if m.opts.GoogleOrgUnit != "" && !strings.EqualFold(user.OrgUnitPath, m.opts.GoogleOrgUnit) {
+		issues = append(issues, fmt.Sprintf("in organizational unit %s instead of %s", user.OrgUnitPath, m.opts.GoogleOrgUnit))
+	}
+	return issues, nil
+}
+
+// verifyOkta returns the configuration issues of an Okta break-glass account
+func (m *BreakGlassMonitor) verifyOkta(account string) ([]string, error) {
+	c := m.client
+
+	user, err := c.Okta.GetUser(account)
+	if requests.StatusCode(err) == http.StatusNotFound {
+		return []string{"account not found"}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	issues := []string{}
+	if user.Status != "ACTIVE" {
+		issues = append(issues, fmt.Sprintf("status %s", user.Status))
+	}
+
+	roles, err := c.Okta.GetUserRoles(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	superAdmin := false
+	for _, role := range *roles {
+		if role.Type == "SUPER_ADMIN" {
+			superAdmin = true
+		}
+	}
+	if !superAdmin {
+		issues = append(issues, "not a super administrator")
+	}
+
+	factors, err := c.Okta.Factors().ListAllEnrolledFactors(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	active := 0
+	for _, factor := range *factors {
+		if factor.Status == "ACTIVE" {
+			active++
+		}
+	}
+	if active == 0 {
+		issues = append(issues, "no active factor")
+	}
+
+	return issues, nil
+}
+
+// breakGlassMessage is the critical alert for a break-glass event
+func breakGlassMessage(event *BreakGlassEvent) *notify.Message {
+	fields := map[string]string{
+		"provider": event.Provider,
+		"account":  event.Account,
+		"time":     event.Time.Format(time.RFC3339),
+		"action":   event.Action,
+	}
+	if event.Actor != "" {
+		fields["actor"] = event.Actor
+	}
+	if event.IPAddress != "" {
+		fields["ip"] = event.IPAddress
+	}
+	if event.Outcome != "" {
+		fields["outcome"] = event.Outcome
+	}
+
+	return &notify.Message{
+		Severity: notify.Critical,
+		Title:    fmt.Sprintf("{Break Glass} %s used in %s: %s", event.Account, event.Provider, event.Action),
+		Body:     fmt.Sprintf("The break-glass account %s was involved in %s at %s. Confirm this was an approved emergency access.", event.Account, event.Detail, event.Time.Format(time.RFC3339)),
+		Fields:   fields,
+		DedupKey: fmt.Sprintf("rego-break-glass-%s-%s-%s", event.Provider, event.Account, event.Time.Format(time.RFC3339)),
+	}
+}
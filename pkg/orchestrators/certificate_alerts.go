@@ -0,0 +1,170 @@
+/*
+# Orchestrators - Jamf Certificate Expiry Alerts
+
+This package contains an orchestration scanning the certificates of Jamf computers for expiring device identity
+certificates, and delivering alerts (and tickets) through the notify package before they cause 802.1X outages.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/certificate_alerts.go
+package orchestrators
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/notify"
+)
+
+// CertificateAlertOptions configures which certificates are alerted on, and where alerts are delivered
+type CertificateAlertOptions struct {
+	Days            int             // Look-ahead window, in days. Default: 30
+	AllCertificates bool            // Also alert on the non-identity certificates of computers
+	SlackChannel    string          // Slack channel to post the summary to; skipped when empty
+	Recipients      []string        // Email recipients of the summary; skipped when empty. Requires SMTP_HOST, SMTP_PORT, SMTP_FROM (and optionally SMTP_USERNAME/SMTP_PASSWORD)
+	Notifier        notify.Notifier // Additional channel(s) for the summary; skipped when nil
+	Tickets         notify.Notifier // Receives one message per certificate, deduplicated by certificate (e.g. PagerDuty, or a webhook opening tickets); skipped when nil
+}
+
+/*
+ * Orchestrate the following:
+ * Scan the certificates of every Jamf computer for certificates expired, or expiring within the window
+ * Post a summary to Slack, email and/or the configured notifier
+ * Open a ticket per certificate through opts.Tickets, keyed by the certificate so repeated runs do not duplicate them
+ */
+func (c *Client) JamfCertificateAlerts(opts *CertificateAlertOptions) error {
+	if err := c.checkFlag(FlagCertificateAlerts); err != nil {
+		return err
+	}
+	if opts == nil {
+		opts = &CertificateAlertOptions{}
+	}
+	if opts.Days == 0 {
+		opts.Days = 30
+	}
+
+	certificates, err := c.jamfCertificates(nil, opts.AllCertificates)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	expired, expiring := []*CertificateRecord{}, []*CertificateRecord{}
+	for _, record := range certificates {
+		switch certificateStatus(record, now, opts.Days) {
+		case CertificateExpired:
+			expired = append(expired, record)
+		case CertificateExpiring:
+			expiring = append(expiring, record)
+		}
+	}
+
+	if len(expired)+len(expiring) == 0 {
+		c.Log.Printf("No Jamf certificates expire in the next %d days; skipping alerts.", opts.Days)
+		return nil
+	}
+
+	router := notify.NewRouter()
+	if opts.SlackChannel != "" {
+		if c.Slack == nil {
+			return fmt.Errorf("slack channel %s configured without a slack client", opts.SlackChannel)
+		}
+		router.Route(notify.Info, &notify.SlackNotifier{Client: c.Slack, Channel: opts.SlackChannel})
+	}
+	if len(opts.Recipients) > 0 {
+		router.Route(notify.Info, notify.EmailNotifierFromEnv(opts.Recipients...))
+	}
+	if opts.Notifier != nil {
+		router.Route(notify.Info, opts.Notifier)
+	}
+
+	severity := notify.Warning
+	if len(expired) > 0 {
+		severity = notify.Error
+	}
+
+	title := fmt.Sprintf("{Jamf} Certificate expiry report %s (next %d days)", now.Format("2006-01-02"), opts.Days)
+	sections := []certificateAlertSection{
+		{"Expired", expired},
+		{"Expiring", expiring},
+	}
+	message, err := notify.FromTemplate(c.Tenant, severity, title, "jamf_certificate_expiry", sections)
+	if err != nil {
+		return err
+	}
+
+	err = router.Notify(message)
+	if err != nil {
+		return err
+	}
+
+	if opts.Tickets != nil {
+		var failed int
+		for _, record := range append(expired, expiring...) {
+			if err := opts.Tickets.Notify(certificateTicket(record)); err != nil {
+				c.Log.Errorf("Unable to open a ticket for %s on %s: %v", record.CommonName, record.Device, err)
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d certificate ticket(s) could not be opened", failed, len(expired)+len(expiring))
+		}
+	}
+
+	c.Log.Printf("Jamf certificate alerts delivered: %d expired, %d expiring.", len(expired), len(expiring))
+	return nil
+}
+
+/*
+ * Orchestrate the following:
+ * Deliver Jamf certificate expiry alerts immediately, then on every interval until stopped
+ */
+func (c *Client) ScheduleJamfCertificateAlerts(opts *CertificateAlertOptions, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.JamfCertificateAlerts(opts); err != nil {
+			c.Log.Error("Error delivering Jamf certificate alerts:", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// certificateAlertSection is a titled group of certificates rendered by the `jamf_certificate_expiry` template
+type certificateAlertSection struct {
+	Title        string
+	Certificates []*CertificateRecord
+}
+
+// certificateTicket is the message opening a ticket for a single certificate, deduplicated by device and certificate
+func certificateTicket(record *CertificateRecord) *notify.Message {
+	severity := notify.Warning
+	if record.Status == CertificateExpired {
+		severity = notify.Error
+	}
+
+	return &notify.Message{
+		Severity: severity,
+		Title:    fmt.Sprintf("Certificate %s on %s %s", record.CommonName, record.Device, record.Status),
+		Body:     fmt.Sprintf("The certificate %s on %s expires on %s (%d days).", record.CommonName, record.Device, record.Expires.Format("2006-01-02"), record.DaysLeft),
+		Fields: map[string]string{
+			"device":        record.Device,
+			"device_serial": record.DeviceSerial,
+			"user":          record.User,
+			"subject":       record.Subject,
+			"serial":        record.Serial,
+			"fingerprint":   record.Fingerprint,
+			"expires":       record.Expires.Format(time.RFC3339),
+		},
+		DedupKey: fmt.Sprintf("rego-certificate-%s-%s", record.DeviceSerial, record.Fingerprint),
+	}
+}
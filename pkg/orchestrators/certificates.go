@@ -0,0 +1,364 @@
+/*
+# Orchestrators - Certificate Inventory
+
+This package contains an orchestration inventorying device identity certificates (e.g. issued by a private CA over SCEP)
+across Jamf computers, joined with the certificate chains trusted by Okta's smart card (X509) identity providers, to
+track expiring certificates fleetwide.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/certificates.go
+package orchestrators
+
+import (
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/google"
+	"github.com/gemini-oss/rego/pkg/jamf"
+	"github.com/gemini-oss/rego/pkg/okta"
+)
+
+// Status of an inventoried certificate
+const (
+	CertificateValid    = "valid"
+	CertificateExpiring = "expiring"
+	CertificateExpired  = "expired"
+	CertificateUnknown  = "unknown" // The expiration date could not be read
+)
+
+// CertificateInventoryOptions configures the certificate inventory
+type CertificateInventoryOptions struct {
+	Days            int  // Certificates expiring within this many days are CertificateExpiring. Default: 30
+	AllCertificates bool // Also inventory the non-identity (e.g. CA and root) certificates of Jamf computers
+}
+
+// CertificateRecord is a single certificate installed on a device, or trusted by Okta
+type CertificateRecord struct {
+	Source       string    // Jamf (installed on a computer) or Okta (trusted by an identity provider)
+	Device       string    // Name of the computer; the identity provider for Okta
+	DeviceSerial string    // Serial number of the computer
+	User         string    // User assigned to the computer
+	CommonName   string    // Common name of the certificate
+	Subject      string    // Subject of the certificate
+	Issuer       string    // Issuer of the certificate, when known
+	Serial       string    // Serial number of the certificate
+	Fingerprint  string    // SHA-1 fingerprint of the certificate, lowercase hex
+	Identity     bool      // The certificate identifies the device (it has a private key on the device)
+	TrustedBy    []string  // Okta identity providers whose trust chain is installed alongside the certificate
+	Expires      time.Time // Expiration of the certificate; zero when unknown
+	DaysLeft     int       // Days until the certificate expires, negative once expired
+	Status       string    // CertificateValid, CertificateExpiring, CertificateExpired or CertificateUnknown
+}
+
+// oktaTrust is a certificate of the trust chain of an Okta identity provider
+type oktaTrust struct {
+	idp  *okta.IdentityProvider
+	cert *x509.Certificate
+}
+
+/*
+ * Orchestrate the following:
+ * Collect the certificate chains trusted by Okta's active smart card (X509) identity providers
+ * Collect the identity certificates installed on every Jamf computer
+ * Tag each computer's certificates with the identity providers whose trust chain (by SHA-1 fingerprint) is installed
+ * on that computer, i.e. the certificates able to authenticate with Okta
+ * Classify every certificate by expiration, soonest first
+ */
+func (c *Client) CertificateInventory(opts *CertificateInventoryOptions) ([]*CertificateRecord, error) {
+	if opts == nil {
+		opts = &CertificateInventoryOptions{}
+	}
+	if opts.Days == 0 {
+		opts.Days = 30
+	}
+
+	inventory := []*CertificateRecord{}
+	var errs []*SourceError
+
+	trust := map[string]*oktaTrust{}
+	if c.Okta != nil {
+		var err error
+		trust, err = c.oktaTrustedCertificates()
+		if err != nil {
+			errs = append(errs, &SourceError{Source: "Okta", Err: err})
+		}
+		for fingerprint, t := range trust {
+			inventory = append(inventory, &CertificateRecord{
+				Source:      "Okta",
+				Device:      t.idp.Name,
+				CommonName:  t.cert.Subject.CommonName,
+				Subject:     t.cert.Subject.String(),
+				Issuer:      t.cert.Issuer.String(),
+				Serial:      t.cert.SerialNumber.Text(16),
+				Fingerprint: fingerprint,
+				TrustedBy:   []string{t.idp.Name},
+				Expires:     t.cert.NotAfter,
+			})
+		}
+	}
+
+	if c.Jamf != nil {
+		rows, err := c.jamfCertificates(trust, opts.AllCertificates)
+		if err != nil {
+			errs = append(errs, &SourceError{Source: "Jamf", Err: err})
+		}
+		inventory = append(inventory, rows...)
+	}
+
+	now := time.Now()
+	for _, record := range inventory {
+		record.Status = certificateStatus(record, now, opts.Days)
+	}
+
+	sort.SliceStable(inventory, func(i, j int) bool {
+		a, b := inventory[i], inventory[j]
+		if a.Expires.IsZero() != b.Expires.IsZero() {
+			return b.Expires.IsZero()
+		}
+		if !a.Expires.Equal(b.Expires) {
+			return a.Expires.Before(b.Expires)
+		}
+		return a.Device < b.Device
+	})
+
+	return complete(c, "certificate inventory", inventory, errs)
+}
+
+// oktaTrustedCertificates returns the certificates trusted by the active X509 identity providers, by SHA-1 fingerprint
+func (c *Client) oktaTrustedCertificates() (map[string]*oktaTrust, error) {
+	trust := map[string]*oktaTrust{}
+
+	idps, err := c.Okta.ListIdentityProviders(okta.IdPTypeX509)
+	if err != nil {
+		return trust, err
+	}
+
+	for _, idp := range *idps {
+		if idp.Status != "ACTIVE" || idp.Protocol == nil || idp.Protocol.Credentials == nil || idp.Protocol.Credentials.Trust == nil {
+			continue
+		}
+
+		key, err := c.Okta.GetIdPKey(idp.Protocol.Credentials.Trust.Kid)
+		if err != nil {
+			return trust, fmt.Errorf("trust of %s: %w", idp.Name, err)
+		}
+
+		for _, encoded := range key.X5C {
+			der, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				return trust, fmt.Errorf("trust of %s: %w", idp.Name, err)
+			}
+			cert, err := x509.ParseCertificate(der)
+			if err != nil {
+				return trust, fmt.Errorf("trust of %s: %w", idp.Name, err)
+			}
+
+			sum := sha1.Sum(der)
+			trust[hex.EncodeToString(sum[:])] = &oktaTrust{idp: idp, cert: cert}
+		}
+	}
+
+	c.Log.Printf("Found %d certificate(s) trusted by Okta smart card identity providers", len(trust))
+	return trust, nil
+}
+
+// jamfCertificates inventories the certificates of every Jamf computer, tagged with the Okta trust installed alongside them
+func (c *Client) jamfCertificates(trust map[string]*oktaTrust, all bool) ([]*CertificateRecord, error) {
+	sections := []string{jamf.Section.General, jamf.Section.Hardware, jamf.Section.UserAndLocation, jamf.Section.Certificates}
+	computers, err := c.Jamf.Devices().Sections(sections).ListAllComputers()
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []*CertificateRecord{}
+	if computers.Results == nil {
+		return rows, nil
+	}
+
+	for _, computer := range *computers.Results {
+		if computer.Certificates == nil {
+			continue
+		}
+
+		trustedBy := []string{}
+		for _, cert := range *computer.Certificates {
+			if t, ok := trust[normalizeFingerprint(cert.Sha1Fingerprint)]; ok && !slices.Contains(trustedBy, t.idp.Name) {
+				trustedBy = append(trustedBy, t.idp.Name)
+			}
+		}
+		sort.Strings(trustedBy)
+
+		for _, cert := range *computer.Certificates {
+			if !cert.Identity && !all {
+				continue
+			}
+
+			record := &CertificateRecord{
+				Source:      "Jamf",
+				CommonName:  cert.CommonName,
+				Subject:     cert.SubjectName,
+				Serial:      cert.SerialNumber,
+				Fingerprint: normalizeFingerprint(cert.Sha1Fingerprint),
+				Identity:    cert.Identity,
+				Expires:     parseCertificateDate(cert.ExpirationDate),
+			}
+			if cert.Identity {
+				record.TrustedBy = trustedBy
+			}
+			if computer.General != nil {
+				record.Device = computer.General.Name
+			}
+			if computer.Hardware != nil {
+				record.DeviceSerial = computer.Hardware.SerialNumber
+			}
+			if computer.UserAndLocation != nil {
+				record.User = computer.UserAndLocation.Email
+			}
+			rows = append(rows, record)
+		}
+	}
+
+	c.Log.Printf("Found %d certificate(s) across %d Jamf computer(s)", len(rows), len(*computers.Results))
+	return rows, nil
+}
+
+// certificateStatus classifies a certificate by its expiration, setting its DaysLeft
+func certificateStatus(record *CertificateRecord, now time.Time, days int) string {
+	if record.Expires.IsZero() {
+		return CertificateUnknown
+	}
+
+	record.DaysLeft = int(record.Expires.Sub(now).Hours() / 24)
+	switch {
+	case !record.Expires.After(now):
+		return CertificateExpired
+	case record.Expires.Before(now.AddDate(0, 0, days)):
+		return CertificateExpiring
+	default:
+		return CertificateValid
+	}
+}
+
+// parseCertificateDate reads the expiration dates of Jamf, returning the zero time when they cannot be parsed
+func parseCertificateDate(value string) time.Time {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05.000Z", "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// normalizeFingerprint lowercases a fingerprint and strips its separators, e.g. `AB:CD:EF` -> `abcdef`
+func normalizeFingerprint(fingerprint string) string {
+	return strings.ToLower(strings.NewReplacer(":", "", " ", "").Replace(fingerprint))
+}
+
+/*
+ * Orchestrate the following:
+ * Generate the certificate inventory
+ * Save the expiring, expired and unreadable certificates to a Google Sheet, with every certificate on a second sheet
+ * Format the sheets
+ */
+func (c *Client) CertificateInventoryToGoogleSheet(opts *CertificateInventoryOptions) error {
+	if err := c.checkFlag(FlagCertificateInventory); err != nil {
+		return err
+	}
+
+	inventory, err := c.CertificateInventory(opts)
+	partial, isPartial := AsPartial(err)
+	if err != nil && !isPartial {
+		return err
+	}
+
+	inventory, err = runPostFetch(c.Hooks, FlagCertificateInventory, inventory)
+	if err != nil {
+		return err
+	}
+
+	newSpreadsheet := &google.Spreadsheet{
+		Properties: &google.SpreadsheetProperties{
+			Title: fmt.Sprintf("{Jamf/Okta} Certificate Inventory %s", time.Now().Format("2006-01-02")),
+		},
+		Sheets: []google.Sheet{
+			{Properties: &google.SheetProperties{Title: "Attention"}},
+			{Properties: &google.SheetProperties{Title: "Certificates"}},
+		},
+	}
+	sheet, err := c.Google.Sheets().CreateSpreadsheet(newSpreadsheet)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{"Source", "Status", "Expires", "Days Left", "Device", "Device Serial", "User", "Common Name", "Subject", "Issuer", "Serial", "SHA-1 Fingerprint", "Identity", "Trusted By (Okta)"}
+	attention := [][]string{headers}
+	all := [][]string{headers}
+
+	for _, record := range inventory {
+		expires := ""
+		if !record.Expires.IsZero() {
+			expires = record.Expires.Format("2006-01-02")
+		}
+		row := []string{
+			record.Source,
+			record.Status,
+			expires,
+			fmt.Sprint(record.DaysLeft),
+			record.Device,
+			record.DeviceSerial,
+			record.User,
+			record.CommonName,
+			record.Subject,
+			record.Issuer,
+			record.Serial,
+			record.Fingerprint,
+			fmt.Sprint(record.Identity),
+			strings.Join(record.TrustedBy, ", "),
+		}
+		all = append(all, row)
+		if record.Status != CertificateValid {
+			attention = append(attention, row)
+		}
+	}
+
+	for i, values := range [][][]string{attention, all} {
+		values, err = c.Hooks.runPreExport(FlagCertificateInventory, values)
+		if err != nil {
+			return err
+		}
+		if isPartial {
+			values = append(append(values, []string{}), partial.Summary()...)
+		}
+
+		vr := &google.ValueRange{
+			Range:          fmt.Sprintf("%s!A:Z", sheet.Sheets[i].Properties.Title),
+			MajorDimension: "ROWS",
+			Values:         values,
+		}
+		err = c.Google.Sheets().UpdateSpreadsheet(sheet.SpreadsheetID, vr)
+		if err != nil {
+			return err
+		}
+
+		err = c.Google.Sheets().FormatHeaderAndAutoSize(sheet.SpreadsheetID, &sheet.Sheets[i], len(values), len(values[0]))
+		if err != nil {
+			return err
+		}
+	}
+
+	c.Log.Println("Certificate inventory saved to Google Sheet.")
+	c.Log.Println("Spreadsheet URL: ", sheet.SpreadsheetURL)
+
+	return nil
+}
@@ -0,0 +1,352 @@
+/*
+# Orchestrators - Device Compliance Sync
+
+This package contains an orchestration converting Jamf compliance state changes into Okta group membership,
+allowing a "non-compliant" Okta group to be mapped to a restrictive sign-on policy.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/device_compliance.go
+package orchestrators
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/config"
+	"github.com/gemini-oss/rego/pkg/common/server"
+)
+
+// ComplianceWebhookAuthHeader carries the webhook secret by default, as sent by Jamf's basic or header authentication
+const ComplianceWebhookAuthHeader = "Authorization"
+
+// oktaMemberPrefix keys the Okta group members found without a non-compliant computer, pending their removal
+const oktaMemberPrefix = "okta:"
+
+// ComplianceChange is a single observed compliance state of a Jamf computer
+type ComplianceChange struct {
+	ComputerID string    // Jamf ID of the computer
+	Compliant  bool      // Observed compliance state
+	Time       time.Time // Time the state was observed
+}
+
+// JamfSmartGroupWebhook is the payload sent by Jamf for `SmartGroupComputerMembershipChange` events
+// https://developer.jamf.com/developer-guide/docs/webhooks#smartgroupcomputermembershipchange
+type JamfSmartGroupWebhook struct {
+	Webhook struct {
+		ID           int    `json:"id"`
+		Name         string `json:"name"`
+		WebhookEvent string `json:"webhookEvent"`
+	} `json:"webhook"`
+	Event struct {
+		Computer               bool   `json:"computer"`
+		GroupAddedDevicesIDs   []int  `json:"groupAddedDevicesIds"`
+		GroupRemovedDevicesIDs []int  `json:"groupRemovedDevicesIds"`
+		JSSID                  int    `json:"jssid"`
+		Name                   string `json:"name"`
+		SmartGroup             bool   `json:"smartGroup"`
+	} `json:"event"`
+}
+
+// deviceCompliance tracks the applied and pending compliance state of a computer
+type deviceCompliance struct {
+	user      string    // Okta login of the user assigned to the computer
+	compliant bool      // State currently reflected in Okta
+	pending   *bool     // Observed state waiting to settle, if any
+	since     time.Time // Time the pending state was first observed
+}
+
+// DeviceComplianceSync converts Jamf compliance changes into Okta group membership with hysteresis
+type DeviceComplianceSync struct {
+	client            *Client
+	OktaGroupID       string        // Okta group for non-compliant users (mapped to a restrictive sign-on policy)
+	JamfSmartGroupID  int           // Jamf smart group containing the non-compliant computers
+	NonCompliantAfter time.Duration // How long a computer must stay non-compliant before the user is added to the group
+	CompliantAfter    time.Duration // How long a computer must stay compliant before the user is removed from the group
+	WebhookSecret     string        // Value of the authentication header of the Jamf webhook; without one, every webhook is rejected
+	WebhookHeader     string        // Header carrying the secret; ComplianceWebhookAuthHeader by default
+	devices           map[string]*deviceCompliance
+	devicesMutex      sync.Mutex
+}
+
+// Entry point for the Jamf compliance to Okta sync
+func (c *Client) DeviceComplianceSync(oktaGroupID string, jamfSmartGroupID int) *DeviceComplianceSync {
+	return &DeviceComplianceSync{
+		client:            c,
+		OktaGroupID:       oktaGroupID,
+		JamfSmartGroupID:  jamfSmartGroupID,
+		NonCompliantAfter: 15 * time.Minute,
+		CompliantAfter:    1 * time.Hour,
+		WebhookSecret:     config.GetEnv("JSS_COMPLIANCE_WEBHOOK_SECRET"),
+		devices:           make(map[string]*deviceCompliance),
+	}
+}
+
+/*
+ * Seed loads the state already applied, so a restarted sync picks up where the webhooks left off
+ * - computers in the Jamf smart group are non-compliant; their users missing from the Okta group are added once settled
+ * - users in the Okta group without a non-compliant computer are removed once settled
+ */
+func (s *DeviceComplianceSync) Seed(now time.Time) error {
+	computers, err := s.client.Jamf.Devices().ListSmartGroupMembers(s.JamfSmartGroupID)
+	if err != nil {
+		return fmt.Errorf("listing jamf smart group %d: %w", s.JamfSmartGroupID, err)
+	}
+
+	members, err := s.client.Okta.ListGroupMembers(s.OktaGroupID)
+	if err != nil {
+		return fmt.Errorf("listing okta group %s: %w", s.OktaGroupID, err)
+	}
+
+	inGroup := map[string]bool{}
+	for _, member := range *members {
+		if member.Profile != nil && member.Profile.Login != "" {
+			inGroup[strings.ToLower(member.Profile.Login)] = true
+		}
+	}
+
+	devices := map[string]*deviceCompliance{}
+	nonCompliant := map[string]bool{}
+	for _, id := range computers {
+		user, err := s.computerUser(id)
+		if err != nil {
+			// Reconcile looks the user up again once the computer has settled
+			s.client.Log.Warningf("Seeding computer %s: %v", id, err)
+		}
+
+		nonCompliant[user] = true
+		if user != "" && inGroup[user] {
+			devices[id] = &deviceCompliance{user: user, compliant: false}
+			continue
+		}
+
+		pending := false
+		devices[id] = &deviceCompliance{user: user, compliant: true, pending: &pending, since: now}
+	}
+
+	for login := range inGroup {
+		if nonCompliant[login] {
+			continue
+		}
+
+		s.client.Log.Printf("%s is in Okta group %s without a non-compliant computer; waiting to settle", login, s.OktaGroupID)
+		pending := true
+		devices[oktaMemberPrefix+login] = &deviceCompliance{user: login, compliant: false, pending: &pending, since: now}
+	}
+
+	s.devicesMutex.Lock()
+	defer s.devicesMutex.Unlock()
+
+	s.devices = devices
+	return nil
+}
+
+/*
+ * Record an observed compliance state
+ * A state only takes effect once it has persisted for the configured settle time;
+ * flapping back to the applied state before then cancels the pending change
+ */
+func (s *DeviceComplianceSync) Observe(change ComplianceChange) {
+	s.devicesMutex.Lock()
+	defer s.devicesMutex.Unlock()
+
+	d, ok := s.devices[change.ComputerID]
+	if !ok {
+		// Unknown computers are assumed compliant, matching their absence from the Okta group
+		d = &deviceCompliance{compliant: true}
+		s.devices[change.ComputerID] = d
+	}
+
+	if change.Compliant == d.compliant {
+		if d.pending != nil {
+			s.client.Log.Printf("Computer %s returned to its applied state (compliant=%t); pending change cancelled", change.ComputerID, d.compliant)
+		}
+		d.pending = nil
+		return
+	}
+
+	if d.pending == nil || *d.pending != change.Compliant {
+		state := change.Compliant
+		d.pending = &state
+		d.since = change.Time
+		s.client.Log.Printf("Computer %s observed compliant=%t; waiting to settle", change.ComputerID, change.Compliant)
+	}
+}
+
+/*
+ * Apply every pending compliance state which has settled (unless the device-compliance-sync flag is switched off)
+ * Non-compliant computers add their user to the Okta group, and the user is removed
+ * only once none of their computers remain non-compliant
+ */
+func (s *DeviceComplianceSync) Reconcile(now time.Time) error {
+	// Pending changes are kept while switched off, and applied once switched back on
+	if err := s.client.checkFlag(FlagDeviceComplianceSync); err != nil {
+		return nil
+	}
+
+	s.devicesMutex.Lock()
+	defer s.devicesMutex.Unlock()
+
+	var errs []error
+
+	for id, d := range s.devices {
+		if d.pending == nil {
+			continue
+		}
+
+		settle := s.CompliantAfter
+		if !*d.pending {
+			settle = s.NonCompliantAfter
+		}
+		if now.Sub(d.since) < settle {
+			continue
+		}
+
+		if d.user == "" {
+			user, err := s.computerUser(id)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			d.user = user
+		}
+
+		compliant := *d.pending
+		if err := s.apply(id, d.user, compliant); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		d.compliant = compliant
+		d.pending = nil
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("error reconciling device compliance: %v", errs)
+	}
+
+	return nil
+}
+
+// apply reflects the compliance state of a single computer in Okta
+func (s *DeviceComplianceSync) apply(computerID, login string, compliant bool) error {
+	user, err := s.client.Okta.GetUser(login)
+	if err != nil {
+		return fmt.Errorf("computer %s: looking up okta user %s: %w", computerID, login, err)
+	}
+
+	if !compliant {
+		s.client.Log.Warningf("Computer %s is non-compliant; adding %s to Okta group %s", computerID, login, s.OktaGroupID)
+		return s.client.Okta.AddUserToGroup(s.OktaGroupID, user.ID)
+	}
+
+	for otherID, other := range s.devices {
+		if otherID != computerID && other.user == login && !other.compliant {
+			s.client.Log.Printf("Computer %s is compliant, but %s still has non-compliant computer %s", computerID, login, otherID)
+			return nil
+		}
+	}
+
+	s.client.Log.Printf("Computer %s is compliant; removing %s from Okta group %s", computerID, login, s.OktaGroupID)
+	return s.client.Okta.RemoveUserFromGroup(s.OktaGroupID, user.ID)
+}
+
+// computerUser returns the email of the user Jamf has assigned to a computer
+func (s *DeviceComplianceSync) computerUser(computerID string) (string, error) {
+	computer, err := s.client.Jamf.Devices().GetComputerDetails(computerID)
+	if err != nil {
+		return "", fmt.Errorf("computer %s: fetching jamf details: %w", computerID, err)
+	}
+
+	if computer.UserAndLocation == nil || computer.UserAndLocation.Email == "" {
+		return "", fmt.Errorf("computer %s: no user assigned in jamf", computerID)
+	}
+
+	return strings.ToLower(computer.UserAndLocation.Email), nil
+}
+
+/*
+ * # Jamf Webhook Handler
+ * Accepts `SmartGroupComputerMembershipChange` events for the configured non-compliant smart group
+ * - https://developer.jamf.com/developer-guide/docs/webhooks
+ * - Requests without the webhook secret are rejected, so nobody can take users out of the Okta group with a forged event
+ */
+func (s *DeviceComplianceSync) HandleWebhook(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		s.client.Log.Warning("Rejected a compliance webhook without a valid secret from", r.RemoteAddr)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload := &JamfSmartGroupWebhook{}
+	if err := json.NewDecoder(r.Body).Decode(payload); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	if payload.Webhook.WebhookEvent != "SmartGroupComputerMembershipChange" || payload.Event.JSSID != s.JamfSmartGroupID {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	now := time.Now()
+	for _, id := range payload.Event.GroupAddedDevicesIDs {
+		s.Observe(ComplianceChange{ComputerID: fmt.Sprint(id), Compliant: false, Time: now})
+	}
+	for _, id := range payload.Event.GroupRemovedDevicesIDs {
+		s.Observe(ComplianceChange{ComputerID: fmt.Sprint(id), Compliant: true, Time: now})
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// authorized compares the authentication header in constant time; a sync without a secret rejects every webhook
+func (s *DeviceComplianceSync) authorized(r *http.Request) bool {
+	header := s.WebhookHeader
+	if header == "" {
+		header = ComplianceWebhookAuthHeader
+	}
+
+	got := r.Header.Get(header)
+	return s.WebhookSecret != "" && subtle.ConstantTimeCompare([]byte(got), []byte(s.WebhookSecret)) == 1
+}
+
+/*
+ * Orchestrate the following:
+ * Seed the applied state from Jamf and Okta
+ * Listen for Jamf compliance webhooks on the given address
+ * Reconcile settled compliance changes into Okta on every interval
+ */
+func (s *DeviceComplianceSync) Serve(addr string, interval time.Duration) {
+	if err := s.Seed(time.Now()); err != nil {
+		s.client.Log.Fatal("Seeding the device compliance state:", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	go func() {
+		for now := range ticker.C {
+			if err := s.Reconcile(now); err != nil {
+				s.client.Log.Error(err)
+			}
+		}
+	}()
+
+	server.StartServer(addr, map[string]http.HandlerFunc{
+		"/webhooks/jamf/compliance": s.HandleWebhook,
+	})
+}
@@ -0,0 +1,369 @@
+/*
+# Orchestrators - External Sharing
+
+This package contains an orchestration reconciling the external domains Google Drive files, shared drives and Google
+groups are actually shared with against an allowlist of approved domains, flagging or removing the violations.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/external_sharing.go
+package orchestrators
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/notify"
+	"github.com/gemini-oss/rego/pkg/google"
+)
+
+// Sources of the sharing checked by ExternalSharingEnforcement
+const (
+	SharingSourceDrive        = "drive"         // Permissions of the Drive files visible to the caller
+	SharingSourceSharedDrives = "shared drives" // Members of the shared drives of the domain
+	SharingSourceGroups       = "groups"        // Members of the Google groups of the domain
+)
+
+// ExternalSharingOptions configures the external sharing enforcement
+type ExternalSharingOptions struct {
+	Domains      []string               // Internal domains of the organization. Required
+	Allowlist    []string               // External domains approved for sharing; their subdomains are approved too
+	Sources      []string               // Sources checked. Default: SharingSourceDrive, SharingSourceSharedDrives, SharingSourceGroups
+	Query        *google.DriveFileQuery // Query selecting the Drive files checked. Default: every non-trashed file visible to the caller
+	AllowAnyone  bool                   // Allow files shared with anyone with the link
+	Remove       bool                   // Remove the permissions and memberships breaking the allowlist, instead of only flagging them
+	DryRun       bool                   // Only report the removals which would be made
+	SlackChannel string                 // Slack channel to post the violations to; skipped when empty
+	Recipients   []string               // Email recipients of the violations; skipped when empty
+	Notifier     notify.Notifier        // Additional channel(s) for the violations; skipped when nil
+}
+
+// ExternalSharingReport is the result of the external sharing enforcement
+type ExternalSharingReport struct {
+	Resources  int                         // Number of files, shared drives and groups checked
+	Domains    map[string]int              // External domains found, with the number of grants to each
+	Violations []*ExternalSharingViolation // Grants to domains outside of the allowlist
+}
+
+// ExternalSharingViolation is a grant to a principal outside of the internal and approved domains
+type ExternalSharingViolation struct {
+	Source     string // SharingSourceDrive, SharingSourceSharedDrives or SharingSourceGroups
+	ResourceID string // File ID, shared drive ID or group email
+	Resource   string // File name, shared drive name or group email
+	Link       string // Link to the file, when available
+	Principal  string // Email address, domain, or `anyone`
+	Domain     string // Domain of the principal; `anyone` for link sharing
+	Role       string // Role granted, e.g. writer, MEMBER
+	GrantID    string // Permission ID, or member email
+	Applied    bool   // The grant was removed (false unless opts.Remove, and false under DryRun or with the flag switched off)
+	Err        error  // Set when the removal failed
+}
+
+/*
+ * Orchestrate the following:
+ * List the permissions of Drive files and shared drives, and the members of Google groups
+ * Collect the external domains they are shared with, and the grants to domains outside of the allowlist
+ * Remove those grants when opts.Remove is set
+ * Deliver the violations to Slack, email and/or the configured notifier
+ * Removals are only reported under DryRun, or with the external-sharing flag switched off
+ */
+func (c *Client) ExternalSharingEnforcement(opts *ExternalSharingOptions) (*ExternalSharingReport, error) {
+	if opts == nil || len(opts.Domains) == 0 {
+		return nil, fmt.Errorf("external sharing enforcement requires the internal domains")
+	}
+	dryRun := opts.DryRun
+	if !dryRun && c.checkFlag(FlagExternalSharing) != nil {
+		dryRun = true
+	}
+
+	sources := opts.Sources
+	if len(sources) == 0 {
+		sources = []string{SharingSourceDrive, SharingSourceSharedDrives, SharingSourceGroups}
+	}
+
+	report := &ExternalSharingReport{Domains: map[string]int{}}
+	var errs []*SourceError
+	for _, source := range sources {
+		var err error
+		switch source {
+		case SharingSourceDrive:
+			err = c.driveSharing(opts, report)
+		case SharingSourceSharedDrives:
+			errs = append(errs, c.sharedDriveSharing(opts, report)...)
+		case SharingSourceGroups:
+			errs = append(errs, c.groupSharing(opts, report)...)
+		default:
+			err = fmt.Errorf("unknown sharing source %q", source)
+		}
+		if err != nil {
+			errs = append(errs, &SourceError{Source: source, Err: err})
+		}
+	}
+
+	if opts.Remove {
+		for _, violation := range report.Violations {
+			if dryRun {
+				c.Log.Printf("[dry run] %s would be removed from %s", violation.Principal, violation.Resource)
+				continue
+			}
+			if violation.Err = c.removeGrant(violation); violation.Err != nil {
+				c.Log.Errorf("Unable to remove %s from %s: %v", violation.Principal, violation.Resource, violation.Err)
+				errs = append(errs, &SourceError{Source: fmt.Sprintf("%s (%s)", violation.Source, violation.Resource), Err: violation.Err})
+				continue
+			}
+			violation.Applied = true
+			c.Log.Printf("Removed %s from %s", violation.Principal, violation.Resource)
+		}
+	}
+
+	if err := c.notifyExternalSharing(report, opts); err != nil {
+		errs = append(errs, &SourceError{Source: "Notifications", Err: err})
+	}
+
+	c.Log.Printf("External sharing: %d resource(s) checked, %d external domain(s), %d violation(s)", report.Resources, len(report.Domains), len(report.Violations))
+	return complete(c, "external sharing", report, errs)
+}
+
+/*
+ * Orchestrate the following:
+ * Run the external sharing enforcement immediately, then on every interval (e.g. daily, 24*time.Hour) until stopped
+ */
+func (c *Client) ScheduleExternalSharingEnforcement(opts *ExternalSharingOptions, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := c.ExternalSharingEnforcement(opts); err != nil {
+			c.Log.Error("Error running the external sharing enforcement:", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// driveSharing checks the permissions of the Drive files matching opts.Query
+func (c *Client) driveSharing(opts *ExternalSharingOptions, report *ExternalSharingReport) error {
+	q := google.DriveFileQuery{Q: "trashed = false"}
+	if opts.Query != nil {
+		q = *opts.Query
+	}
+	q.Fields = "nextPageToken, files(id, name, webViewLink, permissions(id, type, role, emailAddress, domain))"
+	q.PageSize = 1000
+
+	return c.Google.Drive().StreamFiles(&q, func(files []*google.File) error {
+		for _, file := range files {
+			report.Resources++
+			for _, permission := range file.Permissions {
+				c.checkPermission(opts, report, SharingSourceDrive, file.ID, file.Name, file.WebViewLink, &permission)
+			}
+		}
+		return nil
+	})
+}
+
+// sharedDriveSharing checks the members of every shared drive of the domain; a drive whose members cannot be listed is
+// reported, and the others are still checked
+func (c *Client) sharedDriveSharing(opts *ExternalSharingOptions, report *ExternalSharingReport) []*SourceError {
+	drives, err := c.Google.Drive().ListSharedDrives(nil)
+	if err != nil {
+		return []*SourceError{{Source: SharingSourceSharedDrives, Err: err}}
+	}
+
+	var errs []*SourceError
+	for _, drive := range drives.Drives {
+		permissions, err := c.Google.Drive().ListSharedDrivePermissions(drive.ID)
+		if err != nil {
+			c.Log.Errorf("Unable to list the members of shared drive %s: %v", drive.Name, err)
+			errs = append(errs, &SourceError{Source: fmt.Sprintf("%s (%s)", SharingSourceSharedDrives, drive.Name), Err: err})
+			continue
+		}
+
+		report.Resources++
+		for _, permission := range permissions.Permissions {
+			c.checkPermission(opts, report, SharingSourceSharedDrives, drive.ID, drive.Name, "", &permission)
+		}
+	}
+
+	return errs
+}
+
+// groupSharing checks the members of every Google group of the domain; a group whose members cannot be listed is
+// reported, and the others are still checked
+func (c *Client) groupSharing(opts *ExternalSharingOptions, report *ExternalSharingReport) []*SourceError {
+	groups, err := c.Google.Groups().ListAllGroups(nil)
+	if err != nil {
+		return []*SourceError{{Source: SharingSourceGroups, Err: err}}
+	}
+
+	var errs []*SourceError
+	for _, group := range groups.Groups {
+		members, err := c.Google.Groups().ListAllMembers(group.Email)
+		if err != nil {
+			c.Log.Errorf("Unable to list the members of group %s: %v", group.Email, err)
+			errs = append(errs, &SourceError{Source: fmt.Sprintf("%s (%s)", SharingSourceGroups, group.Email), Err: err})
+			continue
+		}
+
+		report.Resources++
+		for _, member := range members.Members {
+			// Members with the CUSTOMER type are the whole domain
+			if member.Email == "" || member.Type == "CUSTOMER" {
+				continue
+			}
+			domain := emailDomain(member.Email)
+			if internalDomain(domain, opts.Domains) {
+				continue
+			}
+
+			report.Domains[domain]++
+			if internalDomain(domain, opts.Allowlist) {
+				continue
+			}
+			report.Violations = append(report.Violations, &ExternalSharingViolation{
+				Source:     SharingSourceGroups,
+				ResourceID: group.Email,
+				Resource:   group.Email,
+				Principal:  member.Email,
+				Domain:     domain,
+				Role:       member.Role,
+				GrantID:    member.Email,
+			})
+		}
+	}
+
+	return errs
+}
+
+// checkPermission records the external domain of a Drive permission, and a violation when it is not approved
+func (c *Client) checkPermission(opts *ExternalSharingOptions, report *ExternalSharingReport, source, id, name, link string, permission *google.Permission) {
+	var principal, domain string
+	switch permission.Type {
+	case "anyone":
+		if opts.AllowAnyone {
+			return
+		}
+		principal, domain = "anyone", "anyone"
+	case "domain":
+		principal, domain = permission.Domain, strings.ToLower(permission.Domain)
+	case "user", "group":
+		principal, domain = permission.EmailAddress, emailDomain(permission.EmailAddress)
+	default:
+		return
+	}
+	if domain == "" || internalDomain(domain, opts.Domains) {
+		return
+	}
+
+	if domain != "anyone" {
+		report.Domains[domain]++
+		if internalDomain(domain, opts.Allowlist) {
+			return
+		}
+	}
+	report.Violations = append(report.Violations, &ExternalSharingViolation{
+		Source:     source,
+		ResourceID: id,
+		Resource:   name,
+		Link:       link,
+		Principal:  principal,
+		Domain:     domain,
+		Role:       permission.Role,
+		GrantID:    permission.ID,
+	})
+}
+
+// removeGrant removes the permission or group membership of a violation
+func (c *Client) removeGrant(violation *ExternalSharingViolation) error {
+	switch violation.Source {
+	case SharingSourceDrive:
+		return c.Google.Permissions().DeletePermission(violation.ResourceID, violation.GrantID)
+	case SharingSourceSharedDrives:
+		return c.Google.Drive().RemoveSharedDrivePermission(violation.ResourceID, violation.GrantID)
+	case SharingSourceGroups:
+		return c.Google.Groups().RemoveMember(violation.ResourceID, violation.GrantID)
+	default:
+		return fmt.Errorf("unknown sharing source %q", violation.Source)
+	}
+}
+
+// emailDomain returns the lowercase domain of an email address
+func emailDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+	return strings.ToLower(email[at+1:])
+}
+
+// internalDomain reports whether a domain is one of the domains listed, or one of their subdomains
+func internalDomain(domain string, domains []string) bool {
+	for _, d := range domains {
+		d = strings.ToLower(strings.TrimPrefix(d, "@"))
+		if domain == d || strings.HasSuffix(domain, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+// externalSharingSection is a titled group of violations rendered by the `external_sharing` template
+type externalSharingSection struct {
+	Title      string
+	Violations []*ExternalSharingViolation
+}
+
+// notifyExternalSharing delivers the violations of the enforcement, if any, to the configured channels
+func (c *Client) notifyExternalSharing(report *ExternalSharingReport, opts *ExternalSharingOptions) error {
+	if len(report.Violations) == 0 {
+		return nil
+	}
+
+	router := notify.NewRouter()
+	routes := 0
+	if opts.SlackChannel != "" {
+		if c.Slack == nil {
+			return fmt.Errorf("slack channel %s configured without a slack client", opts.SlackChannel)
+		}
+		router.Route(notify.Info, &notify.SlackNotifier{Client: c.Slack, Channel: opts.SlackChannel})
+		routes++
+	}
+	if len(opts.Recipients) > 0 {
+		router.Route(notify.Info, notify.EmailNotifierFromEnv(opts.Recipients...))
+		routes++
+	}
+	if opts.Notifier != nil {
+		router.Route(notify.Info, opts.Notifier)
+		routes++
+	}
+	if routes == 0 {
+		return nil
+	}
+
+	sections := []*externalSharingSection{}
+	bySource := map[string]*externalSharingSection{}
+	for _, violation := range report.Violations {
+		section, ok := bySource[violation.Source]
+		if !ok {
+			section = &externalSharingSection{Title: violation.Source}
+			bySource[violation.Source] = section
+			sections = append(sections, section)
+		}
+		section.Violations = append(section.Violations, violation)
+	}
+
+	title := fmt.Sprintf("{Google} External sharing report %s (%d violations)", time.Now().Format("2006-01-02"), len(report.Violations))
+	message, err := notify.FromTemplate(c.Tenant, notify.Warning, title, "external_sharing", sections)
+	if err != nil {
+		return err
+	}
+
+	return router.Notify(message)
+}
@@ -0,0 +1,166 @@
+/*
+# Orchestrators - Freeze User
+
+This package contains the incident-response "freeze user" action, cutting a compromised account off
+from every service at once.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/freeze.go
+package orchestrators
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/jamf"
+)
+
+// FreezeOptions configures FreezeUser
+type FreezeOptions struct {
+	ExpirePassword bool   // Expire the Okta password, forcing a reset once the account is restored
+	LockDevices    bool   // Send DEVICE_LOCK to the user's Jamf computers
+	LockPIN        string // Six digit PIN to unlock the computers. Default: randomly generated, and returned in the outcome
+	LockMessage    string // Message shown on the lock screen
+}
+
+// FreezeOutcome is the result of freezing the user in a single service
+type FreezeOutcome struct {
+	Service  string        // Service the action ran against
+	Action   string        // What was done, e.g. "suspended", "revoked sessions"
+	Detail   string        // Additional detail, e.g. the devices locked and their PIN
+	Err      error         // Set when the action failed
+	Duration time.Duration // Time taken
+}
+
+/*
+ * Orchestrate the following, in parallel:
+ * Suspend the user in Google
+ * Revoke the user's Okta sessions, refresh tokens and grants (optionally expiring the password)
+ * Lock the user's Jamf computers (optional)
+ * Return the outcome of each service; the error summarizes any that failed
+ */
+func (c *Client) FreezeUser(email string, opts *FreezeOptions) ([]*FreezeOutcome, error) {
+	if opts == nil {
+		opts = &FreezeOptions{}
+	}
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	c.Log.Warningf("Freezing %s", email)
+
+	actions := map[string]func() (string, string, error){}
+	if c.Google != nil {
+		actions["Google"] = func() (string, string, error) {
+			_, err := c.Google.Users().SuspendUser(email)
+			return "suspended", "", err
+		}
+	}
+	if c.Okta != nil {
+		actions["Okta"] = func() (string, string, error) {
+			user, err := c.Okta.GetUser(email)
+			if err != nil {
+				return "revoke sessions", "", err
+			}
+			revocation, err := c.Okta.RevokeAllSessions(user.ID, opts.ExpirePassword)
+			detail := ""
+			if revocation != nil {
+				detail = fmt.Sprintf("sessions=%t clients=%d grants=%t password expired=%t", revocation.Sessions, len(revocation.Clients), revocation.Grants, revocation.PasswordExpired)
+			}
+			return "revoked sessions", detail, err
+		}
+	}
+	if c.Jamf != nil && opts.LockDevices {
+		actions["Jamf"] = func() (string, string, error) {
+			return c.lockUserComputers(email, opts)
+		}
+	}
+
+	outcomes := make([]*FreezeOutcome, 0, len(actions))
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+
+	for service, action := range actions {
+		wg.Add(1)
+		go func(service string, action func() (string, string, error)) {
+			defer wg.Done()
+
+			start := time.Now()
+			what, detail, err := action()
+			outcome := &FreezeOutcome{Service: service, Action: what, Detail: detail, Err: err, Duration: time.Since(start)}
+
+			mutex.Lock()
+			outcomes = append(outcomes, outcome)
+			mutex.Unlock()
+		}(service, action)
+	}
+	wg.Wait()
+
+	var failed []string
+	for _, outcome := range outcomes {
+		if outcome.Err != nil {
+			c.Log.Errorf("Freeze %s in %s failed after %s: %v", email, outcome.Service, outcome.Duration, outcome.Err)
+			failed = append(failed, fmt.Sprintf("%s: %v", outcome.Service, outcome.Err))
+			continue
+		}
+		c.Log.Printf("Freeze %s in %s: %s (%s)", email, outcome.Service, outcome.Action, outcome.Duration)
+	}
+
+	if len(failed) > 0 {
+		return outcomes, fmt.Errorf("error freezing %s: %s", email, strings.Join(failed, "; "))
+	}
+
+	return outcomes, nil
+}
+
+// lockUserComputers sends DEVICE_LOCK to every Jamf computer assigned to the user
+func (c *Client) lockUserComputers(email string, opts *FreezeOptions) (string, string, error) {
+	computers, err := c.Jamf.Devices().Sections([]string{jamf.Section.General, jamf.Section.UserAndLocation}).ListAllComputers()
+	if err != nil {
+		return "lock devices", "", err
+	}
+
+	ids := []string{}
+	names := []string{}
+	if computers.Results != nil {
+		for _, computer := range *computers.Results {
+			if computer.UserAndLocation == nil || computer.General == nil || !strings.EqualFold(computer.UserAndLocation.Email, email) {
+				continue
+			}
+			ids = append(ids, computer.General.ManagementID)
+			names = append(names, computer.General.Name)
+		}
+	}
+	if len(ids) == 0 {
+		return "lock devices", "no computers assigned", nil
+	}
+
+	pin := opts.LockPIN
+	if pin == "" {
+		var err error
+		if pin, err = randomPIN(); err != nil {
+			return "lock devices", "", err
+		}
+	}
+
+	if _, err := c.Jamf.LockDevices(ids, pin, opts.LockMessage); err != nil {
+		return "lock devices", strings.Join(names, ", "), err
+	}
+
+	return "locked devices", fmt.Sprintf("%s (PIN %s)", strings.Join(names, ", "), pin), nil
+}
+
+// randomPIN returns a random six digit PIN to lock (or erase) a computer with
+func randomPIN() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
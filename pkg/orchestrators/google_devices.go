@@ -0,0 +1,186 @@
+/*
+# Orchestrators - Google Device Inventory Sync
+
+This package contains a sync creating Snipe-IT assets for the company-owned Android and ChromeOS devices enrolled in
+Google Workspace, matched by serial number and carrying their annotated asset tag, so the mobile fleet stays in the CMDB.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/google_devices.go
+package orchestrators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gemini-oss/rego/pkg/google"
+	"github.com/gemini-oss/rego/pkg/snipeit"
+)
+
+// Actions taken (or planned) by SyncGoogleDevices
+const (
+	DeviceSyncCreated = "created"     // A Snipe-IT asset was created for the device
+	DeviceSyncTagged  = "tag updated" // The asset tag of the existing asset was aligned with Google
+	DeviceSyncSkipped = "skipped"     // The device could not be synced, see the detail
+)
+
+// GoogleDeviceSyncOptions configures the Google device inventory sync
+type GoogleDeviceSyncOptions struct {
+	DeviceTypes    []string       // Google device types to sync. Default: google.DeviceTypeAndroid, google.DeviceTypeChromeOS
+	ModelIDs       map[string]int // Snipe-IT model ID of each Google model name, e.g. "Pixel 8" -> 42
+	DefaultModelID int            // Snipe-IT model ID of devices whose model is not in ModelIDs; such devices are skipped when 0
+	Status         string         // Snipe-IT status label of created assets. Default: Ready to Deploy
+	DryRun         bool           // Only report the changes which would be made
+}
+
+// GoogleDeviceSyncChange is a single change of the Google device inventory sync
+type GoogleDeviceSyncChange struct {
+	Serial     string // Serial number of the device
+	DeviceType string // Google device type, e.g. ANDROID
+	Model      string // Google model name
+	AssetTag   string // Asset tag annotated in Google
+	AssetID    int    // ID of the Snipe-IT asset, once it exists
+	Action     string // DeviceSyncCreated, DeviceSyncTagged or DeviceSyncSkipped
+	Detail     string // Additional detail, e.g. the previous asset tag or why the device was skipped
+	Applied    bool   // The change was made in Snipe-IT (false under DryRun, or with the flag switched off)
+}
+
+/*
+ * Orchestrate the following:
+ * List the company-owned devices of the given types from the Google (Cloud Identity) Devices API
+ * Match each device to a Snipe-IT asset by serial number
+ * Create an asset for devices missing from Snipe-IT, using the model mapping and the annotated asset tag
+ * Align the asset tag of existing assets with the tag annotated in Google
+ * Changes are only reported under DryRun, or with the google-device-sync flag switched off
+ */
+func (c *Client) SyncGoogleDevices(opts *GoogleDeviceSyncOptions) ([]*GoogleDeviceSyncChange, error) {
+	if opts == nil {
+		opts = &GoogleDeviceSyncOptions{}
+	}
+	if len(opts.DeviceTypes) == 0 {
+		opts.DeviceTypes = []string{google.DeviceTypeAndroid, google.DeviceTypeChromeOS}
+	}
+	if opts.Status == "" {
+		opts.Status = "Ready to Deploy"
+	}
+	dryRun := opts.DryRun
+	if !dryRun && c.checkFlag(FlagGoogleDeviceSync) != nil {
+		dryRun = true
+	}
+
+	devices, err := c.Google.Devices().ListCompanyOwnedDevices(opts.DeviceTypes...)
+	if err != nil {
+		return nil, err
+	}
+
+	assets, err := c.SnipeIT.Assets().GetAllAssets()
+	if err != nil {
+		return nil, err
+	}
+	bySerial := map[string]*snipeit.Hardware{}
+	if assets.Rows != nil {
+		for _, asset := range *assets.Rows {
+			if asset.Serial != "" {
+				bySerial[strings.ToUpper(strings.TrimSpace(asset.Serial))] = asset
+			}
+		}
+	}
+
+	statusID := 0
+	if !dryRun {
+		label, err := c.SnipeIT.StatusLabels().FindStatusLabel(opts.Status)
+		if err != nil {
+			return nil, err
+		}
+		statusID = label.ID
+	}
+
+	sort.Slice(devices, func(i, j int) bool { return devices[i].SerialNumber < devices[j].SerialNumber })
+
+	changes := []*GoogleDeviceSyncChange{}
+	var errs []*SourceError
+	inSync := 0
+	for _, device := range devices {
+		serial := strings.ToUpper(strings.TrimSpace(device.SerialNumber))
+		change := &GoogleDeviceSyncChange{
+			Serial:     serial,
+			DeviceType: device.DeviceType,
+			Model:      device.Model,
+			AssetTag:   strings.TrimSpace(device.AssetTag),
+		}
+
+		asset, exists := bySerial[serial]
+		switch {
+		case serial == "":
+			change.Action, change.Detail = DeviceSyncSkipped, fmt.Sprintf("%s reports no serial number", device.Name)
+		case exists && (change.AssetTag == "" || change.AssetTag == asset.AssetTag):
+			inSync++
+			continue
+		case exists:
+			change.AssetID = asset.ID
+			change.Action, change.Detail = DeviceSyncTagged, fmt.Sprintf("previously %q", asset.AssetTag)
+		default:
+			change.Action = DeviceSyncCreated
+			if googleDeviceModelID(device.Model, opts) == 0 {
+				change.Action, change.Detail = DeviceSyncSkipped, fmt.Sprintf("no Snipe-IT model for %q", device.Model)
+			}
+		}
+		changes = append(changes, change)
+
+		if change.Action == DeviceSyncSkipped {
+			c.Log.Warningf("Skipping Google device %s: %s", change.Serial, change.Detail)
+			continue
+		}
+		if dryRun {
+			c.Log.Printf("[dry run] Google device %s (%s, tag %q) would be %s in Snipe-IT", change.Serial, change.Model, change.AssetTag, change.Action)
+			continue
+		}
+
+		if err := c.applyGoogleDeviceChange(change, statusID, opts); err != nil {
+			c.Log.Errorf("Unable to sync Google device %s: %v", change.Serial, err)
+			errs = append(errs, &SourceError{Source: fmt.Sprintf("Snipe-IT (%s)", change.Serial), Err: err})
+			continue
+		}
+		change.Applied = true
+		c.Log.Printf("Snipe-IT asset %d %s for Google device %s", change.AssetID, change.Action, change.Serial)
+	}
+
+	c.Log.Printf("Google device sync: %d device(s) in sync, %d change(s)", inSync, len(changes))
+	return complete(c, "google device sync", changes, errs)
+}
+
+// applyGoogleDeviceChange creates the asset of a device, or updates its asset tag
+func (c *Client) applyGoogleDeviceChange(change *GoogleDeviceSyncChange, statusID int, opts *GoogleDeviceSyncOptions) error {
+	if change.Action == DeviceSyncTagged {
+		_, err := c.SnipeIT.Assets().PartialUpdateAsset(change.AssetID, &snipeit.Hardware{AssetTag: change.AssetTag})
+		return err
+	}
+
+	asset, err := c.SnipeIT.Assets().CreateAsset(&snipeit.Hardware{
+		Name:     fmt.Sprintf("%s (%s)", change.Model, change.Serial),
+		AssetTag: change.AssetTag,
+		Serial:   change.Serial,
+		ModelID:  googleDeviceModelID(change.Model, opts),
+		StatusID: statusID,
+		Notes:    fmt.Sprintf("Company-owned %s device synced from Google Workspace", change.DeviceType),
+	})
+	if err != nil {
+		return err
+	}
+	if asset != nil {
+		change.AssetID = asset.ID
+	}
+	return nil
+}
+
+// googleDeviceModelID returns the Snipe-IT model of a Google model name, falling back to DefaultModelID
+func googleDeviceModelID(model string, opts *GoogleDeviceSyncOptions) int {
+	if id, ok := opts.ModelIDs[model]; ok {
+		return id
+	}
+	return opts.DefaultModelID
+}
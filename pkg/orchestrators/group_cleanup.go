@@ -0,0 +1,589 @@
+/*
+# Orchestrators - Group Cleanup
+
+This package contains the cleanup engine for stale Okta and Google groups (without owners, without members, or without
+activity in the audit logs), moving each through a staged workflow: notify the owners, archive, then delete.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/group_cleanup.go
+package orchestrators
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/notify"
+	"github.com/gemini-oss/rego/pkg/okta"
+)
+
+// Why a group is stale
+const (
+	StaleNoOwners  = "no owners"
+	StaleNoMembers = "no members"
+	StaleInactive  = "inactive"
+)
+
+// Stages of the cleanup workflow, and the actions reported by GroupCleanup.Run
+const (
+	CleanupNotified  = "notified"  // The owners (or administrators) were told the group will be archived
+	CleanupArchived  = "archived"  // The group was archived (archive-only in Google, renamed with ArchivePrefix in Okta)
+	CleanupDeleted   = "deleted"   // The group was deleted
+	CleanupRecovered = "recovered" // The group is no longer stale, and left the workflow before being archived
+)
+
+// GroupCleanupOptions configures the stale group cleanup
+type GroupCleanupOptions struct {
+	Providers     []string        // Providers whose groups are cleaned up. Default: every provider with a client
+	Reasons       []string        // Reasons making a group stale. Default: StaleNoOwners, StaleNoMembers, StaleInactive
+	InactiveDays  int             // Days without audit activity for a group to be inactive. Default: 180; the Okta System Log only keeps 90 days
+	ArchiveAfter  time.Duration   // Time between notifying the owners and archiving the group. Default: 14 days
+	DeleteAfter   time.Duration   // Time between archiving and deleting the group. Default: 30 days
+	ArchivePrefix string          // Prefix added to the names of archived Okta groups. Default: "archived-"
+	Exclude       []string        // Names, emails or IDs of groups which are never cleaned up
+	Admins        notify.Notifier // Receives the notices of groups without owners, and a summary of each run; skipped when nil
+	StateFile     string          // JSON file keeping the workflow across restarts; in memory only when empty
+	DryRun        bool            // Only report the actions which would be taken, without advancing the workflow
+}
+
+// GroupCleanupState is the position of a group in the cleanup workflow
+type GroupCleanupState struct {
+	Provider string    `json:"provider"`
+	ID       string    `json:"id"`      // Okta group ID, or Google group email
+	Name     string    `json:"name"`    // Name of the group when it entered the workflow
+	Owners   []string  `json:"owners"`  // Emails of the owners notified
+	Reasons  []string  `json:"reasons"` // Why the group is stale
+	Stage    string    `json:"stage"`   // CleanupNotified or CleanupArchived
+	Since    time.Time `json:"since"`   // Time the group entered its stage
+}
+
+// GroupCleanupAction is a step of the workflow taken (or planned, under DryRun) for a group
+type GroupCleanupAction struct {
+	Group   *GroupCleanupState
+	Action  string // CleanupNotified, CleanupArchived, CleanupDeleted or CleanupRecovered
+	Applied bool   // The action was taken (false under DryRun, or with the flag switched off)
+	Err     error
+}
+
+// GroupCleanup runs the staged cleanup of stale groups, remembering where each group is in the workflow
+type GroupCleanup struct {
+	client *Client
+	opts   *GroupCleanupOptions
+	groups map[string]*GroupCleanupState // Provider/ID -> state
+	mutex  sync.Mutex
+}
+
+// staleGroup is a group found stale by a run
+type staleGroup struct {
+	provider string
+	id       string
+	name     string
+	owners   []string
+	reasons  []string
+}
+
+// Entry point for the stale group cleanup; the workflow is loaded from opts.StateFile when it exists
+func (c *Client) GroupCleanup(opts *GroupCleanupOptions) (*GroupCleanup, error) {
+	if opts == nil {
+		opts = &GroupCleanupOptions{}
+	}
+	if len(opts.Reasons) == 0 {
+		opts.Reasons = []string{StaleNoOwners, StaleNoMembers, StaleInactive}
+	}
+	if opts.InactiveDays == 0 {
+		opts.InactiveDays = 180
+	}
+	if opts.ArchiveAfter == 0 {
+		opts.ArchiveAfter = 14 * 24 * time.Hour
+	}
+	if opts.DeleteAfter == 0 {
+		opts.DeleteAfter = 30 * 24 * time.Hour
+	}
+	if opts.ArchivePrefix == "" {
+		opts.ArchivePrefix = "archived-"
+	}
+
+	g := &GroupCleanup{
+		client: c,
+		opts:   opts,
+		groups: make(map[string]*GroupCleanupState),
+	}
+
+	if opts.StateFile != "" {
+		data, err := os.ReadFile(opts.StateFile)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			return nil, err
+		default:
+			states := []*GroupCleanupState{}
+			if err := json.Unmarshal(data, &states); err != nil {
+				return nil, fmt.Errorf("reading the group cleanup state %s: %w", opts.StateFile, err)
+			}
+			for _, state := range states {
+				g.groups[state.Provider+"/"+state.ID] = state
+			}
+		}
+	}
+
+	return g, nil
+}
+
+// Groups returns the groups currently in the workflow
+func (g *GroupCleanup) Groups() []*GroupCleanupState {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.sortedStates()
+}
+
+// Restore takes a group out of the workflow, e.g. after its owners asked to keep it; an archived group must be unarchived by hand
+func (g *GroupCleanup) Restore(provider, id string) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	key := provider + "/" + id
+	if _, ok := g.groups[key]; !ok {
+		return fmt.Errorf("%s group %s is not being cleaned up", provider, id)
+	}
+	delete(g.groups, key)
+
+	return g.save()
+}
+
+/*
+ * Orchestrate the following:
+ * Find the stale groups of each provider
+ * Notify the owners of newly stale groups (the administrators, for groups without owners)
+ * Groups which are no longer stale leave the workflow, unless already archived
+ * Archive the groups notified more than opts.ArchiveAfter ago, and delete those archived more than opts.DeleteAfter ago
+ * Actions are only reported under DryRun, or with the group-cleanup flag switched off
+ */
+func (g *GroupCleanup) Run() ([]*GroupCleanupAction, error) {
+	c := g.client
+	dryRun := g.opts.DryRun
+	if !dryRun && c.checkFlag(FlagGroupCleanup) != nil {
+		dryRun = true
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	stale, scanned, errs := g.findStaleGroups()
+
+	now := time.Now()
+	actions := []*GroupCleanupAction{}
+	next := make(map[string]*GroupCleanupState, len(g.groups))
+	for key, state := range g.groups {
+		next[key] = state
+	}
+
+	// Groups leaving the workflow, only judged for providers scanned successfully
+	for key, state := range g.groups {
+		if _, ok := stale[key]; ok || state.Stage != CleanupNotified || !slices.Contains(scanned, state.Provider) {
+			continue
+		}
+		actions = append(actions, &GroupCleanupAction{Group: state, Action: CleanupRecovered, Applied: !dryRun})
+		delete(next, key)
+	}
+
+	// Newly stale groups
+	for key, group := range stale {
+		if _, ok := g.groups[key]; ok {
+			continue
+		}
+		state := &GroupCleanupState{Provider: group.provider, ID: group.id, Name: group.name, Owners: group.owners, Reasons: group.reasons, Stage: CleanupNotified, Since: now}
+		action := &GroupCleanupAction{Group: state, Action: CleanupNotified}
+		actions = append(actions, action)
+		if dryRun {
+			continue
+		}
+		if action.Err = g.notifyOwners(state, now.Add(g.opts.ArchiveAfter)); action.Err != nil {
+			continue
+		}
+		action.Applied = true
+		next[key] = state
+	}
+
+	// Groups due for their next stage
+	for key, state := range g.groups {
+		if _, ok := next[key]; !ok {
+			continue
+		}
+
+		var action *GroupCleanupAction
+		switch {
+		case state.Stage == CleanupNotified && now.Sub(state.Since) >= g.opts.ArchiveAfter:
+			action = &GroupCleanupAction{Group: state, Action: CleanupArchived}
+			if !dryRun {
+				action.Err = g.archive(state)
+			}
+		case state.Stage == CleanupArchived && now.Sub(state.Since) >= g.opts.DeleteAfter:
+			action = &GroupCleanupAction{Group: state, Action: CleanupDeleted}
+			if !dryRun {
+				action.Err = g.delete(state)
+			}
+		default:
+			continue
+		}
+		actions = append(actions, action)
+		if dryRun || action.Err != nil {
+			continue
+		}
+
+		action.Applied = true
+		if action.Action == CleanupDeleted {
+			delete(next, key)
+			continue
+		}
+		state.Stage, state.Since = CleanupArchived, now
+	}
+
+	for _, action := range actions {
+		switch {
+		case action.Err != nil:
+			c.Log.Errorf("Unable to mark %s group %s %s: %v", action.Group.Provider, action.Group.Name, action.Action, action.Err)
+			errs = append(errs, &SourceError{Source: fmt.Sprintf("%s (%s)", action.Group.Provider, action.Group.Name), Err: action.Err})
+		case dryRun:
+			c.Log.Printf("[dry run] %s group %s would be %s (%s)", action.Group.Provider, action.Group.Name, action.Action, strings.Join(action.Group.Reasons, ", "))
+		default:
+			c.Log.Printf("%s group %s %s (%s)", action.Group.Provider, action.Group.Name, action.Action, strings.Join(action.Group.Reasons, ", "))
+		}
+	}
+
+	if !dryRun {
+		g.groups = next
+		if err := g.save(); err != nil {
+			errs = append(errs, &SourceError{Source: "State", Err: err})
+		}
+	}
+
+	if err := g.notifyAdmins(actions, dryRun); err != nil {
+		errs = append(errs, &SourceError{Source: "Notifications", Err: err})
+	}
+
+	c.Log.Printf("Group cleanup: %d stale group(s), %d action(s), %d group(s) in the workflow", len(stale), len(actions), len(g.groups))
+	return complete(c, "group cleanup", actions, errs)
+}
+
+/*
+ * Orchestrate the following:
+ * Run the group cleanup immediately, then on every interval (e.g. daily) until stopped
+ */
+func (g *GroupCleanup) Schedule(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := g.Run(); err != nil {
+			g.client.Log.Error("Error running the group cleanup:", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// findStaleGroups returns the stale groups by Provider/ID, and the providers scanned successfully
+func (g *GroupCleanup) findStaleGroups() (map[string]*staleGroup, []string, []*SourceError) {
+	c := g.client
+
+	providers := g.opts.Providers
+	if len(providers) == 0 {
+		if c.Okta != nil {
+			providers = append(providers, GroupProviderOkta)
+		}
+		if c.Google != nil {
+			providers = append(providers, GroupProviderGoogle)
+		}
+	}
+
+	stale := map[string]*staleGroup{}
+	scanned := []string{}
+	var errs []*SourceError
+	for _, provider := range providers {
+		var found []*staleGroup
+		var err error
+		switch provider {
+		case GroupProviderOkta:
+			found, err = g.staleOktaGroups()
+		case GroupProviderGoogle:
+			found, err = g.staleGoogleGroups()
+		default:
+			err = fmt.Errorf("unknown group provider %q", provider)
+		}
+		if err != nil {
+			errs = append(errs, &SourceError{Source: provider, Err: err})
+			continue
+		}
+
+		scanned = append(scanned, provider)
+		for _, group := range found {
+			if g.excluded(group) {
+				continue
+			}
+			stale[group.provider+"/"+group.id] = group
+		}
+	}
+
+	return stale, scanned, errs
+}
+
+// staleOktaGroups returns the stale Okta (OKTA_GROUP) groups; archived groups are left to the workflow
+func (g *GroupCleanup) staleOktaGroups() ([]*staleGroup, error) {
+	c := g.client
+	now := time.Now()
+	inactiveSince := now.AddDate(0, 0, -g.opts.InactiveDays)
+
+	groups, err := c.Okta.ListAllGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	var activity map[string]time.Time
+	if slices.Contains(g.opts.Reasons, StaleInactive) {
+		// The System Log only keeps 90 days of events
+		since := inactiveSince
+		if oldest := now.AddDate(0, 0, -90); since.Before(oldest) {
+			since = oldest
+		}
+		if activity, err = c.Okta.LastGroupActivity(since, now); err != nil {
+			return nil, err
+		}
+	}
+
+	found := []*staleGroup{}
+	for _, group := range *groups {
+		if group.Type != "OKTA_GROUP" || strings.HasPrefix(group.Profile.Name, g.opts.ArchivePrefix) {
+			continue
+		}
+		stale := &staleGroup{provider: GroupProviderOkta, id: group.ID, name: group.Profile.Name}
+
+		owners, err := c.Okta.ListGroupOwners(group.ID)
+		if err != nil {
+			return nil, fmt.Errorf("listing the owners of %s: %w", group.Profile.Name, err)
+		}
+		for _, owner := range *owners {
+			if owner.Type != "USER" {
+				continue
+			}
+			user, err := c.Okta.GetUser(owner.ID)
+			if err != nil {
+				return nil, fmt.Errorf("looking up owner %s of %s: %w", owner.DisplayName, group.Profile.Name, err)
+			}
+			stale.owners = append(stale.owners, user.Profile.Email)
+		}
+
+		if slices.Contains(g.opts.Reasons, StaleNoOwners) && len(*owners) == 0 {
+			stale.reasons = append(stale.reasons, StaleNoOwners)
+		}
+		if slices.Contains(g.opts.Reasons, StaleNoMembers) {
+			members, err := c.Okta.ListGroupMembers(group.ID)
+			if err != nil {
+				return nil, fmt.Errorf("listing the members of %s: %w", group.Profile.Name, err)
+			}
+			if len(*members) == 0 {
+				stale.reasons = append(stale.reasons, StaleNoMembers)
+			}
+		}
+		if activity != nil {
+			last := activity[group.ID]
+			if group.LastMembershipUpdated.After(last) {
+				last = group.LastMembershipUpdated
+			}
+			if last.Before(inactiveSince) && group.Created.Before(inactiveSince) {
+				stale.reasons = append(stale.reasons, StaleInactive)
+			}
+		}
+
+		if len(stale.reasons) > 0 {
+			found = append(found, stale)
+		}
+	}
+
+	return found, nil
+}
+
+// staleGoogleGroups returns the stale Google groups
+func (g *GroupCleanup) staleGoogleGroups() ([]*staleGroup, error) {
+	c := g.client
+	now := time.Now()
+	inactiveSince := now.AddDate(0, 0, -g.opts.InactiveDays)
+
+	groups, err := c.Google.Groups().ListAllGroups(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var activity map[string]time.Time
+	if slices.Contains(g.opts.Reasons, StaleInactive) {
+		if activity, err = c.Google.Admin().LastGroupActivity(inactiveSince, now); err != nil {
+			return nil, err
+		}
+	}
+
+	found := []*staleGroup{}
+	for _, group := range groups.Groups {
+		stale := &staleGroup{provider: GroupProviderGoogle, id: strings.ToLower(group.Email), name: group.Name}
+
+		members, _ := strconv.Atoi(group.DirectMembersCount)
+		if members > 0 {
+			owners, err := c.Google.Groups().ListAllMembers(group.Email)
+			if err != nil {
+				return nil, fmt.Errorf("listing the members of %s: %w", group.Email, err)
+			}
+			for _, member := range owners.Members {
+				if member.Role == "OWNER" && member.Email != "" {
+					stale.owners = append(stale.owners, member.Email)
+				}
+			}
+		}
+
+		if slices.Contains(g.opts.Reasons, StaleNoOwners) && len(stale.owners) == 0 {
+			stale.reasons = append(stale.reasons, StaleNoOwners)
+		}
+		if slices.Contains(g.opts.Reasons, StaleNoMembers) && members == 0 {
+			stale.reasons = append(stale.reasons, StaleNoMembers)
+		}
+		if _, active := activity[stale.id]; activity != nil && !active {
+			stale.reasons = append(stale.reasons, StaleInactive)
+		}
+
+		if len(stale.reasons) > 0 {
+			found = append(found, stale)
+		}
+	}
+
+	return found, nil
+}
+
+// excluded reports whether a group is listed in opts.Exclude
+func (g *GroupCleanup) excluded(group *staleGroup) bool {
+	for _, exclude := range g.opts.Exclude {
+		if strings.EqualFold(exclude, group.id) || strings.EqualFold(exclude, group.name) {
+			return true
+		}
+	}
+	return false
+}
+
+// notifyOwners tells the owners of a group (the administrators, when it has none) that it will be archived
+func (g *GroupCleanup) notifyOwners(state *GroupCleanupState, archiveOn time.Time) error {
+	message := &notify.Message{
+		Severity: notify.Warning,
+		Title:    fmt.Sprintf("{Groups} %s will be archived on %s", state.Name, archiveOn.Format("2006-01-02")),
+		Body: fmt.Sprintf("The %s group %s is unused (%s). It will be archived on %s, then deleted %d days later. "+
+			"Ask your IT team to keep it if it is still needed.", state.Provider, state.Name, strings.Join(state.Reasons, ", "), archiveOn.Format("2006-01-02"), int(g.opts.DeleteAfter.Hours()/24)),
+		Fields: map[string]string{
+			"provider": state.Provider,
+			"group":    state.ID,
+			"reasons":  strings.Join(state.Reasons, ", "),
+		},
+		DedupKey: fmt.Sprintf("rego-group-cleanup-%s-%s", state.Provider, state.ID),
+	}
+
+	if len(state.Owners) > 0 {
+		return notify.EmailNotifierFromEnv(state.Owners...).Notify(message)
+	}
+	if g.opts.Admins == nil {
+		g.client.Log.Warningf("%s group %s has no owners to notify, and no administrators are configured", state.Provider, state.Name)
+		return nil
+	}
+	return g.opts.Admins.Notify(message)
+}
+
+// archive archives a group: archive-only in Google, renamed with the archive prefix in Okta
+func (g *GroupCleanup) archive(state *GroupCleanupState) error {
+	c := g.client
+
+	switch state.Provider {
+	case GroupProviderOkta:
+		group, err := c.Okta.GetGroup(state.ID)
+		if err != nil {
+			return err
+		}
+		description := strings.TrimSpace(fmt.Sprintf("Archived %s (%s). %s", time.Now().Format("2006-01-02"), strings.Join(state.Reasons, ", "), group.Profile.Description))
+		_, err = c.Okta.UpdateGroup(state.ID, okta.GroupProfile{Name: g.opts.ArchivePrefix + group.Profile.Name, Description: description})
+		return err
+	case GroupProviderGoogle:
+		_, err := c.Google.Groups().ArchiveGroup(state.ID)
+		return err
+	default:
+		return fmt.Errorf("unknown group provider %q", state.Provider)
+	}
+}
+
+// delete deletes a group
+func (g *GroupCleanup) delete(state *GroupCleanupState) error {
+	switch state.Provider {
+	case GroupProviderOkta:
+		return g.client.Okta.DeleteGroup(state.ID)
+	case GroupProviderGoogle:
+		return g.client.Google.Groups().DeleteGroup(state.ID)
+	default:
+		return fmt.Errorf("unknown group provider %q", state.Provider)
+	}
+}
+
+// notifyAdmins sends the administrators a summary of the actions of a run, if any
+func (g *GroupCleanup) notifyAdmins(actions []*GroupCleanupAction, dryRun bool) error {
+	if g.opts.Admins == nil || len(actions) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	for _, action := range actions {
+		fmt.Fprintf(&b, "  - [%s] %s %s (%s)", action.Group.Provider, action.Group.Name, action.Action, strings.Join(action.Group.Reasons, ", "))
+		if action.Err != nil {
+			fmt.Fprintf(&b, ": failed, %v", action.Err)
+		}
+		b.WriteString("\n")
+	}
+
+	title := fmt.Sprintf("{Groups} Cleanup report %s (%d actions)", time.Now().Format("2006-01-02"), len(actions))
+	if dryRun {
+		title += " [dry run]"
+	}
+
+	return g.opts.Admins.Notify(&notify.Message{Severity: notify.Info, Title: title, Body: b.String()})
+}
+
+// save writes the workflow to opts.StateFile, if set
+func (g *GroupCleanup) save() error {
+	if g.opts.StateFile == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(g.sortedStates(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(g.opts.StateFile, data, 0o600)
+}
+
+func (g *GroupCleanup) sortedStates() []*GroupCleanupState {
+	states := make([]*GroupCleanupState, 0, len(g.groups))
+	for _, state := range g.groups {
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Provider+"/"+states[i].Name < states[j].Provider+"/"+states[j].Name
+	})
+
+	return states
+}
@@ -0,0 +1,316 @@
+/*
+# Orchestrators - Group Naming Lint
+
+This package contains an orchestration checking the names, descriptions and owners of Okta and Google groups against
+naming policies, reporting violations and renaming non-compliant groups to a suggested name.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/group_naming.go
+package orchestrators
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/lint"
+	"github.com/gemini-oss/rego/pkg/common/notify"
+	"github.com/gemini-oss/rego/pkg/okta"
+)
+
+// Providers of the groups checked by GroupNamingLint
+const (
+	GroupProviderOkta   = "okta"
+	GroupProviderGoogle = "google"
+)
+
+// GroupNamingOptions configures the group naming lint
+type GroupNamingOptions struct {
+	Policies     []*lint.Policy  // Naming policies the groups are checked against. Required
+	Providers    []string        // Providers whose groups are checked. Default: every provider with a client
+	CheckOwners  bool            // Look up the owners of each group (one request per group), for policies with MinOwners
+	Rename       bool            // Rename groups breaking a naming rule to the suggested name
+	DryRun       bool            // Only report the renames which would be made
+	SlackChannel string          // Slack channel to post the violations to; skipped when empty
+	Recipients   []string        // Email recipients of the violations; skipped when empty
+	Notifier     notify.Notifier // Additional channel(s) for the violations; skipped when nil
+}
+
+// GroupNamingReport is the result of the group naming lint
+type GroupNamingReport struct {
+	Groups     int               // Number of groups checked
+	Violations []*lint.Violation // Rules broken, by group
+	Renames    []*GroupRename    // Renames of groups breaking a naming rule, when opts.Rename is set
+}
+
+// GroupRename is the rename of a group to the name suggested by a policy
+type GroupRename struct {
+	Provider string
+	ID       string
+	From     string
+	To       string
+	Applied  bool  // The group was renamed (false under DryRun, or with the flag switched off)
+	Err      error // Set when the rename failed
+}
+
+/*
+ * Orchestrate the following:
+ * List the Okta (OKTA_GROUP) and Google groups, with their owners when opts.CheckOwners is set
+ * Check each group against the naming policies
+ * Rename groups breaking a naming rule to the suggested name, when opts.Rename is set and the name is not taken
+ * Deliver the violations to Slack, email and/or the configured notifier
+ * Renames are only reported under DryRun, or with the group-naming-lint flag switched off
+ */
+func (c *Client) GroupNamingLint(opts *GroupNamingOptions) (*GroupNamingReport, error) {
+	if opts == nil || len(opts.Policies) == 0 {
+		return nil, fmt.Errorf("group naming lint requires at least one policy")
+	}
+	dryRun := opts.DryRun
+	if !dryRun && c.checkFlag(FlagGroupNamingLint) != nil {
+		dryRun = true
+	}
+
+	providers := opts.Providers
+	if len(providers) == 0 {
+		if c.Okta != nil {
+			providers = append(providers, GroupProviderOkta)
+		}
+		if c.Google != nil {
+			providers = append(providers, GroupProviderGoogle)
+		}
+	}
+
+	groups := []*lint.Group{}
+	var errs []*SourceError
+	for _, provider := range providers {
+		var found []*lint.Group
+		var err error
+		switch provider {
+		case GroupProviderOkta:
+			found, err = c.oktaLintGroups(opts.CheckOwners)
+		case GroupProviderGoogle:
+			found, err = c.googleLintGroups(opts.CheckOwners)
+		default:
+			err = fmt.Errorf("unknown group provider %q", provider)
+		}
+		if err != nil {
+			errs = append(errs, &SourceError{Source: provider, Err: err})
+			continue
+		}
+		groups = append(groups, found...)
+	}
+
+	violations, err := lint.Check(opts.Policies, groups)
+	if err != nil {
+		return nil, err
+	}
+	report := &GroupNamingReport{Groups: len(groups), Violations: violations}
+
+	if opts.Rename {
+		report.Renames = groupRenames(groups, violations)
+		for _, rename := range report.Renames {
+			if dryRun {
+				c.Log.Printf("[dry run] %s group %q would be renamed to %q", rename.Provider, rename.From, rename.To)
+				continue
+			}
+			if rename.Err = c.renameGroup(rename); rename.Err != nil {
+				c.Log.Errorf("Unable to rename %s group %q to %q: %v", rename.Provider, rename.From, rename.To, rename.Err)
+				errs = append(errs, &SourceError{Source: fmt.Sprintf("%s (%s)", rename.Provider, rename.From), Err: rename.Err})
+				continue
+			}
+			rename.Applied = true
+			c.Log.Printf("Renamed %s group %q to %q", rename.Provider, rename.From, rename.To)
+		}
+	}
+
+	if err := c.notifyGroupNaming(report, opts); err != nil {
+		errs = append(errs, &SourceError{Source: "Notifications", Err: err})
+	}
+
+	c.Log.Printf("Group naming lint: %d group(s) checked, %d violation(s), %d rename(s)", report.Groups, len(report.Violations), len(report.Renames))
+	return complete(c, "group naming lint", report, errs)
+}
+
+/*
+ * Orchestrate the following:
+ * Run the group naming lint immediately, then on every interval (e.g. weekly, 7*24*time.Hour) until stopped
+ */
+func (c *Client) ScheduleGroupNamingLint(opts *GroupNamingOptions, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := c.GroupNamingLint(opts); err != nil {
+			c.Log.Error("Error running the group naming lint:", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// oktaLintGroups lists the Okta groups mastered in Okta; groups imported from apps or directories cannot be renamed
+func (c *Client) oktaLintGroups(owners bool) ([]*lint.Group, error) {
+	groups, err := c.Okta.ListAllGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	found := []*lint.Group{}
+	for _, group := range *groups {
+		if group.Type != "OKTA_GROUP" {
+			continue
+		}
+		subject := &lint.Group{Provider: GroupProviderOkta, ID: group.ID, Name: group.Profile.Name, Description: group.Profile.Description}
+
+		if owners {
+			list, err := c.Okta.ListGroupOwners(group.ID)
+			if err != nil {
+				return nil, fmt.Errorf("listing the owners of %s: %w", group.Profile.Name, err)
+			}
+			subject.Owners = []string{}
+			for _, owner := range *list {
+				subject.Owners = append(subject.Owners, owner.DisplayName)
+			}
+		}
+		found = append(found, subject)
+	}
+
+	return found, nil
+}
+
+// googleLintGroups lists the Google groups, checked by display name
+func (c *Client) googleLintGroups(owners bool) ([]*lint.Group, error) {
+	groups, err := c.Google.Groups().ListAllGroups(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	found := []*lint.Group{}
+	for _, group := range groups.Groups {
+		subject := &lint.Group{Provider: GroupProviderGoogle, ID: group.Email, Name: group.Name, Description: group.Description}
+
+		if owners {
+			members, err := c.Google.Groups().ListAllMembers(group.Email)
+			if err != nil {
+				return nil, fmt.Errorf("listing the members of %s: %w", group.Email, err)
+			}
+			subject.Owners = []string{}
+			for _, member := range members.Members {
+				if member.Role == "OWNER" {
+					subject.Owners = append(subject.Owners, member.Email)
+				}
+			}
+		}
+		found = append(found, subject)
+	}
+
+	return found, nil
+}
+
+// groupRenames returns one rename per group with a suggested name, skipping names already taken within the provider
+func groupRenames(groups []*lint.Group, violations []*lint.Violation) []*GroupRename {
+	taken := map[string]bool{}
+	for _, group := range groups {
+		taken[group.Provider+"/"+strings.ToLower(group.Name)] = true
+	}
+
+	renames := []*GroupRename{}
+	renamed := map[*lint.Group]bool{}
+	for _, violation := range violations {
+		group := violation.Group
+		if violation.Suggestion == "" || renamed[group] || taken[group.Provider+"/"+strings.ToLower(violation.Suggestion)] {
+			continue
+		}
+		renamed[group] = true
+		taken[group.Provider+"/"+strings.ToLower(violation.Suggestion)] = true
+
+		renames = append(renames, &GroupRename{Provider: group.Provider, ID: group.ID, From: group.Name, To: violation.Suggestion})
+	}
+
+	return renames
+}
+
+// renameGroup applies a rename, keeping the description of the group
+func (c *Client) renameGroup(rename *GroupRename) error {
+	switch rename.Provider {
+	case GroupProviderOkta:
+		group, err := c.Okta.GetGroup(rename.ID)
+		if err != nil {
+			return err
+		}
+		_, err = c.Okta.UpdateGroup(rename.ID, okta.GroupProfile{Name: rename.To, Description: group.Profile.Description})
+		return err
+	case GroupProviderGoogle:
+		group, err := c.Google.Groups().GetGroup(rename.ID)
+		if err != nil {
+			return err
+		}
+		group.Name = rename.To
+		_, err = c.Google.Groups().UpdateGroup(rename.ID, group)
+		return err
+	default:
+		return fmt.Errorf("unknown group provider %q", rename.Provider)
+	}
+}
+
+// groupNamingSection is a titled group of violations rendered by the `group_naming_lint` template
+type groupNamingSection struct {
+	Title      string
+	Violations []*lint.Violation
+}
+
+// notifyGroupNaming delivers the violations of the lint, if any, to the configured channels
+func (c *Client) notifyGroupNaming(report *GroupNamingReport, opts *GroupNamingOptions) error {
+	if len(report.Violations) == 0 {
+		return nil
+	}
+
+	router := notify.NewRouter()
+	routes := 0
+	if opts.SlackChannel != "" {
+		if c.Slack == nil {
+			return fmt.Errorf("slack channel %s configured without a slack client", opts.SlackChannel)
+		}
+		router.Route(notify.Info, &notify.SlackNotifier{Client: c.Slack, Channel: opts.SlackChannel})
+		routes++
+	}
+	if len(opts.Recipients) > 0 {
+		router.Route(notify.Info, notify.EmailNotifierFromEnv(opts.Recipients...))
+		routes++
+	}
+	if opts.Notifier != nil {
+		router.Route(notify.Info, opts.Notifier)
+		routes++
+	}
+	if routes == 0 {
+		return nil
+	}
+
+	sections := []*groupNamingSection{}
+	byProvider := map[string]*groupNamingSection{}
+	for _, violation := range report.Violations {
+		section, ok := byProvider[violation.Group.Provider]
+		if !ok {
+			section = &groupNamingSection{Title: violation.Group.Provider}
+			byProvider[violation.Group.Provider] = section
+			sections = append(sections, section)
+		}
+		section.Violations = append(section.Violations, violation)
+	}
+
+	title := fmt.Sprintf("{Groups} Naming policy report %s (%d violations)", time.Now().Format("2006-01-02"), len(report.Violations))
+	message, err := notify.FromTemplate(c.Tenant, notify.Warning, title, "group_naming_lint", sections)
+	if err != nil {
+		return err
+	}
+
+	return router.Notify(message)
+}
@@ -0,0 +1,153 @@
+/*
+# Orchestrators - Report Hooks
+
+This package contains hook points in the report pipelines, allowing fetched data and exported rows
+to be transformed (filtered, enriched with computed columns) without forking the report code.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/hooks.go
+package orchestrators
+
+import (
+	"fmt"
+	"sync"
+)
+
+// AllReports registers a hook for every report
+const AllReports = "*"
+
+// TableTransform rewrites the rows of a report before export; the first row holds the headers
+type TableTransform func(table [][]string) ([][]string, error)
+
+// ReportHooks holds the transformations registered for each report, keyed by the report's flag name (e.g. FlagOktaRoleReport)
+type ReportHooks struct {
+	postFetch map[string][]func(data interface{}) (interface{}, error)
+	preExport map[string][]TableTransform
+	mutex     sync.RWMutex
+}
+
+func NewReportHooks() *ReportHooks {
+	return &ReportHooks{
+		postFetch: make(map[string][]func(data interface{}) (interface{}, error)),
+		preExport: make(map[string][]TableTransform),
+	}
+}
+
+/*
+ * OnFetch registers a transformation of a report's fetched data, run before rows are built
+ * `T` must match the data the report fetches, e.g. `*okta.RoleReports` for FlagOktaRoleReport
+ */
+func OnFetch[T any](h *ReportHooks, report string, fn func(data T) (T, error)) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.postFetch[report] = append(h.postFetch[report], func(data interface{}) (interface{}, error) {
+		typed, ok := data.(T)
+		if !ok {
+			var want T
+			return data, fmt.Errorf("fetch hook for %s expects %T, report fetched %T", report, want, data)
+		}
+		return fn(typed)
+	})
+}
+
+// OnExport registers a transformation of a report's rows, run before they are written
+func (h *ReportHooks) OnExport(report string, fn TableTransform) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.preExport[report] = append(h.preExport[report], fn)
+}
+
+// runPostFetch applies the fetch hooks of a report (then those of AllReports) to its data
+func runPostFetch[T any](h *ReportHooks, report string, data T) (T, error) {
+	if h == nil {
+		return data, nil
+	}
+
+	h.mutex.RLock()
+	hooks := append(append([]func(interface{}) (interface{}, error){}, h.postFetch[report]...), h.postFetch[AllReports]...)
+	h.mutex.RUnlock()
+
+	var current interface{} = data
+	for _, hook := range hooks {
+		next, err := hook(current)
+		if err != nil {
+			return data, err
+		}
+		current = next
+	}
+
+	return current.(T), nil
+}
+
+// runPreExport applies the export hooks of a report (then those of AllReports) to its rows
+func (h *ReportHooks) runPreExport(report string, table [][]string) ([][]string, error) {
+	if h == nil {
+		return table, nil
+	}
+
+	h.mutex.RLock()
+	hooks := append(append([]TableTransform{}, h.preExport[report]...), h.preExport[AllReports]...)
+	h.mutex.RUnlock()
+
+	for _, hook := range hooks {
+		next, err := hook(table)
+		if err != nil {
+			return table, err
+		}
+		if len(next) == 0 {
+			return table, fmt.Errorf("export hook for %s removed the header row", report)
+		}
+		table = next
+	}
+
+	return table, nil
+}
+
+// FilterRows keeps the rows for which `keep` returns true; rows are passed as a header -> value map
+func FilterRows(keep func(row map[string]string) bool) TableTransform {
+	return func(table [][]string) ([][]string, error) {
+		if len(table) == 0 {
+			return table, nil
+		}
+
+		filtered := [][]string{table[0]}
+		for _, row := range table[1:] {
+			if keep(rowMap(table[0], row)) {
+				filtered = append(filtered, row)
+			}
+		}
+		return filtered, nil
+	}
+}
+
+// AddColumn appends a computed column; rows are passed as a header -> value map
+func AddColumn(header string, compute func(row map[string]string) string) TableTransform {
+	return func(table [][]string) ([][]string, error) {
+		if len(table) == 0 {
+			return table, nil
+		}
+
+		out := make([][]string, 0, len(table))
+		out = append(out, append(append([]string{}, table[0]...), header))
+		for _, row := range table[1:] {
+			out = append(out, append(append([]string{}, row...), compute(rowMap(table[0], row))))
+		}
+		return out, nil
+	}
+}
+
+func rowMap(headers, row []string) map[string]string {
+	m := make(map[string]string, len(headers))
+	for i, header := range headers {
+		if i < len(row) {
+			m[header] = row[i]
+		}
+	}
+	return m
+}
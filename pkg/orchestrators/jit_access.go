@@ -0,0 +1,498 @@
+/*
+# Orchestrators - Just-in-Time Access
+
+This package contains the just-in-time access grants: a Google admin role or group membership, or an Okta application
+or group assignment, granted for a limited time and revoked automatically once it lapses.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/jit_access.go
+package orchestrators
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/notify"
+	"github.com/gemini-oss/rego/pkg/common/requests"
+	"github.com/gemini-oss/rego/pkg/google"
+)
+
+// Kinds of access granted just in time
+const (
+	JITGoogleRole  = "google-role"  // Google admin role, by ID or name
+	JITGoogleGroup = "google-group" // Membership of a Google group, by email
+	JITOktaApp     = "okta-app"     // Assignment to an Okta application, by ID
+	JITOktaGroup   = "okta-group"   // Membership of an Okta group, by ID
+)
+
+// JITAccessOptions configures the just-in-time access grants
+type JITAccessOptions struct {
+	DefaultDuration time.Duration    // Duration of the grants requested without one. Default: 4 hours
+	MaxDuration     time.Duration    // Longest duration a grant may be requested for. Default: 24 hours
+	AllowSuperAdmin bool             // Allow granting the Google super admin role
+	Customer        *google.Customer // Google customer of the role assignments. Default: my_customer
+	History         time.Duration    // Time revoked grants are kept in the state. Default: 90 days
+	Notifier        notify.Notifier  // Receives each grant and revocation; skipped when nil
+	StateFile       string           // JSON file keeping the grants across restarts; in memory only when empty
+}
+
+// JITAccessRequest asks for access to a target for a limited time
+type JITAccessRequest struct {
+	Kind      string        // JITGoogleRole, JITGoogleGroup, JITOktaApp or JITOktaGroup
+	Target    string        // Role ID or name, group email, or Okta application or group ID
+	User      string        // Email of the user to grant the access to
+	Duration  time.Duration // Default: opts.DefaultDuration
+	Reason    string        // Why the access is needed, e.g. a ticket
+	GrantedBy string        // Who approved the access
+}
+
+// JITGrant is a time-bound access grant
+type JITGrant struct {
+	ID           string    `json:"id" sheet:"ID"`
+	Kind         string    `json:"kind" sheet:"Kind"`
+	Target       string    `json:"target" sheet:"Target"`
+	User         string    `json:"user" sheet:"User"`
+	Reason       string    `json:"reason" sheet:"Reason"`
+	GrantedBy    string    `json:"grantedBy" sheet:"Granted By"`
+	Granted      time.Time `json:"granted" sheet:"Granted"`
+	Expires      time.Time `json:"expires" sheet:"Expires"`
+	Revoked      time.Time `json:"revoked,omitempty" sheet:"Revoked"`
+	LastError    string    `json:"lastError,omitempty" sheet:"Last Error"` // Error of the last failed revocation; retried on the next run
+	AssignmentID string    `json:"assignmentId,omitempty" sheet:"-"`       // Google role assignment, deleted on revocation
+}
+
+// Active reports whether the grant has not been revoked yet
+func (g *JITGrant) Active() bool {
+	return g.Revoked.IsZero()
+}
+
+// JITAccess grants time-bound access, and revokes it once it lapses
+type JITAccess struct {
+	client *Client
+	opts   *JITAccessOptions
+	grants []*JITGrant
+	mutex  sync.Mutex
+}
+
+// Entry point for the just-in-time access grants; the grants are loaded from opts.StateFile when it exists
+func (c *Client) JITAccess(opts *JITAccessOptions) (*JITAccess, error) {
+	if opts == nil {
+		opts = &JITAccessOptions{}
+	}
+	if opts.DefaultDuration == 0 {
+		opts.DefaultDuration = 4 * time.Hour
+	}
+	if opts.MaxDuration == 0 {
+		opts.MaxDuration = 24 * time.Hour
+	}
+	if opts.History == 0 {
+		opts.History = 90 * 24 * time.Hour
+	}
+
+	j := &JITAccess{
+		client: c,
+		opts:   opts,
+	}
+
+	if opts.StateFile != "" {
+		data, err := os.ReadFile(opts.StateFile)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			return nil, err
+		default:
+			if err := json.Unmarshal(data, &j.grants); err != nil {
+				return nil, fmt.Errorf("reading the JIT access grants %s: %w", opts.StateFile, err)
+			}
+		}
+	}
+
+	return j, nil
+}
+
+/*
+ * Orchestrate the following:
+ * Grant a user access to a target until the requested duration lapses
+ * Requesting an access the user already holds through an active grant extends that grant instead
+ * Access the user already holds outside of a grant is refused, so the revocation never removes standing access
+ */
+func (j *JITAccess) Grant(req *JITAccessRequest) (*JITGrant, error) {
+	c := j.client
+	if err := c.checkFlag(FlagJITAccess); err != nil {
+		return nil, err
+	}
+
+	if req.Kind == "" || req.Target == "" || req.User == "" {
+		return nil, fmt.Errorf("a JIT access request needs a kind, a target and a user")
+	}
+	duration := req.Duration
+	if duration == 0 {
+		duration = j.opts.DefaultDuration
+	}
+	if duration < 0 || duration > j.opts.MaxDuration {
+		return nil, fmt.Errorf("JIT access duration %s must be between 0 and %s", duration, j.opts.MaxDuration)
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	now := time.Now()
+	id := fmt.Sprintf("%s/%s/%s", req.Kind, strings.ToLower(req.Target), strings.ToLower(req.User))
+	for _, grant := range j.grants {
+		if grant.ID != id || !grant.Active() {
+			continue
+		}
+		if expires := now.Add(duration); expires.After(grant.Expires) {
+			grant.Expires = expires
+		}
+		c.Log.Printf("Extended JIT %s access of %s to %s until %s", grant.Kind, grant.User, grant.Target, grant.Expires.Format(time.RFC3339))
+		return grant, j.save()
+	}
+
+	grant := &JITGrant{
+		ID:        id,
+		Kind:      req.Kind,
+		Target:    req.Target,
+		User:      req.User,
+		Reason:    req.Reason,
+		GrantedBy: req.GrantedBy,
+		Granted:   now,
+		Expires:   now.Add(duration),
+	}
+	if err := j.apply(grant); err != nil {
+		return nil, fmt.Errorf("granting %s %s to %s: %w", grant.Kind, grant.Target, grant.User, err)
+	}
+
+	j.grants = append(j.grants, grant)
+	c.Log.Printf("Granted JIT %s access to %s for %s until %s", grant.Kind, grant.Target, grant.User, grant.Expires.Format(time.RFC3339))
+	j.notify(fmt.Sprintf("{JIT} Granted %s %s to %s until %s", grant.Kind, grant.Target, grant.User, grant.Expires.Format("2006-01-02 15:04 MST")), grant)
+
+	return grant, j.save()
+}
+
+// Revoke revokes an active grant before it lapses
+func (j *JITAccess) Revoke(id string) error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	for _, grant := range j.grants {
+		if grant.ID != id || !grant.Active() {
+			continue
+		}
+		err := j.revoke(grant, time.Now())
+		if saveErr := j.save(); err == nil {
+			err = saveErr
+		}
+		return err
+	}
+
+	return fmt.Errorf("no active JIT access grant %s", id)
+}
+
+/*
+ * Orchestrate the following:
+ * Revoke every grant which has lapsed; failed revocations stay active, and are retried on the next run
+ * Forget the grants revoked more than opts.History ago
+ */
+func (j *JITAccess) RevokeExpired() ([]*JITGrant, error) {
+	c := j.client
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	now := time.Now()
+	revoked := []*JITGrant{}
+	var errs []*SourceError
+	for _, grant := range j.grants {
+		if !grant.Active() || now.Before(grant.Expires) {
+			continue
+		}
+		if err := j.revoke(grant, now); err != nil {
+			errs = append(errs, &SourceError{Source: grant.ID, Err: err})
+			continue
+		}
+		revoked = append(revoked, grant)
+	}
+
+	kept := j.grants[:0]
+	for _, grant := range j.grants {
+		if grant.Active() || now.Sub(grant.Revoked) < j.opts.History {
+			kept = append(kept, grant)
+		}
+	}
+	j.grants = kept
+
+	if err := j.save(); err != nil {
+		errs = append(errs, &SourceError{Source: "State", Err: err})
+	}
+
+	if len(revoked) > 0 {
+		c.Log.Printf("Revoked %d lapsed JIT access grant(s)", len(revoked))
+	}
+	return complete(c, "JIT access revocation", revoked, errs)
+}
+
+/*
+ * Orchestrate the following:
+ * Revoke the lapsed grants immediately, then on every interval (e.g. every minute) until stopped
+ */
+func (j *JITAccess) Schedule(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := j.RevokeExpired(); err != nil {
+			j.client.Log.Error("Error revoking the lapsed JIT access grants:", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// ActiveGrants returns the grants not revoked yet, soonest to expire first
+func (j *JITAccess) ActiveGrants() []*JITGrant {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	active := []*JITGrant{}
+	for _, grant := range j.grants {
+		if grant.Active() {
+			active = append(active, grant)
+		}
+	}
+	sort.Slice(active, func(a, b int) bool {
+		return active[a].Expires.Before(active[b].Expires)
+	})
+
+	return active
+}
+
+/*
+ * Orchestrate the following:
+ * Generate a report of the active JIT access grants
+ * Save the report to a Google Sheet
+ * Format the sheet
+ */
+func (j *JITAccess) ActiveGrantsToGoogleSheet() (*google.Spreadsheet, error) {
+	c := j.client
+
+	grants, err := runPostFetch(c.Hooks, FlagJITAccess, j.ActiveGrants())
+	if err != nil {
+		return nil, err
+	}
+
+	sheet, err := c.Google.Sheets().CreateSpreadsheet(&google.Spreadsheet{
+		Properties: &google.SpreadsheetProperties{
+			Title: fmt.Sprintf("{JIT} Active Access Grants %s", time.Now().Format("2006-01-02")),
+		},
+		Sheets: []google.Sheet{
+			{
+				Properties: &google.SheetProperties{
+					Title: "Active Grants",
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := google.StructValues(grants, nil)
+	if err != nil {
+		return nil, err
+	}
+	values, err = c.Hooks.runPreExport(FlagJITAccess, values)
+	if err != nil {
+		return nil, err
+	}
+
+	vr := &google.ValueRange{
+		Range:          "Active Grants!A:Z",
+		MajorDimension: "ROWS",
+		Values:         values,
+	}
+	if err := c.Google.Sheets().UpdateSpreadsheet(sheet.SpreadsheetID, vr); err != nil {
+		return nil, err
+	}
+
+	if err := c.Google.Sheets().FormatHeaderAndAutoSize(sheet.SpreadsheetID, &sheet.Sheets[0], len(values), len(values[0])); err != nil {
+		return nil, err
+	}
+
+	c.Log.Println("JIT access report saved to Google Sheet.")
+	c.Log.Println("Spreadsheet URL: ", sheet.SpreadsheetURL)
+
+	return sheet, nil
+}
+
+// apply grants the access of a new grant, refusing access the user already holds
+func (j *JITAccess) apply(grant *JITGrant) error {
+	c := j.client
+
+	switch grant.Kind {
+	case JITGoogleRole:
+		role, err := j.googleRole(grant.Target)
+		if err != nil {
+			return err
+		}
+		user, err := c.Google.Users().GetUser(grant.User)
+		if err != nil {
+			return err
+		}
+		assignment, err := c.Google.Admin().CreateRoleAssignment(j.opts.Customer, &google.RoleAssignment{RoleId: role.RoleID, AssignedTo: user.ID, AssigneeType: "user"})
+		if requests.StatusCode(err) == http.StatusConflict {
+			return fmt.Errorf("%s already has the role %s", grant.User, role.RoleName)
+		}
+		if err != nil {
+			return err
+		}
+		grant.AssignmentID = assignment.RoleAssignmentId
+		return nil
+	case JITGoogleGroup:
+		_, err := c.Google.Groups().AddMember(grant.Target, &google.Member{Email: grant.User, Role: "MEMBER"})
+		if requests.StatusCode(err) == http.StatusConflict {
+			return fmt.Errorf("%s is already a member of %s", grant.User, grant.Target)
+		}
+		return err
+	case JITOktaApp:
+		user, err := c.Okta.GetUser(grant.User)
+		if err != nil {
+			return err
+		}
+		_, err = c.Okta.GetApplicationUser(grant.Target, user.ID)
+		switch {
+		case err == nil:
+			return fmt.Errorf("%s is already assigned to %s", grant.User, grant.Target)
+		case requests.StatusCode(err) != http.StatusNotFound:
+			return err
+		}
+		_, err = c.Okta.AssignUserToApplication(grant.Target, user.ID)
+		return err
+	case JITOktaGroup:
+		user, err := c.Okta.GetUser(grant.User)
+		if err != nil {
+			return err
+		}
+		groups, err := c.Okta.GetUserGroups(user.ID)
+		if err != nil {
+			return err
+		}
+		for _, group := range *groups {
+			if group.ID == grant.Target {
+				return fmt.Errorf("%s is already a member of %s", grant.User, group.Profile.Name)
+			}
+		}
+		return c.Okta.AddUserToGroup(grant.Target, user.ID)
+	default:
+		return fmt.Errorf("unknown JIT access kind %q", grant.Kind)
+	}
+}
+
+// revoke removes the access of a grant, and marks it revoked; access already removed by hand counts as revoked
+func (j *JITAccess) revoke(grant *JITGrant, now time.Time) error {
+	c := j.client
+
+	var err error
+	switch grant.Kind {
+	case JITGoogleRole:
+		err = c.Google.Admin().DeleteRoleAssignment(j.opts.Customer, grant.AssignmentID)
+	case JITGoogleGroup:
+		err = c.Google.Groups().RemoveMember(grant.Target, grant.User)
+	case JITOktaApp, JITOktaGroup:
+		user, lookupErr := c.Okta.GetUser(grant.User)
+		if lookupErr != nil {
+			err = lookupErr
+			break
+		}
+		if grant.Kind == JITOktaApp {
+			err = c.Okta.RemoveApplicationAssignment(grant.Target, user.ID)
+		} else {
+			err = c.Okta.RemoveUserFromGroup(grant.Target, user.ID)
+		}
+	default:
+		err = fmt.Errorf("unknown JIT access kind %q", grant.Kind)
+	}
+
+	if err != nil && requests.StatusCode(err) != http.StatusNotFound {
+		grant.LastError = err.Error()
+		c.Log.Errorf("Unable to revoke JIT %s access of %s to %s: %v", grant.Kind, grant.User, grant.Target, err)
+		return err
+	}
+
+	grant.Revoked, grant.LastError = now, ""
+	c.Log.Printf("Revoked JIT %s access of %s to %s", grant.Kind, grant.User, grant.Target)
+	j.notify(fmt.Sprintf("{JIT} Revoked %s %s from %s", grant.Kind, grant.Target, grant.User), grant)
+	return nil
+}
+
+// googleRole finds a Google admin role by ID or name, refusing the super admin role unless allowed
+func (j *JITAccess) googleRole(target string) (*google.Role, error) {
+	roles, err := j.client.Google.Admin().ListAllRoles(j.opts.Customer)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, role := range roles.Items {
+		if role.RoleID != target && !strings.EqualFold(role.RoleName, target) {
+			continue
+		}
+		if role.IsSuperAdminRole && !j.opts.AllowSuperAdmin {
+			return nil, fmt.Errorf("granting the super admin role %s is not allowed", role.RoleName)
+		}
+		return &role, nil
+	}
+
+	return nil, fmt.Errorf("no Google admin role %q", target)
+}
+
+// notify sends a grant or revocation to opts.Notifier, logging failures
+func (j *JITAccess) notify(title string, grant *JITGrant) {
+	if j.opts.Notifier == nil {
+		return
+	}
+
+	err := j.opts.Notifier.Notify(&notify.Message{
+		Severity: notify.Info,
+		Title:    title,
+		Body:     fmt.Sprintf("Reason: %s\nGranted by: %s", grant.Reason, grant.GrantedBy),
+		Fields: map[string]string{
+			"kind":    grant.Kind,
+			"target":  grant.Target,
+			"user":    grant.User,
+			"granted": grant.Granted.Format(time.RFC3339),
+			"expires": grant.Expires.Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		j.client.Log.Warningf("Unable to send the JIT access notification %q: %v", title, err)
+	}
+}
+
+// save writes the grants to opts.StateFile, if set
+func (j *JITAccess) save() error {
+	if j.opts.StateFile == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(j.grants, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(j.opts.StateFile, data, 0o600)
+}
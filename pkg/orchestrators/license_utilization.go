@@ -0,0 +1,367 @@
+/*
+# Orchestrators - License Utilization
+
+This package contains an orchestration aggregating license counts and usage across Google Workspace, Okta, Jamf (VPP) and Snipe-IT,
+highlighting reclaimable seats and their estimated monthly savings.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/license_utilization.go
+package orchestrators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/google"
+)
+
+// LicenseUtilizationOptions configures which licenses are reported and how seats are priced
+type LicenseUtilizationOptions struct {
+	GoogleSKUs   map[string][]string // Google product ID -> SKU IDs, e.g. {"Google-Apps": {"1010020027"}}
+	OktaApps     []string            // Okta application IDs; all active applications when empty
+	InactiveDays int                 // Assigned users without a login in this many days are reclaimable. Default: 30
+	SeatCosts    map[string]float64  // Monthly cost per seat, keyed by "Provider/Product" (e.g. "Okta/Zoom", "Jamf/Keynote")
+}
+
+// LicenseUtilization is a single product's license usage
+type LicenseUtilization struct {
+	Provider       string  // Source system of the license
+	Product        string  // Product, SKU or application name
+	Total          int     // Purchased seats (assigned seats where the provider does not expose a purchased count)
+	Assigned       int     // Seats assigned to users or devices
+	Reclaimable    int     // Seats which are unassigned, or assigned to suspended/inactive users
+	SeatCost       float64 // Monthly cost per seat
+	MonthlySavings float64 // Estimated monthly savings from reclaiming seats
+}
+
+/*
+ * Orchestrate the following:
+ * Collect license usage from every configured provider
+ * Flag unassigned seats and seats held by suspended or inactive users as reclaimable
+ * Price reclaimable seats from the configured seat costs, sorted by savings
+ */
+func (c *Client) LicenseUtilization(opts *LicenseUtilizationOptions) ([]*LicenseUtilization, error) {
+	if opts == nil {
+		opts = &LicenseUtilizationOptions{}
+	}
+	if opts.InactiveDays == 0 {
+		opts.InactiveDays = 30
+	}
+	cutoff := time.Now().AddDate(0, 0, -opts.InactiveDays)
+
+	report := []*LicenseUtilization{}
+	var errs []*SourceError
+
+	if c.Google != nil && len(opts.GoogleSKUs) > 0 {
+		rows, err := c.googleLicenseUtilization(opts.GoogleSKUs, cutoff)
+		if err != nil {
+			errs = append(errs, &SourceError{Source: "Google", Err: err})
+		}
+		report = append(report, rows...)
+	}
+
+	if c.Okta != nil {
+		rows, err := c.oktaLicenseUtilization(opts.OktaApps, cutoff)
+		if err != nil {
+			errs = append(errs, &SourceError{Source: "Okta", Err: err})
+		}
+		report = append(report, rows...)
+	}
+
+	if c.Jamf != nil {
+		rows, err := c.jamfLicenseUtilization()
+		if err != nil {
+			errs = append(errs, &SourceError{Source: "Jamf", Err: err})
+		}
+		report = append(report, rows...)
+	}
+
+	if c.SnipeIT != nil {
+		rows, err := c.snipeITLicenseUtilization()
+		if err != nil {
+			errs = append(errs, &SourceError{Source: "SnipeIT", Err: err})
+		}
+		report = append(report, rows...)
+	}
+
+	for _, row := range report {
+		row.SeatCost = opts.SeatCosts[row.Provider+"/"+row.Product]
+		row.MonthlySavings = float64(row.Reclaimable) * row.SeatCost
+	}
+
+	sort.SliceStable(report, func(i, j int) bool {
+		if report[i].MonthlySavings != report[j].MonthlySavings {
+			return report[i].MonthlySavings > report[j].MonthlySavings
+		}
+		return report[i].Reclaimable > report[j].Reclaimable
+	})
+
+	return complete(c, "license utilization", report, errs)
+}
+
+// googleLicenseUtilization reports each SKU's assignments, reclaiming seats held by suspended, deleted or inactive users
+func (c *Client) googleLicenseUtilization(skus map[string][]string, cutoff time.Time) ([]*LicenseUtilization, error) {
+	customer, err := c.Google.Admin().MyCustomer()
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := c.Google.Users().ListAllUsers()
+	if err != nil {
+		return nil, err
+	}
+	directory := make(map[string]*google.User, len(users.Users))
+	for _, user := range users.Users {
+		directory[strings.ToLower(user.PrimaryEmail)] = user
+	}
+
+	rows := []*LicenseUtilization{}
+	for productID, skuIDs := range skus {
+		for _, skuID := range skuIDs {
+			assignments, err := c.Google.Licensing().ListAllLicenseAssignments(customer, productID, skuID)
+			if err != nil {
+				return rows, err
+			}
+
+			row := &LicenseUtilization{
+				Provider: "Google",
+				Product:  skuID,
+				Total:    len(assignments.Items),
+				Assigned: len(assignments.Items),
+			}
+			for _, assignment := range assignments.Items {
+				if assignment.SkuName != "" {
+					row.Product = assignment.SkuName
+				}
+
+				user, ok := directory[strings.ToLower(assignment.UserID)]
+				if !ok || user.Suspended {
+					row.Reclaimable++
+					continue
+				}
+				if lastLogin, err := time.Parse(time.RFC3339, user.LastLoginTime); err != nil || lastLogin.Before(cutoff) {
+					row.Reclaimable++
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, nil
+}
+
+// oktaLicenseUtilization reports each application's assignments, reclaiming seats held by inactive or deactivated users
+func (c *Client) oktaLicenseUtilization(appIDs []string, cutoff time.Time) ([]*LicenseUtilization, error) {
+	apps, err := c.Okta.ListAllApplications()
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(appIDs))
+	for _, id := range appIDs {
+		wanted[id] = true
+	}
+
+	users, err := c.Okta.ListAllUsers()
+	if err != nil {
+		return nil, err
+	}
+	status := make(map[string]string, len(*users))
+	lastLogin := make(map[string]time.Time, len(*users))
+	for _, user := range *users {
+		status[user.ID] = user.Status
+		lastLogin[user.ID] = user.LastLogin
+	}
+
+	rows := []*LicenseUtilization{}
+	for _, app := range *apps {
+		if len(wanted) > 0 && !wanted[app.ID] {
+			continue
+		}
+		if len(wanted) == 0 && app.Status != "ACTIVE" {
+			continue
+		}
+
+		appUsers, err := c.Okta.ListAllApplicationUsers(app.ID)
+		if err != nil {
+			return rows, err
+		}
+
+		row := &LicenseUtilization{
+			Provider: "Okta",
+			Product:  app.Label,
+			Total:    len(*appUsers),
+			Assigned: len(*appUsers),
+		}
+		for _, appUser := range *appUsers {
+			if status[appUser.ID] != "ACTIVE" || lastLogin[appUser.ID].Before(cutoff) {
+				row.Reclaimable++
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// jamfLicenseUtilization reports the purchased and in-use licenses of each VPP content item
+func (c *Client) jamfLicenseUtilization() ([]*LicenseUtilization, error) {
+	locations, err := c.Jamf.ListAllVolumePurchasingLocations()
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []*LicenseUtilization{}
+	if locations.Results == nil {
+		return rows, nil
+	}
+
+	for _, location := range *locations.Results {
+		content, err := c.Jamf.ListAllVolumePurchasingContent(location.ID)
+		if err != nil {
+			return rows, err
+		}
+		if content.Results == nil {
+			continue
+		}
+
+		for _, item := range *content.Results {
+			if item.LicenseCountTotal == 0 {
+				continue
+			}
+			rows = append(rows, &LicenseUtilization{
+				Provider:    "Jamf",
+				Product:     item.Name,
+				Total:       item.LicenseCountTotal,
+				Assigned:    item.LicenseCountInUse,
+				Reclaimable: max(item.LicenseCountTotal-item.LicenseCountInUse, 0),
+			})
+		}
+	}
+
+	return rows, nil
+}
+
+// snipeITLicenseUtilization reports the seats and free seats of each Snipe-IT license
+func (c *Client) snipeITLicenseUtilization() ([]*LicenseUtilization, error) {
+	licenses, err := c.SnipeIT.Licenses().GetAllLicenses()
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []*LicenseUtilization{}
+	if licenses.Rows == nil {
+		return rows, nil
+	}
+
+	for _, license := range *licenses.Rows {
+		rows = append(rows, &LicenseUtilization{
+			Provider:    "SnipeIT",
+			Product:     license.Name,
+			Total:       license.Seats,
+			Assigned:    license.Seats - license.FreeSeatsCount,
+			Reclaimable: license.FreeSeatsCount,
+		})
+	}
+
+	return rows, nil
+}
+
+/*
+ * Orchestrate the following:
+ * Generate the cross-provider license utilization report
+ * Save the report to a Google Sheet
+ * Format the sheet
+ */
+func (c *Client) LicenseUtilizationToGoogleSheet(opts *LicenseUtilizationOptions) error {
+	if err := c.checkFlag(FlagLicenseUtilization); err != nil {
+		return err
+	}
+
+	report, err := c.LicenseUtilization(opts)
+	partial, isPartial := AsPartial(err)
+	if err != nil && !isPartial {
+		return err
+	}
+
+	report, err = runPostFetch(c.Hooks, FlagLicenseUtilization, report)
+	if err != nil {
+		return err
+	}
+
+	newSpreadsheet := &google.Spreadsheet{
+		Properties: &google.SpreadsheetProperties{
+			Title: fmt.Sprintf("License Utilization %s", time.Now().Format("2006-01-02")),
+		},
+		Sheets: []google.Sheet{
+			{
+				Properties: &google.SheetProperties{
+					Title: "Utilization",
+				},
+			},
+		},
+	}
+	sheet, err := c.Google.Sheets().CreateSpreadsheet(newSpreadsheet)
+	if err != nil {
+		return err
+	}
+
+	vr := &google.ValueRange{
+		Range:          "A:Z",
+		MajorDimension: "ROWS",
+	}
+	headers := []string{"Provider", "Product", "Total", "Assigned", "Reclaimable", "Utilization", "Seat Cost", "Monthly Savings"}
+	vr.Values = append(vr.Values, headers)
+
+	savings := 0.0
+	for _, row := range report {
+		utilization := "-"
+		if row.Total > 0 {
+			utilization = fmt.Sprintf("%.1f%%", float64(row.Total-row.Reclaimable)/float64(row.Total)*100)
+		}
+		vr.Values = append(vr.Values, []string{
+			row.Provider,
+			row.Product,
+			fmt.Sprint(row.Total),
+			fmt.Sprint(row.Assigned),
+			fmt.Sprint(row.Reclaimable),
+			utilization,
+			fmt.Sprintf("%.2f", row.SeatCost),
+			fmt.Sprintf("%.2f", row.MonthlySavings),
+		})
+		savings += row.MonthlySavings
+	}
+	vr.Values = append(vr.Values, []string{"Total", "", "", "", "", "", "", fmt.Sprintf("%.2f", savings)})
+
+	vr.Values, err = c.Hooks.runPreExport(FlagLicenseUtilization, vr.Values)
+	if err != nil {
+		return err
+	}
+	if isPartial {
+		vr.Values = append(append(vr.Values, []string{}), partial.Summary()...)
+	}
+
+	rows := len(vr.Values)
+	columns := len(vr.Values[0])
+
+	err = c.Google.Sheets().UpdateSpreadsheet(sheet.SpreadsheetID, vr)
+	if err != nil {
+		return err
+	}
+
+	err = c.Google.Sheets().FormatHeaderAndAutoSize(sheet.SpreadsheetID, &sheet.Sheets[0], rows, columns)
+	if err != nil {
+		return err
+	}
+
+	c.Log.Println("License utilization report saved to Google Sheet.")
+	c.Log.Println("Spreadsheet URL: ", sheet.SpreadsheetURL)
+
+	return nil
+}
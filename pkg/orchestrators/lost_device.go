@@ -0,0 +1,360 @@
+/*
+# Orchestrators - Lost Device
+
+This package contains the lost/stolen device action, securing a device and its credentials across services
+and recording how to undo each step once the device is recovered.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/lost_device.go
+package orchestrators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/notify"
+	"github.com/gemini-oss/rego/pkg/google"
+	"github.com/gemini-oss/rego/pkg/jamf"
+	"github.com/gemini-oss/rego/pkg/okta"
+	"github.com/gemini-oss/rego/pkg/snipeit"
+)
+
+// LostDeviceOptions configures LostDevice
+type LostDeviceOptions struct {
+	Wipe         bool                            // Send ERASE_DEVICE instead of DEVICE_LOCK
+	PIN          string                          // Six digit PIN to unlock the computer. Default: randomly generated, and returned in the PIN of the step
+	Message      string                          // Message shown on the lock screen
+	StolenStatus string                          // Snipe-IT status label of the asset. Default: Stolen
+	Ticket       string                          // Incident reference, recorded in the asset notes and the notification
+	Confirm      func(step *LostDeviceStep) bool // Asked before each step runs; returning false skips it. Default: every step runs
+	Notifier     notify.Notifier                 // Security channel(s) to notify; the step is skipped when nil
+}
+
+// LostDeviceStep is a single action of LostDevice
+type LostDeviceStep struct {
+	Service  string        // Service the action runs against
+	Action   string        // What the step does (or did)
+	Detail   string        // Additional detail, e.g. the device ID or the asset tag
+	PIN      string        // Unlock PIN of a locked or wiped computer; returned to the caller, never sent to the notifier
+	Rollback string        // How to undo the step once the device is recovered
+	Skipped  bool          // The step was declined, or does not apply
+	Err      error         // Set when the step failed
+	Duration time.Duration // Time taken
+}
+
+/*
+ * Orchestrate the following for a lost or stolen device, in order:
+ * Lock (or wipe) the computer in Jamf
+ * Disable the Chromebook in Google, annotating it with the incident ticket
+ * List the certificates installed on the computer, to be revoked with their issuing CA (no CA integration is available)
+ * Mark the asset stolen in Snipe-IT
+ * Suspend the device in Okta, so it no longer satisfies device trust
+ * Notify security with the outcome of every step
+ * Each step is confirmed through opts.Confirm; a failing step does not stop the next ones
+ * Steps for a service which does not manage the device (e.g. Jamf for a Chromebook) are marked skipped
+ */
+func (c *Client) LostDevice(serial string, opts *LostDeviceOptions) ([]*LostDeviceStep, error) {
+	if opts == nil {
+		opts = &LostDeviceOptions{}
+	}
+	if opts.StolenStatus == "" {
+		opts.StolenStatus = "Stolen"
+	}
+	serial = strings.TrimSpace(serial)
+
+	c.Log.Warningf("Securing lost device %s", serial)
+
+	type action struct {
+		step *LostDeviceStep
+		run  func(step *LostDeviceStep) error
+	}
+	actions := []action{}
+
+	var computer *jamf.Computer
+	if c.Jamf != nil {
+		verb := "lock"
+		if opts.Wipe {
+			verb = "wipe"
+		}
+		actions = append(actions,
+			action{&LostDeviceStep{Service: "Jamf", Action: verb + " computer"}, func(step *LostDeviceStep) error {
+				var err error
+				if computer, err = c.jamfComputerBySerial(serial); err != nil {
+					return err
+				}
+				return c.lockLostComputer(computer, opts, step)
+			}},
+			action{&LostDeviceStep{Service: "Jamf", Action: "list certificates to revoke"}, func(step *LostDeviceStep) error {
+				if computer == nil {
+					var err error
+					if computer, err = c.jamfComputerBySerial(serial); err != nil {
+						return err
+					}
+				}
+				step.Detail = installedCertificates(computer)
+				step.Rollback = "Reissue the revoked certificates once the device is recovered"
+				return nil
+			}},
+		)
+	}
+	if c.Google != nil {
+		actions = append(actions, action{&LostDeviceStep{Service: "Google", Action: "disable ChromeOS device"}, func(step *LostDeviceStep) error {
+			return c.disableLostChromebook(serial, opts, step)
+		}})
+	}
+	if c.SnipeIT != nil {
+		actions = append(actions, action{&LostDeviceStep{Service: "SnipeIT", Action: "mark asset " + strings.ToLower(opts.StolenStatus)}, func(step *LostDeviceStep) error {
+			return c.markAssetStolen(serial, opts, step)
+		}})
+	}
+	if c.Okta != nil {
+		actions = append(actions, action{&LostDeviceStep{Service: "Okta", Action: "suspend device"}, func(step *LostDeviceStep) error {
+			// Devices are only available on Identity Engine orgs
+			if !c.Okta.Supports(okta.OktaDevices) {
+				return fmt.Errorf("devices are %w: %w", okta.ErrUnsupported, errDeviceNotManaged)
+			}
+			device, err := c.Okta.GetDeviceBySerial(serial)
+			if errors.Is(err, okta.ErrDeviceNotFound) {
+				return fmt.Errorf("%w: %w", err, errDeviceNotManaged)
+			}
+			if err != nil {
+				return err
+			}
+			if err := c.Okta.SuspendDevice(device.ID); err != nil {
+				return err
+			}
+			step.Detail = "device " + device.ID
+			step.Rollback = fmt.Sprintf("Unsuspend Okta device %s (Okta.UnsuspendDevice)", device.ID)
+			return nil
+		}})
+	}
+
+	steps := make([]*LostDeviceStep, 0, len(actions)+1)
+	var errs []error
+	for _, a := range actions {
+		steps = append(steps, a.step)
+		if opts.Confirm != nil && !opts.Confirm(a.step) {
+			a.step.Skipped = true
+			c.Log.Printf("Lost device %s: skipped %s in %s", serial, a.step.Action, a.step.Service)
+			continue
+		}
+
+		start := time.Now()
+		a.step.Err = a.run(a.step)
+		a.step.Duration = time.Since(start)
+		if errors.Is(a.step.Err, errDeviceNotManaged) {
+			a.step.Skipped, a.step.Detail, a.step.Err = true, a.step.Err.Error(), nil
+			continue
+		}
+		if a.step.Err != nil {
+			c.Log.Errorf("Lost device %s: %s in %s failed: %v", serial, a.step.Action, a.step.Service, a.step.Err)
+			errs = append(errs, fmt.Errorf("%s: %s: %w", a.step.Service, a.step.Action, a.step.Err))
+			continue
+		}
+		c.Log.Printf("Lost device %s: %s in %s (%s)", serial, a.step.Action, a.step.Service, a.step.Duration)
+	}
+
+	notification := &LostDeviceStep{Service: "Notify", Action: "notify security"}
+	steps = append(steps, notification)
+	switch {
+	case opts.Notifier == nil:
+		notification.Skipped, notification.Detail = true, "no notifier configured"
+	case opts.Confirm != nil && !opts.Confirm(notification):
+		notification.Skipped = true
+	default:
+		notification.Err = opts.Notifier.Notify(lostDeviceMessage(serial, opts.Ticket, steps))
+		if notification.Err != nil {
+			errs = append(errs, fmt.Errorf("notify: %w", notification.Err))
+		}
+	}
+
+	return steps, errors.Join(errs...)
+}
+
+// errDeviceNotManaged marks a device unknown to a service; its steps are skipped rather than failed
+var errDeviceNotManaged = errors.New("device not managed by this service")
+
+// jamfComputerBySerial finds the Jamf computer with the given serial number
+func (c *Client) jamfComputerBySerial(serial string) (*jamf.Computer, error) {
+	sections := []string{jamf.Section.General, jamf.Section.Hardware, jamf.Section.Certificates}
+	computers, err := c.Jamf.Devices().Sections(sections).ListAllComputers()
+	if err != nil {
+		return nil, err
+	}
+
+	if computers.Results != nil {
+		for _, computer := range *computers.Results {
+			if computer.Hardware != nil && strings.EqualFold(computer.Hardware.SerialNumber, serial) {
+				return computer, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no Jamf computer with serial number %s: %w", serial, errDeviceNotManaged)
+}
+
+// disableLostChromebook disables the ChromeOS device and records the ticket in its notes
+func (c *Client) disableLostChromebook(serial string, opts *LostDeviceOptions, step *LostDeviceStep) error {
+	devices := c.Google.Devices()
+
+	device, err := devices.FindChromeOSBySerial(nil, serial)
+	if err != nil {
+		return err
+	}
+	if device == nil {
+		return fmt.Errorf("no ChromeOS device with serial number %s: %w", serial, errDeviceNotManaged)
+	}
+
+	if err := devices.ChromeOSAction(nil, device.DeviceId, "disable", ""); err != nil {
+		return err
+	}
+	step.Detail = "device " + device.DeviceId
+	step.Rollback = fmt.Sprintf("Re-enable ChromeOS device %s (action `reenable`)", device.DeviceId)
+
+	if opts.Ticket != "" {
+		note := fmt.Sprintf("Disabled as lost/stolen on %s (%s)", time.Now().Format(time.DateOnly), opts.Ticket)
+		if device.Notes != "" {
+			note = device.Notes + "\n" + note
+		}
+		if _, err := devices.AnnotateChromeOS(nil, device.DeviceId, &google.ChromeOSDevice{Notes: note}); err != nil {
+			return fmt.Errorf("disabled, but annotating the ticket failed: %w", err)
+		}
+		step.Detail += ", annotated with " + opts.Ticket
+	}
+
+	return nil
+}
+
+// lockLostComputer locks or wipes the computer
+func (c *Client) lockLostComputer(computer *jamf.Computer, opts *LostDeviceOptions, step *LostDeviceStep) error {
+	if computer.General == nil {
+		return fmt.Errorf("computer %v has no management ID", computer.ID)
+	}
+
+	pin := opts.PIN
+	if pin == "" {
+		var err error
+		if pin, err = randomPIN(); err != nil {
+			return err
+		}
+	}
+
+	ids := []string{computer.General.ManagementID}
+	if opts.Wipe {
+		if _, err := c.Jamf.WipeDevices(ids, pin); err != nil {
+			return err
+		}
+		step.Detail, step.PIN = computer.General.Name+" wiped, PIN withheld", pin
+		step.Rollback = "A wipe cannot be undone; unlock the recovered computer with the PIN and restore it from backup"
+		return nil
+	}
+
+	if _, err := c.Jamf.LockDevices(ids, pin, opts.Message); err != nil {
+		return err
+	}
+	step.Detail, step.PIN = computer.General.Name+" locked, PIN withheld", pin
+	step.Rollback = "Unlock the recovered computer with the PIN"
+	return nil
+}
+
+// installedCertificates lists the identity certificates of a computer, falling back to all certificates
+func installedCertificates(computer *jamf.Computer) string {
+	if computer.Certificates == nil || len(*computer.Certificates) == 0 {
+		return "no certificates reported"
+	}
+
+	identities, all := []string{}, []string{}
+	for _, cert := range *computer.Certificates {
+		description := fmt.Sprintf("%s (serial %s)", cert.CommonName, cert.SerialNumber)
+		all = append(all, description)
+		if cert.Identity {
+			identities = append(identities, description)
+		}
+	}
+	if len(identities) > 0 {
+		return "revoke: " + strings.Join(identities, ", ")
+	}
+	return "revoke: " + strings.Join(all, ", ")
+}
+
+// markAssetStolen sets the stolen status label on the asset, keeping its previous status for the rollback
+func (c *Client) markAssetStolen(serial string, opts *LostDeviceOptions, step *LostDeviceStep) error {
+	assets, err := c.SnipeIT.Assets().GetAssetBySerial(serial)
+	if err != nil {
+		return err
+	}
+	if assets.Rows == nil || len(*assets.Rows) == 0 {
+		return fmt.Errorf("no Snipe-IT asset with serial number %s: %w", serial, errDeviceNotManaged)
+	}
+	asset := (*assets.Rows)[0]
+
+	label, err := c.SnipeIT.StatusLabels().FindStatusLabel(opts.StolenStatus)
+	if err != nil {
+		return err
+	}
+
+	note := fmt.Sprintf("Reported %s on %s", strings.ToLower(opts.StolenStatus), time.Now().Format(time.DateOnly))
+	if opts.Ticket != "" {
+		note += " (" + opts.Ticket + ")"
+	}
+	notes := note
+	if asset.Notes != "" {
+		notes = asset.Notes + "\n" + note
+	}
+
+	if _, err := c.SnipeIT.Assets().PartialUpdateAsset(asset.ID, &snipeit.Hardware{StatusID: label.ID, Notes: notes}); err != nil {
+		return err
+	}
+
+	previous := "its previous status"
+	if asset.StatusLabel != nil {
+		previous = asset.StatusLabel.Name
+	}
+	step.Detail = fmt.Sprintf("asset %s", asset.AssetTag)
+	step.Rollback = fmt.Sprintf("Set the status of asset %s back to %s", asset.AssetTag, previous)
+	return nil
+}
+
+// lostDeviceMessage summarizes the steps for security, leaving out the unlock PIN
+func lostDeviceMessage(serial, ticket string, steps []*LostDeviceStep) *notify.Message {
+	message := &notify.Message{
+		Severity: notify.Critical,
+		Title:    fmt.Sprintf("Lost device %s secured", serial),
+		Fields:   map[string]string{"serial": serial},
+		DedupKey: "lost-device-" + serial,
+	}
+	if ticket != "" {
+		message.Fields["ticket"] = ticket
+	}
+
+	lines := []string{}
+	for _, step := range steps {
+		if step.Service == "Notify" {
+			continue
+		}
+		status := "done"
+		switch {
+		case step.Skipped:
+			status = "skipped"
+		case step.Err != nil:
+			status = "FAILED: " + step.Err.Error()
+		}
+		line := fmt.Sprintf("- %s: %s: %s", step.Service, step.Action, status)
+		if step.Detail != "" {
+			line += " (" + step.Detail + ")"
+		}
+		if step.Rollback != "" && !step.Skipped && step.Err == nil {
+			line += "\n  rollback: " + step.Rollback
+		}
+		lines = append(lines, line)
+	}
+	message.Body = strings.Join(lines, "\n")
+
+	return message
+}
@@ -0,0 +1,304 @@
+/*
+# Orchestrators - Okta Org to Org Push
+
+This package contains a push of selected users and groups from a hub Okta org to the spoke org of a subsidiary
+(hub and spoke model), mapping profile attributes and resolving users which already exist in the spoke.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/okta_push.go
+package orchestrators
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gemini-oss/rego/pkg/okta"
+)
+
+// How OktaOrgPush resolves a spoke user which has the login of a pushed user, but was not pushed from it
+const (
+	OktaPushOverwrite = "overwrite" // Update the spoke user from the hub, and link it to the hub user
+	OktaPushSkip      = "skip"      // Leave the spoke user untouched
+	OktaPushFail      = "fail"      // Report the user as failed
+)
+
+// Outcomes of OktaOrgPush for a user
+const (
+	OktaPushCreated  = "created"
+	OktaPushUpdated  = "updated"
+	OktaPushSkipped  = "skipped"
+	OktaPushConflict = "conflict"
+)
+
+// OktaPushOptions configures a push from a hub org to a spoke org
+type OktaPushOptions struct {
+	Users      []string                                        // Logins (or IDs) of the hub users to push
+	Groups     []string                                        // Names of the hub groups to push; their members are pushed, and added to a spoke group of the same name
+	Attributes map[string]string                               // Profile attributes to copy, hub name -> spoke name. Default: every base profile attribute, unchanged
+	SourceID   string                                          // Spoke profile attribute recording the ID of the hub user, linking the two; no linking when empty
+	Conflict   string                                          // Resolution of a spoke user with the same login but another (or no) hub ID. Default: OktaPushSkip
+	Transform  func(hub *okta.User, profile *okta.UserProfile) // Adjusts the spoke profile before it is written, e.g. to rewrite the login domain
+	Activate   bool                                            // Activate the created users, sending their activation email
+	DryRun     bool                                            // Only report what would be pushed
+}
+
+// OktaPushResult is the outcome of pushing a single hub user
+type OktaPushResult struct {
+	Login   string   // Login of the user in the spoke org
+	HubID   string   // ID of the user in the hub org
+	SpokeID string   // ID of the user in the spoke org, once it exists
+	Action  string   // OktaPushCreated, OktaPushUpdated, OktaPushSkipped or OktaPushConflict
+	Groups  []string // Spoke groups the user was added to
+	Detail  string   // Additional detail, e.g. how a conflict was resolved
+	Applied bool     // The change was made in the spoke org (false under DryRun, or with the flag switched off)
+}
+
+// Base profile attributes, copied by default (emailAliases is a custom property in most orgs)
+var oktaPushAttributes = []string{
+	"city", "costCenter", "countryCode", "department", "displayName", "division", "email", "employeeNumber", "firstName",
+	"honorificPrefix", "honorificSuffix", "lastName", "locale", "login", "manager", "managerId", "middleName", "mobilePhone",
+	"nickName", "organization", "postalAddress", "preferredLanguage", "primaryPhone", "profileUrl", "secondEmail", "state",
+	"streetAddress", "timezone", "title", "userType", "zipCode",
+}
+
+/*
+ * Orchestrate the following, with the receiver as the hub org:
+ * Resolve the selected users, and the members of the selected groups, in the hub org
+ * Create the groups missing from the spoke org
+ * Create (or update) each user in the spoke org with the mapped profile; a spoke user with the same login which is not
+ * linked to the hub user (see SourceID) is a conflict, resolved with opts.Conflict
+ * Add the users to the spoke groups matching their pushed hub groups; memberships are only ever added
+ * Changes are only reported under DryRun, or with the okta-org-push flag switched off
+ *
+ *	spoke, _ := registry.Get("subsidiary")
+ *	results, err := hub.OktaOrgPush(spoke.Okta, &OktaPushOptions{Groups: []string{"Engineering"}, SourceID: "hubUserId"})
+ */
+func (c *Client) OktaOrgPush(spoke *okta.Client, opts *OktaPushOptions) ([]*OktaPushResult, error) {
+	if opts == nil {
+		opts = &OktaPushOptions{}
+	}
+	if opts.Conflict == "" {
+		opts.Conflict = OktaPushSkip
+	}
+	dryRun := opts.DryRun
+	if !dryRun && c.checkFlag(FlagOktaOrgPush) != nil {
+		dryRun = true
+	}
+
+	users := map[string]*okta.User{}    // Hub users by ID
+	userGroups := map[string][]string{} // Hub user ID -> names of the pushed groups
+	var errs []*SourceError
+
+	for _, key := range opts.Users {
+		user, err := c.Okta.GetUser(key)
+		if err != nil {
+			errs = append(errs, &SourceError{Source: fmt.Sprintf("Okta hub (%s)", key), Err: err})
+			continue
+		}
+		users[user.ID] = user
+	}
+
+	spokeGroups := map[string]string{} // Group name -> spoke group ID
+	for _, name := range opts.Groups {
+		group, err := c.Okta.FindGroupByName(name)
+		if err == nil && group == nil {
+			err = fmt.Errorf("no group named %q", name)
+		}
+		if err != nil {
+			errs = append(errs, &SourceError{Source: fmt.Sprintf("Okta hub group (%s)", name), Err: err})
+			continue
+		}
+		members, err := c.Okta.ListGroupMembers(group.ID)
+		if err != nil {
+			errs = append(errs, &SourceError{Source: fmt.Sprintf("Okta hub group (%s)", name), Err: err})
+			continue
+		}
+		for _, member := range *members {
+			users[member.ID] = member
+			userGroups[member.ID] = append(userGroups[member.ID], name)
+		}
+
+		id, err := c.spokeGroup(spoke, group, dryRun)
+		if err != nil {
+			errs = append(errs, &SourceError{Source: fmt.Sprintf("Okta spoke group (%s)", name), Err: err})
+			continue
+		}
+		spokeGroups[name] = id
+	}
+
+	ids := make([]string, 0, len(users))
+	for id := range users {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	results := []*OktaPushResult{}
+	for _, id := range ids {
+		result, err := c.pushOktaUser(spoke, users[id], userGroups[id], spokeGroups, opts, dryRun)
+		if result != nil {
+			results = append(results, result)
+		}
+		if err != nil {
+			c.Log.Errorf("Unable to push Okta user %s: %v", users[id].Profile.Login, err)
+			errs = append(errs, &SourceError{Source: fmt.Sprintf("Okta spoke (%s)", users[id].Profile.Login), Err: err})
+		}
+	}
+
+	c.Log.Printf("Pushed %d Okta user(s) from %d group(s) to the spoke org", len(results), len(opts.Groups))
+	return complete(c, "okta org push", results, errs)
+}
+
+// spokeGroup returns the ID of the spoke group named like a hub group, creating it when missing
+func (c *Client) spokeGroup(spoke *okta.Client, hub *okta.Group, dryRun bool) (string, error) {
+	group, err := spoke.FindGroupByName(hub.Profile.Name)
+	if err != nil {
+		return "", err
+	}
+	if group != nil {
+		return group.ID, nil
+	}
+
+	if dryRun {
+		c.Log.Printf("[dry run] Would create the spoke group %q", hub.Profile.Name)
+		return "", nil
+	}
+	group, err = spoke.CreateGroup(hub.Profile)
+	if err != nil {
+		return "", err
+	}
+	c.Log.Printf("Created the spoke group %q (%s)", hub.Profile.Name, group.ID)
+	return group.ID, nil
+}
+
+// pushOktaUser creates or updates a single user in the spoke org, and adds it to its spoke groups
+func (c *Client) pushOktaUser(spoke *okta.Client, hub *okta.User, groups []string, spokeGroups map[string]string, opts *OktaPushOptions, dryRun bool) (*OktaPushResult, error) {
+	profile, err := oktaPushProfile(hub, opts)
+	if err != nil {
+		return nil, err
+	}
+	result := &OktaPushResult{Login: profile.Login, HubID: hub.ID}
+
+	existing, err := spoke.FindUserByLogin(profile.Login)
+	if err != nil {
+		return result, err
+	}
+
+	result.Action = OktaPushCreated
+	if existing != nil {
+		result.SpokeID = existing.ID
+		result.Action = OktaPushUpdated
+
+		linked := ""
+		if opts.SourceID != "" && existing.Profile != nil {
+			linked, _ = existing.Profile.CustomAttributes[opts.SourceID].(string)
+		}
+		if opts.SourceID != "" && linked != hub.ID {
+			result.Detail = fmt.Sprintf("spoke user %s is linked to %q, not hub user %s", existing.ID, linked, hub.ID)
+			switch opts.Conflict {
+			case OktaPushOverwrite:
+				result.Detail += "; overwritten"
+			case OktaPushFail:
+				result.Action = OktaPushConflict
+				return result, fmt.Errorf("%s", result.Detail)
+			default:
+				result.Action = OktaPushSkipped
+				c.Log.Warningf("Skipping Okta user %s: %s", result.Login, result.Detail)
+				return result, nil
+			}
+		}
+	}
+
+	if dryRun {
+		c.Log.Printf("[dry run] Okta user %s would be %s in the spoke org, in group(s) %v", result.Login, result.Action, groups)
+		result.Groups = groups
+		return result, nil
+	}
+
+	var groupIDs []string
+	for _, name := range groups {
+		if id := spokeGroups[name]; id != "" {
+			groupIDs = append(groupIDs, id)
+		}
+	}
+
+	if existing == nil {
+		user, err := spoke.CreateUser(profile, opts.Activate, groupIDs...)
+		if err != nil {
+			return result, err
+		}
+		result.SpokeID, result.Groups, result.Applied = user.ID, groups, true
+		return result, nil
+	}
+
+	if _, err := spoke.UpdateUser(existing.ID, &okta.User{Profile: profile}); err != nil {
+		return result, err
+	}
+	result.Applied = true
+	for i, id := range groupIDs {
+		if err := spoke.AddUserToGroup(id, existing.ID); err != nil {
+			return result, fmt.Errorf("adding to %s: %w", groups[i], err)
+		}
+		result.Groups = append(result.Groups, groups[i])
+	}
+	return result, nil
+}
+
+// oktaPushProfile maps the profile of a hub user onto the profile written to the spoke org
+func oktaPushProfile(hub *okta.User, opts *OktaPushOptions) (*okta.UserProfile, error) {
+	if hub.Profile == nil {
+		return nil, fmt.Errorf("hub user %s has no profile", hub.ID)
+	}
+
+	raw, err := json.Marshal(hub.Profile)
+	if err != nil {
+		return nil, err
+	}
+	source := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &source); err != nil {
+		return nil, err
+	}
+
+	attributes := opts.Attributes
+	if len(attributes) == 0 {
+		attributes = make(map[string]string, len(oktaPushAttributes))
+		for _, name := range oktaPushAttributes {
+			attributes[name] = name
+		}
+	}
+
+	mapped := map[string]interface{}{
+		"login": source["login"],
+		"email": source["email"],
+	}
+	for from, to := range attributes {
+		if value, ok := source[from]; ok {
+			mapped[to] = value
+		}
+	}
+	if opts.SourceID != "" {
+		mapped[opts.SourceID] = hub.ID
+	}
+
+	raw, err = json.Marshal(mapped)
+	if err != nil {
+		return nil, err
+	}
+	profile := &okta.UserProfile{}
+	if err := json.Unmarshal(raw, profile); err != nil {
+		return nil, err
+	}
+
+	if opts.Transform != nil {
+		opts.Transform(hub, profile)
+	}
+	if strings.TrimSpace(profile.Login) == "" {
+		return nil, fmt.Errorf("hub user %s maps to an empty login", hub.ID)
+	}
+	return profile, nil
+}
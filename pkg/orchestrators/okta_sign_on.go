@@ -0,0 +1,354 @@
+/*
+# Orchestrators - Okta Sign-On Policy Coverage
+
+This package contains an orchestration listing every Okta application with the rules of its authentication (app
+sign-on) policy, their MFA requirement and session lifetime, flagging the apps exempt from phishing-resistant MFA.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/okta_sign_on.go
+package orchestrators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/google"
+	"github.com/gemini-oss/rego/pkg/okta"
+)
+
+// Flags raised on the rules of the sign-on policy coverage
+const (
+	SignOnNoPolicy           = "no authentication policy"
+	SignOnSingleFactor       = "single factor"
+	SignOnNotPhishingProof   = "not phishing resistant"
+	SignOnLongSession        = "long session"
+	SignOnNoReauthentication = "never reauthenticates"
+)
+
+// SignOnPolicyOptions configures the sign-on policy coverage
+type SignOnPolicyOptions struct {
+	IncludeInactive bool          // Include inactive applications
+	MaxSession      time.Duration // Sessions satisfying a rule for longer are flagged. Default: 12 hours
+}
+
+// SignOnPolicyCoverage is a rule of the authentication policy of an application
+type SignOnPolicyCoverage struct {
+	App               string        // Label of the application
+	AppID             string        // ID of the application
+	SignOnMode        string        // Sign-on mode of the application, e.g. SAML_2_0
+	AppStatus         string        // Status of the application
+	Policy            string        // Name of the authentication policy
+	PolicyID          string        // ID of the authentication policy
+	Rule              string        // Name of the rule
+	Priority          int           // Order the rule is evaluated in
+	Conditions        string        // Who, where and when the rule applies, e.g. `groups: Engineering; network: ZONE`
+	Access            string        // Whether matching sign-ons are allowed. {ALLOW, DENY}
+	FactorMode        string        // Number of factors required. {1FA, 2FA}
+	MFA               bool          // The rule requires more than one factor
+	PhishingResistant bool          // The rule requires a phishing-resistant factor
+	Session           time.Duration // How long a session satisfies the rule; negative when it never expires
+	Exempt            bool          // The application can be signed into without a phishing-resistant factor, through any rule
+	Flags             []string      // Weaknesses of the rule, e.g. SignOnNotPhishingProof
+}
+
+/*
+ * Orchestrate the following:
+ * List the Okta applications, and the rules of their authentication policies
+ * Report the MFA requirement and session lifetime of each rule allowing access
+ * Flag single factor, non phishing-resistant and long-lived rules, and the applications exempt from phishing-resistant MFA
+ * Authentication policies are an Identity Engine feature; Classic Engine orgs return okta.ErrUnsupported
+ */
+func (c *Client) OktaSignOnPolicyCoverage(opts *SignOnPolicyOptions) ([]*SignOnPolicyCoverage, error) {
+	if opts == nil {
+		opts = &SignOnPolicyOptions{}
+	}
+	if opts.MaxSession == 0 {
+		opts.MaxSession = 12 * time.Hour
+	}
+
+	if caps, err := c.Okta.Capabilities(); err == nil && !caps.IdentityEngine() {
+		return nil, fmt.Errorf("app sign-on policies: %w", okta.ErrUnsupported)
+	}
+
+	apps, err := c.Okta.ListAllApplications()
+	if err != nil {
+		return nil, err
+	}
+
+	groups := map[string]string{}
+	if list, err := c.Okta.ListAllGroups(); err == nil {
+		for _, group := range *list {
+			groups[group.ID] = group.Profile.Name
+		}
+	} else {
+		c.Log.Warning("Unable to list Okta groups; rule conditions will show group IDs:", err)
+	}
+
+	policies := map[string]*okta.Policy{}
+	rules := []*SignOnPolicyCoverage{}
+	var errs []*SourceError
+	for _, app := range *apps {
+		if app.Status != "ACTIVE" && !opts.IncludeInactive {
+			continue
+		}
+		row := SignOnPolicyCoverage{App: app.Label, AppID: app.ID, SignOnMode: app.SignOnMode, AppStatus: app.Status}
+
+		policyID := app.AccessPolicyID()
+		if policyID == "" {
+			row.Exempt, row.Flags = true, []string{SignOnNoPolicy}
+			rules = append(rules, &row)
+			continue
+		}
+
+		policy, ok := policies[policyID]
+		if !ok {
+			if policy, err = c.Okta.GetPolicy(policyID); err != nil {
+				errs = append(errs, &SourceError{Source: fmt.Sprintf("Okta (%s)", app.Label), Err: err})
+				continue
+			}
+			policies[policyID] = policy
+		}
+		row.Policy, row.PolicyID = policy.Name, policy.ID
+
+		list, err := c.Okta.ListPolicyRules(policyID)
+		if err != nil {
+			errs = append(errs, &SourceError{Source: fmt.Sprintf("Okta (%s)", policy.Name), Err: err})
+			continue
+		}
+		for _, rule := range *list {
+			if rule.Status != "ACTIVE" {
+				continue
+			}
+			coverage := row
+			coverage.Rule, coverage.Priority = rule.Name, rule.Priority
+			coverage.Conditions = signOnConditions(rule.Conditions, groups)
+			signOnRule(&coverage, rule, opts)
+			rules = append(rules, &coverage)
+		}
+	}
+
+	// An application is exempt when any rule allows access without a phishing-resistant factor
+	exempt := map[string]bool{}
+	for _, rule := range rules {
+		exempt[rule.AppID] = exempt[rule.AppID] || rule.Exempt
+	}
+	for _, rule := range rules {
+		rule.Exempt = exempt[rule.AppID]
+	}
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].App != rules[j].App {
+			return rules[i].App < rules[j].App
+		}
+		return rules[i].Priority < rules[j].Priority
+	})
+
+	c.Log.Printf("Okta sign-on policy coverage: %d rule(s) across %d application(s), %d exempt from phishing-resistant MFA", len(rules), len(exempt), countTrue(exempt))
+	return complete(c, "okta sign-on policy coverage", rules, errs)
+}
+
+// signOnRule sets the requirements of a rule on its coverage row, flagging its weaknesses
+func signOnRule(coverage *SignOnPolicyCoverage, rule *okta.PolicyRule, opts *SignOnPolicyOptions) {
+	coverage.Session = -1
+	if rule.Actions == nil || rule.Actions.AppSignOn == nil {
+		return
+	}
+	coverage.Access = rule.Actions.AppSignOn.Access
+	if coverage.Access != "ALLOW" {
+		return
+	}
+
+	method := rule.Actions.AppSignOn.VerificationMethod
+	if method == nil {
+		coverage.Exempt = true
+		coverage.Flags = append(coverage.Flags, SignOnSingleFactor, SignOnNotPhishingProof, SignOnNoReauthentication)
+		return
+	}
+	coverage.FactorMode = method.FactorMode
+	coverage.MFA = method.MFA()
+	coverage.PhishingResistant = method.PhishingResistant()
+	if session, err := method.Reauthentication(); err == nil {
+		coverage.Session = session
+	}
+
+	if !coverage.MFA {
+		coverage.Flags = append(coverage.Flags, SignOnSingleFactor)
+	}
+	if !coverage.PhishingResistant {
+		coverage.Exempt = true
+		coverage.Flags = append(coverage.Flags, SignOnNotPhishingProof)
+	}
+	switch {
+	case coverage.Session < 0:
+		coverage.Flags = append(coverage.Flags, SignOnNoReauthentication)
+	case coverage.Session > opts.MaxSession:
+		coverage.Flags = append(coverage.Flags, SignOnLongSession)
+	}
+}
+
+// signOnConditions summarizes who, where and when a rule applies; `everyone` when it has no conditions
+func signOnConditions(conditions *okta.PolicyRuleConditions, groups map[string]string) string {
+	if conditions == nil {
+		return "everyone"
+	}
+
+	names := func(ids []string) string {
+		list := make([]string, len(ids))
+		for i, id := range ids {
+			list[i] = id
+			if name, ok := groups[id]; ok {
+				list[i] = name
+			}
+		}
+		return strings.Join(list, ", ")
+	}
+
+	parts := []string{}
+	if people := conditions.People; people != nil {
+		if people.Groups != nil && len(people.Groups.Include) > 0 {
+			parts = append(parts, "groups: "+names(people.Groups.Include))
+		}
+		if people.Groups != nil && len(people.Groups.Exclude) > 0 {
+			parts = append(parts, "excluding groups: "+names(people.Groups.Exclude))
+		}
+		if people.Users != nil && len(people.Users.Include) > 0 {
+			parts = append(parts, fmt.Sprintf("users: %d", len(people.Users.Include)))
+		}
+		if people.Users != nil && len(people.Users.Exclude) > 0 {
+			parts = append(parts, fmt.Sprintf("excluding users: %d", len(people.Users.Exclude)))
+		}
+	}
+	if network := conditions.Network; network != nil && network.Connection != "" && network.Connection != "ANYWHERE" {
+		parts = append(parts, "network: "+network.Connection)
+	}
+	if risk := conditions.RiskScore; risk != nil && risk.Level != "" && risk.Level != "ANY" {
+		parts = append(parts, "risk: "+risk.Level)
+	}
+	if expression := conditions.ElCondition; expression != nil && expression.Condition != "" {
+		parts = append(parts, "expression: "+expression.Condition)
+	}
+
+	if len(parts) == 0 {
+		return "everyone"
+	}
+	return strings.Join(parts, "; ")
+}
+
+func countTrue(m map[string]bool) int {
+	n := 0
+	for _, v := range m {
+		if v {
+			n++
+		}
+	}
+	return n
+}
+
+/*
+ * Orchestrate the following:
+ * Generate the Okta sign-on policy coverage
+ * Save the report to a Google Sheet
+ * Format the sheet
+ */
+func (c *Client) OktaSignOnPolicyCoverageToGoogleSheet(opts *SignOnPolicyOptions) error {
+	if err := c.checkFlag(FlagOktaSignOnPolicies); err != nil {
+		return err
+	}
+
+	report, err := c.OktaSignOnPolicyCoverage(opts)
+	partial, isPartial := AsPartial(err)
+	if err != nil && !isPartial {
+		return err
+	}
+
+	report, err = runPostFetch(c.Hooks, FlagOktaSignOnPolicies, report)
+	if err != nil {
+		return err
+	}
+
+	newSpreadsheet := &google.Spreadsheet{
+		Properties: &google.SpreadsheetProperties{
+			Title: fmt.Sprintf("{Okta} Sign-On Policy Coverage %s", time.Now().Format("2006-01-02")),
+		},
+		Sheets: []google.Sheet{
+			{
+				Properties: &google.SheetProperties{
+					Title: "Coverage",
+				},
+			},
+		},
+	}
+	sheet, err := c.Google.Sheets().CreateSpreadsheet(newSpreadsheet)
+	if err != nil {
+		return err
+	}
+
+	vr := &google.ValueRange{
+		Range:          "A:Z",
+		MajorDimension: "ROWS",
+	}
+	headers := []string{"Application", "App ID", "Sign-On Mode", "Status", "Policy", "Rule", "Priority", "Conditions", "Access", "Factor Mode", "MFA", "Phishing Resistant", "Session", "Exempt", "Flags"}
+	vr.Values = append(vr.Values, headers)
+
+	for _, row := range report {
+		session := "-"
+		switch {
+		case row.Access != "ALLOW":
+		case row.Session < 0:
+			session = "never"
+		case row.Session == 0:
+			session = "every sign-on"
+		default:
+			session = row.Session.String()
+		}
+		vr.Values = append(vr.Values, []string{
+			row.App,
+			row.AppID,
+			row.SignOnMode,
+			row.AppStatus,
+			row.Policy,
+			row.Rule,
+			fmt.Sprint(row.Priority),
+			row.Conditions,
+			row.Access,
+			row.FactorMode,
+			fmt.Sprint(row.MFA),
+			fmt.Sprint(row.PhishingResistant),
+			session,
+			fmt.Sprint(row.Exempt),
+			strings.Join(row.Flags, ", "),
+		})
+	}
+
+	vr.Values, err = c.Hooks.runPreExport(FlagOktaSignOnPolicies, vr.Values)
+	if err != nil {
+		return err
+	}
+	if isPartial {
+		vr.Values = append(append(vr.Values, []string{}), partial.Summary()...)
+	}
+
+	rows := len(vr.Values)
+	columns := len(vr.Values[0])
+
+	err = c.Google.Sheets().UpdateSpreadsheet(sheet.SpreadsheetID, vr)
+	if err != nil {
+		return err
+	}
+
+	err = c.Google.Sheets().FormatHeaderAndAutoSize(sheet.SpreadsheetID, &sheet.Sheets[0], rows, columns)
+	if err != nil {
+		return err
+	}
+
+	c.Log.Println("Okta sign-on policy coverage saved to Google Sheet.")
+	c.Log.Println("Spreadsheet URL: ", sheet.SpreadsheetURL)
+
+	return nil
+}
@@ -0,0 +1,305 @@
+/*
+# Orchestrators - Okta Verify Device Report
+
+This package contains an orchestration joining Okta Verify enrollments with Jamf managed mobile devices.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/okta_verify.go
+package orchestrators
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/google"
+	"github.com/gemini-oss/rego/pkg/jamf"
+	"github.com/gemini-oss/rego/pkg/okta"
+)
+
+// OktaVerifyEnrollment represents a single Okta Verify enrollment and its Jamf ownership status
+type OktaVerifyEnrollment struct {
+	User       *okta.User         // Okta user the factor is enrolled for
+	Factor     *okta.Factor       // Okta Verify factor (push or signed_nonce)
+	Device     *okta.Device       // Okta device the factor is enrolled on, if it is registered with Okta
+	JamfDevice *jamf.MobileDevice // Jamf mobile device with the serial number or UDID of the Okta device, if any
+	Corporate  bool               // True if the enrolled device is a Jamf managed device assigned to the user
+}
+
+type OktaVerifyEnrollments []*OktaVerifyEnrollment
+
+// Personal returns the enrollments whose device is not a corporate (Jamf managed) device
+func (e OktaVerifyEnrollments) Personal() OktaVerifyEnrollments {
+	personal := OktaVerifyEnrollments{}
+	for _, enrollment := range e {
+		if !enrollment.Corporate {
+			personal = append(personal, enrollment)
+		}
+	}
+	return personal
+}
+
+/*
+ * Orchestrate the following:
+ * Gather all Okta Verify enrollments for active Okta users, and the Okta devices they are enrolled on
+ * Match each Okta device against Jamf managed mobile devices on its serial number or UDID
+ * Return every enrollment with its corporate/personal classification
+ * - Enrollments are not filtered by the policies they satisfy: every active Okta Verify enrollment is reported
+ * - Device names can be changed by their users, so they are never used to match a Jamf device
+ */
+func (c *Client) OktaVerifyDeviceReport() (OktaVerifyEnrollments, error) {
+	// Devices are only available on Identity Engine orgs
+	if !c.Okta.Supports(okta.OktaDevices) {
+		return nil, fmt.Errorf("okta verify device report: devices are %w", okta.ErrUnsupported)
+	}
+
+	users, err := c.Okta.ListActiveUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	mobileDevices, err := c.Jamf.Devices().ListAllMobileDevices()
+	if err != nil {
+		return nil, err
+	}
+
+	// Index Jamf devices by their serial number and UDID
+	jamfDevices := make(map[string]*jamf.MobileDevice)
+	if mobileDevices.Results != nil {
+		for _, device := range *mobileDevices.Results {
+			for _, id := range []string{device.SerialNumber, device.UDID} {
+				if id = normalizeHardwareID(id); id != "" {
+					jamfDevices[id] = device
+				}
+			}
+		}
+	}
+
+	var report OktaVerifyEnrollments
+	var reportMutex sync.Mutex
+	var reportErrors []*SourceError
+
+	sem := make(chan struct{}, 10)
+	var wg sync.WaitGroup
+
+	for _, user := range *users {
+		wg.Add(1)
+
+		go func(user *okta.User) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			fail := func(source string, err error) {
+				reportMutex.Lock()
+				reportErrors = append(reportErrors, &SourceError{Source: fmt.Sprintf("%s (%s)", source, userLabel(user)), Err: err})
+				reportMutex.Unlock()
+			}
+
+			factors, err := c.Okta.Factors().ListAllEnrolledFactors(user.ID)
+			if err != nil {
+				fail("Okta factors", err)
+				return
+			}
+
+			var enrolled []*okta.Factor
+			for _, factor := range *factors {
+				if isOktaVerify(factor) {
+					enrolled = append(enrolled, factor)
+				}
+			}
+			if len(enrolled) == 0 {
+				return
+			}
+
+			devices, err := c.Okta.ListUserDevices(user.ID)
+			if err != nil {
+				fail("Okta devices", err)
+				return
+			}
+
+			for _, factor := range enrolled {
+				enrollment := &OktaVerifyEnrollment{
+					User:   user,
+					Factor: factor,
+					Device: enrolledDevice(factor, devices),
+				}
+
+				if enrollment.Device != nil && enrollment.Device.Profile != nil {
+					for _, id := range []string{enrollment.Device.Profile.SerialNumber, enrollment.Device.Profile.UDID} {
+						if device, ok := jamfDevices[normalizeHardwareID(id)]; ok {
+							enrollment.JamfDevice = device
+							enrollment.Corporate = deviceAssignedTo(device, user)
+							break
+						}
+					}
+				}
+
+				reportMutex.Lock()
+				report = append(report, enrollment)
+				reportMutex.Unlock()
+			}
+		}(user)
+	}
+
+	wg.Wait()
+	close(sem)
+
+	return complete(c, "okta verify device report", report, reportErrors)
+}
+
+/*
+ * Orchestrate the following:
+ * Generate the Okta Verify device report
+ * Save the personal (non-Jamf) enrollments to a Google Sheet
+ * Format the sheet
+ */
+func (c *Client) OktaVerifyDeviceReportToGoogleSheet() error {
+	if err := c.checkFlag(FlagOktaVerifyReport); err != nil {
+		return err
+	}
+
+	report, err := c.OktaVerifyDeviceReport()
+	partial, isPartial := AsPartial(err)
+	if err != nil && !isPartial {
+		return err
+	}
+
+	report, err = runPostFetch(c.Hooks, FlagOktaVerifyReport, report)
+	if err != nil {
+		return err
+	}
+
+	newSpreadsheet := &google.Spreadsheet{
+		Properties: &google.SpreadsheetProperties{
+			Title: fmt.Sprintf("{Okta/Jamf} Okta Verify Personal Devices %s", time.Now().Format("2006-01-02")),
+		},
+		Sheets: []google.Sheet{
+			{
+				Properties: &google.SheetProperties{
+					Title: "Personal Devices",
+				},
+			},
+		},
+	}
+	sheet, err := c.Google.Sheets().CreateSpreadsheet(newSpreadsheet)
+	if err != nil {
+		return err
+	}
+
+	vr := &google.ValueRange{
+		Range:          "A:Z",
+		MajorDimension: "ROWS",
+	}
+	headers := []string{"User ID", "Login", "Status", "Factor Type", "Device Name", "Serial Number", "Platform", "Device Type", "OS Version", "Enrolled", "Jamf Device Owner"}
+	vr.Values = append(vr.Values, headers)
+
+	for _, e := range report.Personal() {
+		login, serial, owner := "", "", ""
+		if e.User.Profile != nil {
+			login = e.User.Profile.Login
+		}
+		if e.Device != nil && e.Device.Profile != nil {
+			serial = e.Device.Profile.SerialNumber
+		}
+		if e.JamfDevice != nil {
+			owner = e.JamfDevice.Username
+		}
+		vr.Values = append(vr.Values, []string{e.User.ID, login, e.User.Status, e.Factor.FactorType, e.Factor.Profile.Name, serial, e.Factor.Profile.Platform, e.Factor.Profile.DeviceType, e.Factor.Profile.Version, e.Factor.Created, owner})
+	}
+
+	vr.Values, err = c.Hooks.runPreExport(FlagOktaVerifyReport, vr.Values)
+	if err != nil {
+		return err
+	}
+	if isPartial {
+		vr.Values = append(append(vr.Values, []string{}), partial.Summary()...)
+	}
+
+	rows := len(vr.Values)
+	columns := len(vr.Values[0])
+
+	err = c.Google.Sheets().UpdateSpreadsheet(sheet.SpreadsheetID, vr)
+	if err != nil {
+		return err
+	}
+
+	err = c.Google.Sheets().FormatHeaderAndAutoSize(sheet.SpreadsheetID, &sheet.Sheets[0], rows, columns)
+	if err != nil {
+		return err
+	}
+
+	c.Log.Println("Okta Verify device report saved to Google Sheet.")
+	c.Log.Println("Spreadsheet URL: ", sheet.SpreadsheetURL)
+
+	return nil
+}
+
+// isOktaVerify reports whether a factor is an Okta Verify device enrollment
+func isOktaVerify(f *okta.Factor) bool {
+	if f.Provider != "OKTA" || f.Status != "ACTIVE" {
+		return false
+	}
+	return f.FactorType == okta.FactorType.Push || f.FactorType == okta.FactorType.SignedNonce
+}
+
+// deviceAssignedTo reports whether a Jamf device is assigned to the given Okta user
+func deviceAssignedTo(d *jamf.MobileDevice, u *okta.User) bool {
+	if d.Username == "" || u.Profile == nil {
+		return false
+	}
+	owner := strings.ToLower(d.Username)
+	login := strings.ToLower(u.Profile.Login)
+	return owner == login || owner == strings.ToLower(u.Profile.Email) || owner == strings.Split(login, "@")[0]
+}
+
+/*
+ * enrolledDevice returns the Okta device of a user an Okta Verify factor is enrolled on: the only registered device of
+ * the factor's platform, or the one of them named as the factor when there are several; nil if none matches
+ */
+func enrolledDevice(f *okta.Factor, devices *okta.UserDevices) *okta.Device {
+	if devices == nil {
+		return nil
+	}
+
+	var candidates []*okta.Device
+	for _, d := range *devices {
+		if d.Device == nil || d.Device.Profile == nil || !strings.EqualFold(d.Device.Profile.Platform, f.Profile.Platform) {
+			continue
+		}
+		candidates = append(candidates, d.Device)
+	}
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+
+	for _, d := range candidates {
+		if normalizeDeviceName(d.Profile.DisplayName) == normalizeDeviceName(f.Profile.Name) {
+			return d
+		}
+	}
+	return nil
+}
+
+// userLabel returns the login of an Okta user, or its ID when the profile is missing
+func userLabel(u *okta.User) string {
+	if u.Profile == nil || u.Profile.Login == "" {
+		return u.ID
+	}
+	return u.Profile.Login
+}
+
+func normalizeDeviceName(name string) string {
+	name = strings.ReplaceAll(name, "’", "'")
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func normalizeHardwareID(id string) string {
+	return strings.ToUpper(strings.TrimSpace(id))
+}
@@ -12,14 +12,17 @@ This package contains some functions involving practical examples of multi-servi
 package orchestrators
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/gemini-oss/rego/pkg/active_directory"
+	"github.com/gemini-oss/rego/pkg/common/flags"
 	"github.com/gemini-oss/rego/pkg/common/log"
 	"github.com/gemini-oss/rego/pkg/google"
 	"github.com/gemini-oss/rego/pkg/jamf"
 	"github.com/gemini-oss/rego/pkg/okta"
+	"github.com/gemini-oss/rego/pkg/slack"
 	"github.com/gemini-oss/rego/pkg/snipeit"
 )
 
@@ -29,7 +32,84 @@ type Client struct {
 	Google          *google.Client
 	Jamf            *jamf.Client
 	Okta            *okta.Client
+	Slack           *slack.Client
 	SnipeIT         *snipeit.Client
+	Flags           *flags.Set   // Kill switches consulted before each automation runs. Default: flags.Default()
+	Tenant          string       // Tenant the client belongs to, when created by a TenantRegistry
+	Hooks           *ReportHooks // Transformations applied by the report pipelines; none when nil
+	PartialResults  bool         // Aggregated reports return what they collected, with a *PartialError, when a provider is down
+}
+
+// Kill switches for each automation; set e.g. REGO_FLAG_DEVICE_COMPLIANCE_SYNC=false to switch one off
+const (
+	FlagOktaRoleReport          = "okta-role-report"
+	FlagADReport                = "ad-report"
+	FlagOktaVerifyReport        = "okta-verify-report"
+	FlagLicenseUtilization      = "license-utilization-report"
+	FlagSnipeITExpiryAlerts     = "snipeit-expiry-alerts"
+	FlagDeviceComplianceSync    = "device-compliance-sync"
+	FlagOwnershipTransfer       = "ownership-transfer"
+	FlagRoomBookingCleanup      = "room-booking-cleanup"
+	FlagGoogleDeviceSync        = "google-device-sync"
+	FlagOktaOrgPush             = "okta-org-push"
+	FlagCertificateInventory    = "certificate-inventory"
+	FlagCertificateAlerts       = "certificate-expiry-alerts"
+	FlagSnipeITRequestApprovals = "snipeit-request-approvals"
+	FlagGroupNamingLint         = "group-naming-lint"
+	FlagGroupCleanup            = "group-cleanup"
+	FlagExternalSharing         = "external-sharing"
+	FlagOktaSignOnPolicies      = "okta-sign-on-policy-report"
+	FlagTwoStepCampaign         = "2sv-enrollment-campaign"
+	FlagJITAccess               = "jit-access"
+	FlagBreakGlassMonitor       = "break-glass-monitor"
+)
+
+/*
+ * WithContext returns a copy of the client whose provider requests end when ctx is done, e.g. to bound a scheduled report:
+ *
+ *	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+ *	defer cancel()
+ *	report, err := c.WithContext(ctx).LicenseUtilization(nil)
+ *
+ * Active Directory is shared as is; LDAP operations are bounded by the connection's own timeout
+ * Log lines (of the orchestrator and of each provider) carry the fields of ctx, e.g. to follow a run across services:
+ *
+ *	ctx := log.WithStep(log.WithRunID(context.Background(), ""), "license-utilization")
+ *	report, err := c.WithContext(ctx).LicenseUtilization(nil)
+ */
+func (c *Client) WithContext(ctx context.Context) *Client {
+	clone := *c
+	clone.Log = c.Log.WithContext(ctx)
+	if c.Google != nil {
+		clone.Google = c.Google.WithContext(ctx)
+	}
+	if c.Jamf != nil {
+		clone.Jamf = c.Jamf.WithContext(ctx)
+	}
+	if c.Okta != nil {
+		clone.Okta = c.Okta.WithContext(ctx)
+	}
+	if c.Slack != nil {
+		clone.Slack = c.Slack.WithContext(ctx)
+	}
+	if c.SnipeIT != nil {
+		clone.SnipeIT = c.SnipeIT.WithContext(ctx)
+	}
+	return &clone
+}
+
+// checkFlag returns an error wrapping flags.ErrDisabled when the automation has been switched off
+func (c *Client) checkFlag(name string) error {
+	set := c.Flags
+	if set == nil {
+		set = flags.Default()
+	}
+
+	if err := set.Check(name); err != nil {
+		c.Log.Warningf("Skipping %s: switched off", name)
+		return err
+	}
+	return nil
 }
 
 /*
@@ -39,11 +119,20 @@ type Client struct {
  * Format the sheet
  */
 func (c *Client) OktaRoleReportToGoogleSheet() error {
+	if err := c.checkFlag(FlagOktaRoleReport); err != nil {
+		return err
+	}
+
 	roleReports, err := c.Okta.GenerateRoleReport()
 	if err != nil {
 		return err
 	}
 
+	roleReports, err = runPostFetch(c.Hooks, FlagOktaRoleReport, roleReports)
+	if err != nil {
+		return err
+	}
+
 	newSpreadsheet := &google.Spreadsheet{
 		Properties: &google.SpreadsheetProperties{
 			Title: fmt.Sprintf("{Okta} Entitlement Review %s", time.Now().Format("2006-01-02")),
@@ -74,8 +163,13 @@ func (c *Client) OktaRoleReportToGoogleSheet() error {
 		}
 	}
 
+	vr.Values, err = c.Hooks.runPreExport(FlagOktaRoleReport, vr.Values)
+	if err != nil {
+		return err
+	}
+
 	rows := len(vr.Values)
-	columns := len(headers)
+	columns := len(vr.Values[0])
 
 	err = c.Google.Sheets().UpdateSpreadsheet(sheet.SpreadsheetID, vr)
 	if err != nil {
@@ -100,11 +194,20 @@ func (c *Client) OktaRoleReportToGoogleSheet() error {
  * Format the sheet
  */
 func (c *Client) ADReportToGoogleSheet(group string) error {
+	if err := c.checkFlag(FlagADReport); err != nil {
+		return err
+	}
+
 	users, err := c.ActiveDirectory.MemberOf(group)
 	if err != nil {
 		return err
 	}
 
+	users, err = runPostFetch(c.Hooks, FlagADReport, users)
+	if err != nil {
+		return err
+	}
+
 	newSpreadsheet := &google.Spreadsheet{
 		Properties: &google.SpreadsheetProperties{
 			Title: fmt.Sprintf("{Active Directory} Entitlement Review [%s] %s", group, time.Now().Format("2006-01-02")),
@@ -133,8 +236,13 @@ func (c *Client) ADReportToGoogleSheet(group string) error {
 		vr.Values = append(vr.Values, []string{user.SAMAccountName, user.GivenName, user.SN, user.UserPrincipalName, user.DisplayName, group})
 	}
 
+	vr.Values, err = c.Hooks.runPreExport(FlagADReport, vr.Values)
+	if err != nil {
+		return err
+	}
+
 	rows := len(vr.Values)
-	columns := len(headers)
+	columns := len(vr.Values[0])
 
 	err = c.Google.Sheets().UpdateSpreadsheet(sheet.SpreadsheetID, vr)
 	if err != nil {
@@ -0,0 +1,208 @@
+/*
+# Orchestrators - Ownership Transfer
+
+This package contains the offboarding planner proposing successors for the Drive files, calendars and groups of a
+leaving user, and the executor applying the reviewed plan.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/ownership_transfer.go
+package orchestrators
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gemini-oss/rego/pkg/google"
+)
+
+// Kinds of TransferStep
+const (
+	TransferDrive      = "drive"       // Transfer the user's Drive files (Data Transfer API)
+	TransferCalendar   = "calendar"    // Transfer the user's calendars and events (Data Transfer API)
+	TransferGroupOwner = "group-owner" // Make the successor an owner of a group the user owns
+)
+
+// TransferStep is a single ownership change of a TransferPlan
+type TransferStep struct {
+	Kind     string // TransferDrive, TransferCalendar or TransferGroupOwner
+	Resource string // Email of the group for TransferGroupOwner; the leaving user otherwise
+	From     string // Leaving user
+	To       string // Proposed successor. Empty when none could be found; set it before executing the plan
+	Reason   string // Why the successor was proposed
+}
+
+// TransferPlan proposes a successor for everything a leaving user owns. Review (and edit) it before ExecuteTransferPlan
+type TransferPlan struct {
+	User    string          // Leaving user
+	Manager string          // Manager of the user, from their Google relations
+	Steps   []*TransferStep // Ownership changes, Drive and Calendar first
+}
+
+// Unresolved returns the steps without a successor
+func (p *TransferPlan) Unresolved() []*TransferStep {
+	steps := []*TransferStep{}
+	for _, step := range p.Steps {
+		if step.To == "" {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// TransferOutcome is the result of executing a TransferStep
+type TransferOutcome struct {
+	Step   *TransferStep
+	Detail string // e.g. the data transfer ID, or "already an owner"
+	Err    error
+}
+
+/*
+ * Plan the following for a leaving user:
+ * Drive files and calendars go to the user's manager
+ * Each group the user owns goes to an existing co-owner (no change needed), else a manager of the group (promoted), else the user's manager
+ * Steps without any candidate are left with an empty successor
+ */
+func (c *Client) PlanOwnershipTransfer(email string) (*TransferPlan, error) {
+	if c.Google == nil {
+		return nil, fmt.Errorf("planning the ownership transfer of %s requires a Google client", email)
+	}
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	user, err := c.Google.Users().GetUser(email)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &TransferPlan{User: email}
+	for _, relation := range user.Relations {
+		if relation.Type == "manager" {
+			plan.Manager = strings.ToLower(relation.Value)
+			break
+		}
+	}
+
+	managerReason := "manager of " + email
+	if plan.Manager == "" {
+		managerReason = email + " has no manager; choose a successor"
+	}
+	for _, kind := range []string{TransferDrive, TransferCalendar} {
+		plan.Steps = append(plan.Steps, &TransferStep{Kind: kind, Resource: email, From: email, To: plan.Manager, Reason: managerReason})
+	}
+
+	groups, err := c.Google.Groups().ListOwnedGroups(email)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range groups {
+		members, err := c.Google.Groups().ListAllMembers(group.Email)
+		if err != nil {
+			return nil, fmt.Errorf("listing the members of %s: %w", group.Email, err)
+		}
+
+		step := &TransferStep{Kind: TransferGroupOwner, Resource: group.Email, From: email, To: plan.Manager, Reason: managerReason}
+		if owner := successorByRole(members.Members, email, "OWNER"); owner != "" {
+			step.To, step.Reason = owner, "co-owner of "+group.Email
+		} else if manager := successorByRole(members.Members, email, "MANAGER"); manager != "" {
+			step.To, step.Reason = manager, "manager of "+group.Email
+		}
+		plan.Steps = append(plan.Steps, step)
+	}
+
+	c.Log.Printf("Planned %d ownership transfer(s) for %s (%d without a successor)", len(plan.Steps), email, len(plan.Unresolved()))
+	return plan, nil
+}
+
+// successorByRole returns the first (alphabetically) active user with `role`, other than the leaving user
+func successorByRole(members []*google.Member, leaving, role string) string {
+	candidates := []string{}
+	for _, m := range members {
+		if m.Role == role && m.Type == "USER" && m.Status != "SUSPENDED" && !strings.EqualFold(m.Email, leaving) {
+			candidates = append(candidates, strings.ToLower(m.Email))
+		}
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	sort.Strings(candidates)
+	return candidates[0]
+}
+
+/*
+ * Execute a reviewed TransferPlan, step by step
+ * Drive files are transferred with both private and shared files; calendars release the user's resources
+ * Steps without a successor fail without stopping the others; the error joins every failed step
+ */
+func (c *Client) ExecuteTransferPlan(plan *TransferPlan) ([]*TransferOutcome, error) {
+	if err := c.checkFlag(FlagOwnershipTransfer); err != nil {
+		return nil, err
+	}
+	if c.Google == nil {
+		return nil, fmt.Errorf("executing the ownership transfer of %s requires a Google client", plan.User)
+	}
+
+	outcomes := make([]*TransferOutcome, 0, len(plan.Steps))
+	var errs []error
+	for _, step := range plan.Steps {
+		outcome := &TransferOutcome{Step: step}
+		outcome.Detail, outcome.Err = c.executeTransferStep(step)
+		if outcome.Err != nil {
+			c.Log.Errorf("Transfer of %s %s to %q failed: %v", step.Kind, step.Resource, step.To, outcome.Err)
+			errs = append(errs, fmt.Errorf("%s %s: %w", step.Kind, step.Resource, outcome.Err))
+		} else {
+			c.Log.Printf("Transferred %s %s to %s: %s", step.Kind, step.Resource, step.To, outcome.Detail)
+		}
+		outcomes = append(outcomes, outcome)
+	}
+
+	return outcomes, errors.Join(errs...)
+}
+
+func (c *Client) executeTransferStep(step *TransferStep) (string, error) {
+	if step.To == "" {
+		return "", fmt.Errorf("no successor: %s", step.Reason)
+	}
+
+	switch step.Kind {
+	case TransferDrive:
+		params := []*google.ApplicationTransferParam{{Key: "PRIVACY_LEVEL", Value: []string{"PRIVATE", "SHARED"}}}
+		transfer, err := c.Google.DataTransfer().Transfer(google.DataTransferDrive, step.From, step.To, params)
+		if err != nil {
+			return "", err
+		}
+		return "data transfer " + transfer.ID, nil
+
+	case TransferCalendar:
+		params := []*google.ApplicationTransferParam{{Key: "RELEASE_RESOURCES", Value: []string{"TRUE"}}}
+		transfer, err := c.Google.DataTransfer().Transfer(google.DataTransferCalendar, step.From, step.To, params)
+		if err != nil {
+			return "", err
+		}
+		return "data transfer " + transfer.ID, nil
+
+	case TransferGroupOwner:
+		groups := c.Google.Groups()
+		member, err := groups.GetMember(step.Resource, step.To)
+		if err != nil {
+			if _, err := groups.AddMember(step.Resource, &google.Member{Email: step.To, Role: "OWNER"}); err != nil {
+				return "", err
+			}
+			return "added as owner", nil
+		}
+		if member.Role == "OWNER" {
+			return "already an owner", nil
+		}
+		if _, err := groups.UpdateMember(step.Resource, step.To, &google.Member{Role: "OWNER"}); err != nil {
+			return "", err
+		}
+		return "promoted from " + strings.ToLower(member.Role), nil
+	}
+
+	return "", fmt.Errorf("unknown transfer kind %q", step.Kind)
+}
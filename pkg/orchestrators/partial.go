@@ -0,0 +1,105 @@
+/*
+# Orchestrators - Partial Results
+
+This package contains the error reporting shared by reports which aggregate several providers, so a report can be
+returned with whatever was collected (and a summary of the sources that failed) when one provider is down.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/partial.go
+package orchestrators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SourceError is a single source which could not be collected for a report
+type SourceError struct {
+	Source string // Provider, or part of a provider, e.g. `Okta` or `Okta factors (ada@example.com)`
+	Err    error  // Why the source could not be collected
+}
+
+func (e *SourceError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Source, e.Err)
+}
+
+func (e *SourceError) Unwrap() error {
+	return e.Err
+}
+
+// PartialError is returned alongside a report which is missing the data of one or more sources (see Client.PartialResults)
+type PartialError struct {
+	Report  string         // Name of the report
+	Sources []*SourceError // Sources which could not be collected
+}
+
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("%s is incomplete: %s", e.Report, joinSourceErrors(e.Sources))
+}
+
+func (e *PartialError) Unwrap() []error {
+	errs := make([]error, len(e.Sources))
+	for i, source := range e.Sources {
+		errs[i] = source
+	}
+	return errs
+}
+
+// Failed reports whether the named source could not be collected
+func (e *PartialError) Failed(source string) bool {
+	for _, s := range e.Sources {
+		if s.Source == source {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary returns the failed sources as rows, with a header, to be appended to an exported report
+func (e *PartialError) Summary() [][]string {
+	rows := [][]string{{"Unavailable Source", "Error"}}
+	for _, s := range e.Sources {
+		rows = append(rows, []string{s.Source, s.Err.Error()})
+	}
+	return rows
+}
+
+// AsPartial returns the PartialError of a report which was returned with partial results
+func AsPartial(err error) (*PartialError, bool) {
+	var partial *PartialError
+	ok := errors.As(err, &partial)
+	return partial, ok
+}
+
+/*
+ * complete finishes an aggregated report
+ * - every source collected: the report
+ * - some sources failed, with PartialResults: the report and a *PartialError naming the failed sources
+ * - some sources failed, without PartialResults: no report, and an error naming the failed sources
+ */
+func complete[T any](c *Client, report string, result T, sources []*SourceError) (T, error) {
+	if len(sources) == 0 {
+		return result, nil
+	}
+
+	if c.PartialResults {
+		partial := &PartialError{Report: report, Sources: sources}
+		c.Log.Warning(partial)
+		return result, partial
+	}
+
+	return *new(T), fmt.Errorf("error collecting %s: %s", report, joinSourceErrors(sources))
+}
+
+func joinSourceErrors(sources []*SourceError) string {
+	msgs := make([]string, len(sources))
+	for i, s := range sources {
+		msgs[i] = s.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
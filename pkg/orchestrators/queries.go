@@ -0,0 +1,57 @@
+/*
+# Orchestrators - Cross-Provider Queries
+
+This package contains ad-hoc questions answered by joining datasets across providers.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/queries.go
+package orchestrators
+
+import (
+	"strings"
+
+	"github.com/gemini-oss/rego/pkg/common/dataset"
+	"github.com/gemini-oss/rego/pkg/google"
+	"github.com/gemini-oss/rego/pkg/jamf"
+)
+
+/*
+ * Orchestrate the following:
+ * List the Jamf computers (with their assigned user) and the Google users
+ * Return the computers whose assigned user is suspended in Google
+ */
+func (c *Client) JamfDevicesOfSuspendedGoogleUsers() ([]dataset.Pair[*jamf.Computer, *google.User], error) {
+	computers, err := c.Jamf.Devices().Sections([]string{jamf.Section.General, jamf.Section.UserAndLocation}).ListAllComputers()
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := c.Google.Users().ListAllUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	devices := []*jamf.Computer{}
+	if computers.Results != nil {
+		devices = *computers.Results
+	}
+
+	suspended := dataset.From(users.Users).Where(func(u *google.User) bool { return u.Suspended })
+
+	matches := dataset.Join(dataset.From(devices), suspended,
+		func(d *jamf.Computer) string {
+			if d.UserAndLocation == nil {
+				return ""
+			}
+			return strings.ToLower(d.UserAndLocation.Email)
+		},
+		func(u *google.User) string { return strings.ToLower(u.PrimaryEmail) },
+	)
+
+	c.Log.Printf("Found %d Jamf computer(s) assigned to suspended Google users", matches.Count())
+	return matches.Items, nil
+}
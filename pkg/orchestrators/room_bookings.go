@@ -0,0 +1,297 @@
+/*
+# Orchestrators - Room Booking Hygiene
+
+This package contains a report scanning the calendars of Google Workspace rooms for recurring meetings nobody attends,
+double-bookings and bookings held by deactivated users, optionally releasing the stale ones.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/room_bookings.go
+package orchestrators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/google"
+)
+
+// Issues found by RoomBookingHygiene
+const (
+	RoomBookingUnattended  = "unattended"  // Recurring meeting whose recent instances no attendee accepted
+	RoomBookingDouble      = "double"      // Booking overlapping another booking of the same room
+	RoomBookingDeactivated = "deactivated" // Booking organized by a suspended or deleted user
+)
+
+// RoomBookingOptions configures the room booking hygiene report
+type RoomBookingOptions struct {
+	Rooms      []string      // Resource emails of the rooms to scan; every conference room when empty
+	Lookback   time.Duration // Past instances examined for attendance. Default: 28 days
+	Lookahead  time.Duration // Upcoming bookings checked for conflicts and deactivated organizers. Default: 14 days
+	AutoCancel bool          // Release unattended and deactivated bookings from the room (not double-bookings, which need a human)
+	DryRun     bool          // With AutoCancel, only log the bookings which would be released
+}
+
+// RoomBookingIssue is a single problematic booking of a room
+type RoomBookingIssue struct {
+	Room      string    // Resource email of the room
+	RoomName  string    // Name of the room
+	Issue     string    // RoomBookingUnattended, RoomBookingDouble or RoomBookingDeactivated
+	EventID   string    // ID of the recurring series, or of the single event
+	Summary   string    // Title of the meeting
+	Organizer string    // Email of the organizer
+	Start     time.Time // Start of the next (or flagged) instance
+	Detail    string    // Why the booking was flagged
+	Cancelled bool      // The booking was released from the room
+}
+
+/*
+ * Orchestrate the following:
+ * Scan the calendar of every room over the lookback and lookahead windows
+ * Flag recurring meetings without any accepted attendee, overlapping bookings and bookings of deactivated organizers
+ * Release unattended and deactivated bookings when AutoCancel is set (logged only under DryRun, or with the flag switched off)
+ */
+func (c *Client) RoomBookingHygiene(opts *RoomBookingOptions) ([]*RoomBookingIssue, error) {
+	if opts == nil {
+		opts = &RoomBookingOptions{}
+	}
+	if opts.Lookback == 0 {
+		opts.Lookback = 28 * 24 * time.Hour
+	}
+	if opts.Lookahead == 0 {
+		opts.Lookahead = 14 * 24 * time.Hour
+	}
+	now := time.Now()
+
+	calendar := c.Google.Calendar()
+	rooms := map[string]string{}
+	if len(opts.Rooms) > 0 {
+		for _, room := range opts.Rooms {
+			rooms[room] = room
+		}
+	} else {
+		resources, err := calendar.ListAllCalendarResources(nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, resource := range resources.Items {
+			if resource.ResourceCategory == "CONFERENCE_ROOM" {
+				rooms[resource.ResourceEmail] = resource.ResourceName
+			}
+		}
+	}
+
+	deactivated, err := c.deactivatedGoogleUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	emails := make([]string, 0, len(rooms))
+	for email := range rooms {
+		emails = append(emails, email)
+	}
+	sort.Strings(emails)
+
+	report := []*RoomBookingIssue{}
+	var errs []*SourceError
+	for _, room := range emails {
+		events, err := calendar.ListAllEvents(room, &google.CalendarEventQuery{
+			SingleEvents: true,
+			OrderBy:      "startTime",
+			TimeMin:      now.Add(-opts.Lookback).Format(time.RFC3339),
+			TimeMax:      now.Add(opts.Lookahead).Format(time.RFC3339),
+		})
+		if err != nil {
+			errs = append(errs, &SourceError{Source: fmt.Sprintf("Google Calendar (%s)", room), Err: err})
+			continue
+		}
+
+		issues := roomBookingIssues(room, rooms[room], events.Items, deactivated, now)
+		if opts.AutoCancel {
+			c.releaseRoomBookings(calendar, issues, opts.DryRun)
+		}
+		report = append(report, issues...)
+	}
+
+	c.Log.Printf("Found %d room booking issue(s) across %d room(s)", len(report), len(rooms))
+	return complete(c, "room booking hygiene", report, errs)
+}
+
+// deactivatedGoogleUsers returns a check for suspended or deleted users; the directory's domains tell deleted users from guests
+func (c *Client) deactivatedGoogleUsers() (func(email string) bool, error) {
+	users, err := c.Google.Users().ListAllUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	active := map[string]bool{}
+	domains := map[string]bool{}
+	for _, user := range users.Users {
+		email := strings.ToLower(user.PrimaryEmail)
+		active[email] = !user.Suspended
+		domains[email[strings.LastIndex(email, "@")+1:]] = true
+	}
+
+	return func(email string) bool {
+		email = strings.ToLower(email)
+		if isActive, ok := active[email]; ok {
+			return !isActive
+		}
+		// Unknown addresses of our own domains belong to deleted users; other domains are guests
+		return domains[email[strings.LastIndex(email, "@")+1:]]
+	}, nil
+}
+
+// roomBookingIssues flags the bookings of a single room
+func roomBookingIssues(room, name string, events []*google.CalendarEvent, deactivated func(string) bool, now time.Time) []*RoomBookingIssue {
+	type series struct {
+		first    *google.CalendarEvent // First upcoming instance, if any
+		past     int                   // Past instances
+		attended bool                  // An attendee accepted a past instance
+	}
+	recurring := map[string]*series{}
+	upcoming := []*google.CalendarEvent{}
+	issues := []*RoomBookingIssue{}
+	flagged := map[string]bool{}
+
+	for _, event := range events {
+		if event.Status == "cancelled" || roomDeclined(event) {
+			continue
+		}
+		start, err := event.Start.Time()
+		if err != nil {
+			continue
+		}
+		organizer := ""
+		if event.Organizer != nil {
+			organizer = event.Organizer.Email
+		}
+
+		if event.RecurringEventID != "" {
+			s, ok := recurring[event.RecurringEventID]
+			if !ok {
+				s = &series{}
+				recurring[event.RecurringEventID] = s
+			}
+			if start.Before(now) {
+				s.past++
+				s.attended = s.attended || attended(event)
+			} else if s.first == nil {
+				s.first = event
+			}
+		}
+		if start.Before(now) {
+			continue
+		}
+		upcoming = append(upcoming, event)
+
+		id := event.ID
+		if event.RecurringEventID != "" {
+			id = event.RecurringEventID
+		}
+		if organizer != "" && deactivated(organizer) && !flagged[id] {
+			flagged[id] = true
+			issues = append(issues, newRoomBookingIssue(room, name, RoomBookingDeactivated, id, event, start,
+				fmt.Sprintf("%s is suspended or deleted", organizer)))
+		}
+	}
+
+	for id, s := range recurring {
+		if s.first == nil || s.attended || s.past < 2 || flagged[id] {
+			continue
+		}
+		start, _ := s.first.Start.Time()
+		flagged[id] = true
+		issues = append(issues, newRoomBookingIssue(room, name, RoomBookingUnattended, id, s.first, start,
+			fmt.Sprintf("no attendee accepted any of the last %d instances", s.past)))
+	}
+
+	// Upcoming events are ordered by start time, so a booking overlaps when it starts before the latest end so far
+	var latest time.Time
+	var holder *google.CalendarEvent
+	for _, event := range upcoming {
+		start, _ := event.Start.Time()
+		end, err := event.End.Time()
+		if err != nil {
+			continue
+		}
+		if holder != nil && start.Before(latest) {
+			issues = append(issues, newRoomBookingIssue(room, name, RoomBookingDouble, event.ID, event, start,
+				fmt.Sprintf("overlaps %q", holder.Summary)))
+		}
+		if end.After(latest) {
+			latest, holder = end, event
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Start.Before(issues[j].Start) })
+	return issues
+}
+
+func newRoomBookingIssue(room, name, issue, id string, event *google.CalendarEvent, start time.Time, detail string) *RoomBookingIssue {
+	i := &RoomBookingIssue{
+		Room:     room,
+		RoomName: name,
+		Issue:    issue,
+		EventID:  id,
+		Summary:  event.Summary,
+		Start:    start,
+		Detail:   detail,
+	}
+	if event.Organizer != nil {
+		i.Organizer = event.Organizer.Email
+	}
+	return i
+}
+
+// roomDeclined reports whether the room itself declined the booking, e.g. because it was already taken
+func roomDeclined(event *google.CalendarEvent) bool {
+	for _, attendee := range event.Attendees {
+		if attendee.Self && attendee.ResponseStatus == "declined" {
+			return true
+		}
+	}
+	return false
+}
+
+// attended reports whether anyone other than the organizer (and resources) accepted an instance
+func attended(event *google.CalendarEvent) bool {
+	for _, attendee := range event.Attendees {
+		if attendee.Resource || attendee.Organizer {
+			continue
+		}
+		if attendee.ResponseStatus == "accepted" || attendee.ResponseStatus == "tentative" {
+			return true
+		}
+	}
+	return false
+}
+
+// releaseRoomBookings deletes unattended and deactivated bookings from the room's calendar
+func (c *Client) releaseRoomBookings(calendar *google.CalendarClient, issues []*RoomBookingIssue, dryRun bool) {
+	if !dryRun && c.checkFlag(FlagRoomBookingCleanup) != nil {
+		dryRun = true
+	}
+
+	for _, issue := range issues {
+		if issue.Issue == RoomBookingDouble {
+			continue
+		}
+		if dryRun {
+			c.Log.Printf("[dry run] Would release %s from %q (%s): %s", issue.Room, issue.Summary, issue.Organizer, issue.Detail)
+			continue
+		}
+
+		if err := calendar.DeleteEvent(issue.Room, issue.EventID); err != nil {
+			c.Log.Errorf("Unable to release %s from %q: %v", issue.Room, issue.Summary, err)
+			continue
+		}
+		issue.Cancelled = true
+		c.Log.Printf("Released %s from %q (%s): %s", issue.Room, issue.Summary, issue.Organizer, issue.Detail)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+# Orchestrators - Snipe-IT Expiry Alerts
+
+This package contains an orchestration delivering Snipe-IT warranty, EOL, license and depreciation alerts through the notify package (Slack, email, and any other notifier).
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/snipeit_alerts.go
+package orchestrators
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/notify"
+	"github.com/gemini-oss/rego/pkg/snipeit"
+)
+
+// ExpiryAlertOptions configures where Snipe-IT expiry alerts are delivered
+type ExpiryAlertOptions struct {
+	Days         int             // Look-ahead window, in days
+	SlackChannel string          // Slack channel to post to; skipped when empty
+	Recipients   []string        // Email recipients; skipped when empty. Requires SMTP_HOST, SMTP_PORT, SMTP_FROM (and optionally SMTP_USERNAME/SMTP_PASSWORD)
+	Notifier     notify.Notifier // Additional channel(s), e.g. a notify.Router to Google Chat/PagerDuty; skipped when nil
+}
+
+/*
+ * Orchestrate the following:
+ * Build the Snipe-IT expiry report for the configured window
+ * Post a summary to Slack and/or email it to the recipients
+ */
+func (c *Client) SnipeITExpiryAlerts(opts *ExpiryAlertOptions) error {
+	if err := c.checkFlag(FlagSnipeITExpiryAlerts); err != nil {
+		return err
+	}
+
+	report, err := c.SnipeIT.ExpiryReport(opts.Days)
+	if err != nil {
+		return err
+	}
+
+	if len(report.Warranty)+len(report.EOL)+len(report.Licenses)+len(report.Depreciated) == 0 {
+		c.Log.Println("No Snipe-IT assets need attention; skipping alerts.")
+		return nil
+	}
+
+	router := notify.NewRouter()
+	if opts.SlackChannel != "" {
+		if c.Slack == nil {
+			return fmt.Errorf("slack channel %s configured without a slack client", opts.SlackChannel)
+		}
+		router.Route(notify.Info, &notify.SlackNotifier{Client: c.Slack, Channel: opts.SlackChannel})
+	}
+	if len(opts.Recipients) > 0 {
+		router.Route(notify.Info, notify.EmailNotifierFromEnv(opts.Recipients...))
+	}
+	if opts.Notifier != nil {
+		router.Route(notify.Info, opts.Notifier)
+	}
+
+	severity := notify.Info
+	if len(report.Warranty)+len(report.Licenses) > 0 {
+		severity = notify.Warning
+	}
+
+	title := fmt.Sprintf("{Snipe-IT} Asset expiry report %s (next %d days)", time.Now().Format("2006-01-02"), opts.Days)
+	message, err := notify.FromTemplate(c.Tenant, severity, title, "snipeit_expiry", expirySections(report))
+	if err != nil {
+		return err
+	}
+
+	err = router.Notify(message)
+	if err != nil {
+		return err
+	}
+
+	c.Log.Println("Snipe-IT expiry alerts delivered.")
+	return nil
+}
+
+/*
+ * Orchestrate the following:
+ * Deliver Snipe-IT expiry alerts immediately, then on every interval until stopped
+ */
+func (c *Client) ScheduleSnipeITExpiryAlerts(opts *ExpiryAlertOptions, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.SnipeITExpiryAlerts(opts); err != nil {
+			c.Log.Error("Error delivering Snipe-IT expiry alerts:", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// expiryReportSection is a titled group of alerts rendered by the `snipeit_expiry` template
+type expiryReportSection struct {
+	Title  string
+	Alerts []*snipeit.AssetAlert
+}
+
+// expirySections groups the report for the `snipeit_expiry` template
+func expirySections(report *snipeit.ExpiryReport) []expiryReportSection {
+	return []expiryReportSection{
+		{"Warranties expiring", report.Warranty},
+		{"Reaching end of life", report.EOL},
+		{"Licenses expiring", report.Licenses},
+		{"Fully depreciated (deployed)", report.Depreciated},
+	}
+}
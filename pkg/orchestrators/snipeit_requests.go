@@ -0,0 +1,181 @@
+/*
+# Orchestrators - Snipe-IT Request Approvals
+
+This package contains an orchestration routing the pending requests for requestable Snipe-IT assets to the requester's
+manager through an approval gate, and checking each asset out to its requester once approved.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/snipeit_requests.go
+package orchestrators
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/snipeit"
+)
+
+// Actions of an AssetRequestOutcome
+const (
+	AssetRequestAwaiting   = "awaiting approval" // The request was routed to the approver, who has not decided yet
+	AssetRequestCheckedOut = "checked out"       // The request was approved, and the asset checked out to the requester
+	AssetRequestDenied     = "denied"            // The approver denied the request; the asset was left as is
+	AssetRequestSkipped    = "skipped"           // The request could not be routed or fulfilled, see the detail
+)
+
+// AssetRequestOptions configures the Snipe-IT request approvals
+type AssetRequestOptions struct {
+	Gate     ApprovalGate // Gate asking approvers for a decision. Required
+	Approver string       // Approver of requests from users without a manager in Snipe-IT; such requests are skipped when empty
+	DryRun   bool         // Only report the decisions, without checking assets out
+}
+
+// AssetRequestOutcome is the result of processing a single pending request
+type AssetRequestOutcome struct {
+	Request  *snipeit.AssetRequest
+	Approver string // Email of the approver the request was routed to
+	Action   string // AssetRequestAwaiting, AssetRequestCheckedOut, AssetRequestDenied or AssetRequestSkipped
+	Detail   string // Additional detail, e.g. why the request was skipped
+	Applied  bool   // The asset was checked out (false under DryRun, or with the flag switched off)
+}
+
+/*
+ * Orchestrate the following:
+ * List the pending requests for requestable Snipe-IT assets
+ * Route each request to the requester's manager (or opts.Approver) through the approval gate
+ * Check the asset out to the requester once the request is approved
+ * Checkouts are only reported under DryRun, or with the snipeit-request-approvals flag switched off
+ */
+func (c *Client) SnipeITRequestApprovals(opts *AssetRequestOptions) ([]*AssetRequestOutcome, error) {
+	if opts == nil || opts.Gate == nil {
+		return nil, fmt.Errorf("snipe-it request approvals require an approval gate")
+	}
+	dryRun := opts.DryRun
+	if !dryRun && c.checkFlag(FlagSnipeITRequestApprovals) != nil {
+		dryRun = true
+	}
+
+	requests, err := c.SnipeIT.Assets().GetPendingRequests()
+	if err != nil {
+		return nil, err
+	}
+
+	outcomes := []*AssetRequestOutcome{}
+	var errs []*SourceError
+	for _, request := range requests {
+		outcome := &AssetRequestOutcome{Request: request}
+		outcomes = append(outcomes, outcome)
+
+		requester, approver, err := c.assetRequestApprover(request, opts)
+		if err != nil {
+			outcome.Action, outcome.Detail = AssetRequestSkipped, err.Error()
+			c.Log.Warningf("Skipping the request of %s for %s: %v", request.UserName, request.AssetName, err)
+			continue
+		}
+		outcome.Approver = approver
+
+		decision, err := opts.Gate.Decide(assetApprovalRequest(request, requester, approver))
+		if err != nil {
+			outcome.Action, outcome.Detail = AssetRequestSkipped, err.Error()
+			c.Log.Errorf("Unable to route the request of %s for %s: %v", requester, request.AssetName, err)
+			errs = append(errs, &SourceError{Source: "Approval gate", Err: err})
+			continue
+		}
+
+		switch decision {
+		case ApprovalApproved:
+			outcome.Action = AssetRequestCheckedOut
+		case ApprovalDenied:
+			outcome.Action = AssetRequestDenied
+			c.Log.Printf("%s denied the request of %s for %s", approver, requester, request.AssetName)
+			continue
+		default:
+			outcome.Action = AssetRequestAwaiting
+			continue
+		}
+
+		if dryRun {
+			c.Log.Printf("[dry run] %s would be checked out to %s, approved by %s", request.AssetName, requester, approver)
+			continue
+		}
+
+		note := fmt.Sprintf("Requested %s, approved by %s", request.RequestedAt.Format("2006-01-02"), approver)
+		if err := c.SnipeIT.Assets().CheckoutAsset(request.AssetID, request.UserID, note); err != nil {
+			outcome.Action, outcome.Detail = AssetRequestSkipped, err.Error()
+			c.Log.Errorf("Unable to check %s out to %s: %v", request.AssetName, requester, err)
+			errs = append(errs, &SourceError{Source: fmt.Sprintf("Snipe-IT (%s)", request.AssetName), Err: err})
+			continue
+		}
+		outcome.Applied = true
+		c.Log.Printf("Checked %s out to %s, approved by %s", request.AssetName, requester, approver)
+	}
+
+	c.Log.Printf("Snipe-IT request approvals: %d pending request(s)", len(requests))
+	return complete(c, "snipe-it request approvals", outcomes, errs)
+}
+
+/*
+ * Orchestrate the following:
+ * Process the pending Snipe-IT requests immediately, then on every interval until stopped, so approvals are fulfilled
+ */
+func (c *Client) ScheduleSnipeITRequestApprovals(opts *AssetRequestOptions, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := c.SnipeITRequestApprovals(opts); err != nil {
+			c.Log.Error("Error processing Snipe-IT request approvals:", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// assetRequestApprover returns the emails of the requester and of the manager approving their request
+func (c *Client) assetRequestApprover(request *snipeit.AssetRequest, opts *AssetRequestOptions) (string, string, error) {
+	user, err := c.SnipeIT.GetUser(request.UserID)
+	if err != nil {
+		return "", "", fmt.Errorf("looking up requester %d: %w", request.UserID, err)
+	}
+	requester := strings.ToLower(user.Email)
+
+	if user.Manager != nil && user.Manager.ID != 0 {
+		manager, err := c.SnipeIT.GetUser(user.Manager.ID)
+		if err != nil {
+			return requester, "", fmt.Errorf("looking up the manager of %s: %w", requester, err)
+		}
+		if manager.Email != "" {
+			return requester, strings.ToLower(manager.Email), nil
+		}
+	}
+
+	if opts.Approver == "" {
+		return requester, "", fmt.Errorf("%s has no manager in Snipe-IT", requester)
+	}
+	return requester, opts.Approver, nil
+}
+
+// assetApprovalRequest is the approval request of a pending asset request, identified by the asset, user and request time
+func assetApprovalRequest(request *snipeit.AssetRequest, requester, approver string) *ApprovalRequest {
+	return &ApprovalRequest{
+		ID:        fmt.Sprintf("snipeit-request-%d-%d-%d", request.AssetID, request.UserID, request.RequestedAt.Unix()),
+		Approver:  approver,
+		Requester: requester,
+		Title:     fmt.Sprintf("{Snipe-IT} %s requested %s", requester, request.AssetName),
+		Body:      fmt.Sprintf("%s requested the asset %s on %s. Once approved, it will be checked out to them.", requester, request.AssetName, request.RequestedAt.Format("2006-01-02")),
+		Fields: map[string]string{
+			"asset":    request.AssetName,
+			"asset_id": fmt.Sprint(request.AssetID),
+			"note":     request.Note,
+		},
+	}
+}
@@ -0,0 +1,118 @@
+/*
+# Orchestrators - Tenants
+
+This package contains a registry of per-tenant orchestration clients, allowing one deployment to manage
+the Google/Okta/Jamf organizations of several tenants (e.g. subsidiaries).
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/tenants.go
+package orchestrators
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/gemini-oss/rego/pkg/common/config"
+)
+
+// TenantBuilder creates the orchestration client of a tenant, e.g. `&Client{Okta: okta.NewClient(log.INFO), ...}`
+// It runs with the tenant selected in pkg/common/config, so clients pick up `{SERVICE}_{TENANT}_{NAME}` credentials,
+// rate limits (e.g. OKTA_ACME_RATE_LIMIT), and tenant specific cache and log files
+type TenantBuilder func(tenant string) (*Client, error)
+
+// TenantRegistry lazily creates and holds one orchestration client per tenant
+type TenantRegistry struct {
+	build   TenantBuilder
+	clients map[string]*Client
+	mutex   sync.Mutex
+}
+
+// NewTenantRegistry creates a registry building tenant clients with `build`
+func NewTenantRegistry(build TenantBuilder) *TenantRegistry {
+	return &TenantRegistry{
+		build:   build,
+		clients: make(map[string]*Client),
+	}
+}
+
+// Get returns the client of a tenant, creating it on first use
+func (r *TenantRegistry) Get(tenant string) (*Client, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if c, ok := r.clients[tenant]; ok {
+		return c, nil
+	}
+
+	var c *Client
+	err := config.UsingTenant(tenant, func() error {
+		var err error
+		c, err = r.build(tenant)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("tenant %s: %w", tenant, err)
+	}
+	if c == nil {
+		return nil, fmt.Errorf("tenant %s: builder returned no client", tenant)
+	}
+
+	c.Tenant = tenant
+	r.clients[tenant] = c
+	return c, nil
+}
+
+// Register adds an already configured client for a tenant, replacing any existing one
+func (r *TenantRegistry) Register(tenant string, c *Client) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	c.Tenant = tenant
+	r.clients[tenant] = c
+}
+
+// Tenants returns the tenants with a client, sorted by name
+func (r *TenantRegistry) Tenants() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	tenants := make([]string, 0, len(r.clients))
+	for tenant := range r.clients {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+	return tenants
+}
+
+/*
+ * Orchestrate the following:
+ * Run `fn` with the client of each tenant in `tenants` (default: REGO_TENANTS), one tenant at a time
+ * A failing tenant does not stop the others; every error is returned
+ */
+func (r *TenantRegistry) ForEach(tenants []string, fn func(tenant string, c *Client) error) error {
+	if tenants == nil {
+		tenants = config.Tenants()
+	}
+
+	var errs []error
+	for _, tenant := range tenants {
+		c, err := r.Get(tenant)
+		if err == nil {
+			err = fn(tenant, c)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("tenant %s: %w", tenant, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("error running across tenants: %v", errs)
+	}
+
+	return nil
+}
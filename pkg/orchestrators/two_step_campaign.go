@@ -0,0 +1,544 @@
+/*
+# Orchestrators - 2SV Enrollment Campaign
+
+This package contains a campaign getting Google users to enroll in 2-Step Verification: users who are not enrolled
+receive staged reminders by email and Slack, and the stragglers are finally moved to an organizational unit enforcing
+2SV. The progress of the campaign is tracked in a Google Sheet.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/orchestrators/two_step_campaign.go
+package orchestrators
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/notify"
+	"github.com/gemini-oss/rego/pkg/google"
+)
+
+// Stages of the 2SV enrollment campaign, for each user
+const (
+	TwoStepPending  = "pending"  // Not enrolled, and not reminded yet
+	TwoStepReminded = "reminded" // Not enrolled, and reminded at least once
+	TwoStepEnforced = "enforced" // Moved to the enforcement organizational unit
+	TwoStepEnrolled = "enrolled" // Enrolled after joining the campaign
+)
+
+// TwoStepCampaignOptions configures the 2SV enrollment campaign
+type TwoStepCampaignOptions struct {
+	Query           string          // Google user search query selecting the users of the campaign, e.g. `orgUnitPath='/Engineering'`. Default: every user
+	Exclude         []string        // Emails, or organizational unit paths (and their descendants), left out of the campaign
+	Reminders       []time.Duration // Time after joining the campaign at which each reminder is sent. Default: 0, 7 and 14 days
+	EnforceAfter    time.Duration   // Time after joining the campaign at which users still not enrolled are moved to EnforcementOU. Default: 21 days
+	EnforcementOU   string          // Organizational unit enforcing 2SV; users are only reminded when empty
+	RestoreOU       bool            // Move the enforced users back to their original organizational unit once enrolled
+	Slack           bool            // Also remind the users with a direct message from the Slack bot
+	SpreadsheetID   string          // Spreadsheet tracking the campaign; a new one is created (and kept in the state) when empty
+	Admins          notify.Notifier // Receives a summary of each run; skipped when nil
+	StateFile       string          // JSON file keeping the campaign across restarts; in memory only when empty
+	DryRun          bool            // Only report the reminders and moves which would be made
+	ReminderSubject string          // Subject of the reminders. Default: "Action required: enroll in 2-Step Verification"
+}
+
+// TwoStepEnrollee is the progress of a user in the 2SV enrollment campaign
+type TwoStepEnrollee struct {
+	Email        string    `json:"email" sheet:"Email"`
+	Name         string    `json:"name" sheet:"Name"`
+	OrgUnitPath  string    `json:"orgUnitPath" sheet:"Org Unit"` // Organizational unit of the user when they joined the campaign
+	Stage        string    `json:"stage" sheet:"Stage"`
+	Joined       time.Time `json:"joined" sheet:"Joined"`               // Time the user was first found not enrolled
+	Reminders    int       `json:"reminders" sheet:"Reminders"`         // Reminders sent
+	LastReminder time.Time `json:"lastReminder" sheet:"Last Reminder"`  // Time of the last reminder
+	Enforced     time.Time `json:"enforced,omitempty" sheet:"Enforced"` // Time the user was moved to the enforcement organizational unit
+	Enrolled     time.Time `json:"enrolled,omitempty" sheet:"Enrolled"` // Time the user was found enrolled
+}
+
+// TwoStepCampaignState is the state of the 2SV enrollment campaign kept in opts.StateFile
+type TwoStepCampaignState struct {
+	SpreadsheetID string             `json:"spreadsheetId"`
+	Users         []*TwoStepEnrollee `json:"users"`
+}
+
+// TwoStepCampaignAction is a step of the campaign taken (or planned, under DryRun) for a user
+type TwoStepCampaignAction struct {
+	User    *TwoStepEnrollee
+	Action  string // TwoStepReminded, TwoStepEnforced or TwoStepEnrolled
+	Applied bool   // The action was taken (false under DryRun, or with the flag switched off)
+	Err     error
+}
+
+// TwoStepCampaign runs the 2SV enrollment campaign, remembering where each user is in it
+type TwoStepCampaign struct {
+	client        *Client
+	opts          *TwoStepCampaignOptions
+	spreadsheetID string
+	users         map[string]*TwoStepEnrollee // Lowercase email -> progress
+	mutex         sync.Mutex
+}
+
+// twoStepReminder is the data of the `two_step_reminder` template
+type twoStepReminder struct {
+	Name      string
+	Email     string
+	Reminder  int    // Number of this reminder
+	Reminders int    // Number of reminders of the campaign
+	EnforceOn string // Date 2SV is enforced on the user; empty when the campaign does not enforce it
+}
+
+// Entry point for the 2SV enrollment campaign; the campaign is loaded from opts.StateFile when it exists
+func (c *Client) TwoStepCampaign(opts *TwoStepCampaignOptions) (*TwoStepCampaign, error) {
+	if opts == nil {
+		opts = &TwoStepCampaignOptions{}
+	}
+	if len(opts.Reminders) == 0 {
+		opts.Reminders = []time.Duration{0, 7 * 24 * time.Hour, 14 * 24 * time.Hour}
+	}
+	sort.Slice(opts.Reminders, func(i, j int) bool { return opts.Reminders[i] < opts.Reminders[j] })
+	if opts.EnforceAfter == 0 {
+		opts.EnforceAfter = 21 * 24 * time.Hour
+	}
+	if opts.ReminderSubject == "" {
+		opts.ReminderSubject = "Action required: enroll in 2-Step Verification"
+	}
+	if opts.Slack && c.Slack == nil {
+		return nil, fmt.Errorf("slack reminders configured without a slack client")
+	}
+
+	t := &TwoStepCampaign{
+		client:        c,
+		opts:          opts,
+		spreadsheetID: opts.SpreadsheetID,
+		users:         make(map[string]*TwoStepEnrollee),
+	}
+
+	if opts.StateFile != "" {
+		data, err := os.ReadFile(opts.StateFile)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			return nil, err
+		default:
+			state := &TwoStepCampaignState{}
+			if err := json.Unmarshal(data, state); err != nil {
+				return nil, fmt.Errorf("reading the 2SV campaign state %s: %w", opts.StateFile, err)
+			}
+			if t.spreadsheetID == "" {
+				t.spreadsheetID = state.SpreadsheetID
+			}
+			for _, user := range state.Users {
+				t.users[strings.ToLower(user.Email)] = user
+			}
+		}
+	}
+
+	return t, nil
+}
+
+// Users returns the users in the campaign, enrolled ones included
+func (t *TwoStepCampaign) Users() []*TwoStepEnrollee {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.sortedUsers()
+}
+
+/*
+ * Orchestrate the following:
+ * Find the active Google users of the campaign who are not enrolled in 2SV (IsEnrolledIn2Sv)
+ * Send each user the reminders due since they joined the campaign, by email and optionally Slack
+ * Move the users still not enrolled after opts.EnforceAfter to opts.EnforcementOU
+ * Mark the users who enrolled, optionally moving them back to their original organizational unit
+ * Write the progress of the campaign to a Google Sheet
+ * Actions are only reported under DryRun, or with the 2sv-enrollment-campaign flag switched off
+ */
+func (t *TwoStepCampaign) Run() ([]*TwoStepCampaignAction, error) {
+	c := t.client
+	dryRun := t.opts.DryRun
+	if !dryRun && c.checkFlag(FlagTwoStepCampaign) != nil {
+		dryRun = true
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	users, err := t.listUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	actions := []*TwoStepCampaignAction{}
+	var errs []*SourceError
+
+	// Users joining the campaign, and users who enrolled since the last run
+	seen := make(map[string]bool, len(users))
+	joined := []*TwoStepEnrollee{}
+	for _, user := range users {
+		key := strings.ToLower(user.PrimaryEmail)
+		seen[key] = true
+		enrollee, tracked := t.users[key]
+		switch {
+		case !tracked && !user.IsEnrolledIn2Sv:
+			enrollee = &TwoStepEnrollee{Email: user.PrimaryEmail, Name: user.Name.FullName, OrgUnitPath: user.OrgUnitPath, Stage: TwoStepPending, Joined: now}
+			if dryRun {
+				joined = append(joined, enrollee)
+				continue
+			}
+			t.users[key] = enrollee
+		case tracked && user.IsEnrolledIn2Sv && enrollee.Stage != TwoStepEnrolled:
+			action := &TwoStepCampaignAction{User: enrollee, Action: TwoStepEnrolled}
+			actions = append(actions, action)
+			if dryRun {
+				continue
+			}
+			if enrollee.Stage == TwoStepEnforced && t.opts.RestoreOU && !strings.EqualFold(user.OrgUnitPath, enrollee.OrgUnitPath) {
+				if action.Err = t.move(enrollee.OrgUnitPath, enrollee.Email); action.Err != nil {
+					continue
+				}
+			}
+			enrollee.Stage, enrollee.Enrolled = TwoStepEnrolled, now
+			action.Applied = true
+		}
+	}
+
+	// Reminders due, and users due for enforcement; users no longer found (e.g. suspended) are left alone
+	var slackIDs map[string]string
+	enforce := []*TwoStepCampaignAction{}
+	for _, enrollee := range append(t.sortedUsers(), joined...) {
+		if enrollee.Stage == TwoStepEnrolled || enrollee.Stage == TwoStepEnforced || !seen[strings.ToLower(enrollee.Email)] {
+			continue
+		}
+		elapsed := now.Sub(enrollee.Joined)
+
+		if t.opts.EnforcementOU != "" && elapsed >= t.opts.EnforceAfter {
+			enforce = append(enforce, &TwoStepCampaignAction{User: enrollee, Action: TwoStepEnforced})
+			continue
+		}
+
+		due := 0
+		for _, after := range t.opts.Reminders {
+			if elapsed >= after {
+				due++
+			}
+		}
+		if due <= enrollee.Reminders {
+			continue
+		}
+
+		action := &TwoStepCampaignAction{User: enrollee, Action: TwoStepReminded}
+		actions = append(actions, action)
+		if dryRun {
+			continue
+		}
+		if t.opts.Slack && slackIDs == nil {
+			if slackIDs, err = t.slackIDs(); err != nil {
+				errs = append(errs, &SourceError{Source: "Slack", Err: err})
+				slackIDs = map[string]string{}
+			}
+		}
+		if action.Err = t.remind(enrollee, slackIDs); action.Err != nil {
+			continue
+		}
+		enrollee.Stage, enrollee.Reminders, enrollee.LastReminder = TwoStepReminded, enrollee.Reminders+1, now
+		action.Applied = true
+	}
+
+	if len(enforce) > 0 {
+		actions = append(actions, enforce...)
+		if !dryRun {
+			t.enforce(enforce, now)
+		}
+	}
+
+	for _, action := range actions {
+		switch {
+		case action.Err != nil:
+			c.Log.Errorf("Unable to mark %s %s: %v", action.User.Email, action.Action, action.Err)
+			errs = append(errs, &SourceError{Source: action.User.Email, Err: action.Err})
+		case dryRun:
+			c.Log.Printf("[dry run] %s would be %s", action.User.Email, action.Action)
+		default:
+			c.Log.Printf("%s %s", action.User.Email, action.Action)
+		}
+	}
+
+	if !dryRun {
+		if err := t.updateSheet(); err != nil {
+			errs = append(errs, &SourceError{Source: "Google Sheets", Err: err})
+		}
+		if err := t.save(); err != nil {
+			errs = append(errs, &SourceError{Source: "State", Err: err})
+		}
+	}
+
+	if err := t.notifyAdmins(actions, dryRun); err != nil {
+		errs = append(errs, &SourceError{Source: "Notifications", Err: err})
+	}
+
+	c.Log.Printf("2SV enrollment campaign: %d user(s) in the campaign, %d action(s)", len(t.users), len(actions))
+	return complete(c, "2SV enrollment campaign", actions, errs)
+}
+
+/*
+ * Orchestrate the following:
+ * Run the 2SV enrollment campaign immediately, then on every interval (e.g. daily) until stopped
+ */
+func (t *TwoStepCampaign) Schedule(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := t.Run(); err != nil {
+			t.client.Log.Error("Error running the 2SV enrollment campaign:", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// listUsers returns the active users of the campaign, without the excluded ones
+func (t *TwoStepCampaign) listUsers() ([]*google.User, error) {
+	c := t.client
+
+	var all []*google.User
+	if t.opts.Query == "" {
+		users, err := c.Google.Users().ListAllUsers()
+		if err != nil {
+			return nil, err
+		}
+		all = users.Users
+	} else {
+		q := &google.UserQuery{Query: t.opts.Query, MaxResults: 500, Projection: google.BASIC}
+		for {
+			page, err := c.Google.Users().SearchUsers(q)
+			if err != nil {
+				return nil, err
+			}
+			all = append(all, page.Users...)
+			if page.NextPageToken == "" {
+				break
+			}
+			q.PageToken = page.NextPageToken
+		}
+	}
+
+	users := make([]*google.User, 0, len(all))
+	for _, user := range all {
+		if user.Suspended || user.Archived || t.excluded(user) {
+			continue
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+// excluded reports whether a user, or one of their organizational units, is listed in opts.Exclude
+func (t *TwoStepCampaign) excluded(user *google.User) bool {
+	for _, exclude := range t.opts.Exclude {
+		if strings.EqualFold(exclude, user.PrimaryEmail) {
+			return true
+		}
+		if strings.HasPrefix(exclude, "/") {
+			path := strings.TrimRight(strings.ToLower(exclude), "/") + "/"
+			if strings.HasPrefix(strings.ToLower(user.OrgUnitPath)+"/", path) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// slackIDs maps the emails of the Slack users to their IDs, to send them direct messages
+func (t *TwoStepCampaign) slackIDs() (map[string]string, error) {
+	members, err := t.client.Slack.ListUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]string, len(members.Members))
+	for _, member := range members.Members {
+		if member.Deleted || member.IsBot || member.Profile.Email == "" {
+			continue
+		}
+		ids[strings.ToLower(member.Profile.Email)] = member.ID
+	}
+	return ids, nil
+}
+
+// remind sends the next reminder to a user by email, and by Slack direct message when they have a Slack account
+func (t *TwoStepCampaign) remind(enrollee *TwoStepEnrollee, slackIDs map[string]string) error {
+	data := &twoStepReminder{
+		Name:      enrollee.Name,
+		Email:     enrollee.Email,
+		Reminder:  enrollee.Reminders + 1,
+		Reminders: len(t.opts.Reminders),
+	}
+	if t.opts.EnforcementOU != "" {
+		data.EnforceOn = enrollee.Joined.Add(t.opts.EnforceAfter).Format("2006-01-02")
+	}
+
+	message, err := notify.FromTemplate(t.client.Tenant, notify.Warning, t.opts.ReminderSubject, "two_step_reminder", data)
+	if err != nil {
+		return err
+	}
+
+	router := notify.NewRouter()
+	router.Route(notify.Info, notify.EmailNotifierFromEnv(enrollee.Email))
+	if id, ok := slackIDs[strings.ToLower(enrollee.Email)]; ok {
+		router.Route(notify.Info, &notify.SlackNotifier{Client: t.client.Slack, Channel: id})
+	}
+
+	return router.Notify(message)
+}
+
+// enforce moves the users due for enforcement to opts.EnforcementOU, in batches
+func (t *TwoStepCampaign) enforce(actions []*TwoStepCampaignAction, now time.Time) {
+	byEmail := make(map[string]*TwoStepCampaignAction, len(actions))
+	emails := make([]string, 0, len(actions))
+	for _, action := range actions {
+		byEmail[strings.ToLower(action.User.Email)] = action
+		emails = append(emails, action.User.Email)
+	}
+
+	moves, err := t.client.Google.OrgUnits().MoveUsers(t.opts.EnforcementOU, emails...)
+	if moves != nil {
+		for _, failure := range moves.Failed {
+			if action, ok := byEmail[strings.ToLower(failure.User)]; ok {
+				action.Err = failure.Err
+			}
+		}
+		for _, moved := range moves.Moved {
+			if action, ok := byEmail[strings.ToLower(moved)]; ok {
+				action.User.Stage, action.User.Enforced = TwoStepEnforced, now
+				action.Applied = true
+			}
+		}
+	}
+
+	// Users left out of a failed batch request
+	for _, action := range actions {
+		if !action.Applied && action.Err == nil {
+			action.Err = err
+			if action.Err == nil {
+				action.Err = fmt.Errorf("not moved to %s", t.opts.EnforcementOU)
+			}
+		}
+	}
+}
+
+// move moves a single user to an organizational unit
+func (t *TwoStepCampaign) move(orgUnitPath, email string) error {
+	moves, err := t.client.Google.OrgUnits().MoveUsers(orgUnitPath, email)
+	if err != nil {
+		return err
+	}
+	if len(moves.Failed) > 0 {
+		return moves.Failed[0].Err
+	}
+	return nil
+}
+
+// updateSheet writes the progress of every user to the campaign spreadsheet, creating it on the first run
+func (t *TwoStepCampaign) updateSheet() error {
+	c := t.client
+	if len(t.users) == 0 && t.spreadsheetID == "" {
+		return nil
+	}
+
+	if t.spreadsheetID == "" {
+		sheet, err := c.Google.Sheets().CreateSpreadsheet(&google.Spreadsheet{
+			Properties: &google.SpreadsheetProperties{
+				Title: fmt.Sprintf("{Google} 2SV Enrollment Campaign %s", time.Now().Format("2006-01-02")),
+			},
+			Sheets: []google.Sheet{
+				{
+					Properties: &google.SheetProperties{
+						Title: "Campaign",
+					},
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		t.spreadsheetID = sheet.SpreadsheetID
+		c.Log.Println("2SV enrollment campaign tracked in: ", sheet.SpreadsheetURL)
+	}
+
+	_, err := c.Google.Sheets().WriteStructs(t.spreadsheetID, "Campaign!A1", t.sortedUsers(), &google.WriteStructsOptions{FormatHeader: true})
+	return err
+}
+
+// notifyAdmins sends the administrators a summary of the actions of a run, if any
+func (t *TwoStepCampaign) notifyAdmins(actions []*TwoStepCampaignAction, dryRun bool) error {
+	if t.opts.Admins == nil || len(actions) == 0 {
+		return nil
+	}
+
+	counts := map[string]int{}
+	for _, user := range t.users {
+		counts[user.Stage]++
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d pending, %d reminded, %d enforced, %d enrolled\n\n", counts[TwoStepPending], counts[TwoStepReminded], counts[TwoStepEnforced], counts[TwoStepEnrolled])
+	for _, action := range actions {
+		fmt.Fprintf(&b, "  - %s %s", action.User.Email, action.Action)
+		if action.Err != nil {
+			fmt.Fprintf(&b, ": failed, %v", action.Err)
+		}
+		b.WriteString("\n")
+	}
+	if t.spreadsheetID != "" {
+		fmt.Fprintf(&b, "\nhttps://docs.google.com/spreadsheets/d/%s\n", t.spreadsheetID)
+	}
+
+	title := fmt.Sprintf("{Google} 2SV enrollment campaign %s (%d actions)", time.Now().Format("2006-01-02"), len(actions))
+	if dryRun {
+		title += " [dry run]"
+	}
+
+	return t.opts.Admins.Notify(&notify.Message{Severity: notify.Info, Title: title, Body: b.String()})
+}
+
+// save writes the campaign to opts.StateFile, if set
+func (t *TwoStepCampaign) save() error {
+	if t.opts.StateFile == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(&TwoStepCampaignState{SpreadsheetID: t.spreadsheetID, Users: t.sortedUsers()}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(t.opts.StateFile, data, 0o600)
+}
+
+func (t *TwoStepCampaign) sortedUsers() []*TwoStepEnrollee {
+	users := make([]*TwoStepEnrollee, 0, len(t.users))
+	for _, user := range t.users {
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return strings.ToLower(users[i].Email) < strings.ToLower(users[j].Email)
+	})
+
+	return users
+}
@@ -13,6 +13,7 @@ https://api.slack.com/web
 package slack
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/gemini-oss/rego/pkg/common/config"
@@ -33,6 +34,16 @@ func (c *Client) BuildURL(endpoint string, identifiers ...interface{}) string {
 	return url
 }
 
+/*
+ * WithContext returns a copy of the client whose requests end when ctx is done, e.g. to cancel a long pagination
+ */
+func (c *Client) WithContext(ctx context.Context) *Client {
+	clone := *c
+	clone.HTTP = c.HTTP.WithContext(ctx)
+	clone.Log = c.Log.WithContext(ctx)
+	return &clone
+}
+
 /*
   - # Generate Slack Client
   - @param log *log.Logger
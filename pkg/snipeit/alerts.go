@@ -0,0 +1,123 @@
+/*
+# SnipeIT - Alerts
+
+This package builds reports of Snipe-IT assets and licenses needing attention (expiring warranties, end of life,
+expiring licenses and fully depreciated assets):
+https://snipe-it.readme.io/reference/hardware-list
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/snipeit/alerts.go
+package snipeit
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+ * # Asset Expiry Report
+ * Gathers assets whose warranty or EOL date falls within the next `days` days, licenses expiring or terminating
+ * within the same window, and deployed assets which are fully depreciated
+ */
+func (c *Client) ExpiryReport(days int) (*ExpiryReport, error) {
+	assets, err := c.Assets().GetAllAssets()
+	if err != nil {
+		return nil, err
+	}
+
+	licenses, err := c.Licenses().GetAllLicenses()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Truncate(24 * time.Hour)
+	report := &ExpiryReport{Days: days}
+
+	for _, asset := range rowsOf(assets.Rows) {
+		if asset.DeletedAt != "" {
+			continue
+		}
+
+		if alert := expiringAlert("warranty", asset.WarrantyExpires.Time(), now, days); alert != nil {
+			report.Warranty = append(report.Warranty, assetAlert(alert, asset))
+		}
+		if alert := expiringAlert("eol", asset.AssetEOLDate.Time(), now, days); alert != nil {
+			report.EOL = append(report.EOL, assetAlert(alert, asset))
+		}
+		if fullyDepreciated(asset) {
+			alert := &AssetAlert{Kind: "depreciated", Date: asset.PurchaseDate.Time()}
+			report.Depreciated = append(report.Depreciated, assetAlert(alert, asset))
+		}
+	}
+
+	for _, license := range rowsOf(licenses.Rows) {
+		date := license.ExpirationDate.Time()
+		if t := license.TerminationDate.Time(); !t.IsZero() && (date.IsZero() || t.Before(date)) {
+			date = t
+		}
+		if alert := expiringAlert("license", date, now, days); alert != nil {
+			alert.ID = license.ID
+			alert.Name = license.Name
+			report.Licenses = append(report.Licenses, alert)
+		}
+	}
+
+	for _, alerts := range [][]*AssetAlert{report.Warranty, report.EOL, report.Licenses, report.Depreciated} {
+		sort.Slice(alerts, func(i, j int) bool { return alerts[i].Date.Before(alerts[j].Date) })
+	}
+
+	c.Log.Printf("Expiry report (%d days): %d warranties, %d EOL, %d licenses, %d fully depreciated",
+		days, len(report.Warranty), len(report.EOL), len(report.Licenses), len(report.Depreciated))
+
+	return report, nil
+}
+
+// expiringAlert returns an alert if `date` falls within the next `days` days
+func expiringAlert(kind string, date, now time.Time, days int) *AssetAlert {
+	if date.IsZero() {
+		return nil
+	}
+
+	remaining := int(date.Sub(now).Hours() / 24)
+	if remaining < 0 || remaining > days {
+		return nil
+	}
+
+	return &AssetAlert{
+		Kind:          kind,
+		Date:          date,
+		DaysRemaining: remaining,
+	}
+}
+
+func assetAlert(alert *AssetAlert, asset *Hardware) *AssetAlert {
+	alert.ID = asset.ID
+	alert.Name = asset.Name
+	alert.AssetTag = asset.AssetTag
+	alert.Serial = asset.Serial
+	if asset.AssignedTo != nil {
+		alert.AssignedTo = asset.AssignedTo.Name
+	}
+	return alert
+}
+
+// fullyDepreciated reports whether a deployed asset with a purchase cost has no remaining book value
+func fullyDepreciated(asset *Hardware) bool {
+	if asset.BookValue == "" || asset.StatusLabel == nil || !strings.EqualFold(asset.StatusLabel.StatusMeta, "deployed") {
+		return false
+	}
+
+	cost, err := strconv.ParseFloat(strings.ReplaceAll(asset.PurchaseCost, ",", ""), 64)
+	if err != nil || cost <= 0 {
+		return false
+	}
+
+	value, err := strconv.ParseFloat(strings.ReplaceAll(asset.BookValue, ",", ""), 64)
+	return err == nil && value <= 0
+}
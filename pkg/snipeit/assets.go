@@ -156,8 +156,19 @@ func (c *AssetClient) CreateAsset(p *Hardware) (*Hardware, error) {
 /* Partially updates a specific asset in Snipe-IT
  * /api/v1/hardware/{id}
  * - https://snipe-it.readme.io/reference/hardware-partial-update
+ * - Status changes (StatusID) are validated against the client's Lifecycle, when set
+ * - BYOD is optional, so an asset can be marked as company-owned again: `BYOD: optional.Of(false)`
  */
 func (c *AssetClient) PartialUpdateAsset(id int, p *Hardware) (*Hardware, error) {
+	if err := c.checkLifecycle(id, p); err != nil {
+		return nil, err
+	}
+
+	return c.updateAsset(id, p)
+}
+
+// updateAsset sends a partial update, without checking the Lifecycle
+func (c *AssetClient) updateAsset(id int, p *Hardware) (*Hardware, error) {
 	url := c.BuildURL(Assets, id)
 
 	hardware, err := do[SnipeITResponse[Hardware]](c.Client, "PATCH", url, nil, p)
@@ -14,6 +14,7 @@ package snipeit
 
 import (
 	"reflect"
+	"time"
 
 	"github.com/gemini-oss/rego/pkg/common/cache"
 	"github.com/gemini-oss/rego/pkg/common/log"
@@ -27,6 +28,8 @@ type Client struct {
 	HTTP    *requests.Client // HTTP client for the SnipeIT API.
 	Log     *log.Logger      // Log is the logger for the SnipeIT API.
 	Cache   *cache.Cache     // Cache for the SnipeIT API.
+
+	Lifecycle *Lifecycle // Asset status transitions enforced on updates; none when nil. See DefaultLifecycle
 }
 
 // PaginatedList is a generic structure representing a paginated response from SnipeIT with items of any type.
@@ -100,11 +103,13 @@ type Hardware struct {
 	AssetTag         string            `json:"asset_tag,omitempty"`         // Asset tag of the hardware item.
 	Serial           string            `json:"serial,omitempty"`            // Serial number of the hardware item.
 	Model            *Record           `json:"model,omitempty"`             // Model of the hardware item.
-	BYOD             bool              `json:"byod,omitempty"`              // Whether the hardware item is BYOD.
+	BYOD             *bool             `json:"byod,omitempty"`              // Whether the hardware item is BYOD.
 	ModelNumber      string            `json:"model_number,omitempty"`      // Model number of the hardware item.
 	EOL              int               `json:"eol,omitempty"`               // End of life of the hardware item.
 	AssetEOLDate     *DateInfo         `json:"asset_eol_date,omitempty"`    // Asset end of life date of the hardware item.
 	StatusLabel      *StatusLabel      `json:"status_label,omitempty"`      // Status label of the hardware item.
+	StatusID         int               `json:"status_id,omitempty"`         // ID of the status label to set (requests only).
+	ModelID          int               `json:"model_id,omitempty"`          // ID of the model to set (requests only).
 	Category         *Record           `json:"category,omitempty"`          // Category of the hardware item.
 	Manufacturer     *Record           `json:"manufacturer,omitempty"`      // Manufacturer of the hardware item.
 	Supplier         *Record           `json:"supplier,omitempty"`          // Supplier of the hardware item.
@@ -118,7 +123,7 @@ type Hardware struct {
 	AltBarcode       string            `json:"alt_barcode,omitempty"`       // Alternate barcode of the hardware item.
 	AssignedTo       *User             `json:"assigned_to,omitempty"`       // User to whom the hardware item is assigned.
 	WarrantyMonths   string            `json:"warranty_months,omitempty"`   // Warranty months of the hardware item.
-	WarrantyExpires  string            `json:"warranty_expires,omitempty"`  // Warranty expiry date of the hardware item.
+	WarrantyExpires  *DateInfo         `json:"warranty_expires,omitempty"`  // Warranty expiry date of the hardware item.
 	CreatedAt        *DateInfo         `json:"created_at,omitempty"`        // Time when the hardware item was created.
 	UpdatedAt        *DateInfo         `json:"updated_at,omitempty"`        // Time when the hardware item was last updated.
 	LastAuditDate    string            `json:"last_audit_date,omitempty"`   // Last audit date of the hardware item.
@@ -129,6 +134,7 @@ type Hardware struct {
 	LastCheckout     *DateInfo         `json:"last_checkout,omitempty"`     // Time when the hardware item was last checked out.
 	ExpectedCheckin  *DateInfo         `json:"expected_checkin,omitempty"`  // Expected check-in date of the hardware item.
 	PurchaseCost     string            `json:"purchase_cost,omitempty"`     // Purchase cost of the hardware item.
+	BookValue        string            `json:"book_value,omitempty"`        // Current (depreciated) value of the hardware item.
 	CheckinCounter   int               `json:"checkin_counter,omitempty"`   // Check-in counter of the hardware item.
 	CheckoutCounter  int               `json:"checkout_counter,omitempty"`  // Check-out counter of the hardware item.
 	RequestsCounter  int               `json:"requests_counter,omitempty"`  // Request counter of the hardware item.
@@ -287,6 +293,181 @@ type User struct {
 // END OF USER STRUCTS
 //-------------------------------------------------------------------------
 
+// ### Licenses
+// -------------------------------------------------------------------------
+// Source: https://snipe-it.readme.io/reference/licenses
+type LicenseList = PaginatedList[License]
+
+// License represents an individual software license (or subscription).
+// https://snipe-it.readme.io/reference/licenses
+type License struct {
+	ID               int               `json:"id,omitempty"`                // ID of the license.
+	Name             string            `json:"name,omitempty"`              // Name of the license.
+	Company          *Record           `json:"company,omitempty"`           // Company of the license.
+	Manufacturer     *Record           `json:"manufacturer,omitempty"`      // Manufacturer of the license.
+	ProductKey       string            `json:"product_key,omitempty"`       // Product key of the license.
+	OrderNumber      string            `json:"order_number,omitempty"`      // Order number of the license.
+	PurchaseOrder    string            `json:"purchase_order,omitempty"`    // Purchase order of the license.
+	PurchaseDate     *DateInfo         `json:"purchase_date,omitempty"`     // Purchase date of the license.
+	TerminationDate  *DateInfo         `json:"termination_date,omitempty"`  // Termination date of the license.
+	Depreciation     *Record           `json:"depreciation,omitempty"`      // Depreciation of the license.
+	PurchaseCost     string            `json:"purchase_cost,omitempty"`     // Purchase cost of the license.
+	Notes            string            `json:"notes,omitempty"`             // Notes associated with the license.
+	ExpirationDate   *DateInfo         `json:"expiration_date,omitempty"`   // Expiration date of the license.
+	Seats            int               `json:"seats,omitempty"`             // Total number of seats.
+	FreeSeatsCount   int               `json:"free_seats_count,omitempty"`  // Number of unassigned seats.
+	LicenseName      string            `json:"license_name,omitempty"`      // Name the license is registered to.
+	LicenseEmail     string            `json:"license_email,omitempty"`     // Email the license is registered to.
+	Reassignable     bool              `json:"reassignable,omitempty"`      // Whether seats can be reassigned.
+	Maintained       bool              `json:"maintained,omitempty"`        // Whether the license is under maintenance.
+	Supplier         *Record           `json:"supplier,omitempty"`          // Supplier of the license.
+	Category         *Record           `json:"category,omitempty"`          // Category of the license.
+	CreatedAt        *DateInfo         `json:"created_at,omitempty"`        // Time when the license was created.
+	UpdatedAt        *DateInfo         `json:"updated_at,omitempty"`        // Time when the license was last updated.
+	UserCanCheckout  bool              `json:"user_can_checkout,omitempty"` // Whether the license can be checked out.
+	AvailableActions *AvailableActions `json:"available_actions,omitempty"` // Available actions for the license.
+}
+
+// END OF LICENSE STRUCTS
+//-------------------------------------------------------------------------
+
+// ### Alerts
+// -------------------------------------------------------------------------
+// AssetAlert is a single asset or license needing attention. **ReGo only**
+type AssetAlert struct {
+	Kind          string    // Reason for the alert. {warranty, eol, license, depreciated}
+	ID            int       // ID of the asset or license.
+	Name          string    // Name of the asset or license.
+	AssetTag      string    // Asset tag, for assets.
+	Serial        string    // Serial number, for assets.
+	AssignedTo    string    // Name of the user the asset is assigned to, if any.
+	Date          time.Time // Date the warranty/EOL/license expires, or the purchase date for depreciated assets.
+	DaysRemaining int       // Days until Date; negative once it has passed.
+}
+
+// ExpiryReport groups the assets and licenses needing attention. **ReGo only**
+type ExpiryReport struct {
+	Days        int           // Look-ahead window of the report, in days.
+	Warranty    []*AssetAlert // Assets whose warranty expires within the window.
+	EOL         []*AssetAlert // Assets reaching end of life within the window.
+	Licenses    []*AssetAlert // Licenses (leases, subscriptions) expiring or terminating within the window.
+	Depreciated []*AssetAlert // Deployed assets which are fully depreciated.
+}
+
+// END OF ALERT STRUCTS
+//-------------------------------------------------------------------------
+
+// ### Kits
+// -------------------------------------------------------------------------
+// Source: https://snipe-it.readme.io/reference/kits
+type KitList = PaginatedList[Kit]
+
+// Kit represents a predefined bundle of models, licenses, accessories and consumables.
+type Kit struct {
+	ID               int               `json:"id,omitempty"`                // ID of the kit.
+	Name             string            `json:"name,omitempty"`              // Name of the kit.
+	CreatedAt        *DateInfo         `json:"created_at,omitempty"`        // Time when the kit was created.
+	UpdatedAt        *DateInfo         `json:"updated_at,omitempty"`        // Time when the kit was last updated.
+	AvailableActions *AvailableActions `json:"available_actions,omitempty"` // Available actions for the kit.
+}
+
+// Source: https://snipe-it.readme.io/reference/kits-models
+type KitItemList = PaginatedList[KitItem]
+
+// KitItem represents a single {model, license, accessory, consumable} within a kit.
+type KitItem struct {
+	ID               int               `json:"id,omitempty"`                // ID of the {model, license, accessory, consumable}.
+	Name             string            `json:"name,omitempty"`              // Name of the {model, license, accessory, consumable}.
+	Quantity         int               `json:"quantity,omitempty"`          // Number of items of this type in the kit.
+	PivotID          int               `json:"pivot_id,omitempty"`          // ID of the kit <-> item relation.
+	OwnerID          int               `json:"owner_id,omitempty"`          // ID of the kit.
+	AvailableActions *AvailableActions `json:"available_actions,omitempty"` // Available actions for the item.
+}
+
+// KitItemRequest attaches a {model, license, accessory, consumable} to a kit.
+type KitItemRequest struct {
+	Model      int `json:"model,omitempty"`      // ID of the model to attach.
+	License    int `json:"license,omitempty"`    // ID of the license to attach.
+	Accessory  int `json:"accessory,omitempty"`  // ID of the accessory to attach.
+	Consumable int `json:"consumable,omitempty"` // ID of the consumable to attach.
+	Quantity   int `json:"quantity,omitempty"`   // Number of items of this type in the kit.
+}
+
+// CheckoutRequest checks an item out to a user.
+// https://snipe-it.readme.io/reference/hardware-checkout
+type CheckoutRequest struct {
+	CheckoutToType string `json:"checkout_to_type,omitempty" validate:"enum=user|asset|location"` // Type of the target, for assets. {user, asset, location}
+	AssignedUser   int64  `json:"assigned_user,omitempty"`                                        // ID of the user to check an asset out to.
+	AssignedTo     int64  `json:"assigned_to,omitempty"`                                          // ID of the user to check an {accessory, consumable, license seat} out to.
+	Note           string `json:"note,omitempty"`                                                 // Note recorded on the checkout.
+}
+
+// Source: https://snipe-it.readme.io/reference/licenses-seats-list
+type LicenseSeatList = PaginatedList[LicenseSeat]
+
+// LicenseSeat represents a single seat of a license.
+type LicenseSeat struct {
+	ID              int     `json:"id,omitempty"`                // ID of the seat.
+	LicenseID       int     `json:"license_id,omitempty"`        // ID of the license the seat belongs to.
+	Name            string  `json:"name,omitempty"`              // Name of the seat.
+	AssignedUser    *Record `json:"assigned_user,omitempty"`     // User the seat is checked out to.
+	AssignedAsset   *Record `json:"assigned_asset,omitempty"`    // Asset the seat is checked out to.
+	Reassignable    bool    `json:"reassignable,omitempty"`      // Whether the seat can be reassigned.
+	UserCanCheckout bool    `json:"user_can_checkout,omitempty"` // Whether the seat can be checked out.
+}
+
+// KitCheckout summarizes the items checked out for a kit. **ReGo only**
+type KitCheckout struct {
+	Kit         *Kit    // Kit that was checked out.
+	UserID      int64   // ID of the user the kit was checked out to.
+	Assets      []int   // IDs of the assets checked out.
+	Licenses    []int   // IDs of the license seats checked out.
+	Accessories []int   // IDs of the accessories checked out.
+	Consumables []int   // IDs of the consumables checked out.
+	Errors      []error // Items which could not be checked out.
+}
+
+// END OF KIT STRUCTS
+//-------------------------------------------------------------------------
+
+// ### Requests
+// -------------------------------------------------------------------------
+// Source: https://snipe-it.readme.io/reference/api-reports
+type ActivityList = PaginatedList[Activity]
+
+// Activity represents an entry of the activity report.
+type Activity struct {
+	ID         int           `json:"id,omitempty"`          // ID of the log entry.
+	ActionType string        `json:"action_type,omitempty"` // Action performed, e.g. `checkout`, `requested`, `request canceled`.
+	Item       *ActivityItem `json:"item,omitempty"`        // Item acted upon, e.g. the requested asset.
+	Target     *ActivityItem `json:"target,omitempty"`      // Target of the action, e.g. the requesting user.
+	Admin      *Record       `json:"admin,omitempty"`       // User who performed the action.
+	Location   *Record       `json:"location,omitempty"`    // Location of the item.
+	Note       string        `json:"note,omitempty"`        // Note recorded with the action.
+	CreatedAt  *DateInfo     `json:"created_at,omitempty"`  // Time when the action was logged.
+	ActionDate *DateInfo     `json:"action_date,omitempty"` // Time when the action took place.
+}
+
+// ActivityItem represents the item or target of an activity.
+type ActivityItem struct {
+	ID   int64  `json:"id,omitempty"`   // ID of the {asset, accessory, license, user, location, etc.}
+	Name string `json:"name,omitempty"` // Name of the {asset, accessory, license, user, location, etc.}
+	Type string `json:"type,omitempty"` // Type of the record, e.g. `asset`, `user`.
+}
+
+// AssetRequest is a pending request of a user for a requestable asset. **ReGo only**
+type AssetRequest struct {
+	AssetID     int       // ID of the requested asset.
+	AssetName   string    // Name of the requested asset.
+	UserID      int64     // ID of the requesting user.
+	UserName    string    // Name of the requesting user.
+	Note        string    // Note left by the user with the request.
+	RequestedAt time.Time // Time when the request was made.
+}
+
+// END OF REQUEST STRUCTS
+//-------------------------------------------------------------------------
+
 // ### Common Asset types
 // -------------------------------------------------------------------------
 // Record represents an id:name pairing for many types of records in Snipe-IT.
@@ -298,10 +479,29 @@ type Record struct {
 // DateInfo represents a date and its formatted representation.
 type DateInfo struct {
 	Date      string `json:"datetime,omitempty"`  // The date in yyyy-mm-dd format.
+	Day       string `json:"date,omitempty"`      // The date in yyyy-mm-dd format, for date-only fields (purchase, warranty, EOL, expiration dates).
 	Formatted string `json:"formatted,omitempty"` // The formatted date.
 }
 
+// Time parses the date, returning the zero time if it is unset or malformed
+func (d *DateInfo) Time() time.Time {
+	if d == nil {
+		return time.Time{}
+	}
+	for _, v := range []string{d.Day, d.Date} {
+		for _, layout := range []string{"2006-01-02", "2006-01-02 15:04:05"} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t
+			}
+		}
+	}
+	return time.Time{}
+}
+
 // StatusLabel represents the status label of a hardware item.
+// Source: https://snipe-it.readme.io/reference/status-labels
+type StatusLabelList = PaginatedList[StatusLabel]
+
 type StatusLabel struct {
 	ID         int    `json:"id,omitempty"`          // ID of the status label.
 	Name       string `json:"name,omitempty"`        // Name of thestatus label.
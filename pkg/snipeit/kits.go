@@ -0,0 +1,339 @@
+/*
+# SnipeIT - Kits
+
+This package initializes all the methods for functions which interact with the SnipeIT Predefined Kits endpoints:
+https://snipe-it.readme.io/reference/kits
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/snipeit/kits.go
+package snipeit
+
+import (
+	"fmt"
+	"time"
+)
+
+// KitClient for chaining methods
+type KitClient struct {
+	*Client
+}
+
+// Entry point for kit-related operations
+func (c *Client) Kits() *KitClient {
+	kc := &KitClient{
+		Client: c,
+	}
+
+	return kc
+}
+
+/*
+ * Query Parameters for Kits
+ */
+type KitQuery struct {
+	Limit  int    `url:"limit,omitempty"`  // Specify the number of results you wish to return. Defaults to 50.
+	Offset int    `url:"offset,omitempty"` // Specify the number of results to skip before starting to return items. Defaults to 0.
+	Search string `url:"search,omitempty"` // Search for a kit by name.
+	Sort   string `url:"sort,omitempty"`   // Sort the results by the specified column. Defaults to id.
+	Order  string `url:"order,omitempty"`  // Sort the results in the specified order. Defaults to asc.
+}
+
+// ### KitQuery implements QueryInterface
+// ---------------------------------------------------------------------
+func (q *KitQuery) Copy() QueryInterface {
+	return &KitQuery{
+		Limit:  q.Limit,
+		Offset: q.Offset,
+		Search: q.Search,
+		Sort:   q.Sort,
+		Order:  q.Order,
+	}
+}
+
+func (q *KitQuery) GetLimit() int {
+	return q.Limit
+}
+
+func (q *KitQuery) SetLimit(limit int) {
+	q.Limit = limit
+}
+
+func (q *KitQuery) GetOffset() int {
+	return q.Offset
+}
+
+func (q *KitQuery) SetOffset(offset int) {
+	q.Offset = offset
+}
+
+// END OF QUERYINTERFACE METHODS
+//---------------------------------------------------------------------
+
+/*
+ * # List all Kits in Snipe-IT
+ * /api/v1/kits
+ * - https://snipe-it.readme.io/reference/kits
+ */
+func (c *KitClient) GetAllKits() (*KitList, error) {
+	url := c.BuildURL(Kits)
+
+	q := KitQuery{
+		Limit: 50,
+	}
+
+	var cache KitList
+	if c.GetCache(url, &cache) {
+		return &cache, nil
+	}
+
+	kits, err := doConcurrent[KitList](c.Client, "GET", url, &q, nil)
+	if err != nil {
+		c.Log.Warningf("Error fetching kits: %v", err)
+		return nil, err
+	}
+
+	c.SetCache(url, kits, 5*time.Minute)
+	return kits, nil
+}
+
+/*
+ * # Get a Kit in Snipe-IT
+ * /api/v1/kits/{id}
+ * - https://snipe-it.readme.io/reference/kits-by-id
+ */
+func (c *KitClient) GetKit(id int) (*Kit, error) {
+	url := c.BuildURL(Kits, id)
+
+	kit, err := do[Kit](c.Client, "GET", url, nil, nil)
+	if err != nil {
+		c.Log.Warningf("Error fetching kit: %v", err)
+		return nil, err
+	}
+
+	return &kit, nil
+}
+
+/*
+ * # Create a Kit in Snipe-IT
+ * /api/v1/kits
+ * - https://snipe-it.readme.io/reference/kits-create
+ */
+func (c *KitClient) CreateKit(name string) (*Kit, error) {
+	url := c.BuildURL(Kits)
+
+	kit, err := do[SnipeITResponse[Kit]](c.Client, "POST", url, nil, &Kit{Name: name})
+	if err != nil {
+		c.Log.Warningf("Error creating kit: %v", err)
+		return nil, err
+	}
+
+	return kit.Payload, nil
+}
+
+/*
+ * # Delete a Kit in Snipe-IT
+ * /api/v1/kits/{id}
+ * - https://snipe-it.readme.io/reference/kits-delete
+ */
+func (c *KitClient) DeleteKit(id int) error {
+	url := c.BuildURL(Kits, id)
+
+	_, err := do[SnipeITResponse[Kit]](c.Client, "DELETE", url, nil, nil)
+	if err != nil {
+		c.Log.Warningf("Error deleting kit: %v", err)
+	}
+
+	return err
+}
+
+/*
+ * # List the {models, licenses, accessories, consumables} of a Kit
+ * /api/v1/kits/{id}/{models, licenses, accessories, consumables}
+ * - https://snipe-it.readme.io/reference/kits-models
+ */
+func (c *KitClient) GetKitItems(id int, itemType string) (*KitItemList, error) {
+	url := c.BuildURL(Kits, id, itemType)
+
+	items, err := do[KitItemList](c.Client, "GET", url, nil, nil)
+	if err != nil {
+		c.Log.Warningf("Error fetching kit %s: %v", itemType, err)
+		return nil, err
+	}
+
+	return &items, nil
+}
+
+/*
+ * # Attach a {model, license, accessory, consumable} to a Kit
+ * /api/v1/kits/{id}/{models, licenses, accessories, consumables}
+ * - https://snipe-it.readme.io/reference/kits-models-create
+ */
+func (c *KitClient) AddKitItem(id int, itemType string, item *KitItemRequest) error {
+	url := c.BuildURL(Kits, id, itemType)
+
+	res, err := do[SnipeITResponse[KitItem]](c.Client, "POST", url, nil, item)
+	if err != nil {
+		c.Log.Warningf("Error adding item to kit: %v", err)
+		return err
+	}
+	if res.Status == "error" {
+		return fmt.Errorf("adding %s to kit %d: %s", itemType, id, res.Messages)
+	}
+
+	return nil
+}
+
+/*
+ * # Check out a Kit to a User
+ * Snipe-IT only exposes kit checkout through the web UI, so each item of the kit is checked out individually:
+ * - Models: a `Ready to Deploy` asset of the model is checked out for each unit
+ * - Licenses: a free seat is checked out for each unit
+ * - Accessories and Consumables: checked out once for each unit
+ * Items which cannot be checked out are recorded on the result rather than aborting the checkout.
+ */
+func (c *KitClient) CheckoutKit(id int, userID int64, note string) (*KitCheckout, error) {
+	kit, err := c.GetKit(id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &KitCheckout{
+		Kit:    kit,
+		UserID: userID,
+	}
+
+	items := map[string]*KitItemList{}
+	for _, itemType := range []string{"models", "licenses", "accessories", "consumables"} {
+		list, err := c.GetKitItems(id, itemType)
+		if err != nil {
+			return nil, err
+		}
+		items[itemType] = list
+	}
+
+	for _, model := range kitItems(items["models"]) {
+		assets, err := c.readyAssets(model.ID, model.Quantity)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("model %s: %w", model.Name, err))
+			continue
+		}
+		for _, asset := range assets {
+			err := c.checkout(fmt.Sprintf("%s/%d/checkout", Assets, asset.ID), &CheckoutRequest{CheckoutToType: "user", AssignedUser: userID, Note: note})
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("asset %s: %w", asset.AssetTag, err))
+				continue
+			}
+			result.Assets = append(result.Assets, asset.ID)
+		}
+	}
+
+	for _, license := range kitItems(items["licenses"]) {
+		seats, err := c.freeSeats(license.ID, license.Quantity)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("license %s: %w", license.Name, err))
+			continue
+		}
+		for _, seat := range seats {
+			url := c.BuildURL(Licenses, license.ID, "seats", seat.ID)
+			_, err := do[SnipeITResponse[LicenseSeat]](c.Client, "PATCH", url, nil, &CheckoutRequest{AssignedTo: userID, Note: note})
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("license %s: %w", license.Name, err))
+				continue
+			}
+			result.Licenses = append(result.Licenses, seat.ID)
+		}
+	}
+
+	for itemType, endpoint := range map[string]string{"accessories": Accessories, "consumables": Consumables} {
+		for _, item := range kitItems(items[itemType]) {
+			for i := 0; i < max(item.Quantity, 1); i++ {
+				err := c.checkout(fmt.Sprintf("%s/%d/checkout", endpoint, item.ID), &CheckoutRequest{AssignedTo: userID, AssignedUser: userID, Note: note})
+				if err != nil {
+					result.Errors = append(result.Errors, fmt.Errorf("%s %s: %w", itemType, item.Name, err))
+					break
+				}
+				if itemType == "accessories" {
+					result.Accessories = append(result.Accessories, item.ID)
+				} else {
+					result.Consumables = append(result.Consumables, item.ID)
+				}
+			}
+		}
+	}
+
+	c.Log.Printf("Checked out kit %q to user %d: %d assets, %d license seats, %d accessories, %d consumables, %d errors",
+		kit.Name, userID, len(result.Assets), len(result.Licenses), len(result.Accessories), len(result.Consumables), len(result.Errors))
+
+	return result, nil
+}
+
+// checkout performs a single checkout request, surfacing Snipe-IT's in-body errors
+func (c *Client) checkout(endpoint string, req *CheckoutRequest) error {
+	url := c.BuildURL(endpoint)
+
+	res, err := do[SnipeITResponse[interface{}]](c, "POST", url, nil, req)
+	if err != nil {
+		return err
+	}
+	if res.Status == "error" {
+		return fmt.Errorf("%s", res.Messages)
+	}
+
+	return nil
+}
+
+// readyAssets returns `quantity` Ready to Deploy assets of a model
+func (c *KitClient) readyAssets(modelID, quantity int) ([]*Hardware, error) {
+	url := c.BuildURL(Assets)
+
+	q := &AssetQuery{
+		Limit:   max(quantity, 1),
+		ModelID: modelID,
+		Status:  "RTD",
+	}
+
+	assets, err := do[HardwareList](c.Client, "GET", url, q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ready := rowsOf(assets.Rows)
+	if len(ready) < max(quantity, 1) {
+		return nil, fmt.Errorf("only %d of %d assets are ready to deploy", len(ready), max(quantity, 1))
+	}
+
+	return ready, nil
+}
+
+// freeSeats returns `quantity` unassigned seats of a license
+func (c *KitClient) freeSeats(licenseID, quantity int) ([]*LicenseSeat, error) {
+	url := c.BuildURL(Licenses, licenseID, "seats")
+
+	seats, err := doConcurrent[LicenseSeatList](c.Client, "GET", url, &KitQuery{Limit: 50}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	free := []*LicenseSeat{}
+	for _, seat := range rowsOf(seats.Rows) {
+		if seat.AssignedUser == nil && seat.AssignedAsset == nil {
+			free = append(free, seat)
+		}
+		if len(free) == max(quantity, 1) {
+			return free, nil
+		}
+	}
+
+	return nil, fmt.Errorf("only %d of %d seats are available", len(free), max(quantity, 1))
+}
+
+func kitItems(list *KitItemList) []*KitItem {
+	if list == nil {
+		return nil
+	}
+	return rowsOf(list.Rows)
+}
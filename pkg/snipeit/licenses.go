@@ -0,0 +1,106 @@
+/*
+# SnipeIT - Licenses
+
+This package initializes all the methods for functions which interact with the SnipeIT Licenses endpoints:
+https://snipe-it.readme.io/reference/licenses
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/snipeit/licenses.go
+package snipeit
+
+import (
+	"time"
+)
+
+// LicenseClient for chaining methods
+type LicenseClient struct {
+	*Client
+}
+
+// Entry point for license-related operations
+func (c *Client) Licenses() *LicenseClient {
+	lc := &LicenseClient{
+		Client: c,
+	}
+
+	return lc
+}
+
+/*
+ * Query Parameters for Licenses
+ */
+type LicenseQuery struct {
+	Limit          int    `url:"limit,omitempty"`           // Specify the number of results you wish to return. Defaults to 50.
+	Offset         int    `url:"offset,omitempty"`          // Specify the number of results to skip before starting to return items. Defaults to 0.
+	Search         string `url:"search,omitempty"`          // Search for a license by name, product key or order number.
+	Sort           string `url:"sort,omitempty"`            // Sort the results by the specified column. Defaults to created_at.
+	Order          string `url:"order,omitempty"`           // Sort the results in the specified order. Defaults to desc.
+	CategoryID     int    `url:"category_id,omitempty"`     // Return only licenses associated with the specified category ID.
+	ManufacturerID int    `url:"manufacturer_id,omitempty"` // Return only licenses associated with the specified manufacturer ID.
+	SupplierID     int    `url:"supplier_id,omitempty"`     // Return only licenses associated with the specified supplier ID.
+}
+
+// ### LicenseQuery implements QueryInterface
+// ---------------------------------------------------------------------
+func (q *LicenseQuery) Copy() QueryInterface {
+	return &LicenseQuery{
+		Limit:          q.Limit,
+		Offset:         q.Offset,
+		Search:         q.Search,
+		Sort:           q.Sort,
+		Order:          q.Order,
+		CategoryID:     q.CategoryID,
+		ManufacturerID: q.ManufacturerID,
+		SupplierID:     q.SupplierID,
+	}
+}
+
+func (q *LicenseQuery) GetLimit() int {
+	return q.Limit
+}
+
+func (q *LicenseQuery) SetLimit(limit int) {
+	q.Limit = limit
+}
+
+func (q *LicenseQuery) GetOffset() int {
+	return q.Offset
+}
+
+func (q *LicenseQuery) SetOffset(offset int) {
+	q.Offset = offset
+}
+
+// END OF QUERYINTERFACE METHODS
+//---------------------------------------------------------------------
+
+/*
+ * # List all Licenses in Snipe-IT
+ * /api/v1/licenses
+ * - https://snipe-it.readme.io/reference/licenses
+ */
+func (c *LicenseClient) GetAllLicenses() (*LicenseList, error) {
+	url := c.BuildURL(Licenses)
+
+	q := LicenseQuery{
+		Limit: 500,
+	}
+
+	var cache LicenseList
+	if c.GetCache(url, &cache) {
+		return &cache, nil
+	}
+
+	licenses, err := doConcurrent[LicenseList](c.Client, "GET", url, &q, nil)
+	if err != nil {
+		c.Log.Warningf("Error fetching licenses: %v", err)
+		return nil, err
+	}
+
+	c.SetCache(url, licenses, 5*time.Minute)
+	return licenses, nil
+}
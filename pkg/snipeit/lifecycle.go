@@ -0,0 +1,271 @@
+/*
+# SnipeIT - Asset Lifecycle
+
+This package contains a configurable state machine of asset status labels (e.g. In Stock -> Deployed -> Returned -> Retired),
+enforced by the client on status changes so automations cannot make invalid jumps or skip the fields a step requires.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/snipeit/lifecycle.go
+package snipeit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Status labels of the DefaultLifecycle
+const (
+	StatusInStock  = "In Stock"
+	StatusDeployed = "Deployed"
+	StatusReturned = "Returned"
+	StatusRetired  = "Retired"
+	StatusLost     = "Lost"
+	StatusStolen   = "Stolen"
+)
+
+// Fields a Lifecycle can require of an asset entering a status
+const (
+	LifecycleFieldAssignedTo = "assigned_to" // The asset is checked out (to a user, asset or location)
+	LifecycleFieldNotes      = "notes"
+	LifecycleFieldLocation   = "location"
+	LifecycleFieldSerial     = "serial"
+	LifecycleFieldAssetTag   = "asset_tag"
+)
+
+var (
+	ErrInvalidTransition = errors.New("invalid status transition") // The lifecycle does not allow the status change
+	ErrMissingFields     = errors.New("missing required fields")   // The asset lacks fields required by the new status
+)
+
+/*
+ * Lifecycle is a state machine of status labels, matched case-insensitively by name
+ * A status change is allowed when the target is listed under the current status in Transitions, or is one of the
+ * Escapes, and the asset (with the requested changes) has every field Required by the target. Other statuses which are
+ * not part of the lifecycle can neither be left nor entered; an asset without a status can enter any status.
+ */
+type Lifecycle struct {
+	Transitions map[string][]string // Status label -> the status labels an asset may move to from it
+	Required    map[string][]string // Status label -> fields (LifecycleField*) an asset needs to enter it
+	Escapes     []string            // Status labels an asset may move to from any status, e.g. Lost or Stolen
+}
+
+/*
+ * DefaultLifecycle is In Stock -> Deployed -> Returned -> In Stock (redeployed) or Retired; unused stock may be retired directly
+ * Any asset may be reported Lost or Stolen (with notes), and goes back In Stock when recovered or is Retired otherwise
+ */
+func DefaultLifecycle() *Lifecycle {
+	return &Lifecycle{
+		Transitions: map[string][]string{
+			StatusInStock:  {StatusDeployed, StatusRetired},
+			StatusDeployed: {StatusReturned},
+			StatusReturned: {StatusInStock, StatusRetired},
+			StatusRetired:  {},
+			StatusLost:     {StatusInStock, StatusRetired},
+			StatusStolen:   {StatusInStock, StatusRetired},
+		},
+		Required: map[string][]string{
+			StatusInStock:  {LifecycleFieldSerial, LifecycleFieldAssetTag},
+			StatusDeployed: {LifecycleFieldAssignedTo},
+			StatusRetired:  {LifecycleFieldNotes},
+			StatusLost:     {LifecycleFieldNotes},
+			StatusStolen:   {LifecycleFieldNotes},
+		},
+		Escapes: []string{StatusLost, StatusStolen},
+	}
+}
+
+// TransitionError describes a status change rejected by a Lifecycle
+type TransitionError struct {
+	AssetID int
+	From    string
+	To      string
+	Missing []string // Required fields the asset lacks
+	err     error
+}
+
+func (e *TransitionError) Error() string {
+	if len(e.Missing) > 0 {
+		return fmt.Sprintf("asset %d cannot move from %q to %q: %v: %s", e.AssetID, e.From, e.To, e.err, strings.Join(e.Missing, ", "))
+	}
+	return fmt.Sprintf("asset %d cannot move from %q to %q: %v", e.AssetID, e.From, e.To, e.err)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return e.err
+}
+
+// Allowed reports whether the lifecycle lets an asset move between two statuses
+func (l *Lifecycle) Allowed(from, to string) bool {
+	if from == "" || strings.EqualFold(from, to) {
+		return l.known(to)
+	}
+	for _, escape := range l.Escapes {
+		if strings.EqualFold(escape, to) {
+			return true
+		}
+	}
+
+	for status, targets := range l.Transitions {
+		if !strings.EqualFold(status, from) {
+			continue
+		}
+		for _, target := range targets {
+			if strings.EqualFold(target, to) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+/*
+ * Validate checks a change of `asset` to the status `to`
+ * `changes` are the fields updated along with the status, and count towards the required fields; it may be nil
+ * Returns a *TransitionError wrapping ErrInvalidTransition or ErrMissingFields
+ */
+func (l *Lifecycle) Validate(asset *Hardware, to string, changes *Hardware) error {
+	from := ""
+	if asset.StatusLabel != nil {
+		from = asset.StatusLabel.Name
+	}
+
+	if !l.Allowed(from, to) {
+		return &TransitionError{AssetID: asset.ID, From: from, To: to, err: ErrInvalidTransition}
+	}
+	if strings.EqualFold(from, to) {
+		return nil
+	}
+
+	missing := []string{}
+	for status, fields := range l.Required {
+		if !strings.EqualFold(status, to) {
+			continue
+		}
+		for _, field := range fields {
+			if !hasField(asset, field) && (changes == nil || !hasField(changes, field)) {
+				missing = append(missing, field)
+			}
+		}
+	}
+	if len(missing) > 0 {
+		return &TransitionError{AssetID: asset.ID, From: from, To: to, Missing: missing, err: ErrMissingFields}
+	}
+
+	return nil
+}
+
+// known reports whether a status is part of the lifecycle
+func (l *Lifecycle) known(status string) bool {
+	for name := range l.Transitions {
+		if strings.EqualFold(name, status) {
+			return true
+		}
+	}
+	for _, escape := range l.Escapes {
+		if strings.EqualFold(escape, status) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasField reports whether a lifecycle field is set on an asset
+func hasField(asset *Hardware, field string) bool {
+	switch field {
+	case LifecycleFieldAssignedTo:
+		return asset.AssignedTo != nil
+	case LifecycleFieldNotes:
+		return strings.TrimSpace(asset.Notes) != ""
+	case LifecycleFieldLocation:
+		return asset.Location != nil
+	case LifecycleFieldSerial:
+		return strings.TrimSpace(asset.Serial) != ""
+	case LifecycleFieldAssetTag:
+		return strings.TrimSpace(asset.AssetTag) != ""
+	default:
+		return false
+	}
+}
+
+/*
+ * # Get an asset in Snipe-IT
+ * /api/v1/hardware/{id}
+ * - https://snipe-it.readme.io/reference/hardware-by-id
+ */
+func (c *AssetClient) GetAsset(id int) (*Hardware, error) {
+	url := c.BuildURL(Assets, id)
+
+	hardware, err := do[Hardware](c.Client, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hardware, nil
+}
+
+/*
+ * # Transition an Asset to another Status
+ * Validates the change against the client's Lifecycle (DefaultLifecycle when none is set), then updates the asset
+ * - `changes` are applied along with the status, e.g. the notes required to retire an asset; it may be nil
+ */
+func (c *AssetClient) TransitionAsset(id int, status string, changes *Hardware) (*Hardware, error) {
+	lifecycle := c.Lifecycle
+	if lifecycle == nil {
+		lifecycle = DefaultLifecycle()
+	}
+
+	label, err := c.StatusLabels().FindStatusLabel(status)
+	if err != nil {
+		return nil, err
+	}
+
+	asset, err := c.GetAsset(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := lifecycle.Validate(asset, label.Name, changes); err != nil {
+		return nil, err
+	}
+
+	update := &Hardware{}
+	if changes != nil {
+		*update = *changes
+	}
+	update.StatusID = label.ID
+
+	return c.updateAsset(id, update)
+}
+
+// checkLifecycle validates the status change of a partial update against the client's Lifecycle, if any
+func (c *AssetClient) checkLifecycle(id int, p *Hardware) error {
+	if c.Lifecycle == nil || p.StatusID == 0 {
+		return nil
+	}
+
+	labels, err := c.StatusLabels().GetAllStatusLabels()
+	if err != nil {
+		return err
+	}
+
+	to := ""
+	for _, label := range rowsOf(labels.Rows) {
+		if label.ID == p.StatusID {
+			to = label.Name
+		}
+	}
+	if to == "" {
+		return fmt.Errorf("no status label with ID %d", p.StatusID)
+	}
+
+	asset, err := c.GetAsset(id)
+	if err != nil {
+		return err
+	}
+
+	return c.Lifecycle.Validate(asset, to, p)
+}
@@ -0,0 +1,210 @@
+/*
+# SnipeIT - Requestable Assets
+
+This package initializes the methods for requestable assets, the pending requests users make for them (read from the
+activity report), and the checkout fulfilling a request:
+https://snipe-it.readme.io/reference/api-overview
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/snipeit/requestable.go
+package snipeit
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Activity report actions involved in asset requests
+const (
+	ActionRequested       = "requested"
+	ActionRequestCanceled = "request canceled"
+	ActionCheckout        = "checkout"
+)
+
+/*
+ * Query Parameters for the Activity Report
+ */
+type ActivityQuery struct {
+	Limit      int    `url:"limit,omitempty"`       // Specify the number of results you wish to return. Defaults to 50.
+	Offset     int    `url:"offset,omitempty"`      // Specify the number of results to skip before starting to return items. Defaults to 0.
+	Search     string `url:"search,omitempty"`      // Search the activity.
+	TargetType string `url:"target_type,omitempty"` // Type of the target, e.g. `user`.
+	TargetID   int64  `url:"target_id,omitempty"`   // ID of the target.
+	ItemType   string `url:"item_type,omitempty"`   // Type of the item, e.g. `asset`.
+	ItemID     int64  `url:"item_id,omitempty"`     // ID of the item.
+	ActionType string `url:"action_type,omitempty"` // Action, e.g. `checkout`, `requested`, `request canceled`.
+	Order      string `url:"order,omitempty"`       // Sort the results in the specified order. Defaults to desc.
+}
+
+// ### ActivityQuery implements QueryInterface
+// ---------------------------------------------------------------------
+func (q *ActivityQuery) Copy() QueryInterface {
+	return &ActivityQuery{
+		Limit:      q.Limit,
+		Offset:     q.Offset,
+		Search:     q.Search,
+		TargetType: q.TargetType,
+		TargetID:   q.TargetID,
+		ItemType:   q.ItemType,
+		ItemID:     q.ItemID,
+		ActionType: q.ActionType,
+		Order:      q.Order,
+	}
+}
+
+func (q *ActivityQuery) GetLimit() int {
+	return q.Limit
+}
+
+func (q *ActivityQuery) SetLimit(limit int) {
+	q.Limit = limit
+}
+
+func (q *ActivityQuery) GetOffset() int {
+	return q.Offset
+}
+
+func (q *ActivityQuery) SetOffset(offset int) {
+	q.Offset = offset
+}
+
+// END OF QUERYINTERFACE METHODS
+//---------------------------------------------------------------------
+
+/*
+ * # List the Requestable Assets in Snipe-IT
+ * /api/v1/hardware?status=Requestable
+ * - https://snipe-it.readme.io/reference/hardware-list
+ */
+func (c *AssetClient) GetRequestableAssets() (*HardwareList, error) {
+	url := c.BuildURL(Assets)
+
+	q := AssetQuery{
+		Limit:  500,
+		Offset: 0,
+		Status: "Requestable",
+	}
+
+	assets, err := doConcurrent[HardwareList](c.Client, "GET", url, &q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return assets, nil
+}
+
+/*
+ * # Check out an Asset to a User
+ * /api/v1/hardware/{id}/checkout
+ * - https://snipe-it.readme.io/reference/hardware-checkout
+ */
+func (c *AssetClient) CheckoutAsset(id int, userID int64, note string) error {
+	return c.checkout(fmt.Sprintf("%s/%d/checkout", Assets, id), &CheckoutRequest{CheckoutToType: "user", AssignedUser: userID, Note: note})
+}
+
+/*
+ * # Get a User in Snipe-IT
+ * /api/v1/users/{id}
+ * - https://snipe-it.readme.io/reference/usersid
+ */
+func (c *Client) GetUser(id int64) (*User, error) {
+	url := c.BuildURL(Users, id)
+
+	var cache User
+	if c.GetCache(url, &cache) {
+		return &cache, nil
+	}
+
+	user, err := do[User](c, "GET", url, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.SetCache(url, user, 5*time.Minute)
+	return &user, nil
+}
+
+/*
+ * # Get the Activity Report
+ * /api/v1/reports/activity
+ * - https://snipe-it.readme.io/reference/api-reports
+ */
+func (c *Client) GetActivity(q *ActivityQuery) (*ActivityList, error) {
+	url := c.BuildURL(Reports, "activity")
+
+	if q == nil {
+		q = &ActivityQuery{}
+	}
+	if q.Limit == 0 {
+		q.Limit = 500
+	}
+
+	return doConcurrent[ActivityList](c, "GET", url, q, nil)
+}
+
+/*
+ * # List the Pending Asset Requests
+ * Replays the `requested`, `request canceled` and `checkout` activity of assets, oldest first:
+ * - A request stays pending until the requester cancels it, or the asset is checked out to them
+ * - Requests are returned oldest first
+ */
+func (c *AssetClient) GetPendingRequests() ([]*AssetRequest, error) {
+	activity := []*Activity{}
+	for _, action := range []string{ActionRequested, ActionRequestCanceled, ActionCheckout} {
+		list, err := c.GetActivity(&ActivityQuery{ItemType: "asset", ActionType: action})
+		if err != nil {
+			return nil, fmt.Errorf("listing %q activity: %w", action, err)
+		}
+		activity = append(activity, rowsOf(list.Rows)...)
+	}
+
+	return pendingRequests(activity), nil
+}
+
+// pendingRequests replays request activity, returning the requests neither canceled nor fulfilled
+func pendingRequests(activity []*Activity) []*AssetRequest {
+	sort.SliceStable(activity, func(i, j int) bool {
+		return activity[i].CreatedAt.Time().Before(activity[j].CreatedAt.Time())
+	})
+
+	type key struct {
+		asset int64
+		user  int64
+	}
+	pending := map[key]*AssetRequest{}
+	for _, entry := range activity {
+		if entry.Item == nil || entry.Target == nil {
+			continue
+		}
+		k := key{asset: entry.Item.ID, user: entry.Target.ID}
+
+		switch entry.ActionType {
+		case ActionRequested:
+			pending[k] = &AssetRequest{
+				AssetID:     int(entry.Item.ID),
+				AssetName:   entry.Item.Name,
+				UserID:      entry.Target.ID,
+				UserName:    entry.Target.Name,
+				Note:        entry.Note,
+				RequestedAt: entry.CreatedAt.Time(),
+			}
+		case ActionRequestCanceled, ActionCheckout:
+			delete(pending, k)
+		}
+	}
+
+	requests := make([]*AssetRequest, 0, len(pending))
+	for _, request := range pending {
+		requests = append(requests, request)
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].RequestedAt.Before(requests[j].RequestedAt)
+	})
+
+	return requests
+}
@@ -13,6 +13,7 @@ https://developer.okta.com/docs/api/
 package snipeit
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -24,6 +25,7 @@ import (
 	"github.com/gemini-oss/rego/pkg/common/log"
 	"github.com/gemini-oss/rego/pkg/common/ratelimit"
 	"github.com/gemini-oss/rego/pkg/common/requests"
+	"github.com/gemini-oss/rego/pkg/common/schema"
 )
 
 var (
@@ -51,6 +53,7 @@ const (
 	Groups           = "%s/groups"               // https://snipe-it.readme.io/reference#groups
 	Settings         = "%s/settings"             // https://snipe-it.readme.io/reference#settings
 	Reports          = "%s/reports"              // https://snipe-it.readme.io/reference#reports
+	Kits             = "%s/kits"                 // https://snipe-it.readme.io/reference/kits
 )
 
 // BuildURL builds a URL for a given resource and identifiers.
@@ -63,6 +66,16 @@ func (c *Client) BuildURL(endpoint string, identifiers ...interface{}) string {
 	return url
 }
 
+/*
+ * WithContext returns a copy of the client whose requests end when ctx is done, e.g. to cancel a long pagination
+ */
+func (c *Client) WithContext(ctx context.Context) *Client {
+	clone := *c
+	clone.HTTP = c.HTTP.WithContext(ctx)
+	clone.Log = c.Log.WithContext(ctx)
+	return &clone
+}
+
 /*
  * SetCache stores a SnipeIT API response in the cache
  */
@@ -93,10 +106,11 @@ func (c *Client) GetCache(key string, target interface{}) bool {
 	return true
 }
 
-func NewClient(verbosity int) *Client {
+func NewClient(verbosity int, opts ...config.Option) *Client {
 	log := log.NewLogger("{snipeit}", verbosity)
+	profile := config.NewProfile(opts...)
 
-	url := config.GetEnv("SNIPEIT_URL")
+	url := profile.GetEnv("SNIPEIT_URL")
 	if len(url) == 0 {
 		log.Fatal("SNIPEIT_URL is not set.")
 	}
@@ -105,8 +119,8 @@ func NewClient(verbosity int) *Client {
 	url = strings.TrimPrefix(url, "http://")
 	url = strings.Trim(url, "./")
 
-	BaseURL = fmt.Sprintf(BaseURL, url)
-	token := config.GetEnv("SNIPEIT_TOKEN")
+	baseURL := fmt.Sprintf(BaseURL, url)
+	token := profile.GetEnv("SNIPEIT_TOKEN")
 	if len(token) == 0 {
 		log.Fatal("SNIPEIT_TOKEN is not set.")
 	}
@@ -118,24 +132,28 @@ func NewClient(verbosity int) *Client {
 	}
 
 	// https://snipe-it.readme.io/reference/api-throttling
-	rl := ratelimit.NewRateLimiter(120, 1*time.Minute)
+	limit := 120
+	if l := profile.GetEnvAsInt("SNIPEIT_RATE_LIMIT"); l > 0 {
+		limit = l
+	}
+	rl := ratelimit.NewRateLimiter(limit, 1*time.Minute)
 
 	httpClient := requests.NewClient(nil, headers, rl)
 	httpClient.BodyType = requests.JSON
 
 	// To Do: Look into `Functional Options` patterns for a better way to handle this
-	encryptionKey := []byte(config.GetEnv("REGO_ENCRYPTION_KEY"))
+	encryptionKey := []byte(profile.GetEnv("REGO_ENCRYPTION_KEY"))
 	if len(encryptionKey) == 0 {
 		log.Fatal("REGO_ENCRYPTION_KEY is not set")
 	}
 
-	cache, err := cache.NewCache(encryptionKey, "rego_cache_snipeit.gob", 1000000)
+	cache, err := cache.NewCache(encryptionKey, "rego_cache_snipeit.gob", 1000000, profile)
 	if err != nil {
 		panic(err)
 	}
 
 	return &Client{
-		BaseURL: BaseURL,
+		BaseURL: baseURL,
 		HTTP:    httpClient,
 		Log:     log,
 		Cache:   cache,
@@ -162,7 +180,7 @@ func do[T any](c *Client, method string, url string, query interface{}, data int
 	c.Log.Println("Response Status:", res.Status)
 	c.Log.Debug("Response Body:", string(body))
 
-	err = json.Unmarshal(body, &result)
+	err = schema.Unmarshal(body, &result)
 	if err != nil {
 		return *new(T), fmt.Errorf("unmarshalling error: %w", err)
 	}
@@ -219,3 +237,11 @@ func doConcurrent[T PaginatedResponse[E], E any](c *Client, method, url string,
 
 	return &results, nil
 }
+
+// rowsOf safely dereferences the rows of a paginated list
+func rowsOf[E any](rows *[]*E) []*E {
+	if rows == nil {
+		return nil
+	}
+	return *rows
+}
@@ -0,0 +1,117 @@
+/*
+# SnipeIT - Status Labels
+
+This package initializes all the methods for functions which interact with the SnipeIT Status Labels endpoints:
+https://snipe-it.readme.io/reference/status-labels
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/snipeit/statuslabels.go
+package snipeit
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// StatusLabelClient for chaining methods
+type StatusLabelClient struct {
+	*Client
+}
+
+// Entry point for status-label-related operations
+func (c *Client) StatusLabels() *StatusLabelClient {
+	return &StatusLabelClient{
+		Client: c,
+	}
+}
+
+/*
+ * Query Parameters for Status Labels
+ */
+type StatusLabelQuery struct {
+	Limit  int    `url:"limit,omitempty"`  // Specify the number of results you wish to return. Defaults to 50.
+	Offset int    `url:"offset,omitempty"` // Specify the number of results to skip before starting to return items. Defaults to 0.
+	Search string `url:"search,omitempty"` // Search for a status label by name.
+}
+
+// ### StatusLabelQuery implements QueryInterface
+// ---------------------------------------------------------------------
+func (q *StatusLabelQuery) Copy() QueryInterface {
+	return &StatusLabelQuery{
+		Limit:  q.Limit,
+		Offset: q.Offset,
+		Search: q.Search,
+	}
+}
+
+func (q *StatusLabelQuery) GetLimit() int {
+	return q.Limit
+}
+
+func (q *StatusLabelQuery) SetLimit(limit int) {
+	q.Limit = limit
+}
+
+func (q *StatusLabelQuery) GetOffset() int {
+	return q.Offset
+}
+
+func (q *StatusLabelQuery) SetOffset(offset int) {
+	q.Offset = offset
+}
+
+// END OF QUERYINTERFACE METHODS
+//---------------------------------------------------------------------
+
+/*
+ * # List all Status Labels in Snipe-IT
+ * /api/v1/statuslabels
+ * - https://snipe-it.readme.io/reference/status-labels
+ */
+func (c *StatusLabelClient) GetAllStatusLabels() (*StatusLabelList, error) {
+	url := c.BuildURL(StatusLabels)
+
+	q := StatusLabelQuery{
+		Limit: 50,
+	}
+
+	var cache StatusLabelList
+	if c.GetCache(url, &cache) {
+		return &cache, nil
+	}
+
+	labels, err := doConcurrent[StatusLabelList](c.Client, "GET", url, &q, nil)
+	if err != nil {
+		c.Log.Warningf("Error fetching status labels: %v", err)
+		return nil, err
+	}
+
+	c.SetCache(url, labels, 5*time.Minute)
+	return labels, nil
+}
+
+/*
+ * # Find a Status Label by Name
+ * Case-insensitive match on the name of the status label, e.g. `Stolen`
+ */
+func (c *StatusLabelClient) FindStatusLabel(name string) (*StatusLabel, error) {
+	labels, err := c.GetAllStatusLabels()
+	if err != nil {
+		return nil, err
+	}
+
+	if labels.Rows != nil {
+		for _, label := range *labels.Rows {
+			if strings.EqualFold(label.Name, name) {
+				return label, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no status label named %q", name)
+}
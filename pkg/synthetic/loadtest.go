@@ -0,0 +1,97 @@
+/*
+# Synthetic Data - Load Testing
+
+This package walks paginated endpoints (such as those of the mock server) and measures throughput and allocations,
+so changes to pagination and retry code can be compared against a baseline.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/synthetic/loadtest.go
+package synthetic
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/common/requests"
+)
+
+// LoadReport summarizes a walk over a paginated endpoint
+type LoadReport struct {
+	URL            string        `json:"url"`
+	Pages          int           `json:"pages"`
+	Items          int           `json:"items"`
+	Elapsed        time.Duration `json:"elapsed"`
+	PagesPerSecond float64       `json:"pages_per_second"`
+	Allocs         uint64        `json:"allocs"`      // Heap allocations made during the walk
+	AllocBytes     uint64        `json:"alloc_bytes"` // Bytes allocated on the heap during the walk
+}
+
+func (r *LoadReport) String() string {
+	return fmt.Sprintf("%s: %d pages, %d items in %s (%.1f pages/s, %d allocs, %d bytes)",
+		r.URL, r.Pages, r.Items, r.Elapsed.Round(time.Millisecond), r.PagesPerSecond, r.Allocs, r.AllocBytes)
+}
+
+/*
+ * # Walk Okta-Style Pages
+ * Follows `rel="next"` Link headers from the first page until the last one
+ */
+func WalkLinkPages(client *requests.Client, url string, limit int) (*LoadReport, error) {
+	return measure(url, func(report *LoadReport) error {
+		_, err := requests.PaginatedDo(client, "GET", url, nil, requests.Pagination[json.RawMessage]{
+			Strategy: requests.LinkPages{},
+			PageSize: limit,
+			OnPage:   report.count,
+		})
+		return err
+	})
+}
+
+/*
+ * # Walk Google-Style Pages
+ * Follows `nextPageToken` from the first page until the last one, counting the items under `field`
+ */
+func WalkTokenPages(client *requests.Client, url, field string, maxResults int) (*LoadReport, error) {
+	return measure(url, func(report *LoadReport) error {
+		_, err := requests.PaginatedDo(client, "GET", url, nil, requests.Pagination[json.RawMessage]{
+			Strategy: requests.TokenPages{},
+			Items:    requests.ItemsField[json.RawMessage](field),
+			PageSize: maxResults,
+			OnPage:   report.count,
+		})
+		return err
+	})
+}
+
+// count adds a page to the report
+func (r *LoadReport) count(page int, items []json.RawMessage) bool {
+	r.Pages = page
+	r.Items += len(items)
+	return true
+}
+
+// measure runs the walk and fills in its timing and allocation statistics
+func measure(url string, walk func(*LoadReport) error) (*LoadReport, error) {
+	report := &LoadReport{URL: url}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	err := walk(report)
+
+	report.Elapsed = time.Since(start)
+	runtime.ReadMemStats(&after)
+	report.Allocs = after.Mallocs - before.Mallocs
+	report.AllocBytes = after.TotalAlloc - before.TotalAlloc
+	if seconds := report.Elapsed.Seconds(); seconds > 0 {
+		report.PagesPerSecond = float64(report.Pages) / seconds
+	}
+
+	return report, err
+}
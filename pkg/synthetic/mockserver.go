@@ -0,0 +1,149 @@
+/*
+# Synthetic Data - Mock Server
+
+This package serves a synthetic Fleet over HTTP with the pagination styles of the real providers (Okta Link headers,
+Google page tokens) and optional injected rate limiting, so pagination and retry code can be exercised and
+benchmarked without a tenant.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/synthetic/mockserver.go
+package synthetic
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+)
+
+const (
+	MockOktaUsers   = "/api/v1/users"             // Okta style: `limit` and `after`, next page in the Link header
+	MockOktaLogs    = "/api/v1/logs"              // Okta style: `limit` and `after`, next page in the Link header
+	MockGoogleUsers = "/admin/directory/v1/users" // Google style: `maxResults` and `pageToken`, next page in the body
+)
+
+// MockServer serves a Fleet with provider-style pagination
+type MockServer struct {
+	Fleet         *Fleet
+	PageSize      int // Page size used when a request does not set one
+	ThrottleEvery int // Answer every Nth request with 429 Too Many Requests; 0 disables throttling
+
+	requests  atomic.Int64
+	throttled atomic.Int64
+}
+
+// NewMockServer returns a MockServer for the fleet with a default page size of 200
+func NewMockServer(f *Fleet) *MockServer {
+	return &MockServer{Fleet: f, PageSize: 200}
+}
+
+// Handlers returns the routes of the mock server, for server.StartServer
+func (m *MockServer) Handlers() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		MockOktaUsers: func(w http.ResponseWriter, r *http.Request) {
+			serveLinkPage(m, w, r, m.Fleet.OktaUsers)
+		},
+		MockOktaLogs: func(w http.ResponseWriter, r *http.Request) {
+			serveLinkPage(m, w, r, m.Fleet.OktaEvents)
+		},
+		MockGoogleUsers: func(w http.ResponseWriter, r *http.Request) {
+			serveTokenPage(m, w, r, "users", m.Fleet.GoogleUsers)
+		},
+	}
+}
+
+// Handler returns the mock server as a single http.Handler, for httptest.NewServer
+func (m *MockServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	for route, handler := range m.Handlers() {
+		mux.HandleFunc(route, handler)
+	}
+	return mux
+}
+
+// Stats returns the number of requests served and how many of them were throttled
+func (m *MockServer) Stats() (requests, throttled int64) {
+	return m.requests.Load(), m.throttled.Load()
+}
+
+// Reset clears the request counters
+func (m *MockServer) Reset() {
+	m.requests.Store(0)
+	m.throttled.Store(0)
+}
+
+// throttle answers the request with 429 when it is due to be throttled
+func (m *MockServer) throttle(w http.ResponseWriter) bool {
+	n := m.requests.Add(1)
+	if m.ThrottleEvery <= 0 || n%int64(m.ThrottleEvery) != 0 {
+		return false
+	}
+	m.throttled.Add(1)
+	w.Header().Set("Retry-After", "1")
+	w.WriteHeader(http.StatusTooManyRequests)
+	w.Write([]byte(`{"errorCode":"E0000047","errorSummary":"API call exceeded rate limit due to too many requests."}`))
+	return true
+}
+
+// window returns the page of items starting at the cursor, and the cursor of the next page (0 on the last page)
+func window[T any](items []T, cursor, size int) ([]T, int) {
+	if cursor < 0 || cursor > len(items) {
+		cursor = len(items)
+	}
+	end := cursor + size
+	if end >= len(items) {
+		return items[cursor:], 0
+	}
+	return items[cursor:end], end
+}
+
+func (m *MockServer) pageSize(r *http.Request, param string) int {
+	if size, err := strconv.Atoi(r.URL.Query().Get(param)); err == nil && size > 0 {
+		return size
+	}
+	return m.PageSize
+}
+
+func serveLinkPage[T any](m *MockServer, w http.ResponseWriter, r *http.Request, items []T) {
+	if m.throttle(w) {
+		return
+	}
+
+	size := m.pageSize(r, "limit")
+	cursor, _ := strconv.Atoi(r.URL.Query().Get("after"))
+	page, next := window(items, cursor, size)
+
+	self := fmt.Sprintf("<http://%s%s?limit=%d>; rel=\"self\"", r.Host, r.URL.Path, size)
+	w.Header().Add("Link", self)
+	if next > 0 {
+		w.Header().Add("Link", fmt.Sprintf("<http://%s%s?limit=%d&after=%d>; rel=\"next\"", r.Host, r.URL.Path, size, next))
+	}
+	writeJSON(w, page)
+}
+
+func serveTokenPage[T any](m *MockServer, w http.ResponseWriter, r *http.Request, field string, items []T) {
+	if m.throttle(w) {
+		return
+	}
+
+	cursor, _ := strconv.Atoi(r.URL.Query().Get("pageToken"))
+	page, next := window(items, cursor, m.pageSize(r, "maxResults"))
+
+	body := map[string]interface{}{field: page}
+	if next > 0 {
+		body["nextPageToken"] = strconv.Itoa(next)
+	}
+	writeJSON(w, body)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,267 @@
+/*
+# Synthetic Data - Providers
+
+This package converts synthetic people into the records each provider's API returns
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/synthetic/providers.go
+package synthetic
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/google"
+	"github.com/gemini-oss/rego/pkg/jamf"
+	"github.com/gemini-oss/rego/pkg/okta"
+	"github.com/gemini-oss/rego/pkg/snipeit"
+)
+
+// GoogleUsers returns the Google Workspace accounts of the people
+func (g *Generator) GoogleUsers(people []*Person) []*google.User {
+	users := make([]*google.User, 0, len(people))
+	for _, p := range people {
+		user := &google.User{
+			ID:            "1" + p.ID + "0000000000000",
+			PrimaryEmail:  p.Email,
+			Name:          google.UserName{GivenName: p.FirstName, FamilyName: p.LastName, FullName: p.FirstName + " " + p.LastName},
+			OrgUnitPath:   "/" + p.Department,
+			Suspended:     p.Suspended,
+			CreationTime:  p.Created.Format(time.RFC3339),
+			LastLoginTime: p.LastLogin.Format(time.RFC3339),
+			IsAdmin:       p.Department == "IT" && p.Manager == "",
+		}
+		if p.Manager != "" {
+			user.Relations = []google.Relation{{Type: "manager", Value: p.Manager}}
+		}
+		users = append(users, user)
+	}
+	return users
+}
+
+// OktaUsers returns the Okta accounts of the people
+func (g *Generator) OktaUsers(people []*Person) okta.Users {
+	users := make(okta.Users, 0, len(people))
+	for _, p := range people {
+		status := "ACTIVE"
+		if p.Suspended {
+			status = "SUSPENDED"
+		}
+		user := &okta.User{
+			ID:          oktaID("00u", p.ID),
+			Status:      status,
+			Created:     p.Created,
+			Activated:   p.Created.Add(time.Hour),
+			LastLogin:   p.LastLogin,
+			LastUpdated: p.LastLogin,
+			Profile: &okta.UserProfile{
+				UserProfileBase: okta.UserProfileBase{
+					Email:          p.Email,
+					Login:          p.Email,
+					FirstName:      p.FirstName,
+					LastName:       p.LastName,
+					DisplayName:    p.FirstName + " " + p.LastName,
+					Department:     p.Department,
+					Manager:        p.Manager,
+					EmployeeNumber: p.ID,
+				},
+			},
+		}
+		users = append(users, user)
+	}
+	return users
+}
+
+// JamfComputers returns the computer inventory of the people, as listed by /api/v1/computers-inventory
+func (g *Generator) JamfComputers(people []*Person) *jamf.Computers {
+	computers := make([]*jamf.Computer, 0, len(people))
+	for i, p := range people {
+		lastContact := g.between(p.LastLogin.Add(-24*time.Hour), g.now)
+		computer := &jamf.Computer{
+			ID: strconv.Itoa(i + 1),
+			General: &jamf.General{
+				Name:            fmt.Sprintf("%s-%s-MBP", p.FirstName, p.LastName),
+				ManagementID:    fmt.Sprintf("%08x-0000-4000-8000-%012x", i+1, i+1),
+				Platform:        "Mac",
+				LastContactTime: lastContact.Format(time.RFC3339),
+				ReportDate:      lastContact.Format(time.RFC3339),
+			},
+			Hardware: &jamf.Hardware{
+				Make:            "Apple",
+				Model:           p.Model,
+				ModelIdentifier: p.ModelID,
+				SerialNumber:    p.Serial,
+			},
+			OperatingSystem: &jamf.OperatingSystem{
+				Name:             "macOS",
+				Version:          p.OSVersion,
+				FileVault2Status: pick(g, []string{"ALL_ENCRYPTED", "ALL_ENCRYPTED", "ALL_ENCRYPTED", "NOT_ENCRYPTED"}),
+			},
+			UserAndLocation: &jamf.UserAndLocation{
+				Username: p.Email[:len(p.Email)-len(g.domain)-1],
+				Realname: p.FirstName + " " + p.LastName,
+				Email:    p.Email,
+				Position: p.Title,
+			},
+		}
+		computers = append(computers, computer)
+	}
+	return &jamf.Computers{Results: &computers, TotalCount: len(computers)}
+}
+
+// SnipeITAssets returns the asset records of the people's computers
+func (g *Generator) SnipeITAssets(people []*Person) []*snipeit.Hardware {
+	assets := make([]*snipeit.Hardware, 0, len(people))
+	for i, p := range people {
+		status := &snipeit.StatusLabel{ID: 2, Name: "Deployed", StatusMeta: "deployed", StatusType: "deployable"}
+		if p.Suspended {
+			status = &snipeit.StatusLabel{ID: 4, Name: "Pending Return", StatusMeta: "pending", StatusType: "pending"}
+		}
+		asset := &snipeit.Hardware{
+			ID:              i + 1,
+			Name:            fmt.Sprintf("%s-%s-MBP", p.FirstName, p.LastName),
+			AssetTag:        fmt.Sprintf("ASSET-%05d", i+1),
+			Serial:          p.Serial,
+			Model:           &snipeit.Record{ID: 1, Name: p.Model},
+			Manufacturer:    &snipeit.Record{ID: 1, Name: "Apple"},
+			Category:        &snipeit.Record{ID: 1, Name: "Laptops"},
+			StatusLabel:     status,
+			AssignedTo:      &snipeit.User{ID: int64(i + 1), Email: p.Email, Name: p.FirstName + " " + p.LastName, Username: p.Email},
+			PurchaseDate:    &snipeit.DateInfo{Day: p.PurchasedAt.Format(time.DateOnly)},
+			WarrantyExpires: &snipeit.DateInfo{Day: p.PurchasedAt.AddDate(3, 0, 0).Format(time.DateOnly)},
+			PurchaseCost:    pick(g, []string{"1999.00", "2499.00", "1299.00", "3499.00"}),
+		}
+		assets = append(assets, asset)
+	}
+	return assets
+}
+
+// oktaEventTypes and their outcome: a few failures among mostly successful events
+var oktaEventTypes = []struct {
+	eventType string
+	message   string
+	failRate  int // Percentage of events failing
+}{
+	{"user.session.start", "User login to Okta", 10},
+	{"user.authentication.auth_via_mfa", "Authentication of user via MFA", 5},
+	{"user.authentication.sso", "User single sign on to app", 1},
+	{"user.account.update_password", "User update password for Okta", 2},
+	{"user.session.end", "User logout from Okta", 0},
+	{"group.user_membership.add", "Add user to group membership", 0},
+	{"user.lifecycle.suspend", "Suspend Okta user", 0},
+}
+
+// OktaEvents returns `n` System Log events of the people, oldest first, over the last 7 days
+func (g *Generator) OktaEvents(people []*Person, n int) okta.LogEvents {
+	events := make(okta.LogEvents, 0, n)
+	for i := 0; i < n && len(people) > 0; i++ {
+		p := pick(g, people)
+		kind := pick(g, oktaEventTypes)
+
+		result, reason := "SUCCESS", ""
+		if g.rand.Intn(100) < kind.failRate {
+			result, reason = "FAILURE", "INVALID_CREDENTIALS"
+		}
+		severity := "INFO"
+		if result == "FAILURE" {
+			severity = "WARN"
+		}
+
+		events = append(events, &okta.LogEvent{
+			UUID:           fmt.Sprintf("%08x-%04x-4%03x-8000-%012x", g.rand.Uint32(), g.rand.Intn(0x10000), g.rand.Intn(0x1000), i),
+			Published:      g.past(7 * 24 * time.Hour),
+			EventType:      kind.eventType,
+			Version:        "0",
+			Severity:       severity,
+			DisplayMessage: kind.message,
+			Actor:          &okta.LogActor{ID: oktaID("00u", p.ID), Type: "User", AlternateID: p.Email, DisplayName: p.FirstName + " " + p.LastName},
+			Client: &okta.LogClient{
+				IPAddress: p.IPAddress,
+				Device:    "Computer",
+				Zone:      "null",
+				GeographicalContext: map[string]interface{}{
+					"city":    "New York",
+					"state":   "New York",
+					"country": "United States",
+				},
+			},
+			Outcome:     &okta.LogOutcome{Result: result, Reason: reason},
+			Transaction: &okta.LogTransaction{ID: fmt.Sprintf("W%015d", g.rand.Int63n(1e15)), Type: "WEB"},
+		})
+	}
+
+	sort.Slice(events, func(i, j int) bool { return events[i].Published.Before(events[j].Published) })
+	return events
+}
+
+// googleEvents by application, with the parameters they carry
+var googleEvents = []struct {
+	application string
+	eventType   string
+	name        string
+	target      bool // The event targets another user (USER_EMAIL)
+}{
+	{"login", "login", "login_success", false},
+	{"login", "login", "login_failure", false},
+	{"admin", "USER_SETTINGS", "CHANGE_PASSWORD", true},
+	{"admin", "USER_SETTINGS", "SUSPEND_USER", true},
+	{"admin", "GROUP_SETTINGS", "ADD_GROUP_MEMBER", true},
+	{"admin", "USER_SETTINGS", "CHANGE_USER_ORGANIZATION", true},
+}
+
+// GoogleActivities returns `n` Reports API activities, newest first (as the API returns them), over the last 7 days
+// Admin events are performed by the IT managers on the other people
+func (g *Generator) GoogleActivities(people []*Person, n int) []google.Report {
+	admins := []*Person{}
+	for _, p := range people {
+		if p.Department == "IT" {
+			admins = append(admins, p)
+		}
+	}
+	if len(admins) == 0 {
+		admins = people
+	}
+
+	activities := make([]google.Report, 0, n)
+	for i := 0; i < n && len(people) > 0; i++ {
+		kind := pick(g, googleEvents)
+		actor, target := pick(g, people), pick(g, people)
+		if kind.target {
+			actor = pick(g, admins)
+		}
+
+		event := google.Event{Type: kind.eventType, Name: kind.name}
+		if kind.target {
+			event.Parameters = []google.ReportParameter{{Name: "USER_EMAIL", Value: target.Email}}
+		}
+
+		activities = append(activities, google.Report{
+			Kind: "admin#reports#activity",
+			ID: google.ActivityID{
+				Time:            g.past(7 * 24 * time.Hour).Format(time.RFC3339),
+				UniqueQualifier: strconv.FormatInt(g.rand.Int63(), 10),
+				ApplicationName: kind.application,
+				CustomerID:      "C00000000",
+			},
+			Actor:       google.Actor{Email: actor.Email, ProfileID: "1" + actor.ID + "0000000000000", CallerType: "USER"},
+			IPAddress:   actor.IPAddress,
+			OwnerDomain: g.domain,
+			Events:      []google.Event{event},
+		})
+	}
+
+	sort.Slice(activities, func(i, j int) bool { return activities[i].ID.Time > activities[j].ID.Time })
+	return activities
+}
+
+// oktaID derives a 20 character Okta style ID
+func oktaID(prefix, id string) string {
+	return prefix + "syn" + strings.Repeat("0", 14-len(id)) + id
+}
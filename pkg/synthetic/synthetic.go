@@ -0,0 +1,238 @@
+/*
+# Synthetic Data
+
+This package generates realistic, fake users, devices, assets and audit events for every provider, consistent across
+providers (the same people own the same devices everywhere), for demos and load testing of report code without
+touching production tenants.
+
+:Copyright: (c) 2024 by Gemini Space Station, LLC., see AUTHORS for more info
+:License: See the LICENSE file for details
+:Author: Anthony Dardano <anthony.dardano@gemini.com>
+*/
+
+// pkg/synthetic/synthetic.go
+package synthetic
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gemini-oss/rego/pkg/google"
+	"github.com/gemini-oss/rego/pkg/jamf"
+	"github.com/gemini-oss/rego/pkg/okta"
+	"github.com/gemini-oss/rego/pkg/snipeit"
+)
+
+var (
+	firstNames = []string{"Ada", "Alan", "Barbara", "Claude", "Dennis", "Donald", "Edsger", "Frances", "Grace", "Hedy", "Ivan", "Jean", "John", "Katherine", "Ken", "Leslie", "Linus", "Margaret", "Niklaus", "Radia", "Rob", "Shafi", "Sophie", "Tim", "Whitfield"}
+	lastNames  = []string{"Allen", "Backus", "Cerf", "Dijkstra", "Diffie", "Engelbart", "Goldwasser", "Hamilton", "Hopper", "Johnson", "Kernighan", "Knuth", "Lamport", "Liskov", "Lovelace", "McCarthy", "Perlman", "Pike", "Ritchie", "Shannon", "Sutherland", "Thompson", "Torvalds", "Turing", "Wirth"}
+)
+
+// departments and the titles of their members; the first title is the department's manager
+var departments = []struct {
+	name   string
+	titles []string
+}{
+	{"Engineering", []string{"Engineering Manager", "Software Engineer", "Senior Software Engineer", "Staff Engineer", "Site Reliability Engineer"}},
+	{"Security", []string{"Security Manager", "Security Engineer", "Detection Engineer"}},
+	{"IT", []string{"IT Manager", "IT Support Specialist", "Systems Engineer"}},
+	{"Finance", []string{"Finance Manager", "Accountant", "Financial Analyst"}},
+	{"Sales", []string{"Sales Director", "Account Executive", "Sales Engineer"}},
+	{"People", []string{"People Manager", "Recruiter", "People Partner"}},
+}
+
+var macModels = []struct {
+	model      string
+	identifier string
+}{
+	{"MacBook Pro (14-inch, 2023)", "Mac14,9"},
+	{"MacBook Pro (16-inch, 2023)", "Mac14,10"},
+	{"MacBook Air (M2, 2022)", "Mac14,2"},
+	{"MacBook Air (15-inch, M3, 2024)", "Mac15,13"},
+}
+
+// Person is a synthetic employee; every provider record of the person derives from it
+type Person struct {
+	ID          string    // Stable identifier, used to derive the provider IDs
+	FirstName   string    //
+	LastName    string    //
+	Email       string    // Primary email, identical in every provider
+	Department  string    //
+	Title       string    //
+	Manager     string    // Email of the manager; empty for department managers
+	Created     time.Time // Account creation
+	LastLogin   time.Time // Last sign in
+	Suspended   bool      // Suspended/deprovisioned account
+	Serial      string    // Serial number of the person's computer
+	Model       string    // Model of the computer
+	ModelID     string    // Model identifier of the computer
+	OSVersion   string    // macOS version of the computer
+	IPAddress   string    // Usual IP address (documentation ranges only)
+	PurchasedAt time.Time // Purchase date of the computer
+}
+
+/*
+ * Generator produces synthetic data. The same seed always produces the same data
+ *
+ *	g := synthetic.New(42, "example.com")
+ *	fleet := g.Fleet(250, 5000)
+ *	fleet.WriteJSON("testdata/demo")
+ */
+type Generator struct {
+	rand   *rand.Rand
+	domain string
+	now    time.Time
+}
+
+// New returns a Generator for the given seed and email domain (default: example.com)
+func New(seed int64, domain string) *Generator {
+	if domain == "" {
+		domain = "example.com"
+	}
+	return &Generator{
+		rand:   rand.New(rand.NewSource(seed)),
+		domain: domain,
+		now:    time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+// Now sets the reference time the generated dates are relative to. Default: 2024-06-01 12:00 UTC
+func (g *Generator) Now(now time.Time) *Generator {
+	g.now = now.UTC()
+	return g
+}
+
+// People generates `n` people spread across departments, the first member of each department managing the others
+func (g *Generator) People(n int) []*Person {
+	people := make([]*Person, 0, n)
+	managers := map[string]string{}
+	emails := map[string]int{}
+
+	for i := 0; i < n; i++ {
+		first := firstNames[g.rand.Intn(len(firstNames))]
+		last := lastNames[g.rand.Intn(len(lastNames))]
+
+		local := strings.ToLower(first + "." + last)
+		emails[local]++
+		if emails[local] > 1 {
+			local = fmt.Sprintf("%s%d", local, emails[local])
+		}
+
+		dept := departments[i%len(departments)]
+		p := &Person{
+			ID:         fmt.Sprintf("%06d", i+1),
+			FirstName:  first,
+			LastName:   last,
+			Email:      local + "@" + g.domain,
+			Department: dept.name,
+			Created:    g.past(3 * 365 * 24 * time.Hour),
+			Suspended:  g.rand.Intn(100) < 5,
+			Serial:     g.serial(),
+			IPAddress:  fmt.Sprintf("203.0.113.%d", 1+g.rand.Intn(254)),
+		}
+		p.LastLogin = g.between(p.Created, g.now)
+
+		if manager, ok := managers[dept.name]; ok {
+			p.Title = dept.titles[1+g.rand.Intn(len(dept.titles)-1)]
+			p.Manager = manager
+		} else {
+			p.Title = dept.titles[0]
+			managers[dept.name] = p.Email
+		}
+
+		model := macModels[g.rand.Intn(len(macModels))]
+		p.Model, p.ModelID = model.model, model.identifier
+		p.OSVersion = fmt.Sprintf("14.%d.%d", g.rand.Intn(6), g.rand.Intn(2))
+		p.PurchasedAt = g.between(p.Created.AddDate(0, -1, 0), p.Created)
+
+		people = append(people, p)
+	}
+
+	return people
+}
+
+// Fleet is a consistent data set across every provider
+type Fleet struct {
+	People           []*Person
+	GoogleUsers      []*google.User
+	OktaUsers        okta.Users
+	JamfComputers    *jamf.Computers
+	SnipeITAssets    []*snipeit.Hardware
+	OktaEvents       okta.LogEvents
+	GoogleActivities []google.Report
+}
+
+// Fleet generates `users` people with their accounts, computers and assets, and `events` audit events split between Okta and Google
+func (g *Generator) Fleet(users, events int) *Fleet {
+	people := g.People(users)
+	return &Fleet{
+		People:           people,
+		GoogleUsers:      g.GoogleUsers(people),
+		OktaUsers:        g.OktaUsers(people),
+		JamfComputers:    g.JamfComputers(people),
+		SnipeITAssets:    g.SnipeITAssets(people),
+		OktaEvents:       g.OktaEvents(people, events-events/2),
+		GoogleActivities: g.GoogleActivities(people, events/2),
+	}
+}
+
+// WriteJSON writes each data set of the fleet to `dir` (e.g. google_users.json), as the providers' APIs would return it
+func (f *Fleet) WriteJSON(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	files := map[string]interface{}{
+		"people.json":            f.People,
+		"google_users.json":      f.GoogleUsers,
+		"okta_users.json":        f.OktaUsers,
+		"jamf_computers.json":    f.JamfComputers,
+		"snipeit_assets.json":    f.SnipeITAssets,
+		"okta_log_events.json":   f.OktaEvents,
+		"google_activities.json": f.GoogleActivities,
+	}
+	for name, data := range files {
+		content, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling %s: %w", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// past returns a time within `d` before now
+func (g *Generator) past(d time.Duration) time.Time {
+	return g.now.Add(-time.Duration(g.rand.Int63n(int64(d)))).Truncate(time.Second)
+}
+
+// between returns a time between `from` and `to`
+func (g *Generator) between(from, to time.Time) time.Time {
+	if !to.After(from) {
+		return from
+	}
+	return from.Add(time.Duration(g.rand.Int63n(int64(to.Sub(from))))).Truncate(time.Second)
+}
+
+// serial returns an Apple style serial number
+func (g *Generator) serial() string {
+	const chars = "ABCDEFGHJKLMNPQRSTUVWXYZ0123456789"
+	b := make([]byte, 7)
+	for i := range b {
+		b[i] = chars[g.rand.Intn(len(chars))]
+	}
+	return "C02" + string(b)
+}
+
+// pick returns a random element
+func pick[T any](g *Generator, items []T) T {
+	return items[g.rand.Intn(len(items))]
+}